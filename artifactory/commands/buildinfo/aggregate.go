@@ -0,0 +1,157 @@
+package buildinfo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A published build, referenced by an aggregated build.
+type BuildReference struct {
+	Name   string
+	Number string
+}
+
+// Parse a 'name/number' build reference. If the number is omitted, the latest build is referenced.
+func ParseBuildReference(reference string) (BuildReference, error) {
+	name, number := reference, servicesutils.LatestBuildNumberKey
+	if i := strings.LastIndex(reference, "/"); i >= 0 {
+		name, number = reference[:i], reference[i+1:]
+	}
+	if name == "" || number == "" {
+		return BuildReference{}, errorutils.CheckErrorf("invalid build reference '%s'. The expected format is 'name/number'", reference)
+	}
+	return BuildReference{Name: name, Number: number}, nil
+}
+
+// Aggregates several published builds into a single build-info, which references each of them as a module.
+// Promotion and distribution of the aggregated build then operate on the artifacts of all the referenced builds.
+type BuildAggregateCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	references         []BuildReference
+	dryRun             bool
+}
+
+func NewBuildAggregateCommand() *BuildAggregateCommand {
+	return &BuildAggregateCommand{}
+}
+
+func (bac *BuildAggregateCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildAggregateCommand {
+	bac.serverDetails = serverDetails
+	return bac
+}
+
+// Set the name and number of the aggregated build. The referenced builds must be of the same project.
+func (bac *BuildAggregateCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildAggregateCommand {
+	bac.buildConfiguration = buildConfiguration
+	return bac
+}
+
+func (bac *BuildAggregateCommand) SetReferences(references []BuildReference) *BuildAggregateCommand {
+	bac.references = references
+	return bac
+}
+
+func (bac *BuildAggregateCommand) SetDryRun(dryRun bool) *BuildAggregateCommand {
+	bac.dryRun = dryRun
+	return bac
+}
+
+func (bac *BuildAggregateCommand) CommandName() string {
+	return "rt_build_aggregate"
+}
+
+func (bac *BuildAggregateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bac.serverDetails, nil
+}
+
+func (bac *BuildAggregateCommand) Run() error {
+	if len(bac.references) == 0 {
+		return errorutils.CheckErrorf("no builds to aggregate were provided")
+	}
+	if err := bac.buildConfiguration.ValidateBuildParams(); err != nil {
+		return err
+	}
+	buildName, err := bac.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bac.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(bac.serverDetails, -1, 0, bac.dryRun)
+	if err != nil {
+		return err
+	}
+	aggregatedBuildInfo := buildinfo.New()
+	aggregatedBuildInfo.Name, aggregatedBuildInfo.Number = buildName, buildNumber
+	aggregatedBuildInfo.Started = time.Now().Format(buildinfo.TimeFormat)
+	aggregatedBuildInfo.SetAgentName(coreutils.GetCliUserAgentName())
+	aggregatedBuildInfo.SetAgentVersion(coreutils.GetCliUserAgentVersion())
+	aggregatedBuildInfo.SetBuildAgentVersion(coreutils.GetClientAgentVersion())
+	aggregatedBuildInfo.Principal = bac.serverDetails.User
+	for _, reference := range bac.references {
+		module, err := bac.createBuildModule(servicesManager, reference)
+		if err != nil {
+			return err
+		}
+		if containsModule(aggregatedBuildInfo.Modules, module.Id) {
+			return errorutils.CheckErrorf("the build '%s' is referenced more than once", module.Id)
+		}
+		aggregatedBuildInfo.Modules = append(aggregatedBuildInfo.Modules, module)
+	}
+	if _, err = servicesManager.PublishBuildInfo(aggregatedBuildInfo, bac.buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+	if !bac.dryRun {
+		log.Info(fmt.Sprintf("Build %s/%s successfully published, aggregating %d builds.", buildName, buildNumber, len(aggregatedBuildInfo.Modules)))
+	}
+	return nil
+}
+
+// Create a module referencing the published build, with the checksums of its build-info JSON.
+// The build number is resolved, so that referencing the latest build references the build which is currently the latest.
+func (bac *BuildAggregateCommand) createBuildModule(servicesManager artifactory.ArtifactoryServicesManager, reference BuildReference) (buildinfo.Module, error) {
+	project := bac.buildConfiguration.GetProject()
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: reference.Name, BuildNumber: reference.Number, ProjectKey: project})
+	if err != nil {
+		return buildinfo.Module{}, err
+	}
+	if !found {
+		return buildinfo.Module{}, errorutils.CheckErrorf("build %s/%s was not found in Artifactory", reference.Name, reference.Number)
+	}
+	buildTime, err := time.Parse(buildinfo.TimeFormat, publishedBuildInfo.BuildInfo.Started)
+	if errorutils.CheckError(err) != nil {
+		return buildinfo.Module{}, err
+	}
+	resolvedNumber := publishedBuildInfo.BuildInfo.Number
+	appendCommand := NewBuildAppendCommand().SetBuildConfiguration(bac.buildConfiguration).SetBuildNameToAppend(reference.Name).SetBuildNumberToAppend(resolvedNumber)
+	checksum, err := appendCommand.getChecksumDetails(servicesManager, buildTime.UnixMilli())
+	if err != nil {
+		return buildinfo.Module{}, err
+	}
+	log.Debug("Aggregating build", reference.Name+"/"+resolvedNumber)
+	return buildinfo.Module{Type: buildinfo.Build, Id: reference.Name + "/" + resolvedNumber, Checksum: checksum}, nil
+}
+
+func containsModule(modules []buildinfo.Module, moduleId string) bool {
+	for _, module := range modules {
+		if module.Id == moduleId {
+			return true
+		}
+	}
+	return false
+}
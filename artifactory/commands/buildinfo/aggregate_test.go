@@ -0,0 +1,44 @@
+package buildinfo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBuildReference(t *testing.T) {
+	tests := []struct {
+		reference     string
+		expected      BuildReference
+		expectedError bool
+	}{
+		{"my-build/1", BuildReference{Name: "my-build", Number: "1"}, false},
+		{"my/build/2", BuildReference{Name: "my/build", Number: "2"}, false},
+		{"my-build", BuildReference{Name: "my-build", Number: "LATEST"}, false},
+		{"/1", BuildReference{}, true},
+		{"my-build/", BuildReference{}, true},
+		{"", BuildReference{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			reference, err := ParseBuildReference(test.reference)
+			if test.expectedError {
+				assert.ErrorContains(t, err, "invalid build reference")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, reference)
+		})
+	}
+}
+
+func TestBuildAggregateCommandNoReferences(t *testing.T) {
+	assert.EqualError(t, NewBuildAggregateCommand().Run(), "no builds to aggregate were provided")
+}
+
+func TestContainsModule(t *testing.T) {
+	modules := []buildinfo.Module{{Id: "a/1"}, {Id: "b/2"}}
+	assert.True(t, containsModule(modules, "b/2"))
+	assert.False(t, containsModule(modules, "b/3"))
+}
@@ -0,0 +1,187 @@
+package buildinfo
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+// The names of the CycloneDX properties holding the build-info details which have no CycloneDX equivalent.
+const (
+	cdxPropertyModuleType = "jfrog:module:type"
+	cdxPropertyScopes     = "jfrog:dependency:scopes"
+)
+
+// Convert the build-info into a CycloneDX BOM.
+// The build is the main component of the BOM, and depends on its modules.
+// The modules and their dependencies are the components of the BOM, and the dependencies between them are taken from the 'requestedBy' paths.
+func ConvertBuildInfoToCycloneDx(buildInfo *buildinfo.BuildInfo) *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.SerialNumber = "urn:uuid:" + uuid.New().String()
+	buildRef := buildInfo.Name + "/" + buildInfo.Number
+	bom.Metadata = &cdx.Metadata{
+		Timestamp: convertBuildInfoTime(buildInfo.Started),
+		Tools: &cdx.ToolsChoice{Components: &[]cdx.Component{
+			{Type: cdx.ComponentTypeApplication, Name: coreutils.GetCliUserAgentName(), Version: coreutils.GetCliUserAgentVersion()},
+		}},
+		Component: &cdx.Component{BOMRef: buildRef, Type: cdx.ComponentTypeApplication, Name: buildInfo.Name, Version: buildInfo.Number},
+	}
+
+	graph := newCdxGraph()
+	graph.addDependency(buildRef, "")
+	// The modules are added first, so that modules which are also dependencies of other modules are converted as modules.
+	for _, module := range buildInfo.Modules {
+		component := createCdxComponent(module.Id, module.Type, module.Checksum)
+		if module.Type == buildinfo.Docker {
+			component.Type = cdx.ComponentTypeContainer
+		}
+		component.Properties = &[]cdx.Property{{Name: cdxPropertyModuleType, Value: string(module.Type)}}
+		graph.addComponent(component)
+		graph.addDependency(buildRef, module.Id)
+	}
+	for _, module := range buildInfo.Modules {
+		graph.addDependency(module.Id, "")
+		for _, dependency := range module.Dependencies {
+			component := createCdxComponent(dependency.Id, module.Type, dependency.Checksum)
+			if len(dependency.Scopes) > 0 {
+				component.Properties = &[]cdx.Property{{Name: cdxPropertyScopes, Value: strings.Join(dependency.Scopes, ",")}}
+			}
+			graph.addComponent(component)
+			graph.addDependency(dependency.Id, "")
+			if len(dependency.RequestedBy) == 0 {
+				graph.addDependency(module.Id, dependency.Id)
+			}
+			// Each 'requestedBy' path starts with the direct parent of the dependency, and ends with the module.
+			for _, path := range dependency.RequestedBy {
+				if len(path) > 0 {
+					graph.addDependency(path[0], dependency.Id)
+				}
+			}
+		}
+	}
+	bom.Components = &graph.components
+	bom.Dependencies = graph.toCdxDependencies()
+	return bom
+}
+
+// Collects the components of the BOM and the dependencies between them, in the order they were added.
+type cdxGraph struct {
+	components    []cdx.Component
+	componentRefs map[string]bool
+	dependsOn     map[string][]string
+	refs          []string
+}
+
+func newCdxGraph() *cdxGraph {
+	return &cdxGraph{componentRefs: make(map[string]bool), dependsOn: make(map[string][]string)}
+}
+
+// Add the component, unless a component with the same ref was already added.
+func (g *cdxGraph) addComponent(component cdx.Component) {
+	if g.componentRefs[component.BOMRef] {
+		return
+	}
+	g.componentRefs[component.BOMRef] = true
+	g.components = append(g.components, component)
+}
+
+// Add dependsOnRef to the dependencies of ref. An empty dependsOnRef only adds ref to the graph.
+func (g *cdxGraph) addDependency(ref, dependsOnRef string) {
+	if _, exists := g.dependsOn[ref]; !exists {
+		g.refs = append(g.refs, ref)
+		g.dependsOn[ref] = nil
+	}
+	if dependsOnRef != "" && dependsOnRef != ref && !slices.Contains(g.dependsOn[ref], dependsOnRef) {
+		g.dependsOn[ref] = append(g.dependsOn[ref], dependsOnRef)
+	}
+}
+
+func (g *cdxGraph) toCdxDependencies() *[]cdx.Dependency {
+	dependencies := make([]cdx.Dependency, 0, len(g.refs))
+	for _, ref := range g.refs {
+		dependency := cdx.Dependency{Ref: ref}
+		if dependsOn := g.dependsOn[ref]; len(dependsOn) > 0 {
+			dependency.Dependencies = &dependsOn
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return &dependencies
+}
+
+func createCdxComponent(id string, moduleType buildinfo.ModuleType, checksum buildinfo.Checksum) cdx.Component {
+	group, name, version := splitComponentId(id, moduleType)
+	component := cdx.Component{
+		BOMRef:     id,
+		Type:       cdx.ComponentTypeLibrary,
+		Group:      group,
+		Name:       name,
+		Version:    version,
+		PackageURL: createPackageUrl(moduleType, group, name, version),
+	}
+	var hashes []cdx.Hash
+	for _, hash := range []cdx.Hash{{Algorithm: cdx.HashAlgoMD5, Value: checksum.Md5}, {Algorithm: cdx.HashAlgoSHA1, Value: checksum.Sha1}, {Algorithm: cdx.HashAlgoSHA256, Value: checksum.Sha256}} {
+		if hash.Value != "" {
+			hashes = append(hashes, hash)
+		}
+	}
+	if len(hashes) > 0 {
+		component.Hashes = &hashes
+	}
+	return component
+}
+
+// Split the build-info ID of a module or a dependency into its group, name and version, according to the ID format of the package type.
+// IDs of unknown formats are returned as the name.
+func splitComponentId(id string, moduleType buildinfo.ModuleType) (group, name, version string) {
+	switch moduleType {
+	case buildinfo.Maven, buildinfo.Gradle:
+		// group:artifact:version
+		if parts := strings.Split(id, ":"); len(parts) == 3 {
+			return parts[0], parts[1], parts[2]
+		}
+	case buildinfo.Npm, buildinfo.Go, buildinfo.Python, buildinfo.Nuget:
+		// name:version, where npm names may start with '@'.
+		if i := strings.LastIndex(id, ":"); i > 0 {
+			return "", id[:i], id[i+1:]
+		}
+	}
+	return "", id, ""
+}
+
+// Create the package URL (purl) of the component, or return an empty string if the package type has no purl type.
+func createPackageUrl(moduleType buildinfo.ModuleType, group, name, version string) string {
+	if version == "" {
+		return ""
+	}
+	var purl string
+	switch moduleType {
+	case buildinfo.Maven, buildinfo.Gradle:
+		purl = "pkg:maven/" + url.PathEscape(group) + "/" + url.PathEscape(name)
+	case buildinfo.Npm:
+		purl = "pkg:npm/" + strings.Replace(name, "@", "%40", 1)
+	case buildinfo.Go:
+		purl = "pkg:golang/" + name
+	case buildinfo.Python:
+		purl = "pkg:pypi/" + strings.ToLower(name)
+	case buildinfo.Nuget:
+		purl = "pkg:nuget/" + url.PathEscape(name)
+	default:
+		return ""
+	}
+	return purl + "@" + url.PathEscape(version)
+}
+
+// Convert the build-info time to RFC 3339, as expected by CycloneDX. Returns an empty string if the time can't be parsed.
+func convertBuildInfoTime(buildInfoTime string) string {
+	parsed, err := time.Parse(buildinfo.TimeFormat, buildInfoTime)
+	if err != nil {
+		return ""
+	}
+	return parsed.Format(time.RFC3339)
+}
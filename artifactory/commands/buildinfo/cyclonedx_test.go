@@ -0,0 +1,128 @@
+package buildinfo
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCycloneDxTestBuildInfo() *buildinfo.BuildInfo {
+	return &buildinfo.BuildInfo{
+		Name:    "my-build",
+		Number:  "7",
+		Started: "2024-03-01T10:20:30.000+0200",
+		Modules: []buildinfo.Module{
+			{
+				Id:       "org.example:app:1.0",
+				Type:     buildinfo.Maven,
+				Checksum: buildinfo.Checksum{Sha1: "app-sha1"},
+				Dependencies: []buildinfo.Dependency{
+					{Id: "org.example:lib:1.0", Scopes: []string{"compile"}, Checksum: buildinfo.Checksum{Sha1: "lib-sha1", Md5: "lib-md5", Sha256: "lib-sha256"}},
+					{Id: "com.google.guava:guava:33.0.0", RequestedBy: [][]string{{"org.example:lib:1.0", "org.example:app:1.0"}}},
+				},
+			},
+			{
+				Id:   "org.example:lib:1.0",
+				Type: buildinfo.Maven,
+			},
+			{
+				Id:   "web",
+				Type: buildinfo.Npm,
+				Dependencies: []buildinfo.Dependency{
+					{Id: "@types/node:20.1.0", RequestedBy: [][]string{{"web"}}},
+				},
+			},
+		},
+	}
+}
+
+func TestConvertBuildInfoToCycloneDx(t *testing.T) {
+	bom := ConvertBuildInfoToCycloneDx(createCycloneDxTestBuildInfo())
+	assert.Regexp(t, "^urn:uuid:", bom.SerialNumber)
+	require.NotNil(t, bom.Metadata)
+	assert.Equal(t, "2024-03-01T10:20:30+02:00", bom.Metadata.Timestamp)
+	assert.Equal(t, &cdx.Component{BOMRef: "my-build/7", Type: cdx.ComponentTypeApplication, Name: "my-build", Version: "7"}, bom.Metadata.Component)
+
+	require.NotNil(t, bom.Components)
+	expectedComponents := []cdx.Component{
+		{
+			BOMRef: "org.example:app:1.0", Type: cdx.ComponentTypeLibrary, Group: "org.example", Name: "app", Version: "1.0", PackageURL: "pkg:maven/org.example/app@1.0",
+			Hashes:     &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA1, Value: "app-sha1"}},
+			Properties: &[]cdx.Property{{Name: cdxPropertyModuleType, Value: "maven"}},
+		},
+		{
+			BOMRef: "org.example:lib:1.0", Type: cdx.ComponentTypeLibrary, Group: "org.example", Name: "lib", Version: "1.0", PackageURL: "pkg:maven/org.example/lib@1.0",
+			Properties: &[]cdx.Property{{Name: cdxPropertyModuleType, Value: "maven"}},
+		},
+		{
+			BOMRef: "web", Type: cdx.ComponentTypeLibrary, Name: "web",
+			Properties: &[]cdx.Property{{Name: cdxPropertyModuleType, Value: "npm"}},
+		},
+		{BOMRef: "com.google.guava:guava:33.0.0", Type: cdx.ComponentTypeLibrary, Group: "com.google.guava", Name: "guava", Version: "33.0.0", PackageURL: "pkg:maven/com.google.guava/guava@33.0.0"},
+		{BOMRef: "@types/node:20.1.0", Type: cdx.ComponentTypeLibrary, Name: "@types/node", Version: "20.1.0", PackageURL: "pkg:npm/%40types/node@20.1.0"},
+	}
+	assert.Equal(t, expectedComponents, *bom.Components)
+
+	require.NotNil(t, bom.Dependencies)
+	expectedDependencies := []cdx.Dependency{
+		{Ref: "my-build/7", Dependencies: &[]string{"org.example:app:1.0", "org.example:lib:1.0", "web"}},
+		{Ref: "org.example:app:1.0", Dependencies: &[]string{"org.example:lib:1.0"}},
+		{Ref: "org.example:lib:1.0", Dependencies: &[]string{"com.google.guava:guava:33.0.0"}},
+		{Ref: "com.google.guava:guava:33.0.0"},
+		{Ref: "web", Dependencies: &[]string{"@types/node:20.1.0"}},
+		{Ref: "@types/node:20.1.0"},
+	}
+	assert.Equal(t, expectedDependencies, *bom.Dependencies)
+}
+
+func TestSplitComponentId(t *testing.T) {
+	tests := []struct {
+		id              string
+		moduleType      buildinfo.ModuleType
+		expectedGroup   string
+		expectedName    string
+		expectedVersion string
+		expectedPurl    string
+	}{
+		{"org.example:app:1.0", buildinfo.Gradle, "org.example", "app", "1.0", "pkg:maven/org.example/app@1.0"},
+		{"org.example:app", buildinfo.Maven, "", "org.example:app", "", ""},
+		{"lodash:4.17.21", buildinfo.Npm, "", "lodash", "4.17.21", "pkg:npm/lodash@4.17.21"},
+		{"github.com/jfrog/gofrog:v1.7.6", buildinfo.Go, "", "github.com/jfrog/gofrog", "v1.7.6", "pkg:golang/github.com/jfrog/gofrog@v1.7.6"},
+		{"PyYAML:6.0.1", buildinfo.Python, "", "PyYAML", "6.0.1", "pkg:pypi/pyyaml@6.0.1"},
+		{"Newtonsoft.Json:13.0.3", buildinfo.Nuget, "", "Newtonsoft.Json", "13.0.3", "pkg:nuget/Newtonsoft.Json@13.0.3"},
+		{"sha256:0123abcd", buildinfo.Docker, "", "sha256:0123abcd", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			group, name, version := splitComponentId(test.id, test.moduleType)
+			assert.Equal(t, test.expectedGroup, group)
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedVersion, version)
+			assert.Equal(t, test.expectedPurl, createPackageUrl(test.moduleType, group, name, version))
+		})
+	}
+}
+
+func TestEncodeCycloneDx(t *testing.T) {
+	buildInfo := createCycloneDxTestBuildInfo()
+	sbom, err := encodeCycloneDx(ConvertBuildInfoToCycloneDx(buildInfo), cdx.BOMFileFormatJSON)
+	assert.NoError(t, err)
+	assert.Contains(t, sbom, `"specVersion": "1.5"`)
+	assert.Contains(t, sbom, `"purl": "pkg:maven/org.example/app@1.0"`)
+
+	sbom, err = encodeCycloneDx(ConvertBuildInfoToCycloneDx(buildInfo), cdx.BOMFileFormatXML)
+	assert.NoError(t, err)
+	assert.Contains(t, sbom, `xmlns="http://cyclonedx.org/schema/bom/1.5"`)
+	assert.Contains(t, sbom, `<purl>pkg:maven/org.example/app@1.0</purl>`)
+}
+
+func TestBuildSbomCommandInvalidFormat(t *testing.T) {
+	_, err := NewBuildSbomCommand().SetFormat(SbomFormatTagValue).getBomFileFormat()
+	assert.ErrorContains(t, err, "unsupported CycloneDX SBOM format 'tag-value'")
+
+	_, err = NewBuildSbomCommand().SetStandard(SbomStandardSpdx).SetFormat(SbomFormatXml).createSpdx()
+	assert.ErrorContains(t, err, "unsupported SPDX SBOM format 'xml'")
+}
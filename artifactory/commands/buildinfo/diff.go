@@ -0,0 +1,257 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	DiffStatusAdded   = "added"
+	DiffStatusRemoved = "removed"
+	DiffStatusChanged = "changed"
+)
+
+// The differences between two builds.
+type BuildDiff struct {
+	Artifacts    []ComponentDiff `json:"artifacts"`
+	Dependencies []ComponentDiff `json:"dependencies"`
+}
+
+// An artifact or a dependency which was added, removed or changed between the builds.
+// The checksum is the SHA256 checksum, or the SHA1 checksum if the SHA256 checksum is missing.
+type ComponentDiff struct {
+	Status      string `json:"status" col-name:"Status"`
+	Module      string `json:"module" col-name:"Module"`
+	Name        string `json:"name" col-name:"Name"`
+	OldVersion  string `json:"oldVersion,omitempty" col-name:"Old Version" omitempty:"true"`
+	NewVersion  string `json:"newVersion,omitempty" col-name:"New Version" omitempty:"true"`
+	OldChecksum string `json:"oldChecksum,omitempty" col-name:"Old Checksum"`
+	NewChecksum string `json:"newChecksum,omitempty" col-name:"New Checksum"`
+}
+
+// Compares two published builds of the same build name, and reports the artifacts and dependencies added, removed or changed between them.
+// Artifacts and dependencies are matched by their module and name, regardless of their version,
+// so that a version bump is reported as a changed component rather than as a removed and an added one.
+type BuildDiffCommand struct {
+	serverDetails *config.ServerDetails
+	buildName     string
+	oldNumber     string
+	newNumber     string
+	project       string
+	format        format.OutputFormat
+	diff          *BuildDiff
+}
+
+func NewBuildDiffCommand() *BuildDiffCommand {
+	return &BuildDiffCommand{format: format.Table}
+}
+
+func (bdc *BuildDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildDiffCommand {
+	bdc.serverDetails = serverDetails
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetBuildName(buildName string) *BuildDiffCommand {
+	bdc.buildName = buildName
+	return bdc
+}
+
+// Set the numbers of the two compared builds. The changes are reported from the first build to the second.
+func (bdc *BuildDiffCommand) SetBuildNumbers(oldNumber, newNumber string) *BuildDiffCommand {
+	bdc.oldNumber, bdc.newNumber = oldNumber, newNumber
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetProject(project string) *BuildDiffCommand {
+	bdc.project = project
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetFormat(format format.OutputFormat) *BuildDiffCommand {
+	bdc.format = format
+	return bdc
+}
+
+// Returns the differences found by the last run.
+func (bdc *BuildDiffCommand) Diff() *BuildDiff {
+	return bdc.diff
+}
+
+func (bdc *BuildDiffCommand) CommandName() string {
+	return "rt_build_diff"
+}
+
+func (bdc *BuildDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bdc.serverDetails, nil
+}
+
+func (bdc *BuildDiffCommand) Run() error {
+	if bdc.format != format.Table && bdc.format != format.Json {
+		return errorutils.CheckErrorf("unsupported build diff format '%s'. Supported formats: %s, %s", bdc.format, format.Table, format.Json)
+	}
+	servicesManager, err := utils.CreateServiceManager(bdc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	oldBuildInfo, err := bdc.getBuildInfo(servicesManager, bdc.oldNumber)
+	if err != nil {
+		return err
+	}
+	newBuildInfo, err := bdc.getBuildInfo(servicesManager, bdc.newNumber)
+	if err != nil {
+		return err
+	}
+	bdc.diff = DiffBuildInfos(oldBuildInfo, newBuildInfo)
+	return bdc.printDiff()
+}
+
+func (bdc *BuildDiffCommand) getBuildInfo(servicesManager artifactory.ArtifactoryServicesManager, buildNumber string) (*buildinfo.BuildInfo, error) {
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: bdc.buildName, BuildNumber: buildNumber, ProjectKey: bdc.project})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s/%s was not found in Artifactory", bdc.buildName, buildNumber)
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
+
+func (bdc *BuildDiffCommand) printDiff() error {
+	if bdc.format == format.Json {
+		content, err := json.Marshal(bdc.diff)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	if err := coreutils.PrintTable(bdc.diff.Artifacts, "Artifacts", "No artifacts were changed", false); err != nil {
+		return err
+	}
+	return coreutils.PrintTable(bdc.diff.Dependencies, "Dependencies", "No dependencies were changed", false)
+}
+
+// A build-info artifact or dependency, identified by its module and versionless name.
+type diffComponent struct {
+	key      componentKey
+	name     string
+	version  string
+	checksum string
+}
+
+type componentKey struct {
+	module string
+	name   string
+}
+
+// Compare the artifacts and the dependencies of the two build-infos.
+// The differences are sorted by module and name.
+func DiffBuildInfos(oldBuildInfo, newBuildInfo *buildinfo.BuildInfo) *BuildDiff {
+	oldArtifacts, oldDependencies := collectDiffComponents(oldBuildInfo)
+	newArtifacts, newDependencies := collectDiffComponents(newBuildInfo)
+	return &BuildDiff{
+		Artifacts:    diffComponents(oldArtifacts, newArtifacts),
+		Dependencies: diffComponents(oldDependencies, newDependencies),
+	}
+}
+
+func collectDiffComponents(buildInfo *buildinfo.BuildInfo) (artifacts, dependencies map[componentKey]diffComponent) {
+	artifacts, dependencies = make(map[componentKey]diffComponent), make(map[componentKey]diffComponent)
+	for _, module := range buildInfo.Modules {
+		moduleName := getVersionlessName(module.Id, module.Type)
+		_, _, moduleVersion := splitComponentId(module.Id, module.Type)
+		for _, artifact := range module.Artifacts {
+			name := artifact.Name
+			if artifact.Path != "" {
+				name = artifact.Path
+			}
+			key := componentKey{module: moduleName, name: getVersionlessArtifactName(name, moduleVersion)}
+			artifacts[key] = diffComponent{key: key, name: name, version: moduleVersion, checksum: getDiffChecksum(artifact.Checksum)}
+		}
+		for _, dependency := range module.Dependencies {
+			_, _, version := splitComponentId(dependency.Id, module.Type)
+			key := componentKey{module: moduleName, name: getVersionlessName(dependency.Id, module.Type)}
+			dependencies[key] = diffComponent{key: key, name: key.name, version: version, checksum: getDiffChecksum(dependency.Checksum)}
+		}
+	}
+	return
+}
+
+func diffComponents(oldComponents, newComponents map[componentKey]diffComponent) []ComponentDiff {
+	diffs := []ComponentDiff{}
+	for key, oldComponent := range oldComponents {
+		newComponent, exists := newComponents[key]
+		switch {
+		case !exists:
+			diffs = append(diffs, createComponentDiff(DiffStatusRemoved, oldComponent, diffComponent{}))
+		case oldComponent.version != newComponent.version || oldComponent.checksum != newComponent.checksum:
+			diffs = append(diffs, createComponentDiff(DiffStatusChanged, oldComponent, newComponent))
+		}
+	}
+	for key, newComponent := range newComponents {
+		if _, exists := oldComponents[key]; !exists {
+			diffs = append(diffs, createComponentDiff(DiffStatusAdded, diffComponent{}, newComponent))
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Module != diffs[j].Module {
+			return diffs[i].Module < diffs[j].Module
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// The name of the diff is the name of the component in the new build, unless it was removed.
+func createComponentDiff(status string, oldComponent, newComponent diffComponent) ComponentDiff {
+	component := newComponent
+	if status == DiffStatusRemoved {
+		component = oldComponent
+	}
+	return ComponentDiff{
+		Status:      status,
+		Module:      component.key.module,
+		Name:        component.name,
+		OldVersion:  oldComponent.version,
+		NewVersion:  newComponent.version,
+		OldChecksum: oldComponent.checksum,
+		NewChecksum: newComponent.checksum,
+	}
+}
+
+// Returns the group and name of the component, without its version.
+func getVersionlessName(id string, moduleType buildinfo.ModuleType) string {
+	group, name, _ := splitComponentId(id, moduleType)
+	if group != "" {
+		return group + ":" + name
+	}
+	return name
+}
+
+// Returns the name of the artifact without the version of its module, which is usually part of its file name and path,
+// such as 'org/example/app/1.0/app-1.0.jar'.
+func getVersionlessArtifactName(name, moduleVersion string) string {
+	if moduleVersion == "" {
+		return name
+	}
+	return strings.ReplaceAll(name, moduleVersion, "")
+}
+
+func getDiffChecksum(checksum buildinfo.Checksum) string {
+	if checksum.Sha256 != "" {
+		return checksum.Sha256
+	}
+	return checksum.Sha1
+}
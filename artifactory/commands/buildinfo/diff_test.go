@@ -0,0 +1,66 @@
+package buildinfo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffBuildInfos(t *testing.T) {
+	oldBuildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
+		Id:   "org.example:app:1.0",
+		Type: buildinfo.Maven,
+		Artifacts: []buildinfo.Artifact{
+			{Name: "app-1.0.jar", Checksum: buildinfo.Checksum{Sha1: "jar-sha1", Sha256: "jar-sha256"}},
+			{Name: "app.pom", Checksum: buildinfo.Checksum{Sha1: "pom-sha1"}},
+			{Name: "app-1.0-tests.jar", Checksum: buildinfo.Checksum{Sha1: "tests-sha1"}},
+		},
+		Dependencies: []buildinfo.Dependency{
+			{Id: "org.example:lib:1.0", Checksum: buildinfo.Checksum{Sha1: "lib-sha1"}},
+			{Id: "com.google.guava:guava:32.0.0", Checksum: buildinfo.Checksum{Sha1: "guava-sha1"}},
+		},
+	}}}
+	newBuildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
+		Id:   "org.example:app:1.1",
+		Type: buildinfo.Maven,
+		Artifacts: []buildinfo.Artifact{
+			{Name: "app-1.1.jar", Checksum: buildinfo.Checksum{Sha1: "jar-sha1-new", Sha256: "jar-sha256-new"}},
+			{Name: "app.pom", Checksum: buildinfo.Checksum{Sha1: "pom-sha1"}},
+			{Name: "app-1.1-sources.jar", Checksum: buildinfo.Checksum{Sha1: "sources-sha1"}},
+		},
+		Dependencies: []buildinfo.Dependency{
+			{Id: "org.example:lib:1.0", Checksum: buildinfo.Checksum{Sha1: "lib-sha1"}},
+			{Id: "com.google.guava:guava:33.0.0", Checksum: buildinfo.Checksum{Sha1: "guava-sha1-new"}},
+			{Id: "org.slf4j:slf4j-api:2.0.9", Checksum: buildinfo.Checksum{Sha1: "slf4j-sha1"}},
+		},
+	}}}
+
+	diff := DiffBuildInfos(oldBuildInfo, newBuildInfo)
+	assert.Equal(t, []ComponentDiff{
+		{Status: DiffStatusRemoved, Module: "org.example:app", Name: "app-1.0-tests.jar", OldVersion: "1.0", OldChecksum: "tests-sha1"},
+		{Status: DiffStatusAdded, Module: "org.example:app", Name: "app-1.1-sources.jar", NewVersion: "1.1", NewChecksum: "sources-sha1"},
+		{Status: DiffStatusChanged, Module: "org.example:app", Name: "app-1.1.jar", OldVersion: "1.0", NewVersion: "1.1", OldChecksum: "jar-sha256", NewChecksum: "jar-sha256-new"},
+		{Status: DiffStatusChanged, Module: "org.example:app", Name: "app.pom", OldVersion: "1.0", NewVersion: "1.1", OldChecksum: "pom-sha1", NewChecksum: "pom-sha1"},
+	}, diff.Artifacts)
+	assert.Equal(t, []ComponentDiff{
+		{Status: DiffStatusChanged, Module: "org.example:app", Name: "com.google.guava:guava", OldVersion: "32.0.0", NewVersion: "33.0.0", OldChecksum: "guava-sha1", NewChecksum: "guava-sha1-new"},
+		{Status: DiffStatusAdded, Module: "org.example:app", Name: "org.slf4j:slf4j-api", NewVersion: "2.0.9", NewChecksum: "slf4j-sha1"},
+	}, diff.Dependencies)
+
+	diff = DiffBuildInfos(newBuildInfo, &buildinfo.BuildInfo{})
+	assert.Len(t, diff.Artifacts, 3)
+	for _, artifact := range diff.Artifacts {
+		assert.Equal(t, DiffStatusRemoved, artifact.Status)
+	}
+
+	diff = DiffBuildInfos(newBuildInfo, newBuildInfo)
+	assert.Empty(t, diff.Artifacts)
+	assert.Empty(t, diff.Dependencies)
+}
+
+func TestBuildDiffCommandInvalidFormat(t *testing.T) {
+	err := NewBuildDiffCommand().SetFormat(format.Sarif).Run()
+	assert.ErrorContains(t, err, "unsupported build diff format 'sarif'")
+}
@@ -12,6 +12,8 @@ type BuildPromotionCommand struct {
 	buildConfiguration *build.BuildConfiguration
 	serverDetails      *config.ServerDetails
 	dryRun             bool
+	// If set, the build is promoted to each of the targets, instead of to the target repository of the promotion params.
+	targets []utils.PromotionTarget
 }
 
 func NewBuildPromotionCommand() *BuildPromotionCommand {
@@ -33,6 +35,12 @@ func (bpc *BuildPromotionCommand) SetPromotionParams(params services.PromotionPa
 	return bpc
 }
 
+// Promote the build to multiple targets, with the promotions to the previous targets rolled back if one of them fails. See promoteToTargets.
+func (bpc *BuildPromotionCommand) SetTargets(targets []utils.PromotionTarget) *BuildPromotionCommand {
+	bpc.targets = targets
+	return bpc
+}
+
 func (bpc *BuildPromotionCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildPromotionCommand {
 	bpc.buildConfiguration = buildConfiguration
 	return bpc
@@ -55,6 +63,9 @@ func (bpc *BuildPromotionCommand) Run() error {
 		return err
 	}
 	bpc.BuildName, bpc.BuildNumber, bpc.ProjectKey = buildName, buildNumber, bpc.buildConfiguration.GetProject()
+	if len(bpc.targets) > 0 {
+		return bpc.promoteToTargets(servicesManager)
+	}
 	return servicesManager.PromoteBuild(bpc.PromotionParams)
 }
 
@@ -0,0 +1,178 @@
+package buildinfo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Promote the build to all the targets:
+//  1. The promotion to each of the targets is validated by an Artifactory dry run, before promoting to any of them.
+//  2. The build is promoted to the targets one by one. If the promotion to one of the targets fails, the artifacts copied
+//     to the previous targets are deleted from them, keeping those which were in them before the promotion.
+//     The promotion records Artifactory added to the build info remain, and the failed rollbacks are reported.
+//
+// In dry run, the artifacts which would be promoted to each of the targets are listed.
+func (bpc *BuildPromotionCommand) promoteToTargets(servicesManager artifactory.ArtifactoryServicesManager) error {
+	if err := utils.ValidatePromotionTargets(bpc.targets); err != nil {
+		return err
+	}
+	if len(bpc.targets) > 1 && !bpc.Copy {
+		return errorutils.CheckErrorf("promoting a build to multiple targets requires copying the artifacts, since moving them to the first target removes them from the source of the others")
+	}
+	dryRunServicesManager := servicesManager
+	if !bpc.dryRun {
+		var err error
+		if dryRunServicesManager, err = utils.CreateServiceManager(bpc.serverDetails, -1, 0, true); err != nil {
+			return err
+		}
+	}
+	for _, target := range bpc.targets {
+		if err := dryRunServicesManager.PromoteBuild(bpc.getTargetPromotionParams(target)); err != nil {
+			return errors.Join(errorutils.CheckErrorf("the promotion to '%s' failed in dry run, so the build wasn't promoted to any target", target.TargetRepo), err)
+		}
+	}
+	if bpc.dryRun {
+		return bpc.previewTargets(servicesManager)
+	}
+
+	// The build artifacts which already exist in each of the targets, and therefore aren't deleted on rollback.
+	existingItems := make([]map[string]bool, len(bpc.targets))
+	for i, target := range bpc.targets {
+		items, err := bpc.searchBuildItems(servicesManager, target.TargetRepo)
+		if err != nil {
+			return err
+		}
+		existingItems[i] = make(map[string]bool, len(items))
+		for _, item := range items {
+			existingItems[i][item.GetItemRelativePath()] = true
+		}
+	}
+	for i, target := range bpc.targets {
+		if err := servicesManager.PromoteBuild(bpc.getTargetPromotionParams(target)); err != nil {
+			if i > 0 {
+				log.Error(fmt.Sprintf("The promotion to '%s' failed. Rolling back the promotions to the previous targets...", target.TargetRepo))
+			}
+			return errors.Join(bpc.rollbackTargets(servicesManager, i, existingItems), err)
+		}
+		log.Info(fmt.Sprintf("Promoted the build to '%s'.", target.TargetRepo))
+	}
+	return nil
+}
+
+// Delete the build artifacts copied to the targets before the target at index failedTarget, keeping the artifacts which
+// existed in them before the promotion. The returned error describes the state the targets are left in.
+func (bpc *BuildPromotionCommand) rollbackTargets(servicesManager artifactory.ArtifactoryServicesManager, failedTarget int, existingItems []map[string]bool) error {
+	failedRepo := bpc.targets[failedTarget].TargetRepo
+	if failedTarget == 0 {
+		return errorutils.CheckErrorf("the promotion to '%s' failed, so the build wasn't promoted to any target", failedRepo)
+	}
+	var rolledBack, promoted []string
+	var rollbackErr error
+	for i, target := range bpc.targets[:failedTarget] {
+		deleted, err := bpc.rollbackTarget(servicesManager, target.TargetRepo, existingItems[i])
+		if err != nil {
+			promoted = append(promoted, target.TargetRepo)
+			rollbackErr = errors.Join(rollbackErr, errorutils.CheckErrorf("failed to roll back the promotion to '%s': %s", target.TargetRepo, err.Error()))
+			continue
+		}
+		rolledBack = append(rolledBack, target.TargetRepo)
+		log.Info(fmt.Sprintf("Rolled back the promotion to '%s' by deleting %d artifacts.", target.TargetRepo, deleted))
+	}
+	if len(promoted) == 0 {
+		return errorutils.CheckErrorf("the promotion to '%s' failed, so the promotions to: %s were rolled back", failedRepo, strings.Join(rolledBack, ", "))
+	}
+	return errors.Join(errorutils.CheckErrorf("the promotion to '%s' failed, and the build remains promoted to: %s", failedRepo, strings.Join(promoted, ", ")), rollbackErr)
+}
+
+func (bpc *BuildPromotionCommand) rollbackTarget(servicesManager artifactory.ArtifactoryServicesManager, targetRepo string, existingItems map[string]bool) (deleted int, err error) {
+	items, err := bpc.searchBuildItems(servicesManager, targetRepo)
+	if err != nil {
+		return
+	}
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return
+	}
+	for _, item := range items {
+		if !existingItems[item.GetItemRelativePath()] {
+			writer.Write(item)
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	return servicesManager.DeleteFiles(reader)
+}
+
+func (bpc *BuildPromotionCommand) getTargetPromotionParams(target utils.PromotionTarget) services.PromotionParams {
+	params := bpc.PromotionParams
+	params.SourceRepo, params.TargetRepo = target.SourceRepo, target.TargetRepo
+	return params
+}
+
+// Log the artifacts which would be promoted to each of the targets, and the paths they would be promoted to.
+func (bpc *BuildPromotionCommand) previewTargets(servicesManager artifactory.ArtifactoryServicesManager) error {
+	action := "moved"
+	if bpc.Copy {
+		action = "copied"
+	}
+	for _, target := range bpc.targets {
+		items, err := bpc.searchBuildItems(servicesManager, target.SourceRepo)
+		if err != nil {
+			return err
+		}
+		var lines []string
+		for _, item := range items {
+			// Artifacts which are already in the target repository aren't promoted.
+			if item.Repo != target.TargetRepo {
+				source := item.GetItemRelativePath()
+				lines = append(lines, fmt.Sprintf("  %s -> %s/%s", source, target.TargetRepo, strings.TrimPrefix(source, item.Repo+"/")))
+			}
+		}
+		log.Info(fmt.Sprintf("[Dry run] %d artifacts would be %s to '%s':", len(lines), action, target.TargetRepo))
+		for _, line := range lines {
+			log.Info(line)
+		}
+	}
+	return nil
+}
+
+// Search the artifacts of the build in the repository, or in all the repositories if repo is empty.
+// The dependencies of the build are included if the promotion includes them.
+func (bpc *BuildPromotionCommand) searchBuildItems(servicesManager artifactory.ArtifactoryServicesManager, repo string) (items []clientutils.ResultItem, err error) {
+	pattern := "*"
+	if repo != "" {
+		pattern = repo + "/*"
+	}
+	params := services.SearchParams{CommonParams: &clientutils.CommonParams{
+		Pattern:     pattern,
+		Build:       bpc.BuildName + "/" + bpc.BuildNumber,
+		Project:     bpc.ProjectKey,
+		Recursive:   true,
+		IncludeDeps: bpc.IncludeDependencies,
+	}}
+	reader, err := servicesManager.SearchFiles(params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	for item := new(clientutils.ResultItem); reader.NextRecord(item) == nil; item = new(clientutils.ResultItem) {
+		items = append(items, *item)
+	}
+	return items, reader.GetError()
+}
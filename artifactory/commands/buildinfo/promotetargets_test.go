@@ -0,0 +1,96 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTargetPromotionParams(t *testing.T) {
+	command := NewBuildPromotionCommand().SetPromotionParams(services.PromotionParams{BuildName: "build", BuildNumber: "1", TargetRepo: "ignored", Status: "released", Copy: true})
+	params := command.getTargetPromotionParams(utils.PromotionTarget{SourceRepo: "staging", TargetRepo: "release"})
+	assert.Equal(t, services.PromotionParams{BuildName: "build", BuildNumber: "1", SourceRepo: "staging", TargetRepo: "release", Status: "released", Copy: true}, params)
+	// The params of the command remain unchanged.
+	assert.Equal(t, "ignored", command.TargetRepo)
+}
+
+func TestPromoteToTargetsValidation(t *testing.T) {
+	targets := []utils.PromotionTarget{{TargetRepo: "release"}, {TargetRepo: "archive"}}
+	err := NewBuildPromotionCommand().SetTargets(targets).promoteToTargets(nil)
+	assert.ErrorContains(t, err, "promoting a build to multiple targets requires copying the artifacts")
+
+	err = NewBuildPromotionCommand().SetTargets([]utils.PromotionTarget{{TargetRepo: "release"}, {TargetRepo: "release"}}).promoteToTargets(nil)
+	assert.ErrorContains(t, err, "the promotion target 'release' is repeated")
+}
+
+func TestPromoteToTargetsRollback(t *testing.T) {
+	buildItem := func(repo, name string) map[string]any {
+		return map[string]any{"repo": repo, "path": "app", "name": name, "type": "file", "actual_sha1": name + "-sha1",
+			"properties": []map[string]string{{"key": "build.name", "value": "build"}, {"key": "build.number", "value": "1"}}}
+	}
+	var mutex sync.Mutex
+	// The build artifacts in each of the repositories. 'lib.jar' was in 'release' before the promotion.
+	repos := map[string][]map[string]any{"staging": {buildItem("staging", "app.jar"), buildItem("staging", "lib.jar")}, "release": {buildItem("release", "lib.jar")}}
+	var deleted []string
+	testServer, serverDetails, servicesManager := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		switch {
+		case r.URL.Path == "/api/system/version":
+			_, err = w.Write([]byte(`{"version":"7.80.0"}`))
+		case r.URL.Path == "/api/build/build/1":
+			_, err = w.Write([]byte(`{"buildInfo":{"name":"build","number":"1"}}`))
+		case r.URL.Path == "/api/search/aql":
+			results := repos["staging"]
+			if match := regexp.MustCompile(`"repo":"([^"]+)"`).FindSubmatch(body); match != nil {
+				results = repos[string(match[1])]
+			}
+			err = json.NewEncoder(w).Encode(map[string]any{"results": results})
+		case r.URL.Path == "/api/build/promote/build/1":
+			var promotion struct {
+				TargetRepo string `json:"targetRepo"`
+				DryRun     bool   `json:"dryRun"`
+			}
+			require.NoError(t, json.Unmarshal(body, &promotion))
+			if promotion.DryRun {
+				return
+			}
+			if promotion.TargetRepo == "archive" {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			repos[promotion.TargetRepo] = []map[string]any{buildItem(promotion.TargetRepo, "app.jar"), buildItem(promotion.TargetRepo, "lib.jar")}
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		assert.NoError(t, err)
+	})
+	defer testServer.Close()
+
+	command := NewBuildPromotionCommand().SetServerDetails(serverDetails).
+		SetPromotionParams(services.PromotionParams{BuildName: "build", BuildNumber: "1", Copy: true}).
+		SetTargets([]utils.PromotionTarget{{SourceRepo: "staging", TargetRepo: "release"}, {SourceRepo: "staging", TargetRepo: "archive"}})
+	err := command.promoteToTargets(servicesManager)
+	assert.ErrorContains(t, err, "the promotion to 'archive' failed, so the promotions to: release were rolled back")
+	// Only the artifact copied by the promotion is deleted.
+	assert.Equal(t, []string{"/release/app/app.jar"}, deleted)
+}
+
+func TestRollbackTargetsNothingPromoted(t *testing.T) {
+	command := NewBuildPromotionCommand().SetTargets([]utils.PromotionTarget{{TargetRepo: "release"}, {TargetRepo: "archive"}})
+	assert.EqualError(t, command.rollbackTargets(nil, 0, nil), "the promotion to 'release' failed, so the build wasn't promoted to any target")
+}
@@ -114,12 +114,7 @@ func (bpc *BuildPublishCommand) Run() error {
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
-	err = buildInfo.IncludeEnv(strings.Split(bpc.config.EnvInclude, ";")...)
-	if errorutils.CheckError(err) != nil {
-		return err
-	}
-	err = buildInfo.ExcludeEnv(strings.Split(bpc.config.EnvExclude, ";")...)
-	if errorutils.CheckError(err) != nil {
+	if err = bpc.filterEnv(buildInfo); err != nil {
 		return err
 	}
 	if bpc.buildConfiguration.IsLoadedFromConfigFile() {
@@ -216,6 +211,23 @@ func (bpc *BuildPublishCommand) getBuildInfoUiUrl(majorVersion int, buildTime ti
 		baseUrl, buildName, buildNumber, strconv.FormatInt(timestamp, 10)), nil
 }
 
+// Filter the environment variables of the build-info by the include and exclude patterns, and redact the secrets among the remaining variables.
+// Patterns which aren't set in the config are read from the build config file.
+func (bpc *BuildPublishCommand) filterEnv(buildInfo *buildinfo.BuildInfo) error {
+	envInclude, envExclude, err := build.GetEnvFilterPatterns(bpc.config.EnvInclude, bpc.config.EnvExclude)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckError(buildInfo.IncludeEnv(strings.Split(envInclude, ";")...)); err != nil {
+		return err
+	}
+	if err = errorutils.CheckError(buildInfo.ExcludeEnv(strings.Split(envExclude, ";")...)); err != nil {
+		return err
+	}
+	build.RedactSecretEnvs(buildInfo.Properties)
+	return nil
+}
+
 // Return the next build number based on the previously published build.
 // Return "1" if no build is found
 func (bpc *BuildPublishCommand) getNextBuildNumber(buildName string, servicesManager artifactory.ArtifactoryServicesManager) (string, error) {
@@ -0,0 +1,270 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	xrayutils "github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayServices "github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// The standard of the SBOM.
+type SbomStandard string
+
+const (
+	SbomStandardCycloneDx SbomStandard = "cyclonedx"
+	SbomStandardSpdx      SbomStandard = "spdx"
+)
+
+func GetSbomStandards() []string {
+	return []string{string(SbomStandardCycloneDx), string(SbomStandardSpdx)}
+}
+
+// The output format of the SBOM. The supported formats depend on the standard.
+type SbomFormat string
+
+const (
+	SbomFormatJson     SbomFormat = "json"
+	SbomFormatXml      SbomFormat = "xml"
+	SbomFormatTagValue SbomFormat = "tag-value"
+)
+
+// Exports a build-info as a CycloneDX 1.5 or SPDX 2.3 SBOM.
+// The build-info is either the one published to Artifactory, or the local one, collected before it's published.
+// SPDX SBOMs may also be exported from Xray scan results, such as the JSON output of the audit command.
+type BuildSbomCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	local              bool
+	standard           SbomStandard
+	format             SbomFormat
+	scanResultsFile    string
+}
+
+func NewBuildSbomCommand() *BuildSbomCommand {
+	return &BuildSbomCommand{standard: SbomStandardCycloneDx, format: SbomFormatJson}
+}
+
+func (bsc *BuildSbomCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildSbomCommand {
+	bsc.serverDetails = serverDetails
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildSbomCommand {
+	bsc.buildConfiguration = buildConfiguration
+	return bsc
+}
+
+// If true, the SBOM is created from the local build-info instead of the published one.
+func (bsc *BuildSbomCommand) SetLocal(local bool) *BuildSbomCommand {
+	bsc.local = local
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetStandard(standard SbomStandard) *BuildSbomCommand {
+	bsc.standard = standard
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) SetFormat(format SbomFormat) *BuildSbomCommand {
+	bsc.format = format
+	return bsc
+}
+
+// Set a file holding a JSON array of Xray scan results, to create the SBOM from instead of the build-info.
+func (bsc *BuildSbomCommand) SetScanResultsFile(scanResultsFile string) *BuildSbomCommand {
+	bsc.scanResultsFile = scanResultsFile
+	return bsc
+}
+
+func (bsc *BuildSbomCommand) CommandName() string {
+	return "rt_build_sbom"
+}
+
+func (bsc *BuildSbomCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bsc.serverDetails, nil
+}
+
+func (bsc *BuildSbomCommand) Run() (err error) {
+	var sbom string
+	switch bsc.standard {
+	case "", SbomStandardCycloneDx:
+		sbom, err = bsc.createCycloneDx()
+	case SbomStandardSpdx:
+		sbom, err = bsc.createSpdx()
+	default:
+		err = errorutils.CheckErrorf("unsupported SBOM standard '%s'. Supported standards: %s", bsc.standard, strings.Join(GetSbomStandards(), ", "))
+	}
+	if err != nil {
+		return
+	}
+	log.Output(sbom)
+	return
+}
+
+func (bsc *BuildSbomCommand) createCycloneDx() (string, error) {
+	fileFormat, err := bsc.getBomFileFormat()
+	if err != nil {
+		return "", err
+	}
+	if bsc.scanResultsFile != "" {
+		return "", errorutils.CheckErrorf("exporting scan results is supported for SPDX SBOMs only")
+	}
+	buildInfo, err := bsc.getBuildInfo()
+	if err != nil {
+		return "", err
+	}
+	return encodeCycloneDx(ConvertBuildInfoToCycloneDx(buildInfo), fileFormat)
+}
+
+func (bsc *BuildSbomCommand) getBomFileFormat() (cdx.BOMFileFormat, error) {
+	switch bsc.format {
+	case "", SbomFormatJson:
+		return cdx.BOMFileFormatJSON, nil
+	case SbomFormatXml:
+		return cdx.BOMFileFormatXML, nil
+	}
+	return 0, errorutils.CheckErrorf("unsupported CycloneDX SBOM format '%s'. Supported formats: %s, %s", bsc.format, SbomFormatJson, SbomFormatXml)
+}
+
+func (bsc *BuildSbomCommand) createSpdx() (string, error) {
+	if bsc.format != "" && bsc.format != SbomFormatJson && bsc.format != SbomFormatTagValue {
+		return "", errorutils.CheckErrorf("unsupported SPDX SBOM format '%s'. Supported formats: %s, %s", bsc.format, SbomFormatJson, SbomFormatTagValue)
+	}
+	var document *SpdxDocument
+	if bsc.scanResultsFile != "" {
+		results, err := readScanResults(bsc.scanResultsFile)
+		if err != nil {
+			return "", err
+		}
+		document = ConvertScanResultsToSpdx(strings.TrimSuffix(filepath.Base(bsc.scanResultsFile), filepath.Ext(bsc.scanResultsFile)), results)
+	} else {
+		buildInfo, err := bsc.getBuildInfo()
+		if err != nil {
+			return "", err
+		}
+		document = ConvertBuildInfoToSpdx(buildInfo, bsc.getXrayLicenses(buildInfo))
+	}
+	if bsc.format == SbomFormatTagValue {
+		return encodeSpdxTagValue(document), nil
+	}
+	return encodeSpdxJson(document)
+}
+
+func (bsc *BuildSbomCommand) getBuildInfo() (*buildinfo.BuildInfo, error) {
+	buildName, err := bsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return nil, err
+	}
+	buildNumber, err := bsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return nil, err
+	}
+	project := bsc.buildConfiguration.GetProject()
+	if bsc.local {
+		localBuild, err := build.CreateBuildInfoService().GetOrCreateBuildWithProject(buildName, buildNumber, project)
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		buildInfo, err := localBuild.ToBuildInfo()
+		return buildInfo, errorutils.CheckError(err)
+	}
+
+	servicesManager, err := utils.CreateServiceManager(bsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: project})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s/%s was not found in Artifactory", buildName, buildNumber)
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
+
+func encodeCycloneDx(bom *cdx.BOM, fileFormat cdx.BOMFileFormat) (string, error) {
+	var buf bytes.Buffer
+	encoder := cdx.NewBOMEncoder(&buf, fileFormat)
+	encoder.SetPretty(true)
+	if err := encoder.EncodeVersion(bom, cdx.SpecVersion1_5); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func readScanResults(scanResultsFile string) (results []xrayServices.ScanResponse, err error) {
+	content, err := os.ReadFile(scanResultsFile)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, &results); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the scan results file '%s': %s", scanResultsFile, err.Error())
+	}
+	return
+}
+
+// Returns the license expressions of the modules and dependencies of the build-info by their IDs, according to their SHA256 checksums in Xray.
+// Returns nil if no Xray URL is configured. Failing to get the licenses only logs a warning, since the SBOM is valid without them.
+func (bsc *BuildSbomCommand) getXrayLicenses(buildInfo *buildinfo.BuildInfo) map[string]string {
+	if bsc.serverDetails == nil || bsc.serverDetails.GetXrayUrl() == "" {
+		return nil
+	}
+	idsBySha256 := make(map[string][]string)
+	var checksums []string
+	addChecksum := func(id, sha256 string) {
+		if sha256 == "" {
+			return
+		}
+		if _, exists := idsBySha256[sha256]; !exists {
+			checksums = append(checksums, sha256)
+		}
+		idsBySha256[sha256] = append(idsBySha256[sha256], id)
+	}
+	for _, module := range buildInfo.Modules {
+		addChecksum(module.Id, module.Sha256)
+		for _, dependency := range module.Dependencies {
+			addChecksum(dependency.Id, dependency.Sha256)
+		}
+	}
+	if len(checksums) == 0 {
+		return nil
+	}
+	xrayManager, err := xrayutils.CreateXrayServiceManager(bsc.serverDetails)
+	if err != nil {
+		log.Warn("Failed to get the licenses from Xray:", err.Error())
+		return nil
+	}
+	summary, err := xrayManager.ArtifactSummary(xrayServices.ArtifactSummaryParams{Checksums: checksums})
+	if err != nil {
+		log.Warn("Failed to get the licenses from Xray:", err.Error())
+		return nil
+	}
+	return getLicensesByIds(summary, idsBySha256)
+}
+
+func getLicensesByIds(summary *xrayServices.ArtifactSummaryResponse, idsBySha256 map[string][]string) map[string]string {
+	licenses := make(map[string]string)
+	for _, artifact := range summary.Artifacts {
+		var names []string
+		for _, license := range artifact.Licenses {
+			names = append(names, license.Name)
+		}
+		for _, id := range idsBySha256[artifact.General.Sha256] {
+			licenses[id] = ToSpdxLicenseExpression(names)
+		}
+	}
+	return licenses
+}
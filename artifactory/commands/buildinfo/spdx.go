@@ -0,0 +1,367 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	xrayServices "github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const (
+	spdxVersion         = "SPDX-2.3"
+	spdxDataLicense     = "CC0-1.0"
+	spdxDocumentId      = "SPDXRef-DOCUMENT"
+	spdxNoAssertion     = "NOASSERTION"
+	spdxTimeFormat      = "2006-01-02T15:04:05Z"
+	spdxNamespacePrefix = "https://spdx.org/spdxdocs/"
+
+	spdxRelationshipDescribes = "DESCRIBES"
+	spdxRelationshipContains  = "CONTAINS"
+	spdxRelationshipDependsOn = "DEPENDS_ON"
+)
+
+// Characters which aren't allowed in SPDX IDs and license refs.
+var spdxInvalidIdCharsRegexp = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// License names which are valid SPDX license IDs. Other names are converted to license refs.
+var spdxLicenseIdRegexp = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+
+// The package types of the Xray component IDs, which are prefixed with '<type>://'.
+var xrayComponentTypes = map[string]buildinfo.ModuleType{
+	"gav":   buildinfo.Maven,
+	"npm":   buildinfo.Npm,
+	"go":    buildinfo.Go,
+	"pypi":  buildinfo.Python,
+	"nuget": buildinfo.Nuget,
+}
+
+type SpdxDocument struct {
+	SpdxVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SpdxId            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      SpdxCreationInfo   `json:"creationInfo"`
+	Packages          []SpdxPackage      `json:"packages"`
+	Relationships     []SpdxRelationship `json:"relationships"`
+}
+
+type SpdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SpdxPackage struct {
+	Name                  string            `json:"name"`
+	SpdxId                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	Checksums             []SpdxChecksum    `json:"checksums,omitempty"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	CopyrightText         string            `json:"copyrightText"`
+	ExternalRefs          []SpdxExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+}
+
+type SpdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type SpdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SpdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+// Convert the build-info into an SPDX document.
+// The build is the package the document describes, and contains its modules, which depend on their dependencies according to the 'requestedBy' paths.
+// licenses maps the IDs of the modules and dependencies to their license expressions. Packages without a license are declared as NOASSERTION.
+func ConvertBuildInfoToSpdx(buildInfo *buildinfo.BuildInfo, licenses map[string]string) *SpdxDocument {
+	created, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started)
+	if err != nil {
+		created = time.Now()
+	}
+	builder := newSpdxDocumentBuilder(buildInfo.Name+"-"+buildInfo.Number, created)
+	buildRef := buildInfo.Name + "/" + buildInfo.Number
+	builder.addPackage(buildRef, SpdxPackage{Name: buildInfo.Name, VersionInfo: buildInfo.Number, PrimaryPackagePurpose: "APPLICATION"})
+	builder.addRelationship("", spdxRelationshipDescribes, buildRef)
+	for _, module := range buildInfo.Modules {
+		pkg := createSpdxPackage(module.Id, module.Type, module.Checksum, licenses[module.Id])
+		if module.Type == buildinfo.Docker {
+			pkg.PrimaryPackagePurpose = "CONTAINER"
+		}
+		builder.addPackage(module.Id, pkg)
+		builder.addRelationship(buildRef, spdxRelationshipContains, module.Id)
+	}
+	for _, module := range buildInfo.Modules {
+		for _, dependency := range module.Dependencies {
+			builder.addPackage(dependency.Id, createSpdxPackage(dependency.Id, module.Type, dependency.Checksum, licenses[dependency.Id]))
+			if len(dependency.RequestedBy) == 0 {
+				builder.addRelationship(module.Id, spdxRelationshipDependsOn, dependency.Id)
+			}
+			for _, path := range dependency.RequestedBy {
+				if len(path) > 0 {
+					builder.addRelationship(path[0], spdxRelationshipDependsOn, dependency.Id)
+				}
+			}
+		}
+	}
+	return builder.document
+}
+
+// Convert Xray scan results, such as the JSON output of the audit command, into an SPDX document.
+// The components are taken from the vulnerabilities and the licenses of the results, and the dependencies between them from their impact paths.
+// The document describes the roots of the impact paths, which are the scanned projects.
+func ConvertScanResultsToSpdx(name string, results []xrayServices.ScanResponse) *SpdxDocument {
+	licenses := make(map[string][]string)
+	components := make(map[string]bool)
+	roots := make(map[string]bool)
+	dependsOn := make(map[[2]string]bool)
+	addComponent := func(componentId string, component xrayServices.Component) {
+		components[componentId] = true
+		for _, path := range component.ImpactPaths {
+			for i, node := range path {
+				components[node.ComponentId] = true
+				if i == 0 {
+					roots[node.ComponentId] = true
+				} else {
+					dependsOn[[2]string{path[i-1].ComponentId, node.ComponentId}] = true
+				}
+			}
+		}
+	}
+	for _, result := range results {
+		if result.ScannedComponentId != "" {
+			components[result.ScannedComponentId] = true
+			roots[result.ScannedComponentId] = true
+		}
+		for _, vulnerability := range result.Vulnerabilities {
+			for componentId, component := range vulnerability.Components {
+				addComponent(componentId, component)
+			}
+		}
+		for _, license := range result.Licenses {
+			licenseName := license.Key
+			if licenseName == "" {
+				licenseName = license.Name
+			}
+			for componentId, component := range license.Components {
+				addComponent(componentId, component)
+				if !slices.Contains(licenses[componentId], licenseName) {
+					licenses[componentId] = append(licenses[componentId], licenseName)
+				}
+			}
+		}
+	}
+
+	// The components are collected from maps, so they are sorted to create the same document for the same results.
+	builder := newSpdxDocumentBuilder(name, time.Now())
+	for _, componentId := range getSortedKeys(components) {
+		moduleType, id := splitXrayComponentId(componentId)
+		builder.addPackage(componentId, createSpdxPackage(id, moduleType, buildinfo.Checksum{}, ToSpdxLicenseExpression(licenses[componentId])))
+	}
+	for _, root := range getSortedKeys(roots) {
+		builder.addRelationship("", spdxRelationshipDescribes, root)
+	}
+	edges := make([][2]string, 0, len(dependsOn))
+	for edge := range dependsOn {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i][0] < edges[j][0] || edges[i][0] == edges[j][0] && edges[i][1] < edges[j][1]
+	})
+	for _, edge := range edges {
+		builder.addRelationship(edge[0], spdxRelationshipDependsOn, edge[1])
+	}
+	return builder.document
+}
+
+// Convert license names into an SPDX license expression, requiring all the licenses.
+// Names which aren't valid SPDX license IDs are converted to license refs. Returns NOASSERTION if there are no known licenses.
+func ToSpdxLicenseExpression(licenseNames []string) string {
+	var ids []string
+	for _, name := range licenseNames {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.EqualFold(name, "unknown") {
+			continue
+		}
+		if !spdxLicenseIdRegexp.MatchString(name) {
+			name = "LicenseRef-" + toSpdxIdString(name)
+		}
+		if !slices.Contains(ids, name) {
+			ids = append(ids, name)
+		}
+	}
+	if len(ids) == 0 {
+		return spdxNoAssertion
+	}
+	return strings.Join(ids, " AND ")
+}
+
+// Split an Xray component ID, such as 'npm://lodash:4.17.21', into its package type and the ID of the package.
+func splitXrayComponentId(componentId string) (buildinfo.ModuleType, string) {
+	prefix, id, found := strings.Cut(componentId, "://")
+	if !found {
+		return "", componentId
+	}
+	return xrayComponentTypes[prefix], id
+}
+
+func createSpdxPackage(id string, moduleType buildinfo.ModuleType, checksum buildinfo.Checksum, license string) SpdxPackage {
+	group, name, version := splitComponentId(id, moduleType)
+	pkg := SpdxPackage{Name: name, VersionInfo: version, LicenseDeclared: license, PrimaryPackagePurpose: "LIBRARY"}
+	if group != "" {
+		pkg.Name = group + ":" + name
+	}
+	for _, spdxChecksum := range []SpdxChecksum{{"SHA1", checksum.Sha1}, {"SHA256", checksum.Sha256}, {"MD5", checksum.Md5}} {
+		if spdxChecksum.ChecksumValue != "" {
+			pkg.Checksums = append(pkg.Checksums, spdxChecksum)
+		}
+	}
+	if purl := createPackageUrl(moduleType, group, name, version); purl != "" {
+		pkg.ExternalRefs = []SpdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: purl}}
+	}
+	return pkg
+}
+
+// Builds an SPDX document, while mapping the refs of the components to unique SPDX IDs.
+type spdxDocumentBuilder struct {
+	document *SpdxDocument
+	spdxIds  map[string]string
+	usedIds  map[string]bool
+}
+
+func newSpdxDocumentBuilder(name string, created time.Time) *spdxDocumentBuilder {
+	return &spdxDocumentBuilder{
+		document: &SpdxDocument{
+			SpdxVersion:       spdxVersion,
+			DataLicense:       spdxDataLicense,
+			SpdxId:            spdxDocumentId,
+			Name:              name,
+			DocumentNamespace: spdxNamespacePrefix + toSpdxIdString(name) + "-" + uuid.New().String(),
+			CreationInfo: SpdxCreationInfo{
+				Created:  created.UTC().Format(spdxTimeFormat),
+				Creators: []string{"Tool: " + coreutils.GetCliUserAgentName() + "-" + coreutils.GetCliUserAgentVersion()},
+			},
+			Packages:      []SpdxPackage{},
+			Relationships: []SpdxRelationship{},
+		},
+		spdxIds: map[string]string{"": spdxDocumentId},
+		usedIds: map[string]bool{spdxDocumentId: true},
+	}
+}
+
+// Add the package of the component ref, unless it was already added. The empty fields of the package are set to NOASSERTION.
+func (b *spdxDocumentBuilder) addPackage(ref string, pkg SpdxPackage) {
+	if _, exists := b.spdxIds[ref]; exists {
+		return
+	}
+	pkg.SpdxId = "SPDXRef-Package-" + toSpdxIdString(ref)
+	for i := 2; b.usedIds[pkg.SpdxId]; i++ {
+		pkg.SpdxId = fmt.Sprintf("SPDXRef-Package-%s-%d", toSpdxIdString(ref), i)
+	}
+	b.spdxIds[ref] = pkg.SpdxId
+	b.usedIds[pkg.SpdxId] = true
+	for _, field := range []*string{&pkg.DownloadLocation, &pkg.LicenseConcluded, &pkg.LicenseDeclared, &pkg.CopyrightText} {
+		if *field == "" {
+			*field = spdxNoAssertion
+		}
+	}
+	b.document.Packages = append(b.document.Packages, pkg)
+}
+
+// Add a relationship between two added component refs. The empty ref stands for the document.
+func (b *spdxDocumentBuilder) addRelationship(ref, relationshipType, relatedRef string) {
+	spdxId, exists := b.spdxIds[ref]
+	relatedSpdxId, relatedExists := b.spdxIds[relatedRef]
+	if !exists || !relatedExists || spdxId == relatedSpdxId {
+		return
+	}
+	relationship := SpdxRelationship{SpdxElementId: spdxId, RelationshipType: relationshipType, RelatedSpdxElement: relatedSpdxId}
+	if !slices.Contains(b.document.Relationships, relationship) {
+		b.document.Relationships = append(b.document.Relationships, relationship)
+	}
+}
+
+func toSpdxIdString(value string) string {
+	return strings.Trim(spdxInvalidIdCharsRegexp.ReplaceAllString(value, "-"), "-")
+}
+
+func getSortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func encodeSpdxJson(document *SpdxDocument) (string, error) {
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return string(content), nil
+}
+
+// Encode the document in the SPDX tag-value format.
+func encodeSpdxTagValue(document *SpdxDocument) string {
+	var builder strings.Builder
+	writeTag := func(tag, value string) {
+		if value != "" {
+			builder.WriteString(tag + ": " + value + "\n")
+		}
+	}
+	writeTag("SPDXVersion", document.SpdxVersion)
+	writeTag("DataLicense", document.DataLicense)
+	writeTag("SPDXID", document.SpdxId)
+	writeTag("DocumentName", document.Name)
+	writeTag("DocumentNamespace", document.DocumentNamespace)
+	for _, creator := range document.CreationInfo.Creators {
+		writeTag("Creator", creator)
+	}
+	writeTag("Created", document.CreationInfo.Created)
+	for _, pkg := range document.Packages {
+		builder.WriteString("\n")
+		writeTag("PackageName", pkg.Name)
+		writeTag("SPDXID", pkg.SpdxId)
+		writeTag("PackageVersion", pkg.VersionInfo)
+		writeTag("PackageDownloadLocation", pkg.DownloadLocation)
+		writeTag("FilesAnalyzed", fmt.Sprint(pkg.FilesAnalyzed))
+		for _, checksum := range pkg.Checksums {
+			writeTag("PackageChecksum", checksum.Algorithm+": "+checksum.ChecksumValue)
+		}
+		writeTag("PackageLicenseConcluded", pkg.LicenseConcluded)
+		writeTag("PackageLicenseDeclared", pkg.LicenseDeclared)
+		writeTag("PackageCopyrightText", pkg.CopyrightText)
+		for _, ref := range pkg.ExternalRefs {
+			writeTag("ExternalRef", ref.ReferenceCategory+" "+ref.ReferenceType+" "+ref.ReferenceLocator)
+		}
+		writeTag("PrimaryPackagePurpose", pkg.PrimaryPackagePurpose)
+	}
+	if len(document.Relationships) > 0 {
+		builder.WriteString("\n")
+	}
+	for _, relationship := range document.Relationships {
+		writeTag("Relationship", relationship.SpdxElementId+" "+relationship.RelationshipType+" "+relationship.RelatedSpdxElement)
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
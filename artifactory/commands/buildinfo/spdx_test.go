@@ -0,0 +1,123 @@
+package buildinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	xrayServices "github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertBuildInfoToSpdx(t *testing.T) {
+	document := ConvertBuildInfoToSpdx(createCycloneDxTestBuildInfo(), map[string]string{"org.example:lib:1.0": "Apache-2.0"})
+	assert.Equal(t, "SPDX-2.3", document.SpdxVersion)
+	assert.Equal(t, "my-build-7", document.Name)
+	assert.Regexp(t, "^https://spdx.org/spdxdocs/my-build-7-", document.DocumentNamespace)
+	assert.Equal(t, "2024-03-01T08:20:30Z", document.CreationInfo.Created)
+
+	require.Len(t, document.Packages, 6)
+	assert.Equal(t, SpdxPackage{
+		Name: "my-build", SpdxId: "SPDXRef-Package-my-build-7", VersionInfo: "7", DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion, LicenseDeclared: spdxNoAssertion, CopyrightText: spdxNoAssertion, PrimaryPackagePurpose: "APPLICATION",
+	}, document.Packages[0])
+	assert.Equal(t, SpdxPackage{
+		Name: "org.example:lib", SpdxId: "SPDXRef-Package-org.example-lib-1.0", VersionInfo: "1.0", DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion, LicenseDeclared: "Apache-2.0", CopyrightText: spdxNoAssertion, PrimaryPackagePurpose: "LIBRARY",
+		ExternalRefs: []SpdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: "pkg:maven/org.example/lib@1.0"}},
+	}, document.Packages[2])
+	assert.Equal(t, []SpdxChecksum{{"SHA1", "app-sha1"}}, document.Packages[1].Checksums)
+
+	assert.Equal(t, []SpdxRelationship{
+		{"SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Package-my-build-7"},
+		{"SPDXRef-Package-my-build-7", "CONTAINS", "SPDXRef-Package-org.example-app-1.0"},
+		{"SPDXRef-Package-my-build-7", "CONTAINS", "SPDXRef-Package-org.example-lib-1.0"},
+		{"SPDXRef-Package-my-build-7", "CONTAINS", "SPDXRef-Package-web"},
+		{"SPDXRef-Package-org.example-app-1.0", "DEPENDS_ON", "SPDXRef-Package-org.example-lib-1.0"},
+		{"SPDXRef-Package-org.example-lib-1.0", "DEPENDS_ON", "SPDXRef-Package-com.google.guava-guava-33.0.0"},
+		{"SPDXRef-Package-web", "DEPENDS_ON", "SPDXRef-Package-types-node-20.1.0"},
+	}, document.Relationships)
+}
+
+func TestConvertScanResultsToSpdx(t *testing.T) {
+	impactPath := [][]xrayServices.ImpactPathNode{{{ComponentId: "npm://web:1.0.0"}, {ComponentId: "npm://express:4.18.2"}, {ComponentId: "npm://qs:6.11.0"}}}
+	results := []xrayServices.ScanResponse{{
+		ScannedComponentId: "npm://web:1.0.0",
+		Vulnerabilities:    []xrayServices.Vulnerability{{IssueId: "XRAY-1", Components: map[string]xrayServices.Component{"npm://qs:6.11.0": {ImpactPaths: impactPath}}}},
+		Licenses: []xrayServices.License{
+			{Key: "MIT", Components: map[string]xrayServices.Component{"npm://qs:6.11.0": {ImpactPaths: impactPath}}},
+			{Key: "BSD-3-Clause", Components: map[string]xrayServices.Component{"npm://qs:6.11.0": {ImpactPaths: impactPath}}},
+		},
+	}}
+	document := ConvertScanResultsToSpdx("audit-results", results)
+	require.Len(t, document.Packages, 3)
+	assert.Equal(t, "express", document.Packages[0].Name)
+	assert.Equal(t, "qs", document.Packages[1].Name)
+	assert.Equal(t, "6.11.0", document.Packages[1].VersionInfo)
+	assert.Equal(t, "MIT AND BSD-3-Clause", document.Packages[1].LicenseDeclared)
+	assert.Equal(t, "pkg:npm/qs@6.11.0", document.Packages[1].ExternalRefs[0].ReferenceLocator)
+	assert.Equal(t, []SpdxRelationship{
+		{"SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Package-npm-web-1.0.0"},
+		{"SPDXRef-Package-npm-express-4.18.2", "DEPENDS_ON", "SPDXRef-Package-npm-qs-6.11.0"},
+		{"SPDXRef-Package-npm-web-1.0.0", "DEPENDS_ON", "SPDXRef-Package-npm-express-4.18.2"},
+	}, document.Relationships)
+}
+
+func TestToSpdxLicenseExpression(t *testing.T) {
+	tests := []struct {
+		names    []string
+		expected string
+	}{
+		{nil, "NOASSERTION"},
+		{[]string{"Unknown", ""}, "NOASSERTION"},
+		{[]string{"MIT"}, "MIT"},
+		{[]string{"MIT", "Apache-2.0", "MIT"}, "MIT AND Apache-2.0"},
+		{[]string{"GPL-2.0+"}, "GPL-2.0+"},
+		{[]string{"My Company License"}, "LicenseRef-My-Company-License"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.names, ","), func(t *testing.T) {
+			assert.Equal(t, test.expected, ToSpdxLicenseExpression(test.names))
+		})
+	}
+}
+
+func TestGetLicensesByIds(t *testing.T) {
+	summary := &xrayServices.ArtifactSummaryResponse{Artifacts: []xrayServices.Artifact{
+		{General: xrayServices.General{Sha256: "sha-a"}, Licenses: []xrayServices.SummaryLicense{{Name: "MIT"}, {Name: "ISC"}}},
+		{General: xrayServices.General{Sha256: "sha-b"}, Licenses: []xrayServices.SummaryLicense{{Name: "Unknown"}}},
+	}}
+	licenses := getLicensesByIds(summary, map[string][]string{"sha-a": {"a:1.0", "a-copy:1.0"}, "sha-b": {"b:1.0"}})
+	assert.Equal(t, map[string]string{"a:1.0": "MIT AND ISC", "a-copy:1.0": "MIT AND ISC", "b:1.0": "NOASSERTION"}, licenses)
+}
+
+func TestEncodeSpdx(t *testing.T) {
+	document := ConvertBuildInfoToSpdx(createCycloneDxTestBuildInfo(), nil)
+	tagValue := encodeSpdxTagValue(document)
+	assert.True(t, strings.HasPrefix(tagValue, "SPDXVersion: SPDX-2.3\nDataLicense: CC0-1.0\nSPDXID: SPDXRef-DOCUMENT\nDocumentName: my-build-7\n"))
+	assert.Contains(t, tagValue, "\nPackageName: org.example:app\nSPDXID: SPDXRef-Package-org.example-app-1.0\nPackageVersion: 1.0\nPackageDownloadLocation: NOASSERTION\nFilesAnalyzed: false\nPackageChecksum: SHA1: app-sha1\n")
+	assert.Contains(t, tagValue, "\nExternalRef: PACKAGE-MANAGER purl pkg:maven/org.example/app@1.0\n")
+	assert.Contains(t, tagValue, "\nRelationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-my-build-7\n")
+
+	content, err := encodeSpdxJson(document)
+	assert.NoError(t, err)
+	assert.Contains(t, content, `"spdxVersion": "SPDX-2.3"`)
+	assert.Contains(t, content, `"filesAnalyzed": false`)
+}
+
+func TestReadScanResults(t *testing.T) {
+	tempDir := t.TempDir()
+	resultsFile := filepath.Join(tempDir, "results.json")
+	assert.NoError(t, os.WriteFile(resultsFile, []byte(`[{"component_id":"npm://web:1.0.0","licenses":[{"license_key":"MIT","components":{"npm://web:1.0.0":{}}}]}]`), 0644))
+	results, err := readScanResults(resultsFile)
+	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "npm://web:1.0.0", results[0].ScannedComponentId)
+
+	invalidFile := filepath.Join(tempDir, "invalid.json")
+	assert.NoError(t, os.WriteFile(invalidFile, []byte(`{"component_id":`), 0644))
+	_, err = readScanResults(invalidFile)
+	assert.ErrorContains(t, err, "failed to parse the scan results file")
+}
@@ -0,0 +1,213 @@
+package cargo
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/cargo"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The type of the Cargo modules in the build-info.
+const CargoModuleType buildinfo.ModuleType = "cargo"
+
+// The cargo commands which resolve the dependencies of the workspace, and therefore update Cargo.lock.
+var resolvingCommands = []string{"build", "b", "check", "c", "test", "t", "run", "r", "fetch", "generate-lockfile", "update", "install", "doc", "d", "bench", "clippy"}
+
+// Runs cargo commands, such as 'cargo build' and 'cargo fetch', with the crates resolved from an Artifactory Cargo repository instead of crates.io.
+// The build-info of the resolving commands is collected from Cargo.lock, with a module for each member of the workspace.
+type CargoCommand struct {
+	cmdName            string
+	cargoArgs          []string
+	configFilePath     string
+	repo               string
+	executablePath     string
+	workingDirectory   string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *buildUtils.BuildConfiguration
+}
+
+func NewCargoCommand(cmdName string) *CargoCommand {
+	return &CargoCommand{cmdName: cmdName}
+}
+
+func (cc *CargoCommand) CommandName() string {
+	return "rt_cargo_" + cc.cmdName
+}
+
+func (cc *CargoCommand) SetConfigFilePath(configFilePath string) *CargoCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *CargoCommand) SetArgs(args []string) *CargoCommand {
+	cc.cargoArgs = args
+	return cc
+}
+
+func (cc *CargoCommand) SetServerDetails(serverDetails *config.ServerDetails) *CargoCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *CargoCommand) SetRepo(repo string) *CargoCommand {
+	cc.repo = repo
+	return cc
+}
+
+func (cc *CargoCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *CargoCommand {
+	cc.buildConfiguration = buildConfiguration
+	return cc
+}
+
+func (cc *CargoCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+// Read the resolver of the config file, and extract the JFrog CLI flags from the cargo arguments.
+func (cc *CargoCommand) Init() error {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := resolverParams.ServerDetails()
+	if err != nil {
+		return err
+	}
+	filteredArgs, buildConfiguration, err := buildUtils.ExtractBuildDetailsFromArgs(cc.cargoArgs)
+	if err != nil {
+		return err
+	}
+	cc.SetRepo(resolverParams.TargetRepo()).SetServerDetails(serverDetails).SetArgs(filteredArgs).SetBuildConfiguration(buildConfiguration)
+	return nil
+}
+
+func (cc *CargoCommand) Run() (err error) {
+	log.Info("Running cargo " + cc.cmdName + "...")
+	if cc.executablePath, err = cargo.GetExecutablePath(); err != nil {
+		return
+	}
+	if cc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return
+	}
+	log.Debug("Working directory set to:", cc.workingDirectory)
+	if err = runCargo(cc.executablePath, cc.workingDirectory, cc.cmdName, cc.cargoArgs, cc.serverDetails, cc.repo); err != nil {
+		return
+	}
+	if err = cc.collectBuildInfo(); err != nil {
+		return
+	}
+	log.Info("cargo " + cc.cmdName + " finished successfully.")
+	return
+}
+
+// Run a cargo command with the Artifactory repository configured as a registry, which replaces crates.io.
+// The configuration is passed to cargo by arguments and environment variables, so the Cargo configuration files of the user aren't modified.
+func runCargo(executablePath, workingDirectory, cmdName string, args []string, serverDetails *config.ServerDetails, repo string) error {
+	authArtDetails, err := serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return err
+	}
+	if err = utils.ValidateRepoExists(repo, authArtDetails); err != nil {
+		return err
+	}
+	cargoArgs := cargo.GetRegistryConfigArgs(cargo.GetRegistryIndexUrl(serverDetails.ArtifactoryUrl, repo))
+	cargoArgs = append(append(cargoArgs, cmdName), args...)
+	command := exec.Command(executablePath, cargoArgs...)
+	command.Dir = workingDirectory
+	command.Env = os.Environ()
+	if token := cargo.GetRegistryToken(serverDetails); token != "" {
+		command.Env = append(command.Env, cargo.RegistryTokenEnv+"="+token)
+	}
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	return errorutils.CheckError(command.Run())
+}
+
+func (cc *CargoCommand) collectBuildInfo() error {
+	toCollect, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	if !slices.Contains(resolvingCommands, cc.cmdName) {
+		log.Info("Build-info is collected by the resolving commands of cargo only. Build-info creation is skipped.")
+		return nil
+	}
+	buildName, err := cc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := cc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := cc.buildConfiguration.GetProject()
+	if err = buildUtils.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	modules, err := cc.createModules()
+	if err != nil {
+		return err
+	}
+	return buildUtils.SaveBuildInfo(buildName, buildNumber, projectKey, &buildinfo.BuildInfo{Modules: modules})
+}
+
+// Create a module for each member of the workspace. When the workspace has a single member, its module may be renamed by the --module flag.
+func (cc *CargoCommand) createModules() ([]buildinfo.Module, error) {
+	metadata, err := cargo.GetMetadata(cc.executablePath, cc.workingDirectory)
+	if err != nil {
+		return nil, err
+	}
+	lockfile, err := cargo.ReadLockfile(metadata.WorkspaceRoot)
+	if err != nil {
+		return nil, err
+	}
+	members := metadata.GetWorkspaceMembers()
+	var modules []buildinfo.Module
+	var missingChecksums []string
+	for _, member := range members {
+		moduleId := member.Name + ":" + member.Version
+		if len(members) == 1 && cc.buildConfiguration.GetModule() != "" {
+			moduleId = cc.buildConfiguration.GetModule()
+		}
+		dependencies, err := lockfile.GetDependencies(member.Name, member.Version, moduleId)
+		if err != nil {
+			return nil, err
+		}
+		for i := range dependencies {
+			separator := strings.LastIndex(dependencies[i].Id, ":")
+			details, err := cargo.GetCachedCrateDetails(dependencies[i].Id[:separator], dependencies[i].Id[separator+1:], dependencies[i].Sha256)
+			if err != nil {
+				return nil, err
+			}
+			if details == nil {
+				missingChecksums = append(missingChecksums, dependencies[i].Id)
+				continue
+			}
+			dependencies[i].Checksum = details.Checksum
+		}
+		modules = append(modules, buildinfo.Module{Id: moduleId, Type: CargoModuleType, Dependencies: dependencies})
+	}
+	if len(missingChecksums) > 0 {
+		slices.Sort(missingChecksums)
+		log.Warn(strings.Join(slices.Compact(missingChecksums), "\n"), "\nThe crates above weren't found in the cache of cargo under '"+filepath.Join("registry", "cache")+"', "+
+			"so only their SHA-256 checksums from "+cargo.LockfileName+" are included in the build-info.")
+	}
+	return modules, nil
+}
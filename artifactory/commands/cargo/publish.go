@@ -0,0 +1,210 @@
+package cargo
+
+import (
+	"path"
+	"path/filepath"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/cargo"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Publishes a crate of the workspace to an Artifactory Cargo repository by 'cargo publish'.
+// When build-info is collected, the published crate is recorded as an artifact of the build, and the build properties are set on it.
+type CargoPublishCommand struct {
+	cargoArgs          []string
+	configFilePath     string
+	repo               string
+	executablePath     string
+	workingDirectory   string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *buildUtils.BuildConfiguration
+}
+
+func NewCargoPublishCommand() *CargoPublishCommand {
+	return &CargoPublishCommand{}
+}
+
+func (cpc *CargoPublishCommand) CommandName() string {
+	return "rt_cargo_publish"
+}
+
+func (cpc *CargoPublishCommand) SetConfigFilePath(configFilePath string) *CargoPublishCommand {
+	cpc.configFilePath = configFilePath
+	return cpc
+}
+
+func (cpc *CargoPublishCommand) SetArgs(args []string) *CargoPublishCommand {
+	cpc.cargoArgs = args
+	return cpc
+}
+
+func (cpc *CargoPublishCommand) SetServerDetails(serverDetails *config.ServerDetails) *CargoPublishCommand {
+	cpc.serverDetails = serverDetails
+	return cpc
+}
+
+func (cpc *CargoPublishCommand) SetRepo(repo string) *CargoPublishCommand {
+	cpc.repo = repo
+	return cpc
+}
+
+func (cpc *CargoPublishCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *CargoPublishCommand {
+	cpc.buildConfiguration = buildConfiguration
+	return cpc
+}
+
+func (cpc *CargoPublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cpc.serverDetails, nil
+}
+
+// Read the deployer of the config file, and extract the JFrog CLI flags from the cargo arguments.
+func (cpc *CargoPublishCommand) Init() error {
+	log.Debug("Preparing to read the config file", cpc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cpc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	deployerParams, err := project.GetRepoConfigByPrefix(cpc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := deployerParams.ServerDetails()
+	if err != nil {
+		return err
+	}
+	filteredArgs, buildConfiguration, err := buildUtils.ExtractBuildDetailsFromArgs(cpc.cargoArgs)
+	if err != nil {
+		return err
+	}
+	cpc.SetRepo(deployerParams.TargetRepo()).SetServerDetails(serverDetails).SetArgs(filteredArgs).SetBuildConfiguration(buildConfiguration)
+	return nil
+}
+
+func (cpc *CargoPublishCommand) Run() (err error) {
+	log.Info("Running cargo publish...")
+	if cpc.executablePath, err = cargo.GetExecutablePath(); err != nil {
+		return
+	}
+	if cpc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return
+	}
+	metadata, err := cargo.GetMetadata(cpc.executablePath, cpc.workingDirectory)
+	if err != nil {
+		return
+	}
+	packageName, err := getPackageFlag(cpc.cargoArgs)
+	if err != nil {
+		return
+	}
+	member, err := metadata.GetWorkspaceMember(packageName, cpc.workingDirectory)
+	if err != nil {
+		return
+	}
+	if err = runCargo(cpc.executablePath, cpc.workingDirectory, "publish", append(cpc.cargoArgs, "--registry", cargo.RegistryName), cpc.serverDetails, cpc.repo); err != nil {
+		return
+	}
+	if err = cpc.collectBuildInfo(member, filepath.Join(metadata.TargetDirectory, "package", crateFileName(member))); err != nil {
+		return
+	}
+	log.Info("cargo publish finished successfully.")
+	return
+}
+
+func getPackageFlag(args []string) (string, error) {
+	for _, flag := range []string{"--package", "-p"} {
+		_, _, value, err := coreutils.FindFlag(flag, args)
+		if err != nil || value != "" {
+			return value, err
+		}
+	}
+	return "", nil
+}
+
+func crateFileName(member *cargo.MetadataPackage) string {
+	return member.Name + "-" + member.Version + ".crate"
+}
+
+// Record the published crate, which 'cargo publish' packages in the target directory, as an artifact of the build.
+func (cpc *CargoPublishCommand) collectBuildInfo(member *cargo.MetadataPackage, cratePath string) error {
+	toCollect, err := cpc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	buildName, err := cpc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := cpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := cpc.buildConfiguration.GetProject()
+	if err = buildUtils.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	details, err := fileutils.GetFileDetails(cratePath, true)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(cpc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	artifactPath, err := setBuildProperties(servicesManager, cpc.repo, crateFileName(member), buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	moduleId := cpc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = member.Name + ":" + member.Version
+	}
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
+		Id:   moduleId,
+		Type: CargoModuleType,
+		Artifacts: []buildinfo.Artifact{{
+			Name:                   crateFileName(member),
+			Type:                   cargo.CrateType,
+			Checksum:               details.Checksum,
+			Path:                   artifactPath,
+			OriginalDeploymentRepo: cpc.repo,
+		}},
+	}}}
+	return buildUtils.SaveBuildInfo(buildName, buildNumber, projectKey, buildInfo)
+}
+
+// Set the build properties on the published crate, and return its path in the repository.
+// The path of the crate is determined by the layout of the repository, so the crate is searched by its file name.
+func setBuildProperties(servicesManager artifactory.ArtifactoryServicesManager, repo, crateFileName, buildName, buildNumber, projectKey string) (artifactPath string, err error) {
+	props, err := buildUtils.CreateBuildProperties(buildName, buildNumber, projectKey)
+	if err != nil {
+		return
+	}
+	searchParams := services.NewSearchParams()
+	searchParams.CommonParams = &servicesutils.CommonParams{Pattern: repo + "/*/" + crateFileName}
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer ioutils.Close(reader, &err)
+	resultItem := new(servicesutils.ResultItem)
+	if err = reader.NextRecord(resultItem); err != nil {
+		return "", errorutils.CheckErrorf("couldn't find the published crate %s in the repository %s", crateFileName, repo)
+	}
+	reader.Reset()
+	if _, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props}); err != nil {
+		return
+	}
+	return path.Join(resultItem.Path, resultItem.Name), nil
+}
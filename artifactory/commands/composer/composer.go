@@ -0,0 +1,243 @@
+package composer
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/composer"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	globalConfigBackupFileName = "jfrog.config.json.backup"
+	// The type of the Composer modules in the build-info.
+	ComposerModuleType buildinfo.ModuleType = "composer"
+)
+
+// The composer commands which install packages, and therefore update composer.lock and the cache of composer.
+var installCommands = []string{"install", "i", "update", "u", "upgrade", "require", "r", "remove", "rm"}
+
+// Runs composer commands, such as 'composer install' and 'composer audit', with the packages resolved from an Artifactory Composer repository,
+// which replaces packagist.org. The build-info of the installing commands is collected from composer.lock.
+type ComposerCommand struct {
+	cmdName            string
+	composerArgs       []string
+	configFilePath     string
+	repo               string
+	executablePath     string
+	workingDirectory   string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *buildUtils.BuildConfiguration
+}
+
+func NewComposerCommand(cmdName string) *ComposerCommand {
+	return &ComposerCommand{cmdName: cmdName}
+}
+
+func (cc *ComposerCommand) CommandName() string {
+	return "rt_composer_" + cc.cmdName
+}
+
+func (cc *ComposerCommand) SetConfigFilePath(configFilePath string) *ComposerCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *ComposerCommand) SetArgs(args []string) *ComposerCommand {
+	cc.composerArgs = args
+	return cc
+}
+
+func (cc *ComposerCommand) SetServerDetails(serverDetails *config.ServerDetails) *ComposerCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *ComposerCommand) SetRepo(repo string) *ComposerCommand {
+	cc.repo = repo
+	return cc
+}
+
+func (cc *ComposerCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *ComposerCommand {
+	cc.buildConfiguration = buildConfiguration
+	return cc
+}
+
+func (cc *ComposerCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+// Read the resolver of the config file, and extract the JFrog CLI flags from the composer arguments.
+func (cc *ComposerCommand) Init() error {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := resolverParams.ServerDetails()
+	if err != nil {
+		return err
+	}
+	filteredArgs, buildConfiguration, err := buildUtils.ExtractBuildDetailsFromArgs(cc.composerArgs)
+	if err != nil {
+		return err
+	}
+	cc.SetRepo(resolverParams.TargetRepo()).SetServerDetails(serverDetails).SetArgs(filteredArgs).SetBuildConfiguration(buildConfiguration)
+	return nil
+}
+
+func (cc *ComposerCommand) Run() (err error) {
+	log.Info("Running composer " + cc.cmdName + "...")
+	if cc.executablePath, err = composer.GetExecutablePath(); err != nil {
+		return
+	}
+	if cc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return
+	}
+	log.Debug("Working directory set to:", cc.workingDirectory)
+	authArtDetails, err := cc.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return
+	}
+	if err = utils.ValidateRepoExists(cc.repo, authArtDetails); err != nil {
+		return
+	}
+	repositoryUrl := composer.GetRepositoryUrl(cc.serverDetails.ArtifactoryUrl, cc.repo)
+	restoreGlobalConfigFunc, err := cc.configureRepository(repositoryUrl)
+	if restoreGlobalConfigFunc != nil {
+		defer func() {
+			err = errors.Join(err, restoreGlobalConfigFunc())
+		}()
+	}
+	if err != nil {
+		return
+	}
+	if err = cc.runComposer(repositoryUrl); err != nil {
+		return
+	}
+	if err = cc.collectBuildInfo(); err != nil {
+		return
+	}
+	log.Info("composer " + cc.cmdName + " finished successfully.")
+	return
+}
+
+// Set the repository as the replacement of packagist.org in the global configuration of Composer.
+// The repositories of composer.json are part of the content hash of composer.lock, so composer.json isn't modified.
+// The global configuration file is restored when the command ends.
+func (cc *ComposerCommand) configureRepository(repositoryUrl string) (restoreFunc func() error, err error) {
+	composerHome, err := composer.RunConfig(cc.executablePath, "--global", "home")
+	if err != nil {
+		return
+	}
+	if restoreFunc, err = ioutils.BackupFile(filepath.Join(composerHome, composer.GlobalConfigFileName), globalConfigBackupFileName); err != nil {
+		return
+	}
+	log.Debug("Setting the packagist repository of composer to", repositoryUrl)
+	return restoreFunc, composer.SetPackagistRepository(cc.executablePath, repositoryUrl)
+}
+
+// The credentials of the repository are passed by the COMPOSER_AUTH environment variable, so they aren't stored in the auth.json files of the user.
+func (cc *ComposerCommand) runComposer(repositoryUrl string) error {
+	authEnvValue, err := composer.GetAuthEnvValue(cc.serverDetails, repositoryUrl)
+	if err != nil {
+		return err
+	}
+	command := exec.Command(cc.executablePath, append([]string{cc.cmdName}, cc.composerArgs...)...)
+	command.Dir = cc.workingDirectory
+	command.Env = os.Environ()
+	if authEnvValue != "" {
+		command.Env = append(command.Env, composer.AuthEnv+"="+authEnvValue)
+	}
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	return errorutils.CheckError(command.Run())
+}
+
+func (cc *ComposerCommand) collectBuildInfo() error {
+	toCollect, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	if !slices.Contains(installCommands, cc.cmdName) {
+		log.Info("Build-info is collected by the installing commands of composer only. Build-info creation is skipped.")
+		return nil
+	}
+	buildName, err := cc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := cc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := cc.buildConfiguration.GetProject()
+	if err = buildUtils.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	manifest, err := composer.ReadManifest(cc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	lockfile, err := composer.ReadLockfile(cc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	moduleId := cc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = manifest.GetModuleId(cc.workingDirectory)
+	}
+	dependencies, err := cc.setDependenciesChecksums(lockfile, lockfile.GetDependencies(manifest, moduleId))
+	if err != nil {
+		return err
+	}
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{Id: moduleId, Type: ComposerModuleType, Dependencies: dependencies}}}
+	return buildUtils.SaveBuildInfo(buildName, buildNumber, projectKey, buildInfo)
+}
+
+// The module ID is the name of the package, with its version if composer.json sets it, or the name of the project directory.
+// Set the checksums of the dependencies from the archives composer cached while installing them.
+// Dependencies whose archives aren't cached, such as packages installed from source, are removed from the build-info.
+func (cc *ComposerCommand) setDependenciesChecksums(lockfile *composer.Lockfile, dependencies []buildinfo.Dependency) ([]buildinfo.Dependency, error) {
+	cacheFilesDir, err := composer.RunConfig(cc.executablePath, "cache-files-dir")
+	if err != nil {
+		return nil, err
+	}
+	var missingDependencies []string
+	for i := range dependencies {
+		composerPackage, _ := lockfile.GetPackage(dependencies[i].Id[:strings.LastIndex(dependencies[i].Id, ":")])
+		details, err := composer.GetCachedDistDetails(cacheFilesDir, composerPackage)
+		if err != nil {
+			return nil, err
+		}
+		if details == nil {
+			missingDependencies = append(missingDependencies, dependencies[i].Id)
+			continue
+		}
+		dependencies[i].Checksum = details.Checksum
+	}
+	if len(missingDependencies) > 0 {
+		log.Warn(strings.Join(missingDependencies, "\n"), "\nThe archives of the composer packages above were not found in the cache of composer and therefore are not included in the build-info.\n"+
+			"Running 'composer clear-cache' before installing will force downloading the packages from Artifactory.")
+	}
+	return slices.DeleteFunc(dependencies, func(dependency buildinfo.Dependency) bool {
+		return dependency.Checksum.IsEmpty()
+	}), nil
+}
@@ -0,0 +1,241 @@
+package conda
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/conda"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The type of the Conda modules and dependencies in the build-info.
+	CondaModuleType  buildinfo.ModuleType = "conda"
+	condaPackageType                      = "conda"
+)
+
+// The conda commands which install packages to an environment.
+var installCommands = []string{"install", "create", "update", "upgrade"}
+
+// The arguments which select the environment of the command.
+var envFlags = [][]string{{"--name", "-n"}, {"--prefix", "-p"}}
+
+// Runs conda commands, such as 'conda install' and 'conda create', with the channels resolved from an Artifactory Conda repository.
+// Both the named channels, such as 'conda-forge', and the 'defaults' channel are resolved from the repository.
+// The build-info of the installing commands is collected from the export of the environment, with its conda packages as the dependencies.
+type CondaCommand struct {
+	cmdName            string
+	condaArgs          []string
+	configFilePath     string
+	repo               string
+	executablePath     string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *buildUtils.BuildConfiguration
+}
+
+func NewCondaCommand(cmdName string) *CondaCommand {
+	return &CondaCommand{cmdName: cmdName}
+}
+
+func (cc *CondaCommand) CommandName() string {
+	return "rt_conda_" + cc.cmdName
+}
+
+func (cc *CondaCommand) SetConfigFilePath(configFilePath string) *CondaCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *CondaCommand) SetArgs(args []string) *CondaCommand {
+	cc.condaArgs = args
+	return cc
+}
+
+func (cc *CondaCommand) SetServerDetails(serverDetails *config.ServerDetails) *CondaCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *CondaCommand) SetRepo(repo string) *CondaCommand {
+	cc.repo = repo
+	return cc
+}
+
+func (cc *CondaCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *CondaCommand {
+	cc.buildConfiguration = buildConfiguration
+	return cc
+}
+
+func (cc *CondaCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+// Read the resolver of the config file, and extract the JFrog CLI flags from the conda arguments.
+func (cc *CondaCommand) Init() error {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := resolverParams.ServerDetails()
+	if err != nil {
+		return err
+	}
+	filteredArgs, buildConfiguration, err := buildUtils.ExtractBuildDetailsFromArgs(cc.condaArgs)
+	if err != nil {
+		return err
+	}
+	cc.SetRepo(resolverParams.TargetRepo()).SetServerDetails(serverDetails).SetArgs(filteredArgs).SetBuildConfiguration(buildConfiguration)
+	return nil
+}
+
+func (cc *CondaCommand) Run() (err error) {
+	log.Info("Running conda " + cc.cmdName + "...")
+	if cc.executablePath, err = conda.GetExecutablePath(); err != nil {
+		return
+	}
+	authArtDetails, err := cc.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return
+	}
+	if err = utils.ValidateRepoExists(cc.repo, authArtDetails); err != nil {
+		return
+	}
+	if err = cc.runConda(); err != nil {
+		return
+	}
+	if err = cc.collectBuildInfo(); err != nil {
+		return
+	}
+	log.Info("conda " + cc.cmdName + " finished successfully.")
+	return
+}
+
+// The channels are configured by environment variables, which override the .condarc files without modifying them.
+func (cc *CondaCommand) runConda() error {
+	channelAlias, err := conda.GetChannelAlias(cc.serverDetails, cc.repo)
+	if err != nil {
+		return err
+	}
+	command := exec.Command(cc.executablePath, append([]string{cc.cmdName}, cc.condaArgs...)...)
+	command.Env = os.Environ()
+	for key, value := range conda.GetChannelsEnv(channelAlias) {
+		command.Env = append(command.Env, key+"="+value)
+	}
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	return errorutils.CheckError(command.Run())
+}
+
+func (cc *CondaCommand) collectBuildInfo() error {
+	toCollect, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	if !slices.Contains(installCommands, cc.cmdName) {
+		log.Info("Build-info is collected by the installing commands of conda only. Build-info creation is skipped.")
+		return nil
+	}
+	buildName, err := cc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := cc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := cc.buildConfiguration.GetProject()
+	if err = buildUtils.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	envArgs, err := getEnvArgs(cc.condaArgs)
+	if err != nil {
+		return err
+	}
+	envExport, err := conda.ExportEnv(cc.executablePath, envArgs)
+	if err != nil {
+		return err
+	}
+	moduleId := cc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = getModuleId(envExport)
+	}
+	servicesManager, err := utils.CreateServiceManager(cc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	dependencies, err := cc.createDependencies(servicesManager, envExport.GetPackages(), moduleId)
+	if err != nil {
+		return err
+	}
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{Id: moduleId, Type: CondaModuleType, Dependencies: dependencies}}}
+	return buildUtils.SaveBuildInfo(buildName, buildNumber, projectKey, buildInfo)
+}
+
+// Returns the arguments selecting the environment the command ran in, so that the same environment is exported.
+func getEnvArgs(args []string) ([]string, error) {
+	for _, flags := range envFlags {
+		for _, flag := range flags {
+			_, _, value, err := coreutils.FindFlag(flag, args)
+			if err != nil {
+				return nil, err
+			}
+			if value != "" {
+				return []string{flags[0], value}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// The module is named after the environment, or after the directory of the environment if it was created by a prefix.
+func getModuleId(envExport *conda.EnvExport) string {
+	if envExport.Name != "" {
+		return envExport.Name
+	}
+	return filepath.Base(envExport.Prefix)
+}
+
+// Create the dependencies of the module, with their checksums from Artifactory.
+// The environment holds the installed packages without the relations between them, so all the packages are recorded as requested by the module.
+// Packages which can't be found in Artifactory aren't included in the build-info.
+func (cc *CondaCommand) createDependencies(servicesManager artifactory.ArtifactoryServicesManager, packages []conda.Package, moduleId string) ([]buildinfo.Dependency, error) {
+	repo, err := utils.GetRepoNameForDependenciesSearch(cc.repo, servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []buildinfo.Dependency
+	var missingPackages []string
+	for _, condaPackage := range packages {
+		checksum, err := conda.GetDependencyChecksum(servicesManager, repo, condaPackage.DistName())
+		if err != nil {
+			return nil, err
+		}
+		if checksum.IsEmpty() {
+			missingPackages = append(missingPackages, condaPackage.DistName())
+			continue
+		}
+		dependencies = append(dependencies, buildinfo.Dependency{Id: condaPackage.Id(), Type: condaPackageType, Checksum: checksum, RequestedBy: [][]string{{moduleId}}})
+	}
+	if len(missingPackages) > 0 {
+		log.Warn(strings.Join(missingPackages, "\n"), "\nThe conda packages above could not be found in Artifactory and therefore are not included in the build-info.\n"+
+			"Clearing the package cache of conda by 'conda clean --packages --tarballs' will force populating Artifactory with these packages.")
+	}
+	return dependencies, nil
+}
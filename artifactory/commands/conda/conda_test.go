@@ -0,0 +1,31 @@
+package conda
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/conda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEnvArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"numpy", "-n", "data-science"}, []string{"--name", "data-science"}},
+		{[]string{"--name=data-science", "numpy"}, []string{"--name", "data-science"}},
+		{[]string{"-p", "/opt/envs/data-science", "numpy"}, []string{"--prefix", "/opt/envs/data-science"}},
+		{[]string{"numpy", "-y"}, nil},
+	}
+	for _, test := range tests {
+		envArgs, err := getEnvArgs(test.args)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, envArgs)
+	}
+}
+
+func TestGetModuleId(t *testing.T) {
+	assert.Equal(t, "data-science", getModuleId(&conda.EnvExport{Name: "data-science", Prefix: "/opt/conda/envs/data-science"}))
+	assert.Equal(t, "my-env", getModuleId(&conda.EnvExport{Prefix: "/home/user/project/my-env"}))
+}
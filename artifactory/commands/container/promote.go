@@ -1,9 +1,20 @@
 package container
 
 import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type DockerPromoteCommand struct {
@@ -21,8 +32,136 @@ func (dp *DockerPromoteCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	// Multi-platform images are promoted as a unit, by promoting their platform images before the tag.
+	platformImagesParams, err := dp.promotePlatformImages(servicesManager)
+	if err != nil {
+		return err
+	}
 	// Promote docker
-	return servicesManager.PromoteDocker(dp.params)
+	if err = servicesManager.PromoteDocker(dp.params); err != nil {
+		return errors.Join(err, dp.rollbackPlatformImages(servicesManager, platformImagesParams))
+	}
+	return nil
+}
+
+// If the promoted tag is a multi-platform image, copy or move its platform images to the target repository, as the tag is promoted.
+// The platform images are stored by their manifest digests, next to the tag, and aren't promoted with it.
+// Platform images which are already stored in the target repository are skipped, since a digest identifies their content.
+// Returns the params of the promoted platform images, so that they can be rolled back if the tag fails to be promoted.
+func (dp *DockerPromoteCommand) promotePlatformImages(servicesManager artifactory.ArtifactoryServicesManager) ([]services.MoveCopyParams, error) {
+	if dp.params.SourceTag == "" {
+		return nil, nil
+	}
+	fatManifest, err := container.GetFatManifest(servicesManager, dp.params.SourceRepo, dp.params.SourceDockerImage, dp.params.SourceTag)
+	if err != nil || fatManifest == nil {
+		return nil, err
+	}
+	var manifests []container.ManifestDetails
+	for _, manifest := range fatManifest.Manifests {
+		stored, err := container.IsImageDigestStored(servicesManager, dp.params.TargetRepo, dp.getTargetDockerImage(), manifest.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if !stored {
+			manifests = append(manifests, manifest)
+		}
+	}
+	if len(manifests) == 0 {
+		return nil, nil
+	}
+	log.Info(fmt.Sprintf("The image tag '%s' is a multi-platform image. %s its %d platform images to '%s'...", dp.params.SourceTag, dp.getActionName(), len(manifests), dp.params.TargetRepo))
+	params := dp.createPlatformImagesParams(manifests)
+	var failed int
+	if dp.params.Copy {
+		_, failed, err = servicesManager.Copy(params...)
+	} else {
+		_, failed, err = servicesManager.Move(params...)
+	}
+	if err == nil && failed > 0 {
+		err = errorutils.CheckErrorf("failed to promote %d files of the platform images of '%s:%s'. The image tag wasn't promoted", failed, dp.params.SourceDockerImage, dp.params.SourceTag)
+	}
+	if err != nil {
+		return nil, errors.Join(err, dp.rollbackPlatformImages(servicesManager, params))
+	}
+	return params, nil
+}
+
+// Roll back the promoted platform images, by deleting their copies from the target repository,
+// or by moving them back to the source repository.
+func (dp *DockerPromoteCommand) rollbackPlatformImages(servicesManager artifactory.ArtifactoryServicesManager, params []services.MoveCopyParams) error {
+	if len(params) == 0 {
+		return nil
+	}
+	log.Info(fmt.Sprintf("Rolling back the promotion of the platform images of '%s:%s'...", dp.params.SourceDockerImage, dp.params.SourceTag))
+	if dp.params.Copy {
+		var err error
+		for _, copyParams := range params {
+			err = errors.Join(err, deletePlatformImage(servicesManager, copyParams.Target))
+		}
+		return err
+	}
+	_, failed, err := servicesManager.Move(dp.createPlatformImagesRollbackParams(params)...)
+	if err == nil && failed > 0 {
+		err = errorutils.CheckErrorf("failed to move %d files of the platform images back to '%s'", failed, dp.params.SourceRepo)
+	}
+	return err
+}
+
+func deletePlatformImage(servicesManager artifactory.ArtifactoryServicesManager, imageFolder string) (err error) {
+	deleteParams := services.NewDeleteParams()
+	deleteParams.Pattern = imageFolder
+	deleteParams.Recursive = true
+	reader, err := servicesManager.GetPathsToDelete(deleteParams)
+	if err != nil {
+		return err
+	}
+	defer ioutils.Close(reader, &err)
+	_, err = servicesManager.DeleteFiles(reader)
+	return
+}
+
+func (dp *DockerPromoteCommand) getActionName() string {
+	if dp.params.Copy {
+		return "Copying"
+	}
+	return "Moving"
+}
+
+func (dp *DockerPromoteCommand) getTargetDockerImage() string {
+	if dp.params.TargetDockerImage != "" {
+		return dp.params.TargetDockerImage
+	}
+	return dp.params.SourceDockerImage
+}
+
+func (dp *DockerPromoteCommand) createPlatformImagesParams(manifests []container.ManifestDetails) []services.MoveCopyParams {
+	var params []services.MoveCopyParams
+	for _, manifest := range manifests {
+		// Platform images are stored in folders named by their digest, e.g. sha256__30daa5c1...
+		digestFolder := strings.Replace(manifest.Digest, ":", "__", 1)
+		params = append(params, createPlatformImageParams(path.Join(dp.params.SourceRepo, dp.params.SourceDockerImage, digestFolder), path.Join(dp.params.TargetRepo, dp.getTargetDockerImage(), digestFolder)))
+	}
+	return params
+}
+
+// Create the params that move the promoted platform images back to the source repository.
+func (dp *DockerPromoteCommand) createPlatformImagesRollbackParams(params []services.MoveCopyParams) []services.MoveCopyParams {
+	var rollbackParams []services.MoveCopyParams
+	for _, moveParams := range params {
+		digestFolder := path.Base(moveParams.Target)
+		rollbackParams = append(rollbackParams, createPlatformImageParams(moveParams.Target, path.Join(dp.params.SourceRepo, dp.params.SourceDockerImage, digestFolder)))
+	}
+	return rollbackParams
+}
+
+func createPlatformImageParams(sourceFolder, targetFolder string) services.MoveCopyParams {
+	moveCopyParams := services.NewMoveCopyParams()
+	moveCopyParams.CommonParams = &clientutils.CommonParams{
+		Pattern: sourceFolder + "/*",
+		Target:  targetFolder + "/",
+	}
+	moveCopyParams.Flat = true
+	return moveCopyParams
 }
 
 func (dp *DockerPromoteCommand) CommandName() string {
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/jfrog/build-info-go/build"
 	"github.com/jfrog/build-info-go/build/utils/dotnet"
+	buildInfo "github.com/jfrog/build-info-go/entities"
 	frogio "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/nuget"
 	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/auth"
@@ -29,6 +31,10 @@ Note that JFrog CLI does not restore dependencies during a 'dotnet test' command
 The initial error is:
 `
 	noRestoreFlag = "--no-restore"
+	// Fail the restore if the lock files of the projects don't match their dependencies, instead of updating them.
+	dotnetLockedModeFlag = "--locked-mode"
+	nugetLockedModeFlag  = "-LockedMode"
+	forceEvaluateFlag    = "force-evaluate"
 )
 
 type DotnetCommand struct {
@@ -40,8 +46,10 @@ type DotnetCommand struct {
 	useNugetV2    bool
 	// By default, package sources are required to use HTTPS. This option allows sources to use HTTP.
 	allowInsecureConnections bool
-	buildConfiguration       *commonBuild.BuildConfiguration
-	serverDetails            *config.ServerDetails
+	// Collect the dependencies from the packages.lock.json files of the projects, instead of their restore outputs.
+	useLockFile        bool
+	buildConfiguration *commonBuild.BuildConfiguration
+	serverDetails      *config.ServerDetails
 }
 
 func (dc *DotnetCommand) SetServerDetails(serverDetails *config.ServerDetails) *DotnetCommand {
@@ -79,6 +87,11 @@ func (dc *DotnetCommand) SetAllowInsecureConnections(allowInsecureConnections bo
 	return dc
 }
 
+func (dc *DotnetCommand) SetUseLockFile(useLockFile bool) *DotnetCommand {
+	dc.useLockFile = useLockFile
+	return dc
+}
+
 func (dc *DotnetCommand) SetArgAndFlags(argAndFlags []string) *DotnetCommand {
 	dc.argAndFlags = argAndFlags
 	return dc
@@ -104,6 +117,9 @@ func (dc *DotnetCommand) CommandName() string {
 // Exec all consume type nuget commands, install, update, add, restore.
 func (dc *DotnetCommand) Exec() (err error) {
 	log.Info("Running " + dc.toolchainType.String() + "...")
+	if dc.useLockFile {
+		return dc.execWithLockFiles()
+	}
 	buildName, err := dc.buildConfiguration.GetBuildName()
 	if err != nil {
 		return err
@@ -141,6 +157,89 @@ func (dc *DotnetCommand) Exec() (err error) {
 	return nil
 }
 
+// Exec the command, and collect the dependencies of the projects from their packages.lock.json files and central package versions.
+// Restores run in locked mode, so the resolved dependencies are the locked ones.
+func (dc *DotnetCommand) execWithLockFiles() (err error) {
+	callbackFunc, err := dc.prepareConfigFileIfNeeded()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if callbackFunc != nil {
+			err = errors.Join(err, callbackFunc())
+		}
+	}()
+	cmd, err := dotnet.NewToolchainCmd(dc.toolchainType)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if dc.subCommand != "" {
+		cmd.Command = append(cmd.Command, strings.Split(dc.subCommand, " ")...)
+	}
+	cmd.CommandFlags = dc.getLockedModeArgAndFlags()
+	// To prevent NuGet prompting for credentials
+	if err = os.Setenv("NUGET_EXE_NO_PROMPT", "true"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = frogio.RunCmd(cmd); err != nil {
+		if dc.isDotnetTestCommand() {
+			return errors.New(dotnetTestError + err.Error())
+		}
+		return errorutils.CheckError(err)
+	}
+	if err = dc.collectLockFilesBuildInfo(); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("%s finished successfully.", dc.toolchainType))
+	return nil
+}
+
+// Returns the arguments and flags of the command, with the locked mode flag if the command restores the projects.
+func (dc *DotnetCommand) getLockedModeArgAndFlags() []string {
+	if dc.subCommand != "restore" {
+		return dc.argAndFlags
+	}
+	lockedModeFlag := dotnetLockedModeFlag
+	if dc.toolchainType != dotnet.DotnetCore {
+		lockedModeFlag = nugetLockedModeFlag
+	}
+	for _, arg := range dc.argAndFlags {
+		flag := strings.ToLower(strings.TrimLeft(arg, "-"))
+		if flag == strings.ToLower(strings.TrimLeft(lockedModeFlag, "-")) || flag == forceEvaluateFlag {
+			return dc.argAndFlags
+		}
+	}
+	return append(dc.argAndFlags, lockedModeFlag)
+}
+
+func (dc *DotnetCommand) collectLockFilesBuildInfo() error {
+	toCollect, err := dc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	buildName, err := dc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := dc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := dc.buildConfiguration.GetProject()
+	if err = commonBuild.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return err
+	}
+	globalPackagesFolder, err := nuget.GetGlobalPackagesFolder()
+	if err != nil {
+		return err
+	}
+	modules, err := nuget.GetLockfileModules(dc.solutionPath, dc.buildConfiguration.GetModule(), globalPackagesFolder)
+	if err != nil {
+		return err
+	}
+	return commonBuild.SaveBuildInfo(buildName, buildNumber, project, &buildInfo.BuildInfo{Modules: modules})
+}
+
 // prepareDotnetBuildInfoModule prepare dotnet modules with the provided cli parameters.
 // In case no config file was provided - creates a temporary one.
 func (dc *DotnetCommand) prepareDotnetBuildInfoModule(buildInfoModule *build.DotnetModule) (func() error, error) {
@@ -345,3 +345,25 @@ func TestAddConfigFileFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLockedModeArgAndFlags(t *testing.T) {
+	testCases := []struct {
+		name          string
+		toolchainType dotnet.ToolchainType
+		subCommand    string
+		argAndFlags   []string
+		expectedFlags []string
+	}{
+		{"DotnetCore restore", dotnet.DotnetCore, "restore", []string{"app.sln"}, []string{"app.sln", "--locked-mode"}},
+		{"NuGet restore", dotnet.Nuget, "restore", nil, []string{"-LockedMode"}},
+		{"Locked mode provided", dotnet.DotnetCore, "restore", []string{"--locked-mode"}, []string{"--locked-mode"}},
+		{"Force evaluate", dotnet.DotnetCore, "restore", []string{"--force-evaluate"}, []string{"--force-evaluate"}},
+		{"Not a restore", dotnet.DotnetCore, "build", []string{"--no-restore"}, []string{"--no-restore"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dc := &DotnetCommand{toolchainType: testCase.toolchainType, subCommand: testCase.subCommand, argAndFlags: testCase.argAndFlags}
+			assert.Equal(t, testCase.expectedFlags, dc.getLockedModeArgAndFlags())
+		})
+	}
+}
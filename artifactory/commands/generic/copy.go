@@ -27,6 +27,14 @@ func (cc *CopyCommand) SetThreads(threads int) *CopyCommand {
 	return cc
 }
 
+func (cc *CopyCommand) SetConfiguration(configuration *utils.MoveCopyConfiguration) *CopyCommand {
+	cc.threads = configuration.Threads
+	if configuration.DryRun {
+		cc.SetDryRun(true)
+	}
+	return cc
+}
+
 func (cc *CopyCommand) CommandName() string {
 	return "rt_copy"
 }
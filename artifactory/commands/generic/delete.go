@@ -28,6 +28,14 @@ func (dc *DeleteCommand) SetThreads(threads int) *DeleteCommand {
 	return dc
 }
 
+func (dc *DeleteCommand) SetConfiguration(configuration *utils.DeleteConfiguration) *DeleteCommand {
+	dc.threads = configuration.Threads
+	if configuration.DryRun {
+		dc.SetDryRun(true)
+	}
+	return dc
+}
+
 func (dc *DeleteCommand) CommandName() string {
 	return "rt_delete"
 }
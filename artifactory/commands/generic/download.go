@@ -1,7 +1,10 @@
 package generic
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,6 +15,8 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
@@ -27,6 +32,10 @@ type DownloadCommand struct {
 	GenericCommand
 	configuration *utils.DownloadConfiguration
 	progress      ioUtils.ProgressMgr
+	// Mirror the remote files, downloading only the changed files and deleting the local files which don't exist remotely.
+	sync bool
+	// The explicit opt-in to the deletion of local files, which the sync mode requires.
+	syncDeleteLocal bool
 }
 
 func NewDownloadCommand() *DownloadCommand {
@@ -44,6 +53,27 @@ func (dc *DownloadCommand) Configuration() *utils.DownloadConfiguration {
 
 func (dc *DownloadCommand) SetConfiguration(configuration *utils.DownloadConfiguration) *DownloadCommand {
 	dc.configuration = configuration
+	if configuration.DryRun {
+		dc.SetDryRun(true)
+	}
+	return dc
+}
+
+func (dc *DownloadCommand) Sync() bool {
+	return dc.sync
+}
+
+func (dc *DownloadCommand) SetSync(sync bool) *DownloadCommand {
+	dc.sync = sync
+	return dc
+}
+
+func (dc *DownloadCommand) SyncDeleteLocal() bool {
+	return dc.syncDeleteLocal
+}
+
+func (dc *DownloadCommand) SetSyncDeleteLocal(syncDeleteLocal bool) *DownloadCommand {
+	dc.syncDeleteLocal = syncDeleteLocal
 	return dc
 }
 
@@ -52,7 +82,7 @@ func (dc *DownloadCommand) SetProgress(progress ioUtils.ProgressMgr) {
 }
 
 func (dc *DownloadCommand) ShouldPrompt() bool {
-	return !dc.DryRun() && dc.SyncDeletesPath() != "" && !dc.Quiet()
+	return !dc.DryRun() && (dc.SyncDeletesPath() != "" || dc.sync && dc.syncDeleteLocal) && !dc.Quiet()
 }
 
 func (dc *DownloadCommand) CommandName() string {
@@ -64,13 +94,31 @@ func (dc *DownloadCommand) Run() error {
 }
 
 func (dc *DownloadCommand) download() (err error) {
+	// The download service always skips the files which are identical to the local files, by comparing their checksums,
+	// so the sync mode only adds the deletion of the local files which weren't downloaded, as done by the sync-deletes option.
+	if dc.sync && !dc.syncDeleteLocal {
+		return errorutils.CheckErrorf("the sync mode deletes the local files which don't exist remotely, and requires opting in to the local deletion. " +
+			"Files which are identical to the local files are skipped by any download, so to keep the local files, download without the sync mode")
+	}
+	if dc.sync && dc.SyncDeletesPath() == "" {
+		var syncTargetDir string
+		if syncTargetDir, err = getSyncTargetDir(dc.Spec()); err != nil {
+			return err
+		}
+		if err = validateSyncDeleteDir(syncTargetDir); err != nil {
+			return err
+		}
+		log.Info("Syncing the local files under", syncTargetDir, "with the remote files. Local files which don't exist remotely will be deleted.")
+		dc.SetSyncDeletesPath(syncTargetDir)
+	}
 	// Init progress bar if needed
 	if dc.progress != nil {
 		dc.progress.SetHeadlineMsg("")
 		dc.progress.InitProgressReaders()
 	}
 	// Create Service Manager:
-	servicesManager, err := utils.CreateDownloadServiceManager(dc.serverDetails, dc.configuration.Threads, dc.retries, dc.retryWaitTimeMilliSecs, dc.DryRun(), dc.progress)
+	failures := utils.NewTransferFailures(dc.configuration.OnError)
+	servicesManager, err := utils.CreateTransferServiceManager(dc.serverDetails, dc.configuration.Threads, dc.configuration.RateLimit, dc.getRetryPolicy(dc.configuration.RetryPolicy), failures, dc.DryRun(), dc.progress)
 	if err != nil {
 		return err
 	}
@@ -80,6 +128,12 @@ func (dc *DownloadCommand) download() (err error) {
 	if err != nil {
 		return err
 	}
+	if dc.configuration.ToStdout {
+		if toCollect || dc.SyncDeletesPath() != "" {
+			return errorutils.CheckErrorf("downloading to stdout doesn't support build-info collection and sync-deletes")
+		}
+		return dc.downloadToStdout(servicesManager)
+	}
 	if toCollect && !dc.DryRun() {
 		var buildName, buildNumber string
 		buildName, err = dc.buildConfiguration.GetBuildName()
@@ -109,12 +163,19 @@ func (dc *DownloadCommand) download() (err error) {
 		downloadParamsArray = append(downloadParamsArray, downParams)
 	}
 	// Perform download.
-	// In case of build-info collection/sync-deletes operation/a detailed summary is required, we use the download service which provides results file reader,
+	// In case of build-info collection/sync-deletes operation/a detailed summary/selective checksum verification is required, we use the download service which provides results file reader,
 	// otherwise we use the download service which provides only general counters.
 	var totalDownloaded, totalFailed int
 	var summary *serviceutils.OperationSummary
-	if toCollect || dc.SyncDeletesPath() != "" || dc.DetailedSummary() {
-		summary, err = servicesManager.DownloadFilesWithSummary(downloadParamsArray...)
+	verifyChecksums := dc.configuration.IsSelectiveChecksumVerification() && !dc.DryRun()
+	if toCollect || dc.SyncDeletesPath() != "" || dc.DetailedSummary() || verifyChecksums {
+		summary, totalDownloaded, totalFailed, err = transferWithOnErrorPolicy(failures, "download", func() (*serviceutils.OperationSummary, int, int, error) {
+			downloadSummary, downloadErr := servicesManager.DownloadFilesWithSummary(downloadParamsArray...)
+			if downloadSummary == nil {
+				return nil, 0, 0, downloadErr
+			}
+			return downloadSummary, downloadSummary.TotalSucceeded, downloadSummary.TotalFailed, downloadErr
+		})
 		if err != nil {
 			errorOccurred = true
 			log.Error(err)
@@ -128,11 +189,22 @@ func (dc *DownloadCommand) download() (err error) {
 			} else {
 				defer gofrog.Close(summary.TransferDetailsReader, &err)
 			}
-			totalDownloaded = summary.TotalSucceeded
-			totalFailed = summary.TotalFailed
+			if verifyChecksums {
+				var mismatches int
+				mismatches, err = verifyDownloadedFilesChecksums(servicesManager, summary, dc.configuration.ChecksumAlgorithm)
+				if err != nil {
+					errorOccurred = true
+					log.Error(err)
+				}
+				totalDownloaded -= mismatches
+				totalFailed += mismatches
+			}
 		}
 	} else {
-		totalDownloaded, totalFailed, err = servicesManager.DownloadFiles(downloadParamsArray...)
+		_, totalDownloaded, totalFailed, err = transferWithOnErrorPolicy(failures, "download", func() (*serviceutils.OperationSummary, int, int, error) {
+			downloaded, failed, downloadErr := servicesManager.DownloadFiles(downloadParamsArray...)
+			return nil, downloaded, failed, downloadErr
+		})
 		if err != nil {
 			errorOccurred = true
 			log.Error(err)
@@ -202,6 +274,113 @@ func (dc *DownloadCommand) download() (err error) {
 	return err
 }
 
+// Stream a single file to stdout, so that it can be piped to another command.
+// The output and the progress are written to stderr, to keep stdout for the file content.
+func (dc *DownloadCommand) downloadToStdout(servicesManager artifactory.ArtifactoryServicesManager) (err error) {
+	if dc.progress == nil {
+		// When the progress is displayed, the logger already writes to a log file.
+		corelog.SetStderrOutputLogger()
+	}
+	if len(dc.Spec().Files) != 1 {
+		return errorutils.CheckErrorf("downloading to stdout requires a single spec file, but got %d", len(dc.Spec().Files))
+	}
+	searchParams, err := utils.GetSearchParams(dc.Spec().Get(0))
+	if err != nil {
+		return err
+	}
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return err
+	}
+	defer gofrog.Close(reader, &err)
+	length, err := reader.Length()
+	if err != nil {
+		return err
+	}
+	if length != 1 {
+		return errorutils.CheckErrorf("downloading to stdout requires the pattern to match a single file, but it matched %d files", length)
+	}
+	resultItem := new(serviceutils.ResultItem)
+	if err = reader.NextRecord(resultItem); err != nil {
+		return err
+	}
+	remotePath := resultItem.GetItemRelativePath()
+	if dc.DryRun() {
+		log.Info("[Dry run] Downloading", remotePath, "to stdout")
+		dc.result.SetSuccessCount(1)
+		return nil
+	}
+	log.Info("Downloading", remotePath, "to stdout")
+	remoteFile, err := servicesManager.ReadRemoteFile(remotePath)
+	if err != nil {
+		dc.result.SetFailCount(1)
+		return err
+	}
+	defer gofrog.Close(remoteFile, &err)
+	if err = streamRemoteFile(remoteFile, os.Stdout, resultItem, dc.configuration.SkipChecksum, dc.progress); err != nil {
+		dc.result.SetFailCount(1)
+		return err
+	}
+	dc.result.SetSuccessCount(1)
+	return nil
+}
+
+// Copy the remote file to the writer, and verify its SHA-256 checksum, unless skipChecksum is set.
+// Since the content is streamed, a checksum mismatch is only detected after the whole file was written.
+func streamRemoteFile(remoteFile io.Reader, writer io.Writer, resultItem *serviceutils.ResultItem, skipChecksum bool, progressMgr ioUtils.ProgressMgr) error {
+	if progressMgr != nil {
+		progress := progressMgr.NewProgressReader(resultItem.Size, "Downloading", resultItem.GetItemRelativePath())
+		defer progressMgr.RemoveProgress(progress.GetId())
+		remoteFile = progress.ActionWithProgress(remoteFile)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(writer, io.TeeReader(remoteFile, hash)); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if skipChecksum || resultItem.Sha256 == "" {
+		return nil
+	}
+	if actualSha256 := hex.EncodeToString(hash.Sum(nil)); actualSha256 != resultItem.Sha256 {
+		return errorutils.CheckErrorf("sha256 checksum mismatch for '%s'. Expected: %s, actual: %s", resultItem.GetItemRelativePath(), resultItem.Sha256, actualSha256)
+	}
+	return nil
+}
+
+// Verify the downloaded files using a single checksum algorithm, and return the number of files failing the verification.
+// The SHA-256 checksums aren't part of the download summary, so they are fetched from Artifactory for each file.
+func verifyDownloadedFilesChecksums(servicesManager artifactory.ArtifactoryServicesManager, summary *serviceutils.OperationSummary, algorithm utils.ChecksumAlgorithm) (mismatches int, err error) {
+	expectedChecksums := make(map[string]string)
+	for artifact := new(serviceutils.ArtifactDetails); summary.ArtifactsDetailsReader.NextRecord(artifact) == nil; artifact = new(serviceutils.ArtifactDetails) {
+		expectedChecksums[artifact.ArtifactoryPath] = algorithm.GetExpectedChecksum(artifact.Checksums)
+	}
+	summary.ArtifactsDetailsReader.Reset()
+	if err = summary.ArtifactsDetailsReader.GetError(); err != nil {
+		return
+	}
+	for transfer := new(clientutils.FileTransferDetails); summary.TransferDetailsReader.NextRecord(transfer) == nil; transfer = new(clientutils.FileTransferDetails) {
+		expectedChecksum := expectedChecksums[transfer.SourcePath]
+		if expectedChecksum == "" && algorithm == utils.ChecksumAlgorithmSha256 {
+			var fileInfo *serviceutils.FileInfo
+			if fileInfo, err = servicesManager.FileInfo(transfer.SourcePath); err != nil {
+				return
+			}
+			expectedChecksum = fileInfo.Checksums.Sha256
+		}
+		if verifyErr := utils.VerifyLocalFileChecksum(transfer.TargetPath, algorithm, expectedChecksum); verifyErr != nil {
+			log.Error(verifyErr)
+			mismatches++
+		}
+	}
+	summary.TransferDetailsReader.Reset()
+	if err = summary.TransferDetailsReader.GetError(); err != nil {
+		return
+	}
+	if mismatches > 0 {
+		err = errorutils.CheckErrorf("%d downloaded files failed the %s checksum verification", mismatches, algorithm)
+	}
+	return
+}
+
 func getDownloadParams(f *spec.File, configuration *utils.DownloadConfiguration) (downParams services.DownloadParams, err error) {
 	downParams = services.NewDownloadParams()
 	downParams.CommonParams, err = f.ToCommonParams()
@@ -211,7 +390,8 @@ func getDownloadParams(f *spec.File, configuration *utils.DownloadConfiguration)
 	downParams.Symlink = configuration.Symlink
 	downParams.MinSplitSize = configuration.MinSplitSize
 	downParams.SplitCount = configuration.SplitCount
-	downParams.SkipChecksum = configuration.SkipChecksum
+	// The download service verifies all the checksums, so it's skipped when a single checksum should be verified.
+	downParams.SkipChecksum = configuration.SkipChecksum || configuration.IsSelectiveChecksumVerification()
 
 	downParams.Recursive, err = f.IsRecursive(true)
 	if err != nil {
@@ -295,6 +475,61 @@ func createDownloadResultEmptyTmpReflection(reader *content.ContentReader) (tmpR
 	return
 }
 
+// Returns the local dir mirrored by the sync mode, which should be the target dir of all the spec files.
+func getSyncTargetDir(specFiles *spec.SpecFiles) (string, error) {
+	syncTargetDir := ""
+	for i, file := range specFiles.Files {
+		if strings.Contains(file.Target, "{") {
+			return "", errorutils.CheckErrorf("the sync mode doesn't support placeholders in the target path '%s'. Use the sync-deletes option instead", file.Target)
+		}
+		targetDir := file.Target
+		if targetDir == "" {
+			targetDir = "."
+		} else if !strings.HasSuffix(targetDir, "/") && !strings.HasSuffix(targetDir, "\\") {
+			// A target without a trailing slash is the local path of a single file.
+			targetDir = filepath.Dir(targetDir)
+		}
+		targetDir = filepath.Clean(targetDir)
+		if i > 0 && targetDir != syncTargetDir {
+			return "", errorutils.CheckErrorf("the sync mode requires all the spec files to have the same target dir, but got '%s' and '%s'. Use the sync-deletes option instead", syncTargetDir, targetDir)
+		}
+		syncTargetDir = targetDir
+	}
+	return syncTargetDir, nil
+}
+
+// Refuse deleting the local files of dirs which hold more than the synced files:
+// the filesystem root, the home dir, and the current working directory or any of its parents.
+// The current working directory is the target dir of spec files without a target.
+func validateSyncDeleteDir(syncTargetDir string) error {
+	absSyncTargetDir, err := filepath.Abs(syncTargetDir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var reason string
+	switch homeDir, homeDirErr := os.UserHomeDir(); {
+	case filepath.Dir(absSyncTargetDir) == absSyncTargetDir:
+		reason = "the filesystem root"
+	case homeDirErr == nil && absSyncTargetDir == filepath.Clean(homeDir):
+		reason = "the home dir"
+	case isSameOrParentDir(absSyncTargetDir, wd):
+		reason = "the current working directory or one of its parents"
+	default:
+		return nil
+	}
+	return errorutils.CheckErrorf("deleting the local files in sync mode isn't allowed in '%s', which is %s. Set the target of the spec files to a dedicated dir", absSyncTargetDir, reason)
+}
+
+// Returns true if the absolute path is the dir or is under it.
+func isSameOrParentDir(dir, path string) bool {
+	relPath, err := filepath.Rel(dir, path)
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
+
 // Creates absolute path for temp file suitable for all environments
 func createLegalPath(root, path string) string {
 	// Avoid concatenating the volume name (e.g "C://") in Windows environment.
@@ -0,0 +1,128 @@
+package generic
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSyncTargetDir(t *testing.T) {
+	tests := []struct {
+		name          string
+		targets       []string
+		expected      string
+		expectedError string
+	}{
+		{"no target", []string{""}, ".", ""},
+		{"target dir", []string{"out/libs/"}, filepath.Join("out", "libs"), ""},
+		{"target file", []string{"out/libs/a.jar"}, filepath.Join("out", "libs"), ""},
+		{"same target dirs", []string{"out/", "out/b.jar"}, "out", ""},
+		{"different target dirs", []string{"out/", "other/"}, "", "the sync mode requires all the spec files to have the same target dir"},
+		{"placeholders", []string{"out/{1}/"}, "", "the sync mode doesn't support placeholders"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			specFiles := new(spec.SpecFiles)
+			for _, target := range test.targets {
+				specFiles.Files = append(specFiles.Files, spec.File{Pattern: "repo/*", Target: target})
+			}
+			syncTargetDir, err := getSyncTargetDir(specFiles)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, syncTargetDir)
+		})
+	}
+}
+
+func TestStreamRemoteFile(t *testing.T) {
+	const contentSha256 = "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+	tests := []struct {
+		name          string
+		sha256        string
+		skipChecksum  bool
+		expectedError string
+	}{
+		{"valid checksum", contentSha256, false, ""},
+		{"unknown checksum", "", false, ""},
+		{"checksum mismatch", "1234", false, "sha256 checksum mismatch for 'repo/file.txt'"},
+		{"skip checksum", "1234", true, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := new(bytes.Buffer)
+			resultItem := &serviceutils.ResultItem{Repo: "repo", Path: ".", Name: "file.txt", Size: 7, Sha256: test.sha256}
+			err := streamRemoteFile(strings.NewReader("content"), output, resultItem, test.skipChecksum, nil)
+			// The content is streamed before it's verified.
+			assert.Equal(t, "content", output.String())
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSyncDeleteDir(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+	wd := filepath.Join(t.TempDir(), "work", "project")
+	assert.NoError(t, os.MkdirAll(wd, 0755))
+	chdirCallback := testsutils.ChangeDirWithCallback(t, getWd(t), wd)
+	defer chdirCallback()
+	// The working directory may differ from the created path if the temp dir is a symlink.
+	wd = getWd(t)
+
+	tests := []struct {
+		name          string
+		syncTargetDir string
+		expectedError string
+	}{
+		{"empty", "", "the current working directory or one of its parents"},
+		{"current dir", ".", "the current working directory or one of its parents"},
+		{"absolute current dir", wd, "the current working directory or one of its parents"},
+		{"parent dir", "..", "the current working directory or one of its parents"},
+		{"unclean parent dir", "out/../../..", "the current working directory or one of its parents"},
+		{"filesystem root", string(filepath.Separator), "the filesystem root"},
+		{"home dir", homeDir, "the home dir"},
+		{"unclean home dir", homeDir + string(filepath.Separator) + ".", "the home dir"},
+		{"sub dir", filepath.Join("out", "libs"), ""},
+		{"sibling dir", filepath.Join("..", "other"), ""},
+		{"similar name", filepath.Join("..", "project-out"), ""},
+		{"under the home dir", filepath.Join(homeDir, "mirror"), ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateSyncDeleteDir(test.syncTargetDir)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
+
+func getWd(t *testing.T) string {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	return wd
+}
+
+func TestSyncRequiresLocalDeletion(t *testing.T) {
+	command := NewDownloadCommand().SetSync(true)
+	command.SetConfiguration(&utils.DownloadConfiguration{})
+	assert.ErrorContains(t, command.Run(), "requires opting in to the local deletion")
+}
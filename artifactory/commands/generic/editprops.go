@@ -0,0 +1,201 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The default number of items of which the properties are edited in each batch.
+const DefaultPropsEditBatchSize = 1000
+
+type PropsOperationSummary struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+type PropsEditSummary struct {
+	// The number of items matching the spec.
+	Total   int                    `json:"total"`
+	Batches int                    `json:"batches"`
+	Set     *PropsOperationSummary `json:"set,omitempty"`
+	Delete  *PropsOperationSummary `json:"delete,omitempty"`
+}
+
+// Applies a properties delta to all the items matching the file spec.
+// The items are searched once, and then edited in batches, so that the progress is reported
+// and a failing batch doesn't stop the following ones.
+type EditPropsCommand struct {
+	PropsCommand
+	delta     utils.PropsDelta
+	batchSize int
+	summary   *PropsEditSummary
+}
+
+func NewEditPropsCommand() *EditPropsCommand {
+	return &EditPropsCommand{PropsCommand: *NewPropsCommand(), batchSize: DefaultPropsEditBatchSize}
+}
+
+func (ep *EditPropsCommand) SetPropsCommand(command PropsCommand) *EditPropsCommand {
+	ep.PropsCommand = command
+	return ep
+}
+
+func (ep *EditPropsCommand) SetDelta(delta utils.PropsDelta) *EditPropsCommand {
+	ep.delta = delta
+	return ep
+}
+
+func (ep *EditPropsCommand) SetBatchSize(batchSize int) *EditPropsCommand {
+	ep.batchSize = batchSize
+	return ep
+}
+
+// Returns the summary of the edit, once the command is done.
+func (ep *EditPropsCommand) Summary() *PropsEditSummary {
+	return ep.summary
+}
+
+func (ep *EditPropsCommand) CommandName() string {
+	return "rt_edit_properties"
+}
+
+func (ep *EditPropsCommand) Run() (err error) {
+	if err = ep.delta.Validate(); err != nil {
+		return err
+	}
+	if ep.batchSize <= 0 {
+		ep.batchSize = DefaultPropsEditBatchSize
+	}
+	serverDetails, err := ep.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	servicesManager, err := createPropsServiceManager(ep.threads, ep.retries, ep.retryWaitTimeMilliSecs, serverDetails)
+	if err != nil {
+		return err
+	}
+	reader, err := searchItems(ep.Spec(), servicesManager)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	total, err := reader.Length()
+	if err != nil {
+		return err
+	}
+	ep.summary = &PropsEditSummary{Total: total}
+	if ep.delta.Set != "" {
+		ep.summary.Set = new(PropsOperationSummary)
+	}
+	if ep.delta.Delete != "" {
+		ep.summary.Delete = new(PropsOperationSummary)
+	}
+	err = forEachItemsBatch(reader, ep.batchSize, func(batch *content.ContentReader, batchLength int) error {
+		ep.summary.Batches++
+		batchErr := ep.editBatch(servicesManager, batch, batchLength)
+		log.Info(fmt.Sprintf("Edited the properties of %d out of %d items.", min(ep.summary.Batches*ep.batchSize, total), total))
+		if batchErr != nil {
+			// Continue to the next batches, and report the errors at the end.
+			log.Error(batchErr)
+		}
+		return nil
+	})
+	ep.logSummary()
+	succeeded := ep.getSucceededItems()
+	ep.Result().SetSuccessCount(succeeded)
+	ep.Result().SetFailCount(total - succeeded)
+	if err == nil && succeeded < total {
+		err = errorutils.CheckErrorf("failed to edit the properties of %d items. Please review the logs.", total-succeeded)
+	}
+	return err
+}
+
+func (ep *EditPropsCommand) editBatch(servicesManager artifactory.ArtifactoryServicesManager, batch *content.ContentReader, batchLength int) (err error) {
+	if ep.summary.Set != nil {
+		succeeded, setErr := servicesManager.SetProps(GetPropsParams(batch, ep.delta.Set))
+		ep.summary.Set.Succeeded += succeeded
+		ep.summary.Set.Failed += batchLength - succeeded
+		err = errors.Join(err, setErr)
+	}
+	if ep.summary.Delete != nil {
+		succeeded, deleteErr := servicesManager.DeleteProps(GetPropsParams(batch, strings.Join(ep.delta.GetDeletedKeys(), ",")))
+		ep.summary.Delete.Succeeded += succeeded
+		ep.summary.Delete.Failed += batchLength - succeeded
+		err = errors.Join(err, deleteErr)
+	}
+	return
+}
+
+// Returns the number of items for which all the operations succeeded, assuming the failures of the operations are of the same items.
+func (ep *EditPropsCommand) getSucceededItems() int {
+	succeeded := ep.summary.Total
+	for _, operation := range []*PropsOperationSummary{ep.summary.Set, ep.summary.Delete} {
+		if operation != nil {
+			succeeded = min(succeeded, operation.Succeeded)
+		}
+	}
+	return succeeded
+}
+
+func (ep *EditPropsCommand) logSummary() {
+	message := fmt.Sprintf("Edited the properties of %d items in %d batches.", ep.summary.Total, ep.summary.Batches)
+	if ep.summary.Set != nil {
+		message += fmt.Sprintf(" Set: %d succeeded, %d failed.", ep.summary.Set.Succeeded, ep.summary.Set.Failed)
+	}
+	if ep.summary.Delete != nil {
+		message += fmt.Sprintf(" Delete: %d succeeded, %d failed.", ep.summary.Delete.Succeeded, ep.summary.Delete.Failed)
+	}
+	log.Info(message)
+}
+
+// Split the items of the reader into batches of up to batchSize items, and call handleBatch with each of them.
+// Each batch is written to a temp file, which is removed once the batch is handled.
+func forEachItemsBatch(reader *content.ContentReader, batchSize int, handleBatch func(batch *content.ContentReader, batchLength int) error) (err error) {
+	var writer *content.ContentWriter
+	batchLength := 0
+	flush := func() error {
+		if writer == nil {
+			return nil
+		}
+		if e := writer.Close(); e != nil {
+			return e
+		}
+		batch := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+		writer = nil
+		e := handleBatch(batch, batchLength)
+		batchLength = 0
+		return errors.Join(e, batch.Close())
+	}
+	for item := new(clientutils.ResultItem); reader.NextRecord(item) == nil; item = new(clientutils.ResultItem) {
+		if writer == nil {
+			if writer, err = content.NewContentWriter(content.DefaultKey, true, false); err != nil {
+				return
+			}
+		}
+		writer.Write(*item)
+		if batchLength++; batchLength == batchSize {
+			if err = flush(); err != nil {
+				return
+			}
+		}
+	}
+	if err = reader.GetError(); err != nil {
+		if writer != nil {
+			ioutils.Close(writer, &err)
+		}
+		return
+	}
+	reader.Reset()
+	return flush()
+}
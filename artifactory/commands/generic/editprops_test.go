@@ -0,0 +1,58 @@
+package generic
+
+import (
+	"fmt"
+	"testing"
+
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachItemsBatch(t *testing.T) {
+	tests := []struct {
+		items           int
+		batchSize       int
+		expectedBatches []int
+	}{
+		{0, 2, nil},
+		{1, 2, []int{1}},
+		{4, 2, []int{2, 2}},
+		{5, 2, []int{2, 2, 1}},
+		{3, 10, []int{3}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d items in batches of %d", test.items, test.batchSize), func(t *testing.T) {
+			reader := createItemsReader(t, test.items)
+			defer func() {
+				assert.NoError(t, reader.Close())
+			}()
+			var batches []int
+			var names []string
+			err := forEachItemsBatch(reader, test.batchSize, func(batch *content.ContentReader, batchLength int) error {
+				batches = append(batches, batchLength)
+				for item := new(clientutils.ResultItem); batch.NextRecord(item) == nil; item = new(clientutils.ResultItem) {
+					names = append(names, item.Name)
+				}
+				return batch.GetError()
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedBatches, batches)
+			assert.Len(t, names, test.items)
+		})
+	}
+}
+
+func createItemsReader(t *testing.T, items int) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	for i := 0; i < items; i++ {
+		writer.Write(clientutils.ResultItem{Repo: "repo", Path: "path", Name: fmt.Sprintf("file%d", i), Type: "file"})
+	}
+	require.NoError(t, writer.Close())
+	if items == 0 {
+		return content.NewEmptyContentReader(content.DefaultKey)
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+}
@@ -1,9 +1,16 @@
 package generic
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type GenericCommand struct {
@@ -64,6 +71,14 @@ func (gc *GenericCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *Generic
 	return gc
 }
 
+// Returns the given retry policy, or a policy with the command's retries and retry wait time if it's nil.
+func (gc *GenericCommand) getRetryPolicy(retryPolicy *utils.RetryPolicy) utils.RetryPolicy {
+	if retryPolicy != nil {
+		return *retryPolicy
+	}
+	return utils.RetryPolicy{Retries: gc.retries, WaitTime: time.Duration(gc.retryWaitTimeMilliSecs) * time.Millisecond}
+}
+
 func (gc *GenericCommand) Result() *commandsutils.Result {
 	return gc.result
 }
@@ -103,3 +118,45 @@ func (gc *GenericCommand) SetAqlInclude(include []string) *GenericCommand {
 	gc.aqlInclude = include
 	return gc
 }
+
+// Run a batch transfer, and log a summary of its failed and skipped items.
+// If some of the items failed and the on-error policy is retry-at-end, the transfer runs once again, unless all the failures are permanent.
+// The second run skips the items which were already transferred, since they are identical to their targets.
+func transferWithOnErrorPolicy(failures *utils.TransferFailures, operation string, transfer func() (*serviceutils.OperationSummary, int, int, error)) (summary *serviceutils.OperationSummary, succeeded, failed int, err error) {
+	summary, succeeded, failed, err = transfer()
+	if failed > 0 && failures.Policy() == utils.OnErrorRetryAtEnd {
+		if failures.HasOnlyPermanentFailures() {
+			log.Info(fmt.Sprintf("The %d failed items of the %s aren't retried, since none of the failures is retryable.", failed, operation))
+		} else {
+			if err != nil {
+				log.Error(err)
+			}
+			if summary != nil {
+				if err = summary.Close(); err != nil {
+					return
+				}
+			}
+			log.Info(fmt.Sprintf("Retrying the %d failed items of the %s, since the on-error policy is %s.", failed, operation, failures.Policy()))
+			summary, succeeded, failed, err = transfer()
+		}
+	}
+	if failed > 0 {
+		log.Warn(getFailuresSummary(failures, operation, succeeded, failed))
+	}
+	return
+}
+
+func getFailuresSummary(failures *utils.TransferFailures, operation string, succeeded, failed int) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("The %s finished with %d succeeded and %d failed items.", operation, succeeded, failed))
+	if skipped := failures.SkippedCount(); skipped > 0 {
+		builder.WriteString(fmt.Sprintf(" %d requests following the first permanent failure were skipped, since the on-error policy is %s.", skipped, failures.Policy()))
+	}
+	if failedRequests := failures.Failed(); len(failedRequests) > 0 {
+		builder.WriteString(" Failed requests:")
+		for _, failedRequest := range failedRequests {
+			builder.WriteString("\n- " + failedRequest.String())
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,44 @@
+package generic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferWithOnErrorPolicy(t *testing.T) {
+	tests := []struct {
+		name              string
+		onError           utils.OnErrorPolicy
+		failures          []int
+		expectedRuns      int
+		expectedSucceeded int
+		expectedFailed    int
+	}{
+		{"continue", utils.OnErrorContinue, []int{2}, 1, 8, 2},
+		{"fail fast", utils.OnErrorFailFast, []int{2}, 1, 8, 2},
+		{"retry at end recovers", utils.OnErrorRetryAtEnd, []int{2, 0}, 2, 10, 0},
+		{"retry at end fails again", utils.OnErrorRetryAtEnd, []int{2, 1}, 2, 9, 1},
+		{"retry at end without failures", utils.OnErrorRetryAtEnd, []int{0}, 1, 10, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runs := 0
+			_, succeeded, failed, err := transferWithOnErrorPolicy(utils.NewTransferFailures(test.onError), "download", func() (*serviceutils.OperationSummary, int, int, error) {
+				failures := test.failures[runs]
+				runs++
+				if failures > 0 {
+					return nil, 10 - failures, failures, errors.New("some files failed")
+				}
+				return nil, 10, 0, nil
+			})
+			assert.Equal(t, test.expectedRuns, runs)
+			assert.Equal(t, test.expectedSucceeded, succeeded)
+			assert.Equal(t, test.expectedFailed, failed)
+			assert.Equal(t, test.expectedFailed > 0, err != nil)
+		})
+	}
+}
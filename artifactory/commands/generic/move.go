@@ -27,6 +27,14 @@ func (mc *MoveCommand) SetThreads(threads int) *MoveCommand {
 	return mc
 }
 
+func (mc *MoveCommand) SetConfiguration(configuration *utils.MoveCopyConfiguration) *MoveCommand {
+	mc.threads = configuration.Threads
+	if configuration.DryRun {
+		mc.SetDryRun(true)
+	}
+	return mc
+}
+
 // Moves the artifacts using the specified move pattern.
 func (mc *MoveCommand) Run() error {
 	// Create Service Manager:
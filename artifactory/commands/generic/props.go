@@ -51,10 +51,15 @@ func createPropsServiceManager(threads, httpRetries, retryWaitMilliSecs int, ser
 	if err != nil {
 		return nil, err
 	}
+	httpClient, err := serverDetails.GetCustomHttpClient(certsPath, 0)
+	if err != nil {
+		return nil, err
+	}
 	serviceConfig, err := clientConfig.NewConfigBuilder().
 		SetServiceDetails(artAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serverDetails.InsecureTls).
+		SetHttpClient(httpClient).
 		SetThreads(threads).
 		SetHttpRetries(httpRetries).
 		SetHttpRetryWaitMilliSecs(retryWaitMilliSecs).
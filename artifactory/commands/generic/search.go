@@ -11,6 +11,9 @@ import (
 
 type SearchCommand struct {
 	GenericCommand
+	// If set, the results are printed by Run in this format, with the selected columns only.
+	format  utils.SearchOutputFormat
+	columns []string
 }
 
 func NewSearchCommand() *SearchCommand {
@@ -21,10 +24,25 @@ func (sc *SearchCommand) CommandName() string {
 	return "rt_search"
 }
 
+func (sc *SearchCommand) SetFormat(format utils.SearchOutputFormat) *SearchCommand {
+	sc.format = format
+	return sc
+}
+
+// Select the columns of the printed results. All the non-empty columns are printed if no columns are selected.
+func (sc *SearchCommand) SetColumns(columns []string) *SearchCommand {
+	sc.columns = columns
+	return sc
+}
+
 func (sc *SearchCommand) Run() error {
 	reader, err := sc.Search()
 	sc.Result().SetReader(reader)
-	return err
+	if err != nil || sc.format == "" {
+		return err
+	}
+	// The reader is reset once printed, so it can still be read through the result.
+	return utils.PrintSearchResultsWithFormat(reader, sc.format, sc.columns)
 }
 
 func (sc *SearchCommand) Search() (*content.ContentReader, error) {
@@ -5,6 +5,8 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/commandsummary"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"os"
+	"path/filepath"
+	"strings"
 
 	buildInfo "github.com/jfrog/build-info-go/entities"
 
@@ -45,6 +47,9 @@ func (uc *UploadCommand) UploadConfiguration() *utils.UploadConfiguration {
 
 func (uc *UploadCommand) SetUploadConfiguration(uploadConfiguration *utils.UploadConfiguration) *UploadCommand {
 	uc.uploadConfiguration = uploadConfiguration
+	if uploadConfiguration.DryRun {
+		uc.SetDryRun(true)
+	}
 	return uc
 }
 
@@ -92,7 +97,8 @@ func (uc *UploadCommand) upload() (err error) {
 	if errorutils.CheckError(err) != nil {
 		return
 	}
-	servicesManager, err := utils.CreateUploadServiceManager(serverDetails, uc.uploadConfiguration.Threads, uc.retries, uc.retryWaitTimeMilliSecs, uc.DryRun(), uc.progress)
+	failures := utils.NewTransferFailures(uc.uploadConfiguration.OnError)
+	servicesManager, err := utils.CreateTransferServiceManager(serverDetails, uc.uploadConfiguration.Threads, uc.uploadConfiguration.RateLimit, uc.getRetryPolicy(uc.uploadConfiguration.RetryPolicy), failures, uc.DryRun(), uc.progress)
 	if err != nil {
 		return
 	}
@@ -104,6 +110,12 @@ func (uc *UploadCommand) upload() (err error) {
 	if err != nil {
 		return
 	}
+	if uc.uploadConfiguration.ArchiveStream != "" {
+		if toCollect || uc.syncDelete() {
+			return errorutils.CheckErrorf("the archive stream option doesn't support build-info collection and sync-deletes")
+		}
+		return uc.uploadArchiveStreams(servicesManager)
+	}
 	if toCollect && !uc.DryRun() {
 		addVcsProps = true
 		buildProps, err = build.CreateBuildPropsFromConfiguration(uc.buildConfiguration)
@@ -136,7 +148,13 @@ func (uc *UploadCommand) upload() (err error) {
 	var artifactsDetailsReader *content.ContentReader = nil
 	if uc.DetailedSummary() || toCollect {
 		var summary *rtServicesUtils.OperationSummary
-		summary, err = servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParamsArray...)
+		summary, successCount, failCount, err = transferWithOnErrorPolicy(failures, "upload", func() (*rtServicesUtils.OperationSummary, int, int, error) {
+			uploadSummary, uploadErr := servicesManager.UploadFilesWithSummary(artifactory.UploadServiceOptions{}, uploadParamsArray...)
+			if uploadSummary == nil {
+				return nil, 0, 0, uploadErr
+			}
+			return uploadSummary, uploadSummary.TotalSucceeded, uploadSummary.TotalFailed, uploadErr
+		})
 		if err != nil {
 			errorOccurred = true
 			log.Error(err)
@@ -155,15 +173,15 @@ func (uc *UploadCommand) upload() (err error) {
 					log.Error(err)
 				}
 			}
-			successCount = summary.TotalSucceeded
-			failCount = summary.TotalFailed
-
 			if err = recordCommandSummary(summary); err != nil {
 				return
 			}
 		}
 	} else {
-		successCount, failCount, err = servicesManager.UploadFiles(artifactory.UploadServiceOptions{}, uploadParamsArray...)
+		_, successCount, failCount, err = transferWithOnErrorPolicy(failures, "upload", func() (*rtServicesUtils.OperationSummary, int, int, error) {
+			uploaded, failed, uploadErr := servicesManager.UploadFiles(artifactory.UploadServiceOptions{}, uploadParamsArray...)
+			return nil, uploaded, failed, uploadErr
+		})
 		if err != nil {
 			errorOccurred = true
 			log.Error(err)
@@ -311,3 +329,63 @@ func readDetailsFromReader(reader *content.ContentReader) (readContent []byte, e
 	}
 	return
 }
+
+// Upload the directory of each spec file as an archive, which is created while it's being uploaded.
+func (uc *UploadCommand) uploadArchiveStreams(servicesManager artifactory.ArtifactoryServicesManager) error {
+	var successCount, failCount int
+	for i := 0; i < len(uc.Spec().Files); i++ {
+		file := uc.Spec().Get(i)
+		targetProps := clientUtils.AddProps(file.TargetProps, file.Props)
+		if err := uc.uploadArchiveStream(servicesManager, file.Pattern, file.Target, targetProps); err != nil {
+			log.Error(err)
+			failCount++
+			continue
+		}
+		successCount++
+	}
+	uc.result.SetSuccessCount(successCount)
+	uc.result.SetFailCount(failCount)
+	if failCount > 0 {
+		return errors.New("upload finished with errors. Review the logs for more information")
+	}
+	return nil
+}
+
+func (uc *UploadCommand) uploadArchiveStream(servicesManager artifactory.ArtifactoryServicesManager, dir, target, targetProps string) (err error) {
+	dirInfo, err := os.Stat(dir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if !dirInfo.IsDir() {
+		return errorutils.CheckErrorf("the archive stream option requires the pattern to be a directory, but '%s' isn't a directory", dir)
+	}
+	target = getArchiveStreamTarget(dir, target, uc.uploadConfiguration.ArchiveStream)
+	if uc.DryRun() {
+		log.Info("[Dry run] Uploading", dir, "as an archive to", target)
+		return nil
+	}
+	log.Info("Uploading", dir, "as an archive to", target)
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	targetUrl := serviceDetails.GetUrl() + target
+	if targetProps != "" {
+		var props *rtServicesUtils.Properties
+		if props, err = rtServicesUtils.ParseProperties(targetProps); err != nil {
+			return
+		}
+		targetUrl += ";" + props.ToEncodedString(false)
+	}
+	archiveStream := utils.NewArchiveStream(dir, uc.uploadConfiguration.ArchiveStream)
+	defer ioutils.Close(archiveStream, &err)
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	// The size of the archive is unknown until it's fully created, so it's uploaded using chunked transfer encoding.
+	_, _, err = servicesManager.Client().UploadFileFromReader(archiveStream, targetUrl, &httpClientDetails, -1)
+	return
+}
+
+// A target ending with a slash is a directory in Artifactory, to which the archive is uploaded, named after the local directory.
+func getArchiveStreamTarget(dir, target string, format utils.ArchiveStreamFormat) string {
+	if strings.HasSuffix(target, "/") {
+		target += filepath.Base(filepath.Clean(dir)) + format.Extension()
+	}
+	return strings.TrimPrefix(target, "/")
+}
@@ -0,0 +1,27 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetArchiveStreamTarget(t *testing.T) {
+	tests := []struct {
+		dir      string
+		target   string
+		format   utils.ArchiveStreamFormat
+		expected string
+	}{
+		{"build/out", "repo/path/", utils.ArchiveStreamTarGz, "repo/path/out.tar.gz"},
+		{"build/out/", "repo/", utils.ArchiveStreamZip, "repo/out.zip"},
+		{"build/out", "repo/path/archive.tgz", utils.ArchiveStreamTarGz, "repo/path/archive.tgz"},
+		{"out", "/repo/", utils.ArchiveStreamTar, "repo/out.tar"},
+	}
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			assert.Equal(t, test.expected, getArchiveStreamTarget(test.dir, test.target, test.format))
+		})
+	}
+}
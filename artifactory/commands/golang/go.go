@@ -31,6 +31,7 @@ type GoCommand struct {
 	resolverParams     *project.RepositoryConfig
 	configFilePath     string
 	noFallback         bool
+	goProxyConfig      *GoProxyConfig
 }
 
 func NewGoCommand() *GoCommand {
@@ -57,6 +58,11 @@ func (gc *GoCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildC
 	return gc
 }
 
+func (gc *GoCommand) SetGoProxyConfig(goProxyConfig *GoProxyConfig) *GoCommand {
+	gc.goProxyConfig = goProxyConfig
+	return gc
+}
+
 func (gc *GoCommand) SetGoArg(goArg []string) *GoCommand {
 	gc.goArg = goArg
 	return gc
@@ -106,6 +112,8 @@ func (gc *GoCommand) Run() error {
 		}
 	}
 
+	gc.goProxyConfig = ReadGoProxyConfig(vConfig)
+
 	// Extract build info information from the args.
 	gc.goArg, gc.buildConfiguration, err = buildUtils.ExtractBuildDetailsFromArgs(gc.goArg)
 	if err != nil {
@@ -153,8 +161,17 @@ func (gc *GoCommand) run() (err error) {
 	if err != nil {
 		return
 	}
-	// If noFallback=false, missing packages will be fetched directly from VCS
-	repoUrl, err := GetArtifactoryRemoteRepoUrl(resolverDetails, gc.resolverParams.TargetRepo(), GoProxyUrlParams{Direct: !gc.noFallback})
+	goProxyParams := GoProxyUrlParams{Direct: !gc.noFallback}
+	if gc.goProxyConfig != nil {
+		if err = gc.goProxyConfig.SetEnv(); err != nil {
+			return
+		}
+		if !gc.noFallback {
+			goProxyParams.Fallbacks = gc.goProxyConfig.Fallbacks
+		}
+	}
+	// If noFallback=false, missing packages will be fetched from the fallback proxies, and then directly from VCS
+	repoUrl, err := GetArtifactoryRemoteRepoUrl(resolverDetails, gc.resolverParams.TargetRepo(), goProxyParams)
 	if err != nil {
 		return
 	}
@@ -365,12 +382,25 @@ type GoProxyUrlParams struct {
 	// The path from baseUrl to the standard Go repository path
 	// URL structure: <baseUrl>/<EndpointPrefix>/api/go/<repoName>
 	EndpointPrefix string
+	// Proxies to retrieve the modules from if the module failed to be retrieved from Artifactory,
+	// before falling back to the source.
+	// example: https://gocenter.io|https://proxy.golang.org|direct
+	Fallbacks []string
 }
 
 func (gdu *GoProxyUrlParams) BuildUrl(url *url.URL, repoName string) string {
 	url.Path = path.Join(url.Path, gdu.EndpointPrefix, "api/go/", repoName)
 
-	return gdu.addDirect(url.String())
+	return gdu.addDirect(gdu.addFallbacks(url.String()))
+}
+
+func (gdu *GoProxyUrlParams) addFallbacks(url string) string {
+	for _, fallback := range gdu.Fallbacks {
+		if fallback = strings.TrimSpace(fallback); fallback != "" && fallback != "direct" {
+			url += "|" + fallback
+		}
+	}
+	return url
 }
 
 func (gdu *GoProxyUrlParams) addDirect(url string) string {
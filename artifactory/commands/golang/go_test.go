@@ -133,6 +133,7 @@ func TestGoProxyUrlParams_BuildUrl(t *testing.T) {
 		RepoName       string
 		Direct         bool
 		EndpointPrefix string
+		Fallbacks      []string
 		ExpectedUrl    string
 	}{
 		{
@@ -152,6 +153,13 @@ func TestGoProxyUrlParams_BuildUrl(t *testing.T) {
 			EndpointPrefix: "prefix",
 			ExpectedUrl:    "https://test/prefix/api/go/go",
 		},
+		{
+			name:        "Url With fallbacks and direct",
+			RepoName:    "go",
+			Direct:      true,
+			Fallbacks:   []string{"https://proxy.golang.org", " ", "direct"},
+			ExpectedUrl: "https://test/api/go/go|https://proxy.golang.org|direct",
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -160,6 +168,7 @@ func TestGoProxyUrlParams_BuildUrl(t *testing.T) {
 			gdu := &GoProxyUrlParams{
 				Direct:         testCase.Direct,
 				EndpointPrefix: testCase.EndpointPrefix,
+				Fallbacks:      testCase.Fallbacks,
 			}
 			assert.Equalf(t, testCase.ExpectedUrl, gdu.BuildUrl(remoteUrl, testCase.RepoName), "BuildUrl(%v, %v)", remoteUrl, testCase.RepoName)
 		})
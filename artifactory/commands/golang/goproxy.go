@@ -0,0 +1,77 @@
+package golang
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/spf13/viper"
+)
+
+// The keys of the 'goProxy' section of the go config file, which configures the modules resolution beyond the Artifactory repository:
+//
+//	goProxy:
+//	  fallbacks: ["https://proxy.golang.org"]
+//	  private: ["github.com/acme/*"]
+//	  noSumDb: ["github.com/acme-public/*"]
+//	  noProxy: ["github.com/acme/internal/*"]
+//	  sumDb: "sum.golang.org"
+const (
+	ProjectConfigGoProxyFallbacksKey = "goProxy.fallbacks"
+	ProjectConfigGoProxyPrivateKey   = "goProxy.private"
+	ProjectConfigGoProxyNoSumDbKey   = "goProxy.noSumDb"
+	ProjectConfigGoProxyNoProxyKey   = "goProxy.noProxy"
+	ProjectConfigGoProxySumDbKey     = "goProxy.sumDb"
+)
+
+// The modules resolution settings of the go commands, in addition to the Artifactory repository.
+type GoProxyConfig struct {
+	// The proxies modules are retrieved from if they fail to be retrieved from Artifactory, in order, before falling back to the VCS.
+	Fallbacks []string
+	// The module path patterns of private modules, which are retrieved directly from the VCS and aren't verified by the checksum database (GOPRIVATE).
+	Private []string
+	// The module path patterns of modules which aren't verified by the checksum database (GONOSUMDB).
+	NoSumDb []string
+	// The module path patterns of modules which are retrieved directly from the VCS, excluding them from Artifactory (GONOPROXY).
+	NoProxy []string
+	// The checksum database modules are verified by (GOSUMDB), such as 'off' or the Artifactory sumdb proxy.
+	SumDb string
+}
+
+// Read the 'goProxy' section of the go config file. Returns an empty configuration if the section doesn't exist.
+func ReadGoProxyConfig(vConfig *viper.Viper) *GoProxyConfig {
+	return &GoProxyConfig{
+		Fallbacks: vConfig.GetStringSlice(ProjectConfigGoProxyFallbacksKey),
+		Private:   vConfig.GetStringSlice(ProjectConfigGoProxyPrivateKey),
+		NoSumDb:   vConfig.GetStringSlice(ProjectConfigGoProxyNoSumDbKey),
+		NoProxy:   vConfig.GetStringSlice(ProjectConfigGoProxyNoProxyKey),
+		SumDb:     vConfig.GetString(ProjectConfigGoProxySumDbKey),
+	}
+}
+
+// Returns the environment variables of the patterns and the checksum database of the configuration.
+// Variables which the configuration doesn't set aren't returned, so their values in the environment of the user are kept.
+func (gpc *GoProxyConfig) GetEnv() map[string]string {
+	env := map[string]string{}
+	for name, patterns := range map[string][]string{"GOPRIVATE": gpc.Private, "GONOSUMDB": gpc.NoSumDb, "GONOPROXY": gpc.NoProxy} {
+		if len(patterns) > 0 {
+			env[name] = strings.Join(patterns, ",")
+		}
+	}
+	if gpc.SumDb != "" {
+		env["GOSUMDB"] = gpc.SumDb
+	}
+	return env
+}
+
+// Set the environment variables of the configuration for the go commands run by the CLI.
+func (gpc *GoProxyConfig) SetEnv() error {
+	for name, value := range gpc.GetEnv() {
+		log.Debug("Setting", name, "to", value)
+		if err := os.Setenv(name, value); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package golang
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGoProxyConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		expectedEnv map[string]string
+	}{
+		{"no goProxy section", "resolver:\n  repo: go-virtual\n", map[string]string{}},
+		{
+			"patterns and sumdb",
+			"goProxy:\n  private: [github.com/acme/*, gitlab.acme.io]\n  noSumDb: [github.com/acme-public/*]\n  noProxy: [github.com/acme/internal/*]\n  sumDb: \"off\"\n",
+			map[string]string{"GOPRIVATE": "github.com/acme/*,gitlab.acme.io", "GONOSUMDB": "github.com/acme-public/*", "GONOPROXY": "github.com/acme/internal/*", "GOSUMDB": "off"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vConfig := viper.New()
+			vConfig.SetConfigType("yaml")
+			require.NoError(t, vConfig.ReadConfig(strings.NewReader(test.config)))
+			assert.Equal(t, test.expectedEnv, ReadGoProxyConfig(vConfig).GetEnv())
+		})
+	}
+}
+
+func TestGoProxyConfigSetEnv(t *testing.T) {
+	t.Setenv("GOPRIVATE", "github.com/user/*")
+	t.Setenv("GONOSUMDB", "")
+	goProxyConfig := &GoProxyConfig{NoSumDb: []string{"github.com/acme/*"}}
+	require.NoError(t, goProxyConfig.SetEnv())
+	// Variables which aren't configured keep their values.
+	assert.Equal(t, "github.com/user/*", os.Getenv("GOPRIVATE"))
+	assert.Equal(t, "github.com/acme/*", os.Getenv("GONOSUMDB"))
+}
@@ -253,6 +253,7 @@ func runGradle(vConfig *viper.Viper, tasks []string, deployableArtifactsFile str
 	if err != nil {
 		return err
 	}
+	plugin = resolveUsePlugin(plugin, tasks)
 	dependencyLocalPath, err := getGradleDependencyLocalPath()
 	if err != nil {
 		return err
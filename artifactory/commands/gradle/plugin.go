@@ -0,0 +1,165 @@
+package gradle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ArtifactoryPluginId = "com.jfrog.artifactory"
+	// The version of the Gradle Artifactory Plugin suggested for applying it in the build scripts.
+	artifactoryPluginVersion = "5.2.5"
+	configurationCacheFlag   = "--configuration-cache"
+	noConfigurationCacheFlag = "--no-configuration-cache"
+	gradlePropertiesFileName = "gradle.properties"
+	projectDirShortFlag      = "-p"
+	projectDirFlag           = "--project-dir"
+)
+
+var (
+	// Matches applying the plugin by its ID, such as 'id "com.jfrog.artifactory" version "5.2.5"', 'id("com.jfrog.artifactory")',
+	// 'apply plugin: "com.jfrog.artifactory"' and 'apply(plugin = "com.jfrog.artifactory")'.
+	artifactoryPluginRegex = regexp.MustCompile(`(?m)^\s*(?:id|apply)\b.*["']` + regexp.QuoteMeta(ArtifactoryPluginId) + `["']`)
+	// The properties which enable the configuration cache in gradle.properties. The second one is used by Gradle versions older than 8.1.
+	configurationCacheProperties = []string{"org.gradle.configuration-cache", "org.gradle.unsafe.configuration-cache"}
+	// The scripts which apply the plugin to the whole build.
+	buildScripts = []string{"settings.gradle", "settings.gradle.kts", "build.gradle", "build.gradle.kts"}
+)
+
+// Returns true if the settings or the root build script of the project applies the Gradle Artifactory Plugin,
+// so the build can run without the init script which applies it.
+// Subprojects usually get the plugin from the root project, so their build scripts aren't read.
+func IsArtifactoryPluginApplied(projectDir string) (bool, error) {
+	for _, buildScript := range buildScripts {
+		content, err := os.ReadFile(filepath.Join(projectDir, buildScript))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, errorutils.CheckError(err)
+		}
+		if artifactoryPluginRegex.Match(content) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Returns the directory of the project which the gradle tasks run on, set by the '-p' or '--project-dir' option,
+// or the working directory if the option isn't set.
+func GetProjectDir(tasks []string) (string, error) {
+	projectDir := ""
+	for i := 0; i < len(tasks); i++ {
+		switch {
+		case tasks[i] == projectDirShortFlag || tasks[i] == projectDirFlag:
+			if i+1 < len(tasks) {
+				i++
+				projectDir = tasks[i]
+			}
+		case strings.HasPrefix(tasks[i], projectDirFlag+"="):
+			projectDir = strings.TrimPrefix(tasks[i], projectDirFlag+"=")
+		}
+	}
+	if projectDir == "" {
+		projectDir = "."
+	}
+	projectDir, err := filepath.Abs(projectDir)
+	return projectDir, errorutils.CheckError(err)
+}
+
+// Returns true if the gradle tasks run with the configuration cache, by the command line flags or by gradle.properties of the project.
+func IsConfigurationCacheEnabled(projectDir string, tasks []string) (enabled bool, err error) {
+	for i := len(tasks) - 1; i >= 0; i-- {
+		switch tasks[i] {
+		case configurationCacheFlag:
+			return true, nil
+		case noConfigurationCacheFlag:
+			return false, nil
+		}
+	}
+	file, err := os.Open(filepath.Join(projectDir, gradlePropertiesFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if found && slices.Contains(configurationCacheProperties, strings.TrimSpace(key)) {
+			return strings.EqualFold(strings.TrimSpace(value), "true"), nil
+		}
+	}
+	return false, errorutils.CheckError(scanner.Err())
+}
+
+// Returns the plugins block which applies the Gradle Artifactory Plugin in the build script, in the Kotlin or the Groovy DSL.
+func GetArtifactoryPluginBlock(kotlinDsl bool) string {
+	if kotlinDsl {
+		return fmt.Sprintf("plugins {\n    id(\"%s\") version \"%s\"\n}", ArtifactoryPluginId, artifactoryPluginVersion)
+	}
+	return fmt.Sprintf("plugins {\n    id \"%s\" version \"%s\"\n}", ArtifactoryPluginId, artifactoryPluginVersion)
+}
+
+func isKotlinDsl(projectDir string) bool {
+	for _, buildScript := range []string{"settings.gradle.kts", "build.gradle.kts"} {
+		if _, err := os.Stat(filepath.Join(projectDir, buildScript)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Decide whether the build runs without the init script which applies the Gradle Artifactory Plugin, and warn about configurations
+// which don't work well together:
+// The plugin is expected to be applied in the build scripts if 'usePlugin' is set. Otherwise, the init script invalidates the configuration cache
+// of gradle on every run, so if the configuration cache is enabled and the plugin is already applied in the build scripts, the init script is skipped.
+// The checks are advisory, so failing to run them doesn't fail the build.
+func resolveUsePlugin(usePlugin bool, tasks []string) bool {
+	projectDir, err := GetProjectDir(tasks)
+	if err != nil {
+		log.Debug("Skipping the Gradle Artifactory Plugin checks:", err.Error())
+		return usePlugin
+	}
+	if !usePlugin {
+		configurationCacheEnabled, err := IsConfigurationCacheEnabled(projectDir, tasks)
+		if err != nil {
+			log.Debug("Skipping the Gradle Artifactory Plugin checks:", err.Error())
+			return usePlugin
+		}
+		if !configurationCacheEnabled {
+			return usePlugin
+		}
+	}
+	applied, err := IsArtifactoryPluginApplied(projectDir)
+	if err != nil {
+		log.Debug("Skipping the Gradle Artifactory Plugin checks:", err.Error())
+		return usePlugin
+	}
+	switch {
+	case usePlugin && !applied:
+		log.Warn(fmt.Sprintf("'usePlugin' is set in the gradle config, but %s wasn't found in the build scripts of the project. "+
+			"If the plugin isn't applied by other means, apply it by adding the following block to the build script:\n%s", ArtifactoryPluginId, GetArtifactoryPluginBlock(isKotlinDsl(projectDir))))
+	case !usePlugin && applied:
+		log.Info("The Gradle Artifactory Plugin is applied in the build scripts of the project, so the build runs without the init script which applies it, " +
+			"to reuse the configuration cache of gradle. Set 'usePlugin' in the gradle config to skip this check.")
+		return true
+	case !usePlugin:
+		log.Warn(fmt.Sprintf("The init script which applies the Gradle Artifactory Plugin invalidates the configuration cache of gradle on every run. "+
+			"To reuse the configuration cache, add the following block to the build script:\n%s", GetArtifactoryPluginBlock(isKotlinDsl(projectDir))))
+	}
+	return usePlugin
+}
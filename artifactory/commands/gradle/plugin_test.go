@@ -0,0 +1,99 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsArtifactoryPluginApplied(t *testing.T) {
+	tests := []struct {
+		name        string
+		buildScript string
+		content     string
+		expected    bool
+	}{
+		{"groovy plugins block", "build.gradle", "plugins {\n    id \"com.jfrog.artifactory\" version \"5.2.5\"\n}", true},
+		{"kotlin plugins block", "build.gradle.kts", "plugins {\n    id(\"com.jfrog.artifactory\") version \"5.2.5\"\n}", true},
+		{"legacy apply", "build.gradle", "apply plugin: 'com.jfrog.artifactory'", true},
+		{"settings plugins block", "settings.gradle", "plugins {\n    id \"com.jfrog.artifactory\" version \"5.2.5\"\n}", true},
+		{"kotlin apply", "build.gradle.kts", "apply(plugin = \"com.jfrog.artifactory\")", true},
+		{"comment", "build.gradle", "// id \"java\" is applied instead of \"com.jfrog.artifactory\"\nplugins {\n    id \"java\"\n}", false},
+		{"subproject", filepath.Join("sub", "build.gradle"), "apply plugin: 'com.jfrog.artifactory'", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projectDir := t.TempDir()
+			buildScriptPath := filepath.Join(projectDir, test.buildScript)
+			require.NoError(t, os.MkdirAll(filepath.Dir(buildScriptPath), 0755))
+			require.NoError(t, os.WriteFile(buildScriptPath, []byte(test.content), 0644))
+			applied, err := IsArtifactoryPluginApplied(projectDir)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, applied)
+		})
+	}
+}
+
+func TestIsConfigurationCacheEnabled(t *testing.T) {
+	tests := []struct {
+		name             string
+		gradleProperties string
+		tasks            []string
+		expected         bool
+	}{
+		{"disabled", "", []string{"build"}, false},
+		{"flag", "", []string{"build", "--configuration-cache"}, true},
+		{"property", "org.gradle.caching=true\norg.gradle.configuration-cache=true\n", []string{"build"}, true},
+		{"unsafe property", "org.gradle.unsafe.configuration-cache = true", []string{"build"}, true},
+		{"flag overrides property", "org.gradle.configuration-cache=true", []string{"build", "--no-configuration-cache"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projectDir := t.TempDir()
+			if test.gradleProperties != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(projectDir, gradlePropertiesFileName), []byte(test.gradleProperties), 0644))
+			}
+			enabled, err := IsConfigurationCacheEnabled(projectDir, test.tasks)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, enabled)
+		})
+	}
+}
+
+func TestGetProjectDir(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	tests := []struct {
+		name     string
+		tasks    []string
+		expected string
+	}{
+		{"working directory", []string{"build"}, wd},
+		{"short flag", []string{"build", "-p", "app"}, filepath.Join(wd, "app")},
+		{"long flag", []string{"--project-dir", "app", "build"}, filepath.Join(wd, "app")},
+		{"long flag with value", []string{"build", "--project-dir=app"}, filepath.Join(wd, "app")},
+		{"missing value", []string{"build", "-p"}, wd},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projectDir, err := GetProjectDir(test.tasks)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, projectDir)
+		})
+	}
+}
+
+func TestResolveUsePlugin(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "build.gradle"), []byte("plugins {\n    id \"com.jfrog.artifactory\" version \"5.2.5\"\n}"), 0644))
+	// The init script is used unless the configuration cache is enabled.
+	assert.False(t, resolveUsePlugin(false, []string{"build", "-p", projectDir}))
+	assert.True(t, resolveUsePlugin(false, []string{"build", "-p", projectDir, "--configuration-cache"}))
+	assert.True(t, resolveUsePlugin(true, []string{"build", "-p", projectDir}))
+
+	// Without the plugin in the build scripts, the init script is required.
+	assert.False(t, resolveUsePlugin(false, []string{"build", "-p", t.TempDir(), "--configuration-cache"}))
+}
@@ -30,6 +30,9 @@ type MvnCommand struct {
 	scanOutputFormat   format.OutputFormat
 	result             *commandsutils.Result
 	deploymentDisabled bool
+	// Resolve the dependencies of the project before the build, and run the build in offline mode.
+	warmUp        bool
+	warmUpThreads int
 	// File path for Maven extractor in which all build's artifacts details will be listed at the end of the build.
 	buildArtifactsDetailsFile string
 }
@@ -63,6 +66,16 @@ func (mc *MvnCommand) SetThreads(threads int) *MvnCommand {
 	return mc
 }
 
+func (mc *MvnCommand) SetWarmUp(warmUp bool) *MvnCommand {
+	mc.warmUp = warmUp
+	return mc
+}
+
+func (mc *MvnCommand) SetWarmUpThreads(warmUpThreads int) *MvnCommand {
+	mc.warmUpThreads = warmUpThreads
+	return mc
+}
+
 func (mc *MvnCommand) SetInsecureTls(insecureTls bool) *MvnCommand {
 	mc.insecureTls = insecureTls
 	return mc
@@ -139,6 +152,11 @@ func (mc *MvnCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	if mc.warmUp {
+		if err = mc.warmUpDependencies(); err != nil {
+			return err
+		}
+	}
 
 	mvnParams := NewMvnUtils().
 		SetConfig(vConfig).
@@ -0,0 +1,183 @@
+package mvn
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Resolves all the dependencies and plugins of the project, so that the build can run in offline mode.
+	warmUpGoal = "dependency:go-offline"
+	// The number of threads the maven resolver downloads artifacts with.
+	warmUpThreadsProperty = "-Dmaven.artifact.threads="
+	defaultWarmUpThreads  = 8
+	warmUpCacheFileName   = "warmup-cache.json"
+	// The projects whose dependencies were resolved before this period aren't considered warm, and are resolved again.
+	warmUpCacheExpiration = 7 * 24 * time.Hour
+	pomFileName           = "pom.xml"
+)
+
+var offlineFlags = []string{"-o", "--offline"}
+
+// The local metadata cache of the warm-up, which holds the time the dependencies of the projects were resolved at,
+// by the checksums of the POM files of the projects.
+type warmUpCache struct {
+	Projects map[string]time.Time `json:"projects,omitempty"`
+}
+
+// Resolve the dependencies of the project through Artifactory, unless they were resolved since the POM files last changed.
+// The goals of the build are then run in offline mode.
+func (mc *MvnCommand) warmUpDependencies() error {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	pomsChecksum, err := calcPomsChecksum(projectDir)
+	if err != nil {
+		return err
+	}
+	cachePath, err := getWarmUpCachePath()
+	if err != nil {
+		return err
+	}
+	cache, err := readWarmUpCache(cachePath)
+	if err != nil {
+		return err
+	}
+	if resolvedAt, ok := cache.Projects[pomsChecksum]; ok && time.Since(resolvedAt) < warmUpCacheExpiration {
+		log.Info("The dependencies of the project were resolved at", resolvedAt.Format(time.RFC3339), "and the POM files haven't changed since. Skipping the dependencies warm-up.")
+	} else {
+		log.Info("Resolving the dependencies of the project with", strconv.Itoa(mc.getWarmUpThreads()), "threads...")
+		// The config is read again, because running maven modifies it.
+		vConfig, err := buildUtils.ReadMavenConfig(mc.configPath, nil)
+		if err != nil {
+			return err
+		}
+		warmUpParams := NewMvnUtils().
+			SetConfig(vConfig).
+			SetGoals(mc.getWarmUpGoals()).
+			SetInsecureTls(mc.insecureTls).
+			SetDisableDeploy(true)
+		if err = RunMvn(warmUpParams); err != nil {
+			return err
+		}
+		cache.Projects[pomsChecksum] = time.Now()
+		if err = cache.write(cachePath); err != nil {
+			return err
+		}
+	}
+	mc.goals = getOfflineGoals(mc.goals)
+	return nil
+}
+
+func (mc *MvnCommand) getWarmUpThreads() int {
+	if mc.warmUpThreads > 0 {
+		return mc.warmUpThreads
+	}
+	return defaultWarmUpThreads
+}
+
+func (mc *MvnCommand) getWarmUpGoals() []string {
+	threads := strconv.Itoa(mc.getWarmUpThreads())
+	return []string{warmUpGoal, "-T", threads, warmUpThreadsProperty + threads}
+}
+
+// Returns the goals of the build with the offline flag.
+func getOfflineGoals(goals []string) []string {
+	if slices.ContainsFunc(goals, func(goal string) bool { return slices.Contains(offlineFlags, goal) }) {
+		return goals
+	}
+	return append(slices.Clone(goals), offlineFlags[0])
+}
+
+// Calculate a checksum of all the POM files of the project, so that any change of the modules or their dependencies changes it.
+// The build outputs and hidden directories are skipped.
+func calcPomsChecksum(projectDir string) (string, error) {
+	hash := sha256.New()
+	err := filepath.WalkDir(projectDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != projectDir && (entry.Name() == "target" || entry.Name()[0] == '.') {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != pomFileName {
+			return nil
+		}
+		details, err := fileutils.GetFileDetails(path, true)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return err
+		}
+		_, err = hash.Write([]byte(filepath.ToSlash(relativePath) + ":" + details.Checksum.Sha256 + "\n"))
+		return err
+	})
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func getWarmUpCachePath() (string, error) {
+	dependenciesPath, err := config.GetJfrogDependenciesPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dependenciesPath, "maven", warmUpCacheFileName), nil
+}
+
+func readWarmUpCache(cachePath string) (*warmUpCache, error) {
+	cache := &warmUpCache{Projects: map[string]time.Time{}}
+	content, err := os.ReadFile(cachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, cache); err != nil {
+		// A corrupted cache only causes the dependencies to be resolved again.
+		log.Debug("Ignoring the corrupted maven warm-up cache:", err.Error())
+		return &warmUpCache{Projects: map[string]time.Time{}}, nil
+	}
+	if cache.Projects == nil {
+		cache.Projects = map[string]time.Time{}
+	}
+	return cache, nil
+}
+
+// Write the cache, without the expired projects.
+func (cache *warmUpCache) write(cachePath string) error {
+	for pomsChecksum, resolvedAt := range cache.Projects {
+		if time.Since(resolvedAt) >= warmUpCacheExpiration {
+			delete(cache.Projects, pomsChecksum)
+		}
+	}
+	content, err := json.Marshal(cache)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(cachePath)); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(cachePath, content, 0600))
+}
@@ -0,0 +1,69 @@
+package mvn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOfflineGoals(t *testing.T) {
+	tests := []struct {
+		goals    []string
+		expected []string
+	}{
+		{[]string{"clean", "install"}, []string{"clean", "install", "-o"}},
+		{[]string{"install", "-o"}, []string{"install", "-o"}},
+		{[]string{"--offline", "verify"}, []string{"--offline", "verify"}},
+		{nil, []string{"-o"}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getOfflineGoals(test.goals))
+	}
+}
+
+func TestCalcPomsChecksum(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "module", "target"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, pomFileName), []byte("<project/>"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "module", pomFileName), []byte("<project>module</project>"), 0644))
+	checksum, err := calcPomsChecksum(projectDir)
+	require.NoError(t, err)
+
+	// The POM files of the build outputs don't change the checksum.
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "module", "target", pomFileName), []byte("<project>output</project>"), 0644))
+	unchangedChecksum, err := calcPomsChecksum(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, checksum, unchangedChecksum)
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "module", pomFileName), []byte("<project>changed</project>"), 0644))
+	changedChecksum, err := calcPomsChecksum(projectDir)
+	require.NoError(t, err)
+	assert.NotEqual(t, checksum, changedChecksum)
+}
+
+func TestWarmUpCache(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "maven", warmUpCacheFileName)
+	cache, err := readWarmUpCache(cachePath)
+	require.NoError(t, err)
+	assert.Empty(t, cache.Projects)
+
+	resolvedAt := time.Now().Truncate(time.Second)
+	cache.Projects["recent"] = resolvedAt
+	cache.Projects["expired"] = resolvedAt.Add(-warmUpCacheExpiration)
+	require.NoError(t, cache.write(cachePath))
+
+	cache, err = readWarmUpCache(cachePath)
+	require.NoError(t, err)
+	require.Len(t, cache.Projects, 1)
+	assert.True(t, resolvedAt.Equal(cache.Projects["recent"]))
+
+	// A corrupted cache is ignored.
+	require.NoError(t, os.WriteFile(cachePath, []byte("{"), 0600))
+	cache, err = readWarmUpCache(cachePath)
+	require.NoError(t, err)
+	assert.Empty(t, cache.Projects)
+}
@@ -12,6 +12,7 @@ import (
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/pnpm"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
@@ -33,6 +34,9 @@ import (
 
 const (
 	DistTagPropKey = "npm.disttag"
+
+	npmPackageManager  = "npm"
+	pnpmPackageManager = "pnpm"
 	// The --pack-destination argument of npm pack was introduced in npm version 7.18.0.
 	packDestinationNpmMinVersion = "7.18.0"
 )
@@ -53,8 +57,10 @@ type NpmPublishCommandArgs struct {
 }
 
 type NpmPublishCommand struct {
-	configFilePath  string
-	commandName     string
+	configFilePath string
+	commandName    string
+	// The client packing the package, npm or pnpm.
+	packageManager  string
 	result          *commandsutils.Result
 	detailedSummary bool
 	npmVersion      *version.Version
@@ -62,7 +68,15 @@ type NpmPublishCommand struct {
 }
 
 func NewNpmPublishCommand() *NpmPublishCommand {
-	return &NpmPublishCommand{NpmPublishCommandArgs: NewNpmPublishCommandArgs(), commandName: "rt_npm_publish", result: new(commandsutils.Result)}
+	return &NpmPublishCommand{NpmPublishCommandArgs: NewNpmPublishCommandArgs(), commandName: "rt_npm_publish", packageManager: npmPackageManager, result: new(commandsutils.Result)}
+}
+
+// Pack the package with pnpm rather than with npm. The package is deployed to Artifactory in the same way.
+func NewPnpmPublishCommand() *NpmPublishCommand {
+	npc := NewNpmPublishCommand()
+	npc.commandName = "rt_pnpm_publish"
+	npc.packageManager = pnpmPackageManager
+	return npc
 }
 
 func NewNpmPublishCommandArgs() *NpmPublishCommandArgs {
@@ -121,6 +135,11 @@ func (npc *NpmPublishCommand) Init() error {
 	if err != nil {
 		return err
 	}
+	if npc.packageManager == pnpmPackageManager {
+		if npc.executablePath, err = pnpm.GetExecutablePath(); err != nil {
+			return err
+		}
+	}
 	detailedSummary, xrayScan, scanOutputFormat, filteredNpmArgs, buildConfiguration, err := commandsutils.ExtractNpmOptionsFromArgs(npc.NpmPublishCommandArgs.npmArgs)
 	if err != nil {
 		return err
@@ -262,7 +281,13 @@ func (npc *NpmPublishCommand) preparePrerequisites() error {
 
 func (npc *NpmPublishCommand) pack() error {
 	log.Debug("Creating npm package.")
-	packedFileNames, err := npm.Pack(npc.npmArgs, npc.executablePath)
+	var packedFileNames []string
+	var err error
+	if npc.packageManager == pnpmPackageManager {
+		packedFileNames, err = pnpm.Pack(npc.npmArgs, npc.executablePath)
+	} else {
+		packedFileNames, err = npm.Pack(npc.npmArgs, npc.executablePath)
+	}
 	if err != nil {
 		return err
 	}
@@ -273,6 +298,10 @@ func (npc *NpmPublishCommand) pack() error {
 	}
 
 	for _, packageFileName := range packedFileNames {
+		if filepath.IsAbs(packageFileName) {
+			npc.packedFilePaths = append(npc.packedFilePaths, packageFileName)
+			continue
+		}
 		npc.packedFilePaths = append(npc.packedFilePaths, filepath.Join(tarballDir, packageFileName))
 	}
 
@@ -0,0 +1,167 @@
+package oci
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/oci"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Pulls the files of an OCI artifact from an Artifactory OCI repository into a local directory.
+// Each layer is downloaded as a file, named by its title annotation.
+type OciPullCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	repo               string
+	reference          string
+	targetDir          string
+	mediaTypes         []string
+	pulledFiles        []string
+}
+
+func NewOciPullCommand() *OciPullCommand {
+	return &OciPullCommand{}
+}
+
+func (opc *OciPullCommand) SetServerDetails(serverDetails *config.ServerDetails) *OciPullCommand {
+	opc.serverDetails = serverDetails
+	return opc
+}
+
+func (opc *OciPullCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *OciPullCommand {
+	opc.buildConfiguration = buildConfiguration
+	return opc
+}
+
+func (opc *OciPullCommand) SetRepo(repo string) *OciPullCommand {
+	opc.repo = repo
+	return opc
+}
+
+// Set the image path and the tag or digest of the artifact in the repository, e.g. 'charts/my-chart:1.0.0'.
+func (opc *OciPullCommand) SetReference(reference string) *OciPullCommand {
+	opc.reference = reference
+	return opc
+}
+
+func (opc *OciPullCommand) SetTargetDir(targetDir string) *OciPullCommand {
+	opc.targetDir = targetDir
+	return opc
+}
+
+// Pull only the layers of these media types. All the layers are pulled if empty.
+func (opc *OciPullCommand) SetMediaTypes(mediaTypes []string) *OciPullCommand {
+	opc.mediaTypes = mediaTypes
+	return opc
+}
+
+// Returns the paths of the pulled files.
+func (opc *OciPullCommand) PulledFiles() []string {
+	return opc.pulledFiles
+}
+
+func (opc *OciPullCommand) CommandName() string {
+	return "rt_oci_pull"
+}
+
+func (opc *OciPullCommand) ServerDetails() (*config.ServerDetails, error) {
+	return opc.serverDetails, nil
+}
+
+func (opc *OciPullCommand) Run() error {
+	image, reference, err := oci.ParseReference(opc.reference)
+	if err != nil {
+		return err
+	}
+	if reference == "" {
+		reference = "latest"
+	}
+	targetDir := opc.targetDir
+	if targetDir == "" {
+		targetDir = "."
+	}
+	if err = os.MkdirAll(targetDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	servicesManager, err := utils.CreateServiceManager(opc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	client := oci.NewClient(servicesManager, opc.repo, image)
+	manifest, digest, content, err := client.GetManifest(reference)
+	if err != nil {
+		return err
+	}
+	manifestDetails, err := fileutils.GetFileDetailsFromReader(bytes.NewReader(content), true)
+	if err != nil {
+		return err
+	}
+	dependencies := []buildinfo.Dependency{{Id: "manifest.json", Type: "json", Checksum: manifestDetails.Checksum}}
+	for _, layer := range filterLayers(manifest.Layers, opc.mediaTypes) {
+		log.Info(fmt.Sprintf("Pulling %s (%s)...", layer.FileName(), layer.MediaType))
+		localPath, details, err := client.DownloadBlob(layer, targetDir)
+		if err != nil {
+			return err
+		}
+		opc.pulledFiles = append(opc.pulledFiles, localPath)
+		dependencies = append(dependencies, buildinfo.Dependency{Id: layer.FileName(), Type: layer.MediaType, Checksum: details.Checksum})
+	}
+	log.Info(fmt.Sprintf("Pulled %d files of %s/%s@%s.", len(opc.pulledFiles), opc.repo, image, digest))
+	return opc.collectBuildInfo(image, reference, digest, dependencies)
+}
+
+func filterLayers(layers []oci.Descriptor, mediaTypes []string) []oci.Descriptor {
+	if len(mediaTypes) == 0 {
+		return layers
+	}
+	var filtered []oci.Descriptor
+	for _, layer := range layers {
+		if slices.Contains(mediaTypes, layer.MediaType) {
+			filtered = append(filtered, layer)
+		}
+	}
+	return filtered
+}
+
+func (opc *OciPullCommand) collectBuildInfo(image, reference, digest string, dependencies []buildinfo.Dependency) error {
+	toCollect, err := opc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	buildName, err := opc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := opc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := opc.buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return err
+	}
+	module := opc.buildConfiguration.GetModule()
+	if module == "" {
+		module = image + ":" + reference
+		if strings.HasPrefix(reference, "sha256:") {
+			module = image + "@" + reference
+		}
+	}
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
+		Id:           module,
+		Type:         buildinfo.Docker,
+		Dependencies: dependencies,
+		Properties:   map[string]string{"docker.manifest.digest": digest},
+	}}}
+	return build.SaveBuildInfo(buildName, buildNumber, project, buildInfo)
+}
@@ -0,0 +1,242 @@
+package oci
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/oci"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Pushes local files as an OCI artifact, such as a Helm chart, a WASM module, a signature or an attestation, to an Artifactory OCI repository.
+// Each file is a layer of the artifact, and the artifact has an empty config, as done by ORAS.
+type OciPushCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *build.BuildConfiguration
+	repo               string
+	reference          string
+	files              []oci.File
+	artifactType       string
+	annotations        map[string]string
+	subject            string
+	digest             string
+}
+
+func NewOciPushCommand() *OciPushCommand {
+	return &OciPushCommand{}
+}
+
+func (opc *OciPushCommand) SetServerDetails(serverDetails *config.ServerDetails) *OciPushCommand {
+	opc.serverDetails = serverDetails
+	return opc
+}
+
+func (opc *OciPushCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *OciPushCommand {
+	opc.buildConfiguration = buildConfiguration
+	return opc
+}
+
+func (opc *OciPushCommand) SetRepo(repo string) *OciPushCommand {
+	opc.repo = repo
+	return opc
+}
+
+// Set the image path and tag of the artifact in the repository, e.g. 'charts/my-chart:1.0.0'.
+func (opc *OciPushCommand) SetReference(reference string) *OciPushCommand {
+	opc.reference = reference
+	return opc
+}
+
+func (opc *OciPushCommand) SetFiles(files []oci.File) *OciPushCommand {
+	opc.files = files
+	return opc
+}
+
+func (opc *OciPushCommand) SetArtifactType(artifactType string) *OciPushCommand {
+	opc.artifactType = artifactType
+	return opc
+}
+
+func (opc *OciPushCommand) SetAnnotations(annotations map[string]string) *OciPushCommand {
+	opc.annotations = annotations
+	return opc
+}
+
+// Set the tag or digest of the manifest the artifact refers to, in the same image path, e.g. for signatures and attestations.
+func (opc *OciPushCommand) SetSubject(subject string) *OciPushCommand {
+	opc.subject = subject
+	return opc
+}
+
+// Returns the digest of the pushed manifest.
+func (opc *OciPushCommand) Digest() string {
+	return opc.digest
+}
+
+func (opc *OciPushCommand) CommandName() string {
+	return "rt_oci_push"
+}
+
+func (opc *OciPushCommand) ServerDetails() (*config.ServerDetails, error) {
+	return opc.serverDetails, nil
+}
+
+func (opc *OciPushCommand) Run() error {
+	if len(opc.files) == 0 {
+		return errorutils.CheckErrorf("no files to push were provided")
+	}
+	image, tag, err := oci.ParseReference(opc.reference)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(tag, "sha256:") {
+		return errorutils.CheckErrorf("an OCI artifact is pushed by a tag, rather than by a digest")
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	servicesManager, err := utils.CreateServiceManager(opc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	client := oci.NewClient(servicesManager, opc.repo, image)
+	manifest, layersDetails, err := opc.pushBlobs(client)
+	if err != nil {
+		return err
+	}
+	if opc.subject != "" {
+		if manifest.Subject, err = getSubjectDescriptor(client, opc.subject); err != nil {
+			return err
+		}
+	}
+	digest, content, err := client.PutManifest(tag, manifest)
+	if err != nil {
+		return err
+	}
+	opc.digest = digest
+	log.Info(fmt.Sprintf("Pushed %s/%s:%s with digest %s.", opc.repo, image, tag, digest))
+	return opc.collectBuildInfo(servicesManager, image, tag, content, manifest, layersDetails)
+}
+
+// Push the files and the config. Returns the manifest of the artifact, and the details of its layers.
+func (opc *OciPushCommand) pushBlobs(client *oci.Client) (*oci.Manifest, []*fileutils.FileDetails, error) {
+	artifactType := opc.artifactType
+	if artifactType == "" {
+		artifactType = oci.DefaultArtifactType
+	}
+	manifest := &oci.Manifest{SchemaVersion: 2, MediaType: oci.MediaTypeImageManifest, ArtifactType: artifactType, Annotations: opc.annotations, Layers: []oci.Descriptor{}}
+	var layersDetails []*fileutils.FileDetails
+	for _, file := range opc.files {
+		layer, details, err := oci.CreateFileDescriptor(file.Path, file.MediaType)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Info(fmt.Sprintf("Pushing %s (%s)...", file.Path, layer.MediaType))
+		if err = client.UploadBlob(file.Path, nil, layer.Digest); err != nil {
+			return nil, nil, err
+		}
+		manifest.Layers = append(manifest.Layers, layer)
+		layersDetails = append(layersDetails, details)
+	}
+	var err error
+	manifest.Config, err = client.UploadEmptyConfig()
+	return manifest, layersDetails, err
+}
+
+func getSubjectDescriptor(client *oci.Client, subject string) (*oci.Descriptor, error) {
+	subjectManifest, digest, content, err := client.GetManifest(subject)
+	if err != nil {
+		return nil, err
+	}
+	mediaType := subjectManifest.MediaType
+	if mediaType == "" {
+		mediaType = oci.MediaTypeImageManifest
+	}
+	return &oci.Descriptor{MediaType: mediaType, Digest: digest, Size: int64(len(content)), ArtifactType: subjectManifest.ArtifactType}, nil
+}
+
+// The artifact is stored in the tag folder, where its manifest is named manifest.json, and its blobs are named by their digests.
+func (opc *OciPushCommand) collectBuildInfo(servicesManager artifactory.ArtifactoryServicesManager, image, tag string, manifestContent []byte, manifest *oci.Manifest, layersDetails []*fileutils.FileDetails) error {
+	toCollect, err := opc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	buildName, err := opc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := opc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	project := opc.buildConfiguration.GetProject()
+	if err = build.SaveBuildGeneralDetails(buildName, buildNumber, project); err != nil {
+		return err
+	}
+	tagPath := path.Join(image, tag)
+	manifestDetails, err := fileutils.GetFileDetailsFromReader(bytes.NewReader(manifestContent), true)
+	if err != nil {
+		return err
+	}
+	artifacts := []buildinfo.Artifact{{Name: "manifest.json", Type: "json", Checksum: manifestDetails.Checksum, Path: path.Join(tagPath, "manifest.json"), OriginalDeploymentRepo: opc.repo}}
+	for i, layer := range manifest.Layers {
+		artifacts = append(artifacts, createBlobArtifact(layer, layersDetails[i].Checksum, tagPath, opc.repo))
+	}
+	configDetails, err := fileutils.GetFileDetailsFromReader(strings.NewReader("{}"), true)
+	if err != nil {
+		return err
+	}
+	artifacts = append(artifacts, createBlobArtifact(manifest.Config, configDetails.Checksum, tagPath, opc.repo))
+	if err = setBuildProperties(servicesManager, path.Join(opc.repo, tagPath, "*"), buildName, buildNumber, project); err != nil {
+		return err
+	}
+	module := opc.buildConfiguration.GetModule()
+	if module == "" {
+		module = image + ":" + tag
+	}
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
+		Id:        module,
+		Type:      buildinfo.Docker,
+		Artifacts: artifacts,
+		Properties: map[string]string{
+			"docker.image.tag":       image + ":" + tag,
+			"docker.manifest.digest": opc.digest,
+			"oci.artifact.type":      manifest.ArtifactType,
+		},
+	}}}
+	return build.SaveBuildInfo(buildName, buildNumber, project, buildInfo)
+}
+
+func createBlobArtifact(descriptor oci.Descriptor, checksum buildinfo.Checksum, tagPath, repo string) buildinfo.Artifact {
+	name := strings.Replace(descriptor.Digest, ":", "__", 1)
+	return buildinfo.Artifact{Name: name, Type: descriptor.MediaType, Checksum: checksum, Path: path.Join(tagPath, name), OriginalDeploymentRepo: repo}
+}
+
+// Set the build properties on the files of the artifact in Artifactory, so that the build can be promoted.
+func setBuildProperties(servicesManager artifactory.ArtifactoryServicesManager, pattern, buildName, buildNumber, project string) (err error) {
+	props, err := build.CreateBuildProperties(buildName, buildNumber, project)
+	if err != nil {
+		return
+	}
+	searchParams := services.NewSearchParams()
+	searchParams.CommonParams = &servicesutils.CommonParams{Pattern: pattern}
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return
+	}
+	defer ioutils.Close(reader, &err)
+	_, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props})
+	return
+}
@@ -0,0 +1,128 @@
+package permissiontarget
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A field of the permission target which differs between the live server and the template.
+type PermissionTargetChange struct {
+	Field    string `json:"field" col-name:"Field"`
+	Live     string `json:"live" col-name:"Live"`
+	Template string `json:"template" col-name:"Template"`
+}
+
+// Compare a permission target template with the permission target on the server, without changing it.
+type PermissionTargetDiffCommand struct {
+	PermissionTargetCommand
+}
+
+func NewPermissionTargetDiffCommand() *PermissionTargetDiffCommand {
+	return &PermissionTargetDiffCommand{}
+}
+
+func (ptdc *PermissionTargetDiffCommand) SetTemplatePath(path string) *PermissionTargetDiffCommand {
+	ptdc.templatePath = path
+	return ptdc
+}
+
+func (ptdc *PermissionTargetDiffCommand) SetVars(vars string) *PermissionTargetDiffCommand {
+	ptdc.vars = vars
+	return ptdc
+}
+
+func (ptdc *PermissionTargetDiffCommand) SetServerDetails(serverDetails *config.ServerDetails) *PermissionTargetDiffCommand {
+	ptdc.serverDetails = serverDetails
+	return ptdc
+}
+
+func (ptdc *PermissionTargetDiffCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ptdc.serverDetails, nil
+}
+
+func (ptdc *PermissionTargetDiffCommand) CommandName() string {
+	return "rt_permission_target_diff"
+}
+
+func (ptdc *PermissionTargetDiffCommand) Run() (err error) {
+	params, err := ptdc.getPermissionTargetParams()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(ptdc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	livePermissionTarget, err := servicesManager.GetPermissionTarget(params.Name)
+	if err != nil {
+		return err
+	}
+	if livePermissionTarget == nil {
+		log.Info(fmt.Sprintf("The permission target '%s' doesn't exist and would be created.", params.Name))
+		livePermissionTarget = &services.PermissionTargetParams{Name: params.Name}
+	}
+	changes := diffPermissionTargets(livePermissionTarget, &params)
+	return coreutils.PrintTable(changes, "Permission Target Changes", fmt.Sprintf("The permission target '%s' is up to date.", params.Name), false)
+}
+
+func diffPermissionTargets(live, template *services.PermissionTargetParams) []PermissionTargetChange {
+	var changes []PermissionTargetChange
+	changes = append(changes, diffPermissionSections(Repo, live.Repo, template.Repo)...)
+	changes = append(changes, diffPermissionSections(Build, live.Build, template.Build)...)
+	changes = append(changes, diffPermissionSections(ReleaseBundle, live.ReleaseBundle, template.ReleaseBundle)...)
+	return changes
+}
+
+func diffPermissionSections(sectionName string, live, template *services.PermissionTargetSection) []PermissionTargetChange {
+	liveFields := flattenPermissionSection(sectionName, live)
+	templateFields := flattenPermissionSection(sectionName, template)
+	var changes []PermissionTargetChange
+	for field, templateValue := range templateFields {
+		if liveFields[field] != templateValue {
+			changes = append(changes, PermissionTargetChange{Field: field, Live: liveFields[field], Template: templateValue})
+		}
+	}
+	for field, liveValue := range liveFields {
+		if _, exists := templateFields[field]; !exists {
+			changes = append(changes, PermissionTargetChange{Field: field, Live: liveValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// Flatten the section to a map of field paths, such as repo.actions.users.admin, to their sorted comma separated values.
+func flattenPermissionSection(sectionName string, section *services.PermissionTargetSection) map[string]string {
+	fields := make(map[string]string)
+	if section == nil {
+		return fields
+	}
+	addField := func(field string, values []string) {
+		if len(values) > 0 {
+			sortedValues := append([]string{}, values...)
+			sort.Strings(sortedValues)
+			fields[sectionName+"."+field] = strings.Join(sortedValues, ",")
+		}
+	}
+	addField("repositories", section.Repositories)
+	addField("include-patterns", section.IncludePatterns)
+	addField("exclude-patterns", section.ExcludePatterns)
+	if section.Actions != nil {
+		for user, actions := range section.Actions.Users {
+			addField("actions.users."+user, actions)
+		}
+		for group, actions := range section.Actions.Groups {
+			addField("actions.groups."+group, actions)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,77 @@
+package permissiontarget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPermissionTargetYamlTemplate = `name: ${team}-permissions
+repo:
+  repositories: ${team}-local,${team}-remote
+  include-patterns: "**"
+  actions-users:
+    admin: read,write,manage
+  actions-groups:
+    ${team}: read
+build:
+  include-patterns: "**"
+  actions-groups:
+    ${team}: read,annotate
+`
+
+func TestGetPermissionTargetParamsFromYaml(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "permission-target.yaml")
+	require.NoError(t, os.WriteFile(templatePath, []byte(testPermissionTargetYamlTemplate), 0600))
+	params, err := NewPermissionTargetDiffCommand().SetTemplatePath(templatePath).SetVars("team=web").getPermissionTargetParams()
+	require.NoError(t, err)
+	assert.Equal(t, "web-permissions", params.Name)
+	require.NotNil(t, params.Repo)
+	assert.Equal(t, []string{"web-local", "web-remote"}, params.Repo.Repositories)
+	assert.Equal(t, map[string][]string{"admin": {"read", "write", "manage"}}, params.Repo.Actions.Users)
+	require.NotNil(t, params.Build)
+	assert.Equal(t, []string{DefaultBuildRepositoriesValue}, params.Build.Repositories)
+	assert.Nil(t, params.ReleaseBundle)
+}
+
+func TestDiffPermissionTargets(t *testing.T) {
+	live := &services.PermissionTargetParams{
+		Name: "web-permissions",
+		Repo: &services.PermissionTargetSection{
+			Repositories:    []string{"web-remote", "web-local"},
+			IncludePatterns: []string{"**"},
+			Actions: &services.Actions{
+				Users:  map[string][]string{"admin": {"manage", "read", "write"}, "alice": {"read"}},
+				Groups: map[string][]string{"web": {"read"}},
+			},
+		},
+	}
+	template := &services.PermissionTargetParams{
+		Name: "web-permissions",
+		Repo: &services.PermissionTargetSection{
+			Repositories:    []string{"web-local", "web-remote"},
+			IncludePatterns: []string{"**"},
+			ExcludePatterns: []string{"secret/**"},
+			Actions: &services.Actions{
+				Users:  map[string][]string{"admin": {"read", "write", "manage"}},
+				Groups: map[string][]string{"web": {"read", "write"}},
+			},
+		},
+		Build: &services.PermissionTargetSection{
+			Repositories: []string{DefaultBuildRepositoriesValue},
+			Actions:      &services.Actions{Groups: map[string][]string{"web": {"read"}}},
+		},
+	}
+	assert.Equal(t, []PermissionTargetChange{
+		{Field: "repo.actions.groups.web", Live: "read", Template: "read,write"},
+		{Field: "repo.actions.users.alice", Live: "read"},
+		{Field: "repo.exclude-patterns", Template: "secret/**"},
+		{Field: "build.actions.groups.web", Template: "read"},
+		{Field: "build.repositories", Template: DefaultBuildRepositoriesValue},
+	}, diffPermissionTargets(live, template))
+	assert.Empty(t, diffPermissionTargets(template, template))
+}
@@ -0,0 +1,99 @@
+package permissiontarget
+
+import (
+	"encoding/json"
+	"net/http"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const permissionTargetsRestApi = "api/v2/security/permissions"
+
+type permissionTargetSummary struct {
+	Name string `json:"name" col-name:"Name"`
+	Uri  string `json:"uri" col-name:"URI"`
+}
+
+type PermissionTargetListCommand struct {
+	serverDetails *config.ServerDetails
+	format        format.OutputFormat
+}
+
+func NewPermissionTargetListCommand() *PermissionTargetListCommand {
+	return &PermissionTargetListCommand{}
+}
+
+func (ptlc *PermissionTargetListCommand) SetServerDetails(serverDetails *config.ServerDetails) *PermissionTargetListCommand {
+	ptlc.serverDetails = serverDetails
+	return ptlc
+}
+
+// In the JSON format, the full definition of each of the permission targets is written.
+func (ptlc *PermissionTargetListCommand) SetFormat(format format.OutputFormat) *PermissionTargetListCommand {
+	ptlc.format = format
+	return ptlc
+}
+
+func (ptlc *PermissionTargetListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ptlc.serverDetails, nil
+}
+
+func (ptlc *PermissionTargetListCommand) CommandName() string {
+	return "rt_permission_target_list"
+}
+
+func (ptlc *PermissionTargetListCommand) Run() error {
+	servicesManager, err := rtUtils.CreateServiceManager(ptlc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	summaries, err := getPermissionTargetSummaries(servicesManager)
+	if err != nil {
+		return err
+	}
+	if ptlc.format != format.Json {
+		return coreutils.PrintTable(summaries, "Permission Targets", "No permission targets were found", false)
+	}
+	permissionTargets := make([]*services.PermissionTargetParams, 0, len(summaries))
+	for _, summary := range summaries {
+		permissionTarget, err := servicesManager.GetPermissionTarget(summary.Name)
+		if err != nil {
+			return err
+		}
+		// The permission target may have been deleted since it was listed
+		if permissionTarget != nil {
+			permissionTargets = append(permissionTargets, permissionTarget)
+		}
+	}
+	content, err := json.Marshal(permissionTargets)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+func getPermissionTargetSummaries(servicesManager artifactory.ArtifactoryServicesManager) ([]permissionTargetSummary, error) {
+	rtDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := rtDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(rtDetails.GetUrl()+permissionTargetsRestApi, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var summaries []permissionTargetSummary
+	if err = json.Unmarshal(body, &summaries); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return summaries, nil
+}
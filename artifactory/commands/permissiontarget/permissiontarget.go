@@ -27,17 +27,34 @@ func (ptc *PermissionTargetCommand) TemplatePath() string {
 }
 
 func (ptc *PermissionTargetCommand) PerformPermissionTargetCmd(isUpdate bool) (err error) {
-	permissionTargetConfigMap, err := utils.ConvertTemplateToMap(ptc)
+	params, err := ptc.getPermissionTargetParams()
 	if err != nil {
 		return err
 	}
+	servicesManager, err := rtUtils.CreateServiceManager(ptc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if isUpdate {
+		return servicesManager.UpdatePermissionTarget(params)
+	}
+	return servicesManager.CreatePermissionTarget(params)
+}
+
+// Read the JSON or YAML template, replace its vars and convert it to the permission target params.
+func (ptc *PermissionTargetCommand) getPermissionTargetParams() (params services.PermissionTargetParams, err error) {
+	permissionTargetConfigMap, err := utils.ConvertTemplateToMap(ptc)
+	if err != nil {
+		return
+	}
 	// Go over the confMap and write the values with the correct types
 	for key, value := range permissionTargetConfigMap {
 		isBuildSection := false
 		switch key {
 		case Name:
 			if _, ok := value.(string); !ok {
-				return errorutils.CheckErrorf("template syntax error: the value for the  key: \"Name\" is not a string type.")
+				err = errorutils.CheckErrorf("template syntax error: the value for the  key: \"Name\" is not a string type.")
+				return
 			}
 		case Build:
 			isBuildSection = true
@@ -47,31 +64,22 @@ func (ptc *PermissionTargetCommand) PerformPermissionTargetCmd(isUpdate bool) (e
 		case ReleaseBundle:
 			permissionSection, err := covertPermissionSection(value, isBuildSection)
 			if err != nil {
-				return err
+				return params, err
 			}
 			permissionTargetConfigMap[key] = permissionSection
 		default:
-			return errorutils.CheckErrorf("template syntax error: unknown key: \"" + key + "\".")
+			err = errorutils.CheckErrorf("template syntax error: unknown key: \"" + key + "\".")
+			return
 		}
 	}
 	// Convert the new JSON with the correct types to params struct
 	content, err := json.Marshal(permissionTargetConfigMap)
 	if errorutils.CheckError(err) != nil {
-		return err
+		return
 	}
-	params := services.NewPermissionTargetParams()
-	err = json.Unmarshal(content, &params)
-	if errorutils.CheckError(err) != nil {
-		return err
-	}
-	servicesManager, err := rtUtils.CreateServiceManager(ptc.serverDetails, -1, 0, false)
-	if err != nil {
-		return err
-	}
-	if isUpdate {
-		return servicesManager.UpdatePermissionTarget(params)
-	}
-	return servicesManager.CreatePermissionTarget(params)
+	params = services.NewPermissionTargetParams()
+	err = errorutils.CheckError(json.Unmarshal(content, &params))
+	return
 }
 
 // Each section is a map of string->interface{}. We need to convert each value to its correct type
@@ -0,0 +1,333 @@
+package pnpm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/yarn"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/pnpm"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	npmrcFileName       = ".npmrc"
+	npmrcBackupFileName = "jfrog.npmrc.backup"
+	defaultThreads      = 3
+)
+
+// The pnpm commands which install dependencies, and therefore update the lockfile and the virtual store.
+var installCommands = []string{"install", "i", "add", "update", "up", "upgrade", "remove", "rm", "uninstall", "un"}
+
+// Runs pnpm commands, such as 'pnpm install' and 'pnpm audit', with the dependencies resolved from an Artifactory npm repository.
+// The build-info of the installing commands is collected from pnpm-lock.yaml, with a module for each project of the workspace.
+type PnpmCommand struct {
+	cmdName            string
+	pnpmArgs           []string
+	configFilePath     string
+	repo               string
+	threads            int
+	executablePath     string
+	workingDirectory   string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *buildUtils.BuildConfiguration
+}
+
+func NewPnpmCommand(cmdName string) *PnpmCommand {
+	return &PnpmCommand{cmdName: cmdName, threads: defaultThreads}
+}
+
+func (pc *PnpmCommand) CommandName() string {
+	return "rt_pnpm_" + pc.cmdName
+}
+
+func (pc *PnpmCommand) SetConfigFilePath(configFilePath string) *PnpmCommand {
+	pc.configFilePath = configFilePath
+	return pc
+}
+
+func (pc *PnpmCommand) SetArgs(args []string) *PnpmCommand {
+	pc.pnpmArgs = args
+	return pc
+}
+
+func (pc *PnpmCommand) SetServerDetails(serverDetails *config.ServerDetails) *PnpmCommand {
+	pc.serverDetails = serverDetails
+	return pc
+}
+
+func (pc *PnpmCommand) SetRepo(repo string) *PnpmCommand {
+	pc.repo = repo
+	return pc
+}
+
+func (pc *PnpmCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *PnpmCommand {
+	pc.buildConfiguration = buildConfiguration
+	return pc
+}
+
+func (pc *PnpmCommand) SetThreads(threads int) *PnpmCommand {
+	pc.threads = threads
+	return pc
+}
+
+func (pc *PnpmCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pc.serverDetails, nil
+}
+
+// Read the resolver of the config file, and extract the JFrog CLI flags from the pnpm arguments.
+func (pc *PnpmCommand) Init() error {
+	log.Debug("Preparing to read the config file", pc.configFilePath)
+	vConfig, err := project.ReadConfigFile(pc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(pc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := resolverParams.ServerDetails()
+	if err != nil {
+		return err
+	}
+	filteredArgs, threads, err := coreutils.ExtractThreadsFromArgs(pc.pnpmArgs, defaultThreads)
+	if err != nil {
+		return err
+	}
+	_, _, _, filteredArgs, buildConfiguration, err := commandUtils.ExtractNpmOptionsFromArgs(filteredArgs)
+	if err != nil {
+		return err
+	}
+	pc.SetRepo(resolverParams.TargetRepo()).SetServerDetails(serverDetails).SetArgs(filteredArgs).SetBuildConfiguration(buildConfiguration).SetThreads(threads)
+	return nil
+}
+
+func (pc *PnpmCommand) Run() (err error) {
+	log.Info("Running pnpm " + pc.cmdName + "...")
+	if pc.executablePath, err = pnpm.GetExecutablePath(); err != nil {
+		return
+	}
+	if pc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return
+	}
+	log.Debug("Working directory set to:", pc.workingDirectory)
+	restoreNpmrcFunc, err := pc.configureRegistry()
+	if restoreNpmrcFunc != nil {
+		defer func() {
+			err = errors.Join(err, restoreNpmrcFunc())
+		}()
+	}
+	if err != nil {
+		return
+	}
+	if err = pc.runPnpm(); err != nil {
+		return
+	}
+	if err = pc.collectBuildInfo(); err != nil {
+		return
+	}
+	log.Info("pnpm " + pc.cmdName + " finished successfully.")
+	return
+}
+
+// Write an .npmrc file to the project directory, which sets the Artifactory repository as the registry of pnpm.
+// The settings of an existing .npmrc file are kept, and the file is restored when the command ends.
+func (pc *PnpmCommand) configureRegistry() (restoreNpmrcFunc func() error, err error) {
+	authArtDetails, err := pc.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return
+	}
+	if err = utils.ValidateRepoExists(pc.repo, authArtDetails); err != nil {
+		return
+	}
+	registry := commandUtils.GetNpmRepositoryUrl(pc.repo, pc.serverDetails.ArtifactoryUrl)
+	authKey, authValue := commandUtils.GetNpmAuthKeyValue(pc.serverDetails, registry)
+	npmrcPath := filepath.Join(pc.workingDirectory, npmrcFileName)
+	restoreNpmrcFunc, err = ioutils.BackupFile(npmrcPath, npmrcBackupFileName)
+	if err != nil {
+		return
+	}
+	existingNpmrc, err := os.ReadFile(npmrcPath)
+	if err != nil && !os.IsNotExist(err) {
+		return restoreNpmrcFunc, errorutils.CheckError(err)
+	}
+	log.Debug("Creating temporary .npmrc file.")
+	return restoreNpmrcFunc, errorutils.CheckError(os.WriteFile(npmrcPath, prepareNpmrc(existingNpmrc, registry, authKey, authValue), 0600))
+}
+
+// Keep the settings of the existing .npmrc, except for its registries and their credentials, and add the Artifactory registry.
+func prepareNpmrc(existingNpmrc []byte, registry, authKey, authValue string) []byte {
+	var npmrc strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(string(existingNpmrc)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, _, _ := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		switch {
+		case key == "registry" || strings.HasPrefix(key, "//"):
+			continue
+		case strings.HasPrefix(key, "@") && strings.HasSuffix(key, ":registry"):
+			// Scoped registries are also resolved from Artifactory.
+			npmrc.WriteString(fmt.Sprintf("%s=%s\n", key, registry))
+		case line != "":
+			npmrc.WriteString(line + "\n")
+		}
+	}
+	npmrc.WriteString(fmt.Sprintf("registry=%s\n", registry))
+	if authKey != "" && authValue != "" {
+		npmrc.WriteString(fmt.Sprintf("%s=%s\n", authKey, authValue))
+	}
+	return []byte(npmrc.String())
+}
+
+func (pc *PnpmCommand) runPnpm() error {
+	command := exec.Command(pc.executablePath, append([]string{pc.cmdName}, pc.pnpmArgs...)...)
+	command.Dir = pc.workingDirectory
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	return errorutils.CheckError(command.Run())
+}
+
+func (pc *PnpmCommand) collectBuildInfo() error {
+	toCollect, err := pc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	if !slices.Contains(installCommands, pc.cmdName) {
+		log.Info("Build-info is collected by the installing commands of pnpm only. Build-info creation is skipped.")
+		return nil
+	}
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := pc.buildConfiguration.GetProject()
+	if err = buildUtils.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	modules, err := pc.createModules()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if err = pc.setDependenciesChecksums(servicesManager, buildName, modules); err != nil {
+		return err
+	}
+	return buildUtils.SaveBuildInfo(buildName, buildNumber, projectKey, &buildinfo.BuildInfo{Modules: modules})
+}
+
+// Create a module for each project of the lockfile. The module of the root project may be renamed by the --module flag.
+func (pc *PnpmCommand) createModules() ([]buildinfo.Module, error) {
+	lockfile, err := pnpm.ReadLockfile(pc.workingDirectory)
+	if err != nil {
+		return nil, err
+	}
+	installedPackages, err := pnpm.GetInstalledPackages(pc.workingDirectory)
+	if err != nil {
+		return nil, err
+	}
+	var modules []buildinfo.Module
+	for _, importerPath := range lockfile.ImporterPaths() {
+		moduleId, err := getModuleId(filepath.Join(pc.workingDirectory, importerPath), importerPath)
+		if err != nil {
+			return nil, err
+		}
+		if importerPath == pnpm.RootImporter && pc.buildConfiguration.GetModule() != "" {
+			moduleId = pc.buildConfiguration.GetModule()
+		}
+		modules = append(modules, buildinfo.Module{
+			Id:           moduleId,
+			Type:         buildinfo.Npm,
+			Dependencies: lockfile.GetDependencies(importerPath, moduleId, installedPackages),
+		})
+	}
+	return modules, nil
+}
+
+// The module ID is read from the package.json of the project, or is the path of the project if the package has no name or version.
+func getModuleId(projectDir, importerPath string) (string, error) {
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(projectDir, nil)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if moduleId := packageInfo.BuildInfoModuleId(); moduleId != "" {
+		return moduleId, nil
+	}
+	if importerPath == pnpm.RootImporter {
+		return filepath.Base(projectDir), nil
+	}
+	return filepath.ToSlash(importerPath), nil
+}
+
+// Set the checksums of the dependencies, from the previous build or from Artifactory.
+// Dependencies which can't be found in Artifactory are removed from the build-info.
+func (pc *PnpmCommand) setDependenciesChecksums(servicesManager artifactory.ArtifactoryServicesManager, buildName string, modules []buildinfo.Module) error {
+	log.Info("Collecting the checksums of the dependencies... For the first run of the build, the dependencies collection may take a few minutes. Subsequent runs should be faster.")
+	previousBuildDependencies, err := yarn.GetDependenciesFromLatestBuild(servicesManager, buildName)
+	if err != nil {
+		return err
+	}
+	var mutex sync.Mutex
+	var missingDependencies []string
+	for i := range modules {
+		errGroup := new(errgroup.Group)
+		errGroup.SetLimit(max(pc.threads, 1))
+		dependencies := modules[i].Dependencies
+		for j := range dependencies {
+			errGroup.Go(func() error {
+				separator := strings.LastIndex(dependencies[j].Id, ":")
+				checksum, fileType, err := yarn.GetDependencyInfo(dependencies[j].Id[:separator], dependencies[j].Id[separator+1:], previousBuildDependencies, servicesManager)
+				if err != nil {
+					return err
+				}
+				if checksum.IsEmpty() {
+					mutex.Lock()
+					missingDependencies = append(missingDependencies, dependencies[j].Id)
+					mutex.Unlock()
+					return nil
+				}
+				dependencies[j].Checksum = checksum
+				dependencies[j].Type = fileType
+				return nil
+			})
+		}
+		if err = errGroup.Wait(); err != nil {
+			return err
+		}
+		modules[i].Dependencies = slices.DeleteFunc(dependencies, func(dependency buildinfo.Dependency) bool {
+			return dependency.Checksum.IsEmpty()
+		})
+	}
+	if len(missingDependencies) > 0 {
+		slices.Sort(missingDependencies)
+		log.Warn(strings.Join(slices.Compact(missingDependencies), "\n"), "\nThe npm dependencies above could not be found in Artifactory and therefore are not included in the build-info.\n"+
+			"Deleting the local cache will force populating Artifactory with these dependencies.")
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package pnpm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareNpmrc(t *testing.T) {
+	existingNpmrc := "registry=https://registry.npmjs.org/\n" +
+		"//registry.npmjs.org/:_authToken=npm-token\n" +
+		"@my-scope:registry=https://npm.pkg.github.com\n" +
+		"\n" +
+		"auto-install-peers=true\n" +
+		"strict-peer-dependencies = false\n"
+	registry := "https://acme.jfrog.io/artifactory/api/npm/npm-virtual"
+	npmrc := prepareNpmrc([]byte(existingNpmrc), registry, "//acme.jfrog.io/artifactory/api/npm/npm-virtual:_authToken", "access-token")
+	assert.Equal(t, "@my-scope:registry="+registry+"\n"+
+		"auto-install-peers=true\n"+
+		"strict-peer-dependencies = false\n"+
+		"registry="+registry+"\n"+
+		"//acme.jfrog.io/artifactory/api/npm/npm-virtual:_authToken=access-token\n", string(npmrc))
+
+	// Anonymous access.
+	assert.Equal(t, "registry="+registry+"\n", string(prepareNpmrc(nil, registry, "", "")))
+}
@@ -129,6 +129,39 @@ func getDependencyChecksumFromArtifactory(servicesManager artifactory.Artifactor
 	return
 }
 
+// Fetch the checksums of a dependency from Artifactory, by the sha256 checksums of its files, such as the files listed in poetry.lock.
+// The repository is the repository to search in, as returned by utils.GetRepoNameForDependenciesSearch.
+// Returns the name of the first file found and its checksums, or an empty name if none of the files is found.
+func GetDependencyChecksumBySha256(servicesManager artifactory.ArtifactoryServicesManager, repository string, sha256Checksums []string) (fileName string, checksum buildinfo.Checksum, err error) {
+	if len(sha256Checksums) == 0 {
+		return
+	}
+	sha256Criteria := make([]string, 0, len(sha256Checksums))
+	for _, sha256 := range sha256Checksums {
+		sha256Criteria = append(sha256Criteria, fmt.Sprintf(`{"sha256": "%s"}`, sha256))
+	}
+	query := fmt.Sprintf(`items.find({"repo": "%s","$or": [%s]}).include("name","repo","path","actual_md5","actual_sha1","sha256")`, repository, strings.Join(sha256Criteria, ","))
+	stream, err := servicesManager.Aql(query)
+	if err != nil {
+		return
+	}
+	defer ioutils.Close(stream, &err)
+	result, err := io.ReadAll(stream)
+	if err != nil {
+		return
+	}
+	parsedResult := new(aqlResult)
+	if err = errorutils.CheckError(json.Unmarshal(result, parsedResult)); err != nil {
+		return
+	}
+	for _, resultItem := range parsedResult.Results {
+		if resultItem.Actual_Sha1 != "" && resultItem.Actual_Md5 != "" {
+			return resultItem.Name, buildinfo.Checksum{Sha256: resultItem.Sha256, Sha1: resultItem.Actual_Sha1, Md5: resultItem.Actual_Md5}, nil
+		}
+	}
+	return
+}
+
 func promptMissingDependencies(missingDeps []string) {
 	if len(missingDeps) > 0 {
 		log.Warn(strings.Join(missingDeps, "\n"))
@@ -0,0 +1,213 @@
+package dependencies
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/poetry"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	hashFlag     = "--hash="
+	sha256Prefix = "sha256:"
+)
+
+var (
+	// Matches a requirement pinned to an exact version, such as 'requests[security]==2.31.0 ; python_version >= "3.8"'.
+	pinnedRequirementRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*])?\s*===?\s*([^\s;]+)`)
+	requirementNameRegex   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*`)
+	// Matches the links to the distributions in the simple index of a package, such as '<a href="../../requests-2.31.0.tar.gz#sha256=942c...">requests-2.31.0.tar.gz</a>'.
+	distributionLinkRegex = regexp.MustCompile(`<a\s[^>]*href="[^"#]*#sha256=([0-9a-fA-F]{64})"[^>]*>\s*([^<\s]+)\s*</a>`)
+	sdistExtensions       = []string{".tar.gz", ".tar.bz2", ".zip", ".tgz"}
+)
+
+// A requirement of a requirements file, with the sha256 checksums of the distributions it may be installed from.
+type Requirement struct {
+	// The requirement without its hashes, as written in the requirements file.
+	Specifier string
+	Name      string
+	Version   string
+	Hashes    []string
+}
+
+// Parse the requirements of a requirements file, with their hashes. Lines which aren't requirements, such as options, are ignored.
+func ParseRequirements(content []byte) ([]Requirement, error) {
+	var requirements []Requirement
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	line := ""
+	for scanner.Scan() {
+		// Lines which end with a backslash continue in the next line.
+		line += strings.TrimSpace(scanner.Text())
+		if strings.HasSuffix(line, "\\") {
+			line = strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+		if requirement := parseRequirement(line); requirement != nil {
+			requirements = append(requirements, *requirement)
+		}
+		line = ""
+	}
+	if requirement := parseRequirement(line); requirement != nil {
+		requirements = append(requirements, *requirement)
+	}
+	return requirements, errorutils.CheckError(scanner.Err())
+}
+
+func parseRequirement(line string) *Requirement {
+	if comment := strings.Index(line, " #"); comment >= 0 {
+		line = line[:comment]
+	}
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+		return nil
+	}
+	requirement := &Requirement{}
+	var specifier []string
+	for _, field := range strings.Fields(line) {
+		if hash, found := strings.CutPrefix(field, hashFlag); found {
+			if sha256, found := strings.CutPrefix(hash, sha256Prefix); found {
+				requirement.Hashes = append(requirement.Hashes, strings.ToLower(sha256))
+			}
+			continue
+		}
+		specifier = append(specifier, field)
+	}
+	requirement.Specifier = strings.Join(specifier, " ")
+	if match := pinnedRequirementRegex.FindStringSubmatch(requirement.Specifier); match != nil {
+		requirement.Name, requirement.Version = match[1], match[2]
+	} else if name := requirementNameRegex.FindString(requirement.Specifier); name != "" {
+		requirement.Name = name
+	}
+	return requirement
+}
+
+func ReadRequirementsFile(requirementsPath string) ([]Requirement, error) {
+	content, err := os.ReadFile(requirementsPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseRequirements(content)
+}
+
+// Format the requirements as a requirements file for the hash-checking mode of pip, with one hash in each line.
+func FormatRequirements(requirements []Requirement) string {
+	var builder strings.Builder
+	for _, requirement := range requirements {
+		builder.WriteString(requirement.Specifier)
+		for _, hash := range requirement.Hashes {
+			builder.WriteString(" \\\n    " + hashFlag + sha256Prefix + hash)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// Set the hashes of the requirements to the sha256 checksums of the distributions of their versions, as listed by the simple index of the repository.
+// Hash-checking mode requires every requirement to be pinned to an exact version.
+func ResolveRequirementsHashes(servicesManager artifactory.ArtifactoryServicesManager, repository string, requirements []Requirement) error {
+	var unpinned []string
+	for _, requirement := range requirements {
+		if requirement.Version == "" {
+			unpinned = append(unpinned, requirement.Specifier)
+		}
+	}
+	if len(unpinned) > 0 {
+		return errorutils.CheckErrorf("hash-checking mode requires the requirements to be pinned with '==', but the following requirements aren't:\n%s", strings.Join(unpinned, "\n"))
+	}
+	for i := range requirements {
+		hashes, err := getDistributionsHashes(servicesManager, repository, requirements[i].Name, requirements[i].Version)
+		if err != nil {
+			return err
+		}
+		if len(hashes) == 0 {
+			return errorutils.CheckErrorf("no distributions of %s==%s were found in the '%s' repository", requirements[i].Name, requirements[i].Version, repository)
+		}
+		log.Debug(fmt.Sprintf("Found %d distributions of %s==%s", len(hashes), requirements[i].Name, requirements[i].Version))
+		requirements[i].Hashes = hashes
+	}
+	return nil
+}
+
+func getDistributionsHashes(servicesManager artifactory.ArtifactoryServicesManager, repository, name, version string) ([]string, error) {
+	rtDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	indexUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl() + "api/pypi/" + url.PathEscape(repository) + "/simple/" + url.PathEscape(poetry.NormalizeName(name)) + "/"
+	resp, body, _, err := servicesManager.Client().SendGet(indexUrl, true, &rtDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return GetDistributionsHashes(body, name, version), nil
+}
+
+// Returns the sha256 checksums of the distributions of the version of the package, from its simple index page.
+func GetDistributionsHashes(indexPage []byte, name, version string) []string {
+	var hashes []string
+	for _, match := range distributionLinkRegex.FindAllStringSubmatch(string(indexPage), -1) {
+		distributionName, distributionVersion := parseDistributionFileName(match[2])
+		if poetry.NormalizeName(distributionName) == poetry.NormalizeName(name) && strings.EqualFold(distributionVersion, version) {
+			if hash := strings.ToLower(match[1]); !slices.Contains(hashes, hash) {
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+	return hashes
+}
+
+// Returns the name and the version of a wheel ('{name}-{version}(-{build})?-{python}-{abi}-{platform}.whl') or an sdist ('{name}-{version}.tar.gz').
+func parseDistributionFileName(fileName string) (name, version string) {
+	if wheel, found := strings.CutSuffix(fileName, ".whl"); found {
+		parts := strings.Split(wheel, "-")
+		if len(parts) < 5 {
+			return "", ""
+		}
+		return parts[0], parts[1]
+	}
+	for _, extension := range sdistExtensions {
+		if sdist, found := strings.CutSuffix(fileName, extension); found {
+			separator := strings.LastIndex(sdist, "-")
+			if separator < 0 {
+				return "", ""
+			}
+			return sdist[:separator], sdist[separator+1:]
+		}
+	}
+	return "", ""
+}
+
+// Verify that the distributions the dependencies were installed from match the hashes pinned in the requirements.
+// The sha256 checksums of the distributions are the ones Artifactory reported when the build-info was collected.
+func VerifyDependenciesHashes(requirements []Requirement, dependenciesMap map[string]buildinfo.Dependency) error {
+	pinnedHashes := map[string][]string{}
+	for _, requirement := range requirements {
+		if len(requirement.Hashes) > 0 {
+			pinnedHashes[poetry.NormalizeName(requirement.Name)] = requirement.Hashes
+		}
+	}
+	var mismatches []string
+	for name, dependency := range dependenciesMap {
+		hashes, pinned := pinnedHashes[poetry.NormalizeName(name)]
+		if !pinned || dependency.Sha256 == "" {
+			continue
+		}
+		if !slices.Contains(hashes, strings.ToLower(dependency.Sha256)) {
+			mismatches = append(mismatches, fmt.Sprintf("%s (%s): sha256:%s", name, dependency.Id, dependency.Sha256))
+		}
+	}
+	if len(mismatches) > 0 {
+		slices.Sort(mismatches)
+		return errorutils.CheckErrorf("the following distributions don't match the hashes pinned in the requirements files:\n%s", strings.Join(mismatches, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package dependencies
+
+import (
+	"strings"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	requestsSdistHash = strings.Repeat("a", 64)
+	requestsWheelHash = strings.Repeat("b", 64)
+	otherVersionHash  = strings.Repeat("c", 64)
+)
+
+func TestParseRequirements(t *testing.T) {
+	content := `# The requirements of the project
+--index-url https://example.com/simple
+requests[security]==2.31.0 ; python_version >= "3.8" \
+    --hash=sha256:` + strings.ToUpper(requestsSdistHash) + ` \
+    --hash=sha256:` + requestsWheelHash + `
+Typing_Extensions==4.8.0  # pinned for python 3.7
+urllib3>=2
+`
+	requirements, err := ParseRequirements([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, []Requirement{
+		{Specifier: `requests[security]==2.31.0 ; python_version >= "3.8"`, Name: "requests", Version: "2.31.0", Hashes: []string{requestsSdistHash, requestsWheelHash}},
+		{Specifier: "Typing_Extensions==4.8.0", Name: "Typing_Extensions", Version: "4.8.0"},
+		{Specifier: "urllib3>=2", Name: "urllib3"},
+	}, requirements)
+
+	// The formatted requirements are parsed back to the same requirements.
+	formatted, err := ParseRequirements([]byte(FormatRequirements(requirements)))
+	require.NoError(t, err)
+	assert.Equal(t, requirements, formatted)
+}
+
+func TestGetDistributionsHashes(t *testing.T) {
+	indexPage := `<html><body>
+<a href="../../packages/requests-2.31.0.tar.gz#sha256=` + requestsSdistHash + `">requests-2.31.0.tar.gz</a><br/>
+<a href="../../packages/requests-2.31.0-py3-none-any.whl#sha256=` + requestsWheelHash + `" data-requires-python="&gt;=3.7">requests-2.31.0-py3-none-any.whl</a><br/>
+<a href="../../packages/requests-2.30.0-py3-none-any.whl#sha256=` + otherVersionHash + `">requests-2.30.0-py3-none-any.whl</a><br/>
+</body></html>`
+	assert.Equal(t, []string{requestsSdistHash, requestsWheelHash}, GetDistributionsHashes([]byte(indexPage), "Requests", "2.31.0"))
+	assert.Empty(t, GetDistributionsHashes([]byte(indexPage), "requests", "2.29.0"))
+}
+
+func TestParseDistributionFileName(t *testing.T) {
+	tests := []struct {
+		fileName        string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"typing_extensions-4.8.0-py3-none-any.whl", "typing_extensions", "4.8.0"},
+		{"numpy-1.26.0-1-cp311-cp311-manylinux_2_17_x86_64.whl", "numpy", "1.26.0"},
+		{"python-dateutil-2.8.2.tar.gz", "python-dateutil", "2.8.2"},
+		{"pyyaml-6.0.1.zip", "pyyaml", "6.0.1"},
+		{"invalid.whl", "", ""},
+		{"requests.egg", "", ""},
+	}
+	for _, test := range tests {
+		name, version := parseDistributionFileName(test.fileName)
+		assert.Equal(t, test.expectedName, name, test.fileName)
+		assert.Equal(t, test.expectedVersion, version, test.fileName)
+	}
+}
+
+func TestVerifyDependenciesHashes(t *testing.T) {
+	requirements := []Requirement{
+		{Name: "requests", Version: "2.31.0", Hashes: []string{requestsSdistHash, requestsWheelHash}},
+		{Name: "urllib3"},
+	}
+	dependenciesMap := map[string]buildinfo.Dependency{
+		"requests": {Id: "requests-2.31.0-py3-none-any.whl", Checksum: buildinfo.Checksum{Sha256: strings.ToUpper(requestsWheelHash)}},
+		"urllib3":  {Id: "urllib3-2.0.7-py3-none-any.whl", Checksum: buildinfo.Checksum{Sha256: otherVersionHash}},
+	}
+	assert.NoError(t, VerifyDependenciesHashes(requirements, dependenciesMap))
+
+	dependenciesMap["requests"] = buildinfo.Dependency{Id: "requests-2.31.0-py3-none-any.whl", Checksum: buildinfo.Checksum{Sha256: otherVersionHash}}
+	err := VerifyDependenciesHashes(requirements, dependenciesMap)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requests (requests-2.31.0-py3-none-any.whl): sha256:"+otherVersionHash)
+}
+
+func TestResolveRequirementsHashesUnpinned(t *testing.T) {
+	err := ResolveRequirementsHashes(nil, "pypi-virtual", []Requirement{{Specifier: "requests>=2", Name: "requests"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requests>=2")
+}
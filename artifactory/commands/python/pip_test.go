@@ -25,3 +25,9 @@ func TestCreatePipConfigManually(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedContent, string(fileContent))
 }
+
+func TestGetRequirementsFilesPaths(t *testing.T) {
+	args := []string{"install", "-r", "requirements.txt", "--requirement=dev.txt", "-rtest.txt", "--require-hashes", "--no-cache-dir"}
+	assert.Equal(t, []string{"requirements.txt", "dev.txt", "test.txt"}, getRequirementsFilesPaths(args))
+	assert.Empty(t, getRequirementsFilesPaths([]string{"install", "requests"}))
+}
@@ -0,0 +1,106 @@
+package python
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/python/dependencies"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Generates a requirements file for the hash-checking mode of pip, with the hashes of the distributions of the pinned requirements in Artifactory.
+type PipHashesCommand struct {
+	serverDetails    *config.ServerDetails
+	repository       string
+	requirementsPath string
+	// The path of the generated requirements file. The requirements file is overwritten if not provided.
+	outputPath string
+}
+
+func NewPipHashesCommand() *PipHashesCommand {
+	return &PipHashesCommand{}
+}
+
+func (phc *PipHashesCommand) SetServerDetails(serverDetails *config.ServerDetails) *PipHashesCommand {
+	phc.serverDetails = serverDetails
+	return phc
+}
+
+func (phc *PipHashesCommand) SetRepo(repo string) *PipHashesCommand {
+	phc.repository = repo
+	return phc
+}
+
+func (phc *PipHashesCommand) SetRequirementsPath(requirementsPath string) *PipHashesCommand {
+	phc.requirementsPath = requirementsPath
+	return phc
+}
+
+func (phc *PipHashesCommand) SetOutputPath(outputPath string) *PipHashesCommand {
+	phc.outputPath = outputPath
+	return phc
+}
+
+func (phc *PipHashesCommand) ServerDetails() (*config.ServerDetails, error) {
+	return phc.serverDetails, nil
+}
+
+func (phc *PipHashesCommand) CommandName() string {
+	return "rt_python_pip_hashes"
+}
+
+func (phc *PipHashesCommand) Run() error {
+	requirements, err := dependencies.ReadRequirementsFile(phc.requirementsPath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(phc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	log.Info("Resolving the hashes of", len(requirements), "requirements from the", phc.repository, "repository...")
+	if err = dependencies.ResolveRequirementsHashes(servicesManager, phc.repository, requirements); err != nil {
+		return err
+	}
+	outputPath := phc.outputPath
+	if outputPath == "" {
+		outputPath = phc.requirementsPath
+	}
+	if err = os.WriteFile(outputPath, []byte(dependencies.FormatRequirements(requirements)), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("The requirements with their hashes were written to", outputPath+". Install them in hash-checking mode with 'pip install --require-hashes -r", outputPath+"'.")
+	return nil
+}
+
+// Returns the requirements of the requirements files which are installed by the pip arguments, such as '-r requirements.txt'.
+func getRequirementsFromArgs(args []string) (requirements []dependencies.Requirement, err error) {
+	for _, requirementsPath := range getRequirementsFilesPaths(args) {
+		fileRequirements, err := dependencies.ReadRequirementsFile(requirementsPath)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, fileRequirements...)
+	}
+	return
+}
+
+func getRequirementsFilesPaths(args []string) (paths []string) {
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-r" || arg == "--requirement":
+			if i+1 < len(args) {
+				paths = append(paths, args[i+1])
+				i++
+			}
+		case strings.HasPrefix(arg, "--requirement="):
+			paths = append(paths, strings.TrimPrefix(arg, "--requirement="))
+		case strings.HasPrefix(arg, "-r") && !strings.HasPrefix(arg, "--"):
+			paths = append(paths, strings.TrimPrefix(strings.TrimPrefix(arg, "-r"), "="))
+		}
+	}
+	return
+}
@@ -9,6 +9,7 @@ import (
 	gofrogcmd "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/python/dependencies"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/poetry"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
@@ -20,6 +21,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
 const (
@@ -73,45 +75,94 @@ func (pc *PoetryCommand) Run() (err error) {
 	return gofrogcmd.RunCmd(pc)
 }
 
-func (pc *PoetryCommand) install(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build) (err error) {
-	var pythonModule *build.PythonModule
-	pythonModule, err = pythonBuildInfo.AddPythonModule("", pc.pythonTool)
+func (pc *PoetryCommand) install(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build) error {
+	if err := gofrogcmd.RunCmd(pc); err != nil {
+		return err
+	}
+	return pc.collectDependencies(buildConfiguration, pythonBuildInfo)
+}
+
+func (pc *PoetryCommand) publish(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build) error {
+	if err := pc.collectDependencies(buildConfiguration, pythonBuildInfo); err != nil {
+		return err
+	}
+	pc.args = append(slices.Clone(pc.args), "-r "+pc.repository)
+	return gofrogcmd.RunCmd(pc)
+}
+
+// Collect the dependencies of all the dependency groups of the project from poetry.lock, with the groups as their scopes.
+func (pc *PoetryCommand) collectDependencies(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build) error {
+	srcPath, err := os.Getwd()
 	if err != nil {
-		return
+		return errorutils.CheckError(err)
 	}
-	if buildConfiguration.GetModule() != "" {
-		pythonModule.SetName(buildConfiguration.GetModule())
+	pyProject, err := poetry.ReadPyProject(srcPath)
+	if err != nil {
+		return err
 	}
-	var localDependenciesPath string
-	localDependenciesPath, err = config.GetJfrogDependenciesPath()
+	lockfile, err := poetry.ReadLockfile(srcPath)
 	if err != nil {
-		return
+		return err
 	}
-	pythonModule.SetLocalDependenciesPath(localDependenciesPath)
-	pythonModule.SetUpdateDepsChecksumInfoFunc(pc.UpdateDepsChecksumInfoFunc)
-
-	return errorutils.CheckError(pythonModule.RunInstallAndCollectDependencies(pc.args))
-}
-
-func (pc *PoetryCommand) publish(buildConfiguration *buildUtils.BuildConfiguration, pythonBuildInfo *build.Build) error {
-	publishCmdArgs := append(slices.Clone(pc.args), "-r "+pc.repository)
-	// Collect build info by running the jf poetry install cmd
-	pc.args = []string{}
-	err := pc.install(buildConfiguration, pythonBuildInfo)
+	moduleId := buildConfiguration.GetModule()
+	if moduleId == "" {
+		if moduleId = pyProject.GetModuleId(); moduleId == "" {
+			if moduleId, err = buildConfiguration.GetBuildName(); err != nil {
+				return err
+			}
+		}
+	}
+	dependencies, err := pc.setDependenciesChecksums(lockfile, lockfile.GetDependencies(pyProject, moduleId))
 	if err != nil {
 		return err
 	}
-	// Run the publish cmd
-	pc.args = publishCmdArgs
-	return gofrogcmd.RunCmd(pc)
+	buildInfo := &entities.BuildInfo{Modules: []entities.Module{{Id: moduleId, Type: entities.Python, Dependencies: dependencies}}}
+	return errorutils.CheckError(pythonBuildInfo.SaveBuildInfo(buildInfo))
 }
 
-func (pc *PoetryCommand) UpdateDepsChecksumInfoFunc(dependenciesMap map[string]entities.Dependency, srcPath string) error {
+// Set the checksums of the dependencies from the files of the packages Artifactory holds, which are matched by the sha256 checksums in poetry.lock.
+// Dependencies whose files aren't found in Artifactory are removed from the build-info.
+func (pc *PoetryCommand) setDependenciesChecksums(lockfile *poetry.Lockfile, dependenciesList []entities.Dependency) ([]entities.Dependency, error) {
 	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, false)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	searchRepo, err := utils.GetRepoNameForDependenciesSearch(pc.repository, servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	var missingDependencies []string
+	for i := range dependenciesList {
+		poetryPackage, _ := lockfile.GetPackage(dependenciesList[i].Id[:strings.LastIndex(dependenciesList[i].Id, ":")])
+		fileName, checksum, err := dependencies.GetDependencyChecksumBySha256(servicesManager, searchRepo, poetryPackage.GetSha256Checksums())
+		if err != nil {
+			return nil, err
+		}
+		if fileName == "" {
+			missingDependencies = append(missingDependencies, dependenciesList[i].Id)
+			continue
+		}
+		dependenciesList[i].Checksum = checksum
+		dependenciesList[i].Type = getFileType(fileName)
+	}
+	if len(missingDependencies) > 0 {
+		log.Warn(strings.Join(missingDependencies, "\n"), "\nThe files of the pypi packages above could not be found in Artifactory, therefore they are not included in the build-info.\n"+
+			"Clearing the cache of poetry before installing will force downloading and populating Artifactory with these packages.")
+	}
+	return slices.DeleteFunc(dependenciesList, func(dependency entities.Dependency) bool {
+		return dependency.Checksum.IsEmpty()
+	}), nil
+}
+
+// Returns the type of python package file, such as 'whl' or 'tar.gz'.
+func getFileType(fileName string) string {
+	if i := strings.LastIndex(fileName, ".tar."); i != -1 {
+		return fileName[i+1:]
+	}
+	if i := strings.LastIndex(fileName, "."); i != -1 {
+		return fileName[i+1:]
 	}
-	return dependencies.UpdateDepsChecksumInfo(dependenciesMap, srcPath, servicesManager, pc.repository)
+	return ""
 }
 
 func (pc *PoetryCommand) SetRepo(repo string) *PoetryCommand {
@@ -108,7 +108,18 @@ func (pc *PythonCommand) UpdateDepsChecksumInfoFunc(dependenciesMap map[string]e
 	if err != nil {
 		return err
 	}
-	return dependencies.UpdateDepsChecksumInfo(dependenciesMap, srcPath, servicesManager, pc.repository)
+	if err = dependencies.UpdateDepsChecksumInfo(dependenciesMap, srcPath, servicesManager, pc.repository); err != nil {
+		return err
+	}
+	if pc.pythonTool != pythonutils.Pip {
+		return nil
+	}
+	// In hash-checking mode, fail the build if the distributions in Artifactory don't match the pinned hashes.
+	requirements, err := getRequirementsFromArgs(pc.args)
+	if err != nil {
+		return err
+	}
+	return dependencies.VerifyDependenciesHashes(requirements, dependenciesMap)
 }
 
 func (pc *PythonCommand) SetRepo(repo string) *PythonCommand {
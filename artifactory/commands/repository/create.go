@@ -22,6 +22,12 @@ func (rcc *RepoCreateCommand) SetVars(vars string) *RepoCreateCommand {
 	return rcc
 }
 
+// Set the path of a YAML or JSON file of vars, with which the template is rendered.
+func (rcc *RepoCreateCommand) SetVarsFile(varsFile string) *RepoCreateCommand {
+	rcc.varsFile = varsFile
+	return rcc
+}
+
 func (rcc *RepoCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoCreateCommand {
 	rcc.serverDetails = serverDetails
 	return rcc
@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	federationStatusRestApi = "api/federation/status/repo/"
+	// The default time to wait for a full sync of a federated repository.
+	DefaultFederationSyncTimeout = 30 * time.Minute
+)
+
+// The interval between two federation status requests, while waiting for a full sync.
+var federationStatusPollingInterval = 10 * time.Second
+
+// Creates a federated repository with the given members.
+type FederatedRepoCreateCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	packageType   string
+	description   string
+	// The URLs of the members of the federation, such as 'https://<host>/artifactory/<repo-key>'.
+	members []string
+}
+
+func NewFederatedRepoCreateCommand() *FederatedRepoCreateCommand {
+	return &FederatedRepoCreateCommand{}
+}
+
+func (frc *FederatedRepoCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *FederatedRepoCreateCommand {
+	frc.serverDetails = serverDetails
+	return frc
+}
+
+func (frc *FederatedRepoCreateCommand) SetRepoKey(repoKey string) *FederatedRepoCreateCommand {
+	frc.repoKey = repoKey
+	return frc
+}
+
+func (frc *FederatedRepoCreateCommand) SetPackageType(packageType string) *FederatedRepoCreateCommand {
+	frc.packageType = packageType
+	return frc
+}
+
+func (frc *FederatedRepoCreateCommand) SetDescription(description string) *FederatedRepoCreateCommand {
+	frc.description = description
+	return frc
+}
+
+func (frc *FederatedRepoCreateCommand) SetMembers(members []string) *FederatedRepoCreateCommand {
+	frc.members = members
+	return frc
+}
+
+func (frc *FederatedRepoCreateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return frc.serverDetails, nil
+}
+
+func (frc *FederatedRepoCreateCommand) CommandName() string {
+	return "rt_federated_repo_create"
+}
+
+func (frc *FederatedRepoCreateCommand) Run() error {
+	if frc.repoKey == "" || frc.packageType == "" {
+		return errorutils.CheckErrorf("the repository key and package type of the federated repository are mandatory")
+	}
+	if !slices.Contains(append(slices.Clone(commonPkgTypes), federatedRepoAdditionalPkgTypes...), frc.packageType) {
+		return errorutils.CheckErrorf("the package type '%s' isn't supported by federated repositories", frc.packageType)
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(frc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	params := services.NewFederatedRepositoryBaseParams()
+	params.Key = frc.repoKey
+	params.PackageType = frc.packageType
+	params.Description = frc.description
+	params.Members = toFederatedRepositoryMembers(frc.members)
+	return servicesManager.CreateFederatedRepositoryWithParams(params)
+}
+
+func toFederatedRepositoryMembers(memberUrls []string) (members []services.FederatedRepositoryMember) {
+	for _, memberUrl := range memberUrls {
+		members = append(members, services.FederatedRepositoryMember{Url: memberUrl, Enabled: clientutils.Pointer(true)})
+	}
+	return
+}
+
+// Adds members to a federated repository, and removes members from it.
+type FederationMembersCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	addMembers    []string
+	removeMembers []string
+}
+
+func NewFederationMembersCommand() *FederationMembersCommand {
+	return &FederationMembersCommand{}
+}
+
+func (fmc *FederationMembersCommand) SetServerDetails(serverDetails *config.ServerDetails) *FederationMembersCommand {
+	fmc.serverDetails = serverDetails
+	return fmc
+}
+
+func (fmc *FederationMembersCommand) SetRepoKey(repoKey string) *FederationMembersCommand {
+	fmc.repoKey = repoKey
+	return fmc
+}
+
+// Set the URLs of the members to add.
+func (fmc *FederationMembersCommand) SetAddMembers(members []string) *FederationMembersCommand {
+	fmc.addMembers = members
+	return fmc
+}
+
+// Set the URLs of the members to remove.
+func (fmc *FederationMembersCommand) SetRemoveMembers(members []string) *FederationMembersCommand {
+	fmc.removeMembers = members
+	return fmc
+}
+
+func (fmc *FederationMembersCommand) ServerDetails() (*config.ServerDetails, error) {
+	return fmc.serverDetails, nil
+}
+
+func (fmc *FederationMembersCommand) CommandName() string {
+	return "rt_federation_members"
+}
+
+// The configuration sent to update the members of a federated repository.
+type federationMembersParams struct {
+	Rclass  string                               `json:"rclass"`
+	Members []services.FederatedRepositoryMember `json:"members"`
+}
+
+func (fmc *FederationMembersCommand) Run() error {
+	if len(fmc.addMembers) == 0 && len(fmc.removeMembers) == 0 {
+		return errorutils.CheckErrorf("no federation members to add or remove were provided")
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(fmc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	repoDetails := services.FederatedRepositoryBaseParams{}
+	if err = servicesManager.GetRepository(fmc.repoKey, &repoDetails); err != nil {
+		return err
+	}
+	if repoDetails.Rclass != services.FederatedRepositoryRepoType {
+		return errorutils.CheckErrorf("the repository '%s' is a %s repository, and not a federated repository", fmc.repoKey, repoDetails.Rclass)
+	}
+	members, err := updateFederationMembers(repoDetails.Members, fmc.addMembers, fmc.removeMembers)
+	if err != nil {
+		return err
+	}
+	return servicesManager.UpdateRepositoryWithParams(federationMembersParams{Rclass: services.FederatedRepositoryRepoType, Members: members}, fmc.repoKey)
+}
+
+// Returns the current members, without the removed members and with the added members.
+// Member URLs are compared regardless of a trailing slash.
+func updateFederationMembers(current []services.FederatedRepositoryMember, add, remove []string) ([]services.FederatedRepositoryMember, error) {
+	normalize := func(memberUrl string) string {
+		return strings.TrimSuffix(memberUrl, "/")
+	}
+	indexOf := func(members []services.FederatedRepositoryMember, memberUrl string) int {
+		return slices.IndexFunc(members, func(member services.FederatedRepositoryMember) bool {
+			return normalize(member.Url) == normalize(memberUrl)
+		})
+	}
+	members := slices.Clone(current)
+	for _, memberUrl := range remove {
+		index := indexOf(members, memberUrl)
+		if index < 0 {
+			return nil, errorutils.CheckErrorf("'%s' isn't a member of the federation", memberUrl)
+		}
+		members = slices.Delete(members, index, index+1)
+	}
+	for _, memberUrl := range add {
+		if indexOf(members, memberUrl) >= 0 {
+			log.Info(fmt.Sprintf("'%s' is already a member of the federation.", memberUrl))
+			continue
+		}
+		members = append(members, toFederatedRepositoryMembers([]string{memberUrl})...)
+	}
+	return members, nil
+}
+
+type FederationMirrorStatus struct {
+	LocalRepoKey  string `json:"localRepoKey,omitempty"`
+	RemoteUrl     string `json:"remoteUrl,omitempty"`
+	RemoteRepoKey string `json:"remoteRepoKey,omitempty"`
+	Status        string `json:"status,omitempty"`
+	// The time, in milliseconds, by which the mirror is behind the local repository.
+	LagInMs int64 `json:"lagInMS"`
+}
+
+type FederationStatus struct {
+	LocalKey           string                   `json:"localKey,omitempty"`
+	Mirrors            []FederationMirrorStatus `json:"mirrors,omitempty"`
+	UnavailableMirrors []FederationMirrorStatus `json:"unavailableMirrors,omitempty"`
+	Failures           []json.RawMessage        `json:"failures,omitempty"`
+}
+
+// Returns true if all the mirrors are available and have no lag, and there are no sync failures.
+func (fs *FederationStatus) IsFullySynced() bool {
+	if len(fs.UnavailableMirrors) > 0 || len(fs.Failures) > 0 {
+		return false
+	}
+	for _, mirror := range fs.Mirrors {
+		if mirror.LagInMs > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Queries the sync status of a federated repository.
+// In the wait mode, the status is queried repeatedly until the repository is fully synced, or until the timeout expires.
+type FederationStatusCommand struct {
+	serverDetails *config.ServerDetails
+	repoKey       string
+	fullSync      bool
+	wait          bool
+	timeout       time.Duration
+	status        *FederationStatus
+}
+
+func NewFederationStatusCommand() *FederationStatusCommand {
+	return &FederationStatusCommand{timeout: DefaultFederationSyncTimeout}
+}
+
+func (fsc *FederationStatusCommand) SetServerDetails(serverDetails *config.ServerDetails) *FederationStatusCommand {
+	fsc.serverDetails = serverDetails
+	return fsc
+}
+
+func (fsc *FederationStatusCommand) SetRepoKey(repoKey string) *FederationStatusCommand {
+	fsc.repoKey = repoKey
+	return fsc
+}
+
+// Trigger a full sync of the federated repository with all its members, before querying the status.
+func (fsc *FederationStatusCommand) SetFullSync(fullSync bool) *FederationStatusCommand {
+	fsc.fullSync = fullSync
+	return fsc
+}
+
+func (fsc *FederationStatusCommand) SetWait(wait bool) *FederationStatusCommand {
+	fsc.wait = wait
+	return fsc
+}
+
+func (fsc *FederationStatusCommand) SetTimeout(timeout time.Duration) *FederationStatusCommand {
+	fsc.timeout = timeout
+	return fsc
+}
+
+// Returns the last queried status.
+func (fsc *FederationStatusCommand) Status() *FederationStatus {
+	return fsc.status
+}
+
+func (fsc *FederationStatusCommand) ServerDetails() (*config.ServerDetails, error) {
+	return fsc.serverDetails, nil
+}
+
+func (fsc *FederationStatusCommand) CommandName() string {
+	return "rt_federation_status"
+}
+
+func (fsc *FederationStatusCommand) Run() (err error) {
+	servicesManager, err := rtUtils.CreateServiceManager(fsc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if fsc.fullSync {
+		if err = servicesManager.TriggerFederatedRepositoryFullSyncAll(fsc.repoKey); err != nil {
+			return err
+		}
+	}
+	if fsc.status, err = getFederationStatus(servicesManager, fsc.repoKey); err != nil {
+		return err
+	}
+	if fsc.wait {
+		if err = fsc.waitForFullSync(servicesManager); err != nil {
+			return err
+		}
+	}
+	content, err := json.Marshal(fsc.status)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+func (fsc *FederationStatusCommand) waitForFullSync(servicesManager artifactory.ArtifactoryServicesManager) (err error) {
+	deadline := time.Now().Add(fsc.timeout)
+	for !fsc.status.IsFullySynced() {
+		// The last poll is done at the deadline, so timeouts shorter than the polling interval are polled once too.
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return errorutils.CheckErrorf("the federated repository '%s' wasn't fully synced within %s", fsc.repoKey, fsc.timeout.String())
+		}
+		log.Info(fmt.Sprintf("Waiting for the federated repository '%s' to be fully synced...", fsc.repoKey))
+		time.Sleep(min(federationStatusPollingInterval, remaining))
+		if fsc.status, err = getFederationStatus(servicesManager, fsc.repoKey); err != nil {
+			return
+		}
+	}
+	log.Info(fmt.Sprintf("The federated repository '%s' is fully synced.", fsc.repoKey))
+	return nil
+}
+
+func getFederationStatus(servicesManager artifactory.ArtifactoryServicesManager, repoKey string) (*FederationStatus, error) {
+	rtDetails, err := utils.CreateArtifactoryClientDetails(servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	statusUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl() + federationStatusRestApi + url.PathEscape(repoKey)
+	resp, body, _, err := servicesManager.Client().SendGet(statusUrl, true, rtDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	status := new(FederationStatus)
+	return status, errorutils.CheckError(json.Unmarshal(body, status))
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateFederationMembers(t *testing.T) {
+	current := []services.FederatedRepositoryMember{{Url: "https://a/artifactory/repo"}, {Url: "https://b/artifactory/repo/"}}
+	tests := []struct {
+		name          string
+		add           []string
+		remove        []string
+		expectedUrls  []string
+		expectedError string
+	}{
+		{"add", []string{"https://c/artifactory/repo"}, nil, []string{"https://a/artifactory/repo", "https://b/artifactory/repo/", "https://c/artifactory/repo"}, ""},
+		{"add existing", []string{"https://a/artifactory/repo/"}, nil, []string{"https://a/artifactory/repo", "https://b/artifactory/repo/"}, ""},
+		{"remove", nil, []string{"https://b/artifactory/repo"}, []string{"https://a/artifactory/repo"}, ""},
+		{"replace", []string{"https://c/artifactory/repo"}, []string{"https://a/artifactory/repo"}, []string{"https://b/artifactory/repo/", "https://c/artifactory/repo"}, ""},
+		{"remove missing", nil, []string{"https://c/artifactory/repo"}, nil, "'https://c/artifactory/repo' isn't a member of the federation"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			members, err := updateFederationMembers(current, test.add, test.remove)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			var urls []string
+			for _, member := range members {
+				urls = append(urls, member.Url)
+			}
+			assert.Equal(t, test.expectedUrls, urls)
+			// The current members should remain unchanged.
+			assert.Len(t, current, 2)
+		})
+	}
+}
+
+func TestFederationStatusIsFullySynced(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   FederationStatus
+		expected bool
+	}{
+		{"no mirrors", FederationStatus{}, true},
+		{"synced", FederationStatus{Mirrors: []FederationMirrorStatus{{LagInMs: 0}, {LagInMs: 0}}}, true},
+		{"lagging", FederationStatus{Mirrors: []FederationMirrorStatus{{LagInMs: 0}, {LagInMs: 1500}}}, false},
+		{"unavailable", FederationStatus{UnavailableMirrors: []FederationMirrorStatus{{RemoteUrl: "https://a"}}}, false},
+		{"failures", FederationStatus{Failures: []json.RawMessage{json.RawMessage(`{}`)}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.status.IsFullySynced())
+		})
+	}
+}
+
+func TestFederationStatusWait(t *testing.T) {
+	previousInterval := federationStatusPollingInterval
+	federationStatusPollingInterval = time.Millisecond
+	defer func() {
+		federationStatusPollingInterval = previousInterval
+	}()
+
+	tests := []struct {
+		name             string
+		syncedAfter      int32
+		timeout          time.Duration
+		expectedRequests int32
+		expectedError    string
+	}{
+		{"synced", 1, time.Minute, 1, ""},
+		{"synced after polling", 3, time.Minute, 3, ""},
+		{"timeout", 1000, 50 * time.Millisecond, 0, "wasn't fully synced within"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/"+federationStatusRestApi+"repo1", r.URL.Path)
+				lag := 0
+				if requests.Add(1) < test.syncedAfter {
+					lag = 100
+				}
+				_, err := fmt.Fprintf(w, `{"localKey":"repo1","mirrors":[{"remoteUrl":"https://a/artifactory/repo1","lagInMS":%d}]}`, lag)
+				assert.NoError(t, err)
+			}))
+			defer server.Close()
+
+			command := NewFederationStatusCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+				SetRepoKey("repo1").SetWait(true).SetTimeout(test.timeout)
+			err := command.Run()
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedRequests, requests.Load())
+			assert.True(t, command.Status().IsFullySynced())
+		})
+	}
+}
+
+// A timeout shorter than the polling interval still polls the status at the deadline.
+func TestFederationStatusWaitShortTimeout(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lag := 100
+		if requests.Add(1) > 1 {
+			lag = 0
+		}
+		_, err := fmt.Fprintf(w, `{"localKey":"repo1","mirrors":[{"remoteUrl":"https://a/artifactory/repo1","lagInMS":%d}]}`, lag)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	command := NewFederationStatusCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}).
+		SetRepoKey("repo1").SetWait(true).SetTimeout(100 * time.Millisecond)
+	assert.NoError(t, command.Run())
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Less(t, time.Since(start), federationStatusPollingInterval)
+}
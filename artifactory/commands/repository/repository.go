@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/c-bata/go-prompt"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
@@ -14,6 +15,8 @@ import (
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -26,12 +29,17 @@ type RepoCommand struct {
 	serverDetails *config.ServerDetails
 	templatePath  string
 	vars          string
+	varsFile      string
 }
 
 func (rc *RepoCommand) Vars() string {
 	return rc.vars
 }
 
+func (rc *RepoCommand) VarsFile() string {
+	return rc.varsFile
+}
+
 func (rc *RepoCommand) TemplatePath() string {
 	return rc.templatePath
 }
@@ -41,12 +49,17 @@ func (rc *RepoCommand) PerformRepoCmd(isUpdate bool) (err error) {
 	if err != nil {
 		return err
 	}
-	// All the values in the template are strings
-	// Go over the confMap and write the values with the correct type using the writersMap
 	for key, value := range repoConfigMap {
 		if err = utils.ValidateMapEntry(key, value, writersMap); err != nil {
 			return
 		}
+	}
+	if err = validateRepoConfig(repoConfigMap, isUpdate); err != nil {
+		return
+	}
+	// All the values in the template are strings
+	// Go over the confMap and write the values with the correct type using the writersMap
+	for key, value := range repoConfigMap {
 		if err = writersMap[key](&repoConfigMap, key, fmt.Sprint(value)); err != nil {
 			return
 		}
@@ -83,6 +96,54 @@ func (rc *RepoCommand) PerformRepoCmd(isUpdate bool) (err error) {
 	return handlerFunc(servicesManager, content, isUpdate)
 }
 
+// Validate the configuration against the schema of its repository class and package type, before sending it to Artifactory.
+// The configuration values are expected to be strings, as in the template.
+func validateRepoConfig(repoConfigMap map[string]interface{}, isUpdate bool) error {
+	for _, key := range []string{Key, Rclass, PackageType} {
+		if value, _ := repoConfigMap[key].(string); value == "" {
+			return errorutils.CheckErrorf("template syntax error: the mandatory key \"%s\" is missing.", key)
+		}
+	}
+	rclass := repoConfigMap[Rclass].(string)
+	packageType := repoConfigMap[PackageType].(string)
+	var packageTypes []string
+	var confKeys []prompt.Suggest
+	switch rclass {
+	case Local:
+		packageTypes = append(slices.Clone(commonPkgTypes), localRepoAdditionalPkgTypes...)
+		confKeys = getLocalRepoConfKeys(packageType)
+	case Remote:
+		if _, ok := repoConfigMap[Url]; !ok && !isUpdate {
+			return errorutils.CheckErrorf("template syntax error: the key \"%s\" is mandatory when creating a remote repository.", Url)
+		}
+		packageTypes = append(slices.Clone(commonPkgTypes), remoteRepoAdditionalPkgTypes...)
+		confKeys = getRemoteRepoConfKeys(packageType, Update)
+	case Virtual:
+		packageTypes = append(slices.Clone(commonPkgTypes), virtualRepoAdditionalPkgTypes...)
+		confKeys = getVirtualRepoConfKeys(packageType)
+	case Federated:
+		packageTypes = append(slices.Clone(commonPkgTypes), federatedRepoAdditionalPkgTypes...)
+		confKeys = getLocalRepoConfKeys(packageType)
+	default:
+		return errorutils.CheckErrorf("unsupported rclass: %s", rclass)
+	}
+	if !slices.Contains(packageTypes, packageType) {
+		return errorutils.CheckErrorf("the package type \"%s\" isn't supported by %s repositories.", packageType, rclass)
+	}
+	allowedKeys := []string{Key, Rclass, PackageType, environmentsKey}
+	for _, confKey := range confKeys {
+		allowedKeys = append(allowedKeys, confKey.Text)
+	}
+	keys := maps.Keys(repoConfigMap)
+	slices.Sort(keys)
+	for _, key := range keys {
+		if !slices.Contains(allowedKeys, key) {
+			return errorutils.CheckErrorf("template syntax error: the key \"%s\" doesn't apply to %s %s repositories.", key, rclass, packageType)
+		}
+	}
+	return nil
+}
+
 var writersMap = map[string]ioutils.AnswerWriter{
 	Key:                               ioutils.WriteStringAnswer,
 	Rclass:                            ioutils.WriteStringAnswer,
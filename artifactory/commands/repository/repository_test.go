@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRepoConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		isUpdate      bool
+		expectedError string
+	}{
+		{"local", map[string]interface{}{Key: "npm-local", Rclass: Local, PackageType: Npm, XrayIndex: "true", environmentsKey: "DEV"}, false, ""},
+		{"remote", map[string]interface{}{Key: "npm-remote", Rclass: Remote, PackageType: Npm, Url: "https://registry.npmjs.org", ListRemoteFolderItems: "true"}, false, ""},
+		{"remote update without url", map[string]interface{}{Key: "npm-remote", Rclass: Remote, PackageType: Npm}, true, ""},
+		{"virtual", map[string]interface{}{Key: "npm", Rclass: Virtual, PackageType: Npm, Repositories: "npm-local,npm-remote"}, false, ""},
+		{"federated", map[string]interface{}{Key: "generic-federated", Rclass: Federated, PackageType: Generic}, false, ""},
+		{"missing key", map[string]interface{}{Rclass: Local, PackageType: Npm}, false, "the mandatory key \"key\" is missing"},
+		{"missing package type", map[string]interface{}{Key: "npm-local", Rclass: Local}, false, "the mandatory key \"packageType\" is missing"},
+		{"unsupported rclass", map[string]interface{}{Key: "npm-local", Rclass: "other", PackageType: Npm}, false, "unsupported rclass: other"},
+		{"remote create without url", map[string]interface{}{Key: "npm-remote", Rclass: Remote, PackageType: Npm}, false, "\"url\" is mandatory"},
+		{"unsupported package type", map[string]interface{}{Key: "p2-local", Rclass: Local, PackageType: P2}, false, "\"p2\" isn't supported by local repositories"},
+		{"key of another package type", map[string]interface{}{Key: "npm-local", Rclass: Local, PackageType: Npm, HandleReleases: "true"}, false, "\"handleReleases\" doesn't apply to local npm repositories"},
+		{"key of another rclass", map[string]interface{}{Key: "npm-local", Rclass: Local, PackageType: Npm, Url: "https://registry.npmjs.org"}, false, "\"url\" doesn't apply to local npm repositories"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateRepoConfig(test.config, test.isUpdate)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -22,6 +22,12 @@ func (ruc *RepoUpdateCommand) SetVars(vars string) *RepoUpdateCommand {
 	return ruc
 }
 
+// Set the path of a YAML or JSON file of vars, with which the template is rendered.
+func (ruc *RepoUpdateCommand) SetVarsFile(varsFile string) *RepoUpdateCommand {
+	ruc.varsFile = varsFile
+	return ruc
+}
+
 func (ruc *RepoUpdateCommand) SetServerDetails(serverDetails *config.ServerDetails) *RepoUpdateCommand {
 	ruc.serverDetails = serverDetails
 	return ruc
@@ -10,6 +10,8 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/repository"
 	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/composer"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/conda"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/npm"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/yarn"
@@ -37,6 +39,10 @@ var packageManagerToRepositoryPackageType = map[project.ProjectType]string{
 	project.Pipenv: repository.Pypi,
 	project.Poetry: repository.Pypi,
 
+	project.Conda: repository.Conda,
+
+	project.Composer: repository.Composer,
+
 	// Nuget package managers
 	project.Nuget:  repository.Nuget,
 	project.Dotnet: repository.Nuget,
@@ -141,6 +147,10 @@ func (sc *SetupCommand) Run() (err error) {
 		err = sc.configurePip()
 	case project.Poetry:
 		err = sc.configurePoetry()
+	case project.Conda:
+		err = sc.configureConda()
+	case project.Composer:
+		err = sc.configureComposer()
 	case project.Go:
 		err = sc.configureGo()
 	case project.Nuget, project.Dotnet:
@@ -209,6 +219,45 @@ func (sc *SetupCommand) configurePoetry() error {
 	return pythoncommands.RunPoetryConfig(repoUrl.String(), username, password, sc.repoName)
 }
 
+// configureConda sets the channel alias of conda to the Artifactory Conda repository, so that the named channels,
+// such as 'conda-forge', are resolved from the repository.
+// Runs the following command:
+//
+//	conda config --set channel_alias https://<user>:<token>@<your-artifactory-url>/artifactory/api/conda/<repo-name>
+//
+// Note: Custom configuration file can be set by setting the CONDARC environment variable.
+func (sc *SetupCommand) configureConda() error {
+	channelAlias, err := conda.GetChannelAlias(sc.serverDetails, sc.repoName)
+	if err != nil {
+		return err
+	}
+	return conda.ConfigSet("channel_alias", channelAlias)
+}
+
+// configureComposer sets the Artifactory Composer repository as the replacement of packagist.org, and sets its credentials.
+// Runs the following commands:
+//
+//	composer config --global repos.packagist composer https://<your-artifactory-url>/artifactory/api/composer/<repo-name>
+//	composer config --global http-basic.<your-artifactory-host> <user> <password/token>
+//
+// Note: Custom configuration directory can be set by setting the COMPOSER_HOME environment variable.
+func (sc *SetupCommand) configureComposer() error {
+	executablePath, err := composer.GetExecutablePath()
+	if err != nil {
+		return err
+	}
+	repositoryUrl := composer.GetRepositoryUrl(sc.serverDetails.ArtifactoryUrl, sc.repoName)
+	if err = composer.SetPackagistRepository(executablePath, repositoryUrl); err != nil {
+		return err
+	}
+	host, username, password, err := composer.GetRepositoryCredentials(sc.serverDetails, repositoryUrl)
+	if err != nil || password == "" {
+		return err
+	}
+	_, err = composer.RunConfig(executablePath, "--global", "http-basic."+host, username, password)
+	return err
+}
+
 // configureNpmPnpm configures npm to use the Artifactory repository URL and sets authentication. Pnpm supports the same commands.
 // Runs the following commands:
 //
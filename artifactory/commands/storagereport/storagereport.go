@@ -0,0 +1,403 @@
+package storagereport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The output format of the storage report.
+type ReportFormat string
+
+const (
+	ReportFormatTable ReportFormat = "table"
+	ReportFormatCsv   ReportFormat = "csv"
+	ReportFormatJson  ReportFormat = "json"
+
+	// The layout of the --since date.
+	SinceDateLayout = "2006-01-02"
+	// The pseudo repository summarizing all the repositories in the storage summary.
+	totalRepoKey = "TOTAL"
+)
+
+func GetReportFormats() []string {
+	return []string{string(ReportFormatTable), string(ReportFormatCsv), string(ReportFormatJson)}
+}
+
+func GetReportFormat(formatFlagVal string) (ReportFormat, error) {
+	switch ReportFormat(strings.ToLower(formatFlagVal)) {
+	case "", ReportFormatTable:
+		return ReportFormatTable, nil
+	case ReportFormatCsv:
+		return ReportFormatCsv, nil
+	case ReportFormatJson:
+		return ReportFormatJson, nil
+	}
+	return "", errorutils.CheckErrorf("only the following output formats are supported: %s", coreutils.ListToText(GetReportFormats()))
+}
+
+// The storage usage of a repository.
+// The added files and size are counted only if a since date is set, and don't account for deleted files.
+type RepoStorage struct {
+	Repo        string `json:"repo"`
+	RepoType    string `json:"repoType,omitempty"`
+	PackageType string `json:"packageType,omitempty"`
+	SizeBytes   int64  `json:"sizeBytes"`
+	Files       int64  `json:"files"`
+	AddedBytes  *int64 `json:"addedBytesSince,omitempty"`
+	AddedFiles  *int64 `json:"addedFilesSince,omitempty"`
+}
+
+// A file in Artifactory, as reported by the --top mode.
+type LargestArtifact struct {
+	Repo      string `json:"repo"`
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"sizeBytes"`
+	Modified  string `json:"modified,omitempty"`
+}
+
+// The growth columns are printed only if a since date is set.
+type repoStorageRow struct {
+	Repo        string `col-name:"Repository"`
+	RepoType    string `col-name:"Type"`
+	PackageType string `col-name:"Package Type"`
+	Size        string `col-name:"Size"`
+	Files       string `col-name:"Files"`
+	AddedSize   string `col-name:"Added Size" extended:"true"`
+	AddedFiles  string `col-name:"Added Files" extended:"true"`
+}
+
+type largestArtifactRow struct {
+	Path     string `col-name:"Path"`
+	Size     string `col-name:"Size"`
+	Modified string `col-name:"Modified"`
+}
+
+// Reports the used storage and the files count of each repository, based on the storage summary of Artifactory.
+// If a since date is set, the size and count of the files created since that date are added to the report using AQL.
+// If top is positive, the largest files are reported instead of the repositories.
+type StorageReportCommand struct {
+	serverDetails *config.ServerDetails
+	repos         []string
+	since         time.Time
+	top           int
+	format        ReportFormat
+}
+
+func NewStorageReportCommand() *StorageReportCommand {
+	return &StorageReportCommand{format: ReportFormatTable}
+}
+
+func (src *StorageReportCommand) SetServerDetails(serverDetails *config.ServerDetails) *StorageReportCommand {
+	src.serverDetails = serverDetails
+	return src
+}
+
+// Limit the report to the given repositories. All the repositories are reported if empty.
+func (src *StorageReportCommand) SetRepos(repos []string) *StorageReportCommand {
+	src.repos = repos
+	return src
+}
+
+func (src *StorageReportCommand) SetSince(since time.Time) *StorageReportCommand {
+	src.since = since
+	return src
+}
+
+func (src *StorageReportCommand) SetTop(top int) *StorageReportCommand {
+	src.top = top
+	return src
+}
+
+func (src *StorageReportCommand) SetFormat(format ReportFormat) *StorageReportCommand {
+	src.format = format
+	return src
+}
+
+func (src *StorageReportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return src.serverDetails, nil
+}
+
+func (src *StorageReportCommand) CommandName() string {
+	return "rt_storage_report"
+}
+
+func (src *StorageReportCommand) Run() error {
+	if src.top < 0 {
+		return errorutils.CheckErrorf("the number of the largest artifacts to report must be positive, but %d was provided", src.top)
+	}
+	storageInfoManager, err := rtUtils.NewStorageInfoManager(context.Background(), src.serverDetails)
+	if err != nil {
+		return err
+	}
+	if src.top > 0 {
+		artifacts, err := src.getLargestArtifacts(storageInfoManager.GetServiceManager())
+		if err != nil {
+			return err
+		}
+		return src.printLargestArtifacts(artifacts, func(text string) { log.Output(text) })
+	}
+	reposStorage, err := src.getReposStorage(storageInfoManager)
+	if err != nil {
+		return err
+	}
+	if !src.since.IsZero() {
+		if err = src.addReposGrowth(storageInfoManager.GetServiceManager(), reposStorage); err != nil {
+			return err
+		}
+	}
+	return src.printReposStorage(reposStorage, func(text string) { log.Output(text) })
+}
+
+// Get the storage usage of the selected repositories from the storage summary, sorted by their size in a descending order.
+// The storage summary is calculated periodically by Artifactory, so it might not include the most recent changes.
+func (src *StorageReportCommand) getReposStorage(storageInfoManager *rtUtils.StorageInfoManager) ([]*RepoStorage, error) {
+	storageInfo, err := storageInfoManager.GetStorageInfo()
+	if err != nil {
+		return nil, err
+	}
+	var reposStorage []*RepoStorage
+	for i, repoSummary := range storageInfo.RepositoriesSummaryList {
+		if repoSummary.RepoKey == totalRepoKey || (len(src.repos) > 0 && !slices.Contains(src.repos, repoSummary.RepoKey)) {
+			continue
+		}
+		size, err := rtUtils.GetUsedSpaceInBytes(&storageInfo.RepositoriesSummaryList[i])
+		if err != nil {
+			return nil, err
+		}
+		files, err := rtUtils.GetFilesCountFromRepositorySummary(&storageInfo.RepositoriesSummaryList[i])
+		if err != nil {
+			return nil, err
+		}
+		reposStorage = append(reposStorage, &RepoStorage{
+			Repo:        repoSummary.RepoKey,
+			RepoType:    repoSummary.RepoType,
+			PackageType: repoSummary.PackageType,
+			SizeBytes:   size,
+			Files:       files,
+		})
+	}
+	var missingRepos []string
+	for _, repo := range src.repos {
+		if !slices.ContainsFunc(reposStorage, func(repoStorage *RepoStorage) bool { return repoStorage.Repo == repo }) {
+			missingRepos = append(missingRepos, repo)
+		}
+	}
+	if len(missingRepos) > 0 {
+		return nil, errorutils.CheckErrorf("the following repositories were not found in the storage summary: %s", strings.Join(missingRepos, ", "))
+	}
+	sort.SliceStable(reposStorage, func(i, j int) bool {
+		return reposStorage[i].SizeBytes > reposStorage[j].SizeBytes
+	})
+	return reposStorage, nil
+}
+
+// Add the size and count of the files created since the since date to each of the repositories.
+func (src *StorageReportCommand) addReposGrowth(serviceManager artifactory.ArtifactoryServicesManager, reposStorage []*RepoStorage) error {
+	if len(reposStorage) == 0 {
+		return nil
+	}
+	reposByKey := make(map[string]*RepoStorage, len(reposStorage))
+	reposCriteria := make([]rtUtils.AqlCriterion, 0, len(reposStorage))
+	for _, repoStorage := range reposStorage {
+		repoStorage.AddedBytes, repoStorage.AddedFiles = new(int64), new(int64)
+		reposByKey[repoStorage.Repo] = repoStorage
+		reposCriteria = append(reposCriteria, rtUtils.AqlField("repo", rtUtils.AqlEq, repoStorage.Repo))
+	}
+	query := rtUtils.Items().
+		Where(rtUtils.AqlAnyOf(reposCriteria...), rtUtils.AqlTimeField("created", rtUtils.AqlGte, src.since)).
+		Type("file").
+		Include("repo", "path", "name", "size").
+		Distinct(false).
+		Build()
+	// The files are summed while the response is decoded, since a wide date range may match more files than fit in memory.
+	return runAql(serviceManager, query, func(item *servicesUtils.ResultItem) {
+		if repoStorage, ok := reposByKey[item.Repo]; ok {
+			*repoStorage.AddedBytes += item.Size
+			*repoStorage.AddedFiles++
+		}
+	})
+}
+
+// Get the largest files of the selected repositories, created since the since date if set.
+func (src *StorageReportCommand) getLargestArtifacts(serviceManager artifactory.ArtifactoryServicesManager) ([]LargestArtifact, error) {
+	queryBuilder := rtUtils.Items().Type("file")
+	if len(src.repos) > 0 {
+		reposCriteria := make([]rtUtils.AqlCriterion, 0, len(src.repos))
+		for _, repo := range src.repos {
+			reposCriteria = append(reposCriteria, rtUtils.AqlField("repo", rtUtils.AqlEq, repo))
+		}
+		queryBuilder.Where(rtUtils.AqlAnyOf(reposCriteria...))
+	}
+	if !src.since.IsZero() {
+		queryBuilder.Where(rtUtils.AqlTimeField("created", rtUtils.AqlGte, src.since))
+	}
+	query := queryBuilder.Include("repo", "path", "name", "size", "modified").SortBy(rtUtils.AqlDesc, "size").Limit(src.top).Build()
+	artifacts := []LargestArtifact{}
+	err := runAql(serviceManager, query, func(item *servicesUtils.ResultItem) {
+		artifacts = append(artifacts, LargestArtifact{Repo: item.Repo, Path: path.Join(item.Path, item.Name), SizeBytes: item.Size, Modified: item.Modified})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// Run the AQL query, and pass each of the items in the response to handleItem as it's decoded, without reading the whole response into memory.
+func runAql(serviceManager artifactory.ArtifactoryServicesManager, query string, handleItem func(item *servicesUtils.ResultItem)) (err error) {
+	log.Debug("Searching Artifactory using AQL query:\n", query)
+	reader, err := serviceManager.Aql(query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	return errorutils.CheckError(decodeAqlResults(json.NewDecoder(reader), handleItem))
+}
+
+// Decode the AQL response, which looks like {"results":[...],"range":{...}}, passing each of the results to handleItem.
+func decodeAqlResults(decoder *json.Decoder, handleItem func(item *servicesUtils.ResultItem)) error {
+	if err := expectJsonDelim(decoder, '{'); err != nil {
+		return err
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if key != "results" {
+			// Skip the other fields, such as the range of the results.
+			var skipped json.RawMessage
+			if err = decoder.Decode(&skipped); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = expectJsonDelim(decoder, '['); err != nil {
+			return err
+		}
+		for decoder.More() {
+			item := new(servicesUtils.ResultItem)
+			if err = decoder.Decode(item); err != nil {
+				return err
+			}
+			handleItem(item)
+		}
+		if err = expectJsonDelim(decoder, ']'); err != nil {
+			return err
+		}
+	}
+	return expectJsonDelim(decoder, '}')
+}
+
+func expectJsonDelim(decoder *json.Decoder, expected json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return errorutils.CheckErrorf("unexpected token '%v' in the AQL response, expected '%s'", token, expected)
+	}
+	return nil
+}
+
+func (src *StorageReportCommand) printReposStorage(reposStorage []*RepoStorage, output func(text string)) error {
+	withGrowth := !src.since.IsZero()
+	switch src.format {
+	case ReportFormatJson:
+		return printJson(reposStorage, output)
+	case ReportFormatCsv:
+		header := []string{"repo", "repoType", "packageType", "sizeBytes", "files"}
+		if withGrowth {
+			header = append(header, "addedBytesSince", "addedFilesSince")
+		}
+		records := [][]string{header}
+		for _, repoStorage := range reposStorage {
+			record := []string{repoStorage.Repo, repoStorage.RepoType, repoStorage.PackageType, strconv.FormatInt(repoStorage.SizeBytes, 10), strconv.FormatInt(repoStorage.Files, 10)}
+			if withGrowth {
+				record = append(record, strconv.FormatInt(*repoStorage.AddedBytes, 10), strconv.FormatInt(*repoStorage.AddedFiles, 10))
+			}
+			records = append(records, record)
+		}
+		return printCsv(records, output)
+	}
+	title := "Storage usage"
+	if withGrowth {
+		title += " (added since " + src.since.Format(SinceDateLayout) + ")"
+	}
+	rows := make([]repoStorageRow, 0, len(reposStorage))
+	for _, repoStorage := range reposStorage {
+		row := repoStorageRow{
+			Repo:        repoStorage.Repo,
+			RepoType:    repoStorage.RepoType,
+			PackageType: repoStorage.PackageType,
+			Size:        servicesUtils.ConvertIntToStorageSizeString(repoStorage.SizeBytes),
+			Files:       strconv.FormatInt(repoStorage.Files, 10),
+		}
+		if withGrowth {
+			row.AddedSize = servicesUtils.ConvertIntToStorageSizeString(*repoStorage.AddedBytes)
+			row.AddedFiles = strconv.FormatInt(*repoStorage.AddedFiles, 10)
+		}
+		rows = append(rows, row)
+	}
+	return coreutils.PrintTable(rows, title, "No repositories were found", withGrowth)
+}
+
+func (src *StorageReportCommand) printLargestArtifacts(artifacts []LargestArtifact, output func(text string)) error {
+	switch src.format {
+	case ReportFormatJson:
+		return printJson(artifacts, output)
+	case ReportFormatCsv:
+		records := [][]string{{"repo", "path", "sizeBytes", "modified"}}
+		for _, artifact := range artifacts {
+			records = append(records, []string{artifact.Repo, artifact.Path, strconv.FormatInt(artifact.SizeBytes, 10), artifact.Modified})
+		}
+		return printCsv(records, output)
+	}
+	rows := make([]largestArtifactRow, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		rows = append(rows, largestArtifactRow{
+			Path:     artifact.Repo + "/" + artifact.Path,
+			Size:     servicesUtils.ConvertIntToStorageSizeString(artifact.SizeBytes),
+			Modified: artifact.Modified,
+		})
+	}
+	return coreutils.PrintTable(rows, "Largest artifacts", "No artifacts were found", false)
+}
+
+func printJson(value any, output func(text string)) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	output(clientutils.IndentJson(content))
+	return nil
+}
+
+func printCsv(records [][]string, output func(text string)) error {
+	var buf bytes.Buffer
+	csvWriter := csv.NewWriter(&buf)
+	if err := csvWriter.WriteAll(records); err != nil {
+		return errorutils.CheckError(err)
+	}
+	output(strings.TrimSuffix(buf.String(), "\n"))
+	return nil
+}
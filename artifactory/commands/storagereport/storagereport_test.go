@@ -0,0 +1,106 @@
+package storagereport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRepositoriesSummaryList = []servicesUtils.RepositorySummary{
+	{RepoKey: "small-local", RepoType: "LOCAL", PackageType: "Generic", UsedSpaceInBytes: "100", FilesCount: "2"},
+	{RepoKey: "big-local", RepoType: "LOCAL", PackageType: "Maven", UsedSpace: "2 KB", FilesCount: "5"},
+	{RepoKey: "TOTAL", RepoType: "NA", UsedSpaceInBytes: "2148", FilesCount: "7"},
+}
+
+func TestGetReportFormat(t *testing.T) {
+	reportFormat, err := GetReportFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, ReportFormatTable, reportFormat)
+	reportFormat, err = GetReportFormat("CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, ReportFormatCsv, reportFormat)
+	_, err = GetReportFormat("xml")
+	assert.ErrorContains(t, err, "only the following output formats are supported")
+}
+
+func TestReposStorageWithGrowth(t *testing.T) {
+	var aqlQuery string
+	testServer, serverDetails, _ := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/storageinfo":
+			content, err := json.Marshal(&servicesUtils.StorageInfo{RepositoriesSummaryList: testRepositoriesSummaryList})
+			assert.NoError(t, err)
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(content)
+			assert.NoError(t, err)
+		case "/api/search/aql":
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			aqlQuery = string(content)
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write([]byte(`{"results":[{"repo":"big-local","path":"org/a","name":"a.jar","size":300},{"repo":"big-local","path":"org/b","name":"b.jar","size":200}],"range":{"start_pos":0,"end_pos":2,"total":2}}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer testServer.Close()
+
+	storageInfoManager, err := rtUtils.NewStorageInfoManager(context.Background(), serverDetails)
+	require.NoError(t, err)
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	command := NewStorageReportCommand().SetServerDetails(serverDetails).SetSince(since).SetFormat(ReportFormatCsv)
+
+	reposStorage, err := command.getReposStorage(storageInfoManager)
+	require.NoError(t, err)
+	require.NoError(t, command.addReposGrowth(storageInfoManager.GetServiceManager(), reposStorage))
+	assert.Contains(t, aqlQuery, `{"created":{"$gte":"2026-01-01T00:00:00.000Z"}}`)
+
+	var lines []string
+	require.NoError(t, command.printReposStorage(reposStorage, func(text string) { lines = append(lines, text) }))
+	assert.Equal(t, []string{"repo,repoType,packageType,sizeBytes,files,addedBytesSince,addedFilesSince\n" +
+		"big-local,LOCAL,Maven,2048,5,500,2\n" +
+		"small-local,LOCAL,Generic,100,2,0,0"}, lines)
+
+	_, err = command.SetRepos([]string{"small-local", "missing-local"}).getReposStorage(storageInfoManager)
+	assert.ErrorContains(t, err, "not found in the storage summary: missing-local")
+}
+
+func TestLargestArtifacts(t *testing.T) {
+	var aqlQuery string
+	testServer, serverDetails, serviceManager := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		aqlQuery = string(content)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write([]byte(`{"results":[{"repo":"big-local","path":"org/a","name":"a.jar","size":300,"modified":"2026-02-01T10:00:00.000Z"}]}`))
+		assert.NoError(t, err)
+	})
+	defer testServer.Close()
+
+	command := NewStorageReportCommand().SetServerDetails(serverDetails).SetRepos([]string{"big-local"}).SetTop(1).SetFormat(ReportFormatJson)
+	artifacts, err := command.getLargestArtifacts(serviceManager)
+	require.NoError(t, err)
+	assert.Equal(t, `items.find({"$and":[{"type":{"$eq":"file"}},{"$or":[{"repo":{"$eq":"big-local"}}]}]}).include("repo","path","name","size","modified").sort({"$desc":["size"]}).limit(1)`, aqlQuery)
+	assert.Equal(t, []LargestArtifact{{Repo: "big-local", Path: "org/a/a.jar", SizeBytes: 300, Modified: "2026-02-01T10:00:00.000Z"}}, artifacts)
+}
+
+func TestDecodeAqlResults(t *testing.T) {
+	var items []string
+	handleItem := func(item *servicesUtils.ResultItem) { items = append(items, item.Name) }
+	decoder := json.NewDecoder(strings.NewReader(`{"range":{"total":2},"results":[{"name":"a.jar"},{"name":"b.jar"}],"notification":"ok"}`))
+	require.NoError(t, decodeAqlResults(decoder, handleItem))
+	assert.Equal(t, []string{"a.jar", "b.jar"}, items)
+
+	assert.ErrorContains(t, decodeAqlResults(json.NewDecoder(strings.NewReader(`[]`)), handleItem), "unexpected token '[' in the AQL response")
+}
@@ -0,0 +1,271 @@
+package swift
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/swift"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	registriesConfigBackupFileName = "jfrog.registries.json.backup"
+	// The type of the Swift modules in the build-info.
+	SwiftModuleType buildinfo.ModuleType = "swift"
+)
+
+// The swift commands which resolve the dependencies of the package, and therefore update Package.resolved.
+var resolvingCommands = []string{"build", "test", "run", "resolve", "update"}
+
+// Runs swift commands, such as 'swift build' and 'swift package resolve', with the packages resolved from an Artifactory Swift repository.
+// The repository is set as the default registry of the package, so packages are resolved from it by their registry identities,
+// or by their source control URLs when the '--replace-scm-with-registry' option is used.
+// The build-info of the resolving commands is collected from Package.resolved.
+type SwiftCommand struct {
+	cmdName            string
+	swiftArgs          []string
+	configFilePath     string
+	repo               string
+	executablePath     string
+	workingDirectory   string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *buildUtils.BuildConfiguration
+}
+
+// cmdName is the swift command, such as 'build', or 'package' for the subcommands of 'swift package', whose name is the first argument.
+func NewSwiftCommand(cmdName string) *SwiftCommand {
+	return &SwiftCommand{cmdName: cmdName}
+}
+
+func (sc *SwiftCommand) CommandName() string {
+	return "rt_swift_" + sc.cmdName
+}
+
+func (sc *SwiftCommand) SetConfigFilePath(configFilePath string) *SwiftCommand {
+	sc.configFilePath = configFilePath
+	return sc
+}
+
+func (sc *SwiftCommand) SetArgs(args []string) *SwiftCommand {
+	sc.swiftArgs = args
+	return sc
+}
+
+func (sc *SwiftCommand) SetServerDetails(serverDetails *config.ServerDetails) *SwiftCommand {
+	sc.serverDetails = serverDetails
+	return sc
+}
+
+func (sc *SwiftCommand) SetRepo(repo string) *SwiftCommand {
+	sc.repo = repo
+	return sc
+}
+
+func (sc *SwiftCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *SwiftCommand {
+	sc.buildConfiguration = buildConfiguration
+	return sc
+}
+
+func (sc *SwiftCommand) ServerDetails() (*config.ServerDetails, error) {
+	return sc.serverDetails, nil
+}
+
+// Read the resolver of the config file, and extract the JFrog CLI flags from the swift arguments.
+func (sc *SwiftCommand) Init() error {
+	log.Debug("Preparing to read the config file", sc.configFilePath)
+	vConfig, err := project.ReadConfigFile(sc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := project.GetRepoConfigByPrefix(sc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := resolverParams.ServerDetails()
+	if err != nil {
+		return err
+	}
+	filteredArgs, buildConfiguration, err := buildUtils.ExtractBuildDetailsFromArgs(sc.swiftArgs)
+	if err != nil {
+		return err
+	}
+	sc.SetRepo(resolverParams.TargetRepo()).SetServerDetails(serverDetails).SetArgs(filteredArgs).SetBuildConfiguration(buildConfiguration)
+	return nil
+}
+
+func (sc *SwiftCommand) Run() (err error) {
+	log.Info("Running swift " + sc.cmdName + "...")
+	if sc.executablePath, err = swift.GetExecutablePath(); err != nil {
+		return
+	}
+	if sc.workingDirectory, err = coreutils.GetWorkingDirectory(); err != nil {
+		return
+	}
+	log.Debug("Working directory set to:", sc.workingDirectory)
+	authArtDetails, err := sc.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return
+	}
+	if err = utils.ValidateRepoExists(sc.repo, authArtDetails); err != nil {
+		return
+	}
+	registryUrl := swift.GetRegistryUrl(sc.serverDetails.ArtifactoryUrl, sc.repo)
+	restoreRegistriesConfigFunc, err := configureRegistry(sc.workingDirectory, registryUrl)
+	if restoreRegistriesConfigFunc != nil {
+		defer func() {
+			err = errors.Join(err, restoreRegistriesConfigFunc())
+		}()
+	}
+	if err != nil {
+		return
+	}
+	if err = sc.runSwift(registryUrl); err != nil {
+		return
+	}
+	if err = sc.collectBuildInfo(); err != nil {
+		return
+	}
+	log.Info("swift " + sc.cmdName + " finished successfully.")
+	return
+}
+
+// Set the Artifactory repository as the default registry in the registries.json of the package.
+// The other registries of an existing registries.json are kept, and the file is restored when the command ends.
+func configureRegistry(packageDir, registryUrl string) (restoreFunc func() error, err error) {
+	configPath := filepath.Join(packageDir, filepath.FromSlash(swift.RegistriesConfigPath))
+	if err = os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if restoreFunc, err = ioutils.BackupFile(configPath, registriesConfigBackupFileName); err != nil {
+		return
+	}
+	existingConfig, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return restoreFunc, errorutils.CheckError(err)
+	}
+	registriesConfig, err := swift.SetDefaultRegistry(existingConfig, registryUrl)
+	if err != nil {
+		return restoreFunc, err
+	}
+	log.Debug("Setting the default registry of the package to", registryUrl)
+	return restoreFunc, errorutils.CheckError(os.WriteFile(configPath, registriesConfig, 0644))
+}
+
+// Run swift with the credentials of the registry in a temporary .netrc file, so that they aren't stored in the keychain of the user.
+func (sc *SwiftCommand) runSwift(registryUrl string) (err error) {
+	swiftArgs := append([]string{sc.cmdName}, sc.swiftArgs...)
+	netrc, err := swift.CreateNetrc(sc.serverDetails, registryUrl)
+	if err != nil {
+		return
+	}
+	if netrc != "" {
+		var netrcDir string
+		if netrcDir, err = fileutils.CreateTempDir(); err != nil {
+			return
+		}
+		defer func() {
+			err = errors.Join(err, fileutils.RemoveTempDir(netrcDir))
+		}()
+		netrcPath := filepath.Join(netrcDir, ".netrc")
+		if err = os.WriteFile(netrcPath, []byte(netrc), 0600); err != nil {
+			return errorutils.CheckError(err)
+		}
+		swiftArgs = append(swiftArgs, "--netrc-file", netrcPath)
+	}
+	command := exec.Command(sc.executablePath, swiftArgs...)
+	command.Dir = sc.workingDirectory
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	return errorutils.CheckError(command.Run())
+}
+
+// Returns the name of the command which runs, which is the first argument for the subcommands of 'swift package'.
+func (sc *SwiftCommand) subcommandName() string {
+	if sc.cmdName == "package" && len(sc.swiftArgs) > 0 {
+		return sc.swiftArgs[0]
+	}
+	return sc.cmdName
+}
+
+func (sc *SwiftCommand) collectBuildInfo() error {
+	toCollect, err := sc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !toCollect {
+		return err
+	}
+	if !slices.Contains(resolvingCommands, sc.subcommandName()) {
+		log.Info("Build-info is collected by the resolving commands of swift only. Build-info creation is skipped.")
+		return nil
+	}
+	buildName, err := sc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := sc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := sc.buildConfiguration.GetProject()
+	if err = buildUtils.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	resolvedFile, err := swift.ReadResolvedFile(sc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	moduleId := sc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = filepath.Base(sc.workingDirectory)
+	}
+	servicesManager, err := utils.CreateServiceManager(sc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	dependencies, err := sc.setDependenciesChecksums(servicesManager, resolvedFile.GetDependencies(moduleId))
+	if err != nil {
+		return err
+	}
+	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{Id: moduleId, Type: SwiftModuleType, Dependencies: dependencies}}}
+	return buildUtils.SaveBuildInfo(buildName, buildNumber, projectKey, buildInfo)
+}
+
+// Set the checksums of the dependencies from Artifactory. Dependencies which can't be found in Artifactory are removed from the build-info.
+func (sc *SwiftCommand) setDependenciesChecksums(servicesManager artifactory.ArtifactoryServicesManager, dependencies []buildinfo.Dependency) ([]buildinfo.Dependency, error) {
+	repo, err := utils.GetRepoNameForDependenciesSearch(sc.repo, servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	var missingDependencies []string
+	for i := range dependencies {
+		separator := strings.LastIndex(dependencies[i].Id, ":")
+		checksum, err := swift.GetDependencyChecksum(servicesManager, repo, dependencies[i].Id[:separator], dependencies[i].Id[separator+1:])
+		if err != nil {
+			return nil, err
+		}
+		if checksum.IsEmpty() {
+			missingDependencies = append(missingDependencies, dependencies[i].Id)
+			continue
+		}
+		dependencies[i].Checksum = checksum
+	}
+	if len(missingDependencies) > 0 {
+		log.Warn(strings.Join(missingDependencies, "\n"), "\nThe Swift packages above could not be found in Artifactory and therefore are not included in the build-info.")
+	}
+	return slices.DeleteFunc(dependencies, func(dependency buildinfo.Dependency) bool {
+		return dependency.Checksum.IsEmpty()
+	}), nil
+}
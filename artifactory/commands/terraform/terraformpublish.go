@@ -7,6 +7,7 @@ import (
 	"github.com/jfrog/gofrog/parallel"
 	commandsUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/terraform"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
@@ -43,8 +44,10 @@ type TerraformPublishCommand struct {
 	args           []string
 	repo           string
 	configFilePath string
-	serverDetails  *config.ServerDetails
-	result         *commandsUtils.Result
+	// The tool the modules are published for. Read from the config file, or detected if not set there.
+	iacProvider   terraform.Provider
+	serverDetails *config.ServerDetails
+	result        *commandsUtils.Result
 }
 
 func NewTerraformPublishCommand() *TerraformPublishCommand {
@@ -93,12 +96,12 @@ func (tpc *TerraformPublishCommand) Result() *commandsUtils.Result {
 }
 
 func (tpc *TerraformPublishCommand) Run() error {
-	log.Info("Running Terraform publish")
+	log.Info("Running " + tpc.iacProvider.String() + " publish")
 	err := tpc.publish()
 	if err != nil {
 		return err
 	}
-	log.Info("Terraform publish finished successfully.")
+	log.Info(tpc.iacProvider.String() + " publish finished successfully.")
 	return nil
 }
 
@@ -249,7 +252,7 @@ func (tpc *TerraformPublishCommand) walkDirAndUploadTerraformModules(pwd string,
 		if !pathInfo.IsDir() {
 			return nil
 		}
-		isTerraformModule, e := checkIfTerraformModule(path)
+		isTerraformModule, e := checkIfTerraformModule(path, tpc.iacProvider)
 		if e != nil {
 			return e
 		}
@@ -330,7 +333,8 @@ func (tpc *TerraformPublishCommand) getPublishTarget(moduleName string) string {
 }
 
 // We identify a Terraform module by having at least one file with a ".tf" extension inside the module directory.
-func checkIfTerraformModule(path string) (isModule bool, err error) {
+// OpenTofu modules may have ".tofu" files instead.
+func checkIfTerraformModule(path string, provider terraform.Provider) (isModule bool, err error) {
 	dirname := path + string(filepath.Separator)
 	d, err := os.Open(dirname)
 	if err != nil {
@@ -344,9 +348,10 @@ func checkIfTerraformModule(path string) (isModule bool, err error) {
 	if err != nil {
 		return false, errorutils.CheckError(err)
 	}
+	extensions := provider.ModuleFileExtensions()
 	for _, file := range files {
 		if file.Mode().IsRegular() {
-			if filepath.Ext(file.Name()) == ".tf" {
+			if slices.ContainsFunc(extensions, func(extension string) bool { return strings.HasSuffix(file.Name(), extension) }) {
 				return true, nil
 			}
 		}
@@ -366,6 +371,12 @@ func (tpc *TerraformPublishCommand) setRepoFromConfiguration() error {
 		return err
 	}
 	tpc.setRepoConfig(deployerParams)
+	if tpc.iacProvider, err = terraform.ParseProvider(vConfig.GetString(terraform.ProviderConfigKey)); err != nil {
+		return err
+	}
+	if tpc.iacProvider == "" {
+		tpc.iacProvider = terraform.DetectProvider()
+	}
 	return nil
 }
 
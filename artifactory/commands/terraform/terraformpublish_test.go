@@ -3,11 +3,13 @@ package terraform
 import (
 	"errors"
 	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/terraform"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	clientServicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientUtils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/stretchr/testify/assert"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -28,14 +30,23 @@ func TestPreparePrerequisites(t *testing.T) {
 func TestCheckIfTerraformModule(t *testing.T) {
 	dirPath := filepath.Join("..", "testdata", "terraform", "terraform_project")
 	// Check terraform module directory which contain files with a ".tf" extension.
-	isModule, err := checkIfTerraformModule(dirPath)
+	isModule, err := checkIfTerraformModule(dirPath, terraform.TerraformProvider)
 	assert.NoError(t, err)
 	assert.True(t, isModule)
 	// Check npm directory which doesn't contain files with a ".tf" extension.
 	dirPath = filepath.Join("..", "testdata", "npm")
-	isModule, err = checkIfTerraformModule(dirPath)
+	isModule, err = checkIfTerraformModule(dirPath, terraform.TerraformProvider)
 	assert.NoError(t, err)
 	assert.False(t, isModule)
+	// Check OpenTofu module directory which contains only files with a ".tofu" extension.
+	dirPath = t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dirPath, "main.tofu"), []byte{}, 0644))
+	isModule, err = checkIfTerraformModule(dirPath, terraform.TerraformProvider)
+	assert.NoError(t, err)
+	assert.False(t, isModule)
+	isModule, err = checkIfTerraformModule(dirPath, terraform.OpenTofuProvider)
+	assert.NoError(t, err)
+	assert.True(t, isModule)
 }
 
 func TestWalkDirAndUploadTerraformModules(t *testing.T) {
@@ -0,0 +1,238 @@
+package transferconfig
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	commandsUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const permissionTargetsRestApi = "api/v2/security/permissions"
+
+// The impact of transferring the config on an entity type in the target Artifactory.
+type EntityDiff struct {
+	// Entities which exist in the source Artifactory only, and would be created in the target Artifactory.
+	Created []string `json:"created"`
+	// Entities which exist in both Artifactories, and would be overwritten in the target Artifactory.
+	Overwritten []string `json:"overwritten"`
+	// Entities which exist in both Artifactories, and would be left unchanged in the target Artifactory.
+	Skipped []string `json:"skipped"`
+}
+
+// A report of the changes the config transfer would apply to the target Artifactory.
+type ConfigDiffReport struct {
+	SourceUrl         string     `json:"sourceUrl"`
+	TargetUrl         string     `json:"targetUrl"`
+	Repositories      EntityDiff `json:"repositories"`
+	Users             EntityDiff `json:"users"`
+	Groups            EntityDiff `json:"groups"`
+	PermissionTargets EntityDiff `json:"permissionTargets"`
+}
+
+type permissionTargetName struct {
+	Name string `json:"name"`
+}
+
+// Compare the source and target Artifactories and write a report of the changes, without transferring anything.
+// The report is written as HTML if the report path has the .html extension, and as JSON otherwise.
+func (tcc *TransferConfigCommand) runDiffReport() error {
+	tcc.LogTitle("Comparing the configuration of the source and target Artifactories")
+	report, err := tcc.createDiffReport()
+	if err != nil {
+		return err
+	}
+	if err = writeDiffReport(report, tcc.diffReportPath); err != nil {
+		return err
+	}
+	log.Info("The config transfer diff report was written to " + tcc.diffReportPath + ". No changes were applied to the target Artifactory.")
+	return nil
+}
+
+func (tcc *TransferConfigCommand) createDiffReport() (*ConfigDiffReport, error) {
+	report := &ConfigDiffReport{
+		SourceUrl: tcc.SourceServerDetails.GetArtifactoryUrl(),
+		TargetUrl: tcc.TargetServerDetails.GetArtifactoryUrl(),
+	}
+	var err error
+	if report.Repositories, err = tcc.getRepositoriesDiff(); err != nil {
+		return nil, err
+	}
+	if report.Users, err = getEntitiesDiff(tcc.SourceArtifactoryManager, tcc.TargetArtifactoryManager, getUserNames); err != nil {
+		return nil, err
+	}
+	if report.Groups, err = getEntitiesDiff(tcc.SourceArtifactoryManager, tcc.TargetArtifactoryManager, getGroupNames); err != nil {
+		return nil, err
+	}
+	if report.PermissionTargets, err = getEntitiesDiff(tcc.SourceArtifactoryManager, tcc.TargetArtifactoryManager, getPermissionTargetNames); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Repositories which already exist in the target Artifactory are skipped by the config transfer.
+func (tcc *TransferConfigCommand) getRepositoriesDiff() (EntityDiff, error) {
+	sourceRepos, err := getRepositoryNames(tcc.SourceArtifactoryManager)
+	if err != nil {
+		return EntityDiff{}, err
+	}
+	includeExcludeFilter := tcc.GetRepoFilter()
+	var selectedRepos []string
+	for _, repoKey := range sourceRepos {
+		shouldIncludeRepo, err := includeExcludeFilter.ShouldIncludeRepository(repoKey)
+		if err != nil {
+			return EntityDiff{}, err
+		}
+		if shouldIncludeRepo {
+			selectedRepos = append(selectedRepos, repoKey)
+		}
+	}
+	targetRepos, err := getRepositoryNames(tcc.TargetArtifactoryManager)
+	if err != nil {
+		return EntityDiff{}, err
+	}
+	diff := diffNames(selectedRepos, targetRepos)
+	diff.Skipped, diff.Overwritten = diff.Overwritten, []string{}
+	return diff, nil
+}
+
+func getEntitiesDiff(source, target artifactory.ArtifactoryServicesManager, getNames func(artifactory.ArtifactoryServicesManager) ([]string, error)) (EntityDiff, error) {
+	sourceNames, err := getNames(source)
+	if err != nil {
+		return EntityDiff{}, err
+	}
+	targetNames, err := getNames(target)
+	if err != nil {
+		return EntityDiff{}, err
+	}
+	return diffNames(sourceNames, targetNames), nil
+}
+
+// Split the source names into the names which are missing in the target and the names which exist in the target.
+func diffNames(sourceNames, targetNames []string) EntityDiff {
+	targetNamesSet := make(map[string]bool, len(targetNames))
+	for _, name := range targetNames {
+		targetNamesSet[name] = true
+	}
+	diff := EntityDiff{Created: []string{}, Overwritten: []string{}, Skipped: []string{}}
+	for _, name := range sourceNames {
+		if targetNamesSet[name] {
+			diff.Overwritten = append(diff.Overwritten, name)
+		} else {
+			diff.Created = append(diff.Created, name)
+		}
+	}
+	sort.Strings(diff.Created)
+	sort.Strings(diff.Overwritten)
+	return diff
+}
+
+func getRepositoryNames(serviceManager artifactory.ArtifactoryServicesManager) ([]string, error) {
+	repos, err := serviceManager.GetAllRepositories()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(*repos))
+	for _, repo := range *repos {
+		names = append(names, repo.Key)
+	}
+	return names, nil
+}
+
+func getUserNames(serviceManager artifactory.ArtifactoryServicesManager) ([]string, error) {
+	users, err := serviceManager.GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.Name)
+	}
+	return names, nil
+}
+
+func getGroupNames(serviceManager artifactory.ArtifactoryServicesManager) ([]string, error) {
+	groups, err := serviceManager.GetAllGroups()
+	if err != nil || groups == nil {
+		return nil, err
+	}
+	return *groups, nil
+}
+
+func getPermissionTargetNames(serviceManager artifactory.ArtifactoryServicesManager) ([]string, error) {
+	rtDetails, err := commandsUtils.CreateArtifactoryClientDetails(serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	artifactoryUrl := clientutils.AddTrailingSlashIfNeeded(serviceManager.GetConfig().GetServiceDetails().GetUrl())
+	resp, body, _, err := serviceManager.Client().SendGet(artifactoryUrl+permissionTargetsRestApi, true, rtDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var permissionTargets []permissionTargetName
+	if err = json.Unmarshal(body, &permissionTargets); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	names := make([]string, 0, len(permissionTargets))
+	for _, permissionTarget := range permissionTargets {
+		names = append(names, permissionTarget.Name)
+	}
+	return names, nil
+}
+
+var diffReportHtmlTemplate = template.Must(template.New("diffReport").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Config Transfer Diff Report</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ccc; padding: 4px 12px; text-align: left; vertical-align: top; }
+</style>
+</head>
+<body>
+<h1>Config Transfer Diff Report</h1>
+<p>Source: {{.SourceUrl}}<br>Target: {{.TargetUrl}}</p>
+{{define "entityDiff"}}<table>
+<tr><th>Created ({{len .Created}})</th><th>Overwritten ({{len .Overwritten}})</th><th>Skipped ({{len .Skipped}})</th></tr>
+<tr><td>{{range .Created}}{{.}}<br>{{end}}</td><td>{{range .Overwritten}}{{.}}<br>{{end}}</td><td>{{range .Skipped}}{{.}}<br>{{end}}</td></tr>
+</table>{{end}}
+<h2>Repositories</h2>
+{{template "entityDiff" .Repositories}}
+<h2>Users</h2>
+{{template "entityDiff" .Users}}
+<h2>Groups</h2>
+{{template "entityDiff" .Groups}}
+<h2>Permission Targets</h2>
+{{template "entityDiff" .PermissionTargets}}
+</body>
+</html>
+`))
+
+func writeDiffReport(report *ConfigDiffReport, reportPath string) error {
+	var content strings.Builder
+	if strings.EqualFold(filepath.Ext(reportPath), ".html") {
+		if err := diffReportHtmlTemplate.Execute(&content, report); err != nil {
+			return errorutils.CheckError(err)
+		}
+	} else {
+		jsonContent, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		content.Write(jsonContent)
+	}
+	return errorutils.CheckError(os.WriteFile(reportPath, []byte(content.String()), 0644))
+}
@@ -0,0 +1,91 @@
+package transferconfig
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffNames(t *testing.T) {
+	diff := diffNames([]string{"c", "a", "b"}, []string{"b", "d"})
+	assert.Equal(t, EntityDiff{Created: []string{"a", "c"}, Overwritten: []string{"b"}, Skipped: []string{}}, diff)
+}
+
+func TestDiffReport(t *testing.T) {
+	sourceTestServer, sourceServerDetails, _ := createDiffReportMockServer(t, []string{"generic-local", "npm-remote", "docker-local"}, []string{"admin", "alice"}, []string{"readers", "writers"}, []string{"all-readers"})
+	defer sourceTestServer.Close()
+	targetTestServer, targetServerDetails, _ := createDiffReportMockServer(t, []string{"generic-local"}, []string{"admin"}, []string{"readers"}, []string{})
+	defer targetTestServer.Close()
+
+	transferConfigCmd := createTransferConfigCommand(t, sourceServerDetails, targetServerDetails)
+	transferConfigCmd.SetExcludeReposPatterns([]string{"docker-*"})
+	report, err := transferConfigCmd.createDiffReport()
+	require.NoError(t, err)
+	assert.Equal(t, EntityDiff{Created: []string{"npm-remote"}, Overwritten: []string{}, Skipped: []string{"generic-local"}}, report.Repositories)
+	assert.Equal(t, EntityDiff{Created: []string{"alice"}, Overwritten: []string{"admin"}, Skipped: []string{}}, report.Users)
+	assert.Equal(t, EntityDiff{Created: []string{"writers"}, Overwritten: []string{"readers"}, Skipped: []string{}}, report.Groups)
+	assert.Equal(t, EntityDiff{Created: []string{"all-readers"}, Overwritten: []string{}, Skipped: []string{}}, report.PermissionTargets)
+
+	tmpDir := t.TempDir()
+	jsonReportPath := filepath.Join(tmpDir, "report.json")
+	require.NoError(t, writeDiffReport(report, jsonReportPath))
+	content, err := os.ReadFile(jsonReportPath)
+	require.NoError(t, err)
+	var actual ConfigDiffReport
+	require.NoError(t, json.Unmarshal(content, &actual))
+	assert.Equal(t, *report, actual)
+
+	htmlReportPath := filepath.Join(tmpDir, "report.html")
+	require.NoError(t, writeDiffReport(report, htmlReportPath))
+	content, err = os.ReadFile(htmlReportPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "<h2>Permission Targets</h2>")
+	assert.Contains(t, string(content), "Created (1)</th>")
+	assert.Contains(t, string(content), "all-readers<br>")
+}
+
+func createDiffReportMockServer(t *testing.T, repos, users, groups, permissionTargets []string) (*httptest.Server, *config.ServerDetails, artifactory.ArtifactoryServicesManager) {
+	return commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch r.URL.Path {
+		case "/api/repositories":
+			repoDetails := []services.RepositoryDetails{}
+			for _, repo := range repos {
+				repoDetails = append(repoDetails, services.RepositoryDetails{Key: repo})
+			}
+			response = repoDetails
+		case "/api/security/users":
+			response = toNamedEntities(users)
+		case "/api/security/groups":
+			response = toNamedEntities(groups)
+		case "/" + permissionTargetsRestApi:
+			response = toNamedEntities(permissionTargets)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content, err := json.Marshal(response)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	})
+}
+
+func toNamedEntities(names []string) []map[string]string {
+	entities := []map[string]string{}
+	for _, name := range names {
+		entities = append(entities, map[string]string{"name": name})
+	}
+	return entities
+}
@@ -45,6 +45,7 @@ type TransferConfigCommand struct {
 	preChecks        bool
 	sourceWorkingDir string
 	targetWorkingDir string
+	diffReportPath   string
 }
 
 func NewTransferConfigCommand(sourceServer, targetServer *config.ServerDetails) *TransferConfigCommand {
@@ -85,6 +86,13 @@ func (tcc *TransferConfigCommand) SetTargetWorkingDir(workingDir string) *Transf
 	return tcc
 }
 
+// Write a report of the repositories, users, groups and permission targets that would be created or overwritten in the target Artifactory, instead of transferring the config.
+// The report is written as HTML if the path has the .html extension, and as JSON otherwise.
+func (tcc *TransferConfigCommand) SetDiffReportPath(diffReportPath string) *TransferConfigCommand {
+	tcc.diffReportPath = diffReportPath
+	return tcc
+}
+
 func (tcc *TransferConfigCommand) Run() (err error) {
 	if err = tcc.CreateServiceManagers(tcc.dryRun); err != nil {
 		return err
@@ -92,6 +100,9 @@ func (tcc *TransferConfigCommand) Run() (err error) {
 	if tcc.preChecks {
 		return tcc.runPreChecks()
 	}
+	if tcc.diffReportPath != "" {
+		return tcc.runDiffReport()
+	}
 
 	tcc.LogTitle("Phase 1/5 - Preparations")
 	err = tcc.printWarnings()
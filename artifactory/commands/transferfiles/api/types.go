@@ -109,6 +109,14 @@ func (uc *UploadChunk) AppendUploadCandidateIfNeeded(file FileRepresentation, bu
 	uc.UploadCandidates = append(uc.UploadCandidates, file)
 }
 
+// Return the total size of the files in the chunk
+func (uc *UploadChunk) GetTotalSizeBytes() (totalSize int64) {
+	for _, uploadCandidate := range uc.UploadCandidates {
+		totalSize += uploadCandidate.Size
+	}
+	return
+}
+
 // Return true if the chunk contains at least 16 files or at least 1GiB in total
 func (uc *UploadChunk) IsChunkFull() bool {
 	if len(uc.UploadCandidates) >= maxFilesInChunk {
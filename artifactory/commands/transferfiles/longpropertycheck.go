@@ -215,7 +215,7 @@ func (lpc *LongPropertyCheck) runSearchPropertyInFilesAql(serviceManager artifac
 
 // Get the query that search files with specific property
 func (lpc *LongPropertyCheck) getSearchPropertyInFilesQuery(property Property) string {
-	query := fmt.Sprintf(`items.find({"type": {"$eq":"any"},"@%s":"%s"}).include("repo","path","name")`, property.Key, property.Value)
+	query := utils.Items().Type("any").PropEq(property.Key, property.Value).Include("repo", "path", "name").Build()
 	query += appendDistinctIfNeeded(lpc.disabledDistinctiveAql)
 	return query
 }
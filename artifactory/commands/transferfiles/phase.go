@@ -39,6 +39,7 @@ type transferPhase interface {
 	setDisabledDistinctiveAql()
 	setStopSignal(stopSignal chan os.Signal)
 	setMinCheckSumDeploySize(minCheckSumDeploySize int64)
+	setScheduler(scheduler *transferScheduler)
 	StopGracefully()
 }
 
@@ -61,6 +62,7 @@ type phaseBase struct {
 	stateManager              *state.TransferStateManager
 	locallyGeneratedFilter    *locallyGeneratedFilter
 	stopSignal                chan os.Signal
+	scheduler                 *transferScheduler
 	// Optimization in Artifactory version 7.37 and above enables the exclusion of setting DISTINCT in SQL queries
 	disabledDistinctiveAql bool
 	minCheckSumDeploySize  int64
@@ -157,6 +159,10 @@ func (pb *phaseBase) setStopSignal(stopSignal chan os.Signal) {
 	pb.stopSignal = stopSignal
 }
 
+func (pb *phaseBase) setScheduler(scheduler *transferScheduler) {
+	pb.scheduler = scheduler
+}
+
 func createTransferPhase(i int) transferPhase {
 	// Initialize a pointer to an empty producerConsumerWrapper to allow access the real value in StopGracefully
 	curPhaseBase := phaseBase{phaseId: i, pcDetails: &producerConsumerWrapper{}}
@@ -0,0 +1,141 @@
+package transferfiles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles/state"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const transferWindowTimeLayout = "15:04"
+
+// The daily time window in which files may be transferred, in the local time zone, such as 22:00-06:00.
+// The window may span midnight. The start time is included in the window, and the end time is excluded.
+type transferWindow struct {
+	// Minutes since midnight
+	start int
+	end   int
+}
+
+func parseTransferWindow(window string) (*transferWindow, error) {
+	startStr, endStr, found := strings.Cut(strings.TrimSpace(window), "-")
+	if !found {
+		return nil, errorutils.CheckErrorf("invalid transfer window '%s'. The transfer window should be in the format HH:MM-HH:MM, such as 22:00-06:00", window)
+	}
+	start, err := time.Parse(transferWindowTimeLayout, strings.TrimSpace(startStr))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid start time of the transfer window '%s': %s", window, err.Error())
+	}
+	end, err := time.Parse(transferWindowTimeLayout, strings.TrimSpace(endStr))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid end time of the transfer window '%s': %s", window, err.Error())
+	}
+	tw := &transferWindow{start: start.Hour()*60 + start.Minute(), end: end.Hour()*60 + end.Minute()}
+	if tw.start == tw.end {
+		return nil, errorutils.CheckErrorf("invalid transfer window '%s'. The start and end times must be different", window)
+	}
+	return tw, nil
+}
+
+func (tw *transferWindow) contains(t time.Time) bool {
+	minutes := t.Hour()*60 + t.Minute()
+	if tw.start < tw.end {
+		return minutes >= tw.start && minutes < tw.end
+	}
+	// The window spans midnight
+	return minutes >= tw.start || minutes < tw.end
+}
+
+// Returns the next time the window opens after t.
+func (tw *transferWindow) nextStart(t time.Time) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), tw.start/60, tw.start%60, 0, 0, t.Location())
+	if !start.After(t) {
+		start = start.AddDate(0, 0, 1)
+	}
+	return start
+}
+
+// Delays sending the chunks to the source Artifactory, to transfer files only in the allowed time window and at the max transfer rate.
+// The chunks which were already sent are transferred by the source Artifactory, so a pause takes effect once they're done.
+type transferScheduler struct {
+	window            *transferWindow
+	maxBytesPerSecond int64
+	stateManager      *state.TransferStateManager
+	// The time from which the next chunk may be sent, according to the max transfer rate.
+	nextSendTime time.Time
+	mutex        sync.Mutex
+}
+
+// Returns nil if the transfer is not limited.
+func newTransferScheduler(window *transferWindow, maxBytesPerSecond int64, stateManager *state.TransferStateManager) *transferScheduler {
+	if window == nil && maxBytesPerSecond <= 0 {
+		return nil
+	}
+	return &transferScheduler{window: window, maxBytesPerSecond: maxBytesPerSecond, stateManager: stateManager}
+}
+
+// Block until a chunk with files of the input total size may be sent.
+// Returns true if the transfer was stopped while waiting.
+func (ts *transferScheduler) waitForChunk(ctx context.Context, chunkSizeBytes int64) (stopped bool) {
+	if ts == nil {
+		return false
+	}
+	// Hold the lock while waiting, so that all the uploading threads are paused together.
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	if ts.window != nil && !ts.window.contains(time.Now()) {
+		if !ts.pauseUntilWindowStart(ctx) {
+			return true
+		}
+	}
+	if ts.maxBytesPerSecond <= 0 {
+		return ctx.Err() != nil
+	}
+	now := time.Now()
+	if ts.nextSendTime.After(now) {
+		if !sleepWithContext(ctx, ts.nextSendTime.Sub(now)) {
+			return true
+		}
+		now = ts.nextSendTime
+	}
+	ts.nextSendTime = now.Add(time.Duration(float64(chunkSizeBytes) / float64(ts.maxBytesPerSecond) * float64(time.Second)))
+	return false
+}
+
+// Returns false if the transfer was stopped during the pause.
+func (ts *transferScheduler) pauseUntilWindowStart(ctx context.Context) bool {
+	resumeTime := ts.window.nextStart(time.Now())
+	log.Info(fmt.Sprintf("The current time is outside the allowed transfer window. Pausing the transfer until %s...", resumeTime.Format(time.DateTime)))
+	if ts.stateManager != nil {
+		if err := ts.stateManager.SetPaused(resumeTime); err != nil {
+			log.Error(err)
+		}
+	}
+	resumed := sleepWithContext(ctx, time.Until(resumeTime))
+	if ts.stateManager != nil {
+		if err := ts.stateManager.SetResumed(); err != nil {
+			log.Error(err)
+		}
+	}
+	if resumed {
+		log.Info("Resuming the transfer.")
+	}
+	return resumed
+}
+
+// Returns false if the context was canceled before the duration elapsed.
+func sleepWithContext(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,102 @@
+package transferfiles
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/transferfiles/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransferWindow(t *testing.T) {
+	testCases := []struct {
+		window        string
+		expected      *transferWindow
+		errorExpected bool
+	}{
+		{window: "22:00-06:00", expected: &transferWindow{start: 22 * 60, end: 6 * 60}},
+		{window: " 01:30 - 05:45 ", expected: &transferWindow{start: 90, end: 5*60 + 45}},
+		{window: "22:00", errorExpected: true},
+		{window: "25:00-06:00", errorExpected: true},
+		{window: "22:00-6pm", errorExpected: true},
+		{window: "22:00-22:00", errorExpected: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.window, func(t *testing.T) {
+			window, err := parseTransferWindow(testCase.window)
+			if testCase.errorExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, window)
+		})
+	}
+}
+
+func TestTransferWindowContains(t *testing.T) {
+	overnight := &transferWindow{start: 22 * 60, end: 6 * 60}
+	daytime := &transferWindow{start: 9 * 60, end: 17 * 60}
+	testCases := []struct {
+		name      string
+		window    *transferWindow
+		time      string
+		contained bool
+		nextStart string
+	}{
+		{name: "Overnight before midnight", window: overnight, time: "2024-05-01T23:00:00Z", contained: true, nextStart: "2024-05-02T22:00:00Z"},
+		{name: "Overnight after midnight", window: overnight, time: "2024-05-02T05:59:00Z", contained: true, nextStart: "2024-05-02T22:00:00Z"},
+		{name: "Overnight end", window: overnight, time: "2024-05-02T06:00:00Z", contained: false, nextStart: "2024-05-02T22:00:00Z"},
+		{name: "Overnight start", window: overnight, time: "2024-05-01T22:00:00Z", contained: true, nextStart: "2024-05-02T22:00:00Z"},
+		{name: "Daytime", window: daytime, time: "2024-05-01T12:00:00Z", contained: true, nextStart: "2024-05-02T09:00:00Z"},
+		{name: "Before daytime", window: daytime, time: "2024-05-01T08:00:00Z", contained: false, nextStart: "2024-05-01T09:00:00Z"},
+		{name: "After daytime", window: daytime, time: "2024-05-01T18:00:00Z", contained: false, nextStart: "2024-05-02T09:00:00Z"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, testCase.time)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.contained, testCase.window.contains(now))
+			assert.Equal(t, testCase.nextStart, testCase.window.nextStart(now).Format(time.RFC3339))
+		})
+	}
+}
+
+func TestTransferSchedulerMaxRate(t *testing.T) {
+	assert.Nil(t, newTransferScheduler(nil, 0, nil))
+	// A nil scheduler doesn't limit the transfer
+	var unlimited *transferScheduler
+	assert.False(t, unlimited.waitForChunk(context.Background(), 1000))
+
+	scheduler := newTransferScheduler(nil, 1000, nil)
+	start := time.Now()
+	// The first chunk is sent immediately, and delays the next chunk by 200 milliseconds
+	assert.False(t, scheduler.waitForChunk(context.Background(), 200))
+	assert.False(t, scheduler.waitForChunk(context.Background(), 200))
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	// Waiting is stopped when the transfer is stopped
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.False(t, scheduler.waitForChunk(ctx, 10000))
+	cancel()
+	assert.True(t, scheduler.waitForChunk(ctx, 10000))
+}
+
+func TestTransferSchedulerPause(t *testing.T) {
+	stateManager, cleanUp := state.InitStateTest(t)
+	defer cleanUp()
+
+	// A window which opens in a minute, so the scheduler pauses until the transfer is stopped
+	now := time.Now().Add(time.Minute)
+	windowStart := now.Hour()*60 + now.Minute()
+	scheduler := newTransferScheduler(&transferWindow{start: windowStart, end: (windowStart + 1) % (24 * 60)}, 0, stateManager)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.True(t, scheduler.waitForChunk(ctx, 1000))
+	require.Len(t, stateManager.Pauses, 1)
+	assert.NotEmpty(t, stateManager.Pauses[0].Started)
+	assert.NotEmpty(t, stateManager.Pauses[0].Ended)
+	assert.Empty(t, stateManager.PausedUntil)
+}
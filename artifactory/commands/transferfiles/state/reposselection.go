@@ -0,0 +1,96 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"golang.org/x/exp/slices"
+)
+
+// The filters used by the transfer-files command to select the repositories to transfer.
+type ReposFilters struct {
+	IncludePatterns []string `json:"include_patterns,omitempty"`
+	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+	IncludeRegex    string   `json:"include_regex,omitempty"`
+	ExcludeRegex    string   `json:"exclude_regex,omitempty"`
+	PackageTypes    []string `json:"package_types,omitempty"`
+}
+
+func (rf *ReposFilters) Equal(other ReposFilters) bool {
+	return slices.Equal(rf.IncludePatterns, other.IncludePatterns) &&
+		slices.Equal(rf.ExcludePatterns, other.ExcludePatterns) &&
+		rf.IncludeRegex == other.IncludeRegex &&
+		rf.ExcludeRegex == other.ExcludeRegex &&
+		slices.Equal(rf.PackageTypes, other.PackageTypes)
+}
+
+// Returns true if the repositories are selected by regular expressions or package types,
+// whose selection is saved to be reused by a resumed transfer.
+func (rf *ReposFilters) HasSelectionFilters() bool {
+	return rf.IncludeRegex != "" || rf.ExcludeRegex != "" || len(rf.PackageTypes) > 0
+}
+
+// This struct holds the source repositories selected by the transfer-files command, along with the filters and servers which selected them.
+// It is saved to a file in the transfer directory, so that a resumed transfer between the same servers with the same filters transfers
+// the same repositories, even if repositories were created or removed in the source Artifactory since.
+// The selection is removed once the transfer completes.
+type ReposSelection struct {
+	SourceServerId string       `json:"source_server_id,omitempty"`
+	TargetServerId string       `json:"target_server_id,omitempty"`
+	Filters        ReposFilters `json:"filters,omitempty"`
+	LocalRepos     []string     `json:"local_repos,omitempty"`
+	BuildInfoRepos []string     `json:"build_info_repos,omitempty"`
+}
+
+// Returns true if the selection was made by the same filters for the same source and target servers.
+func (rs *ReposSelection) Matches(sourceServerId, targetServerId string, filters ReposFilters) bool {
+	return rs.SourceServerId == sourceServerId && rs.TargetServerId == targetServerId && rs.Filters.Equal(filters)
+}
+
+func (rs *ReposSelection) Persist() error {
+	selectionFilePath, err := coreutils.GetJfrogTransferReposSelectionFilePath()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(rs)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(selectionFilePath, content, 0600))
+}
+
+// Load the repositories selection of the previous transfer, or nil if there's none.
+func LoadReposSelection() (*ReposSelection, error) {
+	selectionFilePath, err := coreutils.GetJfrogTransferReposSelectionFilePath()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsFileExists(selectionFilePath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := fileutils.ReadFile(selectionFilePath)
+	if err != nil {
+		return nil, err
+	}
+	reposSelection := new(ReposSelection)
+	if err = json.Unmarshal(content, reposSelection); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return reposSelection, nil
+}
+
+// Remove the repositories selection of the previous transfer, if exists.
+func RemoveReposSelection() error {
+	selectionFilePath, err := coreutils.GetJfrogTransferReposSelectionFilePath()
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(selectionFilePath); err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
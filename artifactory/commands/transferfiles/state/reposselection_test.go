@@ -0,0 +1,45 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoadReposSelection(t *testing.T) {
+	_, cleanUp := InitStateTest(t)
+	defer cleanUp()
+
+	reposSelection, err := LoadReposSelection()
+	require.NoError(t, err)
+	assert.Nil(t, reposSelection)
+
+	expected := &ReposSelection{
+		SourceServerId: "source",
+		TargetServerId: "target",
+		Filters:        ReposFilters{IncludeRegex: "^repo[1-3]$", PackageTypes: []string{"maven", "buildinfo"}},
+		LocalRepos:     []string{repo1Key, repo2Key},
+		BuildInfoRepos: []string{repo3Key},
+	}
+	require.NoError(t, expected.Persist())
+	reposSelection, err = LoadReposSelection()
+	require.NoError(t, err)
+	assert.Equal(t, expected, reposSelection)
+	assert.True(t, reposSelection.Matches("source", "target", expected.Filters))
+	assert.False(t, reposSelection.Matches("other-source", "target", expected.Filters))
+
+	require.NoError(t, RemoveReposSelection())
+	reposSelection, err = LoadReposSelection()
+	require.NoError(t, err)
+	assert.Nil(t, reposSelection)
+	// Removing a missing selection is a no-op
+	assert.NoError(t, RemoveReposSelection())
+}
+
+func TestReposFiltersEqual(t *testing.T) {
+	filters := ReposFilters{IncludePatterns: []string{"*-local"}, ExcludeRegex: "docker", PackageTypes: []string{"npm"}}
+	assert.True(t, filters.Equal(ReposFilters{IncludePatterns: []string{"*-local"}, ExcludePatterns: []string{}, ExcludeRegex: "docker", PackageTypes: []string{"npm"}}))
+	assert.False(t, filters.Equal(ReposFilters{IncludePatterns: []string{"*-local"}, ExcludeRegex: "docker"}))
+	assert.False(t, filters.Equal(ReposFilters{IncludePatterns: []string{"*-local"}, IncludeRegex: "docker", PackageTypes: []string{"npm"}}))
+}
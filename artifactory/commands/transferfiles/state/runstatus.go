@@ -43,6 +43,10 @@ type TransferRunStatus struct {
 	TransferFailures      uint64 `json:"transfer_failures,omitempty"`
 	TimeEstimationManager `json:"time_estimation,omitempty"`
 	StaleChunks           []StaleChunks `json:"stale_chunks,omitempty"`
+	// The pauses of the transfer outside the allowed transfer window.
+	Pauses []PhaseDetails `json:"pauses,omitempty"`
+	// The time at which the paused transfer resumes, or empty if the transfer isn't paused.
+	PausedUntil string `json:"paused_until,omitempty"`
 }
 
 // This structure contains a collection of chunks that have been undergoing processing for over 30 minutes
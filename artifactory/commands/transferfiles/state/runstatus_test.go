@@ -2,6 +2,7 @@ package state
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -20,3 +21,24 @@ func TestSaveAndLoadRunStatus(t *testing.T) {
 	actualStatus.TimeEstimationManager.stateManager = stateManager
 	assert.Equal(t, stateManager.TransferRunStatus, actualStatus)
 }
+
+func TestSetPausedAndResumed(t *testing.T) {
+	stateManager, cleanUp := InitStateTest(t)
+	defer cleanUp()
+	resumeTime := time.Now().Add(time.Hour)
+
+	assert.NoError(t, stateManager.SetPaused(resumeTime))
+	actualStatus, exists, err := loadTransferRunStatus()
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, ConvertTimeToRFC3339(resumeTime), actualStatus.PausedUntil)
+	assert.Len(t, actualStatus.Pauses, 1)
+	assert.Empty(t, actualStatus.Pauses[0].Ended)
+
+	assert.NoError(t, stateManager.SetResumed())
+	actualStatus, _, err = loadTransferRunStatus()
+	assert.NoError(t, err)
+	assert.Empty(t, actualStatus.PausedUntil)
+	assert.Len(t, actualStatus.Pauses, 1)
+	assert.NotEmpty(t, actualStatus.Pauses[0].Ended)
+}
@@ -362,6 +362,37 @@ func (ts *TransferStateManager) GetStaleChunks() (staleChunks []StaleChunks, err
 	})
 }
 
+// Record the beginning of a pause of the transfer, which resumes at resumeTime.
+// The run status is persisted immediately, since no other action updates it during the pause.
+func (ts *TransferStateManager) SetPaused(resumeTime time.Time) error {
+	return ts.actionAndPersist(func(transferRunStatus *TransferRunStatus) error {
+		transferRunStatus.Pauses = append(transferRunStatus.Pauses, PhaseDetails{Started: ConvertTimeToRFC3339(time.Now())})
+		transferRunStatus.PausedUntil = ConvertTimeToRFC3339(resumeTime)
+		return nil
+	})
+}
+
+// Record the end of the current pause of the transfer.
+func (ts *TransferStateManager) SetResumed() error {
+	return ts.actionAndPersist(func(transferRunStatus *TransferRunStatus) error {
+		if len(transferRunStatus.Pauses) > 0 {
+			transferRunStatus.Pauses[len(transferRunStatus.Pauses)-1].Ended = ConvertTimeToRFC3339(time.Now())
+		}
+		transferRunStatus.PausedUntil = ""
+		return nil
+	})
+}
+
+func (ts *TransferStateManager) actionAndPersist(action ActionOnStatusFunc) error {
+	saveRunStatusMutex.Lock()
+	defer saveRunStatusMutex.Unlock()
+	if err := action(&ts.TransferRunStatus); err != nil {
+		return err
+	}
+	ts.TransferRunStatus.lastSaveTimestamp = time.Now()
+	return ts.persistTransferRunStatus()
+}
+
 func (ts *TransferStateManager) SaveStateAndSnapshots() error {
 	ts.TransferState.lastSaveTimestamp = time.Now()
 	if err := ts.persistTransferState(false); err != nil {
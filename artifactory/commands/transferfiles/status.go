@@ -75,7 +75,11 @@ func isStopping() (bool, error) {
 
 func addOverallStatus(stateManager *state.TransferStateManager, output *strings.Builder, runningTime string) error {
 	addTitle(output, "Overall Transfer Status")
-	addString(output, coreutils.RemoveEmojisIfNonSupportedTerminal("🟢"), "Status", "Running", 3)
+	if pausedUntil, err := state.ConvertRFC3339ToTime(stateManager.PausedUntil); stateManager.PausedUntil != "" && err == nil {
+		addString(output, coreutils.RemoveEmojisIfNonSupportedTerminal("🟡"), "Status", "Paused until "+pausedUntil.Format(time.DateTime), 3)
+	} else {
+		addString(output, coreutils.RemoveEmojisIfNonSupportedTerminal("🟢"), "Status", "Running", 3)
+	}
 	addString(output, "🏃", "Running for", runningTime, 3)
 	addString(output, "🗄 ", "Storage", sizeToString(stateManager.OverallTransfer.TransferredSizeBytes)+" / "+sizeToString(stateManager.OverallTransfer.TotalSizeBytes)+calcPercentageInt64(stateManager.OverallTransfer.TransferredSizeBytes, stateManager.OverallTransfer.TotalSizeBytes), 3)
 	addString(output, "📦", "Repositories", fmt.Sprintf("%d / %d", stateManager.TotalRepositories.TransferredUnits, stateManager.TotalRepositories.TotalUnits)+calcPercentageInt64(stateManager.TotalRepositories.TransferredUnits, stateManager.TotalRepositories.TotalUnits), 2)
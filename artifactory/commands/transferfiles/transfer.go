@@ -13,6 +13,7 @@ import (
 	"syscall"
 
 	"github.com/jfrog/gofrog/safeconvert"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 
 	"github.com/jfrog/gofrog/version"
@@ -38,6 +39,7 @@ const (
 	retriesWaitMilliSecs         = 5000
 	dataTransferPluginMinVersion = "1.7.0"
 	disableDistinctAqlMinVersion = "7.37"
+	buildInfoPackageType         = "buildinfo"
 )
 
 type TransferFilesCommand struct {
@@ -51,6 +53,12 @@ type TransferFilesCommand struct {
 	progressbar               *TransferProgressMng
 	includeReposPatterns      []string
 	excludeReposPatterns      []string
+	includeReposRegex         string
+	excludeReposRegex         string
+	packageTypes              []string
+	maxTransferRate           string
+	transferWindow            string
+	scheduler                 *transferScheduler
 	ignoreState               bool
 	proxyKey                  string
 	status                    bool
@@ -95,6 +103,30 @@ func (tdc *TransferFilesCommand) SetExcludeReposPatterns(excludeReposPatterns []
 	tdc.excludeReposPatterns = excludeReposPatterns
 }
 
+func (tdc *TransferFilesCommand) SetIncludeReposRegex(includeReposRegex string) {
+	tdc.includeReposRegex = includeReposRegex
+}
+
+func (tdc *TransferFilesCommand) SetExcludeReposRegex(excludeReposRegex string) {
+	tdc.excludeReposRegex = excludeReposRegex
+}
+
+// Transfer only the repositories of these package types. Build-info repositories are transferred only if the 'buildinfo' package type is included.
+func (tdc *TransferFilesCommand) SetPackageTypes(packageTypes []string) {
+	tdc.packageTypes = packageTypes
+}
+
+// Limit the rate of the transferred files, such as 10MB (per second).
+func (tdc *TransferFilesCommand) SetMaxTransferRate(maxTransferRate string) {
+	tdc.maxTransferRate = maxTransferRate
+}
+
+// Transfer files only in a daily time window in the local time zone, such as 22:00-06:00.
+// Outside the time window, the transfer is paused and automatically resumed once the time window opens.
+func (tdc *TransferFilesCommand) SetTransferWindow(transferWindow string) {
+	tdc.transferWindow = transferWindow
+}
+
 func (tdc *TransferFilesCommand) SetIgnoreState(ignoreState bool) {
 	tdc.ignoreState = ignoreState
 }
@@ -122,6 +154,9 @@ func (tdc *TransferFilesCommand) Run() (err error) {
 	if tdc.stop {
 		return tdc.signalStop()
 	}
+	if err = tdc.initScheduler(); err != nil {
+		return err
+	}
 	if err = tdc.stateManager.TryLockTransferStateManager(); err != nil {
 		return err
 	}
@@ -169,7 +204,7 @@ func (tdc *TransferFilesCommand) Run() (err error) {
 		return err
 	}
 
-	sourceLocalRepos, sourceBuildInfoRepos, err := tdc.getAllLocalRepos(tdc.sourceServerDetails, tdc.sourceStorageInfoManager)
+	sourceLocalRepos, sourceBuildInfoRepos, err := tdc.getSourceRepos()
 	if err != nil {
 		return err
 	}
@@ -343,11 +378,11 @@ func (tdc *TransferFilesCommand) NewTransferDataPreChecksRunner() (runner *prech
 	if err != nil {
 		return
 	}
-	localRepos, err := utils.GetFilteredRepositoriesWithFilterParams(serviceManager, tdc.includeReposPatterns, tdc.excludeReposPatterns, services.RepositoriesFilterParams{RepoType: utils.Local.String()})
+	localRepos, err := tdc.getFilteredRepos(serviceManager, tdc.excludeReposPatterns, utils.Local)
 	if err != nil {
 		return
 	}
-	federatedRepos, err := utils.GetFilteredRepositoriesWithFilterParams(serviceManager, tdc.includeReposPatterns, tdc.excludeReposPatterns, services.RepositoriesFilterParams{RepoType: utils.Federated.String()})
+	federatedRepos, err := tdc.getFilteredRepos(serviceManager, tdc.excludeReposPatterns, utils.Federated)
 	if err != nil {
 		return
 	}
@@ -575,6 +610,22 @@ func (tdc *TransferFilesCommand) initNewPhase(newPhase transferPhase, srcUpServi
 	newPhase.setLocallyGeneratedFilter(tdc.locallyGeneratedFilter)
 	newPhase.setStopSignal(tdc.stopSignal)
 	newPhase.setMinCheckSumDeploySize(minChecksumDeploySize)
+	newPhase.setScheduler(tdc.scheduler)
+}
+
+func (tdc *TransferFilesCommand) initScheduler() error {
+	maxBytesPerSecond, err := utils.ParseRateLimit(tdc.maxTransferRate)
+	if err != nil {
+		return err
+	}
+	var window *transferWindow
+	if tdc.transferWindow != "" {
+		if window, err = parseTransferWindow(tdc.transferWindow); err != nil {
+			return err
+		}
+	}
+	tdc.scheduler = newTransferScheduler(window, maxBytesPerSecond, tdc.stateManager)
+	return nil
 }
 
 // Get all local and build-info repositories of the input server
@@ -587,15 +638,18 @@ func (tdc *TransferFilesCommand) getAllLocalRepos(serverDetails *config.ServerDe
 	}
 	excludeRepoPatternsWithBuildInfo := tdc.excludeReposPatterns
 	excludeRepoPatternsWithBuildInfo = append(excludeRepoPatternsWithBuildInfo, "*-build-info")
-	localRepos, err := utils.GetFilteredRepositoriesWithFilterParams(serviceManager, tdc.includeReposPatterns, excludeRepoPatternsWithBuildInfo, services.RepositoriesFilterParams{RepoType: utils.Local.String()})
+	localRepos, err := tdc.getFilteredRepos(serviceManager, excludeRepoPatternsWithBuildInfo, utils.Local)
 	if err != nil {
 		return []string{}, []string{}, err
 	}
-	federatedRepos, err := utils.GetFilteredRepositoriesWithFilterParams(serviceManager, tdc.includeReposPatterns, excludeRepoPatternsWithBuildInfo, services.RepositoriesFilterParams{RepoType: utils.Federated.String()})
+	federatedRepos, err := tdc.getFilteredRepos(serviceManager, excludeRepoPatternsWithBuildInfo, utils.Federated)
 	if err != nil {
 		return []string{}, []string{}, err
 	}
 
+	if !tdc.shouldTransferBuildInfoRepos() {
+		return append(localRepos, federatedRepos...), []string{}, nil
+	}
 	storageInfo, err := storageInfoManager.GetStorageInfo()
 	if err != nil {
 		return []string{}, []string{}, err
@@ -605,10 +659,90 @@ func (tdc *TransferFilesCommand) getAllLocalRepos(serverDetails *config.ServerDe
 	if err != nil {
 		return []string{}, []string{}, err
 	}
+	buildInfoRepoKeys, err = utils.FilterRepositoryNamesByRegex(buildInfoRepoKeys, tdc.includeReposRegex, tdc.excludeReposRegex)
+	if err != nil {
+		return []string{}, []string{}, err
+	}
 
 	return append(localRepos, federatedRepos...), buildInfoRepoKeys, err
 }
 
+// Get the local and build-info repositories to transfer from the source server.
+// If the repositories are selected by regular expressions or package types, the selection is saved, so that a resumed transfer
+// between the same servers with the same filters transfers the same repositories, unless the state is ignored.
+func (tdc *TransferFilesCommand) getSourceRepos() ([]string, []string, error) {
+	reposFilters := tdc.getReposFilters()
+	if !reposFilters.HasSelectionFilters() {
+		return tdc.getAllLocalRepos(tdc.sourceServerDetails, tdc.sourceStorageInfoManager)
+	}
+	sourceServerId, targetServerId := tdc.sourceServerDetails.ServerId, tdc.getTargetServerId()
+	if !tdc.ignoreState {
+		reposSelection, err := state.LoadReposSelection()
+		if err != nil {
+			return []string{}, []string{}, err
+		}
+		if reposSelection != nil && reposSelection.Matches(sourceServerId, targetServerId, reposFilters) {
+			log.Info(fmt.Sprintf("Resuming the transfer of the %d repositories selected by the previous run.", len(reposSelection.LocalRepos)+len(reposSelection.BuildInfoRepos)))
+			return reposSelection.LocalRepos, reposSelection.BuildInfoRepos, nil
+		}
+	}
+	localRepos, buildInfoRepos, err := tdc.getAllLocalRepos(tdc.sourceServerDetails, tdc.sourceStorageInfoManager)
+	if err != nil {
+		return []string{}, []string{}, err
+	}
+	reposSelection := &state.ReposSelection{SourceServerId: sourceServerId, TargetServerId: targetServerId, Filters: reposFilters, LocalRepos: localRepos, BuildInfoRepos: buildInfoRepos}
+	return localRepos, buildInfoRepos, reposSelection.Persist()
+}
+
+func (tdc *TransferFilesCommand) getTargetServerId() string {
+	if tdc.targetServerDetails == nil {
+		return ""
+	}
+	return tdc.targetServerDetails.ServerId
+}
+
+func (tdc *TransferFilesCommand) getReposFilters() state.ReposFilters {
+	return state.ReposFilters{
+		IncludePatterns: tdc.includeReposPatterns,
+		ExcludePatterns: tdc.excludeReposPatterns,
+		IncludeRegex:    tdc.includeReposRegex,
+		ExcludeRegex:    tdc.excludeReposRegex,
+		PackageTypes:    tdc.packageTypes,
+	}
+}
+
+// Get the repositories of the input type, filtered by the names patterns, the regular expressions and the package types.
+func (tdc *TransferFilesCommand) getFilteredRepos(serviceManager artifactory.ArtifactoryServicesManager, excludePatterns []string, repoType utils.RepoType) ([]string, error) {
+	var repos []string
+	if len(tdc.packageTypes) == 0 {
+		filteredRepos, err := utils.GetFilteredRepositoriesWithFilterParams(serviceManager, tdc.includeReposPatterns, excludePatterns, services.RepositoriesFilterParams{RepoType: repoType.String()})
+		if err != nil {
+			return nil, err
+		}
+		repos = filteredRepos
+	}
+	for _, packageType := range tdc.packageTypes {
+		if strings.EqualFold(packageType, buildInfoPackageType) {
+			continue
+		}
+		filteredRepos, err := utils.GetFilteredRepositoriesWithFilterParams(serviceManager, tdc.includeReposPatterns, excludePatterns, services.RepositoriesFilterParams{RepoType: repoType.String(), PackageType: packageType})
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, filteredRepos...)
+	}
+	return utils.FilterRepositoryNamesByRegex(repos, tdc.includeReposRegex, tdc.excludeReposRegex)
+}
+
+func (tdc *TransferFilesCommand) shouldTransferBuildInfoRepos() bool {
+	if len(tdc.packageTypes) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(tdc.packageTypes, func(packageType string) bool {
+		return strings.EqualFold(packageType, buildInfoPackageType)
+	})
+}
+
 func (tdc *TransferFilesCommand) initCurThreads(buildInfoRepo bool) error {
 	// Use default threads if settings file doesn't exist or an error occurred.
 	curChunkUploaderThreads = utils.DefaultThreads
@@ -658,6 +792,12 @@ func (tdc *TransferFilesCommand) cleanup(originalErr error, sourceRepos []string
 	if originalErr == nil {
 		log.Info("Files transfer is complete!")
 	}
+	// The next transfer should select the repositories again, unless this one was stopped before completion
+	if originalErr == nil && !tdc.shouldStop() {
+		if e := state.RemoveReposSelection(); e != nil {
+			log.Error("Couldn't remove the repositories selection", e)
+		}
+	}
 	if tdc.stateManager.CurrentRepo.Name != "" {
 		e := tdc.stateManager.SaveStateAndSnapshots()
 		if e != nil {
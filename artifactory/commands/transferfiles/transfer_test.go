@@ -365,6 +365,75 @@ func TestGetAllLocalRepositories(t *testing.T) {
 	assert.ElementsMatch(t, []string{"artifactory-build-info", "proj-build-info"}, localBuildInfoRepo)
 }
 
+func TestGetSourceReposWithFilters(t *testing.T) {
+	_, cleanUp := state.InitStateTest(t)
+	defer cleanUp()
+	repositoriesRequests := 0
+	testServer, serverDetails, _ := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var response any
+		switch r.RequestURI {
+		case "/api/storageinfo":
+			response = &artifactoryUtils.StorageInfo{RepositoriesSummaryList: []artifactoryUtils.RepositorySummary{
+				{RepoKey: "artifactory-build-info", PackageType: "BuildInfo"}, {RepoKey: "proj-build-info", PackageType: "BuildInfo"}},
+			}
+		case "/api/repositories?packageType=maven&type=local":
+			repositoriesRequests++
+			response = &[]services.RepositoryDetails{{Key: "maven-local"}, {Key: "maven-legacy-local"}}
+		case "/api/repositories?packageType=npm&type=local":
+			repositoriesRequests++
+			response = &[]services.RepositoryDetails{{Key: "npm-local"}}
+		default:
+			response = &[]services.RepositoryDetails{}
+		}
+		w.WriteHeader(http.StatusOK)
+		writeMockResponse(t, w, response)
+	})
+	defer testServer.Close()
+
+	transferFilesCommand, err := NewTransferFilesCommand(serverDetails, nil)
+	assert.NoError(t, err)
+	transferFilesCommand.SetPackageTypes([]string{"maven", "npm", "BuildInfo"})
+	transferFilesCommand.SetExcludeReposRegex("legacy|^artifactory-")
+	transferFilesCommand.sourceStorageInfoManager, err = coreUtils.NewStorageInfoManager(context.Background(), serverDetails)
+	assert.NoError(t, err)
+	localRepos, buildInfoRepos, err := transferFilesCommand.getSourceRepos()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"maven-local", "npm-local"}, localRepos)
+	assert.ElementsMatch(t, []string{"proj-build-info"}, buildInfoRepos)
+	assert.Equal(t, 2, repositoriesRequests)
+
+	// A resumed transfer with the same filters transfers the same repositories
+	localRepos, buildInfoRepos, err = transferFilesCommand.getSourceRepos()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"maven-local", "npm-local"}, localRepos)
+	assert.ElementsMatch(t, []string{"proj-build-info"}, buildInfoRepos)
+	assert.Equal(t, 2, repositoriesRequests)
+
+	// Changing the filters selects the repositories again
+	transferFilesCommand.SetPackageTypes([]string{"npm"})
+	localRepos, buildInfoRepos, err = transferFilesCommand.getSourceRepos()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"npm-local"}, localRepos)
+	assert.Empty(t, buildInfoRepos)
+	assert.Equal(t, 3, repositoriesRequests)
+
+	// A transfer from another source server selects the repositories again
+	transferFilesCommand.sourceServerDetails.ServerId = "other-source"
+	_, _, err = transferFilesCommand.getSourceRepos()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, repositoriesRequests)
+
+	// The selection isn't saved without regular expressions or package types
+	assert.NoError(t, state.RemoveReposSelection())
+	transferFilesCommand.SetPackageTypes(nil)
+	transferFilesCommand.SetExcludeReposRegex("")
+	_, _, err = transferFilesCommand.getSourceRepos()
+	assert.NoError(t, err)
+	reposSelection, err := state.LoadReposSelection()
+	assert.NoError(t, err)
+	assert.Nil(t, reposSelection)
+}
+
 func TestInitStorageInfoManagers(t *testing.T) {
 	sourceServerCalculated, targetServerCalculated := false, false
 	// Prepare source mock server
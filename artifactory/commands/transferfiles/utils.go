@@ -230,9 +230,12 @@ func handleFilesOfCompletedChunk(chunkFiles []api.FileUploadStatusResponse, erro
 	return
 }
 
-// Uploads chunk when there is room in queue.
+// Uploads chunk when there is room in queue, and the transfer window and max transfer rate allow it.
 // This is a blocking method.
 func uploadChunkWhenPossible(pcWrapper *producerConsumerWrapper, phaseBase *phaseBase, chunk api.UploadChunk, uploadTokensChan chan UploadedChunk, errorsChannelMng *ErrorsChannelMng) (stopped bool) {
+	if phaseBase.scheduler.waitForChunk(phaseBase.context, chunk.GetTotalSizeBytes()) {
+		return true
+	}
 	for {
 		if ShouldStop(phaseBase, nil, errorsChannelMng) {
 			return true
@@ -1,15 +1,20 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
 )
 
 const pathErrorSuffixMsg = " please enter a path, in which the new template file will be created"
@@ -21,6 +26,13 @@ type TemplateUserCommand interface {
 	Vars() string
 }
 
+// A TemplateUserCommand which may also render the template with the vars of a file.
+type TemplateVarsFileUserCommand interface {
+	TemplateUserCommand
+	// Returns the path of a YAML or JSON file of vars, or an empty string if there's no such file.
+	VarsFile() string
+}
+
 func ConvertTemplateToMap(tuc TemplateUserCommand) (map[string]interface{}, error) {
 	// Read the template file
 	content, err := fileutils.ReadFile(tuc.TemplatePath())
@@ -28,16 +40,75 @@ func ConvertTemplateToMap(tuc TemplateUserCommand) (map[string]interface{}, erro
 		return nil, err
 	}
 	// Replace vars string-by-string if needed
+	var templateVars map[string]string
 	if len(tuc.Vars()) > 0 {
-		templateVars := coreutils.SpecVarsStringToMap(tuc.Vars())
+		templateVars = coreutils.SpecVarsStringToMap(tuc.Vars())
 		content = coreutils.ReplaceVars(content, templateVars)
 	}
-	// Unmarshal template to a map
+	// Render the conditionals and loops of the template, if a vars file is provided
+	if varsFileCommand, ok := tuc.(TemplateVarsFileUserCommand); ok && varsFileCommand.VarsFile() != "" {
+		if content, err = RenderTemplate(content, varsFileCommand.VarsFile(), templateVars); err != nil {
+			return nil, err
+		}
+	}
+	// Unmarshal template to a map. Templates with the .yaml or .yml extension are YAML, and all other templates are JSON.
 	var configMap map[string]interface{}
-	err = json.Unmarshal(content, &configMap)
+	if isYamlFile(tuc.TemplatePath()) {
+		err = yaml.Unmarshal(content, &configMap)
+	} else {
+		err = json.Unmarshal(content, &configMap)
+	}
 	return configMap, errorutils.CheckError(err)
 }
 
+func isYamlFile(filePath string) bool {
+	extension := strings.ToLower(filepath.Ext(filePath))
+	return extension == ".yaml" || extension == ".yml"
+}
+
+// Render the template content as a Go text/template, with the vars of the vars file and the given vars.
+// The vars file may be either YAML or JSON. The given vars override the vars of the file with the same names.
+// Besides the built-in actions, such as 'if' and 'range', the template may use the following functions:
+// 'join' to join a list with a separator and 'json' to write a value as JSON.
+// Using a var which is missing from both the vars file and the given vars fails the rendering, to catch typos in the template,
+// so optional vars should be declared in the vars file with empty values.
+func RenderTemplate(content []byte, varsFilePath string, vars map[string]string) ([]byte, error) {
+	varsFileContent, err := fileutils.ReadFile(varsFilePath)
+	if err != nil {
+		return nil, err
+	}
+	templateData := make(map[string]interface{})
+	if err = yaml.Unmarshal(varsFileContent, &templateData); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the vars file '%s': %s", varsFilePath, err.Error())
+	}
+	for key, value := range vars {
+		templateData[key] = value
+	}
+	tmpl, err := template.New(varsFilePath).Option("missingkey=error").Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("template syntax error: %s", err.Error())
+	}
+	var rendered bytes.Buffer
+	if err = tmpl.Execute(&rendered, templateData); err != nil {
+		return nil, errorutils.CheckErrorf("failed to render the template: %s", err.Error())
+	}
+	return rendered.Bytes(), nil
+}
+
+var templateFuncs = template.FuncMap{
+	"join": func(separator string, list []interface{}) string {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, separator)
+	},
+	"json": func(value interface{}) (string, error) {
+		content, err := json.Marshal(value)
+		return string(content), err
+	},
+}
+
 func ValidateMapEntry(key string, value interface{}, writersMap map[string]ioutils.AnswerWriter) error {
 	if _, ok := writersMap[key]; !ok {
 		return errorutils.CheckErrorf("template syntax error: unknown key: \"" + key + "\".")
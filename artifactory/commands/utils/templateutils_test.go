@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTemplateCommand struct {
+	templatePath string
+	vars         string
+	varsFile     string
+}
+
+func (ttc *testTemplateCommand) TemplatePath() string {
+	return ttc.templatePath
+}
+
+func (ttc *testTemplateCommand) Vars() string {
+	return ttc.vars
+}
+
+func (ttc *testTemplateCommand) VarsFile() string {
+	return ttc.varsFile
+}
+
+const testRepoTemplate = `{
+  "key": "${team}-{{ .env }}-local",
+  "rclass": "local",
+  "packageType": "{{ .packageType }}",
+  {{- if .xray }}
+  "xrayIndex": "true",
+  {{- end }}
+  "propertySets": "{{ join "," .propertySets }}",
+  "description": {{ json .description }}
+}`
+
+func TestConvertTemplateToMapWithVarsFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		varsFile  string
+		varsExt   string
+		vars      string
+		expected  map[string]interface{}
+		expectErr string
+	}{
+		{
+			name:     "yaml",
+			varsExt:  ".yaml",
+			varsFile: "env: prod\npackageType: npm\nxray: true\npropertySets: [artifactory, build]\ndescription: 'Quoted \"description\"'\n",
+			vars:     "team=web",
+			expected: map[string]interface{}{"key": "web-prod-local", "rclass": "local", "packageType": "npm", "xrayIndex": "true",
+				"propertySets": "artifactory,build", "description": `Quoted "description"`},
+		},
+		{
+			name:     "json with vars override",
+			varsExt:  ".json",
+			varsFile: `{"env": "dev", "packageType": "npm", "xray": false, "propertySets": [], "description": ""}`,
+			vars:     "team=web;env=test",
+			expected: map[string]interface{}{"key": "web-test-local", "rclass": "local", "packageType": "npm", "propertySets": "", "description": ""},
+		},
+		{
+			name:      "missing var",
+			varsExt:   ".yaml",
+			varsFile:  "env: prod\n",
+			vars:      "team=web",
+			expectErr: "failed to render the template",
+		},
+		{
+			name:      "invalid vars file",
+			varsExt:   ".yaml",
+			varsFile:  "env: [prod\n",
+			expectErr: "failed to parse the vars file",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			templatePath := filepath.Join(tempDir, "template.json")
+			varsFilePath := filepath.Join(tempDir, "vars"+test.varsExt)
+			require.NoError(t, os.WriteFile(templatePath, []byte(testRepoTemplate), 0600))
+			require.NoError(t, os.WriteFile(varsFilePath, []byte(test.varsFile), 0600))
+
+			configMap, err := ConvertTemplateToMap(&testTemplateCommand{templatePath: templatePath, vars: test.vars, varsFile: varsFilePath})
+			if test.expectErr != "" {
+				assert.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, configMap)
+		})
+	}
+}
+
+func TestConvertTemplateToMapWithoutVarsFile(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "template.json")
+	require.NoError(t, os.WriteFile(templatePath, []byte(`{"key": "${team}-local", "description": "{{ not rendered }}"}`), 0600))
+	configMap, err := ConvertTemplateToMap(&testTemplateCommand{templatePath: templatePath, vars: "team=web"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"key": "web-local", "description": "{{ not rendered }}"}, configMap)
+}
+
+func TestConvertYamlTemplateToMap(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "template.yaml")
+	require.NoError(t, os.WriteFile(templatePath, []byte("name: ${team}-readers\nrepo:\n  repositories: generic-local\n  actions-groups:\n    ${team}: read\n"), 0600))
+	configMap, err := ConvertTemplateToMap(&testTemplateCommand{templatePath: templatePath, vars: "team=web"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "web-readers", "repo": map[string]interface{}{
+		"repositories": "generic-local", "actions-groups": map[string]interface{}{"web": "read"}}}, configMap)
+}
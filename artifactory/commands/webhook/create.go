@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"fmt"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Create the webhooks of a YAML definition file. Webhooks which already exist are updated to match the definition.
+type WebhookCreateCommand struct {
+	serverDetails  *config.ServerDetails
+	definitionPath string
+	vars           string
+}
+
+func NewWebhookCreateCommand() *WebhookCreateCommand {
+	return &WebhookCreateCommand{}
+}
+
+func (wcc *WebhookCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookCreateCommand {
+	wcc.serverDetails = serverDetails
+	return wcc
+}
+
+func (wcc *WebhookCreateCommand) SetDefinitionPath(definitionPath string) *WebhookCreateCommand {
+	wcc.definitionPath = definitionPath
+	return wcc
+}
+
+func (wcc *WebhookCreateCommand) SetVars(vars string) *WebhookCreateCommand {
+	wcc.vars = vars
+	return wcc
+}
+
+func (wcc *WebhookCreateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wcc.serverDetails, nil
+}
+
+func (wcc *WebhookCreateCommand) CommandName() string {
+	return "rt_webhook_create"
+}
+
+func (wcc *WebhookCreateCommand) Run() error {
+	definition, err := readWebhooksDefinition(wcc.definitionPath, wcc.vars)
+	if err != nil {
+		return err
+	}
+	// Validate all the webhooks before creating any of them
+	subscriptions := make([]*webhookSubscription, 0, len(definition.Webhooks))
+	for i := range definition.Webhooks {
+		subscription, err := definition.Webhooks[i].toSubscription()
+		if err != nil {
+			return err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(wcc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	subscriptionsUrl := getSubscriptionsUrl(wcc.serverDetails)
+	existingSubscriptions, err := getSubscriptions(servicesManager, subscriptionsUrl)
+	if err != nil {
+		return err
+	}
+	existingKeys := make(map[string]bool, len(existingSubscriptions))
+	for _, subscription := range existingSubscriptions {
+		existingKeys[subscription.Key] = true
+	}
+	for _, subscription := range subscriptions {
+		exists := existingKeys[subscription.Key]
+		if err = putSubscription(servicesManager, subscriptionsUrl, subscription, exists); err != nil {
+			return err
+		}
+		if exists {
+			log.Info(fmt.Sprintf("The webhook '%s' was updated.", subscription.Key))
+		} else {
+			log.Info(fmt.Sprintf("The webhook '%s' was created.", subscription.Key))
+		}
+	}
+	return nil
+}
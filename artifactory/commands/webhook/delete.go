@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"fmt"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type WebhookDeleteCommand struct {
+	serverDetails *config.ServerDetails
+	key           string
+	quiet         bool
+}
+
+func NewWebhookDeleteCommand() *WebhookDeleteCommand {
+	return &WebhookDeleteCommand{}
+}
+
+func (wdc *WebhookDeleteCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookDeleteCommand {
+	wdc.serverDetails = serverDetails
+	return wdc
+}
+
+func (wdc *WebhookDeleteCommand) SetKey(key string) *WebhookDeleteCommand {
+	wdc.key = key
+	return wdc
+}
+
+func (wdc *WebhookDeleteCommand) SetQuiet(quiet bool) *WebhookDeleteCommand {
+	wdc.quiet = quiet
+	return wdc
+}
+
+func (wdc *WebhookDeleteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wdc.serverDetails, nil
+}
+
+func (wdc *WebhookDeleteCommand) CommandName() string {
+	return "rt_webhook_delete"
+}
+
+func (wdc *WebhookDeleteCommand) Run() error {
+	if !wdc.quiet && !coreutils.AskYesNo("Are you sure you want to permanently delete the webhook "+wdc.key+"?", false) {
+		return nil
+	}
+	servicesManager, err := rtUtils.CreateServiceManager(wdc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	if err = deleteSubscription(servicesManager, getSubscriptionsUrl(wdc.serverDetails), wdc.key); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("The webhook '%s' was deleted.", wdc.key))
+	return nil
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type webhookRow struct {
+	Key     string `col-name:"Key"`
+	Domain  string `col-name:"Domain"`
+	Events  string `col-name:"Events"`
+	Url     string `col-name:"URL"`
+	Enabled bool   `col-name:"Enabled"`
+}
+
+type WebhookListCommand struct {
+	serverDetails *config.ServerDetails
+	format        format.OutputFormat
+}
+
+func NewWebhookListCommand() *WebhookListCommand {
+	return &WebhookListCommand{}
+}
+
+func (wlc *WebhookListCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookListCommand {
+	wlc.serverDetails = serverDetails
+	return wlc
+}
+
+func (wlc *WebhookListCommand) SetFormat(format format.OutputFormat) *WebhookListCommand {
+	wlc.format = format
+	return wlc
+}
+
+func (wlc *WebhookListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wlc.serverDetails, nil
+}
+
+func (wlc *WebhookListCommand) CommandName() string {
+	return "rt_webhook_list"
+}
+
+func (wlc *WebhookListCommand) Run() error {
+	servicesManager, err := rtUtils.CreateServiceManager(wlc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	subscriptions, err := getSubscriptions(servicesManager, getSubscriptionsUrl(wlc.serverDetails))
+	if err != nil {
+		return err
+	}
+	var webhooks []webhookSubscription
+	for _, subscription := range subscriptions {
+		if isWebhook(subscription) {
+			webhooks = append(webhooks, subscription)
+		}
+	}
+	if wlc.format == format.Json {
+		content, err := json.Marshal(webhooks)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	var rows []webhookRow
+	for _, webhook := range webhooks {
+		rows = append(rows, webhookRow{
+			Key:     webhook.Key,
+			Domain:  webhook.EventFilter.Domain,
+			Events:  strings.Join(webhook.EventFilter.EventTypes, ", "),
+			Url:     webhook.Handlers[0].Url,
+			Enabled: webhook.Enabled,
+		})
+	}
+	return coreutils.PrintTable(rows, "Webhooks", "No webhooks were found", false)
+}
+
+// The event service also manages subscriptions of other handler types, such as custom workers.
+func isWebhook(subscription webhookSubscription) bool {
+	return len(subscription.Handlers) > 0 && subscription.Handlers[0].HandlerType == webhookHandlerType
+}
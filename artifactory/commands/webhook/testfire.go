@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The header which holds the secret of the webhook, to allow the receiver to authenticate the event.
+const webhookSecretHeader = "X-JFrog-Event-Auth"
+
+// The sample event data of each domain, which is sent when test-firing a webhook.
+var sampleEventData = map[string]map[string]any{
+	artifactDomain:     {"repo_key": "example-repo-local", "path": "example/file.txt", "name": "file.txt", "size": 1024, "sha256": "0000000000000000000000000000000000000000000000000000000000000000"},
+	buildDomain:        {"build_name": "example-build", "build_number": "1", "build_started": "2024-01-01T00:00:00.000+0000"},
+	distributionDomain: {"release_bundle_name": "example-bundle", "release_bundle_version": "1.0.0", "status": "COMPLETED", "edge_nodes": []string{"example-edge"}},
+}
+
+type webhookTestEvent struct {
+	Domain          string         `json:"domain"`
+	EventType       string         `json:"event_type"`
+	SubscriptionKey string         `json:"subscription_key"`
+	JpdOrigin       string         `json:"jpd_origin"`
+	Source          string         `json:"source"`
+	Data            map[string]any `json:"data"`
+}
+
+// Send a sample event of the webhook to its URL, to verify that the receiver is reachable and accepts the event.
+// The event is sent from the machine running the command, rather than from Artifactory.
+type WebhookTestCommand struct {
+	serverDetails *config.ServerDetails
+	key           string
+}
+
+func NewWebhookTestCommand() *WebhookTestCommand {
+	return &WebhookTestCommand{}
+}
+
+func (wtc *WebhookTestCommand) SetServerDetails(serverDetails *config.ServerDetails) *WebhookTestCommand {
+	wtc.serverDetails = serverDetails
+	return wtc
+}
+
+func (wtc *WebhookTestCommand) SetKey(key string) *WebhookTestCommand {
+	wtc.key = key
+	return wtc
+}
+
+func (wtc *WebhookTestCommand) ServerDetails() (*config.ServerDetails, error) {
+	return wtc.serverDetails, nil
+}
+
+func (wtc *WebhookTestCommand) CommandName() string {
+	return "rt_webhook_test"
+}
+
+func (wtc *WebhookTestCommand) Run() error {
+	servicesManager, err := rtUtils.CreateServiceManager(wtc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	subscription, err := getSubscription(servicesManager, getSubscriptionsUrl(wtc.serverDetails), wtc.key)
+	if err != nil {
+		return err
+	}
+	if !isWebhook(*subscription) {
+		return errorutils.CheckErrorf("the subscription '%s' isn't a webhook", wtc.key)
+	}
+	return fireTestEvent(subscription, wtc.serverDetails.GetUrl())
+}
+
+func fireTestEvent(subscription *webhookSubscription, jpdOrigin string) error {
+	event := webhookTestEvent{
+		Domain:          subscription.EventFilter.Domain,
+		SubscriptionKey: subscription.Key,
+		JpdOrigin:       jpdOrigin,
+		Source:          "jfrog-cli",
+		Data:            sampleEventData[subscription.EventFilter.Domain],
+	}
+	if len(subscription.EventFilter.EventTypes) > 0 {
+		event.EventType = subscription.EventFilter.EventTypes[0]
+	}
+	content, err := json.Marshal(event)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	client, err := httpclient.ClientBuilder().SetOverallRequestTimeout(30 * time.Second).Build()
+	if err != nil {
+		return err
+	}
+	for _, handler := range subscription.Handlers {
+		httpClientDetails := httputils.HttpClientDetails{Headers: map[string]string{"Content-Type": "application/json"}}
+		for _, header := range handler.CustomHttpHeaders {
+			httpClientDetails.Headers[header.Name] = header.Value
+		}
+		if handler.Secret != "" {
+			httpClientDetails.Headers[webhookSecretHeader] = handler.Secret
+		}
+		resp, body, err := client.SendPost(handler.Url, content, httpClientDetails, "")
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return errorutils.CheckErrorf("the webhook '%s' responded to the test event with %s: %s", subscription.Key, resp.Status, string(body))
+		}
+		log.Info(fmt.Sprintf("The test %s event was sent to %s, which responded with %s.", event.EventType, handler.Url, resp.Status))
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	subscriptionsRestApi = "event/api/v1/subscriptions"
+	webhookHandlerType   = "webhook"
+
+	artifactDomain     = "artifact"
+	buildDomain        = "build"
+	distributionDomain = "distribution"
+)
+
+type webhookEvent struct {
+	domain    string
+	eventType string
+}
+
+// The events which can be used in the webhooks definition, mapped to the domain and event type of the event service.
+var webhookEvents = map[string]webhookEvent{
+	"artifact-deployed":                   {artifactDomain, "deployed"},
+	"artifact-deleted":                    {artifactDomain, "deleted"},
+	"artifact-moved":                      {artifactDomain, "moved"},
+	"artifact-copied":                     {artifactDomain, "copied"},
+	"build-uploaded":                      {buildDomain, "uploaded"},
+	"build-promoted":                      {buildDomain, "promoted"},
+	"build-deleted":                       {buildDomain, "deleted"},
+	"release-bundle-distribution-started": {distributionDomain, "distribute_started"},
+	"release-bundle-distributed":          {distributionDomain, "distribute_completed"},
+	"release-bundle-distribution-failed":  {distributionDomain, "distribute_failed"},
+}
+
+// The criteria of each domain, if the webhook definition has no criteria. Matches all the repositories, builds or release bundles.
+var defaultCriteria = map[string]map[string]any{
+	artifactDomain:     {"anyLocal": true, "anyRemote": true, "anyFederated": true, "repoKeys": []string{}, "includePatterns": []string{}, "excludePatterns": []string{}},
+	buildDomain:        {"anyBuild": true, "selectedBuilds": []string{}, "includePatterns": []string{}, "excludePatterns": []string{}},
+	distributionDomain: {"anyReleaseBundle": true, "registeredReleaseBundlesNames": []string{}, "includePatterns": []string{}, "excludePatterns": []string{}},
+}
+
+// A declarative YAML definition of webhooks, for example:
+//
+//	webhooks:
+//	  - key: deployments
+//	    events: [artifact-deployed]
+//	    criteria:
+//	      anyLocal: false
+//	      repoKeys: [${repo}]
+//	    url: https://hooks.example.com/deployments
+//	    secret: ${secret}
+//	    customHeaders:
+//	      X-Team: platform
+type WebhooksDefinition struct {
+	Webhooks []WebhookDefinition `yaml:"webhooks"`
+}
+
+type WebhookDefinition struct {
+	Key         string `yaml:"key"`
+	Description string `yaml:"description"`
+	// Webhooks are enabled by default.
+	Enabled       *bool             `yaml:"enabled"`
+	Events        []string          `yaml:"events"`
+	Criteria      map[string]any    `yaml:"criteria"`
+	Url           string            `yaml:"url"`
+	Secret        string            `yaml:"secret"`
+	Proxy         string            `yaml:"proxy"`
+	CustomHeaders map[string]string `yaml:"customHeaders"`
+}
+
+// A subscription of the Artifactory event service, as accepted and returned by its REST API.
+type webhookSubscription struct {
+	Key         string           `json:"key"`
+	Description string           `json:"description,omitempty"`
+	Enabled     bool             `json:"enabled"`
+	EventFilter eventFilter      `json:"event_filter"`
+	Handlers    []webhookHandler `json:"handlers"`
+}
+
+type eventFilter struct {
+	Domain     string         `json:"domain"`
+	EventTypes []string       `json:"event_types"`
+	Criteria   map[string]any `json:"criteria,omitempty"`
+}
+
+type webhookHandler struct {
+	HandlerType       string       `json:"handler_type"`
+	Url               string       `json:"url"`
+	Secret            string       `json:"secret,omitempty"`
+	Proxy             string       `json:"proxy,omitempty"`
+	CustomHttpHeaders []httpHeader `json:"custom_http_headers,omitempty"`
+}
+
+type httpHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Read the webhooks definition file and replace its vars.
+func readWebhooksDefinition(definitionPath, vars string) (*WebhooksDefinition, error) {
+	content, err := fileutils.ReadFile(definitionPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(vars) > 0 {
+		content = coreutils.ReplaceVars(content, coreutils.SpecVarsStringToMap(vars))
+	}
+	definition := new(WebhooksDefinition)
+	if err = yaml.Unmarshal(content, definition); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the webhooks definition file '%s': %s", definitionPath, err.Error())
+	}
+	if len(definition.Webhooks) == 0 {
+		return nil, errorutils.CheckErrorf("the webhooks definition file '%s' doesn't include any webhooks", definitionPath)
+	}
+	return definition, nil
+}
+
+func (wd *WebhookDefinition) toSubscription() (*webhookSubscription, error) {
+	if wd.Key == "" || wd.Url == "" {
+		return nil, errorutils.CheckErrorf("both 'key' and 'url' are required in each webhook definition")
+	}
+	if len(wd.Events) == 0 {
+		return nil, errorutils.CheckErrorf("the webhook '%s' must include at least one event", wd.Key)
+	}
+	subscription := &webhookSubscription{Key: wd.Key, Description: wd.Description, Enabled: wd.Enabled == nil || *wd.Enabled}
+	for _, eventName := range wd.Events {
+		event, ok := webhookEvents[eventName]
+		if !ok {
+			return nil, errorutils.CheckErrorf("the webhook '%s' includes the unsupported event '%s'. The supported events are: %s", wd.Key, eventName, strings.Join(getSupportedEvents(), ", "))
+		}
+		if subscription.EventFilter.Domain != "" && subscription.EventFilter.Domain != event.domain {
+			return nil, errorutils.CheckErrorf("all the events of the webhook '%s' must be of the same domain, but it includes both %s and %s events", wd.Key, subscription.EventFilter.Domain, event.domain)
+		}
+		subscription.EventFilter.Domain = event.domain
+		subscription.EventFilter.EventTypes = append(subscription.EventFilter.EventTypes, event.eventType)
+	}
+	subscription.EventFilter.Criteria = wd.Criteria
+	if len(subscription.EventFilter.Criteria) == 0 {
+		subscription.EventFilter.Criteria = defaultCriteria[subscription.EventFilter.Domain]
+	}
+	handler := webhookHandler{HandlerType: webhookHandlerType, Url: wd.Url, Secret: wd.Secret, Proxy: wd.Proxy}
+	for name, value := range wd.CustomHeaders {
+		handler.CustomHttpHeaders = append(handler.CustomHttpHeaders, httpHeader{Name: name, Value: value})
+	}
+	sort.Slice(handler.CustomHttpHeaders, func(i, j int) bool {
+		return handler.CustomHttpHeaders[i].Name < handler.CustomHttpHeaders[j].Name
+	})
+	subscription.Handlers = []webhookHandler{handler}
+	return subscription, nil
+}
+
+func getSupportedEvents() []string {
+	var events []string
+	for eventName := range webhookEvents {
+		events = append(events, eventName)
+	}
+	sort.Strings(events)
+	return events
+}
+
+// The event service is served by the JFrog Platform URL, rather than by the Artifactory URL.
+func getSubscriptionsUrl(serverDetails *config.ServerDetails) string {
+	platformUrl := serverDetails.GetUrl()
+	if platformUrl == "" {
+		platformUrl = strings.TrimSuffix(clientutils.AddTrailingSlashIfNeeded(serverDetails.GetArtifactoryUrl()), "artifactory/")
+	}
+	return clientutils.AddTrailingSlashIfNeeded(platformUrl) + subscriptionsRestApi
+}
+
+func getSubscriptions(serviceManager artifactory.ArtifactoryServicesManager, subscriptionsUrl string) ([]webhookSubscription, error) {
+	httpClientDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := serviceManager.Client().SendGet(subscriptionsUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var subscriptions []webhookSubscription
+	if err = json.Unmarshal(body, &subscriptions); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return subscriptions, nil
+}
+
+func getSubscription(serviceManager artifactory.ArtifactoryServicesManager, subscriptionsUrl, key string) (*webhookSubscription, error) {
+	httpClientDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, _, err := serviceManager.Client().SendGet(subscriptionsUrl+"/"+url.PathEscape(key), true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errorutils.CheckErrorf("the webhook '%s' was not found", key)
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	subscription := new(webhookSubscription)
+	if err = json.Unmarshal(body, subscription); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return subscription, nil
+}
+
+// Create the subscription, or replace it if a subscription with the same key already exists.
+func putSubscription(serviceManager artifactory.ArtifactoryServicesManager, subscriptionsUrl string, subscription *webhookSubscription, exists bool) error {
+	content, err := json.Marshal(subscription)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpClientDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpClientDetails.SetContentTypeApplicationJson()
+	var resp *http.Response
+	var body []byte
+	if exists {
+		resp, body, err = serviceManager.Client().SendPut(subscriptionsUrl+"/"+url.PathEscape(subscription.Key), content, &httpClientDetails)
+	} else {
+		resp, body, err = serviceManager.Client().SendPost(subscriptionsUrl, content, &httpClientDetails)
+	}
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+}
+
+func deleteSubscription(serviceManager artifactory.ArtifactoryServicesManager, subscriptionsUrl, key string) error {
+	httpClientDetails := serviceManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := serviceManager.Client().SendDelete(subscriptionsUrl+"/"+url.PathEscape(key), nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errorutils.CheckErrorf("the webhook '%s' was not found", key)
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhooksDefinition = `webhooks:
+  - key: deployments
+    description: Deployments to ${repo}
+    events: [artifact-deployed, artifact-deleted]
+    criteria:
+      anyLocal: false
+      repoKeys: [${repo}]
+    url: https://hooks.example.com/deployments
+    secret: ${secret}
+    customHeaders:
+      X-Team: platform
+  - key: promotions
+    enabled: false
+    events: [build-promoted]
+    url: https://hooks.example.com/promotions
+`
+
+func TestToSubscription(t *testing.T) {
+	testCases := []struct {
+		name          string
+		definition    WebhookDefinition
+		expectedError string
+	}{
+		{name: "Missing url", definition: WebhookDefinition{Key: "hook", Events: []string{"artifact-deployed"}}, expectedError: "both 'key' and 'url' are required"},
+		{name: "No events", definition: WebhookDefinition{Key: "hook", Url: "https://hooks.example.com"}, expectedError: "must include at least one event"},
+		{name: "Unsupported event", definition: WebhookDefinition{Key: "hook", Url: "https://hooks.example.com", Events: []string{"artifact-exploded"}}, expectedError: "unsupported event 'artifact-exploded'"},
+		{name: "Mixed domains", definition: WebhookDefinition{Key: "hook", Url: "https://hooks.example.com", Events: []string{"artifact-deployed", "release-bundle-distributed"}},
+			expectedError: "must be of the same domain"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.definition.toSubscription()
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestWebhookCreate(t *testing.T) {
+	definitionPath := filepath.Join(t.TempDir(), "webhooks.yaml")
+	require.NoError(t, os.WriteFile(definitionPath, []byte(testWebhooksDefinition), 0600))
+
+	saved := map[string]webhookSubscription{}
+	var methods []string
+	testServer, serverDetails, _ := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/"+subscriptionsRestApi:
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`[{"key":"promotions","enabled":true,"event_filter":{"domain":"build","event_types":["promoted"]},"handlers":[{"handler_type":"webhook","url":"https://old.example.com"}]}]`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.URL.Path == "/"+subscriptionsRestApi,
+			r.Method == http.MethodPut && r.URL.Path == "/"+subscriptionsRestApi+"/promotions":
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var subscription webhookSubscription
+			assert.NoError(t, json.Unmarshal(content, &subscription))
+			saved[subscription.Key] = subscription
+			methods = append(methods, r.Method+" "+subscription.Key)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer testServer.Close()
+
+	err := NewWebhookCreateCommand().SetServerDetails(serverDetails).SetDefinitionPath(definitionPath).SetVars("repo=generic-local;secret=s3cr3t").Run()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST deployments", "PUT promotions"}, methods)
+
+	deployments := saved["deployments"]
+	assert.True(t, deployments.Enabled)
+	assert.Equal(t, "Deployments to generic-local", deployments.Description)
+	assert.Equal(t, eventFilter{Domain: artifactDomain, EventTypes: []string{"deployed", "deleted"},
+		Criteria: map[string]any{"anyLocal": false, "repoKeys": []any{"generic-local"}}}, deployments.EventFilter)
+	assert.Equal(t, []webhookHandler{{HandlerType: webhookHandlerType, Url: "https://hooks.example.com/deployments", Secret: "s3cr3t",
+		CustomHttpHeaders: []httpHeader{{Name: "X-Team", Value: "platform"}}}}, deployments.Handlers)
+
+	promotions := saved["promotions"]
+	assert.False(t, promotions.Enabled)
+	assert.Equal(t, buildDomain, promotions.EventFilter.Domain)
+	assert.Equal(t, true, promotions.EventFilter.Criteria["anyBuild"])
+}
+
+func TestFireTestEvent(t *testing.T) {
+	var received webhookTestEvent
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(webhookSecretHeader) != "s3cr3t" || r.Header.Get("X-Team") != "platform" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(content, &received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	subscription := &webhookSubscription{
+		Key:         "deployments",
+		EventFilter: eventFilter{Domain: artifactDomain, EventTypes: []string{"deployed"}},
+		Handlers: []webhookHandler{{HandlerType: webhookHandlerType, Url: receiver.URL, Secret: "s3cr3t",
+			CustomHttpHeaders: []httpHeader{{Name: "X-Team", Value: "platform"}}}},
+	}
+	require.NoError(t, fireTestEvent(subscription, "https://acme.jfrog.io/"))
+	assert.Equal(t, artifactDomain, received.Domain)
+	assert.Equal(t, "deployed", received.EventType)
+	assert.Equal(t, "deployments", received.SubscriptionKey)
+	assert.Equal(t, "example-repo-local", received.Data["repo_key"])
+
+	subscription.Handlers[0].Secret = "wrong"
+	assert.ErrorContains(t, fireTestEvent(subscription, "https://acme.jfrog.io/"), "401 Unauthorized")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -18,6 +19,8 @@ import (
 	"strings"
 
 	"github.com/jfrog/build-info-go/build"
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/gofrog/version"
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 
 	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
@@ -33,16 +36,20 @@ import (
 )
 
 const (
-	YarnrcFileName       = ".yarnrc.yml"
-	YarnrcBackupFileName = "jfrog.yarnrc.backup"
-	NpmScopesConfigName  = "npmScopes"
-	YarnLockFileName     = "yarn.lock"
+	YarnrcFileName          = ".yarnrc.yml"
+	YarnrcBackupFileName    = "jfrog.yarnrc.backup"
+	NpmScopesConfigName     = "npmScopes"
+	NpmRegistriesConfigName = "npmRegistries"
+	YarnLockFileName        = "yarn.lock"
+
+	minSupportedYarnVersion = "2.4.0"
 	//#nosec G101
 	yarnNpmRegistryServerEnv = "YARN_NPM_REGISTRY_SERVER"
 	yarnNpmAuthIndent        = "YARN_NPM_AUTH_IDENT"
 	// #nosec G101
-	yarnNpmAuthToken  = "YARN_NPM_AUTH_TOKEN"
-	yarnNpmAlwaysAuth = "YARN_NPM_ALWAYS_AUTH"
+	yarnNpmAuthToken        = "YARN_NPM_AUTH_TOKEN"
+	yarnNpmAlwaysAuth       = "YARN_NPM_ALWAYS_AUTH"
+	yarnUnsafeHttpWhitelist = "YARN_UNSAFE_HTTP_WHITELIST"
 )
 
 type YarnCommand struct {
@@ -58,7 +65,9 @@ type YarnCommand struct {
 	threads            int
 	serverDetails      *config.ServerDetails
 	buildConfiguration *buildUtils.BuildConfiguration
-	buildInfoModule    *build.YarnModule
+	moduleId           string
+	// Collects the checksums of the dependencies, and returns false for dependencies which can't be found in Artifactory.
+	collectChecksumsFunc func(dependency *entities.Dependency) (bool, error)
 }
 
 func NewYarnCommand() *YarnCommand {
@@ -118,13 +127,16 @@ func (yc *YarnCommand) Run() (err error) {
 		return errors.Join(err, restoreYarnrcFunc())
 	}
 
-	yc.buildInfoModule.SetArgs(filteredYarnArgs)
-	if err = yc.buildInfoModule.Build(); err != nil {
-		return errors.Join(err, restoreYarnrcFunc())
+	if err = build.RunYarnCommand(yc.executablePath, yc.workingDirectory, filteredYarnArgs...); err != nil {
+		return errors.Join(errorutils.CheckError(err), RestoreConfigurationsFromBackup(backupEnvMap, restoreYarnrcFunc))
 	}
 
 	if yc.collectBuildInfo {
+		err = yc.collectDependencies()
 		close(missingDepsChan)
+		if err != nil {
+			return errors.Join(err, RestoreConfigurationsFromBackup(backupEnvMap, restoreYarnrcFunc))
+		}
 		printMissingDependencies(missingDependencies)
 	}
 
@@ -191,31 +203,22 @@ func (yc *YarnCommand) preparePrerequisites() error {
 	}
 	log.Debug("Working directory set to:", yc.workingDirectory)
 
-	yc.collectBuildInfo, err = yc.buildConfiguration.IsCollectBuildInfo()
-	if err != nil {
+	if err = yc.validateYarnVersion(); err != nil {
 		return err
 	}
 
-	buildName, err := yc.buildConfiguration.GetBuildName()
-	if err != nil {
-		return err
-	}
-	buildNumber, err := yc.buildConfiguration.GetBuildNumber()
+	yc.collectBuildInfo, err = yc.buildConfiguration.IsCollectBuildInfo()
 	if err != nil {
 		return err
 	}
 
-	buildInfoService := buildUtils.CreateBuildInfoService()
-	npmBuild, err := buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, yc.buildConfiguration.GetProject())
-	if err != nil {
-		return errorutils.CheckError(err)
-	}
-	yc.buildInfoModule, err = npmBuild.AddYarnModule(yc.workingDirectory)
-	if err != nil {
-		return errorutils.CheckError(err)
-	}
-	if yc.buildConfiguration.GetModule() != "" {
-		yc.buildInfoModule.SetName(yc.buildConfiguration.GetModule())
+	yc.moduleId = yc.buildConfiguration.GetModule()
+	if yc.moduleId == "" {
+		packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(yc.workingDirectory, nil)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		yc.moduleId = packageInfo.BuildInfoModuleId()
 	}
 
 	yc.registry, yc.npmAuthIdent, yc.npmAuthToken, err = GetYarnAuthDetails(yc.serverDetails, yc.repo)
@@ -234,17 +237,55 @@ func (yc *YarnCommand) prepareBuildInfo() (missingDepsChan chan string, err erro
 	if err != nil {
 		return
 	}
-	previousBuildDependencies, err := getDependenciesFromLatestBuild(servicesManager, buildName)
+	previousBuildDependencies, err := GetDependenciesFromLatestBuild(servicesManager, buildName)
 	if err != nil {
 		return
 	}
 	missingDepsChan = make(chan string)
-	collectChecksumsFunc := createCollectChecksumsFunc(previousBuildDependencies, servicesManager, missingDepsChan)
-	yc.buildInfoModule.SetTraverseDependenciesFunc(collectChecksumsFunc)
-	yc.buildInfoModule.SetThreads(yc.threads)
+	yc.collectChecksumsFunc = createCollectChecksumsFunc(previousBuildDependencies, servicesManager, missingDepsChan)
 	return
 }
 
+// Yarn 1 isn't supported, since its configuration and its lockfile differ from those of Yarn Berry (Yarn 2 and above).
+func (yc *YarnCommand) validateYarnVersion() error {
+	yarnVersion, err := biutils.GetVersion(yc.executablePath, yc.workingDirectory)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if version.NewVersion(yarnVersion).Compare(minSupportedYarnVersion) > 0 {
+		return errorutils.CheckErrorf("Yarn must have version %s or higher. The current version is: %s", minSupportedYarnVersion, yarnVersion)
+	}
+	log.Debug("Yarn version:", yarnVersion)
+	return nil
+}
+
+// Collect the dependencies from yarn.lock, rather than from the node_modules directory or from 'yarn info',
+// so that they're also collected in Plug'n'Play installs, in which there's no node_modules directory.
+func (yc *YarnCommand) collectDependencies() error {
+	buildName, err := yc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := yc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := yc.buildConfiguration.GetProject()
+	if err = buildUtils.SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	lockfile, err := yarn.ReadBerryLockfile(yc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	dependencies, err := biutils.TraverseDependencies(lockfile.GetDependencies(yc.moduleId), yc.collectChecksumsFunc, yc.threads)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	buildInfoModule := entities.Module{Id: yc.moduleId, Type: entities.Npm, Dependencies: dependencies}
+	return buildUtils.SaveBuildInfo(buildName, buildNumber, projectKey, &entities.BuildInfo{Modules: []entities.Module{buildInfoModule}})
+}
+
 func (yc *YarnCommand) setYarnExecutable() error {
 	yarnExecPath, err := exec.LookPath("yarn")
 	if err != nil {
@@ -311,6 +352,10 @@ func ModifyYarnConfigurations(execPath, registry, npmAuthIdent, npmAuthToken str
 		yarnNpmAuthToken:         npmAuthToken,
 		yarnNpmAlwaysAuth:        "true",
 	}
+	// Yarn Berry refuses to use HTTP registries, unless their hosts are whitelisted.
+	if registryUrl, err := url.Parse(registry); err == nil && registryUrl.Scheme == "http" {
+		envVarsUpdated[yarnUnsafeHttpWhitelist] = registryUrl.Hostname()
+	}
 	envVarsBackup := make(map[string]*string)
 	for key, value := range envVarsUpdated {
 		oldVal, err := backupAndSetEnvironmentVariable(key, value)
@@ -319,8 +364,11 @@ func ModifyYarnConfigurations(execPath, registry, npmAuthIdent, npmAuthToken str
 		}
 		envVarsBackup[key] = &oldVal
 	}
-	// Update scoped registries (these cannot be set in environment variables)
-	return envVarsBackup, errorutils.CheckError(updateScopeRegistries(execPath, registry, npmAuthIdent, npmAuthToken))
+	// Update scoped registries and the registries credentials (these cannot be set in environment variables)
+	if err := updateScopeRegistries(execPath, registry, npmAuthIdent, npmAuthToken); err != nil {
+		return envVarsBackup, errorutils.CheckError(err)
+	}
+	return envVarsBackup, errorutils.CheckError(updateNpmRegistries(execPath, registry, npmAuthIdent, npmAuthToken))
 }
 
 func updateScopeRegistries(execPath, registry, npmAuthIdent, npmAuthToken string) error {
@@ -344,6 +392,42 @@ func updateScopeRegistries(execPath, registry, npmAuthIdent, npmAuthToken string
 	return yarn.ConfigSet(NpmScopesConfigName, string(updatedNpmScopesStr), execPath, true)
 }
 
+// The credentials of a registry in the npmRegistries of .yarnrc.yml take precedence over the credentials set in the environment variables.
+// Therefore, the credentials of the Artifactory registry are set in npmRegistries as well, replacing existing credentials of the registry.
+func updateNpmRegistries(execPath, registry, npmAuthIdent, npmAuthToken string) error {
+	npmRegistriesStr, err := yarn.ConfigGet(NpmRegistriesConfigName, execPath, true)
+	if err != nil {
+		return err
+	}
+	npmRegistriesMap := make(map[string]yarnNpmScope)
+	if err = json.Unmarshal([]byte(npmRegistriesStr), &npmRegistriesMap); err != nil {
+		return errorutils.CheckError(err)
+	}
+	updatedNpmRegistriesStr, err := json.Marshal(setNpmRegistryCredentials(npmRegistriesMap, registry, npmAuthIdent, npmAuthToken))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return yarn.ConfigSet(NpmRegistriesConfigName, string(updatedNpmRegistriesStr), execPath, true)
+}
+
+// The keys of npmRegistries may be written with or without the protocol of the registry, and with or without a trailing slash.
+func setNpmRegistryCredentials(npmRegistriesMap map[string]yarnNpmScope, registry, npmAuthIdent, npmAuthToken string) map[string]yarnNpmScope {
+	normalizeKey := func(key string) string {
+		if i := strings.Index(key, "//"); i >= 0 {
+			key = key[i:]
+		}
+		return strings.TrimSuffix(key, "/")
+	}
+	registryKey := normalizeKey(registry)
+	for key := range npmRegistriesMap {
+		if normalizeKey(key) == registryKey {
+			delete(npmRegistriesMap, key)
+		}
+	}
+	npmRegistriesMap[registryKey] = yarnNpmScope{NpmAlwaysAuth: true, NpmAuthIdent: npmAuthIdent, NpmAuthToken: npmAuthToken}
+	return npmRegistriesMap
+}
+
 type yarnNpmScope struct {
 	NpmAlwaysAuth     bool   `json:"npmAlwaysAuth,omitempty"`
 	NpmAuthIdent      string `json:"npmAuthIdent,omitempty"`
@@ -389,7 +473,7 @@ type aqlResult struct {
 	Results []*servicesUtils.ResultItem `json:"results,omitempty"`
 }
 
-func getDependenciesFromLatestBuild(servicesManager artifactory.ArtifactoryServicesManager, buildName string) (map[string]*entities.Dependency, error) {
+func GetDependenciesFromLatestBuild(servicesManager artifactory.ArtifactoryServicesManager, buildName string) (map[string]*entities.Dependency, error) {
 	buildDependencies := make(map[string]*entities.Dependency)
 	previousBuild, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: servicesUtils.LatestBuildNumberKey})
 	if err != nil || !found {
@@ -404,8 +488,8 @@ func getDependenciesFromLatestBuild(servicesManager artifactory.ArtifactoryServi
 	return buildDependencies, nil
 }
 
-// Get dependency's checksum and type.
-func getDependencyInfo(name, ver string, previousBuildDependencies map[string]*entities.Dependency,
+// Get the checksum and the type of an npm package, from the dependencies of the previous build or from Artifactory.
+func GetDependencyInfo(name, ver string, previousBuildDependencies map[string]*entities.Dependency,
 	servicesManager artifactory.ArtifactoryServicesManager) (checksum entities.Checksum, fileType string, err error) {
 	id := name + ":" + ver
 	if dep, ok := previousBuildDependencies[id]; ok {
@@ -482,7 +566,7 @@ func createCollectChecksumsFunc(previousBuildDependencies map[string]*entities.D
 		ver := splitDepId[1]
 
 		// Get dependency info.
-		checksum, fileType, err := getDependencyInfo(name, ver, previousBuildDependencies, servicesManager)
+		checksum, fileType, err := GetDependencyInfo(name, ver, previousBuildDependencies, servicesManager)
 		if err != nil || checksum.IsEmpty() {
 			missingDepsChan <- dependency.Id
 			return false, err
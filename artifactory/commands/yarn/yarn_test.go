@@ -73,3 +73,15 @@ func TestExtractAuthValuesFromNpmAuth(t *testing.T) {
 		assert.Equal(t, testCase.expectedExtractedAuthToken, actualExtractedAuthToken)
 	}
 }
+
+func TestSetNpmRegistryCredentials(t *testing.T) {
+	npmRegistries := map[string]yarnNpmScope{
+		"https://acme.jfrog.io/artifactory/api/npm/npm-virtual/": {NpmAuthToken: "old-token"},
+		"//npm.pkg.github.com": {NpmAuthToken: "github-token"},
+	}
+	updated := setNpmRegistryCredentials(npmRegistries, "https://acme.jfrog.io/artifactory/api/npm/npm-virtual", "", "new-token")
+	assert.Equal(t, map[string]yarnNpmScope{
+		"//acme.jfrog.io/artifactory/api/npm/npm-virtual": {NpmAlwaysAuth: true, NpmAuthToken: "new-token"},
+		"//npm.pkg.github.com":                            {NpmAuthToken: "github-token"},
+	}, updated)
+}
@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An AQL comparison operator.
+type AqlOperator string
+
+const (
+	AqlEq       AqlOperator = "$eq"
+	AqlNe       AqlOperator = "$ne"
+	AqlGt       AqlOperator = "$gt"
+	AqlGte      AqlOperator = "$gte"
+	AqlLt       AqlOperator = "$lt"
+	AqlLte      AqlOperator = "$lte"
+	AqlMatch    AqlOperator = "$match"
+	AqlNotMatch AqlOperator = "$nmatch"
+)
+
+// The sort order of an AQL query.
+type AqlSortOrder string
+
+const (
+	AqlAsc  AqlSortOrder = "$asc"
+	AqlDesc AqlSortOrder = "$desc"
+)
+
+// The date format expected by AQL.
+const aqlTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// A single criterion of an AQL query, or a group of criteria joined by '$and' or '$or'.
+type AqlCriterion struct {
+	field    string
+	operator AqlOperator
+	value    any
+	// The operator joining the nested criteria of a group, '$and' or '$or'.
+	group  string
+	nested []AqlCriterion
+}
+
+// Create a criterion comparing a field with a string value.
+// Properties are referenced as '@key'.
+func AqlField(field string, operator AqlOperator, value string) AqlCriterion {
+	return AqlCriterion{field: field, operator: operator, value: value}
+}
+
+// Create a criterion comparing a field with a numeric value, such as the 'size' or 'depth' fields.
+func AqlNumericField(field string, operator AqlOperator, value int64) AqlCriterion {
+	return AqlCriterion{field: field, operator: operator, value: value}
+}
+
+// Create a criterion comparing a date field, such as 'modified' or 'created', with a point in time.
+func AqlTimeField(field string, operator AqlOperator, value time.Time) AqlCriterion {
+	return AqlCriterion{field: field, operator: operator, value: value.UTC().Format(aqlTimeFormat)}
+}
+
+// Create a criterion matched if all the given criteria are matched.
+func AqlAllOf(criteria ...AqlCriterion) AqlCriterion {
+	return AqlCriterion{group: "$and", nested: criteria}
+}
+
+// Create a criterion matched if any of the given criteria is matched.
+func AqlAnyOf(criteria ...AqlCriterion) AqlCriterion {
+	return AqlCriterion{group: "$or", nested: criteria}
+}
+
+func (ac AqlCriterion) writeTo(buf *bytes.Buffer) {
+	buf.WriteByte('{')
+	if ac.group != "" {
+		buf.WriteString(strconv.Quote(ac.group))
+		buf.WriteString(":[")
+		for i, criterion := range ac.nested {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			criterion.writeTo(buf)
+		}
+		buf.WriteByte(']')
+	} else {
+		writeAqlJsonValue(buf, ac.field)
+		buf.WriteString(":{")
+		writeAqlJsonValue(buf, string(ac.operator))
+		buf.WriteByte(':')
+		writeAqlJsonValue(buf, ac.value)
+		buf.WriteByte('}')
+	}
+	buf.WriteByte('}')
+}
+
+// A fluent builder of AQL queries.
+// All the field names and values are written as escaped JSON strings, so that user input can't break the query syntax.
+//
+// Example:
+//
+//	query := Items().Repo("libs-release").PathMatches("org/*").PropEq("build.name", name).Include("repo", "path", "name").SortBy(AqlAsc, "name").Limit(100).Build()
+type AqlQueryBuilder struct {
+	domain     string
+	criteria   []AqlCriterion
+	include    []string
+	sortOrder  AqlSortOrder
+	sortFields []string
+	offset     int
+	limit      int
+	distinct   *bool
+}
+
+// Start a query of the 'items' domain.
+func Items() *AqlQueryBuilder {
+	return &AqlQueryBuilder{domain: "items", offset: -1, limit: -1}
+}
+
+// Start a query of the 'builds' domain.
+func Builds() *AqlQueryBuilder {
+	return &AqlQueryBuilder{domain: "builds", offset: -1, limit: -1}
+}
+
+// Add criteria to the query. All the criteria of the query must be matched.
+func (aqb *AqlQueryBuilder) Where(criteria ...AqlCriterion) *AqlQueryBuilder {
+	aqb.criteria = append(aqb.criteria, criteria...)
+	return aqb
+}
+
+func (aqb *AqlQueryBuilder) Repo(repo string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("repo", AqlEq, repo))
+}
+
+func (aqb *AqlQueryBuilder) RepoMatches(pattern string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("repo", AqlMatch, pattern))
+}
+
+func (aqb *AqlQueryBuilder) Path(path string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("path", AqlEq, path))
+}
+
+// Match the path with a wildcard pattern, where '*' matches any sequence of characters and '?' matches a single character.
+func (aqb *AqlQueryBuilder) PathMatches(pattern string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("path", AqlMatch, pattern))
+}
+
+func (aqb *AqlQueryBuilder) Name(name string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("name", AqlEq, name))
+}
+
+// Match the name with a wildcard pattern, where '*' matches any sequence of characters and '?' matches a single character.
+func (aqb *AqlQueryBuilder) NameMatches(pattern string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("name", AqlMatch, pattern))
+}
+
+// Match the item type - 'file', 'folder' or 'any'.
+func (aqb *AqlQueryBuilder) Type(itemType string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("type", AqlEq, itemType))
+}
+
+func (aqb *AqlQueryBuilder) PropEq(key, value string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("@"+key, AqlEq, value))
+}
+
+func (aqb *AqlQueryBuilder) PropMatches(key, pattern string) *AqlQueryBuilder {
+	return aqb.Where(AqlField("@"+key, AqlMatch, pattern))
+}
+
+// Match items modified at or after the given time.
+func (aqb *AqlQueryBuilder) ModifiedSince(since time.Time) *AqlQueryBuilder {
+	return aqb.Where(AqlTimeField("modified", AqlGte, since))
+}
+
+// Match items modified before the given time.
+func (aqb *AqlQueryBuilder) ModifiedBefore(before time.Time) *AqlQueryBuilder {
+	return aqb.Where(AqlTimeField("modified", AqlLt, before))
+}
+
+// Set the fields returned for each result.
+func (aqb *AqlQueryBuilder) Include(fields ...string) *AqlQueryBuilder {
+	aqb.include = append(aqb.include, fields...)
+	return aqb
+}
+
+func (aqb *AqlQueryBuilder) SortBy(order AqlSortOrder, fields ...string) *AqlQueryBuilder {
+	aqb.sortOrder = order
+	aqb.sortFields = fields
+	return aqb
+}
+
+// Skip the given number of results. Negative values are ignored.
+func (aqb *AqlQueryBuilder) Offset(offset int) *AqlQueryBuilder {
+	aqb.offset = offset
+	return aqb
+}
+
+// Limit the number of results. Negative values are ignored.
+func (aqb *AqlQueryBuilder) Limit(limit int) *AqlQueryBuilder {
+	aqb.limit = limit
+	return aqb
+}
+
+// Set whether duplicate results are removed. Disabling it improves the performance of large queries.
+func (aqb *AqlQueryBuilder) Distinct(distinct bool) *AqlQueryBuilder {
+	aqb.distinct = &distinct
+	return aqb
+}
+
+// Serialize the query.
+// Multiple criteria are joined with '$and', keeping the order in which they were added.
+func (aqb *AqlQueryBuilder) Build() string {
+	var buf bytes.Buffer
+	buf.WriteString(aqb.domain)
+	buf.WriteString(".find(")
+	switch len(aqb.criteria) {
+	case 0:
+		buf.WriteString("{}")
+	case 1:
+		aqb.criteria[0].writeTo(&buf)
+	default:
+		AqlAllOf(aqb.criteria...).writeTo(&buf)
+	}
+	buf.WriteByte(')')
+	if len(aqb.include) > 0 {
+		buf.WriteString(".include(")
+		writeAqlFieldsList(&buf, aqb.include)
+		buf.WriteByte(')')
+	}
+	if len(aqb.sortFields) > 0 {
+		buf.WriteString(".sort({")
+		writeAqlJsonValue(&buf, string(aqb.sortOrder))
+		buf.WriteString(":[")
+		writeAqlFieldsList(&buf, aqb.sortFields)
+		buf.WriteString("]})")
+	}
+	if aqb.offset >= 0 {
+		buf.WriteString(".offset(" + strconv.Itoa(aqb.offset) + ")")
+	}
+	if aqb.limit >= 0 {
+		buf.WriteString(".limit(" + strconv.Itoa(aqb.limit) + ")")
+	}
+	if aqb.distinct != nil {
+		buf.WriteString(".distinct(" + strconv.FormatBool(*aqb.distinct) + ")")
+	}
+	return buf.String()
+}
+
+func (aqb *AqlQueryBuilder) String() string {
+	return aqb.Build()
+}
+
+func writeAqlFieldsList(buf *bytes.Buffer, fields []string) {
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeAqlJsonValue(buf, field)
+	}
+}
+
+// Write the value as JSON, escaping quotes, backslashes and control characters.
+// HTML characters are kept as is, since they have no special meaning in AQL.
+func writeAqlJsonValue(buf *bytes.Buffer, value any) {
+	var valueBuf bytes.Buffer
+	encoder := json.NewEncoder(&valueBuf)
+	encoder.SetEscapeHTML(false)
+	// Only strings and integers are encoded, which can't fail.
+	_ = encoder.Encode(value)
+	buf.WriteString(strings.TrimSuffix(valueBuf.String(), "\n"))
+}
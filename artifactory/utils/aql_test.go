@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAqlQueryBuilder(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		query    *AqlQueryBuilder
+		expected string
+	}{
+		{"empty", Items(), `items.find({})`},
+		{"single criterion", Items().Repo("repo1"), `items.find({"repo":{"$eq":"repo1"}})`},
+		{"multiple criteria", Items().Repo("repo1").PathMatches("a/*").PropEq("k", "v"),
+			`items.find({"$and":[{"repo":{"$eq":"repo1"}},{"path":{"$match":"a/*"}},{"@k":{"$eq":"v"}}]})`},
+		{"any of", Items().Repo("repo1").Where(AqlAnyOf(AqlField("name", AqlEq, "a.json"), AqlField("name", AqlEq, "b.json"))),
+			`items.find({"$and":[{"repo":{"$eq":"repo1"}},{"$or":[{"name":{"$eq":"a.json"}},{"name":{"$eq":"b.json"}}]}]})`},
+		{"numeric and time", Items().Where(AqlNumericField("size", AqlGt, 1024)).ModifiedSince(modified),
+			`items.find({"$and":[{"size":{"$gt":1024}},{"modified":{"$gte":"2024-03-01T10:30:00.000Z"}}]})`},
+		{"modifiers", Items().Type("file").Include("repo", "path", "name").SortBy(AqlDesc, "name", "path").Offset(20).Limit(10).Distinct(false),
+			`items.find({"type":{"$eq":"file"}}).include("repo","path","name").sort({"$desc":["name","path"]}).offset(20).limit(10).distinct(false)`},
+		{"builds", Builds().Where(AqlField("name", AqlEq, "my-build")).Limit(1), `builds.find({"name":{"$eq":"my-build"}}).limit(1)`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.query.Build())
+		})
+	}
+}
+
+func TestAqlQueryBuilderEscaping(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{"quotes", "k", `v"}).include("*`},
+		{"backslash", "k", `a\"b`},
+		{"control characters", "k", "line1\nline2\t"},
+		{"key injection", `k":{"$ne":"x"}},{"@a`, "v"},
+		{"html characters", "k", "<a&b>"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query := Items().PropEq(test.key, test.value).Build()
+			// The criteria must remain a single valid JSON object, holding the original key and value.
+			criteria := strings.TrimSuffix(strings.TrimPrefix(query, "items.find("), ")")
+			var parsed map[string]map[string]string
+			assert.NoError(t, json.Unmarshal([]byte(criteria), &parsed))
+			assert.Len(t, parsed, 1)
+			assert.Equal(t, test.value, parsed["@"+test.key]["$eq"])
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The format of an archive created on the fly while uploading a directory.
+type ArchiveStreamFormat string
+
+const (
+	ArchiveStreamTar   ArchiveStreamFormat = "tar"
+	ArchiveStreamTarGz ArchiveStreamFormat = "tar.gz"
+	ArchiveStreamZip   ArchiveStreamFormat = "zip"
+)
+
+func GetArchiveStreamFormats() []string {
+	return []string{string(ArchiveStreamTar), string(ArchiveStreamTarGz), string(ArchiveStreamZip)}
+}
+
+// Returns the file name extension of the archive format, including the leading dot.
+func (asf ArchiveStreamFormat) Extension() string {
+	return "." + string(asf)
+}
+
+// Create a reader of an archive of the directory tree, which is written while it's being read,
+// so that the archive is never written to the disk.
+// The paths in the archive are relative to rootDir. Symlinks are archived as symlinks in tar archives, and skipped in zip archives.
+func NewArchiveStream(rootDir string, format ArchiveStreamFormat) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(WriteArchive(writer, rootDir, format))
+	}()
+	return reader
+}
+
+// Write an archive of the directory tree to the writer.
+func WriteArchive(writer io.Writer, rootDir string, format ArchiveStreamFormat) error {
+	switch format {
+	case ArchiveStreamTar:
+		return writeTarArchive(writer, rootDir)
+	case ArchiveStreamTarGz:
+		gzipWriter := gzip.NewWriter(writer)
+		err := writeTarArchive(gzipWriter, rootDir)
+		return errors.Join(err, errorutils.CheckError(gzipWriter.Close()))
+	case ArchiveStreamZip:
+		return writeZipArchive(writer, rootDir)
+	}
+	return errorutils.CheckErrorf("unsupported archive format '%s'. Supported formats: %s", format, strings.Join(GetArchiveStreamFormats(), ", "))
+}
+
+func writeTarArchive(writer io.Writer, rootDir string) (err error) {
+	tarWriter := tar.NewWriter(writer)
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(tarWriter.Close()))
+	}()
+	return walkArchiveEntries(rootDir, func(path, name string, info fs.FileInfo) error {
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			var e error
+			if link, e = os.Readlink(path); e != nil {
+				return e
+			}
+		}
+		header, e := tar.FileInfoHeader(info, link)
+		if e != nil {
+			return e
+		}
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if e = tarWriter.WriteHeader(header); e != nil {
+			return e
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFileContent(tarWriter, path)
+	})
+}
+
+func writeZipArchive(writer io.Writer, rootDir string) (err error) {
+	zipWriter := zip.NewWriter(writer)
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(zipWriter.Close()))
+	}()
+	return walkArchiveEntries(rootDir, func(path, name string, info fs.FileInfo) error {
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+		header, e := zip.FileInfoHeader(info)
+		if e != nil {
+			return e
+		}
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		} else {
+			header.Method = zip.Deflate
+		}
+		entryWriter, e := zipWriter.CreateHeader(header)
+		if e != nil || info.IsDir() {
+			return e
+		}
+		return copyFileContent(entryWriter, path)
+	})
+}
+
+// Walk the directory tree, calling addEntry with the slash separated path of each entry, relative to rootDir.
+func walkArchiveEntries(rootDir string, addEntry func(path, name string, info fs.FileInfo) error) error {
+	return errorutils.CheckError(filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == rootDir {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(rootDir, path)
+		if err != nil {
+			return err
+		}
+		return addEntry(path, filepath.ToSlash(name), info)
+	}))
+}
+
+func copyFileContent(writer io.Writer, path string) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+	_, err = io.Copy(writer, file)
+	return
+}
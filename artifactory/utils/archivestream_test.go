@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createArchiveStreamTestDir(t *testing.T) string {
+	rootDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(rootDir, "a", "b"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.txt"), []byte("root"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(rootDir, "a", "b", "nested.txt"), []byte("nested"), 0644))
+	return rootDir
+}
+
+func TestArchiveStreamTar(t *testing.T) {
+	for _, format := range []ArchiveStreamFormat{ArchiveStreamTar, ArchiveStreamTarGz} {
+		t.Run(string(format), func(t *testing.T) {
+			stream := NewArchiveStream(createArchiveStreamTestDir(t), format)
+			defer func() {
+				assert.NoError(t, stream.Close())
+			}()
+			var reader io.Reader = stream
+			if format == ArchiveStreamTarGz {
+				gzipReader, err := gzip.NewReader(stream)
+				assert.NoError(t, err)
+				reader = gzipReader
+			}
+			entries := make(map[string]string)
+			tarReader := tar.NewReader(reader)
+			for {
+				header, err := tarReader.Next()
+				if err == io.EOF {
+					break
+				}
+				assert.NoError(t, err)
+				content, err := io.ReadAll(tarReader)
+				assert.NoError(t, err)
+				entries[header.Name] = string(content)
+			}
+			assert.Equal(t, map[string]string{"a/": "", "a/b/": "", "a/b/nested.txt": "nested", "root.txt": "root"}, entries)
+		})
+	}
+}
+
+func TestArchiveStreamZip(t *testing.T) {
+	stream := NewArchiveStream(createArchiveStreamTestDir(t), ArchiveStreamZip)
+	content, err := io.ReadAll(stream)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	assert.NoError(t, err)
+	entries := make(map[string]string)
+	for _, file := range zipReader.File {
+		fileReader, err := file.Open()
+		assert.NoError(t, err)
+		fileContent, err := io.ReadAll(fileReader)
+		assert.NoError(t, err)
+		assert.NoError(t, fileReader.Close())
+		entries[file.Name] = string(fileContent)
+	}
+	assert.Equal(t, map[string]string{"a/": "", "a/b/": "", "a/b/nested.txt": "nested", "root.txt": "root"}, entries)
+}
+
+func TestArchiveStreamErrors(t *testing.T) {
+	_, err := io.ReadAll(NewArchiveStream(t.TempDir(), "rar"))
+	assert.ErrorContains(t, err, "unsupported archive format 'rar'")
+
+	_, err = io.ReadAll(NewArchiveStream(filepath.Join(t.TempDir(), "missing"), ArchiveStreamTar))
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package cargo
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetExecutablePath() (string, error) {
+	executablePath, err := exec.LookPath("cargo")
+	if err != nil {
+		return "", errorutils.CheckErrorf("couldn't find the cargo executable in the PATH: %s", err.Error())
+	}
+	log.Debug("Found cargo executable at:", executablePath)
+	return executablePath, nil
+}
+
+// The output of 'cargo metadata --no-deps'.
+type Metadata struct {
+	Packages         []MetadataPackage `json:"packages"`
+	WorkspaceMembers []string          `json:"workspace_members"`
+	WorkspaceRoot    string            `json:"workspace_root"`
+	TargetDirectory  string            `json:"target_directory"`
+}
+
+type MetadataPackage struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	ManifestPath string `json:"manifest_path"`
+}
+
+// Returns the metadata of the workspace the directory belongs to, without its dependencies.
+func GetMetadata(executablePath, dir string) (*Metadata, error) {
+	command := exec.Command(executablePath, "metadata", "--no-deps", "--format-version", "1")
+	command.Dir = dir
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	output, err := command.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return nil, errorutils.CheckErrorf("'cargo metadata' failed: %s", strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseMetadata(output)
+}
+
+func ParseMetadata(output []byte) (*Metadata, error) {
+	metadata := new(Metadata)
+	if err := json.Unmarshal(output, metadata); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the output of 'cargo metadata': %s", err.Error())
+	}
+	return metadata, nil
+}
+
+// Returns the members of the workspace, in the order 'cargo metadata' lists them.
+func (metadata *Metadata) GetWorkspaceMembers() []MetadataPackage {
+	var members []MetadataPackage
+	for _, metadataPackage := range metadata.Packages {
+		for _, memberId := range metadata.WorkspaceMembers {
+			if metadataPackage.Id == memberId {
+				members = append(members, metadataPackage)
+				break
+			}
+		}
+	}
+	return members
+}
+
+// Returns the member of the workspace by its name, or the member whose manifest is in the directory if the name is empty.
+func (metadata *Metadata) GetWorkspaceMember(name, dir string) (*MetadataPackage, error) {
+	for _, member := range metadata.GetWorkspaceMembers() {
+		if name != "" && member.Name == name || name == "" && filepath.Dir(member.ManifestPath) == filepath.Clean(dir) {
+			return &member, nil
+		}
+	}
+	if name != "" {
+		return nil, errorutils.CheckErrorf("couldn't find the package '%s' in the workspace", name)
+	}
+	return nil, errorutils.CheckErrorf("couldn't find a package in '%s'. Run the command in the directory of the package, or set the package with the --package flag", dir)
+}
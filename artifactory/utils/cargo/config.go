@@ -0,0 +1,86 @@
+package cargo
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The name of the registry of the Artifactory repository in the Cargo configuration.
+	RegistryName = "artifactory"
+	// The environment variable holding the token of the registry, kept out of the command line of cargo.
+	RegistryTokenEnv = "CARGO_REGISTRIES_ARTIFACTORY_TOKEN"
+
+	cargoHomeEnv = "CARGO_HOME"
+)
+
+// Returns the URL of the sparse index of an Artifactory Cargo repository.
+func GetRegistryIndexUrl(artifactoryUrl, repo string) string {
+	return fmt.Sprintf("sparse+%sapi/cargo/%s/index/", clientutils.AddTrailingSlashIfNeeded(artifactoryUrl), repo)
+}
+
+// Returns the '--config' arguments of cargo, which add the Artifactory repository as a registry and replace crates.io with it.
+// The token of the registry is read from the environment by the 'cargo:token' credential provider.
+func GetRegistryConfigArgs(indexUrl string) []string {
+	return []string{
+		"--config", fmt.Sprintf("registries.%s.index=%q", RegistryName, indexUrl),
+		"--config", fmt.Sprintf("source.crates-io.replace-with=%q", RegistryName),
+		"--config", `registry.global-credential-providers=["cargo:token"]`,
+	}
+}
+
+// Returns the value of the Authorization header cargo sends to the registry.
+// Artifactory accepts either an access token or the basic credentials of the user.
+func GetRegistryToken(serverDetails *config.ServerDetails) string {
+	if serverDetails.AccessToken != "" {
+		return "Bearer " + serverDetails.AccessToken
+	}
+	if serverDetails.User != "" && serverDetails.Password != "" {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(serverDetails.User+":"+serverDetails.Password))
+	}
+	return ""
+}
+
+// Cargo.lock holds only the SHA-256 of the crates. The SHA-1 and MD5 checksums are calculated from the crates cached by cargo,
+// in $CARGO_HOME/registry/cache/<registry>/<name>-<version>.crate. Returns nil if the crate isn't cached.
+func GetCachedCrateDetails(name, version, sha256 string) (*fileutils.FileDetails, error) {
+	cargoHome, err := getCargoHome()
+	if err != nil {
+		return nil, err
+	}
+	cachedCrates, err := filepath.Glob(filepath.Join(cargoHome, "registry", "cache", "*", name+"-"+version+".crate"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, cachedCrate := range cachedCrates {
+		details, err := fileutils.GetFileDetails(cachedCrate, true)
+		if err != nil {
+			return nil, err
+		}
+		// Crates of the same name and version may be cached from different registries.
+		if sha256 == "" || details.Checksum.Sha256 == sha256 {
+			return details, nil
+		}
+	}
+	log.Debug("Couldn't find the crate", name, version, "in the cache of cargo.")
+	return nil, nil
+}
+
+func getCargoHome() (string, error) {
+	if cargoHome := os.Getenv(cargoHomeEnv); cargoHome != "" {
+		return cargoHome, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".cargo"), nil
+}
@@ -0,0 +1,69 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRegistryIndexUrl(t *testing.T) {
+	expected := "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-virtual/index/"
+	assert.Equal(t, expected, GetRegistryIndexUrl("https://acme.jfrog.io/artifactory", "cargo-virtual"))
+	assert.Equal(t, expected, GetRegistryIndexUrl("https://acme.jfrog.io/artifactory/", "cargo-virtual"))
+}
+
+func TestGetRegistryConfigArgs(t *testing.T) {
+	assert.Equal(t, []string{
+		"--config", `registries.artifactory.index="sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-virtual/index/"`,
+		"--config", `source.crates-io.replace-with="artifactory"`,
+		"--config", `registry.global-credential-providers=["cargo:token"]`,
+	}, GetRegistryConfigArgs("sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-virtual/index/"))
+}
+
+func TestGetRegistryToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverDetails *config.ServerDetails
+		expected      string
+	}{
+		{"access token", &config.ServerDetails{AccessToken: "token", User: "user"}, "Bearer token"},
+		{"basic", &config.ServerDetails{User: "user", Password: "password"}, "Basic dXNlcjpwYXNzd29yZA=="},
+		{"anonymous", &config.ServerDetails{}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GetRegistryToken(test.serverDetails))
+		})
+	}
+}
+
+func TestGetCachedCrateDetails(t *testing.T) {
+	cargoHome := t.TempDir()
+	t.Setenv(cargoHomeEnv, cargoHome)
+	cacheDir := filepath.Join(cargoHome, "registry", "cache", "index.crates.io-6f17d22bba15001f")
+	require.NoError(t, os.MkdirAll(cacheDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "serde-1.0.210.crate"), []byte("crate"), 0644))
+
+	details, err := GetCachedCrateDetails("serde", "1.0.210", "")
+	require.NoError(t, err)
+	require.NotNil(t, details)
+	assert.NotEmpty(t, details.Checksum.Sha1)
+	assert.NotEmpty(t, details.Checksum.Md5)
+
+	details, err = GetCachedCrateDetails("serde", "1.0.210", details.Checksum.Sha256)
+	require.NoError(t, err)
+	assert.NotNil(t, details)
+
+	// A crate of the same name and version from another registry.
+	details, err = GetCachedCrateDetails("serde", "1.0.210", "other")
+	require.NoError(t, err)
+	assert.Nil(t, details)
+
+	details, err = GetCachedCrateDetails("rand", "0.8.5", "")
+	require.NoError(t, err)
+	assert.Nil(t, details)
+}
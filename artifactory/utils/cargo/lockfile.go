@@ -0,0 +1,147 @@
+package cargo
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	LockfileName = "Cargo.lock"
+	// The type of the crates in the build-info.
+	CrateType = "crate"
+)
+
+// The content of Cargo.lock, in its versions 3 and 4.
+type Lockfile struct {
+	Version  int       `toml:"version"`
+	Packages []Package `toml:"package"`
+	// The packages by their names.
+	packagesByName map[string][]*Package
+}
+
+type Package struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	// The source of the package, e.g. 'registry+https://github.com/rust-lang/crates.io-index'. Empty for the packages of the workspace and path dependencies.
+	Source string `toml:"source"`
+	// The SHA-256 of the .crate file of the package.
+	Checksum string `toml:"checksum"`
+	// The dependencies of the package, written as 'name', 'name version' or 'name version (source)'.
+	// The version and the source are written only when they're needed to tell apart packages of the same name.
+	Dependencies []string `toml:"dependencies"`
+}
+
+func (p *Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+// Only crates which were resolved from a registry are recorded in the build-info.
+// Packages of the workspace, path dependencies and git dependencies can't be found in Artifactory.
+func (p *Package) isFromRegistry() bool {
+	return strings.HasPrefix(p.Source, "registry+") || strings.HasPrefix(p.Source, "sparse+")
+}
+
+// Read the Cargo.lock file of the workspace in the directory.
+func ReadLockfile(workspaceDir string) (*Lockfile, error) {
+	content, err := os.ReadFile(filepath.Join(workspaceDir, LockfileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("couldn't find %s in '%s'. Run 'cargo generate-lockfile' to create it", LockfileName, workspaceDir)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(content)
+}
+
+func ParseLockfile(content []byte) (*Lockfile, error) {
+	lockfile := new(Lockfile)
+	if err := toml.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	lockfile.packagesByName = map[string][]*Package{}
+	for i := range lockfile.Packages {
+		cargoPackage := &lockfile.Packages[i]
+		lockfile.packagesByName[cargoPackage.Name] = append(lockfile.packagesByName[cargoPackage.Name], cargoPackage)
+	}
+	return lockfile, nil
+}
+
+// Returns the package a dependency of the lockfile refers to.
+func (lockfile *Lockfile) getPackage(dependency string) (*Package, bool) {
+	name, rest, _ := strings.Cut(dependency, " ")
+	version, source, _ := strings.Cut(rest, " ")
+	source = strings.TrimSuffix(strings.TrimPrefix(source, "("), ")")
+	for _, cargoPackage := range lockfile.packagesByName[name] {
+		if (version == "" || cargoPackage.Version == version) && (source == "" || cargoPackage.Source == source) {
+			return cargoPackage, true
+		}
+	}
+	return nil, false
+}
+
+// Returns the dependencies of a package of the workspace, with their SHA-256 checksums and the dependency paths they were requested by.
+// The dependencies of the path dependencies of the package are included as well, as if the package requested them.
+func (lockfile *Lockfile) GetDependencies(name, version, moduleId string) ([]buildinfo.Dependency, error) {
+	root, found := lockfile.getPackage(name + " " + version)
+	if !found {
+		return nil, errorutils.CheckErrorf("couldn't find the package %s %s in %s", name, version, LockfileName)
+	}
+	collector := &dependenciesCollector{lockfile: lockfile, dependencies: map[string]*buildinfo.Dependency{}, visited: map[*Package]bool{root: true}}
+	collector.collectChildren(root, []string{moduleId})
+	dependencies := make([]buildinfo.Dependency, 0, len(collector.dependencies))
+	for _, id := range slices.Sorted(maps.Keys(collector.dependencies)) {
+		dependencies = append(dependencies, *collector.dependencies[id])
+	}
+	return dependencies, nil
+}
+
+type dependenciesCollector struct {
+	lockfile     *Lockfile
+	dependencies map[string]*buildinfo.Dependency
+	// The packages whose dependencies were already collected.
+	visited map[*Package]bool
+}
+
+// The entries of the 'dependencies' arrays, such as 'serde 1.0.190', are sorted as Cargo writes them, in case the lock file was edited by hand.
+// The dependencies of each package are traversed once, so every dependency is recorded with one path for each of its direct parents.
+func (dc *dependenciesCollector) collectChildren(parent *Package, requestedBy []string) {
+	for _, dependency := range slices.Sorted(slices.Values(parent.Dependencies)) {
+		cargoPackage, found := dc.lockfile.getPackage(dependency)
+		if !found {
+			log.Debug("Couldn't find the package of", dependency, "in", LockfileName)
+			continue
+		}
+		childRequestedBy := requestedBy
+		if cargoPackage.isFromRegistry() {
+			dc.addDependency(cargoPackage, requestedBy)
+			childRequestedBy = append([]string{cargoPackage.Id()}, requestedBy...)
+		}
+		if dc.visited[cargoPackage] {
+			continue
+		}
+		dc.visited[cargoPackage] = true
+		dc.collectChildren(cargoPackage, childRequestedBy)
+	}
+}
+
+func (dc *dependenciesCollector) addDependency(cargoPackage *Package, requestedBy []string) {
+	dependency, exists := dc.dependencies[cargoPackage.Id()]
+	if !exists {
+		dependency = &buildinfo.Dependency{Id: cargoPackage.Id(), Type: CrateType, Checksum: buildinfo.Checksum{Sha256: cargoPackage.Checksum}}
+		dc.dependencies[cargoPackage.Id()] = dependency
+	}
+	for _, path := range dependency.RequestedBy {
+		if path[0] == requestedBy[0] {
+			return
+		}
+	}
+	dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+}
@@ -0,0 +1,130 @@
+package cargo
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockfile = `# This file is automatically @generated by Cargo.
+# It is not intended for manual editing.
+version = 4
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "rand 0.8.5",
+ "serde",
+ "utils",
+]
+
+[[package]]
+name = "utils"
+version = "0.1.0"
+dependencies = [
+ "rand 0.7.3",
+ "serde",
+]
+
+[[package]]
+name = "cli"
+version = "0.2.0"
+dependencies = [
+ "fork",
+]
+
+[[package]]
+name = "fork"
+version = "1.0.0"
+source = "git+https://github.com/example/fork#0123456789abcdef"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "rand"
+version = "0.7.3"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "rand073"
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "rand085"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.210"
+source = "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-remote/index/"
+checksum = "serde"
+`
+
+func TestGetDependencies(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	assert.Equal(t, 4, lockfile.Version)
+
+	tests := []struct {
+		name     string
+		version  string
+		expected []buildinfo.Dependency
+	}{
+		{"app", "0.1.0", []buildinfo.Dependency{
+			{Id: "rand:0.7.3", Type: CrateType, Checksum: buildinfo.Checksum{Sha256: "rand073"}, RequestedBy: [][]string{{"app"}}},
+			{Id: "rand:0.8.5", Type: CrateType, Checksum: buildinfo.Checksum{Sha256: "rand085"}, RequestedBy: [][]string{{"app"}}},
+			{Id: "serde:1.0.210", Type: CrateType, Checksum: buildinfo.Checksum{Sha256: "serde"}, RequestedBy: [][]string{{"rand:0.8.5", "app"}, {"app"}}},
+		}},
+		// Git dependencies aren't recorded, but their dependencies are.
+		{"cli", "0.2.0", []buildinfo.Dependency{
+			{Id: "serde:1.0.210", Type: CrateType, Checksum: buildinfo.Checksum{Sha256: "serde"}, RequestedBy: [][]string{{"cli"}}},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dependencies, err := lockfile.GetDependencies(test.name, test.version, test.name)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, dependencies)
+		})
+	}
+
+	_, err = lockfile.GetDependencies("app", "1.0.0", "app")
+	assert.ErrorContains(t, err, "couldn't find the package app 1.0.0")
+}
+
+func TestGetPackage(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+
+	tests := []struct {
+		dependency      string
+		expectedFound   bool
+		expectedVersion string
+	}{
+		{"serde", true, "1.0.210"},
+		{"rand 0.7.3", true, "0.7.3"},
+		{"rand 0.8.5 (registry+https://github.com/rust-lang/crates.io-index)", true, "0.8.5"},
+		{"rand 0.8.5 (registry+https://example.com/index)", false, ""},
+		{"missing", false, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.dependency, func(t *testing.T) {
+			cargoPackage, found := lockfile.getPackage(test.dependency)
+			assert.Equal(t, test.expectedFound, found)
+			if found {
+				assert.Equal(t, test.expectedVersion, cargoPackage.Version)
+			}
+		})
+	}
+}
+
+func TestParseLockfileInvalid(t *testing.T) {
+	_, err := ParseLockfile([]byte("[[package]\nname = "))
+	assert.ErrorContains(t, err, "failed to parse Cargo.lock")
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/crypto"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The checksum algorithm used to verify downloaded files.
+type ChecksumAlgorithm string
+
+const (
+	ChecksumAlgorithmSha256 ChecksumAlgorithm = "sha256"
+	ChecksumAlgorithmSha1   ChecksumAlgorithm = "sha1"
+	ChecksumAlgorithmMd5    ChecksumAlgorithm = "md5"
+	// Verify the files using all the checksums, as done by the download service.
+	ChecksumAlgorithmAll ChecksumAlgorithm = "all"
+)
+
+func GetChecksumAlgorithms() []string {
+	return []string{string(ChecksumAlgorithmSha256), string(ChecksumAlgorithmSha1), string(ChecksumAlgorithmMd5), string(ChecksumAlgorithmAll)}
+}
+
+// Returns true if only a single checksum should be verified.
+// In that case the download service's own verification is skipped, and the files are verified by VerifyLocalFileChecksum,
+// which computes only the selected checksum. This allows FIPS environments to avoid computing MD5 and SHA-1 checksums.
+func (ca ChecksumAlgorithm) IsSelective() bool {
+	return ca == ChecksumAlgorithmSha256 || ca == ChecksumAlgorithmSha1 || ca == ChecksumAlgorithmMd5
+}
+
+// Returns the expected checksum of the algorithm, out of the artifact's checksums.
+func (ca ChecksumAlgorithm) GetExpectedChecksum(checksums entities.Checksum) string {
+	switch ca {
+	case ChecksumAlgorithmSha256:
+		return checksums.Sha256
+	case ChecksumAlgorithmSha1:
+		return checksums.Sha1
+	case ChecksumAlgorithmMd5:
+		return checksums.Md5
+	}
+	return ""
+}
+
+func (ca ChecksumAlgorithm) toCryptoAlgorithm() crypto.Algorithm {
+	switch ca {
+	case ChecksumAlgorithmSha1:
+		return crypto.SHA1
+	case ChecksumAlgorithmMd5:
+		return crypto.MD5
+	default:
+		return crypto.SHA256
+	}
+}
+
+// Verify the checksum of a local file, computing only the checksum of the given algorithm.
+func VerifyLocalFileChecksum(localPath string, algorithm ChecksumAlgorithm, expectedChecksum string) (err error) {
+	if !algorithm.IsSelective() {
+		return errorutils.CheckErrorf("a single checksum algorithm is expected, but got '%s'", algorithm)
+	}
+	if expectedChecksum == "" {
+		return errorutils.CheckErrorf("the %s checksum of '%s' is unknown, and can't be verified", algorithm, localPath)
+	}
+	file, err := os.Open(localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	checksums, err := crypto.CalcChecksums(file, algorithm.toCryptoAlgorithm())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	actualChecksum := checksums[algorithm.toCryptoAlgorithm()]
+	if !strings.EqualFold(actualChecksum, expectedChecksum) {
+		return errorutils.CheckErrorf("%s checksum mismatch for '%s'. Expected: %s, actual: %s", algorithm, localPath, expectedChecksum, actualChecksum)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyLocalFileChecksum(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "file.txt")
+	assert.NoError(t, os.WriteFile(localPath, []byte("content"), 0600))
+	checksums := entities.Checksum{
+		Sha256: "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
+		Sha1:   "040f06fd774092478d450774f5ba30c5da78acc8",
+		Md5:    "9a0364b9e99bb480dd25e1f0284c8555",
+	}
+	tests := []struct {
+		name          string
+		algorithm     ChecksumAlgorithm
+		checksums     entities.Checksum
+		expectedError string
+	}{
+		{"sha256", ChecksumAlgorithmSha256, checksums, ""},
+		{"sha1", ChecksumAlgorithmSha1, checksums, ""},
+		{"md5", ChecksumAlgorithmMd5, checksums, ""},
+		{"upper case", ChecksumAlgorithmSha1, entities.Checksum{Sha1: "040F06FD774092478D450774F5BA30C5DA78ACC8"}, ""},
+		{"mismatch", ChecksumAlgorithmSha256, entities.Checksum{Sha256: "1234"}, "sha256 checksum mismatch"},
+		{"unknown checksum", ChecksumAlgorithmMd5, entities.Checksum{Sha1: checksums.Sha1}, "the md5 checksum of"},
+		{"all", ChecksumAlgorithmAll, checksums, "a single checksum algorithm is expected"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyLocalFileChecksum(localPath, test.algorithm, test.algorithm.GetExpectedChecksum(test.checksums))
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
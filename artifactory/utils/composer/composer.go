@@ -0,0 +1,106 @@
+package composer
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The environment variable holding the credentials of Composer, in the format of auth.json.
+	// Credentials passed by it aren't stored in the auth.json files of the user.
+	AuthEnv = "COMPOSER_AUTH"
+	// The global configuration file, in the home directory of Composer.
+	GlobalConfigFileName = "config.json"
+)
+
+func GetExecutablePath() (string, error) {
+	executablePath, err := exec.LookPath("composer")
+	if err != nil {
+		return "", errorutils.CheckErrorf("couldn't find the composer executable in the PATH: %s", err.Error())
+	}
+	log.Debug("Found composer executable at:", executablePath)
+	return executablePath, nil
+}
+
+// Returns the URL of an Artifactory Composer repository.
+func GetRepositoryUrl(artifactoryUrl, repo string) string {
+	return clientutils.AddTrailingSlashIfNeeded(artifactoryUrl) + "api/composer/" + repo
+}
+
+// Returns the host and the credentials Composer authenticates to the repository with.
+func GetRepositoryCredentials(serverDetails *config.ServerDetails, repositoryUrl string) (host, username, password string, err error) {
+	parsedUrl, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return "", "", "", errorutils.CheckError(err)
+	}
+	username, password = serverDetails.GetUser(), serverDetails.GetPassword()
+	if serverDetails.GetAccessToken() != "" {
+		if username == "" {
+			username = auth.ExtractUsernameFromAccessToken(serverDetails.GetAccessToken())
+		}
+		password = serverDetails.GetAccessToken()
+	}
+	return parsedUrl.Host, username, password, nil
+}
+
+// Returns the value of the COMPOSER_AUTH environment variable, which holds the credentials of the repository.
+// Returns an empty value for anonymous access.
+func GetAuthEnvValue(serverDetails *config.ServerDetails, repositoryUrl string) (string, error) {
+	host, username, password, err := GetRepositoryCredentials(serverDetails, repositoryUrl)
+	if err != nil || password == "" {
+		return "", err
+	}
+	content, err := json.Marshal(map[string]any{"http-basic": map[string]any{host: map[string]string{"username": username, "password": password}}})
+	return string(content), errorutils.CheckError(err)
+}
+
+// Run 'composer config' with the arguments, and return its output.
+func RunConfig(executablePath string, args ...string) (string, error) {
+	command := exec.Command(executablePath, append([]string{"config", "--no-interaction"}, args...)...)
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	output, err := command.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return "", errorutils.CheckErrorf("'composer config' failed: %s", strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return "", errorutils.CheckError(err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Set the repository as the replacement of packagist.org in the global configuration of Composer.
+func SetPackagistRepository(executablePath, repositoryUrl string) error {
+	_, err := RunConfig(executablePath, "--global", "repos.packagist", "composer", repositoryUrl)
+	return err
+}
+
+// Composer caches the archives of the packages in '<cache-files-dir>/<name>/<sha1 of the dist URL>.<dist type>'.
+// Returns the checksums of the cached archive of the package, or nil if it isn't cached.
+func GetCachedDistDetails(cacheFilesDir string, composerPackage *Package) (*fileutils.FileDetails, error) {
+	if composerPackage.Dist.Url == "" {
+		return nil, nil
+	}
+	urlHash := sha1.Sum([]byte(composerPackage.Dist.Url))
+	cachedDist := filepath.Join(cacheFilesDir, filepath.FromSlash(strings.ToLower(composerPackage.Name)), hex.EncodeToString(urlHash[:])+"."+composerPackage.Dist.Type)
+	if _, err := os.Stat(cachedDist); err != nil {
+		if os.IsNotExist(err) {
+			log.Debug("Couldn't find the archive of", composerPackage.Id(), "in the cache of composer.")
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return fileutils.GetFileDetails(cachedDist, true)
+}
@@ -0,0 +1,31 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAuthEnvValue(t *testing.T) {
+	repositoryUrl := GetRepositoryUrl("https://acme.jfrog.io/artifactory/", "php-virtual")
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/api/composer/php-virtual", repositoryUrl)
+
+	tests := []struct {
+		name          string
+		serverDetails *config.ServerDetails
+		expected      string
+	}{
+		{"basic", &config.ServerDetails{User: "user", Password: "password"}, `{"http-basic":{"acme.jfrog.io":{"password":"password","username":"user"}}}`},
+		{"access token", &config.ServerDetails{User: "user", AccessToken: "token"}, `{"http-basic":{"acme.jfrog.io":{"password":"token","username":"user"}}}`},
+		{"anonymous", &config.ServerDetails{}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authEnvValue, err := GetAuthEnvValue(test.serverDetails, repositoryUrl)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, authEnvValue)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package composer
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+const (
+	LockfileName     = "composer.lock"
+	ManifestFileName = "composer.json"
+	// The prefix of the IDs of Composer components in Xray.
+	ComposerPackageTypeIdentifier = "composer://"
+)
+
+// The content of composer.json, which is needed to collect the dependencies of the project.
+type Manifest struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// The content of composer.lock.
+type Lockfile struct {
+	Packages    []Package `json:"packages"`
+	PackagesDev []Package `json:"packages-dev"`
+	// The locked packages by their lowercase names, which is how Composer compares package names.
+	packagesByName map[string]*Package
+}
+
+type Package struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Dist    Dist              `json:"dist"`
+	Require map[string]string `json:"require"`
+}
+
+// The archive a package is installed from.
+type Dist struct {
+	Type   string `json:"type"`
+	Url    string `json:"url"`
+	Shasum string `json:"shasum"`
+}
+
+func (p *Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+// Returns the ID of the build-info module of the project, by the name and the version in composer.json, or by the project directory if it has no name.
+func (manifest *Manifest) GetModuleId(projectDir string) string {
+	switch {
+	case manifest.Name == "":
+		return filepath.Base(projectDir)
+	case manifest.Version == "":
+		return manifest.Name
+	default:
+		return manifest.Name + ":" + manifest.Version
+	}
+}
+
+func ReadManifest(projectDir string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, ManifestFileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	manifest := new(Manifest)
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", ManifestFileName, err.Error())
+	}
+	return manifest, nil
+}
+
+func ReadLockfile(projectDir string) (*Lockfile, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, LockfileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("couldn't find %s in '%s'. Run 'composer update' to create it", LockfileName, projectDir)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(content)
+}
+
+func ParseLockfile(content []byte) (*Lockfile, error) {
+	lockfile := new(Lockfile)
+	if err := json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	lockfile.packagesByName = map[string]*Package{}
+	for _, packages := range [][]Package{lockfile.Packages, lockfile.PackagesDev} {
+		for i := range packages {
+			lockfile.packagesByName[strings.ToLower(packages[i].Name)] = &packages[i]
+		}
+	}
+	return lockfile, nil
+}
+
+// Returns the locked package of a requirement. Platform requirements, such as 'php' and 'ext-json', aren't locked packages.
+func (lockfile *Lockfile) GetPackage(name string) (*Package, bool) {
+	composerPackage, found := lockfile.packagesByName[strings.ToLower(name)]
+	return composerPackage, found
+}
+
+// Returns the checksums of the archives of the locked packages, by the IDs of the packages in the dependency tree.
+// Packages installed from source control have no archive checksum, and aren't returned.
+func (lockfile *Lockfile) GetChecksums() map[string]string {
+	checksums := map[string]string{}
+	for _, composerPackage := range lockfile.packagesByName {
+		if composerPackage.Dist.Shasum != "" {
+			checksums[ComposerPackageTypeIdentifier+composerPackage.Id()] = composerPackage.Dist.Shasum
+		}
+	}
+	return checksums
+}
+
+// Returns the dependencies of the project, with their scopes and the dependency paths they were requested by.
+// The packages required by 'require-dev' of the project, and their own requirements, have the 'dev' scope.
+func (lockfile *Lockfile) GetDependencies(manifest *Manifest, moduleId string) []buildinfo.Dependency {
+	collector := &dependenciesCollector{lockfile: lockfile, dependencies: map[string]*buildinfo.Dependency{}, visited: map[string]bool{}}
+	collector.collect(manifest.Require, "prod", []string{moduleId})
+	collector.collect(manifest.RequireDev, "dev", []string{moduleId})
+	dependencies := make([]buildinfo.Dependency, 0, len(collector.dependencies))
+	for _, id := range slices.Sorted(maps.Keys(collector.dependencies)) {
+		dependencies = append(dependencies, *collector.dependencies[id])
+	}
+	return dependencies
+}
+
+type dependenciesCollector struct {
+	lockfile     *Lockfile
+	dependencies map[string]*buildinfo.Dependency
+	// The packages whose requirements were already collected, by their scope.
+	visited map[string]bool
+}
+
+// The requirements are traversed in a stable order, so that the recorded dependency paths don't change between runs.
+// The requirements of each package are traversed once, so every dependency is recorded with one path for each of its direct parents.
+func (dc *dependenciesCollector) collect(require map[string]string, scope string, requestedBy []string) {
+	for _, name := range slices.Sorted(maps.Keys(require)) {
+		composerPackage, found := dc.lockfile.GetPackage(name)
+		if !found {
+			continue
+		}
+		id := composerPackage.Id()
+		dependency, exists := dc.dependencies[id]
+		if !exists {
+			dependency = &buildinfo.Dependency{Id: id, Type: composerPackage.Dist.Type}
+			dc.dependencies[id] = dependency
+		}
+		if !slices.Contains(dependency.Scopes, scope) {
+			dependency.Scopes = append(dependency.Scopes, scope)
+		}
+		if !slices.ContainsFunc(dependency.RequestedBy, func(path []string) bool { return path[0] == requestedBy[0] }) {
+			dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+		}
+		visitedKey := scope + "|" + id
+		if dc.visited[visitedKey] {
+			continue
+		}
+		dc.visited[visitedKey] = true
+		dc.collect(composerPackage.Require, scope, append([]string{id}, requestedBy...))
+	}
+}
+
+// Returns the dependency tree of the project for auditing. Packages required by several parents appear under each of them,
+// and the requirements of each package are expanded once per branch, so cyclic requirements don't expand endlessly.
+func (lockfile *Lockfile) GetDependencyTree(manifest *Manifest, moduleId string) *xrayUtils.GraphNode {
+	root := &xrayUtils.GraphNode{Id: ComposerPackageTypeIdentifier + moduleId, Nodes: []*xrayUtils.GraphNode{}}
+	require := maps.Clone(manifest.Require)
+	if require == nil {
+		require = map[string]string{}
+	}
+	maps.Copy(require, manifest.RequireDev)
+	lockfile.addTreeNodes(root, require)
+	return root
+}
+
+func (lockfile *Lockfile) addTreeNodes(parent *xrayUtils.GraphNode, require map[string]string) {
+	for _, name := range slices.Sorted(maps.Keys(require)) {
+		composerPackage, found := lockfile.GetPackage(name)
+		if !found {
+			continue
+		}
+		node := &xrayUtils.GraphNode{Id: ComposerPackageTypeIdentifier + composerPackage.Id(), Parent: parent, Nodes: []*xrayUtils.GraphNode{}}
+		parent.Nodes = append(parent.Nodes, node)
+		if node.NodeHasLoop() {
+			continue
+		}
+		lockfile.addTreeNodes(node, composerPackage.Require)
+	}
+}
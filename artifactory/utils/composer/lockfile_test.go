@@ -0,0 +1,90 @@
+package composer
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockfile = `{
+    "content-hash": "abc",
+    "packages": [
+        {
+            "name": "monolog/monolog",
+            "version": "3.5.0",
+            "dist": {"type": "zip", "url": "https://acme.jfrog.io/artifactory/api/composer/php-virtual/direct-dists/monolog/monolog/monolog-3.5.0.zip", "shasum": ""},
+            "require": {"php": ">=8.1", "psr/log": "^2.0 || ^3.0"}
+        },
+        {
+            "name": "psr/log",
+            "version": "3.0.0",
+            "dist": {"type": "zip", "url": "https://acme.jfrog.io/artifactory/api/composer/php-virtual/direct-dists/psr/log/log-3.0.0.zip", "shasum": ""},
+            "require": {"php": ">=8.0.0"}
+        }
+    ],
+    "packages-dev": [
+        {
+            "name": "phpunit/phpunit",
+            "version": "10.5.10",
+            "dist": {"type": "zip", "url": "https://acme.jfrog.io/artifactory/api/composer/php-virtual/direct-dists/phpunit/phpunit/phpunit-10.5.10.zip", "shasum": ""},
+            "require": {"ext-json": "*", "Psr/Log": "^3.0"}
+        }
+    ]
+}`
+
+var testManifest = &Manifest{
+	Name:       "acme/app",
+	Require:    map[string]string{"php": "^8.1", "monolog/monolog": "^3.5"},
+	RequireDev: map[string]string{"phpunit/phpunit": "^10.5"},
+}
+
+func TestGetDependencies(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "monolog/monolog:3.5.0", Type: "zip", Scopes: []string{"prod"}, RequestedBy: [][]string{{"acme/app"}}},
+		{Id: "phpunit/phpunit:10.5.10", Type: "zip", Scopes: []string{"dev"}, RequestedBy: [][]string{{"acme/app"}}},
+		{Id: "psr/log:3.0.0", Type: "zip", Scopes: []string{"prod", "dev"}, RequestedBy: [][]string{{"monolog/monolog:3.5.0", "acme/app"}, {"phpunit/phpunit:10.5.10", "acme/app"}}},
+	}, lockfile.GetDependencies(testManifest, "acme/app"))
+}
+
+func TestGetDependencyTree(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	tree := lockfile.GetDependencyTree(testManifest, "acme/app")
+	assert.Equal(t, "composer://acme/app", tree.Id)
+	require.Len(t, tree.Nodes, 2)
+	assert.Equal(t, "composer://monolog/monolog:3.5.0", tree.Nodes[0].Id)
+	assert.Equal(t, "composer://phpunit/phpunit:10.5.10", tree.Nodes[1].Id)
+	for _, node := range tree.Nodes {
+		require.Len(t, node.Nodes, 1)
+		assert.Equal(t, "composer://psr/log:3.0.0", node.Nodes[0].Id)
+		assert.Equal(t, node, node.Nodes[0].Parent)
+	}
+}
+
+func TestGetCachedDistDetails(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	composerPackage, found := lockfile.GetPackage("psr/log")
+	require.True(t, found)
+	cacheFilesDir := t.TempDir()
+
+	details, err := GetCachedDistDetails(cacheFilesDir, composerPackage)
+	require.NoError(t, err)
+	assert.Nil(t, details)
+
+	urlHash := sha1.Sum([]byte(composerPackage.Dist.Url))
+	require.NoError(t, os.MkdirAll(filepath.Join(cacheFilesDir, "psr", "log"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cacheFilesDir, "psr", "log", hex.EncodeToString(urlHash[:])+".zip"), []byte("dist"), 0644))
+	details, err = GetCachedDistDetails(cacheFilesDir, composerPackage)
+	require.NoError(t, err)
+	require.NotNil(t, details)
+	assert.NotEmpty(t, details.Checksum.Sha1)
+}
@@ -0,0 +1,178 @@
+package conda
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The environment variables overriding the channel_alias and default_channels settings of the .condarc files.
+	ChannelAliasEnv    = "CONDA_CHANNEL_ALIAS"
+	DefaultChannelsEnv = "CONDA_DEFAULT_CHANNELS"
+)
+
+// The channels of the 'defaults' channel, which are resolved under the channel alias as well.
+var defaultChannels = []string{"main", "r", "msys2"}
+
+func GetExecutablePath() (string, error) {
+	executablePath, err := exec.LookPath("conda")
+	if err != nil {
+		return "", errorutils.CheckErrorf("couldn't find the conda executable in the PATH: %s", err.Error())
+	}
+	log.Debug("Found conda executable at:", executablePath)
+	return executablePath, nil
+}
+
+// Returns the URL of an Artifactory Conda repository, with the credentials embedded
+// (https://<user>:<password/token>@<your-artifactory-url>/artifactory/api/conda/<repo-name>).
+// conda resolves the named channels, such as 'conda-forge', under this URL when it's set as the channel alias.
+func GetChannelAlias(serverDetails *config.ServerDetails, repo string) (string, error) {
+	rtUrl, err := url.Parse(serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	username := serverDetails.GetUser()
+	password := serverDetails.GetPassword()
+	if serverDetails.GetAccessToken() != "" {
+		if username == "" {
+			username = auth.ExtractUsernameFromAccessToken(serverDetails.GetAccessToken())
+		}
+		password = serverDetails.GetAccessToken()
+	}
+	rtUrl = rtUrl.JoinPath("api/conda", repo)
+	if password != "" {
+		rtUrl.User = url.UserPassword(username, password)
+	}
+	return rtUrl.String(), nil
+}
+
+// Set a setting in the .condarc file of the user, or in the file set by the CONDARC environment variable.
+func ConfigSet(key, value string) error {
+	configCmd := ioutils.NewCommand("conda", "config", []string{"--set", key, value})
+	if err := ioutils.RunCmd(configCmd); err != nil {
+		return errorutils.CheckErrorf("conda config command failed with: %q", err)
+	}
+	return nil
+}
+
+// Returns the environment variables which resolve both the named channels and the 'defaults' channel from the repository.
+func GetChannelsEnv(channelAlias string) map[string]string {
+	channels := make([]string, 0, len(defaultChannels))
+	for _, channel := range defaultChannels {
+		channels = append(channels, channelAlias+"/"+channel)
+	}
+	return map[string]string{ChannelAliasEnv: channelAlias, DefaultChannelsEnv: strings.Join(channels, ",")}
+}
+
+// The output of 'conda env export --json'.
+type EnvExport struct {
+	Name     string   `json:"name"`
+	Channels []string `json:"channels"`
+	// The conda packages of the environment, written as 'name=version=build'.
+	// The packages installed by pip are listed in an object under the 'pip' key.
+	Dependencies []json.RawMessage `json:"dependencies"`
+	Prefix       string            `json:"prefix"`
+}
+
+// A conda package of the environment.
+type Package struct {
+	Name    string
+	Version string
+	Build   string
+}
+
+func (p *Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+// Returns the name of the package file without its extension, e.g. 'numpy-1.26.4-py311h64a7726_0'.
+func (p *Package) DistName() string {
+	return fmt.Sprintf("%s-%s-%s", p.Name, p.Version, p.Build)
+}
+
+// Export the environment, which is selected by the '--name' or '--prefix' arguments, or is the active environment.
+func ExportEnv(executablePath string, envArgs []string) (*EnvExport, error) {
+	command := exec.Command(executablePath, append([]string{"env", "export", "--json"}, envArgs...)...)
+	log.Debug("Running command:", strings.Join(command.Args, " "))
+	output, err := command.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return nil, errorutils.CheckErrorf("'conda env export' failed: %s", strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseEnvExport(output)
+}
+
+func ParseEnvExport(output []byte) (*EnvExport, error) {
+	envExport := new(EnvExport)
+	if err := json.Unmarshal(output, envExport); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the output of 'conda env export': %s", err.Error())
+	}
+	return envExport, nil
+}
+
+// Returns the conda packages of the environment. The packages installed by pip aren't included.
+func (envExport *EnvExport) GetPackages() []Package {
+	var packages []Package
+	for _, rawDependency := range envExport.Dependencies {
+		var dependency string
+		if err := json.Unmarshal(rawDependency, &dependency); err != nil {
+			log.Debug("Skipping the dependencies of the environment which weren't installed by conda:", string(rawDependency))
+			continue
+		}
+		parts := strings.Split(dependency, "=")
+		if len(parts) != 3 {
+			log.Debug("Skipping the unexpected dependency of the environment:", dependency)
+			continue
+		}
+		packages = append(packages, Package{Name: parts[0], Version: parts[1], Build: parts[2]})
+	}
+	return packages
+}
+
+// Returns the checksums of a package in Artifactory, by the name of the package file without its extension.
+// Packages are published either as '.conda' or as '.tar.bz2' files. Returns an empty checksum if the package can't be found.
+func GetDependencyChecksum(servicesManager artifactory.ArtifactoryServicesManager, repo, distName string) (checksum buildinfo.Checksum, err error) {
+	stream, err := servicesManager.Aql(createAqlQueryForConda(repo, distName))
+	if err != nil {
+		return
+	}
+	defer ioutils.Close(stream, &err)
+	result, err := io.ReadAll(stream)
+	if err != nil {
+		return
+	}
+	parsedResult := new(aqlResult)
+	if err = errorutils.CheckError(json.Unmarshal(result, parsedResult)); err != nil {
+		return
+	}
+	if len(parsedResult.Results) == 0 {
+		log.Debug(fmt.Sprintf("The package %s could not be found in repository: %s", distName, repo))
+		return
+	}
+	item := parsedResult.Results[0]
+	return buildinfo.Checksum{Sha1: item.Actual_Sha1, Md5: item.Actual_Md5, Sha256: item.Sha256}, nil
+}
+
+func createAqlQueryForConda(repo, distName string) string {
+	return fmt.Sprintf(`items.find({"repo":"%s","$or":[{"name":"%s.conda"},{"name":"%s.tar.bz2"}]}).include("name","repo","path","actual_md5","actual_sha1","sha256")`,
+		repo, distName, distName)
+}
+
+type aqlResult struct {
+	Results []*servicesutils.ResultItem `json:"results,omitempty"`
+}
@@ -2,6 +2,7 @@ package container
 
 import (
 	"encoding/json"
+	"fmt"
 	ioutils "github.com/jfrog/gofrog/io"
 	"os"
 	"path"
@@ -342,9 +343,11 @@ func (builder *buildInfoBuilder) createBuildInfo(commandType CommandType, manife
 }
 
 // Create the image's build info from list.manifest.json.
+// The fat-manifest module holds the fat-manifest, and each of its platform images is a module, whose parent is the fat-manifest module.
 func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatManifest, searchResultFatManifest *utils.ResultItem, candidateImages map[string][]*utils.ResultItem, baseModuleId string) (*buildinfo.BuildInfo, error) {
 	imageProperties := map[string]string{
-		"docker.image.tag": builder.image.Name(),
+		"docker.image.tag":       builder.image.Name(),
+		"docker.manifest.digest": "sha256:" + searchResultFatManifest.Sha256,
 	}
 	if baseModuleId == "" {
 		imageName, err := builder.image.GetImageShortNameWithTag()
@@ -368,7 +371,11 @@ func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatMa
 	}
 	// Create all image arch modules
 	for _, manifest := range fatManifest.Manifests {
-		image := candidateImages[manifest.Digest]
+		image, found := candidateImages[manifest.Digest]
+		if !found {
+			log.Warn(fmt.Sprintf("The image of platform '%s' (%s) was not found in Artifactory and therefore will not be added to the build-info.", manifest.Platform.String(), manifest.Digest))
+			continue
+		}
 		var artifacts []buildinfo.Artifact
 		for _, layer := range image {
 			builder.imageLayers = append(builder.imageLayers, *layer)
@@ -379,10 +386,11 @@ func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(fatManifest *FatMa
 			}
 		}
 		buildInfo.Modules = append(buildInfo.Modules, buildinfo.Module{
-			Id:        getModuleIdByManifest(manifest, baseModuleId),
-			Type:      buildinfo.Docker,
-			Artifacts: artifacts,
-			Parent:    imageLongNameWithoutRepo,
+			Id:         getModuleIdByManifest(manifest, baseModuleId),
+			Type:       buildinfo.Docker,
+			Properties: getPlatformModuleProperties(manifest),
+			Artifacts:  artifacts,
+			Parent:     imageLongNameWithoutRepo,
 		})
 	}
 	return buildInfo, setBuildProperties(builder.buildName, builder.buildNumber, builder.project, builder.imageLayers, builder.serviceManager)
@@ -394,11 +402,21 @@ func getModuleIdByManifest(manifest ManifestDetails, baseModuleId string) string
 		return path.Join(AttestationsModuleIdPrefix, baseModuleId)
 	}
 	if manifest.Platform.Os != unknownPlatformPlaceholder && manifest.Platform.Architecture != unknownPlatformPlaceholder {
-		return path.Join(manifest.Platform.Os, manifest.Platform.Architecture, baseModuleId)
+		return path.Join(manifest.Platform.String(), baseModuleId)
 	}
 	return baseModuleId
 }
 
+func getPlatformModuleProperties(manifest ManifestDetails) map[string]string {
+	properties := map[string]string{"docker.manifest.digest": manifest.Digest}
+	if manifest.Annotations.ReferenceType == attestationManifestRefType {
+		properties["docker.reference.digest"] = manifest.Annotations.ReferenceDigest
+	} else if manifest.Platform.Os != unknownPlatformPlaceholder && manifest.Platform.Architecture != unknownPlatformPlaceholder {
+		properties["docker.image.platform"] = manifest.Platform.String()
+	}
+	return properties
+}
+
 func (builder *buildInfoBuilder) createPushBuildProperties(imageManifest *manifest, candidateLayers map[string]*utils.ResultItem) (artifacts []buildinfo.Artifact, dependencies []buildinfo.Dependency, imageLayers []utils.ResultItem, err error) {
 	// Add artifacts.
 	artifacts = append(artifacts, getManifestArtifact(candidateLayers[ManifestJsonFile]))
@@ -161,3 +161,33 @@ func createManifestConfigWithForeignLayer() (map[string]*utils.ResultItem, *mani
 	searchResults["sha__1"] = dummySearchResults
 	return searchResults, manifest
 }
+
+func TestGetModuleIdByManifest(t *testing.T) {
+	tests := []struct {
+		name             string
+		manifest         ManifestDetails
+		expectedModuleId string
+	}{
+		{"platform", ManifestDetails{Platform: Platform{Os: "linux", Architecture: "amd64"}}, "linux/amd64/my-image:1.0"},
+		{"platform with variant", ManifestDetails{Platform: Platform{Os: "linux", Architecture: "arm", Variant: "v7"}}, "linux/arm/v7/my-image:1.0"},
+		{"unknown platform", ManifestDetails{Platform: Platform{Os: "unknown", Architecture: "unknown"}}, "my-image:1.0"},
+		{"attestation", ManifestDetails{Platform: Platform{Os: "unknown", Architecture: "unknown"}, Annotations: Annotations{ReferenceType: "attestation-manifest"}}, "attestations/my-image:1.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedModuleId, getModuleIdByManifest(test.manifest, "my-image:1.0"))
+		})
+	}
+}
+
+func TestGetPlatformModuleProperties(t *testing.T) {
+	properties := getPlatformModuleProperties(ManifestDetails{Digest: "sha256:arm", Platform: Platform{Os: "linux", Architecture: "arm64", Variant: "v8"}})
+	assert.Equal(t, map[string]string{"docker.manifest.digest": "sha256:arm", "docker.image.platform": "linux/arm64/v8"}, properties)
+
+	properties = getPlatformModuleProperties(ManifestDetails{
+		Digest:      "sha256:attestation",
+		Platform:    Platform{Os: "unknown", Architecture: "unknown"},
+		Annotations: Annotations{ReferenceType: "attestation-manifest", ReferenceDigest: "sha256:arm"},
+	})
+	assert.Equal(t, map[string]string{"docker.manifest.digest": "sha256:attestation", "docker.reference.digest": "sha256:arm"}, properties)
+}
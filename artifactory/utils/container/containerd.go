@@ -0,0 +1,195 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The driver type reported by Docker when the images are stored in the containerd image store.
+	containerdSnapshotterDriverType = "io.containerd.snapshotter"
+	// The containerd namespaces of the images of Docker and nerdctl. Overridden by the CONTAINERD_NAMESPACE environment variable.
+	dockerContainerdNamespace  = "moby"
+	nerdctlContainerdNamespace = "default"
+	containerdNamespaceEnv     = "CONTAINERD_NAMESPACE"
+)
+
+// Returns true if the images of the container manager are stored in the containerd image store,
+// as with nerdctl, and with Docker when the containerd image store is enabled (the default of recent Docker Desktop versions).
+// In the containerd image store, the image ID is the digest of the image manifest or index, rather than the digest of the image config.
+// If the storage driver of Docker can't be determined, the classic image store is assumed.
+func IsContainerdImageStore(containerManagerType ContainerManagerType) bool {
+	switch containerManagerType {
+	case Nerdctl:
+		return true
+	case DockerClient:
+		cmd := &driverStatusCmd{containerManager: containerManagerType}
+		content, err := cmd.RunCmd()
+		if err != nil {
+			log.Warn("Couldn't get the Docker storage driver status, so the classic image store is assumed: " + strings.TrimSpace(content))
+			return false
+		}
+		return isContainerdDriverStatus(content)
+	default:
+		return false
+	}
+}
+
+func isContainerdDriverStatus(driverStatus string) bool {
+	return strings.Contains(driverStatus, containerdSnapshotterDriverType)
+}
+
+// The manifest or the index of an image, as stored in the containerd content store.
+type containerdManifest struct {
+	Config manifestConfig `json:"config,omitempty"`
+	// The manifests of the platform images, if this is an index.
+	Manifests []ManifestDetails `json:"manifests,omitempty"`
+}
+
+// Read the manifest or the index of the image from the containerd content store, by the image ID.
+func readContainerdManifest(containerManagerType ContainerManagerType, imageId string) (*containerdManifest, error) {
+	cmd := &contentGetCmd{namespace: getContainerdNamespace(containerManagerType), digest: imageId}
+	content, err := cmd.RunCmd()
+	if err != nil {
+		return nil, err
+	}
+	return parseContainerdManifest(content, imageId)
+}
+
+func parseContainerdManifest(content []byte, imageId string) (*containerdManifest, error) {
+	imageManifest := new(containerdManifest)
+	if err := json.Unmarshal(content, imageManifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the manifest '%s' of the containerd content store: %s", imageId, err.Error())
+	}
+	return imageManifest, nil
+}
+
+func getContainerdNamespace(containerManagerType ContainerManagerType) string {
+	if namespace := os.Getenv(containerdNamespaceEnv); namespace != "" {
+		return namespace
+	}
+	if containerManagerType == Nerdctl {
+		return nerdctlContainerdNamespace
+	}
+	return dockerContainerdNamespace
+}
+
+// Create build-info for an image of the containerd image store.
+// The image is found in Artifactory by matching its ID with the digest of the manifest or the fat-manifest stored in Artifactory,
+// rather than with the digest of the image config, as done for the classic image store.
+func (labib *localAgentBuildInfoBuilder) buildFromContainerdImageStore(module string) (*buildinfo.BuildInfo, error) {
+	builder := labib.buildInfoBuilder
+	longImageName, err := builder.image.GetImageLongNameWithTag()
+	if err != nil {
+		return nil, err
+	}
+	imagePath := strings.Replace(longImageName, ":", "/", 1)
+	log.Debug("Start searching for the image manifest matching digest", builder.imageSha2)
+	for _, imagePathPattern := range getManifestPaths(imagePath, builder.getSearchableRepo(), labib.commandType) {
+		log.Debug(`Searching in:"` + imagePathPattern + `"`)
+		resultMap, err := labib.searchLayers(imagePathPattern)
+		if err != nil {
+			return nil, err
+		}
+		if fatManifest, ok := resultMap["list.manifest.json"]; ok && "sha256:"+fatManifest.Sha256 == builder.imageSha2 {
+			return labib.buildFromContainerdFatManifest(fatManifest, resultMap, module)
+		}
+		if manifest, ok := resultMap[ManifestJsonFile]; ok && manifest.GetProperty("docker.manifest.digest") == builder.imageSha2 {
+			return labib.buildFromContainerdManifest(resultMap, module)
+		}
+	}
+	log.Warn("Failed to collect build-info. No layer(s) was found for image:'" + builder.image.name + "'. Hint, try to delete the image from the local cache and rerun the command")
+	return nil, nil
+}
+
+func (labib *localAgentBuildInfoBuilder) buildFromContainerdManifest(resultMap map[string]*utils.ResultItem, module string) (*buildinfo.BuildInfo, error) {
+	builder := labib.buildInfoBuilder
+	imageManifest, err := getManifest(resultMap, builder.serviceManager, builder.repositoryDetails.key)
+	if err != nil {
+		return nil, err
+	}
+	if imageManifest == nil {
+		return nil, errorutils.CheckErrorf(imageNotFoundErrorMessage, builder.image.name)
+	}
+	// As with the classic image store, the image is identified in the build-info by the digest of its config.
+	builder.setImageSha2(imageManifest.Config.Digest)
+	return builder.createBuildInfo(labib.commandType, imageManifest, resultMap, module)
+}
+
+// A pushed fat-manifest is recorded with all of its platform images.
+// A pulled fat-manifest is recorded by the image of the local platform only, since only this image was pulled.
+func (labib *localAgentBuildInfoBuilder) buildFromContainerdFatManifest(fatManifestResult *utils.ResultItem, resultMap map[string]*utils.ResultItem, module string) (*buildinfo.BuildInfo, error) {
+	builder := labib.buildInfoBuilder
+	log.Debug("Found list.manifest.json (fat-manifest) matching the image ID.")
+	if labib.commandType == Push {
+		fatManifest, err := getFatManifest(resultMap, builder.serviceManager, builder.repositoryDetails.key)
+		if err != nil {
+			return nil, err
+		}
+		candidateImages, err := performMultiPlatformImageSearch(getFatManifestRoot(fatManifestResult.GetItemRelativeLocation())+"/*", builder.serviceManager)
+		if err != nil {
+			return nil, err
+		}
+		return builder.createMultiPlatformBuildInfo(fatManifest, fatManifestResult, candidateImages, module)
+	}
+	digest, err := labib.getImageDigestFromFatManifest(*fatManifestResult)
+	if err != nil {
+		return nil, err
+	}
+	if digest == "" {
+		return nil, errorutils.CheckErrorf("couldn't find the manifest of the local platform in the fat-manifest of image '%s'", builder.image.name)
+	}
+	platformResultMap, err := labib.searchLayers(path.Join(fatManifestResult.Repo, path.Dir(fatManifestResult.Path), digestToLayer(digest), "*"))
+	if err != nil {
+		return nil, err
+	}
+	return labib.buildFromContainerdManifest(platformResultMap, module)
+}
+
+// Read a blob from the containerd content store
+type contentGetCmd struct {
+	namespace string
+	digest    string
+}
+
+func (cgc *contentGetCmd) GetCmd() *exec.Cmd {
+	return exec.Command("ctr", "--namespace", cgc.namespace, "content", "get", cgc.digest)
+}
+
+func (cgc *contentGetCmd) RunCmd() ([]byte, error) {
+	command := cgc.GetCmd()
+	stderr := bytes.NewBuffer([]byte{})
+	command.Stderr = stderr
+	content, err := command.Output()
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read '%s' from the containerd content store: %s %s", cgc.digest, err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return content, nil
+}
+
+// Get the status of the storage driver
+type driverStatusCmd struct {
+	containerManager ContainerManagerType
+}
+
+func (dsc *driverStatusCmd) GetCmd() *exec.Cmd {
+	return exec.Command(dsc.containerManager.String(), "info", "--format", "{{json .DriverStatus}}")
+}
+
+func (dsc *driverStatusCmd) RunCmd() (string, error) {
+	command := dsc.GetCmd()
+	buffer := bytes.NewBuffer([]byte{})
+	command.Stderr = buffer
+	command.Stdout = buffer
+	err := command.Run()
+	return buffer.String(), err
+}
@@ -0,0 +1,52 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsContainerdDriverStatus(t *testing.T) {
+	tests := []struct {
+		driverStatus string
+		expected     bool
+	}{
+		{`[["driver-type","io.containerd.snapshotter.v1"]]`, true},
+		{`[["Backing Filesystem","extfs"],["Supports d_type","true"],["Using metacopy","false"],["Native Overlay Diff","true"],["userxattr","false"]]`, false},
+		{"null", false},
+	}
+	for _, test := range tests {
+		t.Run(test.driverStatus, func(t *testing.T) {
+			assert.Equal(t, test.expected, isContainerdDriverStatus(test.driverStatus))
+		})
+	}
+}
+
+func TestIsContainerdImageStoreNerdctl(t *testing.T) {
+	assert.True(t, IsContainerdImageStore(Nerdctl))
+	assert.False(t, IsContainerdImageStore(Podman))
+	assert.Equal(t, "nerdctl", Nerdctl.String())
+}
+
+func TestParseContainerdManifest(t *testing.T) {
+	imageManifest, err := parseContainerdManifest([]byte(`{"schemaVersion":2,"config":{"digest":"sha256:c0nf19"},"layers":[{"digest":"sha256:1ayer"}]}`), "sha256:1d")
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:c0nf19", imageManifest.Config.Digest)
+	assert.Empty(t, imageManifest.Manifests)
+
+	imageIndex, err := parseContainerdManifest([]byte(`{"schemaVersion":2,"manifests":[{"digest":"sha256:amd64","platform":{"architecture":"amd64","os":"linux"}}]}`), "sha256:1d")
+	assert.NoError(t, err)
+	assert.Empty(t, imageIndex.Config.Digest)
+	assert.Equal(t, "sha256:amd64", searchManifestDigest("linux", "amd64", imageIndex.Manifests))
+
+	_, err = parseContainerdManifest([]byte("ctr: not found"), "sha256:1d")
+	assert.ErrorContains(t, err, "failed to parse the manifest 'sha256:1d'")
+}
+
+func TestGetContainerdNamespace(t *testing.T) {
+	t.Setenv(containerdNamespaceEnv, "")
+	assert.Equal(t, "moby", getContainerdNamespace(DockerClient))
+	assert.Equal(t, "default", getContainerdNamespace(Nerdctl))
+	t.Setenv(containerdNamespaceEnv, "k8s.io")
+	assert.Equal(t, "k8s.io", getContainerdNamespace(Nerdctl))
+}
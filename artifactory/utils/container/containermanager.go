@@ -34,10 +34,12 @@ type ContainerManagerType int
 const (
 	DockerClient ContainerManagerType = iota
 	Podman
+	Nerdctl
+	Buildah
 )
 
 func (cmt ContainerManagerType) String() string {
-	return [...]string{"docker", "podman"}[cmt]
+	return [...]string{"docker", "podman", "nerdctl", "buildah"}[cmt]
 }
 
 // Container image
@@ -70,7 +72,7 @@ func (containerManager *containerManager) Id(image *Image) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return strings.Split(content, "\n")[0], nil
+	return toImageId(strings.Split(content, "\n")[0]), nil
 }
 
 // Return the OS and architecture on which the image runs e.g. (linux, amd64, nil).
@@ -121,6 +123,10 @@ type getImageIdCmd struct {
 
 func (getImageId *getImageIdCmd) GetCmd() *exec.Cmd {
 	var cmd []string
+	if getImageId.containerManager == Buildah {
+		// The image ID is the ID of the image the inspected builder is created from.
+		return exec.Command(getImageId.containerManager.String(), "inspect", "--type", "image", "--format", "{{.FromImageID}}", getImageId.image.name)
+	}
 	cmd = append(cmd, "images")
 	cmd = append(cmd, "--format", "{{.ID}}")
 	cmd = append(cmd, "--no-trunc")
@@ -145,6 +151,10 @@ type getImageSystemCompatibilityCmd struct {
 
 func (getImageSystemCompatibilityCmd *getImageSystemCompatibilityCmd) GetCmd() *exec.Cmd {
 	var cmd []string
+	if getImageSystemCompatibilityCmd.containerManager == Buildah {
+		// Buildah has no 'image inspect' command, and exposes the image config under OCIv1.
+		return exec.Command(getImageSystemCompatibilityCmd.containerManager.String(), "inspect", "--type", "image", "--format", "{{ .OCIv1.OS}},{{ .OCIv1.Architecture}}", getImageSystemCompatibilityCmd.image.name)
+	}
 	cmd = append(cmd, "image")
 	cmd = append(cmd, "inspect")
 	cmd = append(cmd, getImageSystemCompatibilityCmd.image.name)
@@ -172,7 +182,7 @@ type LoginCmd struct {
 
 func (loginCmd *LoginCmd) GetCmd() *exec.Cmd {
 	if coreutils.IsWindows() {
-		return exec.Command("cmd", "/C", "echo", "%CONTAINER_MANAGER_PASS%|", "docker", "login", loginCmd.DockerRegistry, "--username", loginCmd.Username, "--password-stdin")
+		return exec.Command("cmd", "/C", "echo", "%CONTAINER_MANAGER_PASS%|", loginCmd.containerManager.String(), "login", loginCmd.DockerRegistry, "--username", loginCmd.Username, "--password-stdin")
 	}
 	cmd := "echo $CONTAINER_MANAGER_PASS " + fmt.Sprintf(`| `+loginCmd.containerManager.String()+` login %s --username="%s" --password-stdin`, loginCmd.DockerRegistry, loginCmd.Username)
 	return exec.Command("sh", "-c", cmd)
@@ -212,7 +222,7 @@ func ContainerManagerLogin(imageRegistry string, config *ContainerManagerLoginCo
 	if indexOfSlash < 0 {
 		return errorutils.CheckErrorf(LoginFailureMessage, containerManager.String(), imageRegistry, containerManager.String())
 	}
-	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: config.ServerDetails.User, Password: config.ServerDetails.Password}
+	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: username, Password: password, containerManager: containerManager}
 	err = cmd.RunCmd()
 	if err != nil {
 		// Login failed for both attempts
@@ -0,0 +1,56 @@
+package container
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The container tools, in the order of their auto-detection.
+var containerTools = []ContainerManagerType{DockerClient, Podman, Buildah, Nerdctl}
+
+// Allows replacing the lookup of the container tools binaries in tests.
+var lookPath = exec.LookPath
+
+func GetContainerTools() (tools []string) {
+	for _, tool := range containerTools {
+		tools = append(tools, tool.String())
+	}
+	return
+}
+
+// Get the container tool by its name, e.g. 'podman'. If the name is empty, the container tool is auto-detected.
+func GetContainerManagerType(name string) (ContainerManagerType, error) {
+	if name == "" {
+		return DetectContainerManagerType()
+	}
+	for _, tool := range containerTools {
+		if strings.EqualFold(name, tool.String()) {
+			return tool, nil
+		}
+	}
+	return DockerClient, errorutils.CheckErrorf("the container tool '%s' is not supported. Supported container tools: %s", name, strings.Join(GetContainerTools(), ", "))
+}
+
+// Detect the container tool installed on this machine.
+// Docker is preferred, followed by the daemonless tools, which are commonly used on RHEL-based CI agents, and in rootless mode.
+func DetectContainerManagerType() (ContainerManagerType, error) {
+	for _, tool := range containerTools {
+		if _, err := lookPath(tool.String()); err == nil {
+			log.Debug("Detected the container tool:", tool.String())
+			return tool, nil
+		}
+	}
+	return DockerClient, errorutils.CheckErrorf("no container tool was found in the PATH. Install one of the following: %s", strings.Join(GetContainerTools(), ", "))
+}
+
+// Podman and Buildah may return the image ID without the digest algorithm prefix.
+func toImageId(id string) string {
+	id = strings.TrimSpace(id)
+	if id != "" && !strings.Contains(id, ":") {
+		return "sha256:" + id
+	}
+	return id
+}
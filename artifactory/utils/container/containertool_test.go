@@ -0,0 +1,71 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetContainerManagerType(t *testing.T) {
+	tests := []struct {
+		name          string
+		expected      ContainerManagerType
+		expectedError bool
+	}{
+		{"docker", DockerClient, false},
+		{"Podman", Podman, false},
+		{"buildah", Buildah, false},
+		{"nerdctl", Nerdctl, false},
+		{"rkt", DockerClient, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			containerManagerType, err := GetContainerManagerType(test.name)
+			if test.expectedError {
+				assert.ErrorContains(t, err, "the container tool 'rkt' is not supported")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, containerManagerType)
+		})
+	}
+}
+
+func TestDetectContainerManagerType(t *testing.T) {
+	originalLookPath := lookPath
+	defer func() {
+		lookPath = originalLookPath
+	}()
+	installedTools := map[string]bool{}
+	lookPath = func(file string) (string, error) {
+		if installedTools[file] {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	_, err := DetectContainerManagerType()
+	assert.ErrorContains(t, err, "no container tool was found in the PATH")
+
+	installedTools["buildah"] = true
+	containerManagerType, err := GetContainerManagerType("")
+	assert.NoError(t, err)
+	assert.Equal(t, Buildah, containerManagerType)
+
+	installedTools["podman"] = true
+	containerManagerType, err = DetectContainerManagerType()
+	assert.NoError(t, err)
+	assert.Equal(t, Podman, containerManagerType)
+
+	installedTools["docker"] = true
+	containerManagerType, err = DetectContainerManagerType()
+	assert.NoError(t, err)
+	assert.Equal(t, DockerClient, containerManagerType)
+}
+
+func TestToImageId(t *testing.T) {
+	assert.Equal(t, "sha256:0123abcd", toImageId("0123abcd"))
+	assert.Equal(t, "sha256:0123abcd", toImageId("sha256:0123abcd\n"))
+	assert.Equal(t, "", toImageId(""))
+}
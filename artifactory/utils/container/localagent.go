@@ -19,6 +19,9 @@ type localAgentBuildInfoBuilder struct {
 	// Name of the container CLI tool e.g. docker
 	containerManager ContainerManager
 	commandType      CommandType
+	// If true, the image ID is the digest of the image manifest or index, which couldn't be resolved to the digest of the image config
+	// from the containerd content store. See IsContainerdImageStore.
+	containerdImageStore bool
 }
 
 // Create new build info builder container CLI tool
@@ -32,11 +35,26 @@ func NewLocalAgentBuildInfoBuilder(image *Image, repository, buildName, buildNum
 		return nil, err
 	}
 	builder.setImageSha2(imageSha2)
+	containerdImageStore := IsContainerdImageStore(containerManager.GetContainerManagerType())
+	if containerdImageStore {
+		log.Debug("The image is stored in the containerd image store. Its ID is the digest of its manifest:", imageSha2)
+		imageManifest, err := readContainerdManifest(containerManager.GetContainerManagerType(), imageSha2)
+		switch {
+		case err != nil:
+			log.Warn("Couldn't read the image manifest from the containerd content store, so the image is found in Artifactory by its manifest digest: " + err.Error())
+		case len(imageManifest.Manifests) == 0 && imageManifest.Config.Digest != "":
+			// The image is found in Artifactory by the digest of its config, as with the classic image store.
+			log.Debug("Read the image manifest from the containerd content store. The digest of the image config:", imageManifest.Config.Digest)
+			builder.setImageSha2(imageManifest.Config.Digest)
+			containerdImageStore = false
+		}
+	}
 	return &localAgentBuildInfoBuilder{
-		buildInfoBuilder: builder,
-		containerManager: containerManager,
-		commandType:      commandType,
-	}, err
+		buildInfoBuilder:     builder,
+		containerManager:     containerManager,
+		commandType:          commandType,
+		containerdImageStore: containerdImageStore,
+	}, nil
 }
 
 func (labib *localAgentBuildInfoBuilder) GetLayers() *[]utils.ResultItem {
@@ -49,6 +67,9 @@ func (labib *localAgentBuildInfoBuilder) SetSkipTaggingLayers(skipTaggingLayers
 
 // Create build-info for a docker image.
 func (labib *localAgentBuildInfoBuilder) Build(module string) (*buildinfo.BuildInfo, error) {
+	if labib.containerdImageStore {
+		return labib.buildFromContainerdImageStore(module)
+	}
 	// Search for image build-info.
 	candidateLayers, manifest, err := labib.searchImage()
 	if err != nil {
@@ -91,24 +112,9 @@ func (labib *localAgentBuildInfoBuilder) searchImage() (map[string]*utils.Result
 // Search image layers in artifactory by the provided image path in artifactory.
 // If fat-manifest is found, use it to find our image in Artifactory.
 func (labib *localAgentBuildInfoBuilder) search(imagePathPattern string) (resultMap map[string]*utils.ResultItem, err error) {
-	resultMap, err = performSearch(imagePathPattern, labib.buildInfoBuilder.serviceManager)
-	if err != nil {
-		log.Debug("Failed to search  marker layer. Error:", err.Error())
+	if resultMap, err = labib.searchLayers(imagePathPattern); err != nil {
 		return
 	}
-	// Validate there are no .marker layers.
-	totalDownloaded, err := downloadMarkerLayersToRemoteCache(resultMap, labib.buildInfoBuilder)
-	if err != nil {
-		log.Debug("Failed to download marker layer. Error:", err.Error())
-		return nil, err
-	}
-	if totalDownloaded > 0 {
-		// Search again after .marker layer were downloaded.
-		if resultMap, err = performSearch(imagePathPattern, labib.buildInfoBuilder.serviceManager); err != nil {
-			log.Debug("Failed to research layers after download marker layers. Error:", err.Error())
-			return
-		}
-	}
 	// Check if search results contain multi-architecture images (fat-manifest).
 	if searchResult, ok := resultMap["list.manifest.json"]; labib.commandType == Pull && ok {
 		// In case of a fat-manifest, Artifactory will create two folders.
@@ -129,6 +135,28 @@ func (labib *localAgentBuildInfoBuilder) search(imagePathPattern string) (result
 	return resultMap, err
 }
 
+// Search image layers in artifactory by the provided image path in artifactory, after downloading the marker layers into the remote cache.
+func (labib *localAgentBuildInfoBuilder) searchLayers(imagePathPattern string) (resultMap map[string]*utils.ResultItem, err error) {
+	resultMap, err = performSearch(imagePathPattern, labib.buildInfoBuilder.serviceManager)
+	if err != nil {
+		log.Debug("Failed to search  marker layer. Error:", err.Error())
+		return
+	}
+	// Validate there are no .marker layers.
+	totalDownloaded, err := downloadMarkerLayersToRemoteCache(resultMap, labib.buildInfoBuilder)
+	if err != nil {
+		log.Debug("Failed to download marker layer. Error:", err.Error())
+		return nil, err
+	}
+	if totalDownloaded > 0 {
+		// Search again after .marker layer were downloaded.
+		if resultMap, err = performSearch(imagePathPattern, labib.buildInfoBuilder.serviceManager); err != nil {
+			log.Debug("Failed to research layers after download marker layers. Error:", err.Error())
+		}
+	}
+	return
+}
+
 // Verify manifest by comparing sha256, which references to the image digest. If there is no match, return nil.
 func (labib *localAgentBuildInfoBuilder) isVerifiedManifest(imageManifest *manifest) bool {
 	if imageManifest.Config.Digest != labib.buildInfoBuilder.imageSha2 {
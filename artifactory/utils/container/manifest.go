@@ -47,6 +47,13 @@ type ManifestDetails struct {
 type Platform struct {
 	Architecture string `json:"architecture"`
 	Os           string `json:"os"`
+	// The variant of the CPU, e.g. v7 for linux/arm/v7.
+	Variant string `json:"variant,omitempty"`
+}
+
+// Return the platform in the os/architecture[/variant] format, e.g. linux/arm/v7.
+func (platform Platform) String() string {
+	return path.Join(platform.Os, platform.Architecture, platform.Variant)
 }
 
 // Annotations for attestation manifests.
@@ -85,6 +92,15 @@ func getManifest(resultMap map[string]*utils.ResultItem, serviceManager artifact
 	return
 }
 
+// Returns the fat-manifest of an image tag in Artifactory, or nil if the tag isn't a multi-platform image.
+func GetFatManifest(serviceManager artifactory.ArtifactoryServicesManager, repo, image, tag string) (*FatManifest, error) {
+	resultMap, err := performSearch(path.Join(repo, image, tag, "*"), serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	return getFatManifest(resultMap, serviceManager, repo)
+}
+
 func getFatManifest(resultMap map[string]*utils.ResultItem, serviceManager artifactory.ArtifactoryServicesManager, repo string) (imageFatManifest *FatManifest, err error) {
 	if len(resultMap) == 0 {
 		return
@@ -96,3 +112,9 @@ func getFatManifest(resultMap map[string]*utils.ResultItem, serviceManager artif
 	err = downloadLayer(*manifestSearchResult, &imageFatManifest, serviceManager, repo)
 	return
 }
+
+// Returns true if the manifest of the image digest is stored in the repository, in the folder named by the digest.
+func IsImageDigestStored(serviceManager artifactory.ArtifactoryServicesManager, repo, image, digest string) (bool, error) {
+	resultMap, err := performSearch(path.Join(repo, image, digestToLayer(digest), ManifestJsonFile), serviceManager)
+	return len(resultMap) > 0, err
+}
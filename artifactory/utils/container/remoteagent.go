@@ -72,6 +72,9 @@ func (rabib *RemoteAgentBuildInfoBuilder) handleManifest(resultMap map[string]*u
 
 func (rabib *RemoteAgentBuildInfoBuilder) handleFatManifestImage(results map[string]*utils.ResultItem) (map[string][]*utils.ResultItem, *utils.ResultItem, *FatManifest, error) {
 	if fatManifestResult, ok := results["list.manifest.json"]; ok {
+		if "sha256:"+fatManifestResult.Sha256 != rabib.manifestSha2 && fatManifestResult.GetProperty("docker.manifest.digest") != rabib.manifestSha2 {
+			return nil, nil, nil, errorutils.CheckErrorf(`Found incorrect list.manifest.json file. Expects digest "` + rabib.manifestSha2 + `" found "sha256:` + fatManifestResult.Sha256)
+		}
 		log.Debug("Found list.manifest.json. Proceeding to create build-info.")
 		fatManifestRootPath := getFatManifestRoot(fatManifestResult.GetItemRelativeLocation()) + "/*"
 		fatManifest, err := getFatManifest(results, rabib.buildInfoBuilder.serviceManager, rabib.buildInfoBuilder.repositoryDetails.key)
@@ -0,0 +1,278 @@
+package curation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+type PackageType string
+
+const (
+	Go     PackageType = "go"
+	Nuget  PackageType = "nuget"
+	Gradle PackageType = "gradle"
+
+	GoPackageTypeIdentifier     = "go://"
+	NugetPackageTypeIdentifier  = "nuget://"
+	GradlePackageTypeIdentifier = "gav://"
+)
+
+// Matches the violated policies in the messages of the blocked downloads, such as '{Malicious, Malicious package, Package is malicious, Remove the package}'.
+var violatedPolicyRegex = regexp.MustCompile(`\{([^{}]*)}`)
+
+type Package struct {
+	Type    PackageType
+	Name    string
+	Version string
+	// The direct dependencies of the project which bring the package. Direct dependencies bring themselves.
+	DirectDependencies []string
+}
+
+func (p *Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+type Policy struct {
+	Policy         string
+	Condition      string
+	Explanation    string
+	Recommendation string
+}
+
+type BlockedPackage struct {
+	Package
+	Policies []Policy
+}
+
+// The packages which are blocked by the curation policies, of all the audited projects.
+type Report struct {
+	// The number of packages which were checked.
+	Checked int
+	Blocked []BlockedPackage
+}
+
+// Returns the report as a list of the blocked packages, sorted by their types, names and versions.
+func (r *Report) String() string {
+	if len(r.Blocked) == 0 {
+		return fmt.Sprintf("None of the %d packages is blocked by the curation policies.", r.Checked)
+	}
+	blocked := slices.Clone(r.Blocked)
+	slices.SortFunc(blocked, func(a, b BlockedPackage) int {
+		return strings.Compare(string(a.Type)+" "+a.Id(), string(b.Type)+" "+b.Id())
+	})
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%d of the %d packages are blocked by the curation policies:\n", len(blocked), r.Checked))
+	for _, blockedPackage := range blocked {
+		builder.WriteString(fmt.Sprintf("- [%s] %s (direct dependencies: %s)\n", blockedPackage.Type, blockedPackage.Id(), strings.Join(blockedPackage.DirectDependencies, ", ")))
+		for _, policy := range blockedPackage.Policies {
+			builder.WriteString(fmt.Sprintf("    Policy: %s, Condition: %s", policy.Policy, policy.Condition))
+			if policy.Recommendation != "" {
+				builder.WriteString(", Recommendation: " + policy.Recommendation)
+			}
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
+
+// Returns the packages of the dependency tree, including the transitive ones, with the direct dependencies which bring them.
+// The root of the tree is the project itself, and isn't returned.
+func GetPackagesFromTree(root *xrayUtils.GraphNode) ([]*Package, error) {
+	packages := map[string]*Package{}
+	var ids []string
+	for _, directNode := range root.Nodes {
+		directPackage, err := parseComponentId(directNode.Id)
+		if err != nil {
+			return nil, err
+		}
+		err = walkTree(directNode, map[string]bool{}, func(node *xrayUtils.GraphNode) error {
+			curationPackage, exists := packages[node.Id]
+			if !exists {
+				if curationPackage, err = parseComponentId(node.Id); err != nil {
+					return err
+				}
+				packages[node.Id] = curationPackage
+				ids = append(ids, node.Id)
+			}
+			if !slices.Contains(curationPackage.DirectDependencies, directPackage.Id()) {
+				curationPackage.DirectDependencies = append(curationPackage.DirectDependencies, directPackage.Id())
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := make([]*Package, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, packages[id])
+	}
+	return result, nil
+}
+
+func walkTree(node *xrayUtils.GraphNode, visited map[string]bool, visit func(*xrayUtils.GraphNode) error) error {
+	// The trees of some package managers have cycles.
+	if visited[node.Id] {
+		return nil
+	}
+	visited[node.Id] = true
+	if err := visit(node); err != nil {
+		return err
+	}
+	for _, child := range node.Nodes {
+		if err := walkTree(child, visited, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse a component ID, such as 'go://github.com/jfrog/gofrog:v1.7.6', 'nuget://Newtonsoft.Json:13.0.3' or 'gav://org.slf4j:slf4j-api:2.0.9'.
+func parseComponentId(componentId string) (*Package, error) {
+	var packageType PackageType
+	var id string
+	switch {
+	case strings.HasPrefix(componentId, GoPackageTypeIdentifier):
+		packageType, id = Go, strings.TrimPrefix(componentId, GoPackageTypeIdentifier)
+	case strings.HasPrefix(componentId, NugetPackageTypeIdentifier):
+		packageType, id = Nuget, strings.TrimPrefix(componentId, NugetPackageTypeIdentifier)
+	case strings.HasPrefix(componentId, GradlePackageTypeIdentifier):
+		packageType, id = Gradle, strings.TrimPrefix(componentId, GradlePackageTypeIdentifier)
+	default:
+		return nil, errorutils.CheckErrorf("the curation audit doesn't support the component '%s'", componentId)
+	}
+	separator := strings.LastIndex(id, ":")
+	if separator <= 0 || separator == len(id)-1 {
+		return nil, errorutils.CheckErrorf("the component '%s' has no version", componentId)
+	}
+	if packageType == Gradle && !strings.Contains(id[:separator], ":") {
+		return nil, errorutils.CheckErrorf("the component '%s' has no group", componentId)
+	}
+	return &Package{Type: packageType, Name: id[:separator], Version: id[separator+1:]}, nil
+}
+
+// Returns the pass-through URL of the package in the repository, which Artifactory checks against the curation policies without downloading the package.
+func GetPassThroughUrl(artifactoryUrl, repository string, curationPackage *Package) (string, error) {
+	passThroughUrl := strings.TrimSuffix(artifactoryUrl, "/") + "/" + coreutils.CurationPassThroughApi
+	switch curationPackage.Type {
+	case Go:
+		modulePath, version := escapeGoModulePath(curationPackage.Name), escapeGoModulePath(curationPackage.Version)
+		return passThroughUrl + "api/go/" + url.PathEscape(repository) + "/" + modulePath + "/@v/" + version + ".zip", nil
+	case Nuget:
+		name, version := strings.ToLower(curationPackage.Name), strings.ToLower(curationPackage.Version)
+		return passThroughUrl + "api/nuget/v3/" + url.PathEscape(repository) + "/flatcontainer/" + name + "/" + version + "/" + name + "." + version + ".nupkg", nil
+	case Gradle:
+		group, artifact, _ := strings.Cut(curationPackage.Name, ":")
+		// Every version has a POM, while some versions have no JAR.
+		return passThroughUrl + url.PathEscape(repository) + "/" + strings.ReplaceAll(group, ".", "/") + "/" + artifact + "/" + curationPackage.Version + "/" + artifact + "-" + curationPackage.Version + ".pom", nil
+	}
+	return "", errorutils.CheckErrorf("the curation audit doesn't support the package type '%s'", curationPackage.Type)
+}
+
+// Escape the module path or version as the Go module proxy protocol requires, by replacing every uppercase letter with an exclamation mark followed by the lowercase letter.
+func escapeGoModulePath(path string) string {
+	var builder strings.Builder
+	for _, char := range path {
+		if 'A' <= char && char <= 'Z' {
+			builder.WriteRune('!')
+			char += 'a' - 'A'
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+// Check the packages against the curation policies through the pass-through API of the repositories, and add the blocked ones to the report.
+// The repositories are mapped by the package types, so the packages of several projects are reported together.
+func CheckPackages(servicesManager artifactory.ArtifactoryServicesManager, repositories map[PackageType]string, packages []*Package, report *Report) error {
+	artifactoryUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl()
+	for _, curationPackage := range packages {
+		repository, exists := repositories[curationPackage.Type]
+		if !exists {
+			return errorutils.CheckErrorf("no repository is configured for the %s packages", curationPackage.Type)
+		}
+		passThroughUrl, err := GetPassThroughUrl(artifactoryUrl, repository, curationPackage)
+		if err != nil {
+			return err
+		}
+		policies, blocked, err := checkPackage(servicesManager, passThroughUrl)
+		if err != nil {
+			return err
+		}
+		report.Checked++
+		if blocked {
+			log.Debug(fmt.Sprintf("The %s package %s is blocked by the curation policies", curationPackage.Type, curationPackage.Id()))
+			report.Blocked = append(report.Blocked, BlockedPackage{Package: *curationPackage, Policies: policies})
+		}
+	}
+	return nil
+}
+
+func checkPackage(servicesManager artifactory.ArtifactoryServicesManager, passThroughUrl string) (policies []Policy, blocked bool, err error) {
+	clientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	resp, body, err := servicesManager.Client().SendHead(passThroughUrl, &clientDetails)
+	if err != nil {
+		return
+	}
+	switch resp.StatusCode {
+	case http.StatusForbidden:
+		// Responses to HEAD requests have no body, so the violated policies are taken from the response to a GET request.
+		clientDetails = servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+		resp, body, _, err = servicesManager.Client().SendGet(passThroughUrl, true, &clientDetails)
+		if err != nil {
+			return
+		}
+		if resp.StatusCode != http.StatusForbidden {
+			err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusForbidden)
+			return
+		}
+		return ParseViolatedPolicies(body), true, nil
+	case http.StatusNotFound:
+		log.Debug("The package wasn't found in the repository:", passThroughUrl)
+		return nil, false, nil
+	}
+	err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+	return
+}
+
+type errorsResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Parse the violated policies from the response to a blocked download, such as:
+// {"errors":[{"status":403,"message":"Package lodash:4.17.20 download was blocked by JFrog Packages Curation service due to the following policies violated {Malicious, Malicious package, Package is malicious, Remove the package}."}]}
+func ParseViolatedPolicies(body []byte) (policies []Policy) {
+	response := new(errorsResponse)
+	if err := json.Unmarshal(body, response); err != nil {
+		log.Debug("Failed to parse the response to the blocked download:", err.Error())
+		return
+	}
+	for _, responseError := range response.Errors {
+		for _, match := range violatedPolicyRegex.FindAllStringSubmatch(responseError.Message, -1) {
+			fields := strings.SplitN(match[1], ",", 4)
+			for len(fields) < 4 {
+				fields = append(fields, "")
+			}
+			policies = append(policies, Policy{
+				Policy:         strings.TrimSpace(fields[0]),
+				Condition:      strings.TrimSpace(fields[1]),
+				Explanation:    strings.TrimSpace(fields[2]),
+				Recommendation: strings.TrimSpace(fields[3]),
+			})
+		}
+	}
+	return
+}
@@ -0,0 +1,96 @@
+package curation
+
+import (
+	"testing"
+
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPackagesFromTree(t *testing.T) {
+	shared := &xrayUtils.GraphNode{Id: "gav://org.slf4j:slf4j-api:2.0.9"}
+	root := &xrayUtils.GraphNode{Id: "gav://org.example:app:1.0.0", Nodes: []*xrayUtils.GraphNode{
+		{Id: "gav://ch.qos.logback:logback-classic:1.4.11", Nodes: []*xrayUtils.GraphNode{shared}},
+		{Id: "gav://org.apache.logging.log4j:log4j-slf4j2-impl:2.21.0", Nodes: []*xrayUtils.GraphNode{shared}},
+	}}
+	// The trees of some package managers have cycles.
+	shared.Nodes = []*xrayUtils.GraphNode{root.Nodes[0]}
+
+	packages, err := GetPackagesFromTree(root)
+	require.NoError(t, err)
+	require.Len(t, packages, 3)
+	assert.Equal(t, &Package{Type: Gradle, Name: "ch.qos.logback:logback-classic", Version: "1.4.11", DirectDependencies: []string{"ch.qos.logback:logback-classic:1.4.11", "org.apache.logging.log4j:log4j-slf4j2-impl:2.21.0"}}, packages[0])
+	assert.Equal(t, &Package{Type: Gradle, Name: "org.slf4j:slf4j-api", Version: "2.0.9", DirectDependencies: []string{"ch.qos.logback:logback-classic:1.4.11", "org.apache.logging.log4j:log4j-slf4j2-impl:2.21.0"}}, packages[1])
+
+	_, err = GetPackagesFromTree(&xrayUtils.GraphNode{Nodes: []*xrayUtils.GraphNode{{Id: "npm://lodash:4.17.20"}}})
+	assert.Error(t, err)
+}
+
+func TestParseComponentId(t *testing.T) {
+	tests := []struct {
+		componentId string
+		expected    *Package
+	}{
+		{"go://github.com/BurntSushi/toml:v1.4.0", &Package{Type: Go, Name: "github.com/BurntSushi/toml", Version: "v1.4.0"}},
+		{"nuget://Newtonsoft.Json:13.0.3", &Package{Type: Nuget, Name: "Newtonsoft.Json", Version: "13.0.3"}},
+		{"gav://org.slf4j:slf4j-api:2.0.9", &Package{Type: Gradle, Name: "org.slf4j:slf4j-api", Version: "2.0.9"}},
+		{"gav://slf4j-api:2.0.9", nil},
+		{"nuget://Newtonsoft.Json", nil},
+		{"pypi://requests:2.31.0", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.componentId, func(t *testing.T) {
+			curationPackage, err := parseComponentId(test.componentId)
+			if test.expected == nil {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, curationPackage)
+		})
+	}
+}
+
+func TestGetPassThroughUrl(t *testing.T) {
+	tests := []struct {
+		curationPackage *Package
+		expected        string
+	}{
+		{&Package{Type: Go, Name: "github.com/BurntSushi/toml", Version: "v1.4.0"}, "https://acme.jfrog.io/artifactory/api/curation/audit/api/go/go-remote/github.com/!burnt!sushi/toml/@v/v1.4.0.zip"},
+		{&Package{Type: Nuget, Name: "Newtonsoft.Json", Version: "13.0.3-Beta"}, "https://acme.jfrog.io/artifactory/api/curation/audit/api/nuget/v3/nuget-remote/flatcontainer/newtonsoft.json/13.0.3-beta/newtonsoft.json.13.0.3-beta.nupkg"},
+		{&Package{Type: Gradle, Name: "org.slf4j:slf4j-api", Version: "2.0.9"}, "https://acme.jfrog.io/artifactory/api/curation/audit/gradle-remote/org/slf4j/slf4j-api/2.0.9/slf4j-api-2.0.9.pom"},
+	}
+	repositories := map[PackageType]string{Go: "go-remote", Nuget: "nuget-remote", Gradle: "gradle-remote"}
+	for _, test := range tests {
+		t.Run(string(test.curationPackage.Type), func(t *testing.T) {
+			passThroughUrl, err := GetPassThroughUrl("https://acme.jfrog.io/artifactory/", repositories[test.curationPackage.Type], test.curationPackage)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, passThroughUrl)
+		})
+	}
+}
+
+func TestParseViolatedPolicies(t *testing.T) {
+	body := []byte(`{"errors":[{"status":403,"message":"Package slf4j-api:2.0.9 download was blocked by JFrog Packages Curation service due to the following policies violated {Malicious, Malicious package, Package is malicious, Remove the package}, {Aged, Package age, Package is too new}."}]}`)
+	assert.Equal(t, []Policy{
+		{Policy: "Malicious", Condition: "Malicious package", Explanation: "Package is malicious", Recommendation: "Remove the package"},
+		{Policy: "Aged", Condition: "Package age", Explanation: "Package is too new"},
+	}, ParseViolatedPolicies(body))
+	assert.Empty(t, ParseViolatedPolicies([]byte(`{"errors":[{"status":403,"message":"Forbidden"}]}`)))
+}
+
+func TestReportString(t *testing.T) {
+	report := &Report{Checked: 3}
+	assert.Equal(t, "None of the 3 packages is blocked by the curation policies.", report.String())
+	report.Blocked = []BlockedPackage{
+		{Package: Package{Type: Nuget, Name: "Newtonsoft.Json", Version: "13.0.3", DirectDependencies: []string{"Newtonsoft.Json:13.0.3"}}, Policies: []Policy{{Policy: "Aged", Condition: "Package age"}}},
+		{Package: Package{Type: Go, Name: "github.com/gin-gonic/gin", Version: "v1.9.0", DirectDependencies: []string{"github.com/gin-gonic/gin:v1.9.0"}}, Policies: []Policy{{Policy: "CVE", Condition: "Critical CVE", Recommendation: "Upgrade to v1.9.1"}}},
+	}
+	assert.Equal(t, `2 of the 3 packages are blocked by the curation policies:
+- [go] github.com/gin-gonic/gin:v1.9.0 (direct dependencies: github.com/gin-gonic/gin:v1.9.0)
+    Policy: CVE, Condition: Critical CVE, Recommendation: Upgrade to v1.9.1
+- [nuget] Newtonsoft.Json:13.0.3 (direct dependencies: Newtonsoft.Json:13.0.3)
+    Policy: Aged, Condition: Package age
+`, report.String())
+}
@@ -9,6 +9,12 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
+type DeleteConfiguration struct {
+	Threads int
+	// Log the paths which would have been deleted, without deleting them.
+	DryRun bool
+}
+
 func ConfirmDelete(pathsToDeleteReader *content.ContentReader) (bool, error) {
 	length, err := pathsToDeleteReader.Length()
 	if err != nil || length < 1 {
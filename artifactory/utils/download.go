@@ -11,11 +11,30 @@ func CreateDownloadServiceManager(artDetails *config.ServerDetails, threads, htt
 }
 
 type DownloadConfiguration struct {
-	Threads    int
+	Threads int
+	// The parts of a split download are downloaded by jfrog-client-go into a temp dir which is removed when the download ends,
+	// so an interrupted split download restarts from the beginning.
 	SplitCount int
 	// Min split size in Kilobytes
 	MinSplitSize    int64
 	Symlink         bool
 	ValidateSymlink bool
 	SkipChecksum    bool
+	// The checksum algorithm used to verify the downloaded files. An empty value is equivalent to ChecksumAlgorithmAll.
+	ChecksumAlgorithm ChecksumAlgorithm
+	// The max aggregate bandwidth of all the threads and splits, in bytes per second. Zero means no limit.
+	RateLimit int64
+	// Optional. Overrides the command's retries and retry wait time.
+	RetryPolicy *RetryPolicy
+	// Log the files which would have been downloaded, without downloading them.
+	DryRun bool
+	// How the failure of some of the files is handled. An empty value is equivalent to OnErrorContinue.
+	OnError OnErrorPolicy
+	// Stream a single file to stdout, instead of writing it to the disk.
+	ToStdout bool
+}
+
+// Returns true if the downloaded files should be verified by a single checksum algorithm, instead of by the download service.
+func (dc *DownloadConfiguration) IsSelectiveChecksumVerification() bool {
+	return !dc.SkipChecksum && dc.ChecksumAlgorithm.IsSelective()
 }
@@ -0,0 +1,8 @@
+package utils
+
+// The configuration of the move and copy commands.
+type MoveCopyConfiguration struct {
+	Threads int
+	// Log the paths which would have been moved or copied, with their target paths, without moving or copying them.
+	DryRun bool
+}
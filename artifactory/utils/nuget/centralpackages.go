@@ -0,0 +1,87 @@
+package nuget
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// The file which manages the versions of the packages of all the projects under its directory, when central package management is enabled.
+const CentralPackagesFileName = "Directory.Packages.props"
+
+type centralPackagesProject struct {
+	PropertyGroups []struct {
+		ManagePackageVersionsCentrally string `xml:"ManagePackageVersionsCentrally"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		PackageVersions []struct {
+			Include string `xml:"Include,attr"`
+			Version string `xml:"Version,attr"`
+		} `xml:"PackageVersion"`
+	} `xml:"ItemGroup"`
+}
+
+// Returns the central versions of the packages of the project by their lowercase names, from the Directory.Packages.props file closest to the project directory.
+// Like MSBuild, the file is searched from the project directory up to the root directory, and an empty map is returned
+// if the project doesn't use central package management.
+func ReadCentralPackageVersions(projectDir, rootDir string) (map[string]string, error) {
+	propsPath, err := findCentralPackagesFile(projectDir, rootDir)
+	if err != nil || propsPath == "" {
+		return map[string]string{}, err
+	}
+	content, err := os.ReadFile(propsPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseCentralPackageVersions(content)
+}
+
+func ParseCentralPackageVersions(content []byte) (map[string]string, error) {
+	project := new(centralPackagesProject)
+	if err := xml.Unmarshal(content, project); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", CentralPackagesFileName, err.Error())
+	}
+	versions := map[string]string{}
+	enabled := false
+	for _, propertyGroup := range project.PropertyGroups {
+		if value := strings.TrimSpace(propertyGroup.ManagePackageVersionsCentrally); value != "" {
+			enabled = strings.EqualFold(value, "true")
+		}
+	}
+	if !enabled {
+		return versions, nil
+	}
+	for _, itemGroup := range project.ItemGroups {
+		for _, packageVersion := range itemGroup.PackageVersions {
+			if packageVersion.Include != "" && packageVersion.Version != "" {
+				versions[strings.ToLower(packageVersion.Include)] = strings.TrimSpace(packageVersion.Version)
+			}
+		}
+	}
+	return versions, nil
+}
+
+func findCentralPackagesFile(projectDir, rootDir string) (string, error) {
+	projectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	rootDir, err = filepath.Abs(rootDir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	for dir := projectDir; ; dir = filepath.Dir(dir) {
+		propsPath := filepath.Join(dir, CentralPackagesFileName)
+		exists, err := fileutils.IsFileExists(propsPath, false)
+		if err != nil || exists {
+			return propsPath, err
+		}
+		if dir == rootDir || dir == filepath.Dir(dir) {
+			return "", nil
+		}
+	}
+}
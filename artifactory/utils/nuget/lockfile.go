@@ -0,0 +1,336 @@
+package nuget
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+const (
+	LockfileName = "packages.lock.json"
+	// The prefix of the IDs of NuGet components in Xray.
+	NugetPackageTypeIdentifier = "nuget://"
+	// The environment variable which overrides the location of the global packages folder of NuGet.
+	globalPackagesFolderEnv = "NUGET_PACKAGES"
+)
+
+// The types of the dependencies in packages.lock.json.
+const (
+	directDependency             = "Direct"
+	centralTransitiveDependency  = "CentralTransitive"
+	projectReferenceDependency   = "Project"
+	nupkgContentHashFileSuffix   = ".nupkg.sha512"
+	nupkgFileSuffix              = ".nupkg"
+	projectFileExtensionsPattern = "*.*proj"
+)
+
+// The content of packages.lock.json. The dependencies of the project are listed by the target frameworks of the project.
+type Lockfile struct {
+	Version      int                                      `json:"version"`
+	Dependencies map[string]map[string]LockfileDependency `json:"dependencies"`
+}
+
+type LockfileDependency struct {
+	Type string `json:"type"`
+	// The version range the project requested, for direct dependencies and dependencies which are pinned centrally.
+	Requested string `json:"requested,omitempty"`
+	Resolved  string `json:"resolved,omitempty"`
+	// The base64 encoded sha512 checksum of the nupkg file of the package.
+	ContentHash  string            `json:"contentHash,omitempty"`
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+// A package of the lock file, with the frameworks-independent details the dependency graph is built from.
+type Package struct {
+	Name         string
+	Version      string
+	ContentHash  string
+	Direct       bool
+	Dependencies []string
+}
+
+func (p *Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+func ReadLockfile(projectDir string) (*Lockfile, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, LockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(content)
+}
+
+func ParseLockfile(content []byte) (*Lockfile, error) {
+	lockfile := new(Lockfile)
+	if err := json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	return lockfile, nil
+}
+
+// Returns the packages of all the target frameworks of the project, by their lowercase names, which is how NuGet compares package IDs.
+// Project references aren't packages, so they aren't returned. Their own dependencies are listed in the lock file as transitive dependencies.
+func (lockfile *Lockfile) GetPackages() map[string]*Package {
+	packages := map[string]*Package{}
+	for _, framework := range slices.Sorted(maps.Keys(lockfile.Dependencies)) {
+		for name, dependency := range lockfile.Dependencies[framework] {
+			if dependency.Type == projectReferenceDependency || dependency.Resolved == "" {
+				continue
+			}
+			key := strings.ToLower(name)
+			nugetPackage, exists := packages[key]
+			if !exists {
+				nugetPackage = &Package{Name: name, Version: dependency.Resolved, ContentHash: dependency.ContentHash}
+				packages[key] = nugetPackage
+			}
+			nugetPackage.Direct = nugetPackage.Direct || dependency.Type == directDependency
+			for childName := range dependency.Dependencies {
+				if childKey := strings.ToLower(childName); !slices.Contains(nugetPackage.Dependencies, childKey) {
+					nugetPackage.Dependencies = append(nugetPackage.Dependencies, childKey)
+				}
+			}
+			slices.Sort(nugetPackage.Dependencies)
+		}
+	}
+	return packages
+}
+
+// Verify that the versions the project requests directly or pins centrally match the versions of Directory.Packages.props.
+// A mismatch means the lock file was created before the central versions changed, so restoring it isn't reproducible.
+func (lockfile *Lockfile) VerifyCentralPackageVersions(centralVersions map[string]string) error {
+	if len(centralVersions) == 0 {
+		return nil
+	}
+	var staleDependencies []string
+	for _, framework := range slices.Sorted(maps.Keys(lockfile.Dependencies)) {
+		for name, dependency := range lockfile.Dependencies[framework] {
+			if dependency.Type != directDependency && dependency.Type != centralTransitiveDependency {
+				continue
+			}
+			centralVersion, exists := centralVersions[strings.ToLower(name)]
+			if exists && !isRequestedVersion(dependency.Requested, centralVersion) {
+				staleDependencies = append(staleDependencies, name+" ("+framework+"): "+dependency.Requested+" is locked, but "+centralVersion+" is set centrally")
+			}
+		}
+	}
+	if len(staleDependencies) > 0 {
+		slices.Sort(staleDependencies)
+		return errorutils.CheckErrorf("%s doesn't match the versions of %s:\n%s\nRestore with the '--force-evaluate' option to update it",
+			LockfileName, CentralPackagesFileName, strings.Join(staleDependencies, "\n"))
+	}
+	return nil
+}
+
+// NuGet locks a version such as '1.2.3' as the range '[1.2.3, )', and locks ranges as they are.
+func isRequestedVersion(requested, version string) bool {
+	if strings.HasPrefix(version, "[") || strings.HasPrefix(version, "(") {
+		return strings.ReplaceAll(requested, " ", "") == strings.ReplaceAll(version, " ", "")
+	}
+	return strings.EqualFold(requested, "["+version+", )")
+}
+
+// Returns the dependencies of the project, with the dependency paths they were requested by.
+// The checksums of the dependencies are taken from the nupkg files in the global packages folder of NuGet,
+// after verifying that they match the content hashes of the lock file. Dependencies which aren't in the global packages folder are skipped.
+func (lockfile *Lockfile) GetDependencies(moduleId, globalPackagesFolder string) ([]buildinfo.Dependency, error) {
+	packages := lockfile.GetPackages()
+	dependencies := map[string]*buildinfo.Dependency{}
+	var missingPackages []string
+	for key, nugetPackage := range packages {
+		checksum, err := getNupkgChecksum(globalPackagesFolder, nugetPackage)
+		if err != nil {
+			return nil, err
+		}
+		if checksum == nil {
+			missingPackages = append(missingPackages, nugetPackage.Id())
+			continue
+		}
+		dependencies[key] = &buildinfo.Dependency{Id: nugetPackage.Id(), Checksum: *checksum}
+	}
+	if len(missingPackages) > 0 {
+		slices.Sort(missingPackages)
+		log.Warn(strings.Join(missingPackages, "\n"), "\nThe nupkg files of the packages above weren't found in the global packages folder of NuGet, therefore they are not included in the build-info.")
+	}
+	for _, key := range getDirectPackages(packages) {
+		addRequestedBy(key, []string{moduleId}, packages, dependencies)
+	}
+	result := make([]buildinfo.Dependency, 0, len(dependencies))
+	for _, key := range slices.Sorted(maps.Keys(dependencies)) {
+		if len(dependencies[key].RequestedBy) > 0 {
+			result = append(result, *dependencies[key])
+		}
+	}
+	return result, nil
+}
+
+// Every dependency is recorded with one path for each of its direct parents, and the dependencies of each package are traversed once.
+func addRequestedBy(key string, requestedBy []string, packages map[string]*Package, dependencies map[string]*buildinfo.Dependency) {
+	nugetPackage, dependency := packages[key], dependencies[key]
+	if nugetPackage == nil || slices.Contains(requestedBy, nugetPackage.Id()) {
+		return
+	}
+	if dependency != nil {
+		if slices.ContainsFunc(dependency.RequestedBy, func(path []string) bool { return path[0] == requestedBy[0] }) {
+			return
+		}
+		dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+		if len(dependency.RequestedBy) > 1 {
+			return
+		}
+	}
+	for _, childKey := range nugetPackage.Dependencies {
+		addRequestedBy(childKey, append([]string{nugetPackage.Id()}, requestedBy...), packages, dependencies)
+	}
+}
+
+func getDirectPackages(packages map[string]*Package) (directPackages []string) {
+	for key, nugetPackage := range packages {
+		if nugetPackage.Direct {
+			directPackages = append(directPackages, key)
+		}
+	}
+	slices.Sort(directPackages)
+	return
+}
+
+// Returns the checksums of the nupkg file of the package in the global packages folder, or nil if it isn't there.
+// The global packages folder holds the packages by their lowercase names and versions.
+func getNupkgChecksum(globalPackagesFolder string, nugetPackage *Package) (*buildinfo.Checksum, error) {
+	name, version := strings.ToLower(nugetPackage.Name), strings.ToLower(nugetPackage.Version)
+	packageDir := filepath.Join(globalPackagesFolder, name, version)
+	nupkgPath := filepath.Join(packageDir, name+"."+version+nupkgFileSuffix)
+	exists, err := fileutils.IsFileExists(nupkgPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	if nugetPackage.ContentHash != "" {
+		contentHash, err := os.ReadFile(filepath.Join(packageDir, name+"."+version+nupkgContentHashFileSuffix))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errorutils.CheckError(err)
+		}
+		if err == nil && strings.TrimSpace(string(contentHash)) != nugetPackage.ContentHash {
+			return nil, errorutils.CheckErrorf("the content hash of %s in the global packages folder doesn't match the content hash in %s", nugetPackage.Id(), LockfileName)
+		}
+	}
+	fileDetails, err := fileutils.GetFileDetails(nupkgPath, true)
+	if err != nil {
+		return nil, err
+	}
+	return &buildinfo.Checksum{Sha1: fileDetails.Checksum.Sha1, Md5: fileDetails.Checksum.Md5, Sha256: fileDetails.Checksum.Sha256}, nil
+}
+
+// Returns the dependency tree of the project for auditing.
+// The dependencies of each package are expanded once per branch, so cyclic dependencies don't expand endlessly.
+func (lockfile *Lockfile) GetDependencyTree(moduleId string) *xrayUtils.GraphNode {
+	packages := lockfile.GetPackages()
+	root := &xrayUtils.GraphNode{Id: NugetPackageTypeIdentifier + moduleId, Nodes: []*xrayUtils.GraphNode{}}
+	addTreeNodes(root, getDirectPackages(packages), packages)
+	return root
+}
+
+func addTreeNodes(parent *xrayUtils.GraphNode, keys []string, packages map[string]*Package) {
+	for _, key := range keys {
+		nugetPackage, found := packages[key]
+		if !found {
+			continue
+		}
+		node := &xrayUtils.GraphNode{Id: NugetPackageTypeIdentifier + nugetPackage.Id(), Parent: parent, Nodes: []*xrayUtils.GraphNode{}}
+		parent.Nodes = append(parent.Nodes, node)
+		if node.NodeHasLoop() {
+			continue
+		}
+		addTreeNodes(node, nugetPackage.Dependencies, packages)
+	}
+}
+
+// Returns the build-info modules of the projects under the root directory, built from their lock files.
+// The lock files are verified against the central package versions of the projects.
+// If a custom module name is provided, it is used as the ID of all the modules, like the build-info of the restore outputs.
+func GetLockfileModules(rootDir, customModuleId, globalPackagesFolder string) ([]buildinfo.Module, error) {
+	projectDirs, err := FindLockfileProjects(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(projectDirs) == 0 {
+		return nil, errorutils.CheckErrorf("no %s files were found in %s. Enable the lock files of the projects by setting the 'RestorePackagesWithLockFile' property", LockfileName, rootDir)
+	}
+	var modules []buildinfo.Module
+	for _, projectDir := range projectDirs {
+		lockfile, err := ReadLockfile(projectDir)
+		if err != nil {
+			return nil, err
+		}
+		centralVersions, err := ReadCentralPackageVersions(projectDir, rootDir)
+		if err != nil {
+			return nil, err
+		}
+		if err = lockfile.VerifyCentralPackageVersions(centralVersions); err != nil {
+			return nil, err
+		}
+		module := buildinfo.Module{Id: GetProjectName(projectDir), Type: buildinfo.Nuget}
+		if customModuleId != "" {
+			module.Id = customModuleId
+		}
+		if module.Dependencies, err = lockfile.GetDependencies(module.Id, globalPackagesFolder); err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// Returns the global packages folder of NuGet, which NuGet extracts the restored packages to.
+func GetGlobalPackagesFolder() (string, error) {
+	if globalPackagesFolder := os.Getenv(globalPackagesFolderEnv); globalPackagesFolder != "" {
+		return globalPackagesFolder, nil
+	}
+	homeDir := fileutils.GetHomeDir()
+	if homeDir == "" {
+		return "", errorutils.CheckErrorf("couldn't find the global packages folder of NuGet. Set the %s environment variable to its location", globalPackagesFolderEnv)
+	}
+	return filepath.Join(homeDir, ".nuget", "packages"), nil
+}
+
+// Returns the directories of the projects under the root directory which have lock files.
+// The build outputs and hidden directories are skipped.
+func FindLockfileProjects(rootDir string) (projectDirs []string, err error) {
+	err = filepath.WalkDir(rootDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != rootDir && (entry.Name() == "bin" || entry.Name() == "obj" || strings.HasPrefix(entry.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() == LockfileName {
+			projectDirs = append(projectDirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	return projectDirs, errorutils.CheckError(err)
+}
+
+// Returns the name of the project in the directory, which is the name of its project file, or the name of the directory if it has none.
+func GetProjectName(projectDir string) string {
+	projectFiles, err := filepath.Glob(filepath.Join(projectDir, projectFileExtensionsPattern))
+	if err == nil {
+		for _, projectFile := range projectFiles {
+			if strings.HasSuffix(projectFile, "proj") {
+				return strings.TrimSuffix(filepath.Base(projectFile), filepath.Ext(projectFile))
+			}
+		}
+	}
+	return filepath.Base(projectDir)
+}
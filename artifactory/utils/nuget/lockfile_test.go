@@ -0,0 +1,174 @@
+package nuget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockfile = `{
+  "version": 2,
+  "dependencies": {
+    "net6.0": {
+      "Newtonsoft.Json": {
+        "type": "Direct",
+        "requested": "[13.0.3, )",
+        "resolved": "13.0.3",
+        "contentHash": "json-hash"
+      },
+      "Serilog.Sinks.File": {
+        "type": "Direct",
+        "requested": "[5.0.0, )",
+        "resolved": "5.0.0",
+        "contentHash": "sink-hash",
+        "dependencies": {
+          "Serilog": "2.10.0"
+        }
+      },
+      "Serilog": {
+        "type": "CentralTransitive",
+        "requested": "[2.12.0, )",
+        "resolved": "2.12.0",
+        "contentHash": "serilog-hash"
+      },
+      "common": {
+        "type": "Project",
+        "dependencies": {
+          "Newtonsoft.Json": "[13.0.3, )"
+        }
+      }
+    },
+    "net8.0": {
+      "Newtonsoft.Json": {
+        "type": "Direct",
+        "requested": "[13.0.3, )",
+        "resolved": "13.0.3",
+        "contentHash": "json-hash"
+      }
+    }
+  }
+}`
+
+const testCentralPackages = `<Project>
+  <PropertyGroup>
+    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Include="Newtonsoft.Json" Version="13.0.3" />
+    <PackageVersion Include="Serilog.Sinks.File" Version="5.0.0" />
+    <PackageVersion Include="Serilog" Version="2.12.0" />
+  </ItemGroup>
+</Project>`
+
+func TestGetPackages(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	packages := lockfile.GetPackages()
+	require.Len(t, packages, 3)
+	assert.Equal(t, &Package{Name: "Newtonsoft.Json", Version: "13.0.3", ContentHash: "json-hash", Direct: true}, packages["newtonsoft.json"])
+	assert.Equal(t, []string{"serilog"}, packages["serilog.sinks.file"].Dependencies)
+	assert.False(t, packages["serilog"].Direct)
+}
+
+func TestVerifyCentralPackageVersions(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	centralVersions, err := ParseCentralPackageVersions([]byte(testCentralPackages))
+	require.NoError(t, err)
+	assert.NoError(t, lockfile.VerifyCentralPackageVersions(centralVersions))
+
+	centralVersions["serilog"] = "3.0.0"
+	err = lockfile.VerifyCentralPackageVersions(centralVersions)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Serilog (net6.0): [2.12.0, ) is locked, but 3.0.0 is set centrally")
+
+	// Ranges are locked as they are.
+	centralVersions["serilog"] = "[2.12.0,3.0.0)"
+	lockfile.Dependencies["net6.0"]["Serilog"] = LockfileDependency{Type: centralTransitiveDependency, Requested: "[2.12.0, 3.0.0)", Resolved: "2.12.0"}
+	assert.NoError(t, lockfile.VerifyCentralPackageVersions(centralVersions))
+}
+
+func TestParseCentralPackageVersionsDisabled(t *testing.T) {
+	centralVersions, err := ParseCentralPackageVersions([]byte(`<Project><ItemGroup><PackageVersion Include="Serilog" Version="2.12.0" /></ItemGroup></Project>`))
+	require.NoError(t, err)
+	assert.Empty(t, centralVersions)
+}
+
+func TestReadCentralPackageVersions(t *testing.T) {
+	rootDir := t.TempDir()
+	projectDir := filepath.Join(rootDir, "src", "app")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+	centralVersions, err := ReadCentralPackageVersions(projectDir, rootDir)
+	require.NoError(t, err)
+	assert.Empty(t, centralVersions)
+
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, CentralPackagesFileName), []byte(testCentralPackages), 0644))
+	centralVersions, err = ReadCentralPackageVersions(projectDir, rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, "13.0.3", centralVersions["newtonsoft.json"])
+}
+
+func TestGetDependencies(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	globalPackagesFolder := t.TempDir()
+	for _, nupkg := range []struct{ name, version, contentHash string }{
+		{"newtonsoft.json", "13.0.3", "json-hash"},
+		{"serilog.sinks.file", "5.0.0", "sink-hash"},
+		{"serilog", "2.12.0", "serilog-hash"},
+	} {
+		packageDir := filepath.Join(globalPackagesFolder, nupkg.name, nupkg.version)
+		require.NoError(t, os.MkdirAll(packageDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, nupkg.name+"."+nupkg.version+nupkgFileSuffix), []byte(nupkg.name), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(packageDir, nupkg.name+"."+nupkg.version+nupkgContentHashFileSuffix), []byte(nupkg.contentHash), 0644))
+	}
+
+	dependencies, err := lockfile.GetDependencies("app", globalPackagesFolder)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 3)
+	assert.Equal(t, "Newtonsoft.Json:13.0.3", dependencies[0].Id)
+	assert.Equal(t, [][]string{{"app"}}, dependencies[0].RequestedBy)
+	assert.NotEmpty(t, dependencies[0].Sha1)
+	assert.Equal(t, "Serilog:2.12.0", dependencies[1].Id)
+	assert.Equal(t, [][]string{{"Serilog.Sinks.File:5.0.0", "app"}}, dependencies[1].RequestedBy)
+
+	// A modified package in the global packages folder fails the build-info collection.
+	require.NoError(t, os.WriteFile(filepath.Join(globalPackagesFolder, "serilog", "2.12.0", "serilog.2.12.0"+nupkgContentHashFileSuffix), []byte("other-hash"), 0644))
+	_, err = lockfile.GetDependencies("app", globalPackagesFolder)
+	assert.Error(t, err)
+}
+
+func TestGetDependencyTree(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	tree := lockfile.GetDependencyTree("app")
+	assert.Equal(t, "nuget://app", tree.Id)
+	require.Len(t, tree.Nodes, 2)
+	assert.Equal(t, "nuget://Newtonsoft.Json:13.0.3", tree.Nodes[0].Id)
+	require.Len(t, tree.Nodes[1].Nodes, 1)
+	assert.Equal(t, "nuget://Serilog:2.12.0", tree.Nodes[1].Nodes[0].Id)
+}
+
+func TestGetLockfileModules(t *testing.T) {
+	rootDir := t.TempDir()
+	for _, project := range []string{"app", filepath.Join("app", "bin"), "lib"} {
+		projectDir := filepath.Join(rootDir, project)
+		require.NoError(t, os.MkdirAll(projectDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, LockfileName), []byte(`{"version": 2, "dependencies": {}}`), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "app", "App.csproj"), []byte("<Project/>"), 0644))
+
+	modules, err := GetLockfileModules(rootDir, "", t.TempDir())
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+	assert.Equal(t, "App", modules[0].Id)
+	assert.Equal(t, buildinfo.Nuget, modules[0].Type)
+	assert.Equal(t, "lib", modules[1].Id)
+
+	_, err = GetLockfileModules(t.TempDir(), "", t.TempDir())
+	assert.Error(t, err)
+}
@@ -0,0 +1,261 @@
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	MediaTypeImageManifest  = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeImageIndex     = "application/vnd.oci.image.index.v1+json"
+	MediaTypeEmptyJson      = "application/vnd.oci.empty.v1+json"
+	MediaTypeDefaultLayer   = "application/vnd.oci.image.layer.v1.tar"
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// The annotation holding the file name of a layer.
+	AnnotationTitle = "org.opencontainers.image.title"
+
+	contentDigestHeader = "Docker-Content-Digest"
+)
+
+// The content of an empty JSON config, as defined by the OCI image spec.
+var emptyJsonConfig = []byte("{}")
+
+// OCI content descriptor.
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// OCI image manifest, which describes an OCI artifact when its artifact type is set.
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Subject       *Descriptor       `json:"subject,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// Returns the descriptor of the empty JSON config, used by OCI artifacts which have no config.
+func EmptyConfigDescriptor() Descriptor {
+	return Descriptor{MediaType: MediaTypeEmptyJson, Digest: ContentDigest(emptyJsonConfig), Size: int64(len(emptyJsonConfig))}
+}
+
+func ContentDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Returns the file name of a layer, which is its title annotation, or its digest if the title is missing.
+func (descriptor Descriptor) FileName() string {
+	// The title is set by the pushing client, and therefore can't point outside the target directory.
+	if title := filepath.Base(descriptor.Annotations[AnnotationTitle]); title != "." && title != ".." && title != string(filepath.Separator) {
+		return title
+	}
+	return strings.Replace(descriptor.Digest, ":", "_", 1)
+}
+
+// Client of the OCI distribution API of an Artifactory OCI or Docker repository, for a single image (repository in OCI terms).
+type Client struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	repo            string
+	image           string
+}
+
+func NewClient(servicesManager artifactory.ArtifactoryServicesManager, repo, image string) *Client {
+	return &Client{servicesManager: servicesManager, repo: repo, image: image}
+}
+
+func (client *Client) getUrl(endpoint string) string {
+	return client.servicesManager.GetConfig().GetServiceDetails().GetUrl() + "api/docker/" + client.repo + "/v2/" + client.image + "/" + endpoint
+}
+
+func (client *Client) createHttpClientDetails() httputils.HttpClientDetails {
+	return client.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+}
+
+// Returns true if the blob already exists in the repository.
+func (client *Client) BlobExists(digest string) (bool, error) {
+	clientDetails := client.createHttpClientDetails()
+	resp, body, err := client.servicesManager.Client().SendHead(client.getUrl("blobs/"+digest), &clientDetails)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return true, errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+// Upload a blob monolithically. The blob is either the content of the local file, or the content argument if the file path is empty.
+// Blobs which already exist in the repository aren't uploaded again.
+func (client *Client) UploadBlob(localPath string, content []byte, digest string) error {
+	exists, err := client.BlobExists(digest)
+	if err != nil || exists {
+		return err
+	}
+	clientDetails := client.createHttpClientDetails()
+	resp, body, err := client.servicesManager.Client().SendPost(client.getUrl("blobs/uploads/"), nil, &clientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusAccepted); err != nil {
+		return err
+	}
+	uploadUrl, err := resolveUploadUrl(client.getUrl("blobs/uploads/"), resp.Header.Get("Location"), digest)
+	if err != nil {
+		return err
+	}
+	clientDetails = client.createHttpClientDetails()
+	clientDetails.Headers["Content-Type"] = "application/octet-stream"
+	if localPath != "" {
+		resp, body, err = client.servicesManager.Client().UploadFile(localPath, uploadUrl, "", &clientDetails, nil)
+	} else {
+		resp, body, err = client.servicesManager.Client().SendPut(uploadUrl, content, &clientDetails)
+	}
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated)
+}
+
+// The location of an upload may be relative to the registry, and may already have query parameters.
+func resolveUploadUrl(requestUrl, location, digest string) (string, error) {
+	if location == "" {
+		return "", errorutils.CheckErrorf("the registry didn't return the location of the blob upload")
+	}
+	base, err := url.Parse(requestUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	resolved, err := base.Parse(location)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	query := resolved.Query()
+	query.Set("digest", digest)
+	resolved.RawQuery = query.Encode()
+	return resolved.String(), nil
+}
+
+// Upload the empty JSON config, and return its descriptor.
+func (client *Client) UploadEmptyConfig() (Descriptor, error) {
+	config := EmptyConfigDescriptor()
+	return config, client.UploadBlob("", emptyJsonConfig, config.Digest)
+}
+
+// Put the manifest under the reference, which is a tag. Returns the digest of the manifest.
+func (client *Client) PutManifest(reference string, manifest *Manifest) (string, []byte, error) {
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	clientDetails := client.createHttpClientDetails()
+	clientDetails.Headers["Content-Type"] = manifest.MediaType
+	resp, body, err := client.servicesManager.Client().SendPut(client.getUrl("manifests/"+reference), content, &clientDetails)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated, http.StatusOK); err != nil {
+		return "", nil, err
+	}
+	digest := ContentDigest(content)
+	if headerDigest := resp.Header.Get(contentDigestHeader); headerDigest != "" && headerDigest != digest {
+		log.Debug(fmt.Sprintf("The registry reported the manifest digest %s, while its content digest is %s", headerDigest, digest))
+	}
+	return digest, content, nil
+}
+
+// Get the manifest of the reference, which is a tag or a digest. Returns the manifest, its digest and its content.
+func (client *Client) GetManifest(reference string) (*Manifest, string, []byte, error) {
+	clientDetails := client.createHttpClientDetails()
+	clientDetails.Headers["Accept"] = strings.Join([]string{MediaTypeImageManifest, MediaTypeDockerManifest}, ", ")
+	resp, body, _, err := client.servicesManager.Client().SendGet(client.getUrl("manifests/"+reference), true, &clientDetails)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, "", nil, err
+	}
+	digest := ContentDigest(body)
+	if strings.HasPrefix(reference, "sha256:") && reference != digest {
+		return nil, "", nil, errorutils.CheckErrorf("the digest of the manifest '%s' is %s", reference, digest)
+	}
+	manifest := new(Manifest)
+	if err = errorutils.CheckError(json.Unmarshal(body, manifest)); err != nil {
+		return nil, "", nil, err
+	}
+	if manifest.MediaType == MediaTypeImageIndex {
+		return nil, "", nil, errorutils.CheckErrorf("'%s' is an image index. Pull one of its manifests by its digest instead", reference)
+	}
+	return manifest, digest, body, nil
+}
+
+// Download the blob into the local directory, by its file name, and verify its digest. Returns the path of the downloaded file.
+func (client *Client) DownloadBlob(descriptor Descriptor, localDir string) (string, *fileutils.FileDetails, error) {
+	clientDetails := client.createHttpClientDetails()
+	downloadDetails := &httpclient.DownloadFileDetails{
+		DownloadPath:  client.getUrl("blobs/" + descriptor.Digest),
+		LocalPath:     localDir,
+		LocalFileName: descriptor.FileName(),
+		FileName:      descriptor.FileName(),
+		Size:          descriptor.Size,
+		SkipChecksum:  true,
+	}
+	resp, err := client.servicesManager.Client().DownloadFile(downloadDetails, "", &clientDetails, false, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return "", nil, err
+	}
+	localPath := filepath.Join(localDir, descriptor.FileName())
+	details, err := fileutils.GetFileDetails(localPath, true)
+	if err != nil {
+		return "", nil, err
+	}
+	if "sha256:"+details.Checksum.Sha256 != descriptor.Digest {
+		return "", nil, errorutils.CheckErrorf("the digest of the downloaded file '%s' is sha256:%s, while the expected digest is %s", localPath, details.Checksum.Sha256, descriptor.Digest)
+	}
+	return localPath, details, nil
+}
+
+// Create the descriptor of a local file, to be uploaded as a layer.
+func CreateFileDescriptor(localPath, mediaType string) (Descriptor, *fileutils.FileDetails, error) {
+	details, err := fileutils.GetFileDetails(localPath, true)
+	if err != nil {
+		return Descriptor{}, nil, err
+	}
+	if mediaType == "" {
+		mediaType = MediaTypeDefaultLayer
+	}
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return Descriptor{}, nil, errorutils.CheckError(err)
+	}
+	return Descriptor{
+		MediaType:   mediaType,
+		Digest:      "sha256:" + details.Checksum.Sha256,
+		Size:        details.Size,
+		Annotations: map[string]string{AnnotationTitle: info.Name()},
+	}, details, nil
+}
@@ -0,0 +1,127 @@
+package oci
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A minimal OCI registry, serving a single image of the 'oci-local' repository.
+type testRegistry struct {
+	mutex     sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+}
+
+func (registry *testRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	endpoint, found := strings.CutPrefix(r.URL.Path, "/artifactory/api/docker/oci-local/v2/my-artifact/")
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case strings.HasPrefix(endpoint, "blobs/uploads/") && r.Method == http.MethodPost:
+		w.Header().Set("Location", "/artifactory/api/docker/oci-local/v2/my-artifact/blobs/uploads/upload-id?state=1")
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasPrefix(endpoint, "blobs/uploads/") && r.Method == http.MethodPut:
+		digest := r.URL.Query().Get("digest")
+		if r.URL.Query().Get("state") != "1" || ContentDigest(body) != digest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		registry.uploads++
+		registry.blobs[digest] = body
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(endpoint, "blobs/"):
+		blob, exists := registry.blobs[strings.TrimPrefix(endpoint, "blobs/")]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(blob)
+		}
+	case strings.HasPrefix(endpoint, "manifests/") && r.Method == http.MethodPut:
+		registry.manifests[strings.TrimPrefix(endpoint, "manifests/")] = body
+		registry.manifests[ContentDigest(body)] = body
+		w.Header().Set(contentDigestHeader, ContentDigest(body))
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(endpoint, "manifests/"):
+		manifest, exists := registry.manifests[strings.TrimPrefix(endpoint, "manifests/")]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(manifest)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestClientPushAndPull(t *testing.T) {
+	registry := &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/artifactory/"}, -1, 0, false)
+	require.NoError(t, err)
+	client := NewClient(servicesManager, "oci-local", "my-artifact")
+
+	tempDir := t.TempDir()
+	localPath := filepath.Join(tempDir, "module.wasm")
+	require.NoError(t, os.WriteFile(localPath, []byte("wasm content"), 0644))
+	layer, details, err := CreateFileDescriptor(localPath, "application/wasm")
+	require.NoError(t, err)
+	assert.Equal(t, ContentDigest([]byte("wasm content")), layer.Digest)
+	assert.Equal(t, int64(12), details.Size)
+	assert.Equal(t, "module.wasm", layer.Annotations[AnnotationTitle])
+
+	require.NoError(t, client.UploadBlob(localPath, nil, layer.Digest))
+	configDescriptor, err := client.UploadEmptyConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", configDescriptor.Digest)
+	// Existing blobs aren't uploaded again.
+	require.NoError(t, client.UploadBlob(localPath, nil, layer.Digest))
+	assert.Equal(t, 2, registry.uploads)
+
+	manifest := &Manifest{SchemaVersion: 2, MediaType: MediaTypeImageManifest, ArtifactType: "application/vnd.example.wasm", Config: configDescriptor, Layers: []Descriptor{layer}}
+	digest, content, err := client.PutManifest("1.0.0", manifest)
+	require.NoError(t, err)
+	assert.Equal(t, ContentDigest(content), digest)
+
+	pulledManifest, pulledDigest, _, err := client.GetManifest("1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, digest, pulledDigest)
+	assert.Equal(t, manifest, pulledManifest)
+
+	targetDir := filepath.Join(tempDir, "pulled")
+	pulledPath, pulledDetails, err := client.DownloadBlob(pulledManifest.Layers[0], targetDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(targetDir, "module.wasm"), pulledPath)
+	assert.Equal(t, details.Checksum, pulledDetails.Checksum)
+
+	_, _, _, err = client.GetManifest("2.0.0")
+	assert.Error(t, err)
+}
+
+func TestResolveUploadUrl(t *testing.T) {
+	uploadUrl, err := resolveUploadUrl("https://acme.jfrog.io/artifactory/api/docker/oci-local/v2/my-artifact/blobs/uploads/", "/artifactory/api/docker/oci-local/v2/my-artifact/blobs/uploads/id?state=abc", "sha256:01")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/api/docker/oci-local/v2/my-artifact/blobs/uploads/id?digest=sha256%3A01&state=abc", uploadUrl)
+
+	_, err = resolveUploadUrl("https://acme.jfrog.io/", "", "sha256:01")
+	assert.ErrorContains(t, err, "didn't return the location of the blob upload")
+}
@@ -0,0 +1,64 @@
+package oci
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The default artifact type of artifacts pushed without an artifact type, as set by ORAS.
+const DefaultArtifactType = "application/vnd.unknown.artifact.v1"
+
+// A local file pushed as a layer of an OCI artifact.
+type File struct {
+	Path      string
+	MediaType string
+}
+
+// Parse an OCI reference of an image path in the repository, e.g. 'charts/my-chart:1.0.0' or 'wasm/my-module@sha256:...'.
+// Returns the image path and the tag or the digest. The tag is empty if the reference has neither a tag nor a digest.
+func ParseReference(reference string) (image, tagOrDigest string, err error) {
+	image = reference
+	if i := strings.LastIndex(reference, "@"); i >= 0 {
+		image, tagOrDigest = reference[:i], reference[i+1:]
+		if !strings.HasPrefix(tagOrDigest, "sha256:") {
+			return "", "", errorutils.CheckErrorf("invalid OCI reference '%s'. Only sha256 digests are supported", reference)
+		}
+	} else if i = strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		image, tagOrDigest = reference[:i], reference[i+1:]
+	}
+	image = strings.Trim(image, "/")
+	if image == "" || (tagOrDigest == "" && image != reference) {
+		return "", "", errorutils.CheckErrorf("invalid OCI reference '%s'. The expected format is 'image[:tag]' or 'image@digest'", reference)
+	}
+	return
+}
+
+// Parse the files to push, in the ORAS format of 'path[:media-type]'.
+// The media type is recognized by its slash, so that Windows paths such as 'C:\file' aren't split.
+func ParseFiles(args []string) (files []File) {
+	for _, arg := range args {
+		file := File{Path: arg}
+		if i := strings.LastIndex(arg, ":"); i > 0 && strings.Contains(arg[i+1:], "/") {
+			file.Path, file.MediaType = arg[:i], arg[i+1:]
+		}
+		files = append(files, file)
+	}
+	return
+}
+
+// Parse annotations in the 'key=value' format.
+func ParseAnnotations(args []string) (map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	annotations := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, "=")
+		if !found || key == "" {
+			return nil, errorutils.CheckErrorf("invalid annotation '%s'. The expected format is 'key=value'", arg)
+		}
+		annotations[key] = value
+	}
+	return annotations, nil
+}
@@ -0,0 +1,62 @@
+package oci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		reference           string
+		expectedImage       string
+		expectedTagOrDigest string
+		expectedError       bool
+	}{
+		{"charts/my-chart:1.0.0", "charts/my-chart", "1.0.0", false},
+		{"wasm/my-module@sha256:0123abcd", "wasm/my-module", "sha256:0123abcd", false},
+		{"my-artifact", "my-artifact", "", false},
+		{"localhost:8082/my-artifact", "localhost:8082/my-artifact", "", false},
+		{"my-artifact:", "", "", true},
+		{"my-artifact@md5:0123", "", "", true},
+		{":1.0.0", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.reference, func(t *testing.T) {
+			image, tagOrDigest, err := ParseReference(test.reference)
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedImage, image)
+			assert.Equal(t, test.expectedTagOrDigest, tagOrDigest)
+		})
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	files := ParseFiles([]string{"chart.tgz:application/vnd.cncf.helm.chart.content.v1.tar+gzip", "module.wasm", `C:\dist\module.wasm`, `C:\dist\sbom.json:application/spdx+json`})
+	assert.Equal(t, []File{
+		{Path: "chart.tgz", MediaType: "application/vnd.cncf.helm.chart.content.v1.tar+gzip"},
+		{Path: "module.wasm"},
+		{Path: `C:\dist\module.wasm`},
+		{Path: `C:\dist\sbom.json`, MediaType: "application/spdx+json"},
+	}, files)
+}
+
+func TestParseAnnotations(t *testing.T) {
+	annotations, err := ParseAnnotations([]string{"org.opencontainers.image.source=https://github.com/org/repo", "empty="})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"org.opencontainers.image.source": "https://github.com/org/repo", "empty": ""}, annotations)
+
+	_, err = ParseAnnotations([]string{"no-value"})
+	assert.ErrorContains(t, err, "invalid annotation 'no-value'")
+}
+
+func TestDescriptorFileName(t *testing.T) {
+	assert.Equal(t, "chart.tgz", Descriptor{Digest: "sha256:01", Annotations: map[string]string{AnnotationTitle: "chart.tgz"}}.FileName())
+	assert.Equal(t, "passwd", Descriptor{Digest: "sha256:01", Annotations: map[string]string{AnnotationTitle: "../../etc/passwd"}}.FileName())
+	assert.Equal(t, "sha256_01", Descriptor{Digest: "sha256:01", Annotations: map[string]string{AnnotationTitle: ".."}}.FileName())
+	assert.Equal(t, "sha256_01", Descriptor{Digest: "sha256:01"}.FileName())
+}
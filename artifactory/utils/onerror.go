@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Determines how a batch operation handles the failure of some of its items.
+type OnErrorPolicy string
+
+const (
+	// Continue transferring the rest of the items. This is the default policy.
+	OnErrorContinue OnErrorPolicy = "continue"
+	// Abort the operation after the first permanent failure. The remaining items are skipped.
+	OnErrorFailFast OnErrorPolicy = "fail-fast"
+	// Continue transferring the rest of the items, and then retry the failed items once, if some of the failures are retryable.
+	OnErrorRetryAtEnd OnErrorPolicy = "retry-at-end"
+)
+
+func GetOnErrorPolicies() []string {
+	return []string{string(OnErrorContinue), string(OnErrorFailFast), string(OnErrorRetryAtEnd)}
+}
+
+var ErrAbortedAfterFailure = errors.New("skipped, since a previous request failed and the on-error policy is " + string(OnErrorFailFast))
+
+// A request of a batch operation which failed.
+type FailedTransfer struct {
+	Method string
+	Url    string
+	// The status code of the response, or 0 if no response was received.
+	StatusCode int
+	// True if the failure may be transient, such as a connection error, a server error or rate limiting.
+	Retryable bool
+}
+
+func (ft FailedTransfer) String() string {
+	reason := "no response"
+	if ft.StatusCode != 0 {
+		reason = fmt.Sprintf("%d %s", ft.StatusCode, http.StatusText(ft.StatusCode))
+	}
+	if ft.Retryable {
+		reason += ", retryable"
+	}
+	return fmt.Sprintf("%s %s (%s)", ft.Method, ft.Url, reason)
+}
+
+// Records the failed and the skipped requests of a batch operation, and applies its on-error policy.
+// Each request is recorded by its last attempt, so requests which succeed when they are retried aren't reported as failed.
+type TransferFailures struct {
+	policy  OnErrorPolicy
+	aborted atomic.Bool
+	mutex   sync.Mutex
+	failed  map[string]FailedTransfer
+	skipped map[string]bool
+}
+
+func NewTransferFailures(policy OnErrorPolicy) *TransferFailures {
+	return &TransferFailures{policy: policy, failed: map[string]FailedTransfer{}, skipped: map[string]bool{}}
+}
+
+func (tf *TransferFailures) Policy() OnErrorPolicy {
+	return tf.policy
+}
+
+// Returns the failed requests, sorted by their URLs.
+func (tf *TransferFailures) Failed() []FailedTransfer {
+	tf.mutex.Lock()
+	defer tf.mutex.Unlock()
+	failed := make([]FailedTransfer, 0, len(tf.failed))
+	for _, failedTransfer := range tf.failed {
+		failed = append(failed, failedTransfer)
+	}
+	slices.SortFunc(failed, func(a, b FailedTransfer) int {
+		return strings.Compare(a.Url+" "+a.Method, b.Url+" "+b.Method)
+	})
+	return failed
+}
+
+// Returns the number of the requests which were skipped after a permanent failure, since the on-error policy is fail-fast.
+func (tf *TransferFailures) SkippedCount() int {
+	tf.mutex.Lock()
+	defer tf.mutex.Unlock()
+	return len(tf.skipped)
+}
+
+// Returns true if some of the requests failed, and none of them may succeed when retried.
+func (tf *TransferFailures) HasOnlyPermanentFailures() bool {
+	failed := tf.Failed()
+	return len(failed) > 0 && !slices.ContainsFunc(failed, func(failedTransfer FailedTransfer) bool {
+		return failedTransfer.Retryable
+	})
+}
+
+func (tf *TransferFailures) record(req *http.Request, resp *http.Response, err error) {
+	key := req.Method + " " + req.URL.Redacted()
+	tf.mutex.Lock()
+	defer tf.mutex.Unlock()
+	if !isFailedTransferRequest(req, resp, err) {
+		delete(tf.failed, key)
+		return
+	}
+	failedTransfer := FailedTransfer{Method: req.Method, Url: req.URL.Redacted(), Retryable: shouldRetry(resp, err)}
+	if resp != nil {
+		failedTransfer.StatusCode = resp.StatusCode
+	}
+	tf.failed[key] = failedTransfer
+}
+
+func (tf *TransferFailures) recordSkipped(req *http.Request) {
+	tf.mutex.Lock()
+	defer tf.mutex.Unlock()
+	tf.skipped[req.Method+" "+req.URL.Redacted()] = true
+}
+
+// An http.RoundTripper recording the failed requests of a batch operation.
+// If the on-error policy is fail-fast, all the requests following the first permanent failure are failed without being sent.
+// Retryable failures don't abort the operation, since they are retried by the retry policy, and may be retried at the end.
+type failuresTransport struct {
+	base     http.RoundTripper
+	failures *TransferFailures
+}
+
+func (ft *failuresTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if ft.failures.aborted.Load() {
+		ft.failures.recordSkipped(req)
+		return nil, errorutils.CheckError(ErrAbortedAfterFailure)
+	}
+	resp, err := ft.base.RoundTrip(req)
+	ft.failures.record(req, resp, err)
+	if ft.failures.policy == OnErrorFailFast && isFailedTransferRequest(req, resp, err) && !shouldRetry(resp, err) && ft.failures.aborted.CompareAndSwap(false, true) {
+		log.Warn("Aborting the operation after a failed request to", req.URL.Redacted(), "since the on-error policy is", string(OnErrorFailFast))
+	}
+	return resp, err
+}
+
+func isFailedTransferRequest(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	// A checksum deploy is expected to fail if the checksum doesn't exist in Artifactory, and the file is then uploaded.
+	if req.Header.Get("X-Checksum-Deploy") == "true" && resp.StatusCode == http.StatusNotFound {
+		return false
+	}
+	return resp.StatusCode >= http.StatusBadRequest
+}
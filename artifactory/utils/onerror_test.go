@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailuresTransport(t *testing.T) {
+	requests := 0
+	unavailable := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/checksum-deploy":
+			w.WriteHeader(http.StatusNotFound)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "/unavailable":
+			if unavailable {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+	failures := NewTransferFailures(OnErrorFailFast)
+	client := &http.Client{Transport: &failuresTransport{base: http.DefaultTransport, failures: failures}}
+	get := func(path string) (int, error) {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode, resp.Body.Close()
+	}
+
+	// A failed checksum deploy shouldn't be recorded.
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/checksum-deploy", http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("X-Checksum-Deploy", "true")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Empty(t, failures.Failed())
+
+	// A retryable failure is recorded, but doesn't abort the operation, and is removed once the request succeeds.
+	statusCode, err := get("/unavailable")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	assert.Equal(t, []FailedTransfer{{Method: http.MethodGet, Url: server.URL + "/unavailable", StatusCode: http.StatusServiceUnavailable, Retryable: true}}, failures.Failed())
+	assert.False(t, failures.HasOnlyPermanentFailures())
+	unavailable = false
+	_, err = get("/unavailable")
+	assert.NoError(t, err)
+	assert.Empty(t, failures.Failed())
+
+	statusCode, err = get("/forbidden")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.True(t, failures.HasOnlyPermanentFailures())
+	assert.Equal(t, "GET "+server.URL+"/forbidden (403 Forbidden)", failures.Failed()[0].String())
+
+	// The requests following a permanent failure should be skipped without reaching the server.
+	_, err = get("/ok")
+	assert.ErrorIs(t, err, ErrAbortedAfterFailure)
+	assert.Equal(t, 4, requests)
+	assert.Equal(t, 1, failures.SkippedCount())
+}
@@ -0,0 +1,46 @@
+package pnpm
+
+import (
+	"io"
+	"os/exec"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+func GetExecutablePath() (string, error) {
+	executablePath, err := exec.LookPath("pnpm")
+	if err != nil {
+		return "", errorutils.CheckErrorf("couldn't find the pnpm executable in the PATH: %s", err.Error())
+	}
+	log.Debug("Found pnpm executable at:", executablePath)
+	return executablePath, nil
+}
+
+func (config *PnpmConfig) GetCmd() *exec.Cmd {
+	var cmd []string
+	cmd = append(cmd, config.Pnpm)
+	cmd = append(cmd, config.Command...)
+	cmd = append(cmd, config.CommandFlags...)
+	return exec.Command(cmd[0], cmd[1:]...)
+}
+
+func (config *PnpmConfig) GetEnv() map[string]string {
+	return map[string]string{}
+}
+
+func (config *PnpmConfig) GetStdWriter() io.WriteCloser {
+	return config.StrWriter
+}
+
+func (config *PnpmConfig) GetErrWriter() io.WriteCloser {
+	return config.ErrWriter
+}
+
+type PnpmConfig struct {
+	Pnpm         string
+	Command      []string
+	CommandFlags []string
+	StrWriter    io.WriteCloser
+	ErrWriter    io.WriteCloser
+}
@@ -0,0 +1,251 @@
+package pnpm
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	LockfileName = "pnpm-lock.yaml"
+	// The root project of the lockfile.
+	RootImporter = "."
+
+	// The directory of the virtual store, in which the installed packages are linked from.
+	virtualStoreDir = ".pnpm"
+
+	minSupportedLockfileVersion = 6
+)
+
+// The content of pnpm-lock.yaml, in its versions 6 (pnpm 8) and 9 (pnpm 9 and above).
+type Lockfile struct {
+	LockfileVersion string              `yaml:"lockfileVersion"`
+	Importers       map[string]Importer `yaml:"importers"`
+	// Lockfiles of projects which aren't workspaces have the dependencies of the root project at their top level.
+	Importer `yaml:",inline"`
+	// In version 6, the packages hold both the resolution of the packages and their dependencies.
+	Packages map[string]Snapshot `yaml:"packages"`
+	// In version 9, the dependencies of the packages moved to the snapshots.
+	Snapshots map[string]Snapshot `yaml:"snapshots"`
+}
+
+// The dependencies of a project in the lockfile.
+type Importer struct {
+	Dependencies         map[string]ImporterDependency `yaml:"dependencies"`
+	DevDependencies      map[string]ImporterDependency `yaml:"devDependencies"`
+	OptionalDependencies map[string]ImporterDependency `yaml:"optionalDependencies"`
+}
+
+type ImporterDependency struct {
+	Specifier string `yaml:"specifier"`
+	Version   string `yaml:"version"`
+}
+
+// A package installed with a specific set of peer dependencies.
+type Snapshot struct {
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+	Optional             bool              `yaml:"optional"`
+}
+
+// Read the pnpm-lock.yaml file in the directory.
+func ReadLockfile(dir string) (*Lockfile, error) {
+	content, err := os.ReadFile(filepath.Join(dir, LockfileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("couldn't find %s in '%s'. Run 'pnpm install' to create it", LockfileName, dir)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(content)
+}
+
+func ParseLockfile(content []byte) (*Lockfile, error) {
+	lockfile := new(Lockfile)
+	if err := yaml.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	majorVersion, err := lockfile.majorVersion()
+	if err != nil {
+		return nil, err
+	}
+	if majorVersion < minSupportedLockfileVersion {
+		return nil, errorutils.CheckErrorf("version %s of %s isn't supported. Upgrade pnpm to version 8 or above to update it", lockfile.LockfileVersion, LockfileName)
+	}
+	if len(lockfile.Importers) == 0 {
+		lockfile.Importers = map[string]Importer{RootImporter: lockfile.Importer}
+	}
+	return lockfile, nil
+}
+
+func (lockfile *Lockfile) majorVersion() (int, error) {
+	major, _, _ := strings.Cut(lockfile.LockfileVersion, ".")
+	majorVersion, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, errorutils.CheckErrorf("unexpected %s version '%s'", LockfileName, lockfile.LockfileVersion)
+	}
+	return majorVersion, nil
+}
+
+// Returns the snapshot of a package, by its name and its version, which may include its peer dependencies.
+func (lockfile *Lockfile) getSnapshot(name, version string) (Snapshot, bool) {
+	if len(lockfile.Snapshots) > 0 {
+		snapshot, found := lockfile.Snapshots[name+"@"+version]
+		return snapshot, found
+	}
+	snapshot, found := lockfile.Packages["/"+name+"@"+version]
+	return snapshot, found
+}
+
+// Returns the names of the projects of the lockfile, sorted.
+func (lockfile *Lockfile) ImporterPaths() []string {
+	return slices.Sorted(maps.Keys(lockfile.Importers))
+}
+
+// Returns the dependencies of a project of the lockfile, with their scopes and the dependency paths they were requested by.
+// Dependencies which are linked to a local directory, such as the other projects of a workspace, are skipped.
+// installedPackages is the set of packages found in the virtual store. When it isn't nil, optional packages which weren't installed,
+// such as packages of other platforms, are skipped.
+func (lockfile *Lockfile) GetDependencies(importerPath, moduleId string, installedPackages map[string]bool) []buildinfo.Dependency {
+	importer := lockfile.Importers[importerPath]
+	collector := &dependenciesCollector{lockfile: lockfile, installedPackages: installedPackages, dependencies: map[string]*buildinfo.Dependency{}, visited: map[string]bool{}}
+	collector.collect(importer.Dependencies, "prod", moduleId)
+	collector.collect(importer.OptionalDependencies, "prod", moduleId)
+	collector.collect(importer.DevDependencies, "dev", moduleId)
+	dependencies := make([]buildinfo.Dependency, 0, len(collector.dependencies))
+	for _, dependency := range collector.dependencies {
+		dependencies = append(dependencies, *dependency)
+	}
+	slices.SortFunc(dependencies, func(a, b buildinfo.Dependency) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return dependencies
+}
+
+type dependenciesCollector struct {
+	lockfile          *Lockfile
+	installedPackages map[string]bool
+	dependencies      map[string]*buildinfo.Dependency
+	// The snapshots whose dependencies were already collected.
+	visited map[string]bool
+}
+
+func (dc *dependenciesCollector) collect(importerDependencies map[string]ImporterDependency, scope, moduleId string) {
+	// The importer's dependencies are decoded from a YAML mapping, so their order is lost. Since the dependencies of each snapshot
+	// are collected under the first path that reaches it, they're sorted by name to record the same paths in every run.
+	for _, name := range slices.Sorted(maps.Keys(importerDependencies)) {
+		dc.add(name, importerDependencies[name].Version, scope, []string{moduleId})
+	}
+}
+
+// Add the dependency, requested by the dependency path, and its own dependencies.
+// The dependencies of each snapshot are traversed once, so every dependency is recorded with one path for each of its direct parents.
+func (dc *dependenciesCollector) add(alias, lockfileVersion, scope string, requestedBy []string) {
+	name, version, ok := parseDependencyVersion(alias, lockfileVersion)
+	if !ok {
+		return
+	}
+	snapshot, found := dc.lockfile.getSnapshot(name, version)
+	if !found {
+		log.Debug("Couldn't find the snapshot of", name+"@"+version, "in", LockfileName)
+	}
+	id := name + ":" + stripPeerDependencies(version)
+	if snapshot.Optional && dc.installedPackages != nil && !dc.installedPackages[id] {
+		log.Debug("Skipping the optional dependency", id, "which wasn't installed.")
+		return
+	}
+	dependency, exists := dc.dependencies[id]
+	if !exists {
+		dependency = &buildinfo.Dependency{Id: id}
+		dc.dependencies[id] = dependency
+	}
+	if !slices.Contains(dependency.Scopes, scope) {
+		dependency.Scopes = append(dependency.Scopes, scope)
+	}
+	if !isRequestedByParent(dependency.RequestedBy, requestedBy[0]) {
+		dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+	}
+	visitedKey := scope + "|" + name + "@" + version
+	if dc.visited[visitedKey] {
+		return
+	}
+	dc.visited[visitedKey] = true
+	childRequestedBy := append([]string{id}, requestedBy...)
+	for _, childName := range slices.Sorted(maps.Keys(snapshot.Dependencies)) {
+		dc.add(childName, snapshot.Dependencies[childName], scope, childRequestedBy)
+	}
+	for _, childName := range slices.Sorted(maps.Keys(snapshot.OptionalDependencies)) {
+		dc.add(childName, snapshot.OptionalDependencies[childName], scope, childRequestedBy)
+	}
+}
+
+func isRequestedByParent(requestedBy [][]string, parent string) bool {
+	for _, path := range requestedBy {
+		if len(path) > 0 && path[0] == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse the version of a dependency as written in the lockfile. Returns the name and the version of the resolved package,
+// which differ from the alias of the dependency for aliased dependencies ('alias: other-package@1.0.0').
+// Returns false for dependencies which aren't resolved from a registry, such as 'link:' and 'file:' dependencies.
+func parseDependencyVersion(alias, lockfileVersion string) (name, version string, ok bool) {
+	if strings.HasPrefix(lockfileVersion, "link:") || strings.HasPrefix(lockfileVersion, "file:") || strings.Contains(stripPeerDependencies(lockfileVersion), "://") {
+		return "", "", false
+	}
+	// Version 6 prefixes the keys of aliased packages with a slash.
+	lockfileVersion = strings.TrimPrefix(lockfileVersion, "/")
+	withoutPeers := stripPeerDependencies(lockfileVersion)
+	if i := strings.LastIndex(withoutPeers, "@"); i > 0 {
+		return lockfileVersion[:i], lockfileVersion[i+1:], true
+	}
+	return alias, lockfileVersion, true
+}
+
+// Remove the peer dependencies suffix of a version, e.g. '1.0.0(react@18.2.0)' -> '1.0.0'.
+func stripPeerDependencies(version string) string {
+	version, _, _ = strings.Cut(version, "(")
+	return version
+}
+
+// Returns the set of the packages installed in the virtual store of the project (node_modules/.pnpm), by their 'name:version' IDs.
+// Returns nil if the virtual store doesn't exist, for example when pnpm is configured with 'node-linker=hoisted'.
+func GetInstalledPackages(projectDir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(filepath.Join(projectDir, "node_modules", virtualStoreDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	installedPackages := map[string]bool{}
+	for _, entry := range entries {
+		if id := parseVirtualStoreDirName(entry.Name()); entry.IsDir() && id != "" {
+			installedPackages[id] = true
+		}
+	}
+	return installedPackages, nil
+}
+
+// The directories of the virtual store are named by the package name, where the slash of a scope is replaced by '+',
+// its version and its peer dependencies, e.g. '@babel+core@7.24.0' or 'react-dom@18.2.0_react@18.2.0'.
+// Returns the 'name:version' ID of the package, or an empty string for directories which aren't packages, such as 'node_modules'.
+func parseVirtualStoreDirName(dirName string) string {
+	i := strings.Index(dirName[min(1, len(dirName)):], "@") + 1
+	if i <= 0 {
+		return ""
+	}
+	name := strings.Replace(dirName[:i], "+", "/", 1)
+	version, _, _ := strings.Cut(stripPeerDependencies(dirName[i+1:]), "_")
+	return name + ":" + version
+}
@@ -0,0 +1,185 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lockfileV6 = `lockfileVersion: '6.0'
+
+dependencies:
+  express:
+    specifier: ^4.18.2
+    version: 4.18.2
+  my-lodash:
+    specifier: npm:lodash@^4.17.21
+    version: /lodash@4.17.21
+
+devDependencies:
+  local-utils:
+    specifier: link:../utils
+    version: link:../utils
+
+packages:
+  /express@4.18.2:
+    resolution: {integrity: sha512-abc}
+    dependencies:
+      accepts: 1.3.8
+      lodash: 4.17.21
+    dev: false
+  /accepts@1.3.8:
+    resolution: {integrity: sha512-def}
+    dev: false
+  /lodash@4.17.21:
+    resolution: {integrity: sha512-ghi}
+    dev: false
+`
+
+const lockfileV9 = `lockfileVersion: '9.0'
+
+importers:
+
+  .:
+    dependencies:
+      react-dom:
+        specifier: ^18.2.0
+        version: 18.2.0(react@18.2.0)
+    devDependencies:
+      '@types/react':
+        specifier: ^18.2.0
+        version: 18.2.0
+
+  packages/app:
+    dependencies:
+      react:
+        specifier: ^18.2.0
+        version: 18.2.0
+      fsevents:
+        specifier: ^2.3.3
+        version: 2.3.3
+
+packages:
+
+  '@types/react@18.2.0':
+    resolution: {integrity: sha512-abc}
+
+  fsevents@2.3.3:
+    resolution: {integrity: sha512-def}
+    os: [darwin]
+
+  loose-envify@1.4.0:
+    resolution: {integrity: sha512-ghi}
+
+  react-dom@18.2.0:
+    resolution: {integrity: sha512-jkl}
+    peerDependencies:
+      react: ^18.2.0
+
+  react@18.2.0:
+    resolution: {integrity: sha512-mno}
+
+snapshots:
+
+  '@types/react@18.2.0': {}
+
+  fsevents@2.3.3:
+    optional: true
+
+  loose-envify@1.4.0: {}
+
+  react-dom@18.2.0(react@18.2.0):
+    dependencies:
+      loose-envify: 1.4.0
+      react: 18.2.0
+
+  react@18.2.0:
+    dependencies:
+      loose-envify: 1.4.0
+`
+
+func TestGetDependenciesLockfileV6(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(lockfileV6))
+	require.NoError(t, err)
+	assert.Equal(t, []string{RootImporter}, lockfile.ImporterPaths())
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "accepts:1.3.8", Scopes: []string{"prod"}, RequestedBy: [][]string{{"express:4.18.2", "my-app:1.0.0"}}},
+		{Id: "express:4.18.2", Scopes: []string{"prod"}, RequestedBy: [][]string{{"my-app:1.0.0"}}},
+		{Id: "lodash:4.17.21", Scopes: []string{"prod"}, RequestedBy: [][]string{{"express:4.18.2", "my-app:1.0.0"}, {"my-app:1.0.0"}}},
+	}, lockfile.GetDependencies(RootImporter, "my-app:1.0.0", nil))
+}
+
+func TestGetDependenciesLockfileV9(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(lockfileV9))
+	require.NoError(t, err)
+	assert.Equal(t, []string{RootImporter, "packages/app"}, lockfile.ImporterPaths())
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "@types/react:18.2.0", Scopes: []string{"dev"}, RequestedBy: [][]string{{"root:1.0.0"}}},
+		{Id: "loose-envify:1.4.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"react-dom:18.2.0", "root:1.0.0"}, {"react:18.2.0", "react-dom:18.2.0", "root:1.0.0"}}},
+		{Id: "react-dom:18.2.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"root:1.0.0"}}},
+		{Id: "react:18.2.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"react-dom:18.2.0", "root:1.0.0"}}},
+	}, lockfile.GetDependencies(RootImporter, "root:1.0.0", nil))
+
+	// Optional dependencies which weren't installed to the virtual store are skipped.
+	installedPackages := map[string]bool{"react:18.2.0": true, "loose-envify:1.4.0": true}
+	dependencies := lockfile.GetDependencies("packages/app", "app:1.0.0", installedPackages)
+	assert.Len(t, dependencies, 2)
+	installedPackages["fsevents:2.3.3"] = true
+	assert.Len(t, lockfile.GetDependencies("packages/app", "app:1.0.0", installedPackages), 3)
+}
+
+func TestParseLockfileUnsupportedVersion(t *testing.T) {
+	_, err := ParseLockfile([]byte("lockfileVersion: 5.4\n"))
+	assert.ErrorContains(t, err, "version 5.4 of pnpm-lock.yaml isn't supported")
+}
+
+func TestParseDependencyVersion(t *testing.T) {
+	tests := []struct {
+		alias           string
+		lockfileVersion string
+		expectedName    string
+		expectedVersion string
+		expectedOk      bool
+	}{
+		{"lodash", "4.17.21", "lodash", "4.17.21", true},
+		{"react-dom", "18.2.0(react@18.2.0)", "react-dom", "18.2.0(react@18.2.0)", true},
+		{"my-lodash", "lodash@4.17.21", "lodash", "4.17.21", true},
+		{"my-lodash", "/lodash@4.17.21", "lodash", "4.17.21", true},
+		{"my-types", "@types/node@20.0.0", "@types/node", "20.0.0", true},
+		{"utils", "link:../utils", "", "", false},
+		{"local", "file:local.tgz", "", "", false},
+		{"remote", "https://example.com/remote.tgz", "", "", false},
+	}
+	for _, test := range tests {
+		t.Run(test.alias+"@"+test.lockfileVersion, func(t *testing.T) {
+			name, version, ok := parseDependencyVersion(test.alias, test.lockfileVersion)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedVersion, version)
+		})
+	}
+}
+
+func TestGetInstalledPackages(t *testing.T) {
+	projectDir := t.TempDir()
+	installedPackages, err := GetInstalledPackages(projectDir)
+	assert.NoError(t, err)
+	assert.Nil(t, installedPackages)
+
+	for _, dirName := range []string{"lodash@4.17.21", "@babel+core@7.24.0", "react-dom@18.2.0_react@18.2.0", "string_decoder@1.3.0", "styled-jsx@5.1.1(react@18.2.0)", "node_modules"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "node_modules", ".pnpm", dirName), 0755))
+	}
+	installedPackages, err = GetInstalledPackages(projectDir)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"lodash:4.17.21":       true,
+		"@babel/core:7.24.0":   true,
+		"react-dom:18.2.0":     true,
+		"string_decoder:1.3.0": true,
+		"styled-jsx:5.1.1":     true,
+	}, installedPackages)
+}
@@ -0,0 +1,61 @@
+package pnpm
+
+import (
+	"path/filepath"
+	"strings"
+
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const packedTarballSuffix = ".tgz"
+
+// Run 'pnpm pack' and return the absolute paths of the packed tarballs.
+func Pack(pnpmFlags []string, executablePath string) ([]string, error) {
+	packCmdConfig := &PnpmConfig{Pnpm: executablePath, Command: []string{"pack"}, CommandFlags: pnpmFlags}
+	output, err := gofrogcmd.RunCmdOutput(packCmdConfig)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	_, _, packDestination, err := coreutils.FindFlag("--pack-destination", pnpmFlags)
+	if err != nil {
+		return nil, err
+	}
+	return getPackedTarballs(output, packDestination)
+}
+
+// The output of 'pnpm pack' lists the packed files, and ends with the path of the tarball.
+// The path is relative to the working directory, or to the pack destination in older pnpm versions.
+// Lines of prepack scripts may also end with '.tgz', so only existing files are returned.
+func getPackedTarballs(output, packDestination string) (tarballs []string, err error) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasSuffix(line, packedTarballSuffix) {
+			continue
+		}
+		candidates := []string{line}
+		if packDestination != "" && !filepath.IsAbs(line) {
+			candidates = append(candidates, filepath.Join(packDestination, line))
+		}
+		for _, candidate := range candidates {
+			exists, err := fileutils.IsFileExists(candidate, true)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				absPath, err := filepath.Abs(candidate)
+				if err != nil {
+					return nil, errorutils.CheckError(err)
+				}
+				tarballs = append(tarballs, absPath)
+				break
+			}
+		}
+	}
+	if len(tarballs) == 0 {
+		return nil, errorutils.CheckErrorf("couldn't find the tarball packed by pnpm in its output:\n%s", output)
+	}
+	return
+}
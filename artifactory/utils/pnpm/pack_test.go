@@ -0,0 +1,28 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPackedTarballs(t *testing.T) {
+	packDestination := t.TempDir()
+	tarballPath := filepath.Join(packDestination, "my-package-1.0.0.tgz")
+	require.NoError(t, os.WriteFile(tarballPath, []byte{}, 0644))
+	output := "> my-package@1.0.0 prepack\n> echo prepack.tgz\n\nprepack.tgz\n📦  my-package@1.0.0\nTarball Contents\npackage.json\nindex.js\nTarball Details\nmy-package-1.0.0.tgz\n"
+
+	tarballs, err := getPackedTarballs(output, packDestination)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{tarballPath}, tarballs)
+
+	tarballs, err = getPackedTarballs(tarballPath+"\n", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{tarballPath}, tarballs)
+
+	_, err = getPackedTarballs(output, "")
+	assert.ErrorContains(t, err, "couldn't find the tarball packed by pnpm")
+}
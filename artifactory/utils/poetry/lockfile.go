@@ -0,0 +1,271 @@
+package poetry
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+const (
+	LockfileName  = "poetry.lock"
+	PyProjectName = "pyproject.toml"
+	// The group of the dependencies of 'tool.poetry.dependencies' and 'project.dependencies'.
+	MainGroup = "main"
+	// The group of the dependencies of the legacy 'tool.poetry.dev-dependencies'.
+	DevGroup = "dev"
+	// The prefix of the IDs of PyPI components in Xray.
+	PypiPackageTypeIdentifier = "pypi://"
+)
+
+var (
+	// Characters which are equivalent in the names of python packages, as defined by PEP 503.
+	nameSeparatorsRegex = regexp.MustCompile(`[-_.]+`)
+	// The name of the package at the beginning of a PEP 508 requirement, such as 'requests[socks] (>=2.31)'.
+	requirementNameRegex = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+)
+
+// The content of pyproject.toml, which is needed to collect the dependencies of the project.
+// Poetry 2 reads the name, the version and the main dependencies of the project from the 'project' table of PEP 621 as well.
+type PyProject struct {
+	Project struct {
+		Name         string   `toml:"name"`
+		Version      string   `toml:"version"`
+		Dependencies []string `toml:"dependencies"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Name            string         `toml:"name"`
+			Version         string         `toml:"version"`
+			Dependencies    map[string]any `toml:"dependencies"`
+			DevDependencies map[string]any `toml:"dev-dependencies"`
+			Group           map[string]struct {
+				Dependencies map[string]any `toml:"dependencies"`
+			} `toml:"group"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+// The content of poetry.lock.
+type Lockfile struct {
+	Packages []Package `toml:"package"`
+	// Lock files of version 1 list the files of the packages in the metadata, by the names of the packages.
+	Metadata struct {
+		Files map[string][]File `toml:"files"`
+	} `toml:"metadata"`
+	// The locked packages by their normalized names.
+	packagesByName map[string]*Package
+}
+
+type Package struct {
+	Name         string         `toml:"name"`
+	Version      string         `toml:"version"`
+	Files        []File         `toml:"files"`
+	Dependencies map[string]any `toml:"dependencies"`
+}
+
+// A distribution of a package, such as a wheel or a source archive.
+type File struct {
+	File string `toml:"file"`
+	Hash string `toml:"hash"`
+}
+
+func (p *Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+// Returns the sha256 checksums of the files of the package.
+func (p *Package) GetSha256Checksums() (checksums []string) {
+	for _, file := range p.Files {
+		if checksum, found := strings.CutPrefix(file.Hash, "sha256:"); found {
+			checksums = append(checksums, checksum)
+		}
+	}
+	return
+}
+
+// Normalize the name of a python package as defined by PEP 503, so that names such as 'Typing_Extensions' and 'typing-extensions' are matched.
+func NormalizeName(name string) string {
+	return strings.ToLower(nameSeparatorsRegex.ReplaceAllString(name, "-"))
+}
+
+func ReadPyProject(projectDir string) (*PyProject, error) {
+	pyProject := new(PyProject)
+	if _, err := toml.DecodeFile(filepath.Join(projectDir, PyProjectName), pyProject); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read %s: %s", PyProjectName, err.Error())
+	}
+	return pyProject, nil
+}
+
+// Returns the ID of the module of the project in the build-info, or an empty ID if the project has no name.
+func (pp *PyProject) GetModuleId() string {
+	name, version := pp.Tool.Poetry.Name, pp.Tool.Poetry.Version
+	if name == "" {
+		name, version = pp.Project.Name, pp.Project.Version
+	}
+	if name == "" || version == "" {
+		return name
+	}
+	return name + ":" + version
+}
+
+// Returns the normalized names of the direct dependencies of the project, by their dependency groups.
+// The 'python' requirement isn't a package, and therefore isn't returned.
+func (pp *PyProject) GetGroups() map[string][]string {
+	groups := map[string][]string{}
+	addDependencies := func(group string, names []string) {
+		for _, name := range names {
+			name = NormalizeName(name)
+			if name != "python" && !slices.Contains(groups[group], name) {
+				groups[group] = append(groups[group], name)
+			}
+		}
+	}
+	for _, requirement := range pp.Project.Dependencies {
+		if match := requirementNameRegex.FindStringSubmatch(requirement); match != nil {
+			addDependencies(MainGroup, []string{match[1]})
+		}
+	}
+	addDependencies(MainGroup, slices.Sorted(maps.Keys(pp.Tool.Poetry.Dependencies)))
+	addDependencies(DevGroup, slices.Sorted(maps.Keys(pp.Tool.Poetry.DevDependencies)))
+	for group, groupDependencies := range pp.Tool.Poetry.Group {
+		addDependencies(group, slices.Sorted(maps.Keys(groupDependencies.Dependencies)))
+	}
+	for group := range groups {
+		slices.Sort(groups[group])
+	}
+	return groups
+}
+
+func ReadLockfile(projectDir string) (*Lockfile, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, LockfileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("couldn't find %s in '%s'. Run 'poetry lock' to create it", LockfileName, projectDir)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(content)
+}
+
+func ParseLockfile(content []byte) (*Lockfile, error) {
+	lockfile := new(Lockfile)
+	if _, err := toml.Decode(string(content), lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	lockfile.packagesByName = map[string]*Package{}
+	for i := range lockfile.Packages {
+		if len(lockfile.Packages[i].Files) == 0 {
+			lockfile.Packages[i].Files = lockfile.Metadata.Files[lockfile.Packages[i].Name]
+		}
+		lockfile.packagesByName[NormalizeName(lockfile.Packages[i].Name)] = &lockfile.Packages[i]
+	}
+	return lockfile, nil
+}
+
+// Returns the locked package of a requirement.
+func (lockfile *Lockfile) GetPackage(name string) (*Package, bool) {
+	poetryPackage, found := lockfile.packagesByName[NormalizeName(name)]
+	return poetryPackage, found
+}
+
+// Returns a checksum of the files of each locked package, by the IDs of the packages in the dependency tree.
+// The smallest of the sha256 checksums of the files is used, so the checksum doesn't depend on the order of the files.
+func (lockfile *Lockfile) GetChecksums() map[string]string {
+	checksums := map[string]string{}
+	for _, poetryPackage := range lockfile.packagesByName {
+		if fileChecksums := poetryPackage.GetSha256Checksums(); len(fileChecksums) > 0 {
+			checksums[PypiPackageTypeIdentifier+poetryPackage.Id()] = slices.Min(fileChecksums)
+		}
+	}
+	return checksums
+}
+
+// Returns the dependencies of the project, with the dependency groups they belong to as their scopes,
+// and the dependency paths they were requested by.
+func (lockfile *Lockfile) GetDependencies(pyProject *PyProject, moduleId string) []buildinfo.Dependency {
+	collector := &dependenciesCollector{lockfile: lockfile, dependencies: map[string]*buildinfo.Dependency{}, visited: map[string]bool{}}
+	groups := pyProject.GetGroups()
+	for _, group := range slices.Sorted(maps.Keys(groups)) {
+		collector.collect(groups[group], group, []string{moduleId})
+	}
+	dependencies := make([]buildinfo.Dependency, 0, len(collector.dependencies))
+	for _, id := range slices.Sorted(maps.Keys(collector.dependencies)) {
+		dependencies = append(dependencies, *collector.dependencies[id])
+	}
+	return dependencies
+}
+
+type dependenciesCollector struct {
+	lockfile     *Lockfile
+	dependencies map[string]*buildinfo.Dependency
+	// The packages whose dependencies were already collected, by their group.
+	visited map[string]bool
+}
+
+// The requirements of each package are traversed once per group, so every dependency is recorded with one path for each of its direct parents.
+func (dc *dependenciesCollector) collect(names []string, group string, requestedBy []string) {
+	for _, name := range names {
+		poetryPackage, found := dc.lockfile.GetPackage(name)
+		if !found {
+			continue
+		}
+		id := poetryPackage.Id()
+		dependency, exists := dc.dependencies[id]
+		if !exists {
+			dependency = &buildinfo.Dependency{Id: id}
+			dc.dependencies[id] = dependency
+		}
+		if !slices.Contains(dependency.Scopes, group) {
+			dependency.Scopes = append(dependency.Scopes, group)
+		}
+		if !slices.ContainsFunc(dependency.RequestedBy, func(path []string) bool { return path[0] == requestedBy[0] }) {
+			dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+		}
+		visitedKey := group + "|" + id
+		if dc.visited[visitedKey] {
+			continue
+		}
+		dc.visited[visitedKey] = true
+		dc.collect(slices.Sorted(maps.Keys(poetryPackage.Dependencies)), group, append([]string{id}, requestedBy...))
+	}
+}
+
+// Returns the dependency tree of the project, including all of its dependency groups, for auditing.
+// The dependencies of each package are expanded once per branch, so cyclic dependencies don't expand endlessly.
+func (lockfile *Lockfile) GetDependencyTree(pyProject *PyProject, moduleId string) *xrayUtils.GraphNode {
+	root := &xrayUtils.GraphNode{Id: PypiPackageTypeIdentifier + moduleId, Nodes: []*xrayUtils.GraphNode{}}
+	var directDependencies []string
+	for _, names := range pyProject.GetGroups() {
+		for _, name := range names {
+			if !slices.Contains(directDependencies, name) {
+				directDependencies = append(directDependencies, name)
+			}
+		}
+	}
+	slices.Sort(directDependencies)
+	lockfile.addTreeNodes(root, directDependencies)
+	return root
+}
+
+func (lockfile *Lockfile) addTreeNodes(parent *xrayUtils.GraphNode, names []string) {
+	for _, name := range names {
+		poetryPackage, found := lockfile.GetPackage(name)
+		if !found {
+			continue
+		}
+		node := &xrayUtils.GraphNode{Id: PypiPackageTypeIdentifier + poetryPackage.Id(), Parent: parent, Nodes: []*xrayUtils.GraphNode{}}
+		parent.Nodes = append(parent.Nodes, node)
+		if node.NodeHasLoop() {
+			continue
+		}
+		lockfile.addTreeNodes(node, slices.Sorted(maps.Keys(poetryPackage.Dependencies)))
+	}
+}
@@ -0,0 +1,132 @@
+package poetry
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPyProject = `
+[project]
+name = "my-app"
+version = "1.0.0"
+dependencies = ["Requests[socks] (>=2.31)"]
+
+[tool.poetry.dependencies]
+python = "^3.9"
+
+[tool.poetry.group.test.dependencies]
+pytest = "^8.0"
+`
+
+const testLockfile = `
+[[package]]
+name = "requests"
+version = "2.31.0"
+files = [
+    {file = "requests-2.31.0-py3-none-any.whl", hash = "sha256:58cd2187c01e70e6e26505bca751777aa9f2ee0b7f4300988b709f44e013003f"},
+]
+
+[package.dependencies]
+urllib3 = ">=1.21.1,<3"
+
+[[package]]
+name = "urllib3"
+version = "2.2.1"
+files = []
+
+[package.dependencies]
+requests = "*"
+
+[[package]]
+name = "pytest"
+version = "8.1.1"
+
+[package.dependencies]
+urllib3 = "*"
+
+[metadata]
+lock-version = "1.1"
+
+[metadata.files]
+pytest = [
+    {file = "pytest-8.1.1.tar.gz", hash = "sha256:ac978141a75948948817d360297b7aae0fcb9d6ff6bc9ec6d514b85d5a65c044"},
+]
+`
+
+func TestGetGroups(t *testing.T) {
+	pyProject, err := ReadPyProject(filepath.Join("..", "..", "..", "tests", "testdata", "poetry-project"))
+	require.NoError(t, err)
+	assert.Equal(t, "my-poetry-project:0.1.0", pyProject.GetModuleId())
+	assert.Equal(t, map[string][]string{MainGroup: {"numpy"}, DevGroup: {"pytest"}}, pyProject.GetGroups())
+}
+
+func TestGetDependencies(t *testing.T) {
+	pyProject := new(PyProject)
+	_, err := toml.Decode(testPyProject, pyProject)
+	require.NoError(t, err)
+	assert.Equal(t, "my-app:1.0.0", pyProject.GetModuleId())
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "pytest:8.1.1", Scopes: []string{"test"}, RequestedBy: [][]string{{"my-app:1.0.0"}}},
+		{Id: "requests:2.31.0", Scopes: []string{MainGroup, "test"}, RequestedBy: [][]string{{"my-app:1.0.0"}, {"urllib3:2.2.1", "requests:2.31.0", "my-app:1.0.0"}}},
+		{Id: "urllib3:2.2.1", Scopes: []string{MainGroup, "test"}, RequestedBy: [][]string{{"requests:2.31.0", "my-app:1.0.0"}, {"pytest:8.1.1", "my-app:1.0.0"}}},
+	}, lockfile.GetDependencies(pyProject, "my-app:1.0.0"))
+
+	pytest, found := lockfile.GetPackage("PyTest")
+	require.True(t, found)
+	assert.Equal(t, []string{"ac978141a75948948817d360297b7aae0fcb9d6ff6bc9ec6d514b85d5a65c044"}, pytest.GetSha256Checksums())
+}
+
+func TestGetDependencyTree(t *testing.T) {
+	pyProject := new(PyProject)
+	_, err := toml.Decode(testPyProject, pyProject)
+	require.NoError(t, err)
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+
+	tree := lockfile.GetDependencyTree(pyProject, "my-app:1.0.0")
+	assert.Equal(t, "pypi://my-app:1.0.0", tree.Id)
+	require.Len(t, tree.Nodes, 2)
+	assert.Equal(t, "pypi://pytest:8.1.1", tree.Nodes[0].Id)
+	requests := tree.Nodes[1]
+	assert.Equal(t, "pypi://requests:2.31.0", requests.Id)
+	// The cyclic dependency of urllib3 on requests isn't expanded.
+	require.Len(t, requests.Nodes, 1)
+	require.Len(t, requests.Nodes[0].Nodes, 1)
+	assert.Equal(t, "pypi://requests:2.31.0", requests.Nodes[0].Nodes[0].Id)
+	assert.Empty(t, requests.Nodes[0].Nodes[0].Nodes)
+}
+
+func TestGetChecksums(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	// urllib3 has no files, and the files of pytest are listed in the metadata of the lock file.
+	assert.Equal(t, map[string]string{
+		"pypi://requests:2.31.0": "58cd2187c01e70e6e26505bca751777aa9f2ee0b7f4300988b709f44e013003f",
+		"pypi://pytest:8.1.1":    "ac978141a75948948817d360297b7aae0fcb9d6ff6bc9ec6d514b85d5a65c044",
+	}, lockfile.GetChecksums())
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"requests", "requests"},
+		{"Typing_Extensions", "typing-extensions"},
+		{"zope.interface", "zope-interface"},
+		{"a-_.b", "a-b"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NormalizeName(test.name))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A target repository of a build promotion.
+type PromotionTarget struct {
+	// The repository from which the build artifacts are promoted. If empty, they are promoted from all the repositories.
+	SourceRepo string `json:"sourceRepo,omitempty"`
+	TargetRepo string `json:"targetRepo"`
+}
+
+// The content of a promotion mapping spec file, for example:
+// {"targets": [{"targetRepo": "release-local"}, {"sourceRepo": "staging-local", "targetRepo": "archive-local"}]}
+type PromotionTargetsSpec struct {
+	Targets []PromotionTarget `json:"targets"`
+}
+
+// Create the promotion targets of semicolon separated target repositories, all promoted from the given source repository.
+func ParsePromotionTargets(targetRepos, sourceRepo string) (targets []PromotionTarget) {
+	for _, targetRepo := range strings.Split(targetRepos, ";") {
+		if targetRepo = strings.TrimSpace(targetRepo); targetRepo != "" {
+			targets = append(targets, PromotionTarget{SourceRepo: sourceRepo, TargetRepo: targetRepo})
+		}
+	}
+	return
+}
+
+// Read the promotion targets of a mapping spec file, and validate them.
+func LoadPromotionTargets(specFilePath string) ([]PromotionTarget, error) {
+	content, err := os.ReadFile(specFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var spec PromotionTargetsSpec
+	if err = json.Unmarshal(content, &spec); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the promotion targets spec '%s': %s", specFilePath, err.Error())
+	}
+	return spec.Targets, ValidatePromotionTargets(spec.Targets)
+}
+
+// Verify there is at least one target, each target has a target repository, and no target is repeated.
+func ValidatePromotionTargets(targets []PromotionTarget) error {
+	if len(targets) == 0 {
+		return errorutils.CheckErrorf("no promotion targets were provided")
+	}
+	seen := make(map[PromotionTarget]bool, len(targets))
+	for i, target := range targets {
+		if target.TargetRepo == "" {
+			return errorutils.CheckErrorf("the promotion target at position %d has no target repository", i+1)
+		}
+		if target.SourceRepo == target.TargetRepo {
+			return errorutils.CheckErrorf("the promotion target '%s' is also its source repository", target.TargetRepo)
+		}
+		if seen[target] {
+			return errorutils.CheckErrorf("the promotion target '%s' is repeated", target.TargetRepo)
+		}
+		seen[target] = true
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePromotionTargets(t *testing.T) {
+	assert.Equal(t, []PromotionTarget{{SourceRepo: "staging", TargetRepo: "release"}, {SourceRepo: "staging", TargetRepo: "archive"}}, ParsePromotionTargets("release; archive;", "staging"))
+	assert.Empty(t, ParsePromotionTargets("", ""))
+}
+
+func TestLoadPromotionTargets(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "targets.json")
+	assert.NoError(t, os.WriteFile(specFile, []byte(`{"targets": [{"targetRepo": "release"}, {"sourceRepo": "staging", "targetRepo": "archive"}]}`), 0644))
+	targets, err := LoadPromotionTargets(specFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []PromotionTarget{{TargetRepo: "release"}, {SourceRepo: "staging", TargetRepo: "archive"}}, targets)
+
+	assert.NoError(t, os.WriteFile(specFile, []byte(`{"targets": [`), 0644))
+	_, err = LoadPromotionTargets(specFile)
+	assert.ErrorContains(t, err, "failed to parse the promotion targets spec")
+}
+
+func TestValidatePromotionTargets(t *testing.T) {
+	tests := []struct {
+		name          string
+		targets       []PromotionTarget
+		expectedError string
+	}{
+		{"valid", []PromotionTarget{{TargetRepo: "release"}, {SourceRepo: "staging", TargetRepo: "release"}}, ""},
+		{"empty", nil, "no promotion targets were provided"},
+		{"noTargetRepo", []PromotionTarget{{TargetRepo: "release"}, {SourceRepo: "staging"}}, "the promotion target at position 2 has no target repository"},
+		{"sourceIsTarget", []PromotionTarget{{SourceRepo: "release", TargetRepo: "release"}}, "the promotion target 'release' is also its source repository"},
+		{"repeated", []PromotionTarget{{TargetRepo: "release"}, {TargetRepo: "release"}}, "the promotion target 'release' is repeated"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidatePromotionTargets(test.targets)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedError)
+			}
+		})
+	}
+}
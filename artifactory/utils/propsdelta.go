@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"strings"
+
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Changes to the properties of items - properties to set and property keys to delete.
+type PropsDelta struct {
+	// The properties to set, in the 'key1=value1,value2;key2=value3' format.
+	Set string
+	// The comma separated keys of the properties to delete.
+	Delete string
+}
+
+// Verify the delta has changes, and that no property is both set and deleted.
+func (pd PropsDelta) Validate() error {
+	if pd.Set == "" && pd.Delete == "" {
+		return errorutils.CheckErrorf("no properties to set or delete were provided")
+	}
+	if pd.Set == "" || pd.Delete == "" {
+		return nil
+	}
+	props, err := clientutils.ParseProperties(pd.Set)
+	if err != nil {
+		return err
+	}
+	setProps := props.ToMap()
+	for _, key := range pd.GetDeletedKeys() {
+		if _, exists := setProps[key]; exists {
+			return errorutils.CheckErrorf("the property '%s' can't be both set and deleted", key)
+		}
+	}
+	return nil
+}
+
+func (pd PropsDelta) GetDeletedKeys() (keys []string) {
+	for _, key := range strings.Split(pd.Delete, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return
+}
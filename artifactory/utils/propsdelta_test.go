@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropsDeltaValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		delta         PropsDelta
+		expectedError string
+	}{
+		{"set only", PropsDelta{Set: "a=1;b=2,3"}, ""},
+		{"delete only", PropsDelta{Delete: "a,b"}, ""},
+		{"set and delete", PropsDelta{Set: "a=1", Delete: "b, c"}, ""},
+		{"empty", PropsDelta{}, "no properties to set or delete"},
+		{"conflict", PropsDelta{Set: "a=1;b=2", Delete: "c, b"}, "'b' can't be both set and deleted"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.delta.Validate()
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPropsDeltaGetDeletedKeys(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, PropsDelta{Delete: " a,,b "}.GetDeletedKeys())
+	assert.Empty(t, PropsDelta{}.GetDeletedKeys())
+}
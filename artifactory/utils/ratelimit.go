@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The max number of bytes read at once by a rate limited reader, to keep the transfer rate smooth.
+const rateLimitedReadSize = 32 * utils.SizeKib
+
+var rateLimitRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([KMG]?)B?(?:/S)?$`)
+
+// Parse a transfer rate limit, such as '10MB', '500KB' or '1GB/s', into bytes per second.
+// A number without a unit is considered as bytes. An empty value means no limit, and returns 0.
+func ParseRateLimit(rateLimit string) (int64, error) {
+	rateLimit = strings.TrimSpace(rateLimit)
+	if rateLimit == "" {
+		return 0, nil
+	}
+	match := rateLimitRegex.FindStringSubmatch(strings.ToUpper(rateLimit))
+	if match == nil {
+		return 0, errorutils.CheckErrorf("invalid rate limit '%s'. The rate limit should be a size, such as 10MB or 500KB", rateLimit)
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	switch match[2] {
+	case "K":
+		value *= float64(utils.SizeKib)
+	case "M":
+		value *= float64(utils.SizeMiB)
+	case "G":
+		value *= float64(utils.SizeGiB)
+	}
+	if value < 1 {
+		return 0, errorutils.CheckErrorf("invalid rate limit '%s'. The rate limit should be at least 1 byte per second", rateLimit)
+	}
+	return int64(value), nil
+}
+
+// Paces the transferred bytes of all the readers sharing it, to cap their aggregate bandwidth.
+type bandwidthLimiter struct {
+	bytesPerSecond int64
+	// The time at which the next bytes may be transferred.
+	next  time.Time
+	mutex sync.Mutex
+}
+
+func newBandwidthLimiter(bytesPerSecond int64) *bandwidthLimiter {
+	return &bandwidthLimiter{bytesPerSecond: bytesPerSecond}
+}
+
+// Block until n more bytes may be transferred.
+func (bl *bandwidthLimiter) wait(n int) {
+	bl.mutex.Lock()
+	now := time.Now()
+	if bl.next.Before(now) {
+		bl.next = now
+	}
+	delay := bl.next.Sub(now)
+	bl.next = bl.next.Add(time.Duration(float64(n) / float64(bl.bytesPerSecond) * float64(time.Second)))
+	bl.mutex.Unlock()
+	time.Sleep(delay)
+}
+
+type rateLimitedReadCloser struct {
+	io.ReadCloser
+	limiter *bandwidthLimiter
+}
+
+func (rl *rateLimitedReadCloser) Read(p []byte) (int, error) {
+	if int64(len(p)) > rateLimitedReadSize {
+		p = p[:rateLimitedReadSize]
+	}
+	n, err := rl.ReadCloser.Read(p)
+	if n > 0 {
+		rl.limiter.wait(n)
+	}
+	return n, err
+}
+
+// An http.RoundTripper limiting the bandwidth of both the uploaded request bodies and the downloaded response bodies.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *bandwidthLimiter
+}
+
+func (rt *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		// A RoundTripper should not modify the original request.
+		req = req.Clone(req.Context())
+		req.Body = &rateLimitedReadCloser{ReadCloser: req.Body, limiter: rt.limiter}
+	}
+	resp, err := rt.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+	resp.Body = &rateLimitedReadCloser{ReadCloser: resp.Body, limiter: rt.limiter}
+	return resp, nil
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		rateLimit string
+		expected  int64
+		expectErr bool
+	}{
+		{"", 0, false},
+		{"1024", 1024, false},
+		{"500KB", 500 * 1024, false},
+		{"500kb", 500 * 1024, false},
+		{"10MB", 10 * 1024 * 1024, false},
+		{"1.5M", 1536 * 1024, false},
+		{"1GB/s", 1024 * 1024 * 1024, false},
+		{"10 MB", 10 * 1024 * 1024, false},
+		{"0", 0, true},
+		{"-1MB", 0, true},
+		{"10TB", 0, true},
+		{"fast", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.rateLimit, func(t *testing.T) {
+			rateLimit, err := ParseRateLimit(test.rateLimit)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, rateLimit)
+		})
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	const bytesPerSecond = 512 * 1024
+	content := bytes.Repeat([]byte("a"), 128*1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		_, err = w.Write(body)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitedTransport{base: http.DefaultTransport, limiter: newBandwidthLimiter(bytesPerSecond)}}
+	start := time.Now()
+	resp, err := client.Post(server.URL, "application/octet-stream", bytes.NewReader(content))
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, content, body)
+
+	// Both the request and the response bodies count towards the limit: 256KB at 512KB/s.
+	// The last chunk isn't paced, so allow some tolerance.
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	"golang.org/x/exp/slices"
 	"path"
+	"regexp"
 	"strings"
 
 	"github.com/jfrog/jfrog-client-go/artifactory"
@@ -167,6 +168,44 @@ func filterRepositoryNames(repoKeys []string, includePatterns, excludePatterns [
 	return includedRepos.ToSlice(), nil
 }
 
+// FilterRepositoryNamesByRegex returns the repository names that match the include regular expression and don't match the exclude regular expression.
+// An empty regular expression is ignored.
+func FilterRepositoryNamesByRegex(repoKeys []string, includeRegex, excludeRegex string) ([]string, error) {
+	if includeRegex == "" && excludeRegex == "" {
+		return repoKeys, nil
+	}
+	includeRegexp, err := compileRepositoryRegex(includeRegex)
+	if err != nil {
+		return nil, err
+	}
+	excludeRegexp, err := compileRepositoryRegex(excludeRegex)
+	if err != nil {
+		return nil, err
+	}
+	filteredRepos := make([]string, 0, len(repoKeys))
+	for _, repoKey := range repoKeys {
+		if includeRegexp != nil && !includeRegexp.MatchString(repoKey) {
+			continue
+		}
+		if excludeRegexp != nil && excludeRegexp.MatchString(repoKey) {
+			continue
+		}
+		filteredRepos = append(filteredRepos, repoKey)
+	}
+	return filteredRepos, nil
+}
+
+func compileRepositoryRegex(repoRegex string) (*regexp.Regexp, error) {
+	if repoRegex == "" {
+		return nil, nil
+	}
+	compiled, err := regexp.Compile(repoRegex)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid repositories regular expression '%s': %s", repoRegex, err.Error())
+	}
+	return compiled, nil
+}
+
 type IncludeExcludeFilter struct {
 	IncludePatterns []string
 	ExcludePatterns []string
@@ -60,3 +60,28 @@ func TestShouldIncludeRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterRepositoryNamesByRegex(t *testing.T) {
+	repos := []string{"jfrog-docker-local", "jfrog-npm-local", "docker-local", "jfrog-maven-local-2"}
+	testCases := []struct {
+		name          string
+		includeRegex  string
+		excludeRegex  string
+		expectedRepos []string
+	}{
+		{name: "No regex", expectedRepos: repos},
+		{name: "Include", includeRegex: "^jfrog-.*-local$", expectedRepos: []string{"jfrog-docker-local", "jfrog-npm-local"}},
+		{name: "Exclude", excludeRegex: "docker", expectedRepos: []string{"jfrog-npm-local", "jfrog-maven-local-2"}},
+		{name: "Include and exclude", includeRegex: "^jfrog-", excludeRegex: "docker|maven", expectedRepos: []string{"jfrog-npm-local"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := FilterRepositoryNamesByRegex(repos, testCase.includeRegex, testCase.excludeRegex)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedRepos, actual)
+		})
+	}
+
+	_, err := FilterRepositoryNamesByRegex(repos, "jfrog-(", "")
+	assert.ErrorContains(t, err, "invalid repositories regular expression")
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The retry policy of HTTP requests.
+// The wait time before each retry is multiplied by BackoffFactor, and randomized by up to Jitter of its value,
+// so that many clients failing together don't retry together against a struggling server.
+type RetryPolicy struct {
+	Retries int
+	// The wait time before the first retry.
+	WaitTime time.Duration
+	// The factor by which the wait time grows after each retry. A value of 1 or less keeps the wait time fixed.
+	BackoffFactor float64
+	// A fraction between 0 and 1, by which the wait time is randomly increased or decreased.
+	Jitter float64
+}
+
+// Returns true if the policy requires exponential backoff or jitter, which the default HTTP client retries don't support.
+func (rp RetryPolicy) isCustom() bool {
+	return rp.BackoffFactor > 1 || rp.Jitter > 0
+}
+
+// Returns the wait time before the retry with the given index, starting from 0.
+func (rp RetryPolicy) getWaitTime(retry int) time.Duration {
+	waitTime := float64(rp.WaitTime)
+	if rp.BackoffFactor > 1 {
+		waitTime *= math.Pow(rp.BackoffFactor, float64(retry))
+	}
+	if rp.Jitter > 0 {
+		// #nosec G404 -- The jitter doesn't require a cryptographically secure random number.
+		waitTime += waitTime * rp.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(waitTime)
+}
+
+// An http.RoundTripper retrying failed requests according to a retry policy.
+// Requests are retried on connection errors, server errors and when the server is rate limiting the client.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+}
+
+func (rt *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	for retry := 0; ; retry++ {
+		resp, err = rt.base.RoundTrip(req)
+		if !shouldRetry(resp, err) || retry >= rt.policy.Retries {
+			return
+		}
+		// Requests with a body can only be retried if the body can be recreated.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		if resp != nil {
+			// Close the failed response before retrying, to release its connection.
+			_ = resp.Body.Close()
+		}
+		waitTime := rt.policy.getWaitTime(retry)
+		log.Debug("Retrying request to", req.URL.String(), "in", waitTime.String(), "- attempt", retry+1, "of", rt.policy.Retries)
+		select {
+		case <-time.After(waitTime):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
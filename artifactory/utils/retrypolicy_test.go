@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyGetWaitTime(t *testing.T) {
+	fixed := RetryPolicy{WaitTime: time.Second}
+	assert.Equal(t, time.Second, fixed.getWaitTime(0))
+	assert.Equal(t, time.Second, fixed.getWaitTime(3))
+
+	backoff := RetryPolicy{WaitTime: time.Second, BackoffFactor: 2}
+	assert.Equal(t, time.Second, backoff.getWaitTime(0))
+	assert.Equal(t, 2*time.Second, backoff.getWaitTime(1))
+	assert.Equal(t, 8*time.Second, backoff.getWaitTime(3))
+
+	jitter := RetryPolicy{WaitTime: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		waitTime := jitter.getWaitTime(0)
+		assert.GreaterOrEqual(t, waitTime, 500*time.Millisecond)
+		assert.LessOrEqual(t, waitTime, 1500*time.Millisecond)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int
+		retries          int
+		expectedAttempts int
+		expectedStatus   int
+	}{
+		{"no failures", 0, 3, 1, http.StatusOK},
+		{"recovers", 2, 3, 3, http.StatusOK},
+		{"retries exhausted", 5, 2, 3, http.StatusServiceUnavailable},
+		{"no retries", 1, 0, 1, http.StatusServiceUnavailable},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				// The body should be replayed on every attempt.
+				assert.Equal(t, "content", string(body))
+				if attempts <= test.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &retryTransport{base: http.DefaultTransport, policy: RetryPolicy{Retries: test.retries, WaitTime: time.Millisecond, BackoffFactor: 2, Jitter: 0.1}}}
+			resp, err := client.Post(server.URL, "text/plain", strings.NewReader("content"))
+			assert.NoError(t, err)
+			assert.NoError(t, resp.Body.Close())
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			assert.Equal(t, test.expectedAttempts, attempts)
+		})
+	}
+}
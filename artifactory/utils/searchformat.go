@@ -0,0 +1,338 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// The output format of the search results.
+type SearchOutputFormat string
+
+const (
+	// A JSON array, the default format.
+	SearchFormatJson SearchOutputFormat = "json"
+	// A JSON object per line.
+	SearchFormatJsonl SearchOutputFormat = "jsonl"
+	SearchFormatCsv   SearchOutputFormat = "csv"
+	SearchFormatTable SearchOutputFormat = "table"
+	SearchFormatYaml  SearchOutputFormat = "yaml"
+)
+
+func GetSearchOutputFormats() []string {
+	return []string{string(SearchFormatJson), string(SearchFormatJsonl), string(SearchFormatCsv), string(SearchFormatTable), string(SearchFormatYaml)}
+}
+
+// The columns of the search results, named after their JSON keys.
+var searchResultColumns = []string{"path", "type", "size", "created", "modified", "sha1", "sha256", "md5", "original_md5", "modified_by", "updated", "created_by", "original_sha1", "depth", "props"}
+
+// The columns printed in the csv and table formats, if no columns are selected.
+var defaultSearchResultTabularColumns = []string{"path", "type", "size", "modified", "sha1"}
+
+func GetSearchResultColumns() []string {
+	return slices.Clone(searchResultColumns)
+}
+
+// Verify the selected columns are known search result columns.
+func ValidateSearchResultColumns(columns []string) error {
+	for _, column := range columns {
+		if !slices.Contains(searchResultColumns, column) {
+			return errorutils.CheckErrorf("unknown search result column '%s'. Available columns: %s", column, strings.Join(searchResultColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// Returns the value of the column. Empty texts and properties are returned as nil, while the numeric columns keep their zero values,
+// since empty files have a zero size and the results in the root of a repository have a zero depth.
+func (sr *SearchResult) getColumnValue(column string) any {
+	var value any
+	switch column {
+	case "path":
+		value = sr.Path
+	case "type":
+		value = sr.Type
+	case "size":
+		value = sr.Size
+	case "created":
+		value = sr.Created
+	case "modified":
+		value = sr.Modified
+	case "sha1":
+		value = sr.Sha1
+	case "sha256":
+		value = sr.Sha256
+	case "md5":
+		value = sr.Md5
+	case "original_md5":
+		value = sr.OriginalMd5
+	case "modified_by":
+		value = sr.ModifiedBy
+	case "updated":
+		value = sr.Updated
+	case "created_by":
+		value = sr.CreatedBy
+	case "original_sha1":
+		value = sr.OriginalSha1
+	case "depth":
+		value = sr.Depth
+	case "props":
+		if len(sr.Props) > 0 {
+			return sr.Props
+		}
+		return nil
+	}
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// Returns the value of the column as a single line of text.
+// Properties are formatted as 'key1=value1,value2;key2=value3', sorted by their keys.
+func (sr *SearchResult) getColumnText(column string) string {
+	switch value := sr.getColumnValue(column).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case int:
+		return strconv.Itoa(value)
+	case map[string][]string:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		props := make([]string, 0, len(keys))
+		for _, key := range keys {
+			props = append(props, key+"="+strings.Join(value[key], ","))
+		}
+		return strings.Join(props, ";")
+	}
+	return ""
+}
+
+// Print the search results in the given format, with the selected columns only.
+// If no columns are selected, the json, jsonl and yaml formats include all the non-empty columns,
+// and the csv and table formats include the default columns.
+// The results are read and printed one by one, so that large result sets aren't loaded into the memory.
+func PrintSearchResultsWithFormat(reader *content.ContentReader, format SearchOutputFormat, columns []string) error {
+	return writeSearchResults(reader, format, columns, func(text string) {
+		log.Output(text)
+	})
+}
+
+func writeSearchResults(reader *content.ContentReader, format SearchOutputFormat, columns []string, output func(text string)) (err error) {
+	if err = ValidateSearchResultColumns(columns); err != nil {
+		return
+	}
+	switch format {
+	case "", SearchFormatJson:
+		if len(columns) == 0 {
+			return PrintSearchResults(reader)
+		}
+		err = writeSearchResultsJson(reader, columns, output)
+	case SearchFormatJsonl:
+		err = forEachSearchResult(reader, func(result *SearchResult) error {
+			line, e := searchResultToJson(result, columns)
+			output(line)
+			return e
+		})
+	case SearchFormatYaml:
+		err = forEachSearchResult(reader, func(result *SearchResult) error {
+			text, e := searchResultToYaml(result, columns)
+			output(text)
+			return e
+		})
+	case SearchFormatCsv:
+		err = writeSearchResultsCsv(reader, getTabularColumns(columns), output)
+	case SearchFormatTable:
+		err = writeSearchResultsTable(reader, getTabularColumns(columns), output)
+	default:
+		return errorutils.CheckErrorf("unsupported search output format '%s'. Supported formats: %s", format, strings.Join(GetSearchOutputFormats(), ", "))
+	}
+	return
+}
+
+func getTabularColumns(columns []string) []string {
+	if len(columns) == 0 {
+		return defaultSearchResultTabularColumns
+	}
+	return columns
+}
+
+// Call handle with each of the search results, and reset the reader once done.
+func forEachSearchResult(reader *content.ContentReader, handle func(result *SearchResult) error) error {
+	for result := new(SearchResult); reader.NextRecord(result) == nil; result = new(SearchResult) {
+		if err := handle(result); err != nil {
+			return err
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	return nil
+}
+
+// Returns the columns of the result and their values. If no columns are selected, returns the non-empty columns.
+// The depth is returned only if it's positive, since it's set only when requested, and the size is returned for files only.
+func getSearchResultFields(result *SearchResult, columns []string) (fields []string, values []any) {
+	if len(columns) > 0 {
+		for _, column := range columns {
+			fields = append(fields, column)
+			values = append(values, result.getColumnValue(column))
+		}
+		return
+	}
+	for _, column := range searchResultColumns {
+		if (column == "depth" && result.Depth == 0) || (column == "size" && result.Type == "folder") {
+			continue
+		}
+		if value := result.getColumnValue(column); value != nil {
+			fields = append(fields, column)
+			values = append(values, value)
+		}
+	}
+	return
+}
+
+// Serialize the result as a single line JSON object, keeping the order of the columns.
+func searchResultToJson(result *SearchResult, columns []string) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	fields, values := getSearchResultFields(result, columns)
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		buf.WriteString(strconv.Quote(field) + ":")
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.String(), nil
+}
+
+// Serialize the result as an item of a YAML sequence, keeping the order of the columns.
+func searchResultToYaml(result *SearchResult, columns []string) (string, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	fields, values := getSearchResultFields(result, columns)
+	for i, field := range fields {
+		valueNode := new(yaml.Node)
+		if err := valueNode.Encode(values[i]); err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field}, valueNode)
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{mapping}}); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Print the results as a JSON array of objects holding the selected columns.
+func writeSearchResultsJson(reader *content.ContentReader, columns []string, output func(text string)) error {
+	output("[")
+	var previous string
+	err := forEachSearchResult(reader, func(result *SearchResult) error {
+		// Each result is printed once the next one is read, to know whether a comma should follow it.
+		if previous != "" {
+			output("  " + previous + ",")
+		}
+		var e error
+		previous, e = searchResultToJson(result, columns)
+		return e
+	})
+	if previous != "" {
+		output("  " + previous)
+	}
+	output("]")
+	return err
+}
+
+func writeSearchResultsCsv(reader *content.ContentReader, columns []string, output func(text string)) error {
+	var buf bytes.Buffer
+	csvWriter := csv.NewWriter(&buf)
+	writeLine := func(record []string) error {
+		buf.Reset()
+		if err := csvWriter.Write(record); err != nil {
+			return errorutils.CheckError(err)
+		}
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return errorutils.CheckError(err)
+		}
+		output(strings.TrimSuffix(buf.String(), "\n"))
+		return nil
+	}
+	if err := writeLine(columns); err != nil {
+		return err
+	}
+	return forEachSearchResult(reader, func(result *SearchResult) error {
+		return writeLine(getSearchResultRow(result, columns))
+	})
+}
+
+// Print the results as an aligned table.
+// The results are read twice - first to calculate the width of the columns, and then to print them.
+func writeSearchResultsTable(reader *content.ContentReader, columns []string, output func(text string)) error {
+	header := make([]string, len(columns))
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+		widths[i] = utf8.RuneCountInString(header[i])
+	}
+	err := forEachSearchResult(reader, func(result *SearchResult) error {
+		for i, cell := range getSearchResultRow(result, columns) {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	output(formatTableRow(header, widths))
+	return forEachSearchResult(reader, func(result *SearchResult) error {
+		output(formatTableRow(getSearchResultRow(result, columns), widths))
+		return nil
+	})
+}
+
+func getSearchResultRow(result *SearchResult, columns []string) []string {
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		row[i] = result.getColumnText(column)
+	}
+	return row
+}
+
+func formatTableRow(cells []string, widths []int) string {
+	var row strings.Builder
+	for i, cell := range cells {
+		row.WriteString(cell)
+		if i < len(cells)-1 {
+			row.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+		}
+	}
+	return row.String()
+}
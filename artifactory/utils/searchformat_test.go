@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSearchResults(t *testing.T) {
+	tests := []struct {
+		format   SearchOutputFormat
+		columns  []string
+		expected string
+	}{
+		{SearchFormatJson, []string{"path", "size"}, `[
+  {"path":"jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in","size":11},
+  {"path":"jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in","size":11},
+  {"path":"jfrog-cli-tests-repo1-1595270324/a/b/b2.in","size":9},
+  {"path":"jfrog-cli-tests-repo1-1595270324/a/b/b3.in","size":9},
+  {"path":"jfrog-cli-tests-repo1-1595270324/a/a3.in","size":7}
+]`},
+		{SearchFormatJsonl, []string{"path", "props"}, `{"path":"jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in","props":{"c":["3"]}}
+{"path":"jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in","props":{"c":["3"]}}
+{"path":"jfrog-cli-tests-repo1-1595270324/a/b/b2.in","props":{"b":["1"],"c":["3"]}}
+{"path":"jfrog-cli-tests-repo1-1595270324/a/b/b3.in","props":{"a":["1"],"b":["2"],"c":["3"]}}
+{"path":"jfrog-cli-tests-repo1-1595270324/a/a3.in","props":{"a":["1"],"b":["3"],"c":["3"]}}`},
+		{SearchFormatCsv, []string{"path", "size", "props"}, `path,size,props
+jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in,11,c=3
+jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in,11,c=3
+jfrog-cli-tests-repo1-1595270324/a/b/b2.in,9,b=1;c=3
+jfrog-cli-tests-repo1-1595270324/a/b/b3.in,9,a=1;b=2;c=3
+jfrog-cli-tests-repo1-1595270324/a/a3.in,7,a=1;b=3;c=3`},
+		{SearchFormatTable, []string{"path", "size", "md5"}, `PATH                                          SIZE  MD5
+jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in  11    82b6d565393a3fd1cc4778b1d53c0664
+jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in  11    d8020b86244956f647cf1beff5acdb90
+jfrog-cli-tests-repo1-1595270324/a/b/b2.in    9     6931271be1e5f98e36bdc7a05097407b
+jfrog-cli-tests-repo1-1595270324/a/b/b3.in    9     305b21db102cf3a3d2d8c3f7e9584dba
+jfrog-cli-tests-repo1-1595270324/a/a3.in      7     73c046196302ff7218d47046cf3c0501`},
+		{SearchFormatYaml, []string{"path", "type", "props"}, `- path: jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in
+  type: file
+  props:
+    c:
+      - "3"
+- path: jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in
+  type: file
+  props:
+    c:
+      - "3"
+- path: jfrog-cli-tests-repo1-1595270324/a/b/b2.in
+  type: file
+  props:
+    b:
+      - "1"
+    c:
+      - "3"
+- path: jfrog-cli-tests-repo1-1595270324/a/b/b3.in
+  type: file
+  props:
+    a:
+      - "1"
+    b:
+      - "2"
+    c:
+      - "3"
+- path: jfrog-cli-tests-repo1-1595270324/a/a3.in
+  type: file
+  props:
+    a:
+      - "1"
+    b:
+      - "3"
+    c:
+      - "3"`},
+	}
+	testdataPath, err := GetTestDataPath()
+	assert.NoError(t, err)
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			reader := content.NewContentReader(filepath.Join(testdataPath, "search_results.json"), content.DefaultKey)
+			var lines []string
+			assert.NoError(t, writeSearchResults(reader, test.format, test.columns, func(text string) {
+				lines = append(lines, text)
+			}))
+			assert.Equal(t, test.expected, strings.Join(lines, "\n"))
+		})
+	}
+}
+
+func TestWriteSearchResultsDefaultColumns(t *testing.T) {
+	testdataPath, err := GetTestDataPath()
+	assert.NoError(t, err)
+	reader := content.NewContentReader(filepath.Join(testdataPath, "search_results.json"), content.DefaultKey)
+	var lines []string
+	assert.NoError(t, writeSearchResults(reader, SearchFormatJsonl, nil, func(text string) {
+		lines = append(lines, text)
+	}))
+	if assert.Len(t, lines, 5) {
+		assert.Equal(t, `{"path":"jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in","type":"file","size":11,"created":"2020-07-20T21:39:38.374+03:00","modified":"2020-07-20T21:39:38.332+03:00","sha1":"a4f912be11e7d1d346e34c300e6d4b90e136896e","md5":"82b6d565393a3fd1cc4778b1d53c0664","props":{"c":["3"]}}`, lines[0])
+	}
+
+	lines = nil
+	assert.NoError(t, writeSearchResults(reader, SearchFormatCsv, nil, func(text string) {
+		lines = append(lines, text)
+	}))
+	if assert.Len(t, lines, 6) {
+		assert.Equal(t, "path,type,size,modified,sha1", lines[0])
+	}
+}
+
+func TestWriteSearchResultsInvalidInput(t *testing.T) {
+	reader := content.NewEmptyContentReader(content.DefaultKey)
+	output := func(string) {}
+	assert.ErrorContains(t, writeSearchResults(reader, "xml", nil, output), "unsupported search output format 'xml'")
+	assert.ErrorContains(t, writeSearchResults(reader, SearchFormatCsv, []string{"path", "owner"}, output), "unknown search result column 'owner'")
+}
+
+func TestSearchResultZeroColumns(t *testing.T) {
+	emptyFile := &SearchResult{Path: "repo/empty.txt", Type: "file"}
+	assert.Equal(t, []string{"repo/empty.txt", "0", "0"}, getSearchResultRow(emptyFile, []string{"path", "size", "depth"}))
+	fields, values := getSearchResultFields(emptyFile, nil)
+	assert.Equal(t, []string{"path", "type", "size"}, fields)
+	assert.Equal(t, []any{"repo/empty.txt", "file", int64(0)}, values)
+
+	folder := &SearchResult{Path: "repo/folder", Type: "folder", Depth: 1}
+	fields, _ = getSearchResultFields(folder, nil)
+	assert.Equal(t, []string{"path", "type", "depth"}, fields)
+}
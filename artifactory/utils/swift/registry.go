@@ -0,0 +1,111 @@
+package swift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The registries configuration of a package, which takes precedence over the global configuration of the user.
+	RegistriesConfigPath = ".swiftpm/configuration/registries.json"
+	// The key of the default registry in registries.json, which resolves the packages of all the scopes with no registry of their own.
+	defaultRegistryKey = "[default]"
+)
+
+func GetExecutablePath() (string, error) {
+	executablePath, err := exec.LookPath("swift")
+	if err != nil {
+		return "", errorutils.CheckErrorf("couldn't find the swift executable in the PATH: %s", err.Error())
+	}
+	log.Debug("Found swift executable at:", executablePath)
+	return executablePath, nil
+}
+
+// Returns the URL of an Artifactory Swift repository.
+func GetRegistryUrl(artifactoryUrl, repo string) string {
+	return clientutils.AddTrailingSlashIfNeeded(artifactoryUrl) + "api/swift/" + repo
+}
+
+// Set the registry as the default registry of the registries.json content. The other settings, such as the registries of specific scopes, are kept.
+func SetDefaultRegistry(registriesConfig []byte, registryUrl string) ([]byte, error) {
+	content := map[string]any{}
+	if len(registriesConfig) > 0 {
+		if err := json.Unmarshal(registriesConfig, &content); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse %s: %s", RegistriesConfigPath, err.Error())
+		}
+	}
+	registries, ok := content["registries"].(map[string]any)
+	if !ok {
+		registries = map[string]any{}
+	}
+	registries[defaultRegistryKey] = map[string]any{"url": registryUrl}
+	content["registries"] = registries
+	content["version"] = 1
+	return json.MarshalIndent(content, "", "  ")
+}
+
+// Returns the content of a .netrc file holding the credentials of the registry. swift reads the file by the '--netrc-file' option,
+// so the credentials aren't stored in the keychain of the user.
+func CreateNetrc(serverDetails *config.ServerDetails, registryUrl string) (string, error) {
+	parsedUrl, err := url.Parse(registryUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	user, password := serverDetails.GetUser(), serverDetails.GetPassword()
+	if serverDetails.GetAccessToken() != "" {
+		if user == "" {
+			user = auth.ExtractUsernameFromAccessToken(serverDetails.GetAccessToken())
+		}
+		password = serverDetails.GetAccessToken()
+	}
+	if user == "" || password == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("machine %s\nlogin %s\npassword %s\n", parsedUrl.Hostname(), user, password), nil
+}
+
+// Returns the checksums of the source archive of a package version in Artifactory, or an empty checksum if it can't be found.
+func GetDependencyChecksum(servicesManager artifactory.ArtifactoryServicesManager, repo, identity, version string) (checksum buildinfo.Checksum, err error) {
+	scope, name := SplitIdentity(identity)
+	stream, err := servicesManager.Aql(createAqlQueryForSwift(repo, scope, name, version))
+	if err != nil {
+		return
+	}
+	defer ioutils.Close(stream, &err)
+	result, err := io.ReadAll(stream)
+	if err != nil {
+		return
+	}
+	parsedResult := new(aqlResult)
+	if err = errorutils.CheckError(json.Unmarshal(result, parsedResult)); err != nil {
+		return
+	}
+	if len(parsedResult.Results) == 0 {
+		log.Debug(fmt.Sprintf("The source archive of %s %s could not be found in repository: %s", identity, version, repo))
+		return
+	}
+	item := parsedResult.Results[0]
+	return buildinfo.Checksum{Sha1: item.Actual_Sha1, Md5: item.Actual_Md5, Sha256: item.Sha256}, nil
+}
+
+func createAqlQueryForSwift(repo, scope, name, version string) string {
+	return fmt.Sprintf(`items.find({"repo":"%s","path":{"$match":"%s/%s*"},"name":{"$match":"*%s.zip"}}).include("name","repo","path","actual_md5","actual_sha1","sha256")`,
+		repo, scope, name, version)
+}
+
+type aqlResult struct {
+	Results []*servicesutils.ResultItem `json:"results,omitempty"`
+}
@@ -0,0 +1,45 @@
+package swift
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRegistryUrl(t *testing.T) {
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/api/swift/swift-virtual", GetRegistryUrl("https://acme.jfrog.io/artifactory", "swift-virtual"))
+}
+
+func TestSetDefaultRegistry(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		expected string
+	}{
+		{"no config", "", `{"registries":{"[default]":{"url":"https://acme.jfrog.io/artifactory/api/swift/swift-virtual"}},"version":1}`},
+		{
+			"scoped registry",
+			`{"registries":{"[default]":{"url":"https://example.com"},"mona":{"url":"https://mona.example.com"}},"authentication":{"example.com":{"type":"token"}},"version":1}`,
+			`{"authentication":{"example.com":{"type":"token"}},"registries":{"[default]":{"url":"https://acme.jfrog.io/artifactory/api/swift/swift-virtual"},"mona":{"url":"https://mona.example.com"}},"version":1}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registriesConfig, err := SetDefaultRegistry([]byte(test.existing), "https://acme.jfrog.io/artifactory/api/swift/swift-virtual")
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(registriesConfig))
+		})
+	}
+}
+
+func TestCreateNetrc(t *testing.T) {
+	netrc, err := CreateNetrc(&config.ServerDetails{User: "user", Password: "password"}, "https://acme.jfrog.io/artifactory/api/swift/swift-virtual")
+	require.NoError(t, err)
+	assert.Equal(t, "machine acme.jfrog.io\nlogin user\npassword password\n", netrc)
+
+	netrc, err = CreateNetrc(&config.ServerDetails{}, "https://acme.jfrog.io/artifactory/api/swift/swift-virtual")
+	require.NoError(t, err)
+	assert.Empty(t, netrc)
+}
@@ -0,0 +1,135 @@
+package swift
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+const (
+	ResolvedFileName = "Package.resolved"
+	// The prefix of the IDs of Swift components in Xray.
+	SwiftPackageTypeIdentifier = "swift://"
+
+	registryPinKind = "registry"
+)
+
+// The content of Package.resolved, in its versions 1 (Swift 5.5 and below), 2 and 3.
+type ResolvedFile struct {
+	Version int `json:"version"`
+	// The pins of versions 2 and 3.
+	Pins []Pin `json:"pins"`
+	// The pins of version 1.
+	Object struct {
+		Pins []Pin `json:"pins"`
+	} `json:"object"`
+}
+
+// The version a package is pinned to.
+type Pin struct {
+	// The identity of the package, e.g. 'swift-argument-parser', or 'mona.linkedlist' for packages resolved from a registry.
+	Identity string `json:"identity"`
+	// 'registry', 'remoteSourceControl' or 'localSourceControl'.
+	Kind     string `json:"kind"`
+	Location string `json:"location"`
+	// The name and the location of the package in version 1.
+	Package       string `json:"package"`
+	RepositoryUrl string `json:"repositoryURL"`
+	State         struct {
+		Version  string `json:"version"`
+		Revision string `json:"revision"`
+		Branch   string `json:"branch"`
+	} `json:"state"`
+}
+
+func (pin *Pin) isFromRegistry() bool {
+	return pin.Kind == registryPinKind
+}
+
+// Returns the ID of the pinned package, without its version.
+// Packages resolved from a registry are identified by their 'scope.name' identity, and packages resolved from source control by their location,
+// e.g. 'github.com/apple/swift-argument-parser'.
+func (pin *Pin) packageId() string {
+	if pin.isFromRegistry() {
+		return pin.Identity
+	}
+	location := pin.Location
+	if location == "" {
+		location = pin.RepositoryUrl
+	}
+	if _, withoutScheme, found := strings.Cut(location, "://"); found {
+		location = withoutScheme
+	} else if _, scpPath, found := strings.Cut(location, "@"); found {
+		// An SSH location, e.g. 'git@github.com:apple/swift-nio.git'.
+		location = strings.Replace(scpPath, ":", "/", 1)
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+}
+
+// Read the Package.resolved file of the package in the directory.
+func ReadResolvedFile(packageDir string) (*ResolvedFile, error) {
+	content, err := os.ReadFile(filepath.Join(packageDir, ResolvedFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("couldn't find %s in '%s'. Run 'swift package resolve' to create it", ResolvedFileName, packageDir)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseResolvedFile(content)
+}
+
+func ParseResolvedFile(content []byte) (*ResolvedFile, error) {
+	resolvedFile := new(ResolvedFile)
+	if err := json.Unmarshal(content, resolvedFile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", ResolvedFileName, err.Error())
+	}
+	if resolvedFile.Version == 1 {
+		resolvedFile.Pins = resolvedFile.Object.Pins
+		for i := range resolvedFile.Pins {
+			resolvedFile.Pins[i].Identity = strings.ToLower(resolvedFile.Pins[i].Package)
+		}
+	}
+	slices.SortFunc(resolvedFile.Pins, func(a, b Pin) int {
+		return strings.Compare(a.packageId(), b.packageId())
+	})
+	return resolvedFile, nil
+}
+
+// Returns the packages resolved from a registry, by their 'scope.name:version' IDs.
+// Package.resolved holds the pinned packages without the relations between them, so all the packages are recorded as requested by the module.
+// Packages resolved from source control can't be found in Artifactory, and aren't recorded.
+func (resolvedFile *ResolvedFile) GetDependencies(moduleId string) []buildinfo.Dependency {
+	var dependencies []buildinfo.Dependency
+	for _, pin := range resolvedFile.Pins {
+		if !pin.isFromRegistry() {
+			continue
+		}
+		dependencies = append(dependencies, buildinfo.Dependency{Id: pin.packageId() + ":" + pin.State.Version, RequestedBy: [][]string{{moduleId}}})
+	}
+	return dependencies
+}
+
+// Returns the dependency tree of the package for auditing, with all the pinned packages as direct dependencies of the root.
+// Packages pinned to a branch or a revision, rather than to a version, can't be matched with vulnerabilities and are skipped.
+func (resolvedFile *ResolvedFile) GetDependencyTree(moduleId string) *xrayUtils.GraphNode {
+	root := &xrayUtils.GraphNode{Id: SwiftPackageTypeIdentifier + moduleId, Nodes: []*xrayUtils.GraphNode{}}
+	for _, pin := range resolvedFile.Pins {
+		if pin.State.Version == "" {
+			continue
+		}
+		root.Nodes = append(root.Nodes, &xrayUtils.GraphNode{Id: SwiftPackageTypeIdentifier + pin.packageId() + ":" + pin.State.Version, Parent: root})
+	}
+	return root
+}
+
+// Returns the scope and the name of a package resolved from a registry, by its identity.
+func SplitIdentity(identity string) (scope, name string) {
+	scope, name, _ = strings.Cut(identity, ".")
+	return
+}
@@ -0,0 +1,127 @@
+package swift
+
+import (
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResolvedFileV2 = `{
+  "originHash" : "abc",
+  "pins" : [
+    {
+      "identity" : "swift-argument-parser",
+      "kind" : "remoteSourceControl",
+      "location" : "https://github.com/apple/swift-argument-parser.git",
+      "state" : {
+        "revision" : "46989693916f56d1186bd59ac15124caef896560",
+        "version" : "1.3.1"
+      }
+    },
+    {
+      "identity" : "mona.linkedlist",
+      "kind" : "registry",
+      "location" : "",
+      "state" : {
+        "version" : "1.1.0"
+      }
+    },
+    {
+      "identity" : "swift-nio",
+      "kind" : "remoteSourceControl",
+      "location" : "git@github.com:apple/swift-nio.git",
+      "state" : {
+        "branch" : "main",
+        "revision" : "fc63f0cf4e55a4597407a9fc95b16a2bc44b4982"
+      }
+    },
+    {
+      "identity" : "apple.swift-collections",
+      "kind" : "registry",
+      "location" : "",
+      "state" : {
+        "version" : "1.0.6"
+      }
+    }
+  ],
+  "version" : 3
+}`
+
+const testResolvedFileV1 = `{
+  "object": {
+    "pins": [
+      {
+        "package": "SwiftProtobuf",
+        "repositoryURL": "https://github.com/apple/swift-protobuf",
+        "state": {
+          "branch": null,
+          "revision": "7e2c5f3cbbeea68e004915e3a8961e20bd11d824",
+          "version": "1.18.0"
+        }
+      }
+    ]
+  },
+  "version": 1
+}`
+
+func TestGetDependencies(t *testing.T) {
+	resolvedFile, err := ParseResolvedFile([]byte(testResolvedFileV2))
+	require.NoError(t, err)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "apple.swift-collections:1.0.6", RequestedBy: [][]string{{"my-app"}}},
+		{Id: "mona.linkedlist:1.1.0", RequestedBy: [][]string{{"my-app"}}},
+	}, resolvedFile.GetDependencies("my-app"))
+}
+
+func TestGetDependencyTree(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedIds []string
+	}{
+		{"v3", testResolvedFileV2, []string{
+			"swift://apple.swift-collections:1.0.6",
+			"swift://github.com/apple/swift-argument-parser:1.3.1",
+			"swift://mona.linkedlist:1.1.0",
+		}},
+		{"v1", testResolvedFileV1, []string{"swift://github.com/apple/swift-protobuf:1.18.0"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolvedFile, err := ParseResolvedFile([]byte(test.content))
+			require.NoError(t, err)
+			tree := resolvedFile.GetDependencyTree("my-app")
+			assert.Equal(t, "swift://my-app", tree.Id)
+			var ids []string
+			for _, node := range tree.Nodes {
+				ids = append(ids, node.Id)
+				assert.Equal(t, tree, node.Parent)
+			}
+			assert.Equal(t, test.expectedIds, ids)
+		})
+	}
+}
+
+func TestPackageId(t *testing.T) {
+	tests := []struct {
+		pin      Pin
+		expected string
+	}{
+		{Pin{Identity: "mona.linkedlist", Kind: registryPinKind}, "mona.linkedlist"},
+		{Pin{Location: "https://github.com/apple/swift-nio.git"}, "github.com/apple/swift-nio"},
+		{Pin{Location: "git@github.com:apple/swift-nio.git"}, "github.com/apple/swift-nio"},
+		{Pin{RepositoryUrl: "https://github.com/apple/swift-protobuf/"}, "github.com/apple/swift-protobuf"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.pin.packageId())
+		})
+	}
+}
+
+func TestParseResolvedFileInvalid(t *testing.T) {
+	_, err := ParseResolvedFile([]byte("{"))
+	assert.ErrorContains(t, err, "failed to parse Package.resolved")
+}
@@ -0,0 +1,68 @@
+package terraform
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The infrastructure as code tool the modules are written for and consumed by.
+// Not to be confused with the provider of a module, which is the platform the module manages, such as 'aws'.
+type Provider string
+
+const (
+	TerraformProvider Provider = "terraform"
+	OpenTofuProvider  Provider = "opentofu"
+	// The key of the provider in the terraform config file.
+	ProviderConfigKey = "provider"
+)
+
+func (p Provider) String() string {
+	if p == OpenTofuProvider {
+		return "OpenTofu"
+	}
+	return "Terraform"
+}
+
+// Returns the name of the binary of the provider.
+func (p Provider) Executable() string {
+	if p == OpenTofuProvider {
+		return "tofu"
+	}
+	return "terraform"
+}
+
+// Returns the extensions of the files the configuration of a module is written in.
+// OpenTofu also reads '.tofu' files, which Terraform ignores, so a directory with only '.tofu' files is a module of OpenTofu only.
+func (p Provider) ModuleFileExtensions() []string {
+	if p == OpenTofuProvider {
+		return []string{".tf", ".tf.json", ".tofu", ".tofu.json"}
+	}
+	return []string{".tf", ".tf.json"}
+}
+
+// Parse the provider, as set by '--provider=terraform|opentofu'. An empty provider is returned if none is set.
+func ParseProvider(provider string) (Provider, error) {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "":
+		return "", nil
+	case string(TerraformProvider):
+		return TerraformProvider, nil
+	case string(OpenTofuProvider), OpenTofuProvider.Executable():
+		return OpenTofuProvider, nil
+	}
+	return "", errorutils.CheckErrorf("unsupported provider '%s'. The supported providers are '%s' and '%s'", provider, TerraformProvider, OpenTofuProvider)
+}
+
+// Detect the provider by the binary installed on the machine. Terraform is preferred if both are installed.
+func DetectProvider() Provider {
+	for _, provider := range []Provider{TerraformProvider, OpenTofuProvider} {
+		if _, err := exec.LookPath(provider.Executable()); err == nil {
+			log.Debug("Detected the", provider.String(), "binary")
+			return provider
+		}
+	}
+	return TerraformProvider
+}
@@ -0,0 +1,30 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProvider(t *testing.T) {
+	testCases := []struct {
+		provider         string
+		expectedProvider Provider
+		expectError      bool
+	}{
+		{"", "", false},
+		{"terraform", TerraformProvider, false},
+		{"OpenTofu", OpenTofuProvider, false},
+		{"tofu", OpenTofuProvider, false},
+		{"pulumi", "", true},
+	}
+	for _, testCase := range testCases {
+		provider, err := ParseProvider(testCase.provider)
+		if testCase.expectError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedProvider, provider)
+	}
+}
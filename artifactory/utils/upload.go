@@ -23,6 +23,17 @@ type UploadConfiguration struct {
 	SplitCount            int
 	MinSplitSizeMB        int64
 	ChunkSizeMB           int64
+	// The max aggregate bandwidth of all the threads, in bytes per second. Zero means no limit.
+	RateLimit int64
+	// Optional. Overrides the command's retries and retry wait time.
+	RetryPolicy *RetryPolicy
+	// Log the files which would have been uploaded, with their target paths and properties, without uploading them.
+	DryRun bool
+	// How the failure of some of the files is handled. An empty value is equivalent to OnErrorContinue.
+	OnError OnErrorPolicy
+	// If set, each spec pattern should be a directory, which is uploaded as an archive of this format.
+	// The archive is created while it's being uploaded, and isn't written to the disk.
+	ArchiveStream ArchiveStreamFormat
 }
 
 func GetMinChecksumDeploySize() (int64, error) {
@@ -26,6 +26,7 @@ import (
 	clientConfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/distribution"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
 	"github.com/jfrog/jfrog-client-go/jfconnect"
 	"github.com/jfrog/jfrog-client-go/lifecycle"
 	"github.com/jfrog/jfrog-client-go/metadata"
@@ -110,10 +111,15 @@ func CreateServiceManagerWithContext(context context.Context, serverDetails *con
 	if err != nil {
 		return nil, err
 	}
+	httpClient, err := serverDetails.GetCustomHttpClient(certsPath, timeout)
+	if err != nil {
+		return nil, err
+	}
 	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(artAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serverDetails.InsecureTls).
+		SetHttpClient(httpClient).
 		SetDryRun(isDryRun).
 		SetContext(context)
 	if httpRetries >= 0 {
@@ -134,6 +140,14 @@ func CreateServiceManagerWithContext(context context.Context, serverDetails *con
 }
 
 func CreateServiceManagerWithProgressBar(serverDetails *config.ServerDetails, threads, httpRetries, httpRetryWaitMilliSecs int, dryRun bool, progressBar ioUtils.ProgressMgr) (artifactory.ArtifactoryServicesManager, error) {
+	retryPolicy := RetryPolicy{Retries: httpRetries, WaitTime: time.Duration(httpRetryWaitMilliSecs) * time.Millisecond}
+	return CreateTransferServiceManager(serverDetails, threads, 0, retryPolicy, nil, dryRun, progressBar)
+}
+
+// Create a service manager for uploads and downloads.
+// The aggregate bandwidth of all the threads is capped to bytesPerSecond, unless it's zero.
+// Failed requests are retried according to the retry policy. If failures isn't nil, the failed requests are recorded in it, and handled by its on-error policy.
+func CreateTransferServiceManager(serverDetails *config.ServerDetails, threads int, bytesPerSecond int64, retryPolicy RetryPolicy, failures *TransferFailures, dryRun bool, progressBar ioUtils.ProgressMgr) (artifactory.ArtifactoryServicesManager, error) {
 	certsPath, err := coreutils.GetJfrogCertsDir()
 	if err != nil {
 		return nil, err
@@ -142,22 +156,56 @@ func CreateServiceManagerWithProgressBar(serverDetails *config.ServerDetails, th
 	if err != nil {
 		return nil, err
 	}
-	servicesConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(artAuth).
 		SetDryRun(dryRun).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serverDetails.InsecureTls).
-		SetThreads(threads).
-		SetHttpRetries(httpRetries).
-		SetHttpRetryWaitMilliSecs(httpRetryWaitMilliSecs).
-		Build()
-
+		SetThreads(threads)
+	if bytesPerSecond > 0 || retryPolicy.isCustom() || failures != nil || serverDetails.HasProxy() || serverDetails.IsPkcs12ClientCert() {
+		httpClient, err := createTransferHttpClient(serverDetails, certsPath, bytesPerSecond, retryPolicy, failures)
+		if err != nil {
+			return nil, err
+		}
+		configBuilder.SetHttpClient(httpClient)
+	}
+	if retryPolicy.isCustom() {
+		// The requests are retried by the HTTP client, and shouldn't be retried again by the services.
+		configBuilder.SetHttpRetries(0)
+	} else {
+		configBuilder.
+			SetHttpRetries(retryPolicy.Retries).
+			SetHttpRetryWaitMilliSecs(int(retryPolicy.WaitTime.Milliseconds()))
+	}
+	servicesConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
 	return artifactory.NewWithProgress(servicesConfig, progressBar)
 }
 
+// Create an HTTP client for the server, which limits the bandwidth, retries failed requests and records the failures, if required.
+// The client trusts the certificates in certsPath, and uses the proxy and the client certificate of the server, if configured.
+func createTransferHttpClient(serverDetails *config.ServerDetails, certsPath string, bytesPerSecond int64, retryPolicy RetryPolicy, failures *TransferFailures) (*http.Client, error) {
+	transport, err := serverDetails.CreateHttpTransport(certsPath)
+	if err != nil {
+		return nil, err
+	}
+	var roundTripper http.RoundTripper = transport
+	if bytesPerSecond > 0 {
+		roundTripper = &rateLimitedTransport{base: roundTripper, limiter: newBandwidthLimiter(bytesPerSecond)}
+	}
+	if retryPolicy.isCustom() {
+		// The retries wrap the rate limiting, so that the retried requests are limited too.
+		roundTripper = &retryTransport{base: roundTripper, policy: retryPolicy}
+	}
+	if failures != nil {
+		// The failures are recorded after the retries of the transport, so that only the requests failing all their retries are recorded.
+		roundTripper = &failuresTransport{base: roundTripper, failures: failures}
+	}
+	return &http.Client{Transport: roundTripper}, nil
+}
+
 func CreateDistributionServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (*distribution.DistributionServicesManager, error) {
 	certsPath, err := coreutils.GetJfrogCertsDir()
 	if err != nil {
@@ -176,7 +224,14 @@ func CreateDistributionServiceManager(serviceDetails *config.ServerDetails, isDr
 	if err != nil {
 		return nil, err
 	}
-	return distribution.New(serviceConfig)
+	serviceManager, err := distribution.New(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = serviceDetails.SetCustomHttpTransport(serviceManager.Client(), certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
 }
 
 func CreateAccessServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (*access.AccessServicesManager, error) {
@@ -197,7 +252,14 @@ func CreateAccessServiceManager(serviceDetails *config.ServerDetails, isDryRun b
 	if err != nil {
 		return nil, err
 	}
-	return access.New(serviceConfig)
+	serviceManager, err := access.New(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = serviceDetails.SetCustomHttpTransport(serviceManager.Client(), certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
 }
 
 func CreateLifecycleServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (*lifecycle.LifecycleServicesManager, error) {
@@ -218,7 +280,14 @@ func CreateLifecycleServiceManager(serviceDetails *config.ServerDetails, isDryRu
 	if err != nil {
 		return nil, err
 	}
-	return lifecycle.New(serviceConfig)
+	serviceManager, err := lifecycle.New(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = serviceDetails.SetCustomHttpTransport(serviceManager.Client(), certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
 }
 
 func CreateEvidenceServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (*evidence.EvidenceServicesManager, error) {
@@ -239,7 +308,14 @@ func CreateEvidenceServiceManager(serviceDetails *config.ServerDetails, isDryRun
 	if err != nil {
 		return nil, err
 	}
-	return evidence.New(serviceConfig)
+	serviceManager, err := evidence.New(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = serviceDetails.SetCustomHttpTransport(serviceManager.Client(), certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
 }
 
 func CreateMetadataServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (metadata.Manager, error) {
@@ -260,7 +336,14 @@ func CreateMetadataServiceManager(serviceDetails *config.ServerDetails, isDryRun
 	if err != nil {
 		return nil, err
 	}
-	return metadata.NewManager(serviceConfig)
+	serviceManager, err := metadata.NewManager(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = setCustomHttpTransport(serviceDetails, serviceManager, certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
 }
 
 func CreateJfConnectServiceManager(serverDetails *config.ServerDetails, httpRetries, httpRetryWaitMilliSecs int) (jfconnect.Manager, error) {
@@ -282,7 +365,24 @@ func CreateJfConnectServiceManager(serverDetails *config.ServerDetails, httpRetr
 	if err != nil {
 		return nil, err
 	}
-	return jfconnect.NewManager(serviceConfig)
+	serviceManager, err := jfconnect.NewManager(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = setCustomHttpTransport(serverDetails, serviceManager, certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
+}
+
+// The metadata and JFrog Connect managers expose their HTTP client through their implementation only.
+func setCustomHttpTransport(serverDetails *config.ServerDetails, serviceManager any, certsPath string) error {
+	if clientProvider, ok := serviceManager.(interface {
+		Client() *jfroghttpclient.JfrogHttpClient
+	}); ok {
+		return serverDetails.SetCustomHttpTransport(clientProvider.Client(), certsPath)
+	}
+	return nil
 }
 
 // This error indicates that the build was scanned by Xray, but Xray found issues with the build.
@@ -0,0 +1,149 @@
+package yarn
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	LockfileName = "yarn.lock"
+
+	berryLockfileMetadataKey = "__metadata"
+	rootWorkspaceResolution  = "@workspace:."
+)
+
+// The yarn.lock file of Yarn Berry (Yarn 2 and above), which is a YAML file, unlike the lockfile of Yarn 1.
+// The lockfile describes the dependencies regardless of the way they are installed, so it's also complete in Plug'n'Play installs,
+// in which there's no node_modules directory.
+type BerryLockfile struct {
+	// The resolved packages, by each of the descriptors resolved to them ('lodash@npm:^4.17.21').
+	packages map[string]*BerryPackage
+	root     *BerryPackage
+}
+
+type BerryPackage struct {
+	Version      string            `yaml:"version"`
+	Resolution   string            `yaml:"resolution"`
+	Dependencies map[string]string `yaml:"dependencies"`
+}
+
+// Returns the name of the package and the protocol it was resolved by, e.g. 'lodash' and 'npm' for 'lodash@npm:4.17.21'.
+func (bp *BerryPackage) nameAndProtocol() (name, protocol string) {
+	i := strings.Index(bp.Resolution[min(1, len(bp.Resolution)):], "@") + 1
+	if i <= 0 {
+		return bp.Resolution, ""
+	}
+	protocol, _, _ = strings.Cut(bp.Resolution[i+1:], ":")
+	return bp.Resolution[:i], protocol
+}
+
+// Only packages which were resolved from the registry, or patches of such packages, are recorded in the build-info.
+// Workspaces and packages resolved from local paths, URLs and git repositories can't be found in Artifactory.
+func (bp *BerryPackage) isFromRegistry() bool {
+	_, protocol := bp.nameAndProtocol()
+	return protocol == "npm" || (protocol == "patch" && strings.Contains(bp.Resolution, "npm%3A"))
+}
+
+func ReadBerryLockfile(projectDir string) (*BerryLockfile, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, LockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseBerryLockfile(content)
+}
+
+func ParseBerryLockfile(content []byte) (*BerryLockfile, error) {
+	entries := map[string]*BerryPackage{}
+	if err := yaml.Unmarshal(content, &entries); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s. Only lockfiles of Yarn 2 and above can be parsed: %s", LockfileName, err.Error())
+	}
+	if _, exists := entries[berryLockfileMetadataKey]; !exists {
+		return nil, errorutils.CheckErrorf("%s isn't a lockfile of Yarn 2 and above", LockfileName)
+	}
+	delete(entries, berryLockfileMetadataKey)
+	lockfile := &BerryLockfile{packages: map[string]*BerryPackage{}}
+	for key, berryPackage := range entries {
+		// Descriptors resolved to the same package share an entry, e.g. 'lodash@npm:^4.17.0, lodash@npm:^4.17.21'.
+		for _, descriptor := range strings.Split(key, ",") {
+			lockfile.packages[strings.TrimSpace(descriptor)] = berryPackage
+		}
+		if strings.HasSuffix(berryPackage.Resolution, rootWorkspaceResolution) {
+			lockfile.root = berryPackage
+		}
+	}
+	if lockfile.root == nil {
+		return nil, errorutils.CheckErrorf("couldn't find the root workspace in %s", LockfileName)
+	}
+	return lockfile, nil
+}
+
+// Returns the package a dependency of the lockfile is resolved to.
+// In lockfiles of Yarn 2, the ranges of the dependencies have no protocol, and the default 'npm:' protocol is added to them.
+func (bl *BerryLockfile) getPackage(name, versionRange string) (*BerryPackage, bool) {
+	if berryPackage, found := bl.packages[name+"@"+versionRange]; found {
+		return berryPackage, true
+	}
+	berryPackage, found := bl.packages[name+"@npm:"+versionRange]
+	return berryPackage, found
+}
+
+// Returns the dependencies of the root workspace by their IDs, with the dependency paths they were requested by.
+// The dependencies of the workspaces the root workspace depends on are included as well, as if the root workspace requested them.
+func (bl *BerryLockfile) GetDependencies(moduleId string) map[string]*entities.Dependency {
+	collector := &berryDependenciesCollector{lockfile: bl, dependencies: map[string]*entities.Dependency{}, visited: map[*BerryPackage]bool{bl.root: true}}
+	collector.collectChildren(bl.root, []string{moduleId})
+	return collector.dependencies
+}
+
+type berryDependenciesCollector struct {
+	lockfile     *BerryLockfile
+	dependencies map[string]*entities.Dependency
+	// The packages whose dependencies were already collected.
+	visited map[*BerryPackage]bool
+}
+
+// Berry writes the 'dependencies' of each yarn.lock entry sorted by name, and they're sorted again since decoding them into a map loses that order.
+// The dependencies of each package are traversed once, so every dependency is recorded with one path for each of its direct parents.
+func (bdc *berryDependenciesCollector) collectChildren(parent *BerryPackage, requestedBy []string) {
+	for _, name := range slices.Sorted(maps.Keys(parent.Dependencies)) {
+		berryPackage, found := bdc.lockfile.getPackage(name, parent.Dependencies[name])
+		if !found {
+			log.Debug("Couldn't find the package of", name+"@"+parent.Dependencies[name], "in", LockfileName)
+			continue
+		}
+		childRequestedBy := requestedBy
+		if berryPackage.isFromRegistry() {
+			packageName, _ := berryPackage.nameAndProtocol()
+			id := packageName + ":" + berryPackage.Version
+			bdc.addDependency(id, requestedBy)
+			childRequestedBy = append([]string{id}, requestedBy...)
+		}
+		if bdc.visited[berryPackage] {
+			continue
+		}
+		bdc.visited[berryPackage] = true
+		bdc.collectChildren(berryPackage, childRequestedBy)
+	}
+}
+
+func (bdc *berryDependenciesCollector) addDependency(id string, requestedBy []string) {
+	dependency, exists := bdc.dependencies[id]
+	if !exists {
+		dependency = &entities.Dependency{Id: id}
+		bdc.dependencies[id] = dependency
+	}
+	for _, path := range dependency.RequestedBy {
+		if path[0] == requestedBy[0] {
+			return
+		}
+	}
+	dependency.RequestedBy = append(dependency.RequestedBy, requestedBy)
+}
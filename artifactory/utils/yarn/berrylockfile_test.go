@@ -0,0 +1,113 @@
+package yarn
+
+import (
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const berryLockfile = `# This file is generated by running "yarn install" inside your project.
+# Manual changes might be lost - proceed with caution!
+
+__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"accepts@npm:~1.3.8":
+  version: 1.3.8
+  resolution: "accepts@npm:1.3.8"
+  dependencies:
+    mime-types: "npm:~2.1.34"
+  checksum: 10c0/abc
+  languageName: node
+  linkType: hard
+
+"express@npm:^4.18.2":
+  version: 4.18.2
+  resolution: "express@npm:4.18.2"
+  dependencies:
+    accepts: "npm:~1.3.8"
+    resolve: "patch:resolve@npm%3A^1.22.0#optional!builtin<compat/resolve>"
+  checksum: 10c0/def
+  languageName: node
+  linkType: hard
+
+"mime-types@npm:~2.1.24, mime-types@npm:~2.1.34":
+  version: 2.1.35
+  resolution: "mime-types@npm:2.1.35"
+  checksum: 10c0/ghi
+  languageName: node
+  linkType: hard
+
+"my-app@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "my-app@workspace:."
+  dependencies:
+    "@my-scope/utils": "workspace:^"
+    express: "npm:^4.18.2"
+    local-lib: "file:./local-lib"
+  languageName: unknown
+  linkType: soft
+
+"@my-scope/utils@workspace:^, @my-scope/utils@workspace:packages/utils":
+  version: 0.0.0-use.local
+  resolution: "@my-scope/utils@workspace:packages/utils"
+  dependencies:
+    mime-types: "npm:~2.1.24"
+  languageName: unknown
+  linkType: soft
+
+"local-lib@file:./local-lib::locator=my-app%40workspace%3A.":
+  version: 1.0.0
+  resolution: "local-lib@file:./local-lib#./local-lib::hash=abc&locator=my-app%40workspace%3A."
+  languageName: node
+  linkType: hard
+
+"resolve@patch:resolve@npm%3A^1.22.0#optional!builtin<compat/resolve>":
+  version: 1.22.8
+  resolution: "resolve@patch:resolve@npm%3A1.22.8#optional!builtin<compat/resolve>::version=1.22.8&hash=c3c19d"
+  languageName: node
+  linkType: hard
+`
+
+func TestBerryLockfileGetDependencies(t *testing.T) {
+	lockfile, err := ParseBerryLockfile([]byte(berryLockfile))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*entities.Dependency{
+		"mime-types:2.1.35": {Id: "mime-types:2.1.35", RequestedBy: [][]string{{"my-app:1.0.0"}, {"accepts:1.3.8", "express:4.18.2", "my-app:1.0.0"}}},
+		"express:4.18.2":    {Id: "express:4.18.2", RequestedBy: [][]string{{"my-app:1.0.0"}}},
+		"accepts:1.3.8":     {Id: "accepts:1.3.8", RequestedBy: [][]string{{"express:4.18.2", "my-app:1.0.0"}}},
+		"resolve:1.22.8":    {Id: "resolve:1.22.8", RequestedBy: [][]string{{"express:4.18.2", "my-app:1.0.0"}}},
+	}, lockfile.GetDependencies("my-app:1.0.0"))
+}
+
+func TestParseBerryLockfileYarnV1(t *testing.T) {
+	_, err := ParseBerryLockfile([]byte("# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.\n# yarn lockfile v1\n\n\nlodash@^4.17.21:\n  version \"4.17.21\"\n"))
+	assert.Error(t, err)
+}
+
+func TestBerryPackageNameAndProtocol(t *testing.T) {
+	tests := []struct {
+		resolution       string
+		expectedName     string
+		expectedProtocol string
+		fromRegistry     bool
+	}{
+		{"lodash@npm:4.17.21", "lodash", "npm", true},
+		{"@babel/core@npm:7.24.0", "@babel/core", "npm", true},
+		{"resolve@patch:resolve@npm%3A1.22.8#optional!builtin<compat/resolve>::version=1.22.8&hash=c3c19d", "resolve", "patch", true},
+		{"my-app@workspace:.", "my-app", "workspace", false},
+		{"my-lib@https://github.com/org/my-lib.git#commit=abc", "my-lib", "https", false},
+	}
+	for _, test := range tests {
+		t.Run(test.resolution, func(t *testing.T) {
+			berryPackage := &BerryPackage{Resolution: test.resolution}
+			name, protocol := berryPackage.nameAndProtocol()
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedProtocol, protocol)
+			assert.Equal(t, test.fromRegistry, berryPackage.isFromRegistry())
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package build
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+)
+
+const (
+	// The keys of the env filter patterns in the build config file, under the '.jfrog' dir.
+	ProjectConfigEnvIncludeKey = "envInclude"
+	ProjectConfigEnvExcludeKey = "envExclude"
+
+	redactedEnvValue = "****"
+)
+
+// Environment variables with names matching this pattern hold secrets.
+// 'PWD' alone is the working directory, and names such as 'GIT_AUTHOR_NAME' aren't secrets, so both are matched as parts of names only.
+var secretEnvNameRegexp = regexp.MustCompile(`(?i)(passw(or)?d|[_-]pwd($|[_-])|psw|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|credential|auth($|[_-])|authorization)`)
+
+// Values matching one of these patterns are secrets, regardless of the name of their environment variable.
+var secretEnvValueRegexps = []*regexp.Regexp{
+	// JSON web tokens, including JFrog access tokens.
+	regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.`),
+	// JFrog API keys and reference tokens.
+	regexp.MustCompile(`^(AKCp[A-Za-z0-9]{10,}|cmVmdGtuOj)`),
+	// GitHub tokens.
+	regexp.MustCompile(`^(gh[pousr]_|github_pat_)[A-Za-z0-9_]+`),
+	// AWS access key IDs.
+	regexp.MustCompile(`^(AKIA|ASIA)[0-9A-Z]{16}$`),
+	// URLs with credentials.
+	regexp.MustCompile(`://[^/\s:@]+:[^/\s@]+@`),
+}
+
+// Returns the wildcard patterns of the environment variables collected into the build-info, separated by semicolons.
+// A pattern which isn't provided is read from the build config file, under the '.jfrog' dir.
+// The patterns are applied by the include and exclude filters of the build-info.
+func GetEnvFilterPatterns(envInclude, envExclude string) (string, string, error) {
+	if envInclude != "" && envExclude != "" {
+		return envInclude, envExclude, nil
+	}
+	confFilePath, exists, err := project.GetProjectConfFilePath(project.Build)
+	if err != nil && !os.IsPermission(err) {
+		return "", "", err
+	}
+	if !exists {
+		return envInclude, envExclude, nil
+	}
+	vConfig, err := project.ReadConfigFile(confFilePath, project.YAML)
+	if err != nil {
+		return "", "", err
+	}
+	if envInclude == "" {
+		envInclude = strings.Join(vConfig.GetStringSlice(ProjectConfigEnvIncludeKey), ";")
+	}
+	if envExclude == "" {
+		envExclude = strings.Join(vConfig.GetStringSlice(ProjectConfigEnvExcludeKey), ";")
+	}
+	return envInclude, envExclude, nil
+}
+
+// Redact the secrets among the environment variables of the build-info properties.
+// The secrets are redacted even if the variables which hold them aren't excluded by the filters.
+func RedactSecretEnvs(properties map[string]string) {
+	for key, value := range properties {
+		if name, isEnv := strings.CutPrefix(key, buildInfo.BuildInfoEnvPrefix); isEnv && IsSecretEnv(name, value) {
+			properties[key] = redactedEnvValue
+		}
+	}
+}
+
+func matchesAny(regexps []*regexp.Regexp, value string) bool {
+	for _, re := range regexps {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the environment variable holds a secret, according to either its name or its value.
+func IsSecretEnv(name, value string) bool {
+	return secretEnvNameRegexp.MatchString(name) || matchesAny(secretEnvValueRegexps, value)
+}
@@ -14,7 +14,28 @@ const (
 const (
 	// Common
 	Threads = 3
+	Retries = 3
+
+	// Download
+	DownloadMinSplitKb    = 5120
+	DownloadSplitCount    = 3
+	DownloadMaxSplitCount = 15
+
+	// Upload
+	UploadMinSplitMb    = 200
+	UploadSplitCount    = 5
+	UploadMaxSplitCount = 100
+	UploadChunkSizeMb   = 20
+	// Multipart upload chunk size limits, in MB
+	UploadMinChunkSizeMb = 5
+	UploadMaxChunkSizeMb = 5120
 
 	// Environment variables
 	JfrogCliAvoidDeprecationWarnings = "JFROG_CLI_AVOID_DEPRECATION_WARNINGS"
+	// When set to true, using a deprecated flag fails the command, instead of logging a warning.
+	JfrogCliStrictDeprecation = "JFROG_CLI_STRICT_DEPRECATION"
+	// When set to true, checksum validation is enforced on downloads, even if the --skip-checksum option is used.
+	JfrogCliForceChecksum = "JFROG_CLI_FORCE_CHECKSUM"
+	// A soft cap on the number of download threads. Requested threads count above this value is lowered to it.
+	JfrogCliMaxThreads = "JFROG_CLI_MAX_THREADS"
 )
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 
@@ -115,6 +117,21 @@ func GetThreadsCount(threadCountStrVal string) (threads int, err error) {
 	return threads, nil
 }
 
+// Same as GetThreadsCount, but also accepts a percentage of the available CPUs, such as '50%'.
+// The result of a percentage value is rounded up, and is at least 1.
+func GetThreadsCountWithScaling(threadCountStrVal string) (threads int, err error) {
+	percentageStrVal, isPercentage := strings.CutSuffix(threadCountStrVal, "%")
+	if !isPercentage {
+		return GetThreadsCount(threadCountStrVal)
+	}
+	percentage, err := strconv.ParseFloat(percentageStrVal, 64)
+	if err != nil || percentage < 0 || percentage > 100 {
+		return 0, errors.New("the '--threads' option percentage value should be between 0% and 100%. " + GetCLIDocumentationMessage())
+	}
+	threads = int(math.Ceil(float64(runtime.NumCPU()) * percentage / 100))
+	return max(threads, 1), nil
+}
+
 // Get a secret value from a flag or from stdin.
 func HandleSecretInput(stringFlag, secretRaw, stdinFlag string, isStdin bool) (secret string, err error) {
 	secret = secretRaw
@@ -0,0 +1,40 @@
+package cliutils
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetThreadsCountWithScaling(t *testing.T) {
+	numCpu := runtime.NumCPU()
+	tests := []struct {
+		value     string
+		expected  int
+		expectErr bool
+	}{
+		{"", Threads, false},
+		{"5", 5, false},
+		{"100%", numCpu, false},
+		{"50%", (numCpu + 1) / 2, false},
+		{"0%", 1, false},
+		{"1%", 1, false},
+		{"101%", 0, true},
+		{"-1%", 0, true},
+		{"abc%", 0, true},
+		{"%", 0, true},
+		{"0", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			threads, err := GetThreadsCountWithScaling(test.value)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, threads)
+		})
+	}
+}
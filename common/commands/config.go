@@ -154,7 +154,7 @@ func (cc *ConfigCommand) CommandName() string {
 	if err != nil {
 		log.Warn("Failed to get the value of the environment variable: " + coreutils.UsageAutoPublishedBuild + ". " + err.Error())
 	}
-	if oidcConfigured {
+	if oidcConfigured || (cc.details != nil && cc.details.OidcProvider != "") {
 		return configOidcCommandName
 	}
 	return configCommandName
@@ -184,6 +184,15 @@ func (cc *ConfigCommand) config() error {
 	if err != nil {
 		return err
 	}
+	if err = cc.details.ValidateProxy(); err != nil {
+		return err
+	}
+	if err = cc.details.ValidatePkcs12ClientCert(); err != nil {
+		return err
+	}
+	if err = cc.exchangeOidcTokenIfNeeded(); err != nil {
+		return err
+	}
 	cc.addTrailingSlashes()
 	cc.lowerUsername()
 	cc.setDefaultIfNeeded(configurations)
@@ -224,6 +233,36 @@ func (cc *ConfigCommand) getConfigurationNonInteractively() error {
 	return nil
 }
 
+// Exchange the OIDC ID token of the CI provider for a short-lived access token.
+// The provider is saved in the config, so the token is re-exchanged when it expires, and no long-lived secrets are stored.
+func (cc *ConfigCommand) exchangeOidcTokenIfNeeded() error {
+	if cc.details.OidcProvider == "" {
+		return nil
+	}
+	provider, err := config.ParseOidcProvider(cc.details.OidcProvider)
+	if err != nil {
+		return err
+	}
+	if cc.details.Url == "" || cc.details.OidcIntegration == "" {
+		return errorutils.CheckErrorf("the platform URL and the name of the OIDC integration are required for the OIDC token exchange")
+	}
+	if cc.details.AccessToken != "" || cc.details.User != "" || cc.details.Password != "" || cc.details.SshKeyPath != "" {
+		return errorutils.CheckErrorf("the OIDC token exchange can't be combined with other authentication methods")
+	}
+	idToken, err := config.GetCiIdToken(provider, cc.details.OidcAudience)
+	if err != nil {
+		return err
+	}
+	token, err := config.ExchangeOidcToken(cc.details, idToken)
+	if err != nil {
+		return err
+	}
+	cc.details.OidcProvider = string(provider)
+	cc.details.AccessToken = token.AccessToken
+	cc.tryExtractingUsernameFromAccessToken()
+	return nil
+}
+
 func (cc *ConfigCommand) addTrailingSlashes() {
 	cc.details.ArtifactoryUrl = clientUtils.AddTrailingSlashIfNeeded(cc.details.ArtifactoryUrl)
 	cc.details.DistributionUrl = clientUtils.AddTrailingSlashIfNeeded(cc.details.DistributionUrl)
@@ -387,7 +426,7 @@ func (cc *ConfigCommand) fillSpecificUrlsFromPlatform() (disallowUsingSavedPassw
 }
 
 func (cc *ConfigCommand) checkCertificateForMTLS() {
-	if cc.details.ClientCertPath != "" && cc.details.ClientCertKeyPath != "" {
+	if cc.details.ClientCertPath != "" && (cc.details.ClientCertKeyPath != "" || cc.details.IsPkcs12ClientCert()) {
 		return
 	}
 	cc.readClientCertInfoFromConsole()
@@ -478,6 +517,16 @@ func (cc *ConfigCommand) readClientCertInfoFromConsole() {
 	if cc.details.ClientCertPath == "" {
 		ioutils.ScanFromConsole("Client certificate file path", &cc.details.ClientCertPath, cc.defaultDetails.ClientCertPath)
 	}
+	if cc.details.IsPkcs12ClientCert() {
+		// The PKCS#12 archive contains the private key, which may be protected by a password.
+		if cc.details.ClientCertPassword == "" {
+			password, err := ioutils.ScanPasswordFromConsole("Client certificate password (optional):")
+			if err == nil {
+				cc.details.ClientCertPassword = password
+			}
+		}
+		return
+	}
 	if cc.details.ClientCertKeyPath == "" {
 		ioutils.ScanFromConsole("Client certificate key path", &cc.details.ClientCertKeyPath, cc.defaultDetails.ClientCertKeyPath)
 	}
@@ -595,6 +644,24 @@ func Import(configTokenString string) (err error) {
 	return configCommand.config()
 }
 
+// Set the store of the secrets of the configured servers, to 'file' or 'keyring', and migrate the secrets to it.
+func SetCredentialStore(storeType string) (err error) {
+	credentialStoreType, err := config.ParseCredentialStoreType(storeType)
+	if err != nil {
+		return err
+	}
+	log.Debug("Locking config file to run config set credential-store command.")
+	unlockFunc, err := lockConfig()
+	// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
+	defer func() {
+		err = errors.Join(err, unlockFunc())
+	}()
+	if err != nil {
+		return err
+	}
+	return config.SetCredentialStoreType(credentialStoreType)
+}
+
 func Export(serverName string) error {
 	serverDetails, err := config.GetSpecificConfig(serverName, true, false)
 	if err != nil {
@@ -611,6 +678,76 @@ func Export(serverName string) error {
 	return nil
 }
 
+// Export the configurations of all the servers, including their secrets, to a bundle file encrypted with the passphrase.
+// If the passphrase is empty, it's read from the console.
+func ExportBundle(bundlePath, passphrase string) error {
+	if passphrase == "" {
+		var err error
+		if passphrase, err = readBundlePassphraseFromConsole(true); err != nil {
+			return err
+		}
+	}
+	content, err := config.ExportBundle(passphrase)
+	if err != nil {
+		return err
+	}
+	// The bundle is readable by its owner only, as the config file.
+	if err = os.WriteFile(bundlePath, content, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("The config bundle was exported to", bundlePath)
+	return nil
+}
+
+// Import the configurations of the servers in a bundle file, which was exported with the passphrase.
+// If the passphrase is empty, it's read from the console.
+func ImportBundle(bundlePath, passphrase string) (err error) {
+	content, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if passphrase == "" {
+		if passphrase, err = readBundlePassphraseFromConsole(false); err != nil {
+			return err
+		}
+	}
+	servers, err := config.DecryptBundle(content, passphrase)
+	if err != nil {
+		return err
+	}
+	log.Debug("Locking config file to run config import command.")
+	unlockFunc, err := lockConfig()
+	// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
+	defer func() {
+		err = errors.Join(err, unlockFunc())
+	}()
+	if err != nil {
+		return err
+	}
+	if err = config.MergeServersConf(servers); err != nil {
+		return err
+	}
+	for _, serverDetails := range servers {
+		log.Info("Imported server ID", "'"+serverDetails.ServerId+"'")
+	}
+	return nil
+}
+
+func readBundlePassphraseFromConsole(confirm bool) (string, error) {
+	passphrase, err := ioutils.ScanPasswordFromConsole("Config bundle passphrase:")
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+	confirmation, err := ioutils.ScanPasswordFromConsole("Confirm the passphrase:")
+	if err != nil {
+		return "", err
+	}
+	if confirmation != passphrase {
+		return "", errorutils.CheckErrorf("the passphrases don't match")
+	}
+	return passphrase, nil
+}
+
 func moveDefaultConfigToSliceEnd(configuration []*config.ServerDetails) []*config.ServerDetails {
 	lastIndex := len(configuration) - 1
 	// If configuration list has more than one config and the last one is not default, switch the last default config with the last one
@@ -647,6 +784,10 @@ func printConfigs(configuration []*config.ServerDetails) {
 		logIfNotEmpty(details.SshPassphrase, "SSH passphrase:\t\t\t", true, isDefault)
 		logIfNotEmpty(details.ClientCertPath, "Client certificate file path:\t", false, isDefault)
 		logIfNotEmpty(details.ClientCertKeyPath, "Client certificate key path:\t", false, isDefault)
+		logIfNotEmpty(details.ClientCertPassword, "Client certificate password:\t", true, isDefault)
+		logIfNotEmpty(details.HttpProxy, "HTTP proxy:\t\t\t", false, isDefault)
+		logIfNotEmpty(details.HttpsProxy, "HTTPS proxy:\t\t\t", false, isDefault)
+		logIfNotEmpty(details.NoProxy, "No proxy:\t\t\t", false, isDefault)
 		logIfNotEmpty(strconv.FormatBool(details.IsDefault), "Default:\t\t\t", false, isDefault)
 		log.Output()
 	}
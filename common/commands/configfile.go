@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/terraform"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
@@ -51,6 +52,9 @@ const (
 	// Nuget flags
 	nugetV2 = "nuget-v2"
 
+	// Terraform flags
+	provider = "provider"
+
 	// Default values
 	defaultIvyDescPattern      = "[organization]/[module]/ivy-[revision].xml"
 	defaultIvyArtifactsPattern = "[organization]/[module]/[revision]/[artifact]-[revision](-[classifier]).[ext]"
@@ -71,6 +75,7 @@ type ConfigFile struct {
 	Deployer    project.Repository `yaml:"deployer,omitempty"`
 	UsePlugin   bool               `yaml:"usePlugin,omitempty"`
 	UseWrapper  bool               `yaml:"useWrapper,omitempty"`
+	Provider    string             `yaml:"provider,omitempty"`
 }
 
 type ConfigOption func(c *ConfigFile)
@@ -113,6 +118,8 @@ func NewConfigFile(confType project.ProjectType, c *cli.Context) *ConfigFile {
 		configFile.populateGradleConfigFromFlags(c)
 	case project.Nuget, project.Dotnet:
 		configFile.populateNugetConfigFromFlags(c)
+	case project.Terraform:
+		configFile.populateTerraformConfigFromFlags(c)
 	}
 	return configFile
 }
@@ -175,6 +182,10 @@ func handleInteractiveConfigCreation(configFile *ConfigFile, confType project.Pr
 		return configFile.setDeployerResolver()
 	case project.Swift:
 		return configFile.setDeployerResolver()
+	case project.Cargo:
+		return configFile.setDeployerResolver()
+	case project.Conda, project.Composer:
+		return configFile.setResolver(false)
 	}
 	return
 }
@@ -368,6 +379,18 @@ func WithResolverNugetV2(nugetV2 bool) ConfigOption {
 	}
 }
 
+// Populate Terraform related configuration from cli flags
+func (configFile *ConfigFile) populateTerraformConfigFromFlags(c *cli.Context) {
+	configFile.Provider = c.String(provider)
+}
+
+// Set the tool the modules are published for, terraform or opentofu.
+func WithTerraformProvider(terraformProvider string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Provider = terraformProvider
+	}
+}
+
 // Verify config file doesn't exist or prompt to override it
 func (configFile *ConfigFile) VerifyConfigFile(configFilePath string) error {
 	exists, err := fileutils.IsFileExists(configFilePath, false)
@@ -614,7 +637,26 @@ func (configFile *ConfigFile) validateConfig() error {
 	if err != nil {
 		return err
 	}
-	return validateRepositoryConfig(&configFile.Deployer, deploymentErrorPrefix)
+	if err = validateRepositoryConfig(&configFile.Deployer, deploymentErrorPrefix); err != nil {
+		return err
+	}
+	return configFile.validateProvider()
+}
+
+// Validate the provider and normalize it, so that 'tofu' is saved as 'opentofu'.
+func (configFile *ConfigFile) validateProvider() error {
+	if configFile.Provider == "" {
+		return nil
+	}
+	if configFile.ConfigType != project.Terraform.String() {
+		return errorutils.CheckErrorf("the --%s option is supported for %s configurations only", provider, project.Terraform.String())
+	}
+	terraformProvider, err := terraform.ParseProvider(configFile.Provider)
+	if err != nil {
+		return err
+	}
+	configFile.Provider = string(terraformProvider)
+	return nil
 }
 
 // Get Artifactory serverId from the user. If useArtifactoryQuestion is not empty, ask first whether to use artifactory.
@@ -337,6 +337,30 @@ func TestGradleConfigFileDefaultPatterns(t *testing.T) {
 	assert.Equal(t, true, config.GetBool("useWrapper"))
 }
 
+func TestTerraformConfigFile(t *testing.T) {
+	// Set JFROG_CLI_HOME_DIR environment variable
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	// Create build config
+	context := createContext(t, deploymentServerId+"=depServer", deploymentRepo+"=repo", provider+"=tofu")
+	err := CreateBuildConfig(context, project.Terraform)
+	assert.NoError(t, err)
+
+	// Check configuration
+	config := checkCommonAndGetConfiguration(t, project.Terraform.String(), tempDirPath)
+	assert.Equal(t, "depServer", config.GetString("deployer.serverId"))
+	assert.Equal(t, "repo", config.GetString("deployer.repo"))
+	assert.Equal(t, "opentofu", config.GetString("provider"))
+}
+
+func TestValidateConfigProvider(t *testing.T) {
+	configFile := NewConfigFileWithOptions(project.Terraform, WithTerraformProvider("pulumi"))
+	assert.Error(t, configFile.validateConfig())
+	configFile = NewConfigFileWithOptions(project.Npm, WithTerraformProvider("opentofu"))
+	assert.Error(t, configFile.validateConfig())
+}
+
 func TestValidateConfigResolver(t *testing.T) {
 	// Create and check empty config
 	tempDirPath := createTempEnv(t)
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type ScanCacheAction string
+
+const (
+	ShowScanCache   ScanCacheAction = "show"
+	ClearScanCache  ScanCacheAction = "clear"
+	SetScanCacheTtl ScanCacheAction = "set-ttl"
+)
+
+// Manages the local cache of the scan results.
+type ScanCacheCommand struct {
+	action ScanCacheAction
+	ttl    time.Duration
+}
+
+func NewScanCacheCommand() *ScanCacheCommand {
+	return &ScanCacheCommand{}
+}
+
+func (scc *ScanCacheCommand) SetAction(action ScanCacheAction) *ScanCacheCommand {
+	scc.action = action
+	return scc
+}
+
+func (scc *ScanCacheCommand) SetTtl(ttl time.Duration) *ScanCacheCommand {
+	scc.ttl = ttl
+	return scc
+}
+
+func (scc *ScanCacheCommand) Run() error {
+	cache, err := xray.NewScanCache("", false)
+	if err != nil {
+		return err
+	}
+	switch scc.action {
+	case ShowScanCache:
+		entries, size, err := cache.Size()
+		if err != nil {
+			return err
+		}
+		log.Output(fmt.Sprintf("Entries: %d\nSize: %s\nTTL: %s", entries, servicesUtils.ConvertIntToStorageSizeString(size), cache.Ttl()))
+		return nil
+	case ClearScanCache:
+		if err = cache.Clear(); err != nil {
+			return err
+		}
+		log.Info("The scan cache was cleared")
+		return nil
+	case SetScanCacheTtl:
+		if err = cache.SetTtl(scc.ttl); err != nil {
+			return err
+		}
+		log.Info("The scan cache TTL was set to", scc.ttl)
+		return nil
+	}
+	return errorutils.CheckErrorf("unsupported scan cache action '%s'. Supported actions: %s, %s, %s", scc.action, ShowScanCache, ClearScanCache, SetScanCacheTtl)
+}
+
+func (scc *ScanCacheCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (scc *ScanCacheCommand) CommandName() string {
+	return "scan_cache"
+}
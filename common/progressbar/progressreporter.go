@@ -0,0 +1,272 @@
+package progressbar
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+)
+
+const (
+	// The interactive progress bar.
+	ProgressFormatBar = "bar"
+	// Structured progress events, written as JSON lines.
+	ProgressFormatJson = "json"
+)
+
+type ProgressEventType string
+
+const (
+	FileStarted      ProgressEventType = "file_started"
+	BytesTransferred ProgressEventType = "bytes_transferred"
+	FileCompleted    ProgressEventType = "file_completed"
+	FileFailed       ProgressEventType = "file_failed"
+	TaskCompleted    ProgressEventType = "task_completed"
+	ProgressError    ProgressEventType = "error"
+)
+
+// The min interval between two 'bytes_transferred' events of the same file.
+var bytesTransferredEventsInterval = time.Second
+
+type ProgressEvent struct {
+	Type ProgressEventType `json:"type"`
+	Time time.Time         `json:"time"`
+	// The id of the file transfer, shared by all the events of the same file.
+	Id    int    `json:"id,omitempty"`
+	Label string `json:"label,omitempty"`
+	Path  string `json:"path,omitempty"`
+	// The size of the file, in bytes.
+	Total int64 `json:"total,omitempty"`
+	// The number of bytes transferred so far.
+	Transferred int64  `json:"transferred,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Receives the progress events of a command, to report them to CI systems and wrappers.
+type ProgressReporter interface {
+	Report(event ProgressEvent) error
+}
+
+// A ProgressReporter writing each event as a JSON line.
+type JsonProgressReporter struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+func NewJsonProgressReporter(writer io.Writer) *JsonProgressReporter {
+	return &JsonProgressReporter{writer: writer}
+}
+
+func (jpr *JsonProgressReporter) Report(event ProgressEvent) error {
+	content, err := json.Marshal(event)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	jpr.mutex.Lock()
+	defer jpr.mutex.Unlock()
+	_, err = jpr.writer.Write(append(content, '\n'))
+	return errorutils.CheckError(err)
+}
+
+// Open the output of the progress events.
+// The output may be 'stdout', 'stderr', a file descriptor such as 'fd:3', or a file path. The default output is stderr.
+func OpenProgressOutput(output string) (io.WriteCloser, error) {
+	switch {
+	case output == "" || output == "stderr":
+		return nopWriteCloser{os.Stderr}, nil
+	case output == "stdout":
+		return nopWriteCloser{os.Stdout}, nil
+	case strings.HasPrefix(output, "fd:"):
+		fd, err := strconv.ParseUint(strings.TrimPrefix(output, "fd:"), 10, 64)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("invalid progress output '%s'. A file descriptor number is expected after 'fd:'", output)
+		}
+		return os.NewFile(uintptr(fd), output), nil
+	default:
+		file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		return file, errorutils.CheckError(err)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// An ioUtils.ProgressMgr reporting the progress as events, instead of displaying progress bars.
+type eventsProgressMgr struct {
+	reporter ProgressReporter
+	progress map[int]*eventsProgress
+	nextId   int
+	mutex    sync.Mutex
+}
+
+func NewEventsProgressMgr(reporter ProgressReporter) ioUtils.ProgressMgr {
+	return &eventsProgressMgr{reporter: reporter, progress: make(map[int]*eventsProgress)}
+}
+
+func (epm *eventsProgressMgr) report(event ProgressEvent) {
+	event.Time = time.Now()
+	// A failure to report the progress shouldn't fail the command.
+	_ = epm.reporter.Report(event)
+}
+
+func (epm *eventsProgressMgr) NewProgressReader(total int64, label, path string) ioUtils.Progress {
+	epm.mutex.Lock()
+	epm.nextId++
+	progress := &eventsProgress{manager: epm, id: epm.nextId, label: strings.TrimSpace(label), path: path, total: total}
+	epm.progress[progress.id] = progress
+	epm.mutex.Unlock()
+	epm.report(ProgressEvent{Type: FileStarted, Id: progress.id, Label: progress.label, Path: path, Total: total})
+	return progress
+}
+
+func (epm *eventsProgressMgr) SetMergingState(id int, _ bool) ioUtils.Progress {
+	return epm.GetProgress(id)
+}
+
+func (epm *eventsProgressMgr) GetProgress(id int) ioUtils.Progress {
+	epm.mutex.Lock()
+	defer epm.mutex.Unlock()
+	if progress, exists := epm.progress[id]; exists {
+		return progress
+	}
+	return nil
+}
+
+func (epm *eventsProgressMgr) RemoveProgress(id int) {
+	epm.mutex.Lock()
+	progress, exists := epm.progress[id]
+	delete(epm.progress, id)
+	epm.mutex.Unlock()
+	if exists {
+		progress.Abort()
+	}
+}
+
+func (epm *eventsProgressMgr) IncrementGeneralProgress() {
+	epm.report(ProgressEvent{Type: TaskCompleted})
+}
+
+func (epm *eventsProgressMgr) Quit() error {
+	return nil
+}
+
+func (epm *eventsProgressMgr) IncGeneralProgressTotalBy(int64) {}
+
+func (epm *eventsProgressMgr) SetHeadlineMsg(string) {}
+
+func (epm *eventsProgressMgr) ClearHeadlineMsg() {}
+
+func (epm *eventsProgressMgr) InitProgressReaders() {}
+
+func (epm *eventsProgressMgr) ClearProgress() {}
+
+type eventsProgress struct {
+	manager     *eventsProgressMgr
+	id          int
+	label       string
+	path        string
+	total       int64
+	transferred atomic.Int64
+	// The time of the last 'bytes_transferred' event, in Unix nanoseconds.
+	lastReported atomic.Int64
+	completed    atomic.Bool
+	// The first error returned while reading the transferred content, other than io.EOF.
+	readErr atomic.Pointer[error]
+}
+
+func (ep *eventsProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	if reader == nil {
+		return nil
+	}
+	return &eventsProgressReader{Reader: reader, progress: ep}
+}
+
+func (ep *eventsProgress) SetProgress(progress int64) {
+	ep.transferred.Store(progress)
+	ep.reportTransferred()
+}
+
+// Reports the transferred bytes, unless they were reported less than bytesTransferredEventsInterval ago.
+func (ep *eventsProgress) reportTransferred() {
+	now := time.Now().UnixNano()
+	last := ep.lastReported.Load()
+	if now-last < int64(bytesTransferredEventsInterval) || !ep.lastReported.CompareAndSwap(last, now) {
+		return
+	}
+	ep.manager.report(ProgressEvent{Type: BytesTransferred, Id: ep.id, Path: ep.path, Total: ep.total, Transferred: ep.transferred.Load()})
+}
+
+// Reports the end of the file transfer. Called on both successful and unsuccessful transfers.
+// Since the ProgressMgr isn't notified of the transfer result, a transfer is reported as failed if reading its content failed,
+// or if less than its known size was transferred.
+func (ep *eventsProgress) Abort() {
+	if !ep.completed.CompareAndSwap(false, true) {
+		return
+	}
+	event := ProgressEvent{Type: FileCompleted, Id: ep.id, Label: ep.label, Path: ep.path, Total: ep.total, Transferred: ep.transferred.Load()}
+	if readErr := ep.readErr.Load(); readErr != nil {
+		event.Type, event.Error = FileFailed, (*readErr).Error()
+	} else if event.Total > 0 && event.Transferred < event.Total {
+		event.Type, event.Error = FileFailed, fmt.Sprintf("only %d of %d bytes were transferred", event.Transferred, event.Total)
+	}
+	ep.manager.report(event)
+}
+
+//nolint:gocritic
+func (ep *eventsProgress) GetId() (Id int) {
+	return ep.id
+}
+
+type eventsProgressReader struct {
+	io.Reader
+	progress *eventsProgress
+}
+
+func (epr *eventsProgressReader) Read(p []byte) (n int, err error) {
+	n, err = epr.Reader.Read(p)
+	if n > 0 {
+		epr.progress.transferred.Add(int64(n))
+		epr.progress.reportTransferred()
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		epr.progress.readErr.CompareAndSwap(nil, &err)
+	}
+	return
+}
+
+// Execute the command while reporting its progress in the given format.
+// In the 'json' format, the progress events are written to the given output (see OpenProgressOutput),
+// followed by an 'error' event if the command fails.
+func ExecWithProgressFormat(cmd CommandWithProgress, progressFormat, progressOutput string) (err error) {
+	if progressFormat != ProgressFormatJson {
+		return ExecWithProgress(cmd)
+	}
+	output, err := OpenProgressOutput(progressOutput)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(output.Close()))
+	}()
+	reporter := NewJsonProgressReporter(output)
+	cmd.SetProgress(NewEventsProgressMgr(reporter))
+	if err = commands.Exec(cmd); err != nil {
+		_ = reporter.Report(ProgressEvent{Type: ProgressError, Time: time.Now(), Error: err.Error()})
+	}
+	return
+}
@@ -0,0 +1,110 @@
+package progressbar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventsProgressMgr(t *testing.T) {
+	previousInterval := bytesTransferredEventsInterval
+	bytesTransferredEventsInterval = 0
+	defer func() {
+		bytesTransferredEventsInterval = previousInterval
+	}()
+
+	output := new(bytes.Buffer)
+	progressMgr := NewEventsProgressMgr(NewJsonProgressReporter(output))
+	progress := progressMgr.NewProgressReader(7, "  Uploading  ", "repo/a.txt")
+	content, err := io.ReadAll(progress.ActionWithProgress(strings.NewReader("content")))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+	progressMgr.RemoveProgress(progress.GetId())
+	// Removing a progress twice should report its completion once.
+	progressMgr.RemoveProgress(progress.GetId())
+	progressMgr.IncrementGeneralProgress()
+	assert.Nil(t, progressMgr.GetProgress(progress.GetId()))
+
+	events := readProgressEvents(t, output)
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, ProgressEvent{Type: FileStarted, Id: 1, Label: "Uploading", Path: "repo/a.txt", Total: 7}, withoutTime(events[0]))
+		assert.Equal(t, ProgressEvent{Type: BytesTransferred, Id: 1, Path: "repo/a.txt", Total: 7, Transferred: 7}, withoutTime(events[1]))
+		assert.Equal(t, ProgressEvent{Type: FileCompleted, Id: 1, Label: "Uploading", Path: "repo/a.txt", Total: 7, Transferred: 7}, withoutTime(events[2]))
+		assert.Equal(t, TaskCompleted, events[3].Type)
+	}
+}
+
+func TestEventsProgressMgrFailedTransfer(t *testing.T) {
+	output := new(bytes.Buffer)
+	progressMgr := NewEventsProgressMgr(NewJsonProgressReporter(output))
+	// A transfer which was interrupted after some of the file was transferred.
+	progress := progressMgr.NewProgressReader(10, "", "a.txt")
+	_, err := io.ReadAll(progress.ActionWithProgress(strings.NewReader("content")))
+	assert.NoError(t, err)
+	progressMgr.RemoveProgress(progress.GetId())
+	// A transfer whose content couldn't be read.
+	progress = progressMgr.NewProgressReader(0, "", "b.txt")
+	_, err = io.ReadAll(progress.ActionWithProgress(iotest.ErrReader(errors.New("connection reset"))))
+	assert.EqualError(t, err, "connection reset")
+	progressMgr.RemoveProgress(progress.GetId())
+
+	events := readProgressEvents(t, output)
+	if assert.Len(t, events, 5) {
+		assert.Equal(t, ProgressEvent{Type: FileFailed, Id: 1, Path: "a.txt", Total: 10, Transferred: 7, Error: "only 7 of 10 bytes were transferred"}, withoutTime(events[2]))
+		assert.Equal(t, ProgressEvent{Type: FileFailed, Id: 2, Path: "b.txt", Error: "connection reset"}, withoutTime(events[4]))
+	}
+}
+
+func TestBytesTransferredEventsThrottling(t *testing.T) {
+	output := new(bytes.Buffer)
+	progressMgr := NewEventsProgressMgr(NewJsonProgressReporter(output))
+	progress := progressMgr.NewProgressReader(3, "", "a.txt")
+	for i := int64(1); i <= 3; i++ {
+		progress.SetProgress(i)
+	}
+	// Only the first update should be reported, since the rest are within the events interval.
+	assert.Equal(t, 1, strings.Count(output.String(), string(BytesTransferred)))
+}
+
+func TestOpenProgressOutput(t *testing.T) {
+	output, err := OpenProgressOutput("")
+	assert.NoError(t, err)
+	assert.NoError(t, output.Close())
+
+	_, err = OpenProgressOutput("fd:three")
+	assert.ErrorContains(t, err, "invalid progress output 'fd:three'")
+
+	filePath := filepath.Join(t.TempDir(), "progress.jsonl")
+	output, err = OpenProgressOutput(filePath)
+	assert.NoError(t, err)
+	assert.NoError(t, NewJsonProgressReporter(output).Report(ProgressEvent{Type: TaskCompleted}))
+	assert.NoError(t, output.Close())
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"type":"task_completed"`)
+}
+
+func readProgressEvents(t *testing.T, output io.Reader) (events []ProgressEvent) {
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		var event ProgressEvent
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	return
+}
+
+func withoutTime(event ProgressEvent) ProgressEvent {
+	event.Time = time.Time{}
+	return event
+}
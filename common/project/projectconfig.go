@@ -44,6 +44,9 @@ const (
 	Swift
 	Docker
 	Podman
+	Cargo
+	Conda
+	Composer
 )
 
 type ConfigType string
@@ -71,6 +74,9 @@ var ProjectTypes = []string{
 	"swift",
 	"docker",
 	"podman",
+	"cargo",
+	"conda",
+	"composer",
 }
 
 func (projectType ProjectType) String() string {
@@ -15,6 +15,9 @@ func TestFromString(t *testing.T) {
 		{"pip", Pip},
 		{"npm", Npm},
 		{"pnpm", Pnpm},
+		{"cargo", Cargo},
+		{"conda", Conda},
+		{"composer", Composer},
 	}
 
 	for _, testCase := range testCases {
@@ -0,0 +1,104 @@
+package spec
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// Matches the capture group references in the output of a path mapping, such as $1 or ${1}.
+var pathMappingGroupReference = regexp.MustCompile(`\$(\d+)|\$\{(\d+)}`)
+
+// Path mapping rules, which re-path the artifacts of a release bundle on the distribution targets.
+type PathMappings struct {
+	Mappings []PathMapping `json:"mappings,omitempty"`
+}
+
+// A path mapping rule. The input is a regular expression, which is matched against the full path of the artifact,
+// including its repository. The output is the target path, which may reference the input capture groups, such as $1.
+type PathMapping struct {
+	Input  string `json:"input,omitempty"`
+	Output string `json:"output,omitempty"`
+}
+
+func CreatePathMappingsFromFile(pathMappingsSpecPath string) (*PathMappings, error) {
+	content, err := fileutils.ReadFile(pathMappingsSpecPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	pathMappings := new(PathMappings)
+	if err = json.Unmarshal(content, pathMappings); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return pathMappings, pathMappings.Validate()
+}
+
+func (pathMappings *PathMappings) Validate() error {
+	if len(pathMappings.Mappings) == 0 {
+		return errorutils.CheckErrorf("the path mappings spec must include at least one mapping")
+	}
+	for i, pathMapping := range pathMappings.Mappings {
+		if err := pathMapping.validate(); err != nil {
+			return errorutils.CheckErrorf("invalid path mapping #%d: %s", i+1, err.Error())
+		}
+	}
+	return nil
+}
+
+func (pathMapping *PathMapping) validate() error {
+	if pathMapping.Input == "" || pathMapping.Output == "" {
+		return errorutils.CheckErrorf("both 'input' and 'output' are required")
+	}
+	inputRegex, err := pathMapping.compileInput()
+	if err != nil {
+		return errorutils.CheckErrorf("the input '%s' is not a valid regular expression: %s", pathMapping.Input, err.Error())
+	}
+	for _, reference := range pathMappingGroupReference.FindAllStringSubmatch(pathMapping.Output, -1) {
+		groupIndex, err := strconv.Atoi(reference[1] + reference[2])
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if groupIndex > inputRegex.NumSubexp() {
+			return errorutils.CheckErrorf("the output '%s' references the capture group %s, but the input '%s' has %d capture groups",
+				pathMapping.Output, reference[0], pathMapping.Input, inputRegex.NumSubexp())
+		}
+	}
+	return nil
+}
+
+// The input must match the full path of the artifact.
+func (pathMapping *PathMapping) compileInput() (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pathMapping.Input + ")$")
+}
+
+// Return the target path of an artifact according to the first matching mapping.
+// Artifacts which match none of the mappings keep their original path.
+// The mappings are matched with Go's RE2 syntax, which may differ from the Java regular expressions the server applies the mappings with.
+func (pathMappings *PathMappings) MapPath(artifactPath string) (string, error) {
+	for _, pathMapping := range pathMappings.Mappings {
+		inputRegex, err := pathMapping.compileInput()
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		match := inputRegex.FindStringSubmatchIndex(artifactPath)
+		if match == nil {
+			continue
+		}
+		// Use the ${N} form, so that a reference followed by a letter or a digit, such as $1a, isn't parsed as a named group.
+		template := pathMappingGroupReference.ReplaceAllString(pathMapping.Output, "$${$1$2}")
+		return string(inputRegex.ExpandString(nil, template, artifactPath, match)), nil
+	}
+	return artifactPath, nil
+}
+
+func (pathMappings *PathMappings) ToPathMappings() []utils.PathMapping {
+	var mappings []utils.PathMapping
+	for _, pathMapping := range pathMappings.Mappings {
+		mappings = append(mappings, utils.PathMapping{Input: pathMapping.Input, Output: pathMapping.Output})
+	}
+	return mappings
+}
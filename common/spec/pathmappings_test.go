@@ -0,0 +1,71 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathMappingsValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mappings      []PathMapping
+		expectedError string
+	}{
+		{name: "Valid", mappings: []PathMapping{{Input: "generic-local/(.*)/(.*)", Output: "generic-edge/$2/${1}"}}},
+		{name: "No mappings", expectedError: "at least one mapping"},
+		{name: "Missing output", mappings: []PathMapping{{Input: "generic-local/(.*)"}}, expectedError: "invalid path mapping #1: both 'input' and 'output' are required"},
+		{name: "Invalid regex", mappings: []PathMapping{{Input: "generic-local/(.*", Output: "generic-edge/$1"}}, expectedError: "is not a valid regular expression"},
+		{name: "Missing capture group", mappings: []PathMapping{{Input: "a/(.*)", Output: "b/$1"}, {Input: "a/(.*)", Output: "b/$2"}}, expectedError: "invalid path mapping #2: the output 'b/$2' references the capture group $2"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := (&PathMappings{Mappings: testCase.mappings}).Validate()
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestPathMappingsMapPath(t *testing.T) {
+	pathMappings := &PathMappings{Mappings: []PathMapping{
+		{Input: "docker-local/(.*)", Output: "docker-edge/$1"},
+		{Input: "generic-local/([^/]+)/(.*)", Output: "generic-edge/$2/$1a"},
+		{Input: "generic-local/(.*)", Output: "generic-edge/all/$1"},
+	}}
+	testCases := []struct {
+		sourcePath         string
+		expectedTargetPath string
+	}{
+		{sourcePath: "docker-local/alpine/latest/manifest.json", expectedTargetPath: "docker-edge/alpine/latest/manifest.json"},
+		{sourcePath: "generic-local/v1/app.zip", expectedTargetPath: "generic-edge/app.zip/v1a"},
+		{sourcePath: "generic-local/app.zip", expectedTargetPath: "generic-edge/all/app.zip"},
+		// The input must match the full path
+		{sourcePath: "npm-local/docker-local/a.tgz", expectedTargetPath: "npm-local/docker-local/a.tgz"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.sourcePath, func(t *testing.T) {
+			targetPath, err := pathMappings.MapPath(testCase.sourcePath)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedTargetPath, targetPath)
+		})
+	}
+}
+
+func TestCreatePathMappingsFromFile(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "mappings.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"mappings": [{"input": "generic-local/(.*)", "output": "generic-edge/$1"}]}`), 0644))
+	pathMappings, err := CreatePathMappingsFromFile(specPath)
+	require.NoError(t, err)
+	assert.Equal(t, []PathMapping{{Input: "generic-local/(.*)", Output: "generic-edge/$1"}}, pathMappings.Mappings)
+
+	require.NoError(t, os.WriteFile(specPath, []byte(`{"mappings": [{"input": "generic-local/(.*)", "output": "generic-edge/$2"}]}`), 0644))
+	_, err = CreatePathMappingsFromFile(specPath)
+	assert.ErrorContains(t, err, "references the capture group $2")
+}
@@ -12,7 +12,9 @@ import (
 
 const (
 	AdminScope        = "applied-permissions/admin"
+	UserScope         = "applied-permissions/user"
 	GroupsScopePrefix = "applied-permissions/groups:"
+	RolesScopePrefix  = "applied-permissions/roles:"
 )
 
 type AccessTokenCreateCommand struct {
@@ -22,7 +24,10 @@ type AccessTokenCreateCommand struct {
 
 	scope      string
 	groups     string
+	roles      string
 	grantAdmin bool
+	// Additional scopes, such as system:metrics:r, which are added to the scopes built from the groups, roles and admin.
+	extraScopes []string
 
 	expiry      *uint
 	refreshable bool
@@ -63,6 +68,17 @@ func (atc *AccessTokenCreateCommand) SetScope(scope string) *AccessTokenCreateCo
 	return atc
 }
 
+// Comma separated project roles. Requires a project key.
+func (atc *AccessTokenCreateCommand) SetRoles(roles string) *AccessTokenCreateCommand {
+	atc.roles = roles
+	return atc
+}
+
+func (atc *AccessTokenCreateCommand) SetExtraScopes(extraScopes []string) *AccessTokenCreateCommand {
+	atc.extraScopes = extraScopes
+	return atc
+}
+
 func (atc *AccessTokenCreateCommand) SetGrantAdmin(grantAdmin bool) *AccessTokenCreateCommand {
 	atc.grantAdmin = grantAdmin
 	return atc
@@ -98,6 +114,11 @@ func (atc *AccessTokenCreateCommand) Response() ([]byte, error) {
 	return content, errorutils.CheckError(err)
 }
 
+// The ID of the created token, which can be used to revoke it.
+func (atc *AccessTokenCreateCommand) TokenId() string {
+	return atc.response.TokenId
+}
+
 func (atc *AccessTokenCreateCommand) ServerDetails() (*config.ServerDetails, error) {
 	return atc.serverDetails, nil
 }
@@ -107,6 +128,9 @@ func (atc *AccessTokenCreateCommand) CommandName() string {
 }
 
 func (atc *AccessTokenCreateCommand) Run() error {
+	if err := atc.validate(); err != nil {
+		return err
+	}
 	servicesManager, err := rtUtils.CreateAccessServiceManager(atc.serverDetails, false)
 	if err != nil {
 		return err
@@ -130,8 +154,15 @@ func (atc *AccessTokenCreateCommand) getTokenParams() services.CreateTokenParams
 	return tokenParams
 }
 
+func (atc *AccessTokenCreateCommand) validate() error {
+	if atc.scope == "" && atc.roles != "" && atc.projectKey == "" {
+		return errorutils.CheckErrorf("a project key is required to create a token scoped to project roles")
+	}
+	return validateScope(atc.getScope())
+}
+
 // If an explicit scope was provided, apply it.
-// Otherwise, if admin or groups scopes were requested, construct scope from them (space separated).
+// Otherwise, if admin, groups, roles or additional scopes were requested, construct scope from them (space separated).
 // If no scopes were requested, leave scope empty to provide the default user scope.
 func (atc *AccessTokenCreateCommand) getScope() string {
 	if atc.scope != "" {
@@ -143,8 +174,31 @@ func (atc *AccessTokenCreateCommand) getScope() string {
 		scopes = append(scopes, GroupsScopePrefix+atc.groups)
 	}
 
+	if atc.roles != "" {
+		scopes = append(scopes, RolesScopePrefix+atc.projectKey+":"+atc.roles)
+	}
+
 	if atc.grantAdmin {
 		scopes = append(scopes, AdminScope)
 	}
+	scopes = append(scopes, atc.extraScopes...)
 	return strings.Join(scopes, " ")
 }
+
+// Validate the format of the groups and roles scopes. Other scopes are validated by Access.
+func validateScope(scope string) error {
+	for _, singleScope := range strings.Fields(scope) {
+		switch {
+		case strings.HasPrefix(singleScope, GroupsScopePrefix):
+			if strings.TrimPrefix(singleScope, GroupsScopePrefix) == "" {
+				return errorutils.CheckErrorf("the scope '%s' must include at least one group", singleScope)
+			}
+		case strings.HasPrefix(singleScope, RolesScopePrefix):
+			projectKey, roles, found := strings.Cut(strings.TrimPrefix(singleScope, RolesScopePrefix), ":")
+			if !found || projectKey == "" || roles == "" {
+				return errorutils.CheckErrorf("the scope '%s' must be in the format %s<project-key>:<role>[,<role>...]", singleScope, RolesScopePrefix)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessTokenCreateScope(t *testing.T) {
+	testCases := []struct {
+		name          string
+		command       *AccessTokenCreateCommand
+		expectedScope string
+		expectedError string
+	}{
+		{name: "Default user scope", command: NewAccessTokenCreateCommand()},
+		{name: "Explicit scope", command: NewAccessTokenCreateCommand().SetScope(UserScope + " system:metrics:r"), expectedScope: "applied-permissions/user system:metrics:r"},
+		{name: "Groups and admin", command: NewAccessTokenCreateCommand().SetGroups("readers,writers").SetGrantAdmin(true),
+			expectedScope: "applied-permissions/groups:readers,writers applied-permissions/admin"},
+		{name: "Project roles", command: NewAccessTokenCreateCommand().SetProjectKey("proj").SetRoles("Developer,Viewer").SetExtraScopes([]string{"system:livelogs:r"}),
+			expectedScope: "applied-permissions/roles:proj:Developer,Viewer system:livelogs:r"},
+		{name: "Roles without project", command: NewAccessTokenCreateCommand().SetRoles("Developer"), expectedError: "a project key is required"},
+		// An explicit scope takes precedence over the groups, roles, admin and additional scopes.
+		{name: "Explicit scope with groups", command: NewAccessTokenCreateCommand().SetScope(UserScope).SetGroups("readers").SetRoles("Developer").SetGrantAdmin(true),
+			expectedScope: "applied-permissions/user"},
+		{name: "Invalid roles scope", command: NewAccessTokenCreateCommand().SetScope("applied-permissions/roles:proj"), expectedError: "must be in the format"},
+		{name: "Empty groups scope", command: NewAccessTokenCreateCommand().SetScope("applied-permissions/groups:"), expectedError: "at least one group"},
+		// Scopes which aren't known to the CLI are left for Access to validate.
+		{name: "Unknown applied-permissions scope", command: NewAccessTokenCreateCommand().SetScope("applied-permissions/everything"), expectedScope: "applied-permissions/everything"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.command.validate()
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedScope, testCase.command.getScope())
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package lifecycle
 
 import (
+	"path"
+
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
 	"github.com/jfrog/jfrog-client-go/lifecycle/services"
+	"github.com/jfrog/jfrog-client-go/utils/distribution"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type ReleaseBundleDistributeCommand struct {
@@ -13,9 +20,16 @@ type ReleaseBundleDistributeCommand struct {
 	autoCreateRepo     bool
 	pathMappingPattern string
 	pathMappingTarget  string
+	pathMappings       *spec.PathMappings
 	maxWaitMinutes     int
 }
 
+// The source and target paths of an artifact distributed with path mappings.
+type distributedArtifactPath struct {
+	SourcePath string `col-name:"Source Path"`
+	TargetPath string `col-name:"Target Path"`
+}
+
 func NewReleaseBundleDistributeCommand() *ReleaseBundleDistributeCommand {
 	return &ReleaseBundleDistributeCommand{}
 }
@@ -65,6 +79,12 @@ func (rbd *ReleaseBundleDistributeCommand) SetPathMappingTarget(pathMappingTarge
 	return rbd
 }
 
+// Regex based path mappings, loaded from a path mappings spec file. Can't be used together with the path mapping pattern and target.
+func (rbd *ReleaseBundleDistributeCommand) SetPathMappings(pathMappings *spec.PathMappings) *ReleaseBundleDistributeCommand {
+	rbd.pathMappings = pathMappings
+	return rbd
+}
+
 func (rbd *ReleaseBundleDistributeCommand) SetSync(sync bool) *ReleaseBundleDistributeCommand {
 	rbd.sync = sync
 	return rbd
@@ -76,6 +96,9 @@ func (rbd *ReleaseBundleDistributeCommand) SetMaxWaitMinutes(maxWaitMinutes int)
 }
 
 func (rbd *ReleaseBundleDistributeCommand) Run() error {
+	if err := rbd.validatePathMappings(); err != nil {
+		return err
+	}
 	if err := validateArtifactoryVersionSupported(rbd.serverDetails); err != nil {
 		return err
 	}
@@ -99,7 +122,94 @@ func (rbd *ReleaseBundleDistributeCommand) Run() error {
 		ProjectKey:        rbd.rbProjectKey,
 	}
 
-	return servicesManager.DistributeReleaseBundle(rbDetails, distributeParams)
+	if rbd.pathMappings == nil && !rbd.dryRun {
+		return servicesManager.DistributeReleaseBundle(rbDetails, distributeParams)
+	}
+	pathMappings := rbd.getEffectivePathMappings()
+	if rbd.dryRun {
+		if err = printDistributedArtifactPaths(servicesManager, rbDetails, pathMappings); err != nil {
+			return err
+		}
+	}
+	return rbd.distributeWithPathMappings(servicesManager, rbDetails, distributeParams, pathMappings)
+}
+
+func (rbd *ReleaseBundleDistributeCommand) validatePathMappings() error {
+	if rbd.pathMappings == nil {
+		return nil
+	}
+	if rbd.pathMappingPattern != "" || rbd.pathMappingTarget != "" {
+		return errorutils.CheckErrorf("a path mappings spec can't be used together with a path mapping pattern and target")
+	}
+	return rbd.pathMappings.Validate()
+}
+
+// Return the path mappings spec, or convert the wildcard based path mapping pattern and target to a regex based mapping.
+func (rbd *ReleaseBundleDistributeCommand) getEffectivePathMappings() *spec.PathMappings {
+	if rbd.pathMappings != nil {
+		return rbd.pathMappings
+	}
+	pathMappings := new(spec.PathMappings)
+	for _, pathMapping := range distribution.CreatePathMappingsFromPatternAndTarget(rbd.pathMappingPattern, rbd.pathMappingTarget) {
+		pathMappings.Mappings = append(pathMappings.Mappings, spec.PathMapping{Input: pathMapping.Input, Output: pathMapping.Output})
+	}
+	return pathMappings
+}
+
+// The lifecycle services manager accepts wildcard based path mappings only, so the regex based mappings are sent using the distribution service directly.
+func (rbd *ReleaseBundleDistributeCommand) distributeWithPathMappings(servicesManager *lifecycle.LifecycleServicesManager, rbDetails services.ReleaseBundleDetails,
+	distributeParams services.DistributeReleaseBundleParams, pathMappings *spec.PathMappings) error {
+	lcDetails, err := rbd.serverDetails.CreateLifecycleAuthConfig()
+	if err != nil {
+		return err
+	}
+	distributeBundleService := services.NewDistributeReleaseBundleService(servicesManager.Client())
+	distributeBundleService.LcDetails = lcDetails
+	distributeBundleService.DryRun = rbd.dryRun
+	distributeBundleService.DistributeParams = distribution.DistributionParams{
+		Name:              rbDetails.ReleaseBundleName,
+		Version:           rbDetails.ReleaseBundleVersion,
+		DistributionRules: distributeParams.DistributionRules,
+	}
+	distributeBundleService.AutoCreateRepo = distributeParams.AutoCreateRepo
+	distributeBundleService.Sync = distributeParams.Sync
+	distributeBundleService.MaxWaitMinutes = distributeParams.MaxWaitMinutes
+	distributeBundleService.ProjectKey = distributeParams.ProjectKey
+	distributeBundleService.Modifications.PathMappings = pathMappings.ToPathMappings()
+	return distributeBundleService.Distribute()
+}
+
+// Print the target path of each of the release bundle artifacts on the distribution targets.
+// The target paths are previewed with Go's RE2 regular expressions, matching the full path of each artifact, while the server applies
+// Java regular expressions. Constructs which RE2 doesn't support, such as lookarounds and backreferences, are rejected by the path mappings validation,
+// but constructs which both support with different semantics, such as the Unicode classes and the \Z anchor, may be previewed differently than they are applied.
+func printDistributedArtifactPaths(servicesManager *lifecycle.LifecycleServicesManager, rbDetails services.ReleaseBundleDetails, pathMappings *spec.PathMappings) error {
+	rbSpec, err := servicesManager.GetReleaseBundleSpecification(rbDetails)
+	if err != nil {
+		return err
+	}
+	var sourcePaths []string
+	for _, artifact := range rbSpec.Artifacts {
+		sourcePaths = append(sourcePaths, path.Join(artifact.SourceRepositoryKey, artifact.Path))
+	}
+	artifactPaths, err := getDistributedArtifactPaths(sourcePaths, pathMappings)
+	if err != nil {
+		return err
+	}
+	log.Info("The target paths are previewed locally. Path mappings using regular expression constructs which behave differently in Java may be applied differently by the server.")
+	return coreutils.PrintTable(artifactPaths, "Distributed Artifacts", "The release bundle has no artifacts", false)
+}
+
+func getDistributedArtifactPaths(sourcePaths []string, pathMappings *spec.PathMappings) ([]distributedArtifactPath, error) {
+	artifactPaths := make([]distributedArtifactPath, 0, len(sourcePaths))
+	for _, sourcePath := range sourcePaths {
+		targetPath, err := pathMappings.MapPath(sourcePath)
+		if err != nil {
+			return nil, err
+		}
+		artifactPaths = append(artifactPaths, distributedArtifactPath{SourcePath: sourcePath, TargetPath: targetPath})
+	}
+	return artifactPaths, nil
 }
 
 func (rbd *ReleaseBundleDistributeCommand) ServerDetails() (*config.ServerDetails, error) {
@@ -120,10 +120,15 @@ func createArtifactoryServiceManager(artDetails *config.ServerDetails) (artifact
 	if err != nil {
 		return nil, err
 	}
+	httpClient, err := artDetails.GetCustomHttpClient(certsPath, 0)
+	if err != nil {
+		return nil, err
+	}
 	serviceConfig, err := clientConfig.NewConfigBuilder().
 		SetServiceDetails(artAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(artDetails.InsecureTls).
+		SetHttpClient(httpClient).
 		SetDryRun(false).
 		Build()
 	if err != nil {
@@ -0,0 +1,340 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	distributionServices "github.com/jfrog/jfrog-client-go/distribution/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	keyPairRestApi              = "api/security/keypair"
+	distributionPropagateKeyApi = "api/v1/keys/pgp/propagate"
+
+	KeyPairTypeGpg = "GPG"
+	KeyPairTypeRsa = "RSA"
+)
+
+// A signing key pair, stored in Artifactory and used for signing release bundles.
+// Release bundles v2 are signed by Artifactory with the key pair given by its name.
+// Release bundles v1 are signed by Distribution with its GPG key, which is also propagated to the Edge nodes for verifying the bundles.
+type SigningKeyPair struct {
+	PairName   string `json:"pairName" col-name:"Name"`
+	PairType   string `json:"pairType" col-name:"Type"`
+	Alias      string `json:"alias" col-name:"Alias"`
+	PublicKey  string `json:"publicKey,omitempty"`
+	PrivateKey string `json:"privateKey,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+func (kp *SigningKeyPair) validate() error {
+	if kp.PairName == "" {
+		return errorutils.CheckErrorf("the signing key pair name is required")
+	}
+	if kp.PairType != KeyPairTypeGpg && kp.PairType != KeyPairTypeRsa {
+		return errorutils.CheckErrorf("unsupported signing key pair type '%s'. The supported types are %s and %s", kp.PairType, KeyPairTypeGpg, KeyPairTypeRsa)
+	}
+	if kp.PublicKey == "" || kp.PrivateKey == "" {
+		return errorutils.CheckErrorf("both the public key and the private key of the signing key pair '%s' are required", kp.PairName)
+	}
+	return nil
+}
+
+type signingKeyCmd struct {
+	serverDetails *config.ServerDetails
+}
+
+func (skc *signingKeyCmd) ServerDetails() (*config.ServerDetails, error) {
+	return skc.serverDetails, nil
+}
+
+// Upload a signing key pair to Artifactory.
+// If requested, the GPG key pair is also set as the Distribution signing key of release bundles v1, and propagated to the Edge nodes.
+type SigningKeyUploadCommand struct {
+	signingKeyCmd
+	keyPair      SigningKeyPair
+	distribution bool
+}
+
+func NewSigningKeyUploadCommand() *SigningKeyUploadCommand {
+	return &SigningKeyUploadCommand{}
+}
+
+func (sku *SigningKeyUploadCommand) SetServerDetails(serverDetails *config.ServerDetails) *SigningKeyUploadCommand {
+	sku.serverDetails = serverDetails
+	return sku
+}
+
+func (sku *SigningKeyUploadCommand) SetKeyPair(keyPair SigningKeyPair) *SigningKeyUploadCommand {
+	sku.keyPair = keyPair
+	return sku
+}
+
+func (sku *SigningKeyUploadCommand) SetDistribution(distribution bool) *SigningKeyUploadCommand {
+	sku.distribution = distribution
+	return sku
+}
+
+func (sku *SigningKeyUploadCommand) CommandName() string {
+	return "rb_signing_key_upload"
+}
+
+func (sku *SigningKeyUploadCommand) Run() error {
+	return uploadSigningKey(sku.serverDetails, sku.keyPair, sku.distribution)
+}
+
+// List the signing key pairs stored in Artifactory.
+type SigningKeyListCommand struct {
+	signingKeyCmd
+	format format.OutputFormat
+}
+
+func NewSigningKeyListCommand() *SigningKeyListCommand {
+	return &SigningKeyListCommand{}
+}
+
+func (skl *SigningKeyListCommand) SetServerDetails(serverDetails *config.ServerDetails) *SigningKeyListCommand {
+	skl.serverDetails = serverDetails
+	return skl
+}
+
+func (skl *SigningKeyListCommand) SetFormat(format format.OutputFormat) *SigningKeyListCommand {
+	skl.format = format
+	return skl
+}
+
+func (skl *SigningKeyListCommand) CommandName() string {
+	return "rb_signing_key_list"
+}
+
+func (skl *SigningKeyListCommand) Run() error {
+	serviceManager, err := utils.CreateServiceManager(skl.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	keyPairs, err := getSigningKeyPairs(serviceManager)
+	if err != nil {
+		return err
+	}
+	if skl.format == format.Json {
+		content, err := json.Marshal(keyPairs)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	}
+	return coreutils.PrintTable(keyPairs, "Signing Keys", "No signing keys were found", false)
+}
+
+// Replace a signing key pair with a new one.
+// The new key pair is uploaded, and if requested, set as the Distribution signing key and propagated to the Edge nodes, before the old key pair is deleted.
+type SigningKeyRotateCommand struct {
+	signingKeyCmd
+	oldPairName  string
+	newKeyPair   SigningKeyPair
+	distribution bool
+	keepOld      bool
+}
+
+func NewSigningKeyRotateCommand() *SigningKeyRotateCommand {
+	return &SigningKeyRotateCommand{}
+}
+
+func (skr *SigningKeyRotateCommand) SetServerDetails(serverDetails *config.ServerDetails) *SigningKeyRotateCommand {
+	skr.serverDetails = serverDetails
+	return skr
+}
+
+func (skr *SigningKeyRotateCommand) SetOldPairName(oldPairName string) *SigningKeyRotateCommand {
+	skr.oldPairName = oldPairName
+	return skr
+}
+
+func (skr *SigningKeyRotateCommand) SetNewKeyPair(newKeyPair SigningKeyPair) *SigningKeyRotateCommand {
+	skr.newKeyPair = newKeyPair
+	return skr
+}
+
+func (skr *SigningKeyRotateCommand) SetDistribution(distribution bool) *SigningKeyRotateCommand {
+	skr.distribution = distribution
+	return skr
+}
+
+// Keep the old key pair, to allow verifying the release bundles which were signed with it.
+func (skr *SigningKeyRotateCommand) SetKeepOld(keepOld bool) *SigningKeyRotateCommand {
+	skr.keepOld = keepOld
+	return skr
+}
+
+func (skr *SigningKeyRotateCommand) CommandName() string {
+	return "rb_signing_key_rotate"
+}
+
+func (skr *SigningKeyRotateCommand) Run() error {
+	if skr.oldPairName == skr.newKeyPair.PairName {
+		return errorutils.CheckErrorf("the new signing key pair must have a different name than the rotated key pair '%s'", skr.oldPairName)
+	}
+	serviceManager, err := utils.CreateServiceManager(skr.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	if _, err = getSigningKeyPair(serviceManager, skr.oldPairName); err != nil {
+		return err
+	}
+	if err = uploadSigningKey(skr.serverDetails, skr.newKeyPair, skr.distribution); err != nil {
+		return err
+	}
+	if skr.keepOld {
+		log.Info(fmt.Sprintf("The signing key pair '%s' was rotated to '%s'. The old key pair was kept.", skr.oldPairName, skr.newKeyPair.PairName))
+		return nil
+	}
+	if err = deleteSigningKeyPair(serviceManager, skr.oldPairName); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("The signing key pair '%s' was rotated to '%s'.", skr.oldPairName, skr.newKeyPair.PairName))
+	return nil
+}
+
+// Propagate the public GPG key of Distribution to all the Edge nodes, to allow them to verify the release bundles v1.
+type SigningKeyPropagateCommand struct {
+	signingKeyCmd
+}
+
+func NewSigningKeyPropagateCommand() *SigningKeyPropagateCommand {
+	return &SigningKeyPropagateCommand{}
+}
+
+func (skp *SigningKeyPropagateCommand) SetServerDetails(serverDetails *config.ServerDetails) *SigningKeyPropagateCommand {
+	skp.serverDetails = serverDetails
+	return skp
+}
+
+func (skp *SigningKeyPropagateCommand) CommandName() string {
+	return "rb_signing_key_propagate"
+}
+
+func (skp *SigningKeyPropagateCommand) Run() error {
+	return propagateDistributionSigningKey(skp.serverDetails)
+}
+
+func uploadSigningKey(serverDetails *config.ServerDetails, keyPair SigningKeyPair, distribution bool) error {
+	if err := keyPair.validate(); err != nil {
+		return err
+	}
+	if distribution && keyPair.PairType != KeyPairTypeGpg {
+		return errorutils.CheckErrorf("release bundles v1 can be signed by Distribution with a %s key pair only", KeyPairTypeGpg)
+	}
+	serviceManager, err := utils.CreateServiceManager(serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	if err = createSigningKeyPair(serviceManager, keyPair); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("The %s signing key pair '%s' was uploaded to Artifactory.", keyPair.PairType, keyPair.PairName))
+	if !distribution {
+		return nil
+	}
+	distributionManager, err := utils.CreateDistributionServiceManager(serverDetails, false)
+	if err != nil {
+		return err
+	}
+	if err = distributionManager.SetSigningKey(distributionServices.NewSetSigningKeyParams(keyPair.PublicKey, keyPair.PrivateKey)); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("The signing key pair '%s' was set as the Distribution signing key.", keyPair.PairName))
+	return propagateDistributionSigningKey(serverDetails)
+}
+
+func propagateDistributionSigningKey(serverDetails *config.ServerDetails) error {
+	distributionManager, err := utils.CreateDistributionServiceManager(serverDetails, false)
+	if err != nil {
+		return err
+	}
+	distDetails := distributionManager.Config().GetServiceDetails()
+	httpClientDetails := distDetails.CreateHttpClientDetails()
+	resp, body, err := distributionManager.Client().SendPost(clientutils.AddTrailingSlashIfNeeded(distDetails.GetUrl())+distributionPropagateKeyApi, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return err
+	}
+	log.Debug("Distribution response:", resp.Status)
+	log.Debug(clientutils.IndentJson(body))
+	log.Info("The Distribution signing key was propagated to the Edge nodes.")
+	return nil
+}
+
+func createSigningKeyPair(serviceManager artifactory.ArtifactoryServicesManager, keyPair SigningKeyPair) error {
+	content, err := json.Marshal(keyPair)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	rtDetails := serviceManager.GetConfig().GetServiceDetails()
+	httpClientDetails := rtDetails.CreateHttpClientDetails()
+	httpClientDetails.SetContentTypeApplicationJson()
+	resp, body, err := serviceManager.Client().SendPost(rtDetails.GetUrl()+keyPairRestApi, content, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+func getSigningKeyPairs(serviceManager artifactory.ArtifactoryServicesManager) ([]SigningKeyPair, error) {
+	rtDetails := serviceManager.GetConfig().GetServiceDetails()
+	httpClientDetails := rtDetails.CreateHttpClientDetails()
+	resp, body, _, err := serviceManager.Client().SendGet(rtDetails.GetUrl()+keyPairRestApi, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var keyPairs []SigningKeyPair
+	if err = json.Unmarshal(body, &keyPairs); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return keyPairs, nil
+}
+
+func getSigningKeyPair(serviceManager artifactory.ArtifactoryServicesManager, pairName string) (*SigningKeyPair, error) {
+	rtDetails := serviceManager.GetConfig().GetServiceDetails()
+	httpClientDetails := rtDetails.CreateHttpClientDetails()
+	resp, body, _, err := serviceManager.Client().SendGet(rtDetails.GetUrl()+keyPairRestApi+"/"+url.PathEscape(pairName), true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errorutils.CheckErrorf("the signing key pair '%s' was not found", pairName)
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	keyPair := new(SigningKeyPair)
+	if err = json.Unmarshal(body, keyPair); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return keyPair, nil
+}
+
+func deleteSigningKeyPair(serviceManager artifactory.ArtifactoryServicesManager, pairName string) error {
+	rtDetails := serviceManager.GetConfig().GetServiceDetails()
+	httpClientDetails := rtDetails.CreateHttpClientDetails()
+	resp, body, err := serviceManager.Client().SendDelete(rtDetails.GetUrl()+keyPairRestApi+"/"+url.PathEscape(pairName), nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+package lifecycle
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"testing"
+
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindJwsSigningKeyPair(t *testing.T) {
+	signingKey, signingPublicKey := createRsaKeyPair(t)
+	_, otherPublicKey := createRsaKeyPair(t)
+	keyPairs := []SigningKeyPair{
+		{PairName: "gpg-key", PairType: KeyPairTypeGpg, PublicKey: "-----BEGIN PGP PUBLIC KEY BLOCK-----"},
+		{PairName: "other-key", PairType: KeyPairTypeRsa, PublicKey: otherPublicKey},
+		{PairName: "signing-key", PairType: KeyPairTypeRsa, PublicKey: signingPublicKey},
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"name":"bundle","version":"1.0.0"}`))
+	digest := sha256.Sum256([]byte(header + "." + payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, signingKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	jws := header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+	keyPair, err := findJwsSigningKeyPair(jws+"\n", keyPairs)
+	require.NoError(t, err)
+	assert.Equal(t, "signing-key", keyPair.PairName)
+
+	_, err = findJwsSigningKeyPair(jws, keyPairs[:2])
+	assert.ErrorContains(t, err, "was not signed by any")
+	_, err = findJwsSigningKeyPair("not-a-jws", keyPairs)
+	assert.ErrorContains(t, err, "not a valid JWS")
+	unsupportedHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`))
+	_, err = findJwsSigningKeyPair(unsupportedHeader+"."+payload+".c2ln", keyPairs)
+	assert.ErrorContains(t, err, "unsupported JWS signature algorithm")
+}
+
+func TestSigningKeyPairValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		keyPair       SigningKeyPair
+		expectedError string
+	}{
+		{name: "Valid", keyPair: SigningKeyPair{PairName: "key", PairType: KeyPairTypeGpg, PublicKey: "public", PrivateKey: "private"}},
+		{name: "No name", keyPair: SigningKeyPair{PairType: KeyPairTypeGpg, PublicKey: "public", PrivateKey: "private"}, expectedError: "name is required"},
+		{name: "Unsupported type", keyPair: SigningKeyPair{PairName: "key", PairType: "SSH", PublicKey: "public", PrivateKey: "private"}, expectedError: "unsupported signing key pair type"},
+		{name: "No private key", keyPair: SigningKeyPair{PairName: "key", PairType: KeyPairTypeRsa, PublicKey: "public"}, expectedError: "are required"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.keyPair.validate()
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestSigningKeyRotate(t *testing.T) {
+	var created []SigningKeyPair
+	var deleted []string
+	testServer, serverDetails, _ := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/"+keyPairRestApi+"/old-key":
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"pairName":"old-key","pairType":"RSA"}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.URL.Path == "/"+keyPairRestApi:
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var keyPair SigningKeyPair
+			assert.NoError(t, json.Unmarshal(content, &keyPair))
+			created = append(created, keyPair)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && r.URL.Path == "/"+keyPairRestApi+"/old-key":
+			deleted = append(deleted, "old-key")
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer testServer.Close()
+
+	newKeyPair := SigningKeyPair{PairName: "new-key", PairType: KeyPairTypeRsa, Alias: "new", PublicKey: "public", PrivateKey: "private"}
+	rotateCommand := NewSigningKeyRotateCommand().SetServerDetails(serverDetails).SetOldPairName("old-key").SetNewKeyPair(newKeyPair)
+	require.NoError(t, rotateCommand.Run())
+	assert.Equal(t, []SigningKeyPair{newKeyPair}, created)
+	assert.Equal(t, []string{"old-key"}, deleted)
+
+	// A missing key pair isn't rotated
+	err := rotateCommand.SetOldPairName("missing-key").Run()
+	assert.ErrorContains(t, err, "'missing-key' was not found")
+	assert.Len(t, created, 1)
+
+	// Distribution signs release bundles v1 with GPG keys only
+	err = rotateCommand.SetOldPairName("old-key").SetDistribution(true).Run()
+	assert.ErrorContains(t, err, "GPG key pair only")
+	assert.Len(t, created, 1)
+}
+
+func createRsaKeyPair(t *testing.T) (*rsa.PrivateKey, string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	return privateKey, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+}
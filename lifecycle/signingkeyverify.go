@@ -0,0 +1,163 @@
+package lifecycle
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Find the signing key pair which signed a release bundle.
+// The signed release bundle is a JWS, such as a release bundle v1 stored in the release-bundles repository.
+// Only RSA key pairs can be verified, since Artifactory doesn't expose the raw keys of GPG key pairs.
+type SigningKeyVerifyCommand struct {
+	signingKeyCmd
+	signedBundlePath string
+}
+
+func NewSigningKeyVerifyCommand() *SigningKeyVerifyCommand {
+	return &SigningKeyVerifyCommand{}
+}
+
+func (skv *SigningKeyVerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *SigningKeyVerifyCommand {
+	skv.serverDetails = serverDetails
+	return skv
+}
+
+func (skv *SigningKeyVerifyCommand) SetSignedBundlePath(signedBundlePath string) *SigningKeyVerifyCommand {
+	skv.signedBundlePath = signedBundlePath
+	return skv
+}
+
+func (skv *SigningKeyVerifyCommand) CommandName() string {
+	return "rb_signing_key_verify"
+}
+
+func (skv *SigningKeyVerifyCommand) Run() error {
+	content, err := os.ReadFile(skv.signedBundlePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	serviceManager, err := utils.CreateServiceManager(skv.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	keyPairs, err := getSigningKeyPairs(serviceManager)
+	if err != nil {
+		return err
+	}
+	keyPair, err := findJwsSigningKeyPair(string(content), keyPairs)
+	if err != nil {
+		return err
+	}
+	log.Output(fmt.Sprintf("The release bundle was signed with the %s signing key pair '%s'.", keyPair.PairType, keyPair.PairName))
+	return nil
+}
+
+type jwsHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+// Return the RSA key pair which verifies the JWS signature.
+func findJwsSigningKeyPair(jws string, keyPairs []SigningKeyPair) (*SigningKeyPair, error) {
+	parts := strings.Split(strings.TrimSpace(jws), ".")
+	if len(parts) != 3 {
+		return nil, errorutils.CheckErrorf("the signed release bundle is not a valid JWS")
+	}
+	headerContent, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to decode the JWS header: %s", err.Error())
+	}
+	var header jwsHeader
+	if err = json.Unmarshal(headerContent, &header); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the JWS header: %s", err.Error())
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to decode the JWS signature: %s", err.Error())
+	}
+	hash, pss, err := getJwsHash(header.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(parts[0] + "." + parts[1]))
+	digest := hasher.Sum(nil)
+	for i, keyPair := range keyPairs {
+		if !strings.EqualFold(keyPair.PairType, KeyPairTypeRsa) {
+			log.Debug(fmt.Sprintf("Skipping the %s signing key pair '%s'.", keyPair.PairType, keyPair.PairName))
+			continue
+		}
+		publicKey, err := parseRsaPublicKey(keyPair.PublicKey)
+		if err != nil {
+			log.Debug(fmt.Sprintf("Skipping the signing key pair '%s': %s", keyPair.PairName, err.Error()))
+			continue
+		}
+		if pss {
+			err = rsa.VerifyPSS(publicKey, hash, digest, signature, nil)
+		} else {
+			err = rsa.VerifyPKCS1v15(publicKey, hash, digest, signature)
+		}
+		if err == nil {
+			return &keyPairs[i], nil
+		}
+	}
+	return nil, errorutils.CheckErrorf("the release bundle was not signed by any of the RSA signing key pairs in Artifactory")
+}
+
+func getJwsHash(algorithm string) (hash crypto.Hash, pss bool, err error) {
+	switch algorithm {
+	case "RS256":
+		return crypto.SHA256, false, nil
+	case "RS384":
+		return crypto.SHA384, false, nil
+	case "RS512":
+		return crypto.SHA512, false, nil
+	case "PS256":
+		return crypto.SHA256, true, nil
+	case "PS384":
+		return crypto.SHA384, true, nil
+	case "PS512":
+		return crypto.SHA512, true, nil
+	}
+	return 0, false, errorutils.CheckErrorf("unsupported JWS signature algorithm '%s'", algorithm)
+}
+
+// Parse an RSA public key in PEM format, which may be a PKIX or a PKCS#1 public key, or a certificate.
+func parseRsaPublicKey(publicKeyPem string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPem))
+	if block == nil {
+		return nil, fmt.Errorf("the public key is not in PEM format")
+	}
+	var publicKey any
+	var err error
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var certificate *x509.Certificate
+		if certificate, err = x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, err
+		}
+		publicKey = certificate.PublicKey
+	default:
+		if publicKey, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, err
+		}
+	}
+	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("the public key is not an RSA key")
+	}
+	return rsaPublicKey, nil
+}
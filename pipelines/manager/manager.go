@@ -2,6 +2,7 @@ package manager
 
 import (
 	utilsconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	clientConfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/pipelines"
 )
@@ -21,5 +22,16 @@ func CreateServiceManager(serviceDetails *utilsconfig.ServerDetails) (*pipelines
 	if err != nil {
 		return nil, err
 	}
-	return pipelines.New(serviceConfig)
+	serviceManager, err := pipelines.New(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err = serviceDetails.SetCustomHttpTransport(serviceManager.Client(), certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
 }
@@ -34,16 +34,22 @@ func GetFileSystemSpec(c *components.Context) (fsSpec *spec.SpecFiles, err error
 	// Override spec with CLI options
 	for i := 0; i < len(fsSpec.Files); i++ {
 		fsSpec.Get(i).Target = strings.TrimPrefix(fsSpec.Get(i).Target, "/")
-		OverrideSpecFieldsIfSet(fsSpec.Get(i), c)
+		if err = OverrideSpecFieldsIfSet(fsSpec.Get(i), c); err != nil {
+			return
+		}
 	}
 	return
 }
 
-func OverrideSpecFieldsIfSet(spec *spec.File, c *components.Context) {
+func OverrideSpecFieldsIfSet(spec *spec.File, c *components.Context) error {
 	OverrideArrayIfSet(&spec.Exclusions, c, "exclusions")
 	OverrideArrayIfSet(&spec.SortBy, c, "sort-by")
-	OverrideIntIfSet(&spec.Offset, c, "offset")
-	OverrideIntIfSet(&spec.Limit, c, "limit")
+	if err := OverrideIntIfSet(&spec.Offset, c, "offset"); err != nil {
+		return err
+	}
+	if err := OverrideIntIfSet(&spec.Limit, c, "limit"); err != nil {
+		return err
+	}
 	OverrideStringIfSet(&spec.SortOrder, c, "sort-order")
 	OverrideStringIfSet(&spec.Props, c, "props")
 	OverrideStringIfSet(&spec.TargetProps, c, "target-props")
@@ -63,4 +69,5 @@ func OverrideSpecFieldsIfSet(spec *spec.File, c *components.Context) {
 	OverrideStringIfSet(&spec.Symlinks, c, "symlinks")
 	OverrideStringIfSet(&spec.Transitive, c, "transitive")
 	OverrideStringIfSet(&spec.PublicGpgKey, c, "gpg-key")
+	return nil
 }
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Read flag values from a file, to be used as defaults for flags which weren't provided in the command line.
+// Each line in the file should be in the 'key=value' format. Blank lines and lines starting with '#' are ignored.
+// Flags which were explicitly set in the command line always take precedence, and are therefore omitted from the returned map.
+func LoadFlagDefaultsFromFile(c *components.Context, path string) (defaults map[string]string, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+
+	defaults = make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, errorutils.CheckErrorf("malformed line %d in flags file '%s': expected 'key=value' but got '%s'", lineNumber, path, line)
+		}
+		if c.IsFlagSet(key) {
+			continue
+		}
+		defaults[key] = strings.TrimSpace(value)
+	}
+	return defaults, errorutils.CheckError(scanner.Err())
+}
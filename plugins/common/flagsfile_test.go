@@ -0,0 +1,47 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFlagDefaultsFromFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		expected  map[string]string
+		expectErr bool
+	}{
+		{"empty", "", map[string]string{}, false},
+		{"comments and blank lines", "# comment\n\n  # indented comment\nthreads=5\n", map[string]string{"threads": "5"}, false},
+		{"trim spaces", " threads = 5 \nrecursive=", map[string]string{"threads": "5", "recursive": ""}, false},
+		{"value with separator", "props=a=b;c=d", map[string]string{"props": "a=b;c=d"}, false},
+		{"explicit flag precedence", "threads=5\nproject=proj", map[string]string{"threads": "5"}, false},
+		{"missing separator", "threads=5\nrecursive", nil, true},
+		{"missing key", "=5", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "flags")
+			assert.NoError(t, os.WriteFile(path, []byte(test.content), 0600))
+			c := &components.Context{}
+			c.AddStringFlag("project", "cli-proj")
+			defaults, err := LoadFlagDefaultsFromFile(c, path)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, defaults)
+		})
+	}
+}
+
+func TestLoadFlagDefaultsFromFileNotExist(t *testing.T) {
+	_, err := LoadFlagDefaultsFromFile(&components.Context{}, filepath.Join(t.TempDir(), "not-exist"))
+	assert.Error(t, err)
+}
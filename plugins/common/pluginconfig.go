@@ -0,0 +1,137 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const pluginConfigFileName = "config.yaml"
+
+type PluginConfigValueType string
+
+const (
+	PluginConfigString PluginConfigValueType = "string"
+	PluginConfigBool   PluginConfigValueType = "bool"
+	PluginConfigInt    PluginConfigValueType = "int"
+)
+
+// Declares which flags can be configured in the plugin's config file, mapped to the expected type of their values.
+type PluginConfigSchema map[string]PluginConfigValueType
+
+// Returns the path to the plugin's config file - '<JFrog home>/plugins/<plugin-name>/config.yaml'.
+func GetPluginConfigFilePath(pluginName string) (string, error) {
+	pluginsDir, err := coreutils.GetJfrogPluginsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(pluginsDir, pluginName, pluginConfigFileName), nil
+}
+
+// Load the plugin's config file and validate it against the schema.
+// The returned map holds the string representation of each configured value.
+// If the config file doesn't exist, an empty map is returned.
+func LoadPluginConfig(pluginName string, schema PluginConfigSchema) (map[string]string, error) {
+	path, err := GetPluginConfigFilePath(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	return loadPluginConfigFile(path, schema)
+}
+
+func loadPluginConfigFile(path string, schema PluginConfigSchema) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var rawConfig map[string]interface{}
+	if err = yaml.Unmarshal(content, &rawConfig); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the plugin config file '%s': %s", path, err.Error())
+	}
+	config := make(map[string]string, len(rawConfig))
+	for key, rawValue := range rawConfig {
+		expectedType, ok := schema[key]
+		if !ok {
+			return nil, errorutils.CheckErrorf("the plugin config file '%s' contains the key '%s', which is not configurable. Configurable keys: %s", path, key, strings.Join(getSortedSchemaKeys(schema), ", "))
+		}
+		value, err := convertPluginConfigValue(rawValue, expectedType)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("the plugin config file '%s' contains an invalid value for the key '%s': %s", path, key, err.Error())
+		}
+		config[key] = value
+	}
+	return config, nil
+}
+
+func convertPluginConfigValue(rawValue interface{}, expectedType PluginConfigValueType) (string, error) {
+	switch expectedType {
+	case PluginConfigString:
+		if value, ok := rawValue.(string); ok {
+			return value, nil
+		}
+	case PluginConfigBool:
+		if value, ok := rawValue.(bool); ok {
+			return strconv.FormatBool(value), nil
+		}
+	case PluginConfigInt:
+		if value, ok := rawValue.(int); ok {
+			return strconv.Itoa(value), nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported type '%s' in the schema", expectedType)
+	}
+	return "", fmt.Errorf("expected a value of type %s but got '%v'", expectedType, rawValue)
+}
+
+func getSortedSchemaKeys(schema PluginConfigSchema) []string {
+	keys := make([]string, 0, len(schema))
+	for key := range schema {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Merge the values of the plugin's config file into the context's flags.
+// The precedence order is: command line flag > environment variable > config file > flag default value.
+func ApplyPluginConfig(c *components.Context, pluginName string, schema PluginConfigSchema) error {
+	path, err := GetPluginConfigFilePath(pluginName)
+	if err != nil {
+		return err
+	}
+	return applyPluginConfigFile(c, path, schema)
+}
+
+func applyPluginConfigFile(c *components.Context, path string, schema PluginConfigSchema) error {
+	config, err := loadPluginConfigFile(path, schema)
+	if err != nil {
+		return err
+	}
+	for key, value := range config {
+		if c.IsFlagExplicitlySet(key) {
+			log.Debug(fmt.Sprintf("The '--%s' option was provided in the command line or as an environment variable. Ignoring its value in the plugin config file '%s'.", key, path))
+			continue
+		}
+		if schema[key] == PluginConfigBool {
+			// The value was already validated while loading the config file.
+			boolValue, _ := strconv.ParseBool(value)
+			c.AddBoolFlag(key, boolValue)
+			continue
+		}
+		c.AddStringFlag(key, value)
+	}
+	return nil
+}
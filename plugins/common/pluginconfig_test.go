@@ -0,0 +1,103 @@
+package common
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+var testPluginConfigSchema = PluginConfigSchema{
+	"url":     PluginConfigString,
+	"threads": PluginConfigInt,
+	"dry-run": PluginConfigBool,
+}
+
+func TestLoadPluginConfigFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expected      map[string]string
+		expectedError string
+	}{
+		{"empty", "", map[string]string{}, ""},
+		{"all types", "url: http://localhost\nthreads: 5\ndry-run: true\n", map[string]string{"url": "http://localhost", "threads": "5", "dry-run": "true"}, ""},
+		{"unknown key", "recursive: true\n", nil, "contains the key 'recursive', which is not configurable. Configurable keys: dry-run, threads, url"},
+		{"invalid int", "threads: five\n", nil, "invalid value for the key 'threads': expected a value of type int but got 'five'"},
+		{"invalid bool", "dry-run: 1\n", nil, "invalid value for the key 'dry-run': expected a value of type bool but got '1'"},
+		{"malformed", "url: [", nil, "failed to parse the plugin config file"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), pluginConfigFileName)
+			assert.NoError(t, os.WriteFile(path, []byte(test.content), 0600))
+			config, err := loadPluginConfigFile(path, testPluginConfigSchema)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, config)
+		})
+	}
+}
+
+func TestLoadPluginConfigNotExist(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	config, err := LoadPluginConfig("my-plugin", testPluginConfigSchema)
+	assert.NoError(t, err)
+	assert.Empty(t, config)
+}
+
+func TestApplyPluginConfig(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, homeDir)
+	t.Setenv("JFROG_CLI_TEST_PLUGIN_THREADS", "7")
+	configDir := filepath.Join(homeDir, coreutils.JfrogPluginsDirName, "my-plugin")
+	assert.NoError(t, os.MkdirAll(configDir, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(configDir, pluginConfigFileName), []byte("url: http://file\nthreads: 5\ndry-run: true\n"), 0600))
+
+	flags := []components.Flag{
+		components.NewStringFlag("url", "", components.WithStrDefaultValue("http://default")),
+		components.NewStringFlag("threads", "", components.WithStrEnvVars("JFROG_CLI_TEST_PLUGIN_THREADS")),
+		components.NewBoolFlag("dry-run", ""),
+	}
+	tests := []struct {
+		name            string
+		args            []string
+		expectedUrl     string
+		expectedThreads string
+		expectedDryRun  bool
+	}{
+		{"file overrides default", []string{}, "http://file", "7", true},
+		{"flags override file", []string{"--url=http://cli", "--threads=3", "--dry-run=false"}, "http://cli", "3", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cliFlags := []cli.Flag{
+				cli.StringFlag{Name: "url"},
+				cli.StringFlag{Name: "threads", EnvVar: "JFROG_CLI_TEST_PLUGIN_THREADS"},
+				cli.BoolFlag{Name: "dry-run"},
+			}
+			flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+			for _, f := range cliFlags {
+				f.Apply(flagSet)
+			}
+			assert.NoError(t, flagSet.Parse(test.args))
+			baseContext := cli.NewContext(nil, flagSet, nil)
+			baseContext.Command = cli.Command{Name: "test", Flags: cliFlags}
+			c, err := components.ConvertContext(baseContext, flags...)
+			assert.NoError(t, err)
+
+			assert.NoError(t, ApplyPluginConfig(c, "my-plugin", testPluginConfigSchema))
+			assert.Equal(t, test.expectedUrl, c.GetStringFlagValue("url"))
+			assert.Equal(t, test.expectedThreads, c.GetStringFlagValue("threads"))
+			assert.Equal(t, test.expectedDryRun, c.GetBoolFlagValue("dry-run"))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+	"golang.org/x/term"
+)
+
+// Returned by the prompt functions when a value is required, but the user can't be prompted for it.
+var ErrNonInteractive = errors.New("can't prompt for input when running in quiet mode, in CI or without a terminal")
+
+// Overridden in tests.
+var isStdinTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Returns true if the user can be prompted for input.
+// Prompts are disabled if the '--quiet' option or the CI environment variable is set, or if stdin isn't a terminal.
+func IsInteractive(c *components.Context) bool {
+	return !GetQuietValue(c) && isStdinTerminal()
+}
+
+// Ask the user a yes or no question.
+// In quiet mode the question is considered approved, and when stdin isn't a terminal the default answer is returned.
+func PromptConfirm(c *components.Context, message string, defaultValue bool) bool {
+	if GetQuietValue(c) {
+		return true
+	}
+	if !isStdinTerminal() {
+		return defaultValue
+	}
+	return coreutils.AskYesNo(message, defaultValue)
+}
+
+// Ask the user to select one of the options.
+// When running non-interactively, the default value is returned, or ErrNonInteractive if there's no default value.
+func PromptSelect(c *components.Context, message string, options []string, defaultValue string) (selected string, err error) {
+	if !IsInteractive(c) {
+		if defaultValue == "" {
+			return "", errorutils.CheckError(fmt.Errorf("%s: %w", message, ErrNonInteractive))
+		}
+		return defaultValue, nil
+	}
+	items := make([]ioutils.PromptItem, 0, len(options))
+	for _, option := range options {
+		item := ioutils.PromptItem{Option: option}
+		if option == defaultValue {
+			item.DefaultValue = "default"
+		}
+		items = append(items, item)
+	}
+	err = ioutils.SelectString(items, message, false, func(item ioutils.PromptItem) {
+		selected = item.Option
+	})
+	return
+}
+
+// Ask the user to select any number of the options, by entering their numbers or names separated by commas.
+// An empty answer selects the default values.
+// When running non-interactively, the default values are returned, or ErrNonInteractive if there are no default values.
+func PromptMultiSelect(c *components.Context, message string, options []string, defaultValues []string) ([]string, error) {
+	if !IsInteractive(c) {
+		if len(defaultValues) == 0 {
+			return nil, errorutils.CheckError(fmt.Errorf("%s: %w", message, ErrNonInteractive))
+		}
+		return defaultValues, nil
+	}
+	log.Output(message)
+	for i, option := range options {
+		log.Output(fmt.Sprintf("  %d) %s", i+1, option))
+	}
+	for {
+		var answer string
+		ioutils.ScanFromConsole("Enter your selection, separated by commas", &answer, strings.Join(defaultValues, ","))
+		selected, err := parseMultiSelectAnswer(answer, options)
+		if err == nil {
+			return selected, nil
+		}
+		log.Output(err.Error())
+	}
+}
+
+func parseMultiSelectAnswer(answer string, options []string) ([]string, error) {
+	var selected []string
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if index, err := strconv.Atoi(part); err == nil && index >= 1 && index <= len(options) {
+			selected = append(selected, options[index-1])
+			continue
+		}
+		if !slices.Contains(options, part) {
+			return nil, fmt.Errorf("'%s' is not a valid option", part)
+		}
+		selected = append(selected, part)
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("at least one option should be selected")
+	}
+	return selected, nil
+}
+
+// Ask the user for a secret, such as a password or an access token, without echoing it.
+// When running non-interactively, ErrNonInteractive is returned.
+func PromptSecret(c *components.Context, message string) (string, error) {
+	if !IsInteractive(c) {
+		return "", errorutils.CheckError(fmt.Errorf("%s: %w", message, ErrNonInteractive))
+	}
+	return ioutils.ScanPasswordFromConsole(message + ": ")
+}
@@ -0,0 +1,135 @@
+package common
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setStdinTerminal(t *testing.T, isTerminal bool) {
+	original := isStdinTerminal
+	isStdinTerminal = func() bool { return isTerminal }
+	t.Cleanup(func() { isStdinTerminal = original })
+}
+
+func TestIsInteractive(t *testing.T) {
+	tests := []struct {
+		name       string
+		quiet      bool
+		ci         string
+		isTerminal bool
+		expected   bool
+	}{
+		{"terminal", false, "", true, true},
+		{"no terminal", false, "", false, false},
+		{"quiet", true, "", true, false},
+		{"ci", false, "true", true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(coreutils.CI, test.ci)
+			setStdinTerminal(t, test.isTerminal)
+			var args []string
+			if test.quiet {
+				args = append(args, "--quiet")
+			}
+			c := createBoolFlagsContext(t, args, "quiet")
+			assert.Equal(t, test.expected, IsInteractive(c))
+		})
+	}
+}
+
+func TestPromptsNonInteractive(t *testing.T) {
+	t.Setenv(coreutils.CI, "")
+	setStdinTerminal(t, false)
+	c := &components.Context{}
+
+	assert.True(t, PromptConfirm(c, "Continue", true))
+	assert.False(t, PromptConfirm(c, "Continue", false))
+	c = createBoolFlagsContext(t, []string{"--quiet"}, "quiet")
+	assert.True(t, PromptConfirm(c, "Continue", false))
+
+	selected, err := PromptSelect(c, "Select a repository", []string{"a", "b"}, "b")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", selected)
+	_, err = PromptSelect(c, "Select a repository", []string{"a", "b"}, "")
+	assert.ErrorIs(t, err, ErrNonInteractive)
+
+	multiSelected, err := PromptMultiSelect(c, "Select repositories", []string{"a", "b"}, []string{"a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, multiSelected)
+	_, err = PromptMultiSelect(c, "Select repositories", []string{"a", "b"}, nil)
+	assert.ErrorIs(t, err, ErrNonInteractive)
+
+	_, err = PromptSecret(c, "Access token")
+	assert.ErrorIs(t, err, ErrNonInteractive)
+}
+
+func TestGetFlagValueOrPrompt(t *testing.T) {
+	c := &components.Context{PrintCommandHelp: func(string) error { return nil }}
+	c.AddStringFlag("repo", "flag-repo")
+	setStdinTerminal(t, false)
+	value, err := GetFlagValueOrPrompt(c, "repo", "Repository")
+	assert.NoError(t, err)
+	assert.Equal(t, "flag-repo", value)
+
+	// Without a terminal, an error is returned instead of waiting for input.
+	c = &components.Context{PrintCommandHelp: func(string) error { return nil }}
+	_, err = GetFlagValueOrPrompt(c, "repo", "Repository")
+	assert.ErrorContains(t, err, "The '--repo' option is mandatory when not running interactively.")
+
+	setStdinTerminal(t, true)
+	setStdin(t, " prompt-repo \n")
+	value, err = GetFlagValueOrPrompt(c, "repo", "Repository")
+	assert.NoError(t, err)
+	assert.Equal(t, "prompt-repo", value)
+
+	setStdin(t, "\n")
+	_, err = GetFlagValueOrPrompt(c, "repo", "Repository")
+	assert.ErrorContains(t, err, "no value was provided for the '--repo' option")
+}
+
+// Replace stdin with the given input for the rest of the test.
+func setStdin(t *testing.T, input string) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = writer.WriteString(input)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	original := os.Stdin
+	os.Stdin = reader
+	t.Cleanup(func() {
+		os.Stdin = original
+		assert.NoError(t, reader.Close())
+	})
+}
+
+func TestParseMultiSelectAnswer(t *testing.T) {
+	options := []string{"npm", "maven", "go"}
+	tests := []struct {
+		answer    string
+		expected  []string
+		expectErr bool
+	}{
+		{"1,3", []string{"npm", "go"}, false},
+		{" maven , 1 ", []string{"maven", "npm"}, false},
+		{"4", nil, true},
+		{"pip", nil, true},
+		{" , ", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.answer, func(t *testing.T) {
+			selected, err := parseMultiSelectAnswer(test.answer, options)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, selected)
+		})
+	}
+}
@@ -18,9 +18,13 @@ func GetServerIdFlag() components.StringFlag {
 	return components.NewStringFlag("server-id", "Server ID configured using the config command.")
 }
 
-// Return the Artifactory Details of the provided 'server-id', or the default one.
+// Return the Artifactory Details of the provided 'server-id', the one pinned by the repo-local config, or the default one.
 func GetServerDetails(c *components.Context) (*config.ServerDetails, error) {
-	details, err := commands.GetConfig(c.GetStringFlagValue("server-id"), false)
+	serverId, err := GetServerIdWithLocalConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	details, err := commands.GetConfig(serverId, false)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +64,11 @@ func CreateServerDetailsFromFlags(c *components.Context) (details *config.Server
 	if details.ServerId == "" {
 		details.ServerId = os.Getenv(coreutils.ServerID)
 	}
+	if details.ServerId == "" {
+		if details.ServerId, err = getLocalServerId(); err != nil {
+			return
+		}
+	}
 	details.InsecureTls = c.GetBoolFlagValue("insecure-tls")
 	return
 }
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const cliFlagTag = "cliflag"
+
+// Override the fields of the struct pointed by `target` with the values of their matching flags, if set.
+// Fields are matched to flags using the 'cliflag' struct tag, for example:
+//
+//	type Config struct {
+//		MinSplit   int64    `cliflag:"min-split"`
+//		Exclusions []string `cliflag:"exclusions"`
+//	}
+//
+// This is equivalent to calling OverrideStringIfSet, OverrideIntIfSet, OverrideArrayIfSet etc. for each tagged field.
+// Supported field types are string, bool, []string and all signed integer types.
+func OverrideStructFromFlags(target interface{}, c *components.Context) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return errorutils.CheckErrorf("expected a non-nil pointer to a struct, but got %T", target)
+	}
+	structValue := targetValue.Elem()
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		flagName, ok := structType.Field(i).Tag.Lookup(cliFlagTag)
+		if !ok || flagName == "" || !c.IsFlagSet(flagName) {
+			continue
+		}
+		if err := setFieldFromFlag(structValue.Field(i), structType.Field(i), c, flagName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setFieldFromFlag(field reflect.Value, structField reflect.StructField, c *components.Context, flagName string) error {
+	if !field.CanSet() {
+		return errorutils.CheckErrorf("field '%s' tagged with flag '%s' is not exported", structField.Name, flagName)
+	}
+	value := c.GetStringFlagValue(flagName)
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		// Bool fields may be populated by either a bool flag or a string flag.
+		if value == "" {
+			field.SetBool(c.GetBoolFlagValue(flagName))
+			return nil
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return errorutils.CheckErrorf("can't parse bool flag '%s': %s", flagName, err.Error())
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 0, field.Type().Bits())
+		if err != nil {
+			return errorutils.CheckErrorf("can't parse int flag '%s': %s", flagName, err.Error())
+		}
+		field.SetInt(parsed)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return errorutils.CheckErrorf("field '%s' has an unsupported type %s", structField.Name, field.Type())
+		}
+		field.Set(reflect.ValueOf(strings.Split(value, ";")))
+	default:
+		return errorutils.CheckErrorf("field '%s' has an unsupported type %s", structField.Name, field.Type())
+	}
+	return nil
+}
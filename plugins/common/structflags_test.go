@@ -0,0 +1,59 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/stretchr/testify/assert"
+)
+
+type testFlagsStruct struct {
+	Pattern    string   `cliflag:"pattern"`
+	Threads    int      `cliflag:"threads"`
+	MinSplit   int64    `cliflag:"min-split"`
+	Recursive  bool     `cliflag:"recursive"`
+	Flat       bool     `cliflag:"flat"`
+	Exclusions []string `cliflag:"exclusions"`
+	Untagged   string
+	NotSet     string `cliflag:"not-set"`
+}
+
+func TestOverrideStructFromFlags(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("pattern", "repo/*")
+	c.AddStringFlag("threads", "5")
+	c.AddStringFlag("min-split", "10000000000")
+	c.AddStringFlag("recursive", "false")
+	c.AddBoolFlag("flat", true)
+	c.AddStringFlag("exclusions", "a;b")
+	c.AddStringFlag("Untagged", "value")
+
+	target := testFlagsStruct{Recursive: true, Untagged: "original", NotSet: "original"}
+	assert.NoError(t, OverrideStructFromFlags(&target, c))
+	assert.Equal(t, testFlagsStruct{
+		Pattern:    "repo/*",
+		Threads:    5,
+		MinSplit:   10000000000,
+		Recursive:  false,
+		Flat:       true,
+		Exclusions: []string{"a", "b"},
+		Untagged:   "original",
+		NotSet:     "original",
+	}, target)
+}
+
+func TestOverrideStructFromFlagsErrors(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("threads", "abc")
+	c.AddStringFlag("unsupported", "1.5")
+
+	// Not a pointer to a struct.
+	assert.Error(t, OverrideStructFromFlags(testFlagsStruct{}, c))
+	assert.Error(t, OverrideStructFromFlags((*testFlagsStruct)(nil), c))
+	// Parse error.
+	assert.Error(t, OverrideStructFromFlags(&testFlagsStruct{}, c))
+	// Unsupported field type.
+	assert.Error(t, OverrideStructFromFlags(&struct {
+		Unsupported float64 `cliflag:"unsupported"`
+	}{}, c))
+}
@@ -1,12 +1,28 @@
 package common
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
 	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/progressbar"
 	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"golang.org/x/exp/slices"
 )
@@ -18,22 +34,143 @@ func GetStringsArrFlagValue(c *components.Context, flagName string) (resultArray
 	return
 }
 
+// Same as GetStringsArrFlagValue, but returns an error if any of the elements is empty or contains only whitespaces.
+func GetNonEmptyStringsArrFlagValue(c *components.Context, flagName string) ([]string, error) {
+	resultArray := GetStringsArrFlagValue(c, flagName)
+	for i, element := range resultArray {
+		if strings.TrimSpace(element) == "" {
+			return nil, errorutils.CheckErrorf("the '--%s' option contains an empty value at position %d", flagName, i+1)
+		}
+	}
+	return resultArray, nil
+}
+
+// Same as GetStringsArrFlagValue, but elements may be quoted with single or double quotes to contain the `;` separator.
+// For example, `'a;b';c` is parsed to ["a;b", "c"]. The surrounding quotes are removed from the returned elements.
+func GetQuotedStringsArrFlagValue(c *components.Context, flagName string) (resultArray []string, err error) {
+	if !c.IsFlagSet(flagName) {
+		return
+	}
+	var current strings.Builder
+	var openQuote rune
+	for _, char := range c.GetStringFlagValue(flagName) {
+		switch {
+		case openQuote != 0 && char == openQuote:
+			openQuote = 0
+		case openQuote == 0 && (char == '\'' || char == '"'):
+			openQuote = char
+		case openQuote == 0 && char == ';':
+			resultArray = append(resultArray, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(char)
+		}
+	}
+	if openQuote != 0 {
+		return nil, errorutils.CheckErrorf("the '--%s' option value has an unbalanced %c quote", flagName, openQuote)
+	}
+	return append(resultArray, current.String()), nil
+}
+
+// Resolve an array from a default set of values, which the user can extend using `addFlag` and reduce using `removeFlag`.
+// The values of `addFlag` are appended to `defaults`, and then the values of `removeFlag` are removed, so removals take precedence.
+// The returned array contains no duplicates, and preserves the order of the values.
+func ResolveArrayFlag(c *components.Context, addFlag, removeFlag string, defaults []string) (resultArray []string) {
+	removed := GetStringsArrFlagValue(c, removeFlag)
+	for _, value := range append(slices.Clone(defaults), GetStringsArrFlagValue(c, addFlag)...) {
+		if !slices.Contains(removed, value) && !slices.Contains(resultArray, value) {
+			resultArray = append(resultArray, value)
+		}
+	}
+	return
+}
+
 // If `fieldName` exist in the cli args, read it to `field` as an array split by `;`.
+// Any existing values in `field` are replaced. To keep them, use AppendArrayIfSet.
 func OverrideArrayIfSet(field *[]string, c *components.Context, fieldName string) {
 	if c.IsFlagSet(fieldName) {
 		*field = append([]string{}, strings.Split(c.GetStringFlagValue(fieldName), ";")...)
 	}
 }
 
-// If `fieldName` exist in the cli args, read it to `field` as a int.
-func OverrideIntIfSet(field *int, c *components.Context, fieldName string) {
+// If `fieldName` exist in the cli args, split it by `;` and append the values to the existing values in `field`.
+// To replace the existing values instead, use OverrideArrayIfSet.
+func AppendArrayIfSet(field *[]string, c *components.Context, fieldName string) {
 	if c.IsFlagSet(fieldName) {
-		value, err := strconv.ParseInt(c.GetStringFlagValue(fieldName), 0, 64)
-		if err != nil {
-			return
+		*field = append(*field, strings.Split(c.GetStringFlagValue(fieldName), ";")...)
+	}
+}
+
+// If `fieldName` exist in the cli args, read it to `field` as a int.
+// Returns an error if the value isn't an integer.
+func OverrideIntIfSet(field *int, c *components.Context, fieldName string) error {
+	if !c.IsFlagSet(fieldName) {
+		return nil
+	}
+	value, err := c.GetIntFlagValue(fieldName)
+	if err != nil {
+		return err
+	}
+	*field = value
+	return nil
+}
+
+// Get the value of a flag that requires 64-bit parsing, such as byte offsets or large counts.
+// isSet is false if the flag wasn't provided.
+func GetInt64FlagValue(c *components.Context, flagName string) (value int64, isSet bool, err error) {
+	if !c.IsFlagSet(flagName) {
+		return
+	}
+	value, err = c.GetInt64FlagValue(flagName)
+	return value, true, err
+}
+
+// Get the value of a path flag, with a leading '~' expanded to the user's home directory and environment variables (such as $HOME) expanded.
+// The shell doesn't expand these when the value is joined to the flag name with '=', e.g. '--key-file=~/.ssh/id_rsa'.
+func GetExpandedPathFlagValue(c *components.Context, flagName string) (string, error) {
+	path := os.ExpandEnv(c.GetStringFlagValue(flagName))
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to expand '~' in the '--%s' option value: %s", flagName, err.Error())
+	}
+	return filepath.Join(homeDir, path[1:]), nil
+}
+
+// Get the boolean value of a string flag, for flags which should distinguish between 'false' and 'unset'.
+// Accepts true/false, 1/0 and yes/no, case-insensitively. set is false if the flag wasn't provided.
+func GetTristateBoolFlagValue(c *components.Context, flagName string) (value bool, set bool, err error) {
+	if !c.IsFlagSet(flagName) {
+		return
+	}
+	set = true
+	switch strings.ToLower(strings.TrimSpace(c.GetStringFlagValue(flagName))) {
+	case "true", "1", "yes":
+		value = true
+	case "false", "0", "no":
+		value = false
+	default:
+		err = errorutils.CheckErrorf("the '--%s' option should have a boolean value (true/false, yes/no or 1/0), but got '%s'", flagName, c.GetStringFlagValue(flagName))
+	}
+	return
+}
+
+// Get the value of a string flag which may also be provided by one of its legacy aliases, such as '--Project' for '--project'.
+// The canonical flag is checked first, then each of the aliases in order. A deprecation warning is logged if an alias is used.
+// Note that the aliases must also be declared as flags of the command (possibly hidden), to be available in the context.
+func GetStringFlagValueAnyCase(c *components.Context, canonical string, aliases ...string) string {
+	if c.IsFlagSet(canonical) {
+		return c.GetStringFlagValue(canonical)
+	}
+	for _, alias := range aliases {
+		if c.IsFlagSet(alias) {
+			log.Warn(fmt.Sprintf("The '--%s' option is deprecated. Please use '--%s' instead.", alias, canonical))
+			return c.GetStringFlagValue(alias)
 		}
-		*field = int(value)
 	}
+	return ""
 }
 
 // If `fieldName` exist in the cli args, read it to `field` as a string.
@@ -48,16 +185,666 @@ func HandleSecretInput(c *components.Context, stringFlag, stdinFlag string) (sec
 	return cliutils.HandleSecretInput(stringFlag, c.GetStringFlagValue(stringFlag), stdinFlag, c.GetBoolFlagValue(stdinFlag))
 }
 
+// Get the value of a flag, or prompt the user for it if the flag isn't set.
+// Should be used for non-secret values only, since the input is echoed. For secrets, use HandleSecretInput.
+// If stdin isn't a terminal (e.g. in CI), an error is returned instead of waiting for input.
+func GetFlagValueOrPrompt(c *components.Context, flagName, promptMessage string) (string, error) {
+	if value := c.GetStringFlagValue(flagName); value != "" {
+		return value, nil
+	}
+	if !isStdinTerminal() {
+		return "", PrintHelpAndReturnError(fmt.Sprintf("The '--%s' option is mandatory when not running interactively.", flagName), c)
+	}
+	var value string
+	ioutils.ScanFromConsole(promptMessage, &value, "")
+	if value == "" {
+		return "", errorutils.CheckErrorf("no value was provided for the '--%s' option", flagName)
+	}
+	return value, nil
+}
+
+// If set, called once per run for each deprecated command syntax in use.
+// Allows embedders to collect usage analytics about deprecated commands.
+var DeprecationReporter func(cmdName, oldSubcommand string)
+
+// The deprecated command syntaxes used in this run, in the order they were first used.
+var (
+	usedDeprecations      [][2]string
+	usedDeprecationsMutex sync.Mutex
+)
+
+// Run the command, warning about its deprecated syntax and reporting it to the DeprecationReporter the first time it's used in this run.
 func RunCmdWithDeprecationWarning(cmdName, oldSubcommand string, c *components.Context,
 	cmd func(c *components.Context) error) error {
-	cliutils.LogNonNativeCommandDeprecation(cmdName, oldSubcommand)
+	if markDeprecationUsed(cmdName, oldSubcommand) {
+		cliutils.LogNonNativeCommandDeprecation(cmdName, oldSubcommand)
+		if DeprecationReporter != nil {
+			DeprecationReporter(cmdName, oldSubcommand)
+		}
+	}
 	return cmd(c)
 }
 
+// Returns true if the deprecated syntax wasn't used before in this run.
+func markDeprecationUsed(cmdName, oldSubcommand string) bool {
+	usedDeprecationsMutex.Lock()
+	defer usedDeprecationsMutex.Unlock()
+	deprecation := [2]string{cmdName, oldSubcommand}
+	if slices.Contains(usedDeprecations, deprecation) {
+		return false
+	}
+	usedDeprecations = append(usedDeprecations, deprecation)
+	return true
+}
+
+// Returns a summary of the deprecated command syntaxes used in this run, or an empty string if none was used.
+// Embedders may print it at the end of the run, next to their own summaries.
+func GetDeprecationsSummary() string {
+	usedDeprecationsMutex.Lock()
+	defer usedDeprecationsMutex.Unlock()
+	if len(usedDeprecations) == 0 {
+		return ""
+	}
+	executable := coreutils.GetCliExecutableName()
+	summary := "Deprecated command syntaxes used in this run:"
+	for _, deprecation := range usedDeprecations {
+		summary += fmt.Sprintf("\n\t$ %s %s %s (use: $ %s %s)", executable, deprecation[1], deprecation[0], executable, deprecation[0])
+	}
+	return summary
+}
+
 func GetThreadsCount(c *components.Context) (threads int, err error) {
 	return cliutils.GetThreadsCount(c.GetStringFlagValue("threads"))
 }
 
+// Same as GetThreadsCount, but explicitly guarantees a threads count of at least 1, so that commands never stall with no threads.
+func GetThreadsCountAtLeastOne(c *components.Context) (int, error) {
+	threads, err := GetThreadsCount(c)
+	if err != nil {
+		return 0, err
+	}
+	if threads < 1 {
+		return 0, errorutils.CheckErrorf("the '--threads' option should have a numeric value of at least 1. %s", cliutils.GetCLIDocumentationMessage())
+	}
+	return threads, nil
+}
+
+// Same as GetThreadsCount, but also accepts a percentage of the available CPUs, such as '--threads=50%'.
+func GetThreadsCountWithScaling(c *components.Context) (threads int, err error) {
+	return cliutils.GetThreadsCountWithScaling(c.GetStringFlagValue("threads"))
+}
+
+// Get the project key from the 'project' flag, or from the JFROG_CLI_BUILD_PROJECT environment variable if the flag is not set.
+func GetProject(c *components.Context) string {
+	if projectKey := c.GetStringFlagValue("project"); projectKey != "" {
+		return projectKey
+	}
+	return os.Getenv(coreutils.Project)
+}
+
+// Returns a short description of the value of a flag and where it was resolved from, for debugging purposes.
+// For example: 'project=proj (source: env JFROG_CLI_BUILD_PROJECT)'.
+// The flag is checked first, then the environment variable (if envKey isn't empty).
+// Note that a flag's declared default value is reported as a flag value, since it's resolved before the command runs.
+// Values of sensitive flags are redacted.
+func DescribeFlagResolution(c *components.Context, flagName, envKey string) string {
+	value, source := "", "unset"
+	if c.IsFlagSet(flagName) {
+		value, source = c.GetStringFlagValue(flagName), "flag"
+	} else if envValue := os.Getenv(envKey); envKey != "" && envValue != "" {
+		value, source = envValue, "env "+envKey
+	}
+	return fmt.Sprintf("%s=%s (source: %s)", flagName, RedactFlagValue(flagName, value), source)
+}
+
+var sensitiveFlagNameParts = []string{"password", "token", "passphrase", "secret"}
+
+// Returns a masked value for flags which may hold secrets, such as '--password' or '--access-token'.
+func RedactFlagValue(flagName, value string) string {
+	if value == "" {
+		return value
+	}
+	lowerFlagName := strings.ToLower(flagName)
+	for _, part := range sensitiveFlagNameParts {
+		if strings.Contains(lowerFlagName, part) {
+			return "***"
+		}
+	}
+	return value
+}
+
+// Same as GetProject, but if neither the flag nor the environment variable is set, the project key is read from
+// the repo-local '.jfrog/config' file, or from a '.jfrog/project' file, in the current directory or in one of its parent directories.
+func GetProjectWithLocalConfig(c *components.Context) (string, error) {
+	if projectKey := GetProject(c); projectKey != "" {
+		return projectKey, nil
+	}
+	localConfig, err := config.GetLocalConfig()
+	if err != nil {
+		return "", err
+	}
+	if localConfig != nil && localConfig.Project != "" {
+		return localConfig.Project, nil
+	}
+	projectFile := filepath.Join(".jfrog", "project")
+	projectDir, exists, err := fileutils.FindUpstream(projectFile, fileutils.File)
+	if err != nil || !exists {
+		return "", err
+	}
+	content, err := os.ReadFile(filepath.Join(projectDir, projectFile))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// Returns the value of the repository flag, or if it's not set, the default repository of the package type or purpose
+// in the repo-local '.jfrog/config' file.
+func GetRepositoryWithLocalConfig(c *components.Context, flagName, repositoryName string) (string, error) {
+	if repository := c.GetStringFlagValue(flagName); repository != "" {
+		return repository, nil
+	}
+	localConfig, err := config.GetLocalConfig()
+	if err != nil {
+		return "", err
+	}
+	return localConfig.GetRepository(repositoryName), nil
+}
+
+// Returns the value of the 'server-id' flag, or if it's not set, the server ID pinned by the repo-local '.jfrog/config' file.
+func GetServerIdWithLocalConfig(c *components.Context) (string, error) {
+	if serverId := c.GetStringFlagValue("server-id"); serverId != "" {
+		return serverId, nil
+	}
+	return getLocalServerId()
+}
+
+// Returns the server ID pinned by the repo-local '.jfrog/config' file, or an empty string if there's none.
+func getLocalServerId() (string, error) {
+	localConfig, err := config.GetLocalConfig()
+	if err != nil || localConfig == nil || localConfig.ServerId == "" {
+		return "", err
+	}
+	log.Info(fmt.Sprintf("Using the server ID '%s' pinned by %s", localConfig.ServerId, localConfig.Path))
+	return localConfig.ServerId, nil
+}
+
+// Same as GetProject, but returns an error if no project is configured.
+// Should be used by commands that cannot run without a project.
+func GetProjectOrFail(c *components.Context) (string, error) {
+	projectKey := GetProject(c)
+	if projectKey == "" {
+		return "", PrintHelpAndReturnError(fmt.Sprintf("No project was provided. Use the --project option or set the %s environment variable.", coreutils.Project), c)
+	}
+	return projectKey, nil
+}
+
+// Get a boolean value from the provided flag if explicitly set, otherwise from the provided environment variable if set, otherwise return the default value.
+// Bool flags always hold a value after the conversion of the context, so the flag's default value doesn't override the environment variable.
+func GetBoolEnvOrFlag(c *components.Context, flagName, envKey string, def bool) bool {
+	if c.IsFlagExplicitlySet(flagName) {
+		return c.GetBoolFlagValue(flagName)
+	}
+	value, err := clientutils.GetBoolEnvValue(envKey, def)
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// Commands are quiet if the 'quiet' flag is set, or if running in CI when the flag isn't set.
+func GetQuietValue(c *components.Context) bool {
+	quiet, _ := GetQuietValueWithSource(c)
+	return quiet
+}
+
+// Same as GetQuietValue, but also returns whether the decision was explicitly requested using the 'quiet' flag,
+// rather than derived from the CI environment variable.
+func GetQuietValueWithSource(c *components.Context) (quiet bool, fromFlag bool) {
+	return GetBoolEnvOrFlag(c, "quiet", coreutils.CI, false), c.IsFlagExplicitlySet("quiet")
+}
+
+// Determine whether a progress bar should be shown.
+// The progress bar is hidden if the command is quiet (explicitly or when running in CI), or if stdout isn't a terminal (e.g. redirected output).
+// Otherwise, the optional 'progress' flag is honored, defaulting to true.
+func ShouldShowProgress(c *components.Context) bool {
+	if GetQuietValue(c) || !log.IsStdOutTerminal() {
+		return false
+	}
+	if c.IsFlagExplicitlySet("progress") {
+		return c.GetBoolFlagValue("progress")
+	}
+	return true
+}
+
+// Get the value of the '--progress-format' flag. Defaults to 'bar' if the flag isn't set.
+// Unlike the progress bar, the 'json' progress events are reported in quiet mode and without a terminal too,
+// so they should be handled before ShouldShowProgress.
+func GetProgressFormat(c *components.Context) (string, error) {
+	progressFormat := strings.ToLower(c.GetStringFlagValue("progress-format"))
+	if progressFormat == "" {
+		return progressbar.ProgressFormatBar, nil
+	}
+	allowedFormats := []string{progressbar.ProgressFormatBar, progressbar.ProgressFormatJson}
+	if !slices.Contains(allowedFormats, progressFormat) {
+		return "", errorutils.CheckErrorf("the '--progress-format' option value '%s' is invalid. Allowed values: %s", progressFormat, strings.Join(allowedFormats, ", "))
+	}
+	return progressFormat, nil
+}
+
+// Execute the command with the progress requested by the '--progress-format' and '--progress-output' flags.
+// The 'json' progress events are always reported, while the progress bar is shown only if ShouldShowProgress allows it.
+func ExecWithProgress(c *components.Context, cmd progressbar.CommandWithProgress) error {
+	progressFormat, err := GetProgressFormat(c)
+	if err != nil {
+		return err
+	}
+	if progressFormat == progressbar.ProgressFormatBar && !ShouldShowProgress(c) {
+		return commands.Exec(cmd)
+	}
+	return progressbar.ExecWithProgressFormat(cmd, progressFormat, c.GetStringFlagValue("progress-output"))
+}
+
+// Get the properties delta of the '--set-props' and '--delete-props' flags.
+func GetPropsDelta(c *components.Context) (artifactoryUtils.PropsDelta, error) {
+	delta := artifactoryUtils.PropsDelta{Set: c.GetStringFlagValue("set-props"), Delete: c.GetStringFlagValue("delete-props")}
+	return delta, delta.Validate()
+}
+
+// Get the value of the '--format' flag of the search command. Defaults to 'json' if the flag isn't set.
+func GetSearchOutputFormat(c *components.Context) (artifactoryUtils.SearchOutputFormat, error) {
+	format := strings.ToLower(c.GetStringFlagValue("format"))
+	if format == "" {
+		return artifactoryUtils.SearchFormatJson, nil
+	}
+	allowedFormats := artifactoryUtils.GetSearchOutputFormats()
+	if !slices.Contains(allowedFormats, format) {
+		return "", errorutils.CheckErrorf("the '--format' option value '%s' is invalid. Allowed values: %s", format, strings.Join(allowedFormats, ", "))
+	}
+	return artifactoryUtils.SearchOutputFormat(format), nil
+}
+
+// Get the comma separated columns of the '--columns' flag of the search command.
+func GetSearchResultColumns(c *components.Context) ([]string, error) {
+	var columns []string
+	for _, column := range strings.Split(c.GetStringFlagValue("columns"), ",") {
+		if column = strings.ToLower(strings.TrimSpace(column)); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns, artifactoryUtils.ValidateSearchResultColumns(columns)
+}
+
+// Get the targets of a build promotion, from the spec file of the '--targets-spec' option if set,
+// or else from the semicolon separated target repositories, promoted from the repository of the '--source-repo' option.
+func GetPromotionTargets(c *components.Context, targetRepos string) ([]artifactoryUtils.PromotionTarget, error) {
+	if c.IsFlagSet("targets-spec") {
+		return artifactoryUtils.LoadPromotionTargets(c.GetStringFlagValue("targets-spec"))
+	}
+	targets := artifactoryUtils.ParsePromotionTargets(targetRepos, c.GetStringFlagValue("source-repo"))
+	return targets, artifactoryUtils.ValidatePromotionTargets(targets)
+}
+
+// The download split defaults used by CreateDownloadConfiguration.
+var (
+	downloadMinSplitKb    int64 = cliutils.DownloadMinSplitKb
+	downloadSplitCount          = cliutils.DownloadSplitCount
+	downloadMaxSplitCount       = cliutils.DownloadMaxSplitCount
+)
+
+// Override the download split defaults used by CreateDownloadConfiguration.
+// Allows embedders to tune the defaults, and should be called at init time.
+func SetDownloadDefaults(minSplitKb int64, splitCount, maxSplitCount int) error {
+	if minSplitKb < 0 || splitCount < 0 {
+		return errorutils.CheckErrorf("download defaults cannot be negative (min split: %d KB, split count: %d)", minSplitKb, splitCount)
+	}
+	if maxSplitCount < splitCount {
+		return errorutils.CheckErrorf("the max split count (%d) cannot be lower than the default split count (%d)", maxSplitCount, splitCount)
+	}
+	downloadMinSplitKb, downloadSplitCount, downloadMaxSplitCount = minSplitKb, splitCount, maxSplitCount
+	return nil
+}
+
+func CreateDownloadConfiguration(c *components.Context) (downloadConfiguration *artifactoryUtils.DownloadConfiguration, err error) {
+	downloadConfiguration = new(artifactoryUtils.DownloadConfiguration)
+	downloadConfiguration.MinSplitSize, err = getMinSplit(c, "min-split", downloadMinSplitKb)
+	if err != nil {
+		return nil, err
+	}
+	downloadConfiguration.SplitCount, err = getSplitCount(c, "split-count", downloadSplitCount, downloadMaxSplitCount)
+	if err != nil {
+		return nil, err
+	}
+	downloadConfiguration.Threads, err = GetThreadsCount(c)
+	if err != nil {
+		return nil, err
+	}
+	downloadConfiguration.Threads = applyMaxThreadsLimit(downloadConfiguration.Threads)
+	if downloadConfiguration.SplitCount > downloadConfiguration.Threads {
+		log.Warn(fmt.Sprintf("The '--split-count' value (%d) is greater than the '--threads' value (%d). "+
+			"Since no more than %d parts can be downloaded in parallel, consider aligning these values.",
+			downloadConfiguration.SplitCount, downloadConfiguration.Threads, downloadConfiguration.Threads))
+	}
+	downloadConfiguration.SkipChecksum, err = getSkipChecksum(c)
+	if err != nil {
+		return nil, err
+	}
+	downloadConfiguration.ChecksumAlgorithm, err = GetChecksumAlgorithm(c)
+	if err != nil {
+		return nil, err
+	}
+	downloadConfiguration.Symlink = true
+	downloadConfiguration.DryRun = GetDryRun(c)
+	downloadConfiguration.ToStdout, err = isDownloadToStdout(c)
+	if err != nil {
+		return nil, err
+	}
+	downloadConfiguration.RateLimit, err = getRateLimit(c)
+	if err != nil {
+		return nil, err
+	}
+	downloadConfiguration.RetryPolicy, err = GetRetryPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	downloadConfiguration.OnError, err = GetOnErrorPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Returns true if the '--output' option is '-', which streams the downloaded file to stdout.
+func isDownloadToStdout(c *components.Context) (bool, error) {
+	output := c.GetStringFlagValue("output")
+	if output == "" {
+		return false, nil
+	}
+	if output != "-" {
+		return false, errors.New("The '--output' option only supports the '-' value, which streams the downloaded file to stdout. " + cliutils.GetCLIDocumentationMessage())
+	}
+	return true, nil
+}
+
+func CreateUploadConfiguration(c *components.Context) (uploadConfiguration *artifactoryUtils.UploadConfiguration, err error) {
+	uploadConfiguration = new(artifactoryUtils.UploadConfiguration)
+	uploadConfiguration.Threads, err = GetThreadsCount(c)
+	if err != nil {
+		return nil, err
+	}
+	uploadConfiguration.Threads = applyMaxThreadsLimit(uploadConfiguration.Threads)
+	uploadConfiguration.MinChecksumDeploySize, err = artifactoryUtils.GetMinChecksumDeploySize()
+	if err != nil {
+		return nil, err
+	}
+	// Files larger than the min split size are uploaded in parallel chunks, using multipart upload.
+	// Each chunk is retried separately according to the '--retries' value, so a failed chunk doesn't restart the whole upload.
+	uploadConfiguration.MinSplitSizeMB, err = getMinSplit(c, "upload-min-split", cliutils.UploadMinSplitMb)
+	if err != nil {
+		return nil, err
+	}
+	uploadConfiguration.SplitCount, err = getSplitCount(c, "upload-split-count", cliutils.UploadSplitCount, cliutils.UploadMaxSplitCount)
+	if err != nil {
+		return nil, err
+	}
+	uploadConfiguration.ChunkSizeMB, err = getUploadChunkSize(c, cliutils.UploadChunkSizeMb)
+	if err != nil {
+		return nil, err
+	}
+	uploadConfiguration.ExplodeArchive = c.GetBoolFlagValue("explode")
+	uploadConfiguration.ArchiveStream, err = getArchiveStreamFormat(c)
+	if err != nil {
+		return nil, err
+	}
+	uploadConfiguration.DryRun = GetDryRun(c)
+	uploadConfiguration.RateLimit, err = getRateLimit(c)
+	if err != nil {
+		return nil, err
+	}
+	uploadConfiguration.RetryPolicy, err = GetRetryPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	uploadConfiguration.OnError, err = GetOnErrorPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func CreateDeleteConfiguration(c *components.Context) (deleteConfiguration *artifactoryUtils.DeleteConfiguration, err error) {
+	deleteConfiguration = new(artifactoryUtils.DeleteConfiguration)
+	deleteConfiguration.Threads, err = GetThreadsCount(c)
+	if err != nil {
+		return nil, err
+	}
+	deleteConfiguration.Threads = applyMaxThreadsLimit(deleteConfiguration.Threads)
+	deleteConfiguration.DryRun = GetDryRun(c)
+	return
+}
+
+func CreateMoveCopyConfiguration(c *components.Context) (moveCopyConfiguration *artifactoryUtils.MoveCopyConfiguration, err error) {
+	moveCopyConfiguration = new(artifactoryUtils.MoveCopyConfiguration)
+	moveCopyConfiguration.Threads, err = GetThreadsCount(c)
+	if err != nil {
+		return nil, err
+	}
+	moveCopyConfiguration.Threads = applyMaxThreadsLimit(moveCopyConfiguration.Threads)
+	moveCopyConfiguration.DryRun = GetDryRun(c)
+	return
+}
+
+// Returns true if the '--dry-run' option is set.
+// Commands running in dry-run mode log the operations which would have been executed, without changing anything on the server.
+func GetDryRun(c *components.Context) bool {
+	return c.GetBoolFlagValue("dry-run")
+}
+
+// Lower the threads count to the limit set by the JFROG_CLI_MAX_THREADS environment variable, if needed.
+// The threads count is never raised, and is left as is if the environment variable is unset or invalid.
+func applyMaxThreadsLimit(threads int) int {
+	maxThreadsStr := os.Getenv(cliutils.JfrogCliMaxThreads)
+	if maxThreadsStr == "" {
+		return threads
+	}
+	maxThreads, err := strconv.Atoi(maxThreadsStr)
+	if err != nil || maxThreads < 1 {
+		log.Debug(fmt.Sprintf("Ignoring the invalid value '%s' of the %s environment variable.", maxThreadsStr, cliutils.JfrogCliMaxThreads))
+		return threads
+	}
+	if threads > maxThreads {
+		log.Info(fmt.Sprintf("The requested threads count (%d) is limited to %d by the %s environment variable.", threads, maxThreads, cliutils.JfrogCliMaxThreads))
+		return maxThreads
+	}
+	return threads
+}
+
+// Get the '--min-split' value in KB, with the same defaults and validations used by CreateDownloadConfiguration.
+func GetMinSplit(c *components.Context) (int64, error) {
+	return getMinSplit(c, "min-split", downloadMinSplitKb)
+}
+
+// Get the '--split-count' value, with the same defaults and validations used by CreateDownloadConfiguration.
+func GetSplitCount(c *components.Context) (int, error) {
+	return getSplitCount(c, "split-count", downloadSplitCount, downloadMaxSplitCount)
+}
+
+// Returns the value of the '--rate-limit' option in bytes per second, or 0 if the option isn't set.
+func getRateLimit(c *components.Context) (int64, error) {
+	rateLimit, err := artifactoryUtils.ParseRateLimit(c.GetStringFlagValue("rate-limit"))
+	if err != nil {
+		return 0, errors.New("The '--rate-limit' option should have a size value, such as 10MB or 500KB. " + cliutils.GetCLIDocumentationMessage())
+	}
+	return rateLimit, nil
+}
+
+// Get the retry policy from the '--retries', '--retries-wait-time', '--retry-backoff-factor' and '--retry-jitter' options.
+// Returns nil if none of these options is set, so that the command's own retries configuration is used.
+func GetRetryPolicy(c *components.Context) (*artifactoryUtils.RetryPolicy, error) {
+	isSet := func(flagName string) bool { return c.GetStringFlagValue(flagName) != "" }
+	if !slices.ContainsFunc([]string{"retries", "retries-wait-time", "retry-backoff-factor", "retry-jitter"}, isSet) {
+		return nil, nil
+	}
+	retryPolicy := &artifactoryUtils.RetryPolicy{Retries: cliutils.Retries, BackoffFactor: 1}
+	var err error
+	if c.GetStringFlagValue("retries") != "" {
+		retryPolicy.Retries, err = strconv.Atoi(c.GetStringFlagValue("retries"))
+		if err != nil || retryPolicy.Retries < 0 {
+			return nil, errors.New("The '--retries' option should have a non-negative numeric value. " + cliutils.GetCLIDocumentationMessage())
+		}
+	}
+	if c.GetStringFlagValue("retries-wait-time") != "" {
+		retryPolicy.WaitTime, err = time.ParseDuration(c.GetStringFlagValue("retries-wait-time"))
+		if err != nil || retryPolicy.WaitTime < 0 {
+			return nil, errors.New("The '--retries-wait-time' option should have a duration value, such as 500ms or 10s. " + cliutils.GetCLIDocumentationMessage())
+		}
+	}
+	if c.GetStringFlagValue("retry-backoff-factor") != "" {
+		retryPolicy.BackoffFactor, err = strconv.ParseFloat(c.GetStringFlagValue("retry-backoff-factor"), 64)
+		if err != nil || retryPolicy.BackoffFactor < 1 {
+			return nil, errors.New("The '--retry-backoff-factor' option should have a numeric value of at least 1. " + cliutils.GetCLIDocumentationMessage())
+		}
+	}
+	if c.GetStringFlagValue("retry-jitter") != "" {
+		retryPolicy.Jitter, err = strconv.ParseFloat(c.GetStringFlagValue("retry-jitter"), 64)
+		if err != nil || retryPolicy.Jitter < 0 || retryPolicy.Jitter > 1 {
+			return nil, errors.New("The '--retry-jitter' option should have a numeric value between 0 and 1. " + cliutils.GetCLIDocumentationMessage())
+		}
+	}
+	return retryPolicy, nil
+}
+
+func getMinSplit(c *components.Context, flagName string, defaultMinSplit int64) (minSplitSize int64, err error) {
+	minSplitSize = defaultMinSplit
+	if c.GetStringFlagValue(flagName) != "" {
+		minSplitSize, err = strconv.ParseInt(c.GetStringFlagValue(flagName), 10, 64)
+		if err != nil {
+			err = errors.New("The '--" + flagName + "' option should have a numeric value. " + cliutils.GetCLIDocumentationMessage())
+			return 0, err
+		}
+	}
+	return minSplitSize, nil
+}
+
+func getSplitCount(c *components.Context, flagName string, defaultSplitCount, maxSplitCount int) (splitCount int, err error) {
+	splitCount = defaultSplitCount
+	if c.GetStringFlagValue(flagName) != "" {
+		splitCount, err = strconv.Atoi(c.GetStringFlagValue(flagName))
+		if err != nil {
+			return 0, errors.New("The '--" + flagName + "' option should have a numeric value. " + cliutils.GetCLIDocumentationMessage())
+		}
+		if splitCount > maxSplitCount {
+			return 0, errors.New("The '--" + flagName + "' option value is limited to a maximum of " + strconv.Itoa(maxSplitCount) + ".")
+		}
+		if splitCount < 0 {
+			return 0, errors.New("the '--" + flagName + "' option cannot have a negative value")
+		}
+	}
+	return
+}
+
+// The chunk size of multipart uploads, in MB.
+func getUploadChunkSize(c *components.Context, defaultChunkSize int64) (chunkSize int64, err error) {
+	chunkSize = defaultChunkSize
+	if c.GetStringFlagValue("chunk-size") != "" {
+		chunkSize, err = strconv.ParseInt(c.GetStringFlagValue("chunk-size"), 10, 64)
+		if err != nil {
+			return 0, errors.New("The '--chunk-size' option should have a numeric value. " + cliutils.GetCLIDocumentationMessage())
+		}
+		if chunkSize < cliutils.UploadMinChunkSizeMb || chunkSize > cliutils.UploadMaxChunkSizeMb {
+			return 0, errorutils.CheckErrorf("the '--chunk-size' option value should be between %d and %d", cliutils.UploadMinChunkSizeMb, cliutils.UploadMaxChunkSizeMb)
+		}
+	}
+	return
+}
+
+// Checksum validation may be enforced by the JFROG_CLI_FORCE_CHECKSUM environment variable,
+// regardless of the --skip-checksum and --checksum-mode options.
+func getSkipChecksum(c *components.Context) (bool, error) {
+	checksumMode, err := GetChecksumMode(c)
+	if err != nil {
+		return false, err
+	}
+	if !c.GetBoolFlagValue("skip-checksum") && checksumMode != ChecksumModeSkip {
+		return false, nil
+	}
+	forceChecksum, err := clientutils.GetBoolEnvValue(cliutils.JfrogCliForceChecksum, false)
+	if err != nil {
+		return false, err
+	}
+	if forceChecksum {
+		log.Info(fmt.Sprintf("Checksum validation cannot be skipped, since the %s environment variable is set.", cliutils.JfrogCliForceChecksum))
+		return false, nil
+	}
+	return true, nil
+}
+
+const (
+	ChecksumModeStrict = "strict"
+	ChecksumModeSkip   = "skip"
+	ChecksumModeWarn   = "warn"
+)
+
+// Get the value of the '--checksum-mode' flag. Defaults to 'strict' if the flag isn't set.
+func GetChecksumMode(c *components.Context) (string, error) {
+	checksumMode := c.GetStringFlagValue("checksum-mode")
+	if checksumMode == "" {
+		return ChecksumModeStrict, nil
+	}
+	allowedModes := []string{ChecksumModeStrict, ChecksumModeSkip, ChecksumModeWarn}
+	if !slices.Contains(allowedModes, checksumMode) {
+		return "", errorutils.CheckErrorf("the '--checksum-mode' option value '%s' is invalid. Allowed values: %s", checksumMode, strings.Join(allowedModes, ", "))
+	}
+	return checksumMode, nil
+}
+
+// Get the value of the '--archive-stream' flag, or an empty value if directories shouldn't be uploaded as archives.
+func getArchiveStreamFormat(c *components.Context) (artifactoryUtils.ArchiveStreamFormat, error) {
+	archiveStream := strings.ToLower(c.GetStringFlagValue("archive-stream"))
+	if archiveStream == "" {
+		return "", nil
+	}
+	allowedFormats := artifactoryUtils.GetArchiveStreamFormats()
+	if !slices.Contains(allowedFormats, archiveStream) {
+		return "", errorutils.CheckErrorf("the '--archive-stream' option value '%s' is invalid. Allowed values: %s", archiveStream, strings.Join(allowedFormats, ", "))
+	}
+	if c.GetBoolFlagValue("explode") {
+		return "", errorutils.CheckErrorf("the '--archive-stream' and '--explode' options cannot be used together")
+	}
+	return artifactoryUtils.ArchiveStreamFormat(archiveStream), nil
+}
+
+// Get the value of the '--on-error' flag. Defaults to 'continue' if the flag isn't set.
+func GetOnErrorPolicy(c *components.Context) (artifactoryUtils.OnErrorPolicy, error) {
+	onError := strings.ToLower(c.GetStringFlagValue("on-error"))
+	if onError == "" {
+		return artifactoryUtils.OnErrorContinue, nil
+	}
+	allowedPolicies := artifactoryUtils.GetOnErrorPolicies()
+	if !slices.Contains(allowedPolicies, onError) {
+		return "", errorutils.CheckErrorf("the '--on-error' option value '%s' is invalid. Allowed values: %s", onError, strings.Join(allowedPolicies, ", "))
+	}
+	return artifactoryUtils.OnErrorPolicy(onError), nil
+}
+
+// Get the value of the '--checksum-algorithm' flag. Defaults to 'all' if the flag isn't set.
+func GetChecksumAlgorithm(c *components.Context) (artifactoryUtils.ChecksumAlgorithm, error) {
+	checksumAlgorithm := strings.ToLower(c.GetStringFlagValue("checksum-algorithm"))
+	if checksumAlgorithm == "" {
+		return artifactoryUtils.ChecksumAlgorithmAll, nil
+	}
+	allowedAlgorithms := artifactoryUtils.GetChecksumAlgorithms()
+	if !slices.Contains(allowedAlgorithms, checksumAlgorithm) {
+		return "", errorutils.CheckErrorf("the '--checksum-algorithm' option value '%s' is invalid. Allowed values: %s", checksumAlgorithm, strings.Join(allowedAlgorithms, ", "))
+	}
+	return artifactoryUtils.ChecksumAlgorithm(checksumAlgorithm), nil
+}
+
+// Get the container tool selected by the '--container-tool' flag. The container tool is auto-detected if the flag isn't set.
+func GetContainerManagerType(c *components.Context) (container.ContainerManagerType, error) {
+	return container.GetContainerManagerType(c.GetStringFlagValue("container-tool"))
+}
+
 func GetPrintCurrentCmdHelp(c *components.Context) func() error {
 	return func() error {
 		return c.PrintCommandHelp(c.CommandName)
@@ -75,6 +862,17 @@ func PrintHelpAndReturnError(msg string, context *components.Context) error {
 	return cliutils.PrintHelpAndReturnError(msg, GetPrintCurrentCmdHelp(context))
 }
 
+// Returns an error if none of the provided flags is set.
+// Useful for commands which require at least one input source, such as '--spec' or '--aql'.
+func ValidateAtLeastOneFlag(c *components.Context, flagNames ...string) error {
+	for _, flagName := range flagNames {
+		if c.IsFlagSet(flagName) {
+			return nil
+		}
+	}
+	return PrintHelpAndReturnError(fmt.Sprintf("At least one of the following options must be provided: --%s.", strings.Join(flagNames, ", --")), c)
+}
+
 func WrongNumberOfArgumentsHandler(context *components.Context) error {
 	return cliutils.WrongNumberOfArgumentsHandler(len(context.Arguments), GetPrintCurrentCmdHelp(context))
 }
@@ -83,6 +881,46 @@ func ExtractArguments(context *components.Context) []string {
 	return slices.Clone(context.Arguments)
 }
 
+// Returns the arguments which look like flags (start with '--') but aren't one of `knownFlags`, such as a mistyped '--treads=5'.
+// This function should be used iff the SkipFlagParsing option is used, since flags are then received as arguments.
+// `knownFlags` are flag names, with or without the '--' prefix. The '--help' flag is always considered known.
+func DetectLikelyMistypedFlags(c *components.Context, knownFlags []string) (suspects []string) {
+	for _, arg := range ExtractArguments(c) {
+		if !strings.HasPrefix(arg, "--") || arg == "--" {
+			continue
+		}
+		flagName, _, _ := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if flagName == "help" || slices.ContainsFunc(knownFlags, func(knownFlag string) bool {
+			return strings.TrimPrefix(knownFlag, "--") == flagName
+		}) {
+			continue
+		}
+		suspects = append(suspects, arg)
+	}
+	return
+}
+
+// Convert a 'repo/path' argument to its canonical form, by trimming leading and trailing slashes and collapsing repeated slashes.
+// For example, '/repo//a/' is converted to 'repo/a'.
+func NormalizeRepoPath(arg string) string {
+	var parts []string
+	for _, part := range strings.Split(arg, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// Apply NormalizeRepoPath on each of the provided arguments. Can be used on the output of ExtractArguments.
+func NormalizeRepoPathArguments(args []string) []string {
+	normalized := make([]string, 0, len(args))
+	for _, arg := range args {
+		normalized = append(normalized, NormalizeRepoPath(arg))
+	}
+	return normalized
+}
+
 // Return a sorted list of a command's flags by a given command key.
 func GetCommandFlags(cmdKey string, commandToFlags map[string][]string, flagsMap map[string]components.Flag) []components.Flag {
 	flagList, ok := commandToFlags[cmdKey]
@@ -93,6 +931,38 @@ func GetCommandFlags(cmdKey string, commandToFlags map[string][]string, flagsMap
 	return buildAndSortFlags(flagList, flagsMap)
 }
 
+// Memoizes the sorted flags list of each command, to avoid rebuilding it on every call (e.g. on every help rendering).
+// Safe for concurrent use.
+type CommandFlagsCache struct {
+	commandToFlags map[string][]string
+	flagsMap       map[string]components.Flag
+	cache          map[string][]components.Flag
+	mutex          sync.RWMutex
+}
+
+func NewCommandFlagsCache(commandToFlags map[string][]string, flagsMap map[string]components.Flag) *CommandFlagsCache {
+	return &CommandFlagsCache{commandToFlags: commandToFlags, flagsMap: flagsMap, cache: make(map[string][]components.Flag)}
+}
+
+// Same as GetCommandFlags, but the sorted list is built once per command key.
+// A copy of the cached list is returned, so callers may modify it freely.
+func (cfc *CommandFlagsCache) GetCommandFlags(cmdKey string) []components.Flag {
+	cfc.mutex.RLock()
+	flags, ok := cfc.cache[cmdKey]
+	cfc.mutex.RUnlock()
+	if ok {
+		return slices.Clone(flags)
+	}
+	flags = GetCommandFlags(cmdKey, cfc.commandToFlags, cfc.flagsMap)
+	if flags == nil {
+		return nil
+	}
+	cfc.mutex.Lock()
+	cfc.cache[cmdKey] = flags
+	cfc.mutex.Unlock()
+	return slices.Clone(flags)
+}
+
 func buildAndSortFlags(keys []string, flagsMap map[string]components.Flag) (flags []components.Flag) {
 	for _, flag := range keys {
 		flags = append(flags, flagsMap[flag])
@@ -0,0 +1,1190 @@
+package common
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/progressbar"
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func createTestFlagsMaps(commandsCount, flagsCount int) (map[string][]string, map[string]components.Flag) {
+	commandToFlags := make(map[string][]string, commandsCount)
+	flagsMap := make(map[string]components.Flag, flagsCount)
+	for i := 0; i < flagsCount; i++ {
+		flagName := fmt.Sprintf("flag-%d", flagsCount-i)
+		flagsMap[flagName] = components.NewStringFlag(flagName, "")
+	}
+	for i := 0; i < commandsCount; i++ {
+		cmdKey := fmt.Sprintf("cmd-%d", i)
+		for flagName := range flagsMap {
+			commandToFlags[cmdKey] = append(commandToFlags[cmdKey], flagName)
+		}
+	}
+	return commandToFlags, flagsMap
+}
+
+func TestCommandFlagsCache(t *testing.T) {
+	commandToFlags, flagsMap := createTestFlagsMaps(3, 10)
+	cache := NewCommandFlagsCache(commandToFlags, flagsMap)
+
+	expected := GetCommandFlags("cmd-1", commandToFlags, flagsMap)
+	assert.Equal(t, expected, cache.GetCommandFlags("cmd-1"))
+
+	// Modifying the returned list should not affect the cache.
+	flags := cache.GetCommandFlags("cmd-1")
+	flags[0] = components.NewBoolFlag("modified", "")
+	assert.Equal(t, expected, cache.GetCommandFlags("cmd-1"))
+
+	assert.Nil(t, cache.GetCommandFlags("not-exist"))
+}
+
+func BenchmarkGetCommandFlags(b *testing.B) {
+	commandToFlags, flagsMap := createTestFlagsMaps(100, 50)
+	b.Run("no-cache", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			GetCommandFlags(fmt.Sprintf("cmd-%d", i%100), commandToFlags, flagsMap)
+		}
+	})
+	b.Run("cache", func(b *testing.B) {
+		cache := NewCommandFlagsCache(commandToFlags, flagsMap)
+		for i := 0; i < b.N; i++ {
+			cache.GetCommandFlags(fmt.Sprintf("cmd-%d", i%100))
+		}
+	})
+}
+
+func TestCreateDownloadConfigurationSkipChecksum(t *testing.T) {
+	tests := []struct {
+		name                 string
+		skipChecksum         bool
+		checksumMode         string
+		forceChecksum        string
+		expectedSkipChecksum bool
+	}{
+		{"default", false, "", "", false},
+		{"skip checksum", true, "", "", true},
+		{"skip checksum with force", true, "", "true", false},
+		{"no skip checksum with force", false, "", "true", false},
+		{"skip checksum with force disabled", true, "", "false", true},
+		{"strict mode", false, ChecksumModeStrict, "", false},
+		{"warn mode", false, ChecksumModeWarn, "", false},
+		{"skip mode", false, ChecksumModeSkip, "", true},
+		{"skip mode with force", false, ChecksumModeSkip, "true", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(cliutils.JfrogCliForceChecksum, test.forceChecksum)
+			c := &components.Context{}
+			c.AddBoolFlag("skip-checksum", test.skipChecksum)
+			if test.checksumMode != "" {
+				c.AddStringFlag("checksum-mode", test.checksumMode)
+			}
+			downloadConfiguration, err := CreateDownloadConfiguration(c)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSkipChecksum, downloadConfiguration.SkipChecksum)
+		})
+	}
+}
+
+func TestCreateDownloadConfigurationSplitCountWarning(t *testing.T) {
+	_, logBuffer, previousLog := tests.RedirectLogOutputToBuffer()
+	defer log.SetLogger(previousLog)
+
+	c := &components.Context{}
+	c.AddStringFlag("split-count", "2")
+	c.AddStringFlag("threads", "4")
+	_, err := CreateDownloadConfiguration(c)
+	assert.NoError(t, err)
+	assert.NotContains(t, logBuffer.String(), "--split-count")
+
+	c = &components.Context{}
+	c.AddStringFlag("split-count", "5")
+	c.AddStringFlag("threads", "2")
+	_, err = CreateDownloadConfiguration(c)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(logBuffer.String(), "The '--split-count' value (5) is greater than the '--threads' value (2)"))
+}
+
+func TestCreateDownloadConfigurationToStdout(t *testing.T) {
+	tests := []struct {
+		name          string
+		output        string
+		expected      bool
+		expectedError string
+	}{
+		{"not set", "", false, ""},
+		{"stdout", "-", true, ""},
+		{"local path", "out.txt", false, "The '--output' option only supports the '-' value"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{}
+			c.AddStringFlag("output", test.output)
+			downloadConfiguration, err := CreateDownloadConfiguration(c)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, downloadConfiguration.ToStdout)
+		})
+	}
+}
+
+func TestCreateConfigurationsDryRun(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		c := &components.Context{}
+		c.AddBoolFlag("dry-run", dryRun)
+		downloadConfiguration, err := CreateDownloadConfiguration(c)
+		assert.NoError(t, err)
+		assert.Equal(t, dryRun, downloadConfiguration.DryRun)
+		uploadConfiguration, err := CreateUploadConfiguration(c)
+		assert.NoError(t, err)
+		assert.Equal(t, dryRun, uploadConfiguration.DryRun)
+		deleteConfiguration, err := CreateDeleteConfiguration(c)
+		assert.NoError(t, err)
+		assert.Equal(t, dryRun, deleteConfiguration.DryRun)
+		moveCopyConfiguration, err := CreateMoveCopyConfiguration(c)
+		assert.NoError(t, err)
+		assert.Equal(t, dryRun, moveCopyConfiguration.DryRun)
+		assert.Equal(t, cliutils.Threads, moveCopyConfiguration.Threads)
+	}
+}
+
+func TestCreateTransferConfigurationRateLimit(t *testing.T) {
+	tests := []struct {
+		rateLimit string
+		expected  int64
+		expectErr bool
+	}{
+		{"", 0, false},
+		{"500KB", 500 * 1024, false},
+		{"10MB", 10 * 1024 * 1024, false},
+		{"fast", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.rateLimit, func(t *testing.T) {
+			c := &components.Context{}
+			c.AddStringFlag("rate-limit", test.rateLimit)
+			downloadConfiguration, downloadErr := CreateDownloadConfiguration(c)
+			uploadConfiguration, uploadErr := CreateUploadConfiguration(c)
+			if test.expectErr {
+				assert.ErrorContains(t, downloadErr, "The '--rate-limit' option should have a size value")
+				assert.ErrorContains(t, uploadErr, "The '--rate-limit' option should have a size value")
+				return
+			}
+			assert.NoError(t, downloadErr)
+			assert.NoError(t, uploadErr)
+			assert.Equal(t, test.expected, downloadConfiguration.RateLimit)
+			assert.Equal(t, test.expected, uploadConfiguration.RateLimit)
+		})
+	}
+}
+
+func TestGetRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		flags         map[string]string
+		expected      *artifactoryUtils.RetryPolicy
+		expectedError string
+	}{
+		{"not set", nil, nil, ""},
+		{"retries only", map[string]string{"retries": "5"}, &artifactoryUtils.RetryPolicy{Retries: 5, BackoffFactor: 1}, ""},
+		{"full policy", map[string]string{"retries-wait-time": "500ms", "retry-backoff-factor": "2", "retry-jitter": "0.2"},
+			&artifactoryUtils.RetryPolicy{Retries: cliutils.Retries, WaitTime: 500 * time.Millisecond, BackoffFactor: 2, Jitter: 0.2}, ""},
+		{"negative retries", map[string]string{"retries": "-1"}, nil, "The '--retries' option should have a non-negative numeric value"},
+		{"invalid wait time", map[string]string{"retries-wait-time": "5"}, nil, "The '--retries-wait-time' option should have a duration value"},
+		{"backoff factor below 1", map[string]string{"retry-backoff-factor": "0.5"}, nil, "The '--retry-backoff-factor' option should have a numeric value of at least 1"},
+		{"jitter above 1", map[string]string{"retry-jitter": "1.5"}, nil, "The '--retry-jitter' option should have a numeric value between 0 and 1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{}
+			for flagName, value := range test.flags {
+				c.AddStringFlag(flagName, value)
+			}
+			retryPolicy, err := GetRetryPolicy(c)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, retryPolicy)
+		})
+	}
+}
+
+func TestCreateUploadConfigurationSplit(t *testing.T) {
+	tests := []struct {
+		name               string
+		flags              map[string]string
+		expectedMinSplit   int64
+		expectedSplitCount int
+		expectedChunkSize  int64
+		expectedError      string
+	}{
+		{"defaults", nil, cliutils.UploadMinSplitMb, cliutils.UploadSplitCount, cliutils.UploadChunkSizeMb, ""},
+		{"custom", map[string]string{"upload-min-split": "100", "upload-split-count": "10", "chunk-size": "50"}, 100, 10, 50, ""},
+		{"invalid min split", map[string]string{"upload-min-split": "big"}, 0, 0, 0, "The '--upload-min-split' option should have a numeric value"},
+		{"split count above max", map[string]string{"upload-split-count": "101"}, 0, 0, 0, "The '--upload-split-count' option value is limited to a maximum of 100"},
+		{"chunk size too small", map[string]string{"chunk-size": "4"}, 0, 0, 0, "the '--chunk-size' option value should be between 5 and 5120"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{}
+			for flagName, value := range test.flags {
+				c.AddStringFlag(flagName, value)
+			}
+			uploadConfiguration, err := CreateUploadConfiguration(c)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedMinSplit, uploadConfiguration.MinSplitSizeMB)
+			assert.Equal(t, test.expectedSplitCount, uploadConfiguration.SplitCount)
+			assert.Equal(t, test.expectedChunkSize, uploadConfiguration.ChunkSizeMB)
+		})
+	}
+}
+
+func TestGetBoolEnvOrFlag(t *testing.T) {
+	const flagName, envKey = "test-flag", "JFROG_CLI_TEST_BOOL_ENV"
+	tests := []struct {
+		name      string
+		flagValue *bool
+		envValue  string
+		def       bool
+		expected  bool
+	}{
+		{"default false", nil, "", false, false},
+		{"default true", nil, "", true, true},
+		{"env true", nil, "true", false, true},
+		{"env false", nil, "false", true, false},
+		{"invalid env", nil, "invalid", true, true},
+		{"flag false overrides env", clientutils.Pointer(false), "true", true, false},
+		{"flag true overrides env", clientutils.Pointer(true), "false", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(envKey, test.envValue)
+			var args []string
+			if test.flagValue != nil {
+				args = append(args, fmt.Sprintf("--%s=%t", flagName, *test.flagValue))
+			}
+			c := createBoolFlagsContext(t, args, flagName)
+			assert.Equal(t, test.expected, GetBoolEnvOrFlag(c, flagName, envKey, test.def))
+		})
+	}
+}
+
+// Converts a CLI context with the provided bool flags, parsed from the provided arguments.
+// Unlike adding the flags to an empty context, the converted context tells explicitly set flags from their default values.
+func createBoolFlagsContext(t *testing.T, args []string, flagNames ...string) *components.Context {
+	var cliFlags []cli.Flag
+	var flags []components.Flag
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, flagName := range flagNames {
+		cliFlag := cli.BoolFlag{Name: flagName}
+		cliFlag.Apply(flagSet)
+		cliFlags = append(cliFlags, cliFlag)
+		flags = append(flags, components.NewBoolFlag(flagName, ""))
+	}
+	assert.NoError(t, flagSet.Parse(args))
+	baseContext := cli.NewContext(nil, flagSet, nil)
+	baseContext.Command = cli.Command{Name: "test", Flags: cliFlags}
+	c, err := components.ConvertContext(baseContext, flags...)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestGetNonEmptyStringsArrFlagValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		flagValue *string
+		expected  []string
+		expectErr bool
+	}{
+		{"not set", nil, nil, false},
+		{"single value", clientutils.Pointer("a"), []string{"a"}, false},
+		{"multiple values", clientutils.Pointer("a;b;c"), []string{"a", "b", "c"}, false},
+		{"empty flag", clientutils.Pointer(""), nil, true},
+		{"empty element", clientutils.Pointer("a;;b"), nil, true},
+		{"whitespace element", clientutils.Pointer("a; ;b"), nil, true},
+		{"trailing separator", clientutils.Pointer("a;b;"), nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{}
+			if test.flagValue != nil {
+				c.AddStringFlag("repos", *test.flagValue)
+			}
+			result, err := GetNonEmptyStringsArrFlagValue(c, "repos")
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestValidateAtLeastOneFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		setFlags  []string
+		expectErr bool
+	}{
+		{"none set", nil, true},
+		{"unrelated set", []string{"threads"}, true},
+		{"one set", []string{"spec"}, false},
+		{"many set", []string{"path", "spec", "aql"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{PrintCommandHelp: func(string) error { return nil }}
+			for _, flagName := range test.setFlags {
+				c.AddStringFlag(flagName, "value")
+			}
+			err := ValidateAtLeastOneFlag(c, "path", "spec", "aql")
+			if test.expectErr {
+				assert.ErrorContains(t, err, "--path, --spec, --aql")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetExpandedPathFlagValue(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	assert.NoError(t, err)
+	t.Setenv("JFROG_CLI_TEST_DIR", "test-dir")
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"path/to/file", "path/to/file"},
+		{"~", homeDir},
+		{"~/.ssh/id_rsa", filepath.Join(homeDir, ".ssh", "id_rsa")},
+		{"~user/file", "~user/file"},
+		{"$JFROG_CLI_TEST_DIR/file", "test-dir/file"},
+		{"~/${JFROG_CLI_TEST_DIR}", filepath.Join(homeDir, "test-dir")},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			c := &components.Context{}
+			c.AddStringFlag("key-file", test.value)
+			path, err := GetExpandedPathFlagValue(c, "key-file")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, path)
+		})
+	}
+}
+
+func TestOverrideAndAppendArrayIfSet(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("exclusions", "c;d")
+
+	overridden := []string{"a", "b"}
+	OverrideArrayIfSet(&overridden, c, "exclusions")
+	assert.Equal(t, []string{"c", "d"}, overridden)
+
+	appended := []string{"a", "b"}
+	AppendArrayIfSet(&appended, c, "exclusions")
+	assert.Equal(t, []string{"a", "b", "c", "d"}, appended)
+
+	notSet := []string{"a", "b"}
+	AppendArrayIfSet(&notSet, c, "not-set")
+	assert.Equal(t, []string{"a", "b"}, notSet)
+}
+
+func TestOverrideIntIfSet(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("limit", "10")
+	c.AddStringFlag("offset", "ten")
+
+	limit := 5
+	assert.NoError(t, OverrideIntIfSet(&limit, c, "limit"))
+	assert.Equal(t, 10, limit)
+
+	offset := 5
+	assert.ErrorContains(t, OverrideIntIfSet(&offset, c, "offset"), "the '--offset' option should have an integer value")
+	assert.Equal(t, 5, offset)
+
+	notSet := 5
+	assert.NoError(t, OverrideIntIfSet(&notSet, c, "not-set"))
+	assert.Equal(t, 5, notSet)
+}
+
+func TestGetInt64FlagValue(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("offset", "5000000000")
+	c.AddStringFlag("limit", "ten")
+
+	value, isSet, err := GetInt64FlagValue(c, "offset")
+	assert.NoError(t, err)
+	assert.True(t, isSet)
+	assert.Equal(t, int64(5000000000), value)
+
+	_, isSet, err = GetInt64FlagValue(c, "limit")
+	assert.ErrorContains(t, err, "the '--limit' option should have an integer value")
+	assert.True(t, isSet)
+
+	value, isSet, err = GetInt64FlagValue(c, "not-set")
+	assert.NoError(t, err)
+	assert.False(t, isSet)
+	assert.Zero(t, value)
+}
+
+func TestNormalizeRepoPath(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"", ""},
+		{"repo", "repo"},
+		{"repo/", "repo"},
+		{"/repo/a", "repo/a"},
+		{"repo//a//b", "repo/a/b"},
+		{"//repo//a/b//", "repo/a/b"},
+		{"repo/a/*.zip", "repo/a/*.zip"},
+	}
+	for _, test := range tests {
+		t.Run(test.arg, func(t *testing.T) {
+			assert.Equal(t, test.expected, NormalizeRepoPath(test.arg))
+		})
+	}
+}
+
+func TestNormalizeRepoPathArguments(t *testing.T) {
+	c := &components.Context{Arguments: []string{"/repo/a/", "repo-b//c"}}
+	assert.Equal(t, []string{"repo/a", "repo-b/c"}, NormalizeRepoPathArguments(ExtractArguments(c)))
+	assert.Equal(t, []string{"/repo/a/", "repo-b//c"}, c.Arguments)
+}
+
+func TestGetProjectWithLocalConfig(t *testing.T) {
+	tmpDir, createTempDirCallback := tests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".jfrog"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".jfrog", "project"), []byte(" local-proj\n"), 0644))
+	subDir := filepath.Join(tmpDir, "a", "b")
+	assert.NoError(t, os.MkdirAll(subDir, 0755))
+
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	chdirCallBack := testsutils.ChangeDirWithCallback(t, wd, subDir)
+	defer chdirCallBack()
+
+	// Project from the local config file.
+	t.Setenv(coreutils.Project, "")
+	c := &components.Context{}
+	projectKey, err := GetProjectWithLocalConfig(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "local-proj", projectKey)
+
+	// Project from the environment variable.
+	t.Setenv(coreutils.Project, "env-proj")
+	projectKey, err = GetProjectWithLocalConfig(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "env-proj", projectKey)
+
+	// Project from the flag.
+	c.AddStringFlag("project", "flag-proj")
+	projectKey, err = GetProjectWithLocalConfig(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "flag-proj", projectKey)
+}
+
+func TestGetProjectOrFail(t *testing.T) {
+	tests := []struct {
+		name      string
+		flagValue string
+		envValue  string
+		expected  string
+	}{
+		{"flag", "flag-proj", "env-proj", "flag-proj"},
+		{"env", "", "env-proj", "env-proj"},
+		{"missing", "", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(coreutils.Project, test.envValue)
+			helpPrinted := false
+			c := &components.Context{PrintCommandHelp: func(string) error {
+				helpPrinted = true
+				return nil
+			}}
+			if test.flagValue != "" {
+				c.AddStringFlag("project", test.flagValue)
+			}
+			projectKey, err := GetProjectOrFail(c)
+			if test.expected == "" {
+				assert.ErrorContains(t, err, "No project was provided")
+				assert.ErrorContains(t, err, coreutils.Project)
+				assert.True(t, helpPrinted)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, projectKey)
+			assert.False(t, helpPrinted)
+		})
+	}
+}
+
+func TestGetLocalConfigProjectAndRepository(t *testing.T) {
+	tmpDir, createTempDirCallback := tests.CreateTempDirWithCallbackAndAssert(t)
+	defer createTempDirCallback()
+	assert.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ".jfrog"), 0755))
+	// The project in the config file is preferred over the project file.
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".jfrog", "project"), []byte("file-proj"), 0644))
+	localConfig := "serverId: pinned\nproject: config-proj\nrepositories:\n  npm: npm-virtual\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".jfrog", "config"), []byte(localConfig), 0644))
+
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	chdirCallBack := testsutils.ChangeDirWithCallback(t, wd, tmpDir)
+	defer chdirCallBack()
+
+	t.Setenv(coreutils.Project, "")
+	c := &components.Context{}
+	projectKey, err := GetProjectWithLocalConfig(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "config-proj", projectKey)
+
+	repository, err := GetRepositoryWithLocalConfig(c, "repo", "npm")
+	assert.NoError(t, err)
+	assert.Equal(t, "npm-virtual", repository)
+	repository, err = GetRepositoryWithLocalConfig(c, "repo", "maven")
+	assert.NoError(t, err)
+	assert.Empty(t, repository)
+	c.AddStringFlag("repo", "flag-repo")
+	repository, err = GetRepositoryWithLocalConfig(c, "repo", "npm")
+	assert.NoError(t, err)
+	assert.Equal(t, "flag-repo", repository)
+
+	serverId, err := GetServerIdWithLocalConfig(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "pinned", serverId)
+	c.AddStringFlag("server-id", "flag-server")
+	serverId, err = GetServerIdWithLocalConfig(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "flag-server", serverId)
+}
+
+func TestGetChecksumModeInvalid(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("checksum-mode", "lenient")
+	_, err := GetChecksumMode(c)
+	assert.ErrorContains(t, err, "strict, skip, warn")
+}
+
+func TestGetChecksumAlgorithm(t *testing.T) {
+	tests := []struct {
+		flagValue     string
+		expected      artifactoryUtils.ChecksumAlgorithm
+		expectedError string
+	}{
+		{"", artifactoryUtils.ChecksumAlgorithmAll, ""},
+		{"sha256", artifactoryUtils.ChecksumAlgorithmSha256, ""},
+		{"SHA1", artifactoryUtils.ChecksumAlgorithmSha1, ""},
+		{"md5", artifactoryUtils.ChecksumAlgorithmMd5, ""},
+		{"sha512", "", "sha256, sha1, md5, all"},
+	}
+	for _, test := range tests {
+		t.Run(test.flagValue, func(t *testing.T) {
+			c := &components.Context{}
+			c.AddStringFlag("checksum-algorithm", test.flagValue)
+			checksumAlgorithm, err := GetChecksumAlgorithm(c)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, checksumAlgorithm)
+		})
+	}
+}
+
+func TestCreateUploadConfigurationArchiveStream(t *testing.T) {
+	tests := []struct {
+		name          string
+		archiveStream string
+		explode       bool
+		expected      artifactoryUtils.ArchiveStreamFormat
+		expectedError string
+	}{
+		{"not set", "", false, "", ""},
+		{"tar.gz", "TAR.GZ", false, artifactoryUtils.ArchiveStreamTarGz, ""},
+		{"zip", "zip", false, artifactoryUtils.ArchiveStreamZip, ""},
+		{"invalid", "rar", false, "", "Allowed values: tar, tar.gz, zip"},
+		{"with explode", "tar", true, "", "the '--archive-stream' and '--explode' options cannot be used together"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{}
+			c.AddStringFlag("archive-stream", test.archiveStream)
+			c.AddBoolFlag("explode", test.explode)
+			uploadConfiguration, err := CreateUploadConfiguration(c)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, uploadConfiguration.ArchiveStream)
+		})
+	}
+}
+
+func TestGetOnErrorPolicy(t *testing.T) {
+	c := &components.Context{}
+	onError, err := GetOnErrorPolicy(c)
+	assert.NoError(t, err)
+	assert.Equal(t, artifactoryUtils.OnErrorContinue, onError)
+
+	c.AddStringFlag("on-error", "Fail-Fast")
+	onError, err = GetOnErrorPolicy(c)
+	assert.NoError(t, err)
+	assert.Equal(t, artifactoryUtils.OnErrorFailFast, onError)
+
+	c.AddStringFlag("on-error", "ignore")
+	_, err = GetOnErrorPolicy(c)
+	assert.ErrorContains(t, err, "continue, fail-fast, retry-at-end")
+}
+
+func TestGetProgressFormat(t *testing.T) {
+	tests := []struct {
+		flagValue     string
+		expected      string
+		expectedError string
+	}{
+		{"", progressbar.ProgressFormatBar, ""},
+		{"bar", progressbar.ProgressFormatBar, ""},
+		{"JSON", progressbar.ProgressFormatJson, ""},
+		{"xml", "", "bar, json"},
+	}
+	for _, test := range tests {
+		t.Run(test.flagValue, func(t *testing.T) {
+			c := &components.Context{}
+			c.AddStringFlag("progress-format", test.flagValue)
+			progressFormat, err := GetProgressFormat(c)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, progressFormat)
+		})
+	}
+}
+
+type progressTestCommand struct {
+	progress ioUtils.ProgressMgr
+}
+
+func (ptc *progressTestCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	ptc.progress = progress
+}
+
+func (ptc *progressTestCommand) Run() error {
+	progress := ptc.progress.NewProgressReader(10, "Uploading", "repo/a.txt")
+	defer ptc.progress.RemoveProgress(progress.GetId())
+	return errors.New("upload failed")
+}
+
+func (ptc *progressTestCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (ptc *progressTestCommand) CommandName() string {
+	return "progress_test"
+}
+
+func TestExecWithProgressJson(t *testing.T) {
+	progressOutput := filepath.Join(t.TempDir(), "progress.jsonl")
+	c := &components.Context{}
+	c.AddStringFlag("progress-format", progressbar.ProgressFormatJson)
+	c.AddStringFlag("progress-output", progressOutput)
+	// The events are reported in quiet mode too.
+	c.AddBoolFlag("quiet", true)
+	assert.EqualError(t, ExecWithProgress(c, &progressTestCommand{}), "upload failed")
+
+	content, err := os.ReadFile(progressOutput)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.Contains(t, lines[0], `"type":"file_started"`)
+		assert.Contains(t, lines[1], `"type":"file_failed"`)
+		assert.Contains(t, lines[2], `"type":"error","time":`)
+		assert.Contains(t, lines[2], `"error":"upload failed"`)
+	}
+}
+
+func TestGetSearchOutputFormatAndColumns(t *testing.T) {
+	tests := []struct {
+		format          string
+		columns         string
+		expectedFormat  artifactoryUtils.SearchOutputFormat
+		expectedColumns []string
+		expectedError   string
+	}{
+		{"", "", artifactoryUtils.SearchFormatJson, nil, ""},
+		{"CSV", "path, size,SHA256", artifactoryUtils.SearchFormatCsv, []string{"path", "size", "sha256"}, ""},
+		{"jsonl", "path,,type", artifactoryUtils.SearchFormatJsonl, []string{"path", "type"}, ""},
+		{"xml", "", "", nil, "'--format' option value 'xml' is invalid"},
+		{"table", "path,owner", "", nil, "unknown search result column 'owner'"},
+	}
+	for _, test := range tests {
+		t.Run(test.format+"/"+test.columns, func(t *testing.T) {
+			c := &components.Context{}
+			c.AddStringFlag("format", test.format)
+			c.AddStringFlag("columns", test.columns)
+			format, err := GetSearchOutputFormat(c)
+			if err == nil {
+				var columns []string
+				columns, err = GetSearchResultColumns(c)
+				if err == nil {
+					assert.Equal(t, test.expectedFormat, format)
+					assert.Equal(t, test.expectedColumns, columns)
+				}
+			}
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetPropsDelta(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("set-props", "a=1;b=2")
+	c.AddStringFlag("delete-props", "c")
+	delta, err := GetPropsDelta(c)
+	assert.NoError(t, err)
+	assert.Equal(t, artifactoryUtils.PropsDelta{Set: "a=1;b=2", Delete: "c"}, delta)
+
+	_, err = GetPropsDelta(&components.Context{})
+	assert.ErrorContains(t, err, "no properties to set or delete")
+}
+
+func TestGetTristateBoolFlagValue(t *testing.T) {
+	tests := []struct {
+		flagValue     *string
+		expectedValue bool
+		expectedSet   bool
+		expectErr     bool
+	}{
+		{nil, false, false, false},
+		{clientutils.Pointer("true"), true, true, false},
+		{clientutils.Pointer("TRUE"), true, true, false},
+		{clientutils.Pointer("1"), true, true, false},
+		{clientutils.Pointer("yes"), true, true, false},
+		{clientutils.Pointer("Yes"), true, true, false},
+		{clientutils.Pointer("false"), false, true, false},
+		{clientutils.Pointer("False"), false, true, false},
+		{clientutils.Pointer("0"), false, true, false},
+		{clientutils.Pointer("no"), false, true, false},
+		{clientutils.Pointer("NO"), false, true, false},
+		{clientutils.Pointer(""), false, true, true},
+		{clientutils.Pointer("maybe"), false, true, true},
+	}
+	for _, test := range tests {
+		name := "unset"
+		if test.flagValue != nil {
+			name = "'" + *test.flagValue + "'"
+		}
+		t.Run(name, func(t *testing.T) {
+			c := &components.Context{}
+			if test.flagValue != nil {
+				c.AddStringFlag("recursive", *test.flagValue)
+			}
+			value, set, err := GetTristateBoolFlagValue(c, "recursive")
+			assert.Equal(t, test.expectedSet, set)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedValue, value)
+		})
+	}
+}
+
+func TestApplyMaxThreadsLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxThreads string
+		threads    int
+		expected   int
+	}{
+		{"unset", "", 10, 10},
+		{"lowered", "4", 10, 4},
+		{"not raised", "20", 10, 10},
+		{"equal", "10", 10, 10},
+		{"invalid", "abc", 10, 10},
+		{"zero", "0", 10, 10},
+		{"negative", "-3", 10, 10},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(cliutils.JfrogCliMaxThreads, test.maxThreads)
+			assert.Equal(t, test.expected, applyMaxThreadsLimit(test.threads))
+		})
+	}
+}
+
+func TestGetStringFlagValueAnyCase(t *testing.T) {
+	aliases := []string{"Project", "PROJECT"}
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected string
+	}{
+		{"none set", map[string]string{}, ""},
+		{"canonical", map[string]string{"project": "proj"}, "proj"},
+		{"alias", map[string]string{"PROJECT": "proj-upper"}, "proj-upper"},
+		{"first alias wins", map[string]string{"Project": "proj-title", "PROJECT": "proj-upper"}, "proj-title"},
+		{"canonical wins", map[string]string{"project": "proj", "Project": "proj-title"}, "proj"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{}
+			for flagName, value := range test.flags {
+				c.AddStringFlag(flagName, value)
+			}
+			assert.Equal(t, test.expected, GetStringFlagValueAnyCase(c, "project", aliases...))
+		})
+	}
+}
+
+func TestSetDownloadDefaults(t *testing.T) {
+	defer func() {
+		assert.NoError(t, SetDownloadDefaults(cliutils.DownloadMinSplitKb, cliutils.DownloadSplitCount, cliutils.DownloadMaxSplitCount))
+	}()
+	c := &components.Context{}
+
+	// Out-of-the-box defaults.
+	downloadConfiguration, err := CreateDownloadConfiguration(c)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(cliutils.DownloadMinSplitKb), downloadConfiguration.MinSplitSize)
+	assert.Equal(t, cliutils.DownloadSplitCount, downloadConfiguration.SplitCount)
+
+	// Invalid defaults are rejected.
+	assert.Error(t, SetDownloadDefaults(-1, 3, 15))
+	assert.Error(t, SetDownloadDefaults(1024, 5, 4))
+
+	// Custom defaults.
+	assert.NoError(t, SetDownloadDefaults(1024, 2, 4))
+	downloadConfiguration, err = CreateDownloadConfiguration(c)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024), downloadConfiguration.MinSplitSize)
+	assert.Equal(t, 2, downloadConfiguration.SplitCount)
+	c.AddStringFlag("split-count", "5")
+	_, err = CreateDownloadConfiguration(c)
+	assert.ErrorContains(t, err, "maximum of 4")
+}
+
+func TestDescribeFlagResolution(t *testing.T) {
+	const envKey = "JFROG_CLI_TEST_DESCRIBE_ENV"
+	tests := []struct {
+		name     string
+		flagName string
+		flags    map[string]string
+		envValue string
+		expected string
+	}{
+		{"unset", "project", nil, "", "project= (source: unset)"},
+		{"from env", "project", nil, "env-proj", "project=env-proj (source: env " + envKey + ")"},
+		{"from flag", "project", map[string]string{"project": "flag-proj"}, "env-proj", "project=flag-proj (source: flag)"},
+		{"redacted flag", "access-token", map[string]string{"access-token": "abc"}, "", "access-token=*** (source: flag)"},
+		{"redacted env", "password", nil, "abc", "password=*** (source: env " + envKey + ")"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(envKey, test.envValue)
+			c := &components.Context{}
+			for flagName, value := range test.flags {
+				c.AddStringFlag(flagName, value)
+			}
+			assert.Equal(t, test.expected, DescribeFlagResolution(c, test.flagName, envKey))
+		})
+	}
+}
+
+// Should be run with the race detector (-race) to detect unsafe concurrent access.
+func TestCommandFlagsCacheConcurrentAccess(t *testing.T) {
+	commandToFlags, flagsMap := createTestFlagsMaps(5, 10)
+	cache := NewCommandFlagsCache(commandToFlags, flagsMap)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cmdKey := fmt.Sprintf("cmd-%d", i%5)
+			assert.Equal(t, GetCommandFlags(cmdKey, commandToFlags, flagsMap), cache.GetCommandFlags(cmdKey))
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestGetQuotedStringsArrFlagValue(t *testing.T) {
+	tests := []struct {
+		flagValue *string
+		expected  []string
+		expectErr bool
+	}{
+		{nil, nil, false},
+		{clientutils.Pointer(""), []string{""}, false},
+		{clientutils.Pointer("a;b"), []string{"a", "b"}, false},
+		{clientutils.Pointer("'a;b';c"), []string{"a;b", "c"}, false},
+		{clientutils.Pointer(`"a;b";'c;d'`), []string{"a;b", "c;d"}, false},
+		{clientutils.Pointer(`"it's";c`), []string{"it's", "c"}, false},
+		{clientutils.Pointer("key='a;b'"), []string{"key=a;b"}, false},
+		{clientutils.Pointer("''"), []string{""}, false},
+		{clientutils.Pointer("'a;b"), nil, true},
+		{clientutils.Pointer(`a;"b`), nil, true},
+	}
+	for _, test := range tests {
+		name := "unset"
+		if test.flagValue != nil {
+			name = *test.flagValue
+		}
+		t.Run(name, func(t *testing.T) {
+			c := &components.Context{}
+			if test.flagValue != nil {
+				c.AddStringFlag("props", *test.flagValue)
+			}
+			result, err := GetQuotedStringsArrFlagValue(c, "props")
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestRunCmdWithDeprecationWarning(t *testing.T) {
+	previousDeprecations := usedDeprecations
+	usedDeprecations = nil
+	defer func() {
+		usedDeprecations = previousDeprecations
+		DeprecationReporter = nil
+	}()
+	t.Setenv(cliutils.JfrogCliAvoidDeprecationWarnings, "")
+	_, logBuffer, previousLog := tests.RedirectLogOutputToBuffer()
+	defer log.SetLogger(previousLog)
+
+	var reported []string
+	DeprecationReporter = func(cmdName, oldSubcommand string) {
+		reported = append(reported, oldSubcommand+" "+cmdName)
+	}
+	assert.Empty(t, GetDeprecationsSummary())
+	runs := 0
+	cmd := func(*components.Context) error {
+		runs++
+		return nil
+	}
+	for _, cmdName := range []string{"upload", "upload", "download"} {
+		assert.NoError(t, RunCmdWithDeprecationWarning(cmdName, "rt", &components.Context{}, cmd))
+	}
+	// The commands always run, but each deprecated syntax is warned about and reported once.
+	assert.Equal(t, 3, runs)
+	assert.Equal(t, []string{"rt upload", "rt download"}, reported)
+	assert.Equal(t, 2, strings.Count(logBuffer.String(), "You are using a deprecated syntax of the command"))
+
+	summary := GetDeprecationsSummary()
+	assert.Equal(t, 1, strings.Count(summary, "rt upload"))
+	assert.Equal(t, 1, strings.Count(summary, "rt download"))
+}
+
+func TestGetThreadsCountAtLeastOne(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  int
+		expectErr bool
+	}{
+		{"", cliutils.Threads, false},
+		{"1", 1, false},
+		{"8", 8, false},
+		{"0", 0, true},
+		{"-2", 0, true},
+		{"abc", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			c := &components.Context{}
+			if test.value != "" {
+				c.AddStringFlag("threads", test.value)
+			}
+			threads, err := GetThreadsCountAtLeastOne(c)
+			if test.expectErr {
+				// The parse error of GetThreadsCount is returned as is.
+				assert.EqualError(t, err, "the '--threads' option should have a numeric positive value")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, threads)
+		})
+	}
+}
+
+func TestGetMinSplitAndSplitCount(t *testing.T) {
+	c := &components.Context{}
+	minSplit, err := GetMinSplit(c)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(cliutils.DownloadMinSplitKb), minSplit)
+	splitCount, err := GetSplitCount(c)
+	assert.NoError(t, err)
+	assert.Equal(t, cliutils.DownloadSplitCount, splitCount)
+
+	c.AddStringFlag("min-split", "1024")
+	c.AddStringFlag("split-count", "10")
+	minSplit, err = GetMinSplit(c)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024), minSplit)
+	splitCount, err = GetSplitCount(c)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, splitCount)
+
+	c.AddStringFlag("min-split", "abc")
+	c.AddStringFlag("split-count", strconv.Itoa(cliutils.DownloadMaxSplitCount+1))
+	_, err = GetMinSplit(c)
+	assert.ErrorContains(t, err, "--min-split")
+	_, err = GetSplitCount(c)
+	assert.ErrorContains(t, err, "maximum")
+}
+
+func TestGetQuietValueWithSource(t *testing.T) {
+	tests := []struct {
+		name             string
+		quietFlag        *bool
+		ci               string
+		expectedQuiet    bool
+		expectedFromFlag bool
+	}{
+		{"default", nil, "", false, false},
+		{"ci", nil, "true", true, false},
+		{"flag", clientutils.Pointer(true), "", true, true},
+		{"flag false in ci", clientutils.Pointer(false), "true", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(coreutils.CI, test.ci)
+			var args []string
+			if test.quietFlag != nil {
+				args = append(args, fmt.Sprintf("--quiet=%t", *test.quietFlag))
+			}
+			c := createBoolFlagsContext(t, args, "quiet")
+			quiet, fromFlag := GetQuietValueWithSource(c)
+			assert.Equal(t, test.expectedQuiet, quiet)
+			assert.Equal(t, test.expectedFromFlag, fromFlag)
+			assert.Equal(t, test.expectedQuiet, GetQuietValue(c))
+		})
+	}
+}
+
+func TestResolveArrayFlag(t *testing.T) {
+	defaults := []string{"*.log", "*.tmp", "node_modules"}
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected []string
+	}{
+		{"defaults", nil, defaults},
+		{"add", map[string]string{"add-exclusions": "*.bak;*.swp"}, []string{"*.log", "*.tmp", "node_modules", "*.bak", "*.swp"}},
+		{"remove", map[string]string{"remove-exclusions": "*.tmp"}, []string{"*.log", "node_modules"}},
+		{"deduplicate", map[string]string{"add-exclusions": "*.log;*.bak;*.bak"}, []string{"*.log", "*.tmp", "node_modules", "*.bak"}},
+		{"removals after additions", map[string]string{"add-exclusions": "*.bak", "remove-exclusions": "*.bak;*.log"}, []string{"*.tmp", "node_modules"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{}
+			for flagName, value := range test.flags {
+				c.AddStringFlag(flagName, value)
+			}
+			assert.Equal(t, test.expected, ResolveArrayFlag(c, "add-exclusions", "remove-exclusions", defaults))
+		})
+	}
+	// The defaults should not be modified.
+	assert.Equal(t, []string{"*.log", "*.tmp", "node_modules"}, defaults)
+}
+
+func TestDetectLikelyMistypedFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{"no args", nil, nil},
+		{"known flags", []string{"--threads=5", "--recursive", "repo/path"}, nil},
+		{"help", []string{"--help"}, nil},
+		{"end of options", []string{"--", "arg"}, nil},
+		{"short flags ignored", []string{"-t", "arg"}, nil},
+		{"mistyped flags", []string{"--treads=5", "repo/path", "--recursiv", "--threads=3"}, []string{"--treads=5", "--recursiv"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &components.Context{Arguments: test.args}
+			assert.Equal(t, test.expected, DetectLikelyMistypedFlags(c, []string{"threads", "--recursive"}))
+		})
+	}
+}
+
+func TestGetPromotionTargets(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("source-repo", "staging")
+	targets, err := GetPromotionTargets(c, "release;archive")
+	assert.NoError(t, err)
+	assert.Equal(t, []artifactoryUtils.PromotionTarget{{SourceRepo: "staging", TargetRepo: "release"}, {SourceRepo: "staging", TargetRepo: "archive"}}, targets)
+
+	_, err = GetPromotionTargets(&components.Context{}, "")
+	assert.ErrorContains(t, err, "no promotion targets were provided")
+
+	specFile := filepath.Join(t.TempDir(), "targets.json")
+	assert.NoError(t, os.WriteFile(specFile, []byte(`{"targets": [{"targetRepo": "release"}]}`), 0644))
+	c = &components.Context{}
+	c.AddStringFlag("targets-spec", specFile)
+	targets, err = GetPromotionTargets(c, "ignored")
+	assert.NoError(t, err)
+	assert.Equal(t, []artifactoryUtils.PromotionTarget{{TargetRepo: "release"}}, targets)
+}
+
+func TestGetContainerManagerType(t *testing.T) {
+	c := &components.Context{}
+	c.AddStringFlag("container-tool", "podman")
+	containerManagerType, err := GetContainerManagerType(c)
+	assert.NoError(t, err)
+	assert.Equal(t, container.Podman, containerManagerType)
+
+	c = &components.Context{}
+	c.AddStringFlag("container-tool", "rkt")
+	_, err = GetContainerManagerType(c)
+	assert.ErrorContains(t, err, "the container tool 'rkt' is not supported")
+}
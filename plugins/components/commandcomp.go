@@ -1,8 +1,12 @@
 package components
 
 import (
-	"fmt"
 	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 )
 
 type Argument struct {
@@ -48,37 +52,106 @@ type EnvVar struct {
 
 type ActionFunc func(c *Context) error
 
+// The context of a running command.
+// Safe for concurrent use, so flag values may be read from multiple goroutines, e.g. when running sub-commands concurrently.
 type Context struct {
-	Arguments        []string
-	CommandName      string
-	stringFlags      map[string]string
-	boolFlags        map[string]bool
+	Arguments       []string
+	CommandName     string
+	stringFlags     map[string]string
+	boolFlags       map[string]bool
+	stringFlagsDefs map[string]StringFlag
+	// Flags which were provided in the command line or through their environment variables.
+	explicitFlags    map[string]bool
+	flagsMutex       sync.RWMutex
 	PrintCommandHelp func(commandName string) error
 }
 
 func (c *Context) GetStringFlagValue(flagName string) string {
+	c.flagsMutex.RLock()
+	defer c.flagsMutex.RUnlock()
 	return c.stringFlags[flagName]
 }
 
 func (c *Context) AddStringFlag(key, value string) {
+	c.flagsMutex.Lock()
+	defer c.flagsMutex.Unlock()
+	if c.stringFlags == nil {
+		c.stringFlags = make(map[string]string)
+	}
 	c.stringFlags[key] = value
 }
 
+func (c *Context) AddBoolFlag(key string, value bool) {
+	c.flagsMutex.Lock()
+	defer c.flagsMutex.Unlock()
+	if c.boolFlags == nil {
+		c.boolFlags = make(map[string]bool)
+	}
+	c.boolFlags[key] = value
+}
+
+// The typed getters below return an error if the flag's value can't be parsed,
+// or if it violates the Min/Max constraints declared on the flag definition (see WithMinValue and WithMaxValue).
 func (c *Context) GetIntFlagValue(flagName string) (value int, err error) {
-	parsed, err := strconv.ParseInt(c.GetStringFlagValue(flagName), 0, 64)
+	parsed, err := strconv.ParseInt(c.GetStringFlagValue(flagName), 0, strconv.IntSize)
 	if err != nil {
-		err = fmt.Errorf("can't parse int flag '%s': %w", flagName, err)
-		return
+		return 0, newFlagValueError(flagName, "an integer")
+	}
+	return int(parsed), c.validateFlagRange(flagName, float64(parsed))
+}
+
+func (c *Context) GetInt64FlagValue(flagName string) (value int64, err error) {
+	if value, err = strconv.ParseInt(c.GetStringFlagValue(flagName), 0, 64); err != nil {
+		return 0, newFlagValueError(flagName, "an integer")
+	}
+	return value, c.validateFlagRange(flagName, float64(value))
+}
+
+func (c *Context) GetFloatFlagValue(flagName string) (value float64, err error) {
+	if value, err = strconv.ParseFloat(c.GetStringFlagValue(flagName), 64); err != nil {
+		return 0, newFlagValueError(flagName, "a numeric")
+	}
+	return value, c.validateFlagRange(flagName, value)
+}
+
+// The value should be in the Go duration format, such as '1m30s'.
+// The Min/Max constraints of duration flags are in seconds.
+func (c *Context) GetDurationFlagValue(flagName string) (value time.Duration, err error) {
+	if value, err = time.ParseDuration(c.GetStringFlagValue(flagName)); err != nil {
+		return 0, newFlagValueError(flagName, "a duration (such as '1m30s')")
+	}
+	return value, c.validateFlagRange(flagName, value.Seconds())
+}
+
+func (c *Context) validateFlagRange(flagName string, value float64) error {
+	c.flagsMutex.RLock()
+	flag, exists := c.stringFlagsDefs[flagName]
+	c.flagsMutex.RUnlock()
+	if !exists {
+		return nil
+	}
+	if flag.MinValue != nil && value < *flag.MinValue {
+		return errorutils.CheckErrorf("the '--%s' option value should be at least %v. %s", flagName, *flag.MinValue, cliutils.GetCLIDocumentationMessage())
+	}
+	if flag.MaxValue != nil && value > *flag.MaxValue {
+		return errorutils.CheckErrorf("the '--%s' option value should be at most %v. %s", flagName, *flag.MaxValue, cliutils.GetCLIDocumentationMessage())
 	}
-	value = int(parsed)
-	return
+	return nil
+}
+
+func newFlagValueError(flagName, expectedValue string) error {
+	return errorutils.CheckErrorf("the '--%s' option should have %s value. %s", flagName, expectedValue, cliutils.GetCLIDocumentationMessage())
 }
 
 func (c *Context) GetBoolFlagValue(flagName string) bool {
+	c.flagsMutex.RLock()
+	defer c.flagsMutex.RUnlock()
 	return c.boolFlags[flagName]
 }
 
 func (c *Context) IsFlagSet(flagName string) bool {
+	c.flagsMutex.RLock()
+	defer c.flagsMutex.RUnlock()
 	if _, exist := c.stringFlags[flagName]; exist {
 		return true
 	}
@@ -86,6 +159,14 @@ func (c *Context) IsFlagSet(flagName string) bool {
 	return exist
 }
 
+// Returns true if the flag was provided in the command line or through one of its environment variables,
+// as opposed to IsFlagSet, which also returns true for flags holding their default values.
+func (c *Context) IsFlagExplicitlySet(flagName string) bool {
+	c.flagsMutex.RLock()
+	defer c.flagsMutex.RUnlock()
+	return c.explicitFlags[flagName]
+}
+
 type Flag interface {
 	GetName() string
 	IsMandatory() bool
@@ -96,6 +177,19 @@ type BaseFlag struct {
 	Name        string
 	Description string
 	Hidden      bool
+	// Optional. Environment variables to read the flag's value from, if the flag isn't provided in the command line.
+	// The first environment variable that is set is used.
+	EnvVars []string
+	// Optional. Names of flags that cannot be used together with this flag.
+	MutuallyExclusiveWith []string
+	// Optional. Names of flags that must also be set when this flag is set.
+	RequiredWith []string
+	// Optional. Names of flags that make this flag mandatory when any of them is set.
+	RequiredIfSet []string
+	// Deprecated flags log a warning when used, or fail the command if the JFROG_CLI_STRICT_DEPRECATION environment variable is set to true.
+	Deprecated bool
+	// Optional. The name of the flag that replaces this deprecated flag. The deprecated flag's value is passed to it, unless it is set too.
+	ReplacedBy string
 }
 
 func NewFlag(name, description string) BaseFlag {
@@ -121,6 +215,11 @@ type StringFlag struct {
 	DefaultValue string
 	// Optional. If provided, this field will be used for help usage. --<Name>=<HelpValue> else: --<Name>=<value>
 	HelpValue string
+	// Optional. Numeric constraints, validated by the Context's typed getters, such as GetIntFlagValue.
+	MinValue *float64
+	MaxValue *float64
+	// Optional. If provided, the flag's value must be one of these values. Also used for shell completion.
+	AllowedValues []string
 }
 
 type StringFlagOption func(f *StringFlag)
@@ -165,6 +264,56 @@ func WithHelpValue(helpValue string) StringFlagOption {
 	}
 }
 
+func WithMinValue(minValue float64) StringFlagOption {
+	return func(f *StringFlag) {
+		f.MinValue = &minValue
+	}
+}
+
+func WithMaxValue(maxValue float64) StringFlagOption {
+	return func(f *StringFlag) {
+		f.MaxValue = &maxValue
+	}
+}
+
+func WithAllowedValues(allowedValues ...string) StringFlagOption {
+	return func(f *StringFlag) {
+		f.AllowedValues = allowedValues
+	}
+}
+
+func WithStrEnvVars(envVars ...string) StringFlagOption {
+	return func(f *StringFlag) {
+		f.EnvVars = envVars
+	}
+}
+
+func WithStrMutuallyExclusiveWith(flagNames ...string) StringFlagOption {
+	return func(f *StringFlag) {
+		f.MutuallyExclusiveWith = flagNames
+	}
+}
+
+func WithStrRequiredWith(flagNames ...string) StringFlagOption {
+	return func(f *StringFlag) {
+		f.RequiredWith = flagNames
+	}
+}
+
+func WithStrRequiredIfSet(flagNames ...string) StringFlagOption {
+	return func(f *StringFlag) {
+		f.RequiredIfSet = flagNames
+	}
+}
+
+// Mark the flag as deprecated. The replacing flag name is optional.
+func SetDeprecatedStrFlag(replacedBy string) StringFlagOption {
+	return func(f *StringFlag) {
+		f.Deprecated = true
+		f.ReplacedBy = replacedBy
+	}
+}
+
 func SetHiddenStrFlag() StringFlagOption {
 	return func(f *StringFlag) {
 		f.Hidden = true
@@ -196,6 +345,38 @@ func WithBoolDefaultValue(defaultValue bool) BoolFlagOption {
 	}
 }
 
+func WithBoolEnvVars(envVars ...string) BoolFlagOption {
+	return func(f *BoolFlag) {
+		f.EnvVars = envVars
+	}
+}
+
+func WithBoolMutuallyExclusiveWith(flagNames ...string) BoolFlagOption {
+	return func(f *BoolFlag) {
+		f.MutuallyExclusiveWith = flagNames
+	}
+}
+
+func WithBoolRequiredWith(flagNames ...string) BoolFlagOption {
+	return func(f *BoolFlag) {
+		f.RequiredWith = flagNames
+	}
+}
+
+func WithBoolRequiredIfSet(flagNames ...string) BoolFlagOption {
+	return func(f *BoolFlag) {
+		f.RequiredIfSet = flagNames
+	}
+}
+
+// Mark the flag as deprecated. The replacing flag name is optional.
+func SetDeprecatedBoolFlag(replacedBy string) BoolFlagOption {
+	return func(f *BoolFlag) {
+		f.Deprecated = true
+		f.ReplacedBy = replacedBy
+	}
+}
+
 func SetHiddenBoolFlag() BoolFlagOption {
 	return func(f *BoolFlag) {
 		f.Hidden = true
@@ -0,0 +1,109 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Should be run with the race detector (-race) to detect unsafe concurrent access.
+func TestContextConcurrentFlagAccess(t *testing.T) {
+	c := &Context{}
+	c.AddStringFlag("threads", "3")
+	c.AddBoolFlag("quiet", true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "3", c.GetStringFlagValue("threads"))
+			assert.True(t, c.GetBoolFlagValue("quiet"))
+			assert.True(t, c.IsFlagSet("threads"))
+			value, err := c.GetIntFlagValue("threads")
+			assert.NoError(t, err)
+			assert.Equal(t, 3, value)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			c.AddStringFlag(fmt.Sprintf("flag-%d", i), "value")
+			c.AddBoolFlag(fmt.Sprintf("bool-flag-%d", i), true)
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < 10; i++ {
+		assert.True(t, c.IsFlagSet(fmt.Sprintf("flag-%d", i)))
+		assert.True(t, c.IsFlagSet(fmt.Sprintf("bool-flag-%d", i)))
+	}
+}
+
+func TestContextTypedFlagValues(t *testing.T) {
+	c := &Context{}
+	c.AddStringFlag("int", "0x10")
+	c.AddStringFlag("int64", "10000000000")
+	c.AddStringFlag("float", "0.5")
+	c.AddStringFlag("duration", "1m30s")
+	c.AddStringFlag("invalid", "abc")
+
+	intValue, err := c.GetIntFlagValue("int")
+	assert.NoError(t, err)
+	assert.Equal(t, 16, intValue)
+	int64Value, err := c.GetInt64FlagValue("int64")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000000000), int64Value)
+	floatValue, err := c.GetFloatFlagValue("float")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, floatValue)
+	durationValue, err := c.GetDurationFlagValue("duration")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, durationValue)
+
+	_, err = c.GetIntFlagValue("invalid")
+	assert.ErrorContains(t, err, "the '--invalid' option should have an integer value")
+	_, err = c.GetInt64FlagValue("invalid")
+	assert.ErrorContains(t, err, "the '--invalid' option should have an integer value")
+	_, err = c.GetFloatFlagValue("invalid")
+	assert.ErrorContains(t, err, "the '--invalid' option should have a numeric value")
+	_, err = c.GetDurationFlagValue("invalid")
+	assert.ErrorContains(t, err, "the '--invalid' option should have a duration (such as '1m30s') value")
+	_, err = c.GetIntFlagValue("not-set")
+	assert.Error(t, err)
+}
+
+func TestContextTypedFlagValuesRange(t *testing.T) {
+	c := &Context{stringFlagsDefs: map[string]StringFlag{
+		"threads": NewStringFlag("threads", "", WithMinValue(1), WithMaxValue(10)),
+		"timeout": NewStringFlag("timeout", "", WithMaxValue(60)),
+	}}
+	tests := []struct {
+		flagName  string
+		value     string
+		expectErr string
+	}{
+		{"threads", "1", ""},
+		{"threads", "10", ""},
+		{"threads", "0", "at least 1"},
+		{"threads", "11", "at most 10"},
+		{"timeout", "1m", ""},
+		{"timeout", "61s", "at most 60"},
+	}
+	for _, test := range tests {
+		t.Run(test.flagName+"="+test.value, func(t *testing.T) {
+			c.AddStringFlag(test.flagName, test.value)
+			var err error
+			if test.flagName == "timeout" {
+				_, err = c.GetDurationFlagValue(test.flagName)
+			} else {
+				_, err = c.GetIntFlagValue(test.flagName)
+			}
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectErr)
+			}
+		})
+	}
+}
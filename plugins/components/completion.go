@@ -0,0 +1,298 @@
+package components
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	Bash       = "bash"
+	Zsh        = "zsh"
+	Fish       = "fish"
+	PowerShell = "powershell"
+)
+
+var supportedCompletionShells = []string{Bash, Zsh, Fish, PowerShell}
+
+// A completion node represents a command path, such as 'app group action', and the words that may follow it.
+type completionNode struct {
+	path string
+	// Subcommand names and flags (prefixed with '--'), in order.
+	words []string
+	// Word descriptions, used by shells which support them.
+	descriptions map[string]string
+	// Enumerated values of flags, by flag (prefixed with '--').
+	flagValues map[string][]string
+}
+
+func newCompletionNode(path string) *completionNode {
+	return &completionNode{path: path, descriptions: make(map[string]string), flagValues: make(map[string][]string)}
+}
+
+func (cn *completionNode) addWord(word, description string) {
+	cn.words = append(cn.words, word)
+	cn.descriptions[word] = description
+}
+
+// Returns a completion command for the given app, which prints the completion script of the requested shell.
+// Usage: <app-name> completion <bash|zsh|fish|powershell>
+func CreateCompletionCommand(app App) Command {
+	return Command{
+		Name:        "completion",
+		Description: "Generate a shell completion script.",
+		Arguments: []Argument{{
+			Name:        "shell",
+			Description: "The shell to generate the completion script for. Supported shells: " + strings.Join(supportedCompletionShells, ", ") + ".",
+		}},
+		Action: func(c *Context) error {
+			if len(c.Arguments) != 1 {
+				return errorutils.CheckErrorf("wrong number of arguments (%d). Expected a shell name: %s", len(c.Arguments), strings.Join(supportedCompletionShells, ", "))
+			}
+			script, err := GenerateCompletionScript(app, c.Arguments[0])
+			if err != nil {
+				return err
+			}
+			log.Output(script)
+			return nil
+		},
+	}
+}
+
+// Generate a completion script of the given shell for the app's commands, flags and enumerated flag values (see WithAllowedValues).
+// Hidden commands and flags are omitted.
+func GenerateCompletionScript(app App, shell string) (string, error) {
+	nodes := collectCompletionNodes(app)
+	switch shell {
+	case Bash:
+		return generateBashCompletion(app.Name, nodes), nil
+	case Zsh:
+		return "autoload -U +X bashcompinit && bashcompinit\n" + generateBashCompletion(app.Name, nodes), nil
+	case Fish:
+		return generateFishCompletion(app.Name, nodes), nil
+	case PowerShell:
+		return generatePowerShellCompletion(app.Name, nodes), nil
+	}
+	return "", errorutils.CheckErrorf("unsupported shell '%s'. Supported shells: %s", shell, strings.Join(supportedCompletionShells, ", "))
+}
+
+func collectCompletionNodes(app App) (nodes []*completionNode) {
+	root := newCompletionNode(app.Name)
+	nodes = append(nodes, root)
+	nodes = append(nodes, collectCommandsCompletionNodes(root, app.Commands, nil)...)
+	for _, ns := range app.Subcommands {
+		if ns.Hidden {
+			continue
+		}
+		root.addWord(ns.Name, ns.Description)
+		nsNode := newCompletionNode(root.path + " " + ns.Name)
+		nodes = append(nodes, nsNode)
+		nodes = append(nodes, collectCommandsCompletionNodes(nsNode, ns.Commands, nil)...)
+	}
+	root.addWord("--help", "Show help")
+	return
+}
+
+func collectCommandsCompletionNodes(parent *completionNode, commands []Command, parentFlags []Flag) (nodes []*completionNode) {
+	for _, cmd := range commands {
+		if cmd.Hidden {
+			continue
+		}
+		flags := cmd.Flags
+		if parentFlags != nil {
+			flags = inheritParentFlags([]Command{cmd}, parentFlags)[0].Flags
+		}
+		// Aliases get their own nodes, so that the command path is matched by any of the command names.
+		for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+			parent.addWord(name, cmd.Description)
+			cmdNode := newCompletionNode(parent.path + " " + name)
+			nodes = append(nodes, cmdNode)
+			nodes = append(nodes, collectCommandsCompletionNodes(cmdNode, cmd.Subcommands, flags)...)
+			addFlagsCompletionWords(cmdNode, flags)
+		}
+	}
+	return
+}
+
+func addFlagsCompletionWords(node *completionNode, flags []Flag) {
+	for _, flag := range flags {
+		if isHiddenFlag(flag) {
+			continue
+		}
+		word := "--" + flag.GetName()
+		node.addWord(word, flag.GetDescription())
+		if stringFlag, ok := flag.(StringFlag); ok && len(stringFlag.AllowedValues) > 0 {
+			node.flagValues[word] = stringFlag.AllowedValues
+		}
+	}
+	node.addWord("--help", "Show help")
+}
+
+func isHiddenFlag(flag Flag) bool {
+	baseFlag, ok := getBaseFlag(flag)
+	return ok && baseFlag.Hidden
+}
+
+var nonIdentifierCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+func generateBashCompletion(appName string, nodes []*completionNode) string {
+	funcName := "_" + nonIdentifierCharsRegex.ReplaceAllString(appName, "_") + "_completions"
+	var paths []string
+	for _, node := range nodes[1:] {
+		paths = append(paths, fmt.Sprintf("%q", node.path))
+	}
+	var script strings.Builder
+	fmt.Fprintf(&script, "# bash completion for %s\n", appName)
+	fmt.Fprintf(&script, "%s() {\n", funcName)
+	script.WriteString("    local cur path word candidate flag prefix opts i\n")
+	script.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(&script, "    path=%q\n", appName)
+	script.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	script.WriteString("        word=\"${COMP_WORDS[i]}\"\n")
+	script.WriteString("        candidate=\"$path $word\"\n")
+	if len(paths) > 0 {
+		script.WriteString("        case \"$candidate\" in\n")
+		fmt.Fprintf(&script, "            %s) path=\"$candidate\" ;;\n", strings.Join(paths, "|"))
+		script.WriteString("        esac\n")
+	}
+	script.WriteString("    done\n")
+	// The '=' character may be a word break, so '--flag=value' may be split to '--flag', '=' and 'value'.
+	script.WriteString("    flag=\"\"\n")
+	script.WriteString("    prefix=\"\"\n")
+	script.WriteString("    if [[ \"$cur\" == \"=\" ]]; then\n")
+	script.WriteString("        flag=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	script.WriteString("        cur=\"\"\n")
+	script.WriteString("    elif [[ \"$COMP_CWORD\" -gt 1 && \"${COMP_WORDS[COMP_CWORD-1]}\" == \"=\" ]]; then\n")
+	script.WriteString("        flag=\"${COMP_WORDS[COMP_CWORD-2]}\"\n")
+	script.WriteString("    elif [[ \"$cur\" == --*=* ]]; then\n")
+	script.WriteString("        flag=\"${cur%%=*}\"\n")
+	script.WriteString("        prefix=\"$flag=\"\n")
+	script.WriteString("        cur=\"${cur#*=}\"\n")
+	script.WriteString("    fi\n")
+	script.WriteString("    if [[ -n \"$flag\" ]]; then\n")
+	script.WriteString("        case \"$path $flag\" in\n")
+	for _, node := range nodes {
+		for _, word := range node.words {
+			if values, ok := node.flagValues[word]; ok {
+				fmt.Fprintf(&script, "            %q) opts=%q ;;\n", node.path+" "+word, strings.Join(values, " "))
+			}
+		}
+	}
+	script.WriteString("            *) return 0 ;;\n")
+	script.WriteString("        esac\n")
+	script.WriteString("        COMPREPLY=($(compgen -P \"$prefix\" -W \"$opts\" -- \"$cur\"))\n")
+	script.WriteString("        return 0\n")
+	script.WriteString("    fi\n")
+	script.WriteString("    case \"$path\" in\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&script, "        %q) opts=%q ;;\n", node.path, strings.Join(node.words, " "))
+	}
+	script.WriteString("    esac\n")
+	script.WriteString("    COMPREPLY=($(compgen -W \"$opts\" -- \"$cur\"))\n")
+	script.WriteString("}\n")
+	fmt.Fprintf(&script, "complete -F %s %s\n", funcName, appName)
+	return script.String()
+}
+
+func generateFishCompletion(appName string, nodes []*completionNode) string {
+	funcName := "__" + nonIdentifierCharsRegex.ReplaceAllString(appName, "_") + "_path"
+	var paths []string
+	for _, node := range nodes[1:] {
+		paths = append(paths, quoteFish(node.path))
+	}
+	var script strings.Builder
+	fmt.Fprintf(&script, "# fish completion for %s\n", appName)
+	fmt.Fprintf(&script, "function %s\n", funcName)
+	fmt.Fprintf(&script, "    set -l path %s\n", quoteFish(appName))
+	script.WriteString("    set -l tokens (commandline -opc)\n")
+	script.WriteString("    set -e tokens[1]\n")
+	script.WriteString("    for token in $tokens\n")
+	script.WriteString("        set -l candidate \"$path $token\"\n")
+	if len(paths) > 0 {
+		fmt.Fprintf(&script, "        contains -- $candidate %s; and set path $candidate\n", strings.Join(paths, " "))
+	}
+	script.WriteString("    end\n")
+	script.WriteString("    echo $path\n")
+	script.WriteString("end\n")
+	for _, node := range nodes {
+		condition := quoteFish(fmt.Sprintf("test (%s) = %s", funcName, quoteFish(node.path)))
+		for _, word := range node.words {
+			description := quoteFish(node.descriptions[word])
+			flagName, isFlag := strings.CutPrefix(word, "--")
+			if !isFlag {
+				fmt.Fprintf(&script, "complete -c %s -n %s -a %s -d %s\n", appName, condition, quoteFish(word), description)
+				continue
+			}
+			if values, ok := node.flagValues[word]; ok {
+				fmt.Fprintf(&script, "complete -c %s -n %s -l %s -x -a %s -d %s\n", appName, condition, flagName, quoteFish(strings.Join(values, " ")), description)
+				continue
+			}
+			fmt.Fprintf(&script, "complete -c %s -n %s -l %s -d %s\n", appName, condition, flagName, description)
+		}
+	}
+	return script.String()
+}
+
+func quoteFish(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+func generatePowerShellCompletion(appName string, nodes []*completionNode) string {
+	var script strings.Builder
+	fmt.Fprintf(&script, "# powershell completion for %s\n", appName)
+	fmt.Fprintf(&script, "Register-ArgumentCompleter -Native -CommandName %s -ScriptBlock {\n", quotePowerShell(appName))
+	script.WriteString("    param($wordToComplete, $commandAst, $cursorPosition)\n")
+	script.WriteString("    $completions = @{\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&script, "        %s = @(%s)\n", quotePowerShell(node.path), joinPowerShell(node.words))
+	}
+	script.WriteString("    }\n")
+	script.WriteString("    $flagValues = @{\n")
+	for _, node := range nodes {
+		for _, word := range node.words {
+			if values, ok := node.flagValues[word]; ok {
+				fmt.Fprintf(&script, "        %s = @(%s)\n", quotePowerShell(node.path+" "+word), joinPowerShell(values))
+			}
+		}
+	}
+	script.WriteString("    }\n")
+	fmt.Fprintf(&script, "    $path = %s\n", quotePowerShell(appName))
+	script.WriteString("    $elements = @($commandAst.CommandElements | Select-Object -Skip 1)\n")
+	script.WriteString("    if ($wordToComplete) { $elements = @($elements | Select-Object -SkipLast 1) }\n")
+	script.WriteString("    foreach ($element in $elements) {\n")
+	script.WriteString("        $candidate = \"$path $($element.ToString())\"\n")
+	script.WriteString("        if ($completions.ContainsKey($candidate)) { $path = $candidate }\n")
+	script.WriteString("    }\n")
+	script.WriteString("    if ($wordToComplete -match '^(--[^=]+)=(.*)$') {\n")
+	script.WriteString("        $flag = $Matches[1]\n")
+	script.WriteString("        $value = $Matches[2]\n")
+	script.WriteString("        $key = \"$path $flag\"\n")
+	script.WriteString("        if ($flagValues.ContainsKey($key)) {\n")
+	script.WriteString("            $flagValues[$key] | Where-Object { $_ -like \"$value*\" } | ForEach-Object {\n")
+	script.WriteString("                [System.Management.Automation.CompletionResult]::new(\"$flag=$_\", $_, 'ParameterValue', $_)\n")
+	script.WriteString("            }\n")
+	script.WriteString("        }\n")
+	script.WriteString("        return\n")
+	script.WriteString("    }\n")
+	script.WriteString("    $completions[$path] | Where-Object { $_ -like \"$wordToComplete*\" } | ForEach-Object {\n")
+	script.WriteString("        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)\n")
+	script.WriteString("    }\n")
+	script.WriteString("}\n")
+	return script.String()
+}
+
+func quotePowerShell(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func joinPowerShell(values []string) string {
+	quoted := slices.Clone(values)
+	for i, value := range quoted {
+		quoted[i] = quotePowerShell(value)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,84 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createCompletionTestApp() App {
+	app := CreateApp("test-app", "v1.0.0", "", []Command{
+		{
+			Name:    "group",
+			Aliases: []string{"g"},
+			Flags:   []Flag{NewStringFlag("parent-flag", "Parent's flag.")},
+			Subcommands: []Command{
+				{
+					Name: "action",
+					Flags: []Flag{
+						NewStringFlag("mode", "Mode.", WithAllowedValues("strict", "skip", "warn")),
+						NewBoolFlag("hidden-flag", "", SetHiddenBoolFlag()),
+					},
+				},
+			},
+		},
+		{Name: "hidden-cmd", Hidden: true},
+	})
+	app.Subcommands = []Namespace{{Name: "ns", Commands: []Command{{Name: "ns-cmd"}}}}
+	return app
+}
+
+func TestCollectCompletionNodes(t *testing.T) {
+	nodes := collectCompletionNodes(createCompletionTestApp())
+	nodesByPath := map[string]*completionNode{}
+	for _, node := range nodes {
+		nodesByPath[node.path] = node
+	}
+	assert.Len(t, nodes, 7)
+	assert.Equal(t, []string{"group", "g", "ns", "--help"}, nodesByPath["test-app"].words)
+	assert.Equal(t, []string{"action", "--parent-flag", "--help"}, nodesByPath["test-app group"].words)
+	assert.Equal(t, []string{"action", "--parent-flag", "--help"}, nodesByPath["test-app g"].words)
+	assert.Equal(t, []string{"--mode", "--parent-flag", "--help"}, nodesByPath["test-app group action"].words)
+	assert.Equal(t, []string{"strict", "skip", "warn"}, nodesByPath["test-app group action"].flagValues["--mode"])
+	assert.Equal(t, []string{"ns-cmd"}, nodesByPath["test-app ns"].words)
+	assert.Equal(t, []string{"--help"}, nodesByPath["test-app ns ns-cmd"].words)
+}
+
+func TestGenerateCompletionScript(t *testing.T) {
+	app := createCompletionTestApp()
+	tests := []struct {
+		shell    string
+		expected []string
+	}{
+		{Bash, []string{
+			"complete -F _test_app_completions test-app",
+			`"test-app group action") opts="--mode --parent-flag --help" ;;`,
+			`"test-app group action --mode") opts="strict skip warn" ;;`,
+		}},
+		{Zsh, []string{"bashcompinit", "complete -F _test_app_completions test-app"}},
+		{Fish, []string{
+			"function __test_app_path",
+			`complete -c test-app -n 'test (__test_app_path) = \'test-app group\'' -a 'action' -d ''`,
+			`complete -c test-app -n 'test (__test_app_path) = \'test-app group action\'' -l mode -x -a 'strict skip warn' -d 'Mode.'`,
+			`-l parent-flag -d 'Parent\'s flag.'`,
+		}},
+		{PowerShell, []string{
+			"Register-ArgumentCompleter -Native -CommandName 'test-app'",
+			"'test-app group action' = @('--mode', '--parent-flag', '--help')",
+			"'test-app group action --mode' = @('strict', 'skip', 'warn')",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.shell, func(t *testing.T) {
+			script, err := GenerateCompletionScript(app, test.shell)
+			assert.NoError(t, err)
+			for _, expected := range test.expected {
+				assert.Contains(t, script, expected)
+			}
+			assert.NotContains(t, script, "hidden")
+		})
+	}
+
+	_, err := GenerateCompletionScript(app, "tcsh")
+	assert.ErrorContains(t, err, "unsupported shell")
+}
@@ -3,11 +3,16 @@ package components
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/jfrog/gofrog/datastructures"
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
 	"github.com/jfrog/jfrog-cli-core/v2/docs/common"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
 )
 
@@ -27,11 +32,16 @@ func ConvertApp(jfrogApp App) (*cli.App, error) {
 }
 
 func ConvertAppCommands(jfrogApp App, commandPrefix ...string) (cmds []cli.Command, err error) {
-	cmds, err = convertCommands(jfrogApp.Commands, commandPrefix...)
+	cmds, err = convertCommands(applyAppHooks(jfrogApp, jfrogApp.Commands), commandPrefix...)
 	if err != nil || len(jfrogApp.Subcommands) == 0 {
 		return
 	}
-	subcommands, err := convertSubcommands(jfrogApp.Subcommands, commandPrefix...)
+	namespaces := make([]Namespace, 0, len(jfrogApp.Subcommands))
+	for _, ns := range jfrogApp.Subcommands {
+		ns.Commands = applyAppHooks(jfrogApp, ns.Commands)
+		namespaces = append(namespaces, ns)
+	}
+	subcommands, err := convertSubcommands(namespaces, commandPrefix...)
 	if err != nil {
 		return
 	}
@@ -39,6 +49,42 @@ func ConvertAppCommands(jfrogApp App, commandPrefix ...string) (cmds []cli.Comma
 	return
 }
 
+// Wrap the actions of the commands and their subcommands with the app's BeforeCommand, AfterCommand and OnError hooks.
+func applyAppHooks(jfrogApp App, commands []Command) []Command {
+	if jfrogApp.BeforeCommand == nil && jfrogApp.AfterCommand == nil && jfrogApp.OnError == nil {
+		return commands
+	}
+	wrapped := make([]Command, 0, len(commands))
+	for _, cmd := range commands {
+		if cmd.Action != nil {
+			cmd.Action = wrapActionWithHooks(jfrogApp, cmd.Action)
+		}
+		cmd.Subcommands = applyAppHooks(jfrogApp, cmd.Subcommands)
+		wrapped = append(wrapped, cmd)
+	}
+	return wrapped
+}
+
+func wrapActionWithHooks(jfrogApp App, action ActionFunc) ActionFunc {
+	return func(c *Context) (err error) {
+		defer func() {
+			if err != nil && jfrogApp.OnError != nil {
+				err = jfrogApp.OnError(c, err)
+			}
+		}()
+		if jfrogApp.BeforeCommand != nil {
+			if err = jfrogApp.BeforeCommand(c); err != nil {
+				return
+			}
+		}
+		err = action(c)
+		if jfrogApp.AfterCommand != nil {
+			err = errors.Join(err, jfrogApp.AfterCommand(c, err))
+		}
+		return
+	}
+}
+
 func convertSubcommands(subcommands []Namespace, nameSpaces ...string) ([]cli.Command, error) {
 	var converted []cli.Command
 	for _, ns := range subcommands {
@@ -97,9 +143,31 @@ func convertCommand(cmd Command, namespaces ...string) (cli.Command, error) {
 		// Passing any other interface than 'cli.ActionFunc' will fail the command.
 		cliCmd.Action = getActionFunc(cmd)
 	}
+	if len(cmd.Subcommands) > 0 {
+		cliCmd.Subcommands, err = convertCommands(inheritParentFlags(cmd.Subcommands, cmd.Flags), append(slices.Clone(namespaces), cmd.Name)...)
+		if err != nil {
+			return cli.Command{}, err
+		}
+	}
 	return cliCmd, nil
 }
 
+// Add the parent command's flags to each of its subcommands, unless the subcommand defines a flag with the same name.
+func inheritParentFlags(subcommands []Command, parentFlags []Flag) []Command {
+	inherited := make([]Command, 0, len(subcommands))
+	for _, subcommand := range subcommands {
+		subcommandFlags := slices.Clone(subcommand.Flags)
+		for _, parentFlag := range parentFlags {
+			if !slices.ContainsFunc(subcommand.Flags, func(flag Flag) bool { return flag.GetName() == parentFlag.GetName() }) {
+				subcommandFlags = append(subcommandFlags, parentFlag)
+			}
+		}
+		subcommand.Flags = subcommandFlags
+		inherited = append(inherited, subcommand)
+	}
+	return inherited
+}
+
 func removeEmptyValues(slice []string) []string {
 	var result []string
 	for _, s := range slice {
@@ -304,6 +372,9 @@ func createEnvVarsSummary(cmd Command) string {
 }
 
 func convertFlags(cmd Command) ([]cli.Flag, map[string]StringFlag, error) {
+	if err := validateFlagConstraintsNames(cmd); err != nil {
+		return nil, nil, err
+	}
 	var convertedFlags []cli.Flag
 	convertedStringFlags := map[string]StringFlag{}
 	for _, flag := range cmd.Flags {
@@ -321,6 +392,38 @@ func convertFlags(cmd Command) ([]cli.Flag, map[string]StringFlag, error) {
 	return convertedFlags, convertedStringFlags, nil
 }
 
+// Verify that the flag constraints only refer to flags that the command defines.
+func validateFlagConstraintsNames(cmd Command) error {
+	flags := cmd.Flags
+	for _, flag := range flags {
+		baseFlag, ok := getBaseFlag(flag)
+		if !ok {
+			continue
+		}
+		for _, constraint := range [][]string{baseFlag.MutuallyExclusiveWith, baseFlag.RequiredWith, baseFlag.RequiredIfSet} {
+			for _, flagName := range constraint {
+				if !slices.ContainsFunc(flags, func(f Flag) bool { return f.GetName() == flagName }) {
+					return errorutils.CheckErrorf("command '%s': flag '%s' has a constraint on an unknown flag '%s'", cmd.Name, flag.GetName(), flagName)
+				}
+			}
+		}
+		if baseFlag.ReplacedBy != "" && !slices.ContainsFunc(flags, func(f Flag) bool { return f.GetName() == baseFlag.ReplacedBy }) {
+			return errorutils.CheckErrorf("command '%s': flag '%s' is replaced by an unknown flag '%s'", cmd.Name, flag.GetName(), baseFlag.ReplacedBy)
+		}
+	}
+	return nil
+}
+
+func getBaseFlag(flag Flag) (BaseFlag, bool) {
+	switch actualType := flag.(type) {
+	case StringFlag:
+		return actualType.BaseFlag, true
+	case BoolFlag:
+		return actualType.BaseFlag, true
+	}
+	return BaseFlag{}, false
+}
+
 func convertByType(flag Flag) (cli.Flag, *StringFlag, error) {
 	switch actualType := flag.(type) {
 	case StringFlag:
@@ -336,6 +439,7 @@ func convertStringFlag(f StringFlag) cli.Flag {
 		Name:   f.Name,
 		Hidden: f.Hidden,
 		Usage:  f.Description + "` `",
+		EnvVar: strings.Join(f.EnvVars, ","),
 	}
 	// If default is set, add its value and return.
 	if f.DefaultValue != "" {
@@ -357,12 +461,14 @@ func convertBoolFlag(f BoolFlag) cli.Flag {
 			Name:   f.Name,
 			Hidden: f.Hidden,
 			Usage:  "[Default: true] " + f.Description + "` `",
+			EnvVar: strings.Join(f.EnvVars, ","),
 		}
 	}
 	return cli.BoolFlag{
 		Name:   f.Name,
 		Hidden: f.Hidden,
 		Usage:  "[Default: false] " + f.Description + "` `",
+		EnvVar: strings.Join(f.EnvVars, ","),
 	}
 }
 
@@ -373,6 +479,12 @@ func getActionFunc(cmd Command) cli.ActionFunc {
 		if err != nil {
 			return err
 		}
+		if err = handleDeprecatedFlags(baseContext, pluginContext, cmd.Flags); err != nil {
+			return err
+		}
+		if err = validateFlagConstraints(baseContext, cmd.Flags); err != nil {
+			return err
+		}
 		return cmd.Action(pluginContext)
 	}
 }
@@ -386,6 +498,77 @@ func ConvertContext(baseContext *cli.Context, flagsToConvert ...Flag) (*Context,
 	return pluginContext, fillFlagMaps(pluginContext, baseContext, flagsToConvert)
 }
 
+// Enforce the MutuallyExclusiveWith, RequiredWith and RequiredIfSet constraints of the flags.
+// A flag is considered set if it was provided in the command line or through one of its environment variables.
+func validateFlagConstraints(baseContext *cli.Context, flags []Flag) error {
+	for _, flag := range flags {
+		baseFlag, ok := getBaseFlag(flag)
+		if !ok {
+			continue
+		}
+		isSet := baseContext.IsSet(baseFlag.Name)
+		for _, flagName := range baseFlag.MutuallyExclusiveWith {
+			if isSet && baseContext.IsSet(flagName) {
+				return errorutils.CheckErrorf("flags '%s' and '%s' cannot be used together", baseFlag.Name, flagName)
+			}
+		}
+		for _, flagName := range baseFlag.RequiredWith {
+			if isSet && !baseContext.IsSet(flagName) {
+				return errorutils.CheckErrorf("flag '%s' requires flag '%s' to be set", baseFlag.Name, flagName)
+			}
+		}
+		for _, flagName := range baseFlag.RequiredIfSet {
+			if !isSet && baseContext.IsSet(flagName) {
+				return errorutils.CheckErrorf("flag '%s' is mandatory when flag '%s' is set", baseFlag.Name, flagName)
+			}
+		}
+	}
+	return nil
+}
+
+// Deprecated flags which were already warned about, to log each warning once.
+var deprecationWarnings sync.Map
+
+// Warn about deprecated flags in use, and pass their values to their replacing flags.
+// In strict mode, using a deprecated flag returns an error instead.
+func handleDeprecatedFlags(baseContext *cli.Context, c *Context, flags []Flag) error {
+	for _, flag := range flags {
+		baseFlag, ok := getBaseFlag(flag)
+		if !ok || !baseFlag.Deprecated || !baseContext.IsSet(baseFlag.Name) {
+			continue
+		}
+		msg := fmt.Sprintf("The '--%s' option is deprecated", baseFlag.Name)
+		if baseFlag.ReplacedBy != "" {
+			msg += fmt.Sprintf(". Use the '--%s' option instead", baseFlag.ReplacedBy)
+		}
+		strict, err := clientutils.GetBoolEnvValue(cliutils.JfrogCliStrictDeprecation, false)
+		if err != nil {
+			return err
+		}
+		if strict {
+			return errorutils.CheckErrorf("%s. Deprecated options are not allowed when the %s environment variable is set to true", msg, cliutils.JfrogCliStrictDeprecation)
+		}
+		if _, warned := deprecationWarnings.LoadOrStore(baseFlag.Name, true); !warned && cliutils.ShouldLogWarning() {
+			log.Warn(msg + ".")
+		}
+		if baseFlag.ReplacedBy == "" || baseContext.IsSet(baseFlag.ReplacedBy) {
+			continue
+		}
+		if _, isBool := flag.(BoolFlag); isBool {
+			c.AddBoolFlag(baseFlag.ReplacedBy, c.GetBoolFlagValue(baseFlag.Name))
+		} else {
+			c.AddStringFlag(baseFlag.ReplacedBy, c.GetStringFlagValue(baseFlag.Name))
+		}
+		c.flagsMutex.Lock()
+		if c.explicitFlags == nil {
+			c.explicitFlags = make(map[string]bool)
+		}
+		c.explicitFlags[baseFlag.ReplacedBy] = true
+		c.flagsMutex.Unlock()
+	}
+	return nil
+}
+
 func getPrintCommandHelpFunc(c *cli.Context) func(commandName string) error {
 	return func(commandName string) error {
 		return cli.ShowCommandHelp(c, c.Command.Name)
@@ -395,10 +578,16 @@ func getPrintCommandHelpFunc(c *cli.Context) func(commandName string) error {
 func fillFlagMaps(c *Context, baseContext *cli.Context, originalFlags []Flag) error {
 	c.stringFlags = make(map[string]string)
 	c.boolFlags = make(map[string]bool)
+	c.stringFlagsDefs = make(map[string]StringFlag)
+	c.explicitFlags = make(map[string]bool)
 
 	// Loop over all plugin's known flags.
 	for _, flag := range originalFlags {
+		if baseContext.IsSet(flag.GetName()) {
+			c.explicitFlags[flag.GetName()] = true
+		}
 		if stringFlag, ok := flag.(StringFlag); ok {
+			c.stringFlagsDefs[stringFlag.Name] = stringFlag
 			finalValue, err := getValueForStringFlag(stringFlag, baseContext)
 			if err != nil {
 				return err
@@ -417,6 +606,9 @@ func fillFlagMaps(c *Context, baseContext *cli.Context, originalFlags []Flag) er
 func getValueForStringFlag(f StringFlag, baseContext *cli.Context) (string, error) {
 	value := baseContext.String(f.Name)
 	if value != "" {
+		if len(f.AllowedValues) > 0 && !slices.Contains(f.AllowedValues, value) {
+			return "", errorutils.CheckErrorf("flag '%s' has an invalid value '%s'. Allowed values: %s", f.Name, value, strings.Join(f.AllowedValues, ", "))
+		}
 		return value, nil
 	}
 	if f.DefaultValue != "" {
@@ -1,10 +1,12 @@
 package components
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"testing"
 
+	"github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/urfave/cli"
 )
@@ -302,3 +304,266 @@ func (d DummyFlagValue) String() string {
 func (d DummyFlagValue) Set(value string) error {
 	return nil
 }
+
+func TestConvertContextWithEnvVars(t *testing.T) {
+	t.Setenv("JFROG_CLI_TEST_SECOND", "env-value")
+	t.Setenv("JFROG_CLI_TEST_BOOL", "true")
+	strFlag := NewStringFlag("str-flag", "", WithStrEnvVars("JFROG_CLI_TEST_FIRST", "JFROG_CLI_TEST_SECOND"))
+	boolFlag := NewBoolFlag("bool-flag", "", WithBoolEnvVars("JFROG_CLI_TEST_BOOL"))
+	createFlagSet := func() *flag.FlagSet {
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		for _, f := range []Flag{strFlag, boolFlag} {
+			converted, _, err := convertByType(f)
+			assert.NoError(t, err)
+			converted.Apply(flagSet)
+		}
+		return flagSet
+	}
+
+	// Not received, verify the environment variables are used.
+	pluginContext, err := ConvertContext(cli.NewContext(nil, createFlagSet(), nil), strFlag, boolFlag)
+	assert.NoError(t, err)
+	assert.Equal(t, "env-value", pluginContext.GetStringFlagValue("str-flag"))
+	assert.True(t, pluginContext.GetBoolFlagValue("bool-flag"))
+
+	// Received, verify the environment variables are ignored.
+	flagSet := createFlagSet()
+	assert.NoError(t, flagSet.Parse([]string{"--str-flag=cli-value", "--bool-flag=false"}))
+	pluginContext, err = ConvertContext(cli.NewContext(nil, flagSet, nil), strFlag, boolFlag)
+	assert.NoError(t, err)
+	assert.Equal(t, "cli-value", pluginContext.GetStringFlagValue("str-flag"))
+	assert.False(t, pluginContext.GetBoolFlagValue("bool-flag"))
+}
+
+func TestConvertAppWithNestedSubcommands(t *testing.T) {
+	var actionContext *Context
+	action := func(c *Context) error {
+		actionContext = c
+		return nil
+	}
+	app := CreateApp("test-app", "v1.0.0", "", []Command{
+		{
+			Name:  "group",
+			Flags: []Flag{NewStringFlag("parent-flag", ""), NewStringFlag("overridden-flag", "", WithStrDefaultValue("parent"))},
+			Subcommands: []Command{
+				{
+					Name:   "action",
+					Flags:  []Flag{NewBoolFlag("child-flag", ""), NewStringFlag("overridden-flag", "", WithStrDefaultValue("child"))},
+					Action: action,
+				},
+			},
+		},
+	})
+	baseApp, err := ConvertApp(app)
+	assert.NoError(t, err)
+	assert.Len(t, baseApp.Commands, 1)
+	assert.Len(t, baseApp.Commands[0].Subcommands, 1)
+	subcommand := baseApp.Commands[0].Subcommands[0]
+	assert.Equal(t, "action", subcommand.Name)
+	assert.Contains(t, subcommand.HelpName, "group action")
+	// The subcommand inherits the parent's flags, without overriding its own.
+	assert.Len(t, subcommand.Flags, 3)
+
+	// Verify routing of nested commands.
+	assert.NoError(t, baseApp.Run([]string{"test-app", "group", "action", "--parent-flag=value", "--child-flag", "arg"}))
+	if assert.NotNil(t, actionContext) {
+		assert.Equal(t, "value", actionContext.GetStringFlagValue("parent-flag"))
+		assert.Equal(t, "child", actionContext.GetStringFlagValue("overridden-flag"))
+		assert.True(t, actionContext.GetBoolFlagValue("child-flag"))
+		assert.Equal(t, []string{"arg"}, actionContext.Arguments)
+	}
+}
+
+func TestGetValueForStringFlagAllowedValues(t *testing.T) {
+	f := NewStringFlag("mode", "", WithAllowedValues("strict", "skip"))
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.String(f.Name, "", "")
+	baseContext := cli.NewContext(nil, flagSet, nil)
+
+	assert.NoError(t, flagSet.Set(f.Name, "skip"))
+	finalValue, err := getValueForStringFlag(f, baseContext)
+	assert.NoError(t, err)
+	assert.Equal(t, "skip", finalValue)
+
+	assert.NoError(t, flagSet.Set(f.Name, "warn"))
+	_, err = getValueForStringFlag(f, baseContext)
+	assert.ErrorContains(t, err, "Allowed values: strict, skip")
+}
+
+func TestValidateFlagConstraints(t *testing.T) {
+	flags := []Flag{
+		NewStringFlag("url", "", WithStrMutuallyExclusiveWith("server-id")),
+		NewStringFlag("server-id", ""),
+		NewStringFlag("user", "", WithStrRequiredWith("password")),
+		NewStringFlag("password", "", WithStrRequiredIfSet("user")),
+		NewBoolFlag("dry-run", "", WithBoolRequiredIfSet("detailed")),
+		NewBoolFlag("detailed", ""),
+	}
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{"no flags", []string{}, ""},
+		{"mutually exclusive one set", []string{"--url=http://localhost"}, ""},
+		{"mutually exclusive both set", []string{"--url=http://localhost", "--server-id=server"}, "flags 'url' and 'server-id' cannot be used together"},
+		{"required with missing", []string{"--password=pass"}, ""},
+		{"required with set", []string{"--user=admin", "--password=pass"}, ""},
+		{"required with and required if set missing", []string{"--user=admin"}, "flag 'user' requires flag 'password' to be set"},
+		{"required if set missing", []string{"--detailed"}, "flag 'dry-run' is mandatory when flag 'detailed' is set"},
+		{"required if set set", []string{"--detailed", "--dry-run=false"}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+			for _, f := range flags {
+				converted, _, err := convertByType(f)
+				assert.NoError(t, err)
+				converted.Apply(flagSet)
+			}
+			assert.NoError(t, flagSet.Parse(test.args))
+			err := validateFlagConstraints(cli.NewContext(nil, flagSet, nil), flags)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestConvertFlagsWithUnknownConstraint(t *testing.T) {
+	cmd := Command{Name: "test-command", Flags: []Flag{NewBoolFlag("dry-run", "", WithBoolMutuallyExclusiveWith("unknown"))}}
+	_, _, err := convertFlags(cmd)
+	assert.EqualError(t, err, "command 'test-command': flag 'dry-run' has a constraint on an unknown flag 'unknown'")
+}
+
+func TestWrapActionWithHooks(t *testing.T) {
+	actionErr := errors.New("action error")
+	beforeErr := errors.New("before error")
+	afterErr := errors.New("after error")
+	tests := []struct {
+		name          string
+		actionErr     error
+		beforeErr     error
+		afterErr      error
+		suppressError bool
+		expectedCalls []string
+		expectedError []error
+	}{
+		{"success", nil, nil, nil, false, []string{"before", "action", "after"}, nil},
+		{"action fails", actionErr, nil, nil, false, []string{"before", "action", "after", "on-error"}, []error{actionErr}},
+		{"before fails", nil, beforeErr, nil, false, []string{"before", "on-error"}, []error{beforeErr}},
+		{"after fails", actionErr, nil, afterErr, false, []string{"before", "action", "after", "on-error"}, []error{actionErr, afterErr}},
+		{"error suppressed", actionErr, nil, nil, true, []string{"before", "action", "after", "on-error"}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			app := App{
+				BeforeCommand: func(c *Context) error {
+					calls = append(calls, "before")
+					return test.beforeErr
+				},
+				AfterCommand: func(c *Context, err error) error {
+					calls = append(calls, "after")
+					assert.Equal(t, test.actionErr, err)
+					return test.afterErr
+				},
+				OnError: func(c *Context, err error) error {
+					calls = append(calls, "on-error")
+					if test.suppressError {
+						return nil
+					}
+					return err
+				},
+			}
+			action := wrapActionWithHooks(app, func(c *Context) error {
+				calls = append(calls, "action")
+				return test.actionErr
+			})
+			err := action(&Context{})
+			assert.Equal(t, test.expectedCalls, calls)
+			if len(test.expectedError) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expected := range test.expectedError {
+				assert.ErrorIs(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestApplyAppHooksToNestedCommands(t *testing.T) {
+	var hookCalls []string
+	app := CreateApp("test-app", "v1.0.0", "", []Command{
+		{
+			Name:        "group",
+			Subcommands: []Command{{Name: "action", Action: func(c *Context) error { return nil }}},
+		},
+	})
+	app.Subcommands = []Namespace{{Name: "ns", Commands: []Command{{Name: "ns-cmd", Action: func(c *Context) error { return nil }}}}}
+	app.BeforeCommand = func(c *Context) error {
+		hookCalls = append(hookCalls, c.CommandName)
+		return nil
+	}
+	cliApp, err := ConvertApp(app)
+	assert.NoError(t, err)
+	assert.NoError(t, cliApp.Run([]string{"test-app", "group", "action"}))
+	assert.NoError(t, cliApp.Run([]string{"test-app", "ns", "ns-cmd"}))
+	assert.Equal(t, []string{"action", "ns-cmd"}, hookCalls)
+}
+
+func TestHandleDeprecatedFlags(t *testing.T) {
+	flags := []Flag{
+		NewStringFlag("old-url", "", SetDeprecatedStrFlag("url")),
+		NewStringFlag("url", ""),
+		NewBoolFlag("old-dry-run", "", SetDeprecatedBoolFlag("dry-run")),
+		NewBoolFlag("dry-run", ""),
+		NewStringFlag("legacy", "", SetDeprecatedStrFlag("")),
+	}
+	tests := []struct {
+		name           string
+		args           []string
+		strict         bool
+		expectedUrl    string
+		expectedDryRun bool
+		expectedError  string
+	}{
+		{"not used", []string{"--url=http://new"}, true, "http://new", false, ""},
+		{"mapped to replacing flags", []string{"--old-url=http://old", "--old-dry-run"}, false, "http://old", true, ""},
+		{"replacing flag takes precedence", []string{"--old-url=http://old", "--url=http://new"}, false, "http://new", false, ""},
+		{"no replacing flag", []string{"--legacy=value"}, false, "", false, ""},
+		{"strict", []string{"--old-url=http://old"}, true, "", false, "The '--old-url' option is deprecated. Use the '--url' option instead. Deprecated options are not allowed"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(cliutils.JfrogCliStrictDeprecation, fmt.Sprint(test.strict))
+			flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+			for _, f := range flags {
+				converted, _, err := convertByType(f)
+				assert.NoError(t, err)
+				converted.Apply(flagSet)
+			}
+			assert.NoError(t, flagSet.Parse(test.args))
+			baseContext := cli.NewContext(nil, flagSet, nil)
+			pluginContext, err := ConvertContext(baseContext, flags...)
+			assert.NoError(t, err)
+
+			err = handleDeprecatedFlags(baseContext, pluginContext, flags)
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedUrl, pluginContext.GetStringFlagValue("url"))
+			assert.Equal(t, test.expectedDryRun, pluginContext.GetBoolFlagValue("dry-run"))
+		})
+	}
+}
+
+func TestConvertFlagsWithUnknownReplacingFlag(t *testing.T) {
+	cmd := Command{Name: "test-command", Flags: []Flag{NewStringFlag("old-url", "", SetDeprecatedStrFlag("url"))}}
+	_, _, err := convertFlags(cmd)
+	assert.EqualError(t, err, "command 'test-command': flag 'old-url' is replaced by an unknown flag 'url'")
+}
@@ -4,6 +4,14 @@ type App struct {
 	Version string
 	Namespace
 	Subcommands []Namespace
+	// Optional. Called before the action of each of the app's commands. Returning an error aborts the command.
+	BeforeCommand func(c *Context) error
+	// Optional. Called after the action of each of the app's commands, with the error returned from the action, if any.
+	// A returned error is joined to the action's error.
+	AfterCommand func(c *Context, actionErr error) error
+	// Optional. Called when one of the app's commands fails. The returned error replaces the original one,
+	// so it can be used to wrap the error, or to suppress it by returning nil.
+	OnError func(c *Context, err error) error
 }
 
 func CreateApp(name, version, description string, commands []Command) App {
@@ -48,6 +56,9 @@ type Command struct {
 	Action          ActionFunc
 	SkipFlagParsing bool
 	Hidden          bool
+	// Optional. Nested commands, used as: <cli-name> <command-name> <subcommand-name>.
+	// Subcommands inherit the flags of their parent command, unless they define a flag with the same name.
+	Subcommands []Command
 }
 
 type UsageOptions struct {
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"flag"
+	"io"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+// A components.Context for testing commands, which records the calls to PrintCommandHelp.
+type TestContext struct {
+	*components.Context
+	// The command names PrintCommandHelp was called with.
+	PrintedHelp []string
+}
+
+// Create a context for the command, as if it was executed with the given arguments, for example:
+//
+//	c, err := CreateContext(cmd, "--threads=5", "--dry-run", "source", "target")
+//
+// Flag values are resolved the same way as in a real execution, including default values,
+// environment variables and mandatory flags validation.
+func CreateContext(cmd components.Command, args ...string) (*TestContext, error) {
+	cliApp, err := components.ConvertApp(components.CreateApp("test-app", "", "", []components.Command{cmd}))
+	if err != nil {
+		return nil, err
+	}
+	cliCmd := cliApp.Commands[0]
+	flagSet := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	for _, cliFlag := range cliCmd.Flags {
+		cliFlag.Apply(flagSet)
+	}
+	if err = flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+	baseContext := cli.NewContext(cliApp, flagSet, nil)
+	baseContext.Command = cliCmd
+	pluginContext, err := components.ConvertContext(baseContext, cmd.Flags...)
+	if err != nil {
+		return nil, err
+	}
+	testContext := &TestContext{Context: pluginContext}
+	testContext.PrintCommandHelp = func(commandName string) error {
+		testContext.PrintedHelp = append(testContext.PrintedHelp, commandName)
+		return nil
+	}
+	return testContext, nil
+}
+
+// Replace the stdin with the given content, until the end of the test.
+func SetStdin(t *testing.T, content string) {
+	reader, writer, err := os.Pipe()
+	assert.NoError(t, err)
+	_, err = writer.WriteString(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	previousStdin := os.Stdin
+	os.Stdin = reader
+	t.Cleanup(func() {
+		os.Stdin = previousStdin
+		assert.NoError(t, reader.Close())
+	})
+}
+
+// Run the function while capturing everything written to the stdout and stderr, including the logs.
+func CaptureOutput(t *testing.T, run func() error) (stdout, stderr string, err error) {
+	stdoutReader, stdoutWriter, e := os.Pipe()
+	assert.NoError(t, e)
+	stderrReader, stderrWriter, e := os.Pipe()
+	assert.NoError(t, e)
+	previousStdout, previousStderr, previousLog := os.Stdout, os.Stderr, log.Logger
+	os.Stdout, os.Stderr = stdoutWriter, stderrWriter
+	// The logger should be recreated, to write to the replaced stdout and stderr.
+	log.SetLogger(log.NewLogger(log.GetLogger().GetLogLevel(), nil))
+	defer func() {
+		os.Stdout, os.Stderr = previousStdout, previousStderr
+		log.SetLogger(previousLog)
+	}()
+
+	// Read the pipes concurrently, to avoid blocking the function once a pipe's buffer is full.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	readPipe := func(reader *os.File, output *string) {
+		defer wg.Done()
+		content, readErr := io.ReadAll(reader)
+		assert.NoError(t, readErr)
+		*output = string(content)
+		assert.NoError(t, reader.Close())
+	}
+	go readPipe(stdoutReader, &stdout)
+	go readPipe(stderrReader, &stderr)
+
+	err = run()
+	assert.NoError(t, stdoutWriter.Close())
+	assert.NoError(t, stderrWriter.Close())
+	wg.Wait()
+	return
+}
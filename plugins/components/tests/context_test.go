@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/plugins/components"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCommand = components.Command{
+	Name: "test-command",
+	Flags: []components.Flag{
+		components.NewStringFlag("threads", "", components.WithIntDefaultValue(3)),
+		components.NewStringFlag("server-id", "", components.WithStrEnvVars("JFROG_CLI_TEST_SERVER_ID")),
+		components.NewBoolFlag("dry-run", ""),
+	},
+}
+
+func TestCreateContext(t *testing.T) {
+	t.Setenv("JFROG_CLI_TEST_SERVER_ID", "my-server")
+	c, err := CreateContext(testCommand, "--dry-run", "source", "target")
+	assert.NoError(t, err)
+	assert.Equal(t, "test-command", c.CommandName)
+	assert.Equal(t, []string{"source", "target"}, c.Arguments)
+	assert.Equal(t, "3", c.GetStringFlagValue("threads"))
+	assert.Equal(t, "my-server", c.GetStringFlagValue("server-id"))
+	assert.True(t, c.GetBoolFlagValue("dry-run"))
+
+	assert.NoError(t, c.PrintCommandHelp(c.CommandName))
+	assert.Equal(t, []string{"test-command"}, c.PrintedHelp)
+
+	_, err = CreateContext(testCommand, "--unknown-flag")
+	assert.Error(t, err)
+}
+
+func TestSetStdinAndCaptureOutput(t *testing.T) {
+	SetStdin(t, "user input\n")
+	stdout, stderr, err := CaptureOutput(t, func() error {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Scan()
+		fmt.Println("read:", scanner.Text())
+		log.Warn("a warning")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "read: user input\n", stdout)
+	assert.Contains(t, stderr, "a warning")
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/auth/cert"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var pkcs12Extensions = []string{".p12", ".pfx"}
+
+// Returns true if the client certificate of the server is a PKCS#12 archive, which contains both the certificate and its private key.
+func (serverDetails *ServerDetails) IsPkcs12ClientCert() bool {
+	extension := strings.ToLower(filepath.Ext(serverDetails.ClientCertPath))
+	for _, pkcs12Extension := range pkcs12Extensions {
+		if extension == pkcs12Extension {
+			return true
+		}
+	}
+	return false
+}
+
+// Load the client certificate of the server for mutual TLS, from either PEM files or a PKCS#12 archive.
+func (serverDetails *ServerDetails) LoadClientCertificate() (tls.Certificate, error) {
+	if serverDetails.IsPkcs12ClientCert() {
+		return loadPkcs12Certificate(serverDetails.ClientCertPath, serverDetails.ClientCertPassword)
+	}
+	return cert.LoadCertificate(serverDetails.ClientCertPath, serverDetails.ClientCertKeyPath)
+}
+
+// Returns an error if the client certificate of the server is a PKCS#12 archive which can't be loaded,
+// so that a wrong password is reported when the server is configured, rather than on its first request.
+func (serverDetails *ServerDetails) ValidatePkcs12ClientCert() error {
+	if !serverDetails.IsPkcs12ClientCert() {
+		return nil
+	}
+	_, err := serverDetails.LoadClientCertificate()
+	return err
+}
+
+func loadPkcs12Certificate(path, password string) (tls.Certificate, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return tls.Certificate{}, errorutils.CheckError(err)
+	}
+	privateKey, leaf, caCerts, err := pkcs12.DecodeChain(content, password)
+	if err != nil {
+		return tls.Certificate{}, errorutils.CheckErrorf("failed to decode the PKCS#12 client certificate '%s': %s", path, err.Error())
+	}
+	certificate := tls.Certificate{Certificate: [][]byte{leaf.Raw}, PrivateKey: privateKey, Leaf: leaf}
+	for _, caCert := range caCerts {
+		certificate.Certificate = append(certificate.Certificate, caCert.Raw)
+	}
+	return certificate, nil
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pkcs12ClientCertPath = filepath.Join("testdata", "config", "clientcert", "client.p12")
+
+func TestIsPkcs12ClientCert(t *testing.T) {
+	testCases := []struct {
+		clientCertPath string
+		expected       bool
+	}{
+		{"", false},
+		{"client.pem", false},
+		{"client.crt", false},
+		{"client.p12", true},
+		{"CLIENT.PFX", true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.clientCertPath, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, (&ServerDetails{ClientCertPath: testCase.clientCertPath}).IsPkcs12ClientCert())
+		})
+	}
+}
+
+func TestLoadPkcs12ClientCertificate(t *testing.T) {
+	// client-aes.p12 is encrypted with the OpenSSL 3 defaults - AES-256 and PBKDF2.
+	for _, clientCertPath := range []string{pkcs12ClientCertPath, filepath.Join("testdata", "config", "clientcert", "client-aes.p12")} {
+		t.Run(filepath.Base(clientCertPath), func(t *testing.T) {
+			serverDetails := &ServerDetails{ClientCertPath: clientCertPath, ClientCertPassword: "password"}
+			certificate, err := serverDetails.LoadClientCertificate()
+			require.NoError(t, err)
+			// The archive contains the CA certificate too, which follows the leaf certificate.
+			assert.Len(t, certificate.Certificate, 2)
+			require.NotNil(t, certificate.Leaf)
+			assert.Equal(t, "client", certificate.Leaf.Subject.CommonName)
+			assert.NoError(t, serverDetails.ValidatePkcs12ClientCert())
+
+			serverDetails.ClientCertPassword = "wrong"
+			assert.Error(t, serverDetails.ValidatePkcs12ClientCert())
+		})
+	}
+}
+
+func TestPkcs12ClientCertAuthConfig(t *testing.T) {
+	serverDetails := &ServerDetails{
+		ArtifactoryUrl:     "https://acme.jfrog.io/artifactory/",
+		XrayUrl:            "https://acme.jfrog.io/xray/",
+		ClientCertPath:     pkcs12ClientCertPath,
+		ClientCertPassword: "password",
+	}
+	// The Artifactory services manager uses the custom HTTP client, which loads the PKCS#12 certificate.
+	artAuth, err := serverDetails.CreateArtAuthConfig()
+	require.NoError(t, err)
+	assert.Empty(t, artAuth.GetClientCertPath())
+	httpClient, err := serverDetails.GetCustomHttpClient("", 0)
+	require.NoError(t, err)
+	assert.NotNil(t, httpClient)
+
+	// The other services managers use the PKCS#12 certificate through the transport of their HTTP client.
+	xrayAuth, err := serverDetails.CreateXrayAuthConfig()
+	require.NoError(t, err)
+	assert.Empty(t, xrayAuth.GetClientCertPath())
+	serverDetails.Url = "https://acme.jfrog.io/"
+	accessManager, err := createAccessTokensServiceManager(serverDetails)
+	require.NoError(t, err)
+	transport, ok := accessManager.Client().GetHttpClient().GetClient().Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Len(t, transport.TLSClientConfig.Certificates, 1)
+}
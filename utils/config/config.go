@@ -172,8 +172,17 @@ func saveConfig(config *Config) error {
 	if err != nil {
 		return err
 	}
-	err = cloneConfig.encrypt()
-	if err != nil {
+	if cloneConfig.getCredentialStoreType() == KeyringCredentialStore {
+		// The secrets are moved to the keyring, so there's nothing to encrypt in the config file.
+		previousContent, err := getConfigFile()
+		if err != nil {
+			return err
+		}
+		if err = cloneConfig.storeSecretsInKeyring(getConfiguredServerIds(previousContent)); err != nil {
+			return err
+		}
+		cloneConfig.Enc = false
+	} else if err = cloneConfig.encrypt(); err != nil {
 		return err
 	}
 
@@ -214,6 +223,9 @@ func readConf() (*Config, error) {
 		return nil, errorutils.CheckError(err)
 	}
 
+	if config.getCredentialStoreType() == KeyringCredentialStore {
+		return config, config.loadSecretsFromKeyring()
+	}
 	err = config.decrypt()
 	return config, err
 }
@@ -514,6 +526,7 @@ type Config struct {
 
 type ConfigV6 struct {
 	ConfigV5
+	CredentialStore CredentialStoreType `json:"credentialStore,omitempty"`
 }
 
 type ConfigV5 struct {
@@ -593,10 +606,22 @@ type ServerDetails struct {
 	ArtifactoryTokenRefreshInterval int    `json:"tokenRefreshInterval,omitempty"`
 	ClientCertPath                  string `json:"clientCertPath,omitempty"`
 	ClientCertKeyPath               string `json:"clientCertKeyPath,omitempty"`
+	ClientCertPassword              string `json:"clientCertPassword,omitempty"`
 	ServerId                        string `json:"serverId,omitempty"`
 	IsDefault                       bool   `json:"isDefault,omitempty"`
 	InsecureTls                     bool   `json:"-"`
 	WebLogin                        bool   `json:"webLogin,omitempty"`
+	// The CI provider whose OIDC ID tokens are exchanged for the access token, which is re-exchanged when it expires.
+	OidcProvider string `json:"oidcProvider,omitempty"`
+	// The name of the OIDC integration in the JFrog Platform.
+	OidcIntegration string `json:"oidcIntegration,omitempty"`
+	OidcAudience    string `json:"oidcAudience,omitempty"`
+	// The proxies of the requests to Artifactory, which override the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	// The requests to the other services use the environment variables, since their clients are created by jfrog-client-go.
+	HttpProxy  string `json:"httpProxy,omitempty"`
+	HttpsProxy string `json:"httpsProxy,omitempty"`
+	// Comma-separated hosts, domains and CIDRs, which are accessed without a proxy.
+	NoProxy string `json:"noProxy,omitempty"`
 }
 
 // Deprecated
@@ -778,20 +803,28 @@ func (serverDetails *ServerDetails) createAuthConfig(details auth.ServiceDetails
 	// If refresh token is not empty, set a refresh handler and skip other credentials.
 	// First we check access's token, if empty we check artifactory's token.
 	switch {
+	case serverDetails.OidcProvider != "":
+		// The interceptor re-exchanges the token of the server if needed. If the serverId is empty, default will be used.
+		tokenRefreshServerId = serverDetails.ServerId
+		details.AppendPreRequestFunction(newTokenRefreshPreRequestInterceptor(serverDetails.ServerId, OidcToken))
 	case serverDetails.RefreshToken != "":
-		// Save serverId for refreshing if needed. If empty serverId is saved, default will be used.
+		// The interceptor refreshes the token of the server if needed. If the serverId is empty, default will be used.
 		tokenRefreshServerId = serverDetails.ServerId
-		details.AppendPreRequestFunction(AccessTokenRefreshPreRequestInterceptor)
+		details.AppendPreRequestFunction(newTokenRefreshPreRequestInterceptor(serverDetails.ServerId, AccessToken))
 	case serverDetails.ArtifactoryRefreshToken != "":
-		// Save serverId for refreshing if needed. If empty serverId is saved, default will be used.
+		// The interceptor refreshes the token of the server if needed. If the serverId is empty, default will be used.
 		tokenRefreshServerId = serverDetails.ServerId
-		details.AppendPreRequestFunction(ArtifactoryTokenRefreshPreRequestInterceptor)
+		details.AppendPreRequestFunction(newTokenRefreshPreRequestInterceptor(serverDetails.ServerId, ArtifactoryToken))
 	default:
 		details.SetUser(serverDetails.User)
 		details.SetPassword(serverDetails.Password)
 	}
-	details.SetClientCertPath(serverDetails.ClientCertPath)
-	details.SetClientCertKeyPath(serverDetails.ClientCertKeyPath)
+	// jfrog-client-go loads PEM client certificates only.
+	// A PKCS#12 certificate is loaded by the transport set by GetCustomHttpClient or SetCustomHttpTransport.
+	if !serverDetails.IsPkcs12ClientCert() {
+		details.SetClientCertPath(serverDetails.ClientCertPath)
+		details.SetClientCertKeyPath(serverDetails.ClientCertKeyPath)
+	}
 	details.SetSshKeyPath(serverDetails.SshKeyPath)
 	details.SetSshPassphrase(serverDetails.SshPassphrase)
 	return details, nil
@@ -239,7 +239,7 @@ func TestConfigEncryptionEnvVarUpdate(t *testing.T) {
 }
 
 func createEncryptionTestConfig() *Config {
-	return &Config{ConfigV6{ConfigV5{
+	return &Config{ConfigV6{ConfigV5: ConfigV5{
 		Version: strconv.Itoa(coreutils.GetCliConfigVersion()),
 		Servers: []*ServerDetails{{
 			ServerId:      "test-server",
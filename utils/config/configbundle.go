@@ -0,0 +1,153 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/crypto/scrypt"
+)
+
+// A config bundle contains the configurations of all the servers, including their secrets, encrypted with a passphrase.
+// It's used for moving the config between machines, or for provisioning containers.
+const (
+	bundleVersion = 1
+	bundleKdf     = "scrypt"
+	bundleCipher  = "aes-256-gcm"
+	// The scrypt parameters recommended for interactive logins, which take about 100ms to derive the key.
+	bundleScryptN = 1 << 15
+	bundleScryptR = 8
+	bundleScryptP = 1
+	// Limit the memory and time of deriving the key of a bundle, since its parameters are read from the bundle.
+	bundleMaxScryptN  = 1 << 20
+	bundleMaxScryptRP = 16
+	bundleSaltLen     = 16
+	bundleKeyLen      = 32
+
+	MinBundlePassphraseLength = 8
+)
+
+type configBundle struct {
+	Version int    `json:"version"`
+	Kdf     string `json:"kdf"`
+	ScryptN int    `json:"scryptN"`
+	ScryptR int    `json:"scryptR"`
+	ScryptP int    `json:"scryptP"`
+	Salt    []byte `json:"salt"`
+	Cipher  string `json:"cipher"`
+	// The nonce, followed by the encrypted JSON of the servers.
+	Data []byte `json:"data"`
+}
+
+// Export the configurations of all the servers to a bundle, encrypted with the passphrase.
+func ExportBundle(passphrase string) ([]byte, error) {
+	if len(passphrase) < MinBundlePassphraseLength {
+		return nil, errorutils.CheckErrorf("the passphrase of the config bundle must be at least %d characters long", MinBundlePassphraseLength)
+	}
+	conf, err := readConf()
+	if err != nil {
+		return nil, err
+	}
+	if len(conf.Servers) == 0 {
+		return nil, errorutils.CheckErrorf("cannot export config, because it is empty. Run 'jf c add' and then export again")
+	}
+	if err = verifyMasterKeyIfEncrypted(conf); err != nil {
+		return nil, err
+	}
+	servers, err := json.Marshal(conf.Servers)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	bundle := &configBundle{
+		Version: bundleVersion,
+		Kdf:     bundleKdf,
+		ScryptN: bundleScryptN,
+		ScryptR: bundleScryptR,
+		ScryptP: bundleScryptP,
+		Salt:    make([]byte, bundleSaltLen),
+		Cipher:  bundleCipher,
+	}
+	if _, err = io.ReadFull(rand.Reader, bundle.Salt); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	gcm, err := bundle.newGcm(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	bundle.Data = gcm.Seal(nonce, nonce, servers, nil)
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	return content, errorutils.CheckError(err)
+}
+
+// Decrypt the configurations of the servers in a bundle, which was exported with the passphrase.
+func DecryptBundle(content []byte, passphrase string) ([]*ServerDetails, error) {
+	bundle := new(configBundle)
+	if err := json.Unmarshal(content, bundle); err != nil {
+		return nil, errorutils.CheckErrorf("invalid config bundle: %s", err.Error())
+	}
+	if bundle.Version != bundleVersion || bundle.Kdf != bundleKdf || bundle.Cipher != bundleCipher || bundle.ScryptN > bundleMaxScryptN || bundle.ScryptR*bundle.ScryptP > bundleMaxScryptRP {
+		return nil, errorutils.CheckErrorf("unsupported config bundle: version %d, %s and %s", bundle.Version, bundle.Kdf, bundle.Cipher)
+	}
+	gcm, err := bundle.newGcm(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundle.Data) < gcm.NonceSize() {
+		return nil, errorutils.CheckErrorf("invalid config bundle: unexpected data size")
+	}
+	nonce, sealed := bundle.Data[:gcm.NonceSize()], bundle.Data[gcm.NonceSize():]
+	servers, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("cannot decrypt the config bundle: wrong passphrase, or the bundle was modified")
+	}
+	var serversDetails []*ServerDetails
+	if err = json.Unmarshal(servers, &serversDetails); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return serversDetails, nil
+}
+
+// Merge the configurations of the servers into the config. A configured server is replaced by the imported server with the same ID.
+// The default server remains the default, unless no server was configured before the import.
+func MergeServersConf(imported []*ServerDetails) error {
+	configurations, err := GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	defaultServerId := ""
+	for _, serverDetails := range configurations {
+		if serverDetails.IsDefault {
+			defaultServerId = serverDetails.ServerId
+		}
+	}
+	for _, serverDetails := range imported {
+		_, configurations = GetAndRemoveConfiguration(serverDetails.ServerId, configurations)
+	}
+	for _, serverDetails := range imported {
+		if defaultServerId != "" {
+			serverDetails.IsDefault = serverDetails.ServerId == defaultServerId
+		}
+		configurations = append(configurations, serverDetails)
+	}
+	return SaveServersConf(configurations)
+}
+
+func (bundle *configBundle) newGcm(passphrase string) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), bundle.Salt, bundle.ScryptN, bundle.ScryptR, bundle.ScryptP, bundleKeyLen)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	return gcm, errorutils.CheckError(err)
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigBundle(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+
+	exported := []*ServerDetails{
+		{ServerId: "first", Url: "https://first.jfrog.io/", AccessToken: "token", RefreshToken: "refresh", IsDefault: true},
+		{ServerId: "second", Url: "https://second.jfrog.io/", User: "user", Password: "password", ClientCertPath: "/certs/client.p12", ClientCertPassword: "cert-password"},
+	}
+	require.NoError(t, SaveServersConf(exported))
+	_, err := ExportBundle("short")
+	assert.ErrorContains(t, err, "at least")
+	bundle, err := ExportBundle("bundle-passphrase")
+	require.NoError(t, err)
+	assert.NotContains(t, string(bundle), "refresh")
+	assert.NotContains(t, string(bundle), "password")
+
+	_, err = DecryptBundle(bundle, "wrong-passphrase")
+	assert.ErrorContains(t, err, "wrong passphrase")
+	servers, err := DecryptBundle(bundle, "bundle-passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, exported, servers)
+
+	// Import into a config with another default server, which replaces the configured server with the same ID.
+	require.NoError(t, SaveServersConf([]*ServerDetails{
+		{ServerId: "second", Url: "https://old.jfrog.io/"},
+		{ServerId: "third", Url: "https://third.jfrog.io/", IsDefault: true},
+	}))
+	require.NoError(t, MergeServersConf(servers))
+	configurations, err := GetAllServersConfigs()
+	require.NoError(t, err)
+	require.Len(t, configurations, 3)
+	for _, serverDetails := range configurations {
+		assert.Equal(t, serverDetails.ServerId == "third", serverDetails.IsDefault, serverDetails.ServerId)
+	}
+	second, err := GetSpecificConfig("second", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "https://second.jfrog.io/", second.Url)
+	assert.Equal(t, "cert-password", second.ClientCertPassword)
+}
@@ -26,6 +26,7 @@ type configToken struct {
 	TokenRefreshInterval int    `json:"tokenRefreshInterval,omitempty"`
 	ClientCertPath       string `json:"clientCertPath,omitempty"`
 	ClientCertKeyPath    string `json:"clientCertKeyPath,omitempty"`
+	ClientCertPassword   string `json:"clientCertPassword,omitempty"`
 	ServerId             string `json:"serverId,omitempty"`
 }
 
@@ -54,6 +55,7 @@ func fromServerDetails(details *ServerDetails) *configToken {
 		TokenRefreshInterval: details.ArtifactoryTokenRefreshInterval,
 		ClientCertPath:       details.ClientCertPath,
 		ClientCertKeyPath:    details.ClientCertKeyPath,
+		ClientCertPassword:   details.ClientCertPassword,
 		ServerId:             details.ServerId,
 	}
 }
@@ -75,6 +77,7 @@ func toServerDetails(detailsSerialization *configToken) *ServerDetails {
 		ArtifactoryTokenRefreshInterval: detailsSerialization.TokenRefreshInterval,
 		ClientCertPath:                  detailsSerialization.ClientCertPath,
 		ClientCertKeyPath:               detailsSerialization.ClientCertKeyPath,
+		ClientCertPassword:              detailsSerialization.ClientCertPassword,
 		ServerId:                        detailsSerialization.ServerId,
 	}
 }
@@ -84,19 +87,8 @@ func Export(details *ServerDetails) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	// If config is encrypted, ask for master key.
-	if conf.Enc {
-		masterKeyFromFile, err := getEncryptionKey()
-		if err != nil {
-			return "", err
-		}
-		masterKeyFromConsole, err := readMasterKeyFromConsole()
-		if err != nil {
-			return "", err
-		}
-		if masterKeyFromConsole != masterKeyFromFile {
-			return "", errorutils.CheckErrorf("could not generate config token: config is encrypted, and wrong master key was provided")
-		}
+	if err = verifyMasterKeyIfEncrypted(conf); err != nil {
+		return "", err
 	}
 	buffer, err := json.Marshal(fromServerDetails(details))
 	if err != nil {
@@ -105,6 +97,25 @@ func Export(details *ServerDetails) (string, error) {
 	return base64.StdEncoding.EncodeToString(buffer), nil
 }
 
+// If the config is encrypted, ask for the master key, so that the secrets are exported only by whoever has it.
+func verifyMasterKeyIfEncrypted(conf *Config) error {
+	if !conf.Enc {
+		return nil
+	}
+	masterKeyFromFile, err := getEncryptionKey()
+	if err != nil {
+		return err
+	}
+	masterKeyFromConsole, err := readMasterKeyFromConsole()
+	if err != nil {
+		return err
+	}
+	if masterKeyFromConsole != masterKeyFromFile {
+		return errorutils.CheckErrorf("could not export config: config is encrypted, and wrong master key was provided")
+	}
+	return nil
+}
+
 func Import(configTokenString string) (*ServerDetails, error) {
 	decoded, err := base64.StdEncoding.DecodeString(configTokenString)
 	if err != nil {
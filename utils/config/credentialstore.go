@@ -0,0 +1,238 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type CredentialStoreType string
+
+const (
+	// The secrets are stored in the config file, encrypted if a master key is configured.
+	FileCredentialStore CredentialStoreType = "file"
+	// The secrets are stored in the OS keyring: the macOS Keychain, the Windows Credential Manager or the Secret Service (libsecret) on Linux.
+	KeyringCredentialStore CredentialStoreType = "keyring"
+
+	keyringService = "jfrog-cli"
+)
+
+// The keyring of the OS. Replaced in tests.
+// Every access to the OS keyring runs a process, so the secrets are cached for the whole process.
+var osKeyring keyring = newCachedKeyring(newOsKeyring())
+
+type keyring interface {
+	// Returns the secret of the account, and false if the keyring has no such secret.
+	get(service, account string) (string, bool, error)
+	set(service, account, secret string) error
+	delete(service, account string) error
+}
+
+type cachedSecret struct {
+	secret string
+	found  bool
+}
+
+// A keyring which reads each secret from the underlying keyring once, and skips writing the secrets which didn't change.
+type cachedKeyring struct {
+	keyring keyring
+	secrets map[string]cachedSecret
+	mutex   sync.Mutex
+}
+
+func newCachedKeyring(keyring keyring) *cachedKeyring {
+	return &cachedKeyring{keyring: keyring, secrets: map[string]cachedSecret{}}
+}
+
+func (ck *cachedKeyring) get(service, account string) (string, bool, error) {
+	ck.mutex.Lock()
+	defer ck.mutex.Unlock()
+	key := service + ":" + account
+	if cached, ok := ck.secrets[key]; ok {
+		return cached.secret, cached.found, nil
+	}
+	secret, found, err := ck.keyring.get(service, account)
+	if err != nil {
+		return "", false, err
+	}
+	ck.secrets[key] = cachedSecret{secret: secret, found: found}
+	return secret, found, nil
+}
+
+func (ck *cachedKeyring) set(service, account, secret string) error {
+	ck.mutex.Lock()
+	defer ck.mutex.Unlock()
+	key := service + ":" + account
+	if cached, ok := ck.secrets[key]; ok && cached.found && cached.secret == secret {
+		return nil
+	}
+	if err := ck.keyring.set(service, account, secret); err != nil {
+		// The secret in the keyring is unknown after a failed write.
+		delete(ck.secrets, key)
+		return err
+	}
+	ck.secrets[key] = cachedSecret{secret: secret, found: true}
+	return nil
+}
+
+func (ck *cachedKeyring) delete(service, account string) error {
+	ck.mutex.Lock()
+	defer ck.mutex.Unlock()
+	key := service + ":" + account
+	if cached, ok := ck.secrets[key]; ok && !cached.found {
+		return nil
+	}
+	if err := ck.keyring.delete(service, account); err != nil {
+		delete(ck.secrets, key)
+		return err
+	}
+	ck.secrets[key] = cachedSecret{}
+	return nil
+}
+
+func ParseCredentialStoreType(storeType string) (CredentialStoreType, error) {
+	switch CredentialStoreType(strings.ToLower(storeType)) {
+	case FileCredentialStore:
+		return FileCredentialStore, nil
+	case KeyringCredentialStore:
+		return KeyringCredentialStore, nil
+	}
+	return "", errorutils.CheckErrorf("unsupported credential store '%s'. Supported stores: %s, %s", storeType, FileCredentialStore, KeyringCredentialStore)
+}
+
+func GetCredentialStoreType() (CredentialStoreType, error) {
+	conf, err := readConf()
+	if err != nil {
+		return "", err
+	}
+	return conf.getCredentialStoreType(), nil
+}
+
+// Set the store of the secrets of all the configured servers, and migrate the secrets to it.
+func SetCredentialStoreType(storeType CredentialStoreType) error {
+	// The secrets are read from the current store.
+	conf, err := readConf()
+	if err != nil {
+		return err
+	}
+	previousStoreType := conf.getCredentialStoreType()
+	if previousStoreType == storeType {
+		log.Info("The credential store is already", storeType)
+		return nil
+	}
+	conf.CredentialStore = storeType
+	if err = saveConfig(conf); err != nil {
+		return err
+	}
+	if previousStoreType == KeyringCredentialStore {
+		// The secrets were written to the config file, so they are removed from the keyring.
+		for _, serverDetails := range conf.Servers {
+			if err = deleteKeyringSecrets(serverDetails.ServerId); err != nil {
+				return err
+			}
+		}
+	}
+	log.Info("The secrets of", len(conf.Servers), "servers were migrated to the", storeType, "credential store")
+	return nil
+}
+
+func (config *Config) getCredentialStoreType() CredentialStoreType {
+	if config.CredentialStore == "" {
+		return FileCredentialStore
+	}
+	return config.CredentialStore
+}
+
+// Returns the secrets of the server details by their names in the keyring.
+func getSecrets(serverDetails *ServerDetails) map[string]*string {
+	return map[string]*string{
+		"password":                &serverDetails.Password,
+		"accessToken":             &serverDetails.AccessToken,
+		"refreshToken":            &serverDetails.RefreshToken,
+		"artifactoryRefreshToken": &serverDetails.ArtifactoryRefreshToken,
+		"sshPassphrase":           &serverDetails.SshPassphrase,
+		"clientCertPassword":      &serverDetails.ClientCertPassword,
+	}
+}
+
+func getKeyringAccount(serverId, secretName string) string {
+	return serverId + "/" + secretName
+}
+
+// Move the secrets of the config to the keyring, and remove the secrets of the servers which were removed from the config.
+// The config is cleared of the secrets, so it must be a clone of the config in use.
+func (config *Config) storeSecretsInKeyring(previousServerIds []string) error {
+	serverIds := map[string]bool{}
+	for _, serverDetails := range config.Servers {
+		serverIds[serverDetails.ServerId] = true
+		for name, secret := range getSecrets(serverDetails) {
+			account := getKeyringAccount(serverDetails.ServerId, name)
+			var err error
+			if *secret == "" {
+				err = osKeyring.delete(keyringService, account)
+			} else {
+				err = osKeyring.set(keyringService, account, *secret)
+			}
+			if err != nil {
+				return errorutils.CheckErrorf("failed to store the %s of the '%s' server in the keyring: %s", name, serverDetails.ServerId, err.Error())
+			}
+			*secret = ""
+		}
+	}
+	for _, serverId := range previousServerIds {
+		if !serverIds[serverId] {
+			if err := deleteKeyringSecrets(serverId); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Load the secrets of the config from the keyring.
+func (config *Config) loadSecretsFromKeyring() error {
+	for _, serverDetails := range config.Servers {
+		for name, secret := range getSecrets(serverDetails) {
+			value, found, err := osKeyring.get(keyringService, getKeyringAccount(serverDetails.ServerId, name))
+			if err != nil {
+				return errorutils.CheckErrorf("failed to read the %s of the '%s' server from the keyring: %s", name, serverDetails.ServerId, err.Error())
+			}
+			if found {
+				*secret = value
+			}
+		}
+	}
+	return nil
+}
+
+func deleteKeyringSecrets(serverId string) error {
+	var err error
+	for name := range getSecrets(new(ServerDetails)) {
+		err = errors.Join(err, osKeyring.delete(keyringService, getKeyringAccount(serverId, name)))
+	}
+	if err != nil {
+		return errorutils.CheckErrorf("failed to delete the secrets of the '%s' server from the keyring: %s", serverId, err.Error())
+	}
+	return nil
+}
+
+// Returns the IDs of the servers in the config file, without reading their secrets.
+func getConfiguredServerIds(content []byte) (serverIds []string) {
+	var conf struct {
+		Servers []struct {
+			ServerId string `json:"serverId"`
+		} `json:"servers"`
+	}
+	if err := json.Unmarshal(content, &conf); err != nil {
+		log.Debug("Failed to read the server IDs of the config file:", err.Error())
+		return
+	}
+	for _, server := range conf.Servers {
+		serverIds = append(serverIds, server.ServerId)
+	}
+	return
+}
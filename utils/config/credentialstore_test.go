@@ -0,0 +1,149 @@
+package config
+
+import (
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryKeyring map[string]string
+
+func (mk memoryKeyring) get(service, account string) (string, bool, error) {
+	secret, found := mk[service+":"+account]
+	return secret, found, nil
+}
+
+func (mk memoryKeyring) set(service, account, secret string) error {
+	mk[service+":"+account] = secret
+	return nil
+}
+
+func (mk memoryKeyring) delete(service, account string) error {
+	delete(mk, service+":"+account)
+	return nil
+}
+
+// Counts the accesses to the keyring.
+type countingKeyring struct {
+	memoryKeyring
+	reads, writes int
+}
+
+func (ck *countingKeyring) get(service, account string) (string, bool, error) {
+	ck.reads++
+	return ck.memoryKeyring.get(service, account)
+}
+
+func (ck *countingKeyring) set(service, account, secret string) error {
+	ck.writes++
+	return ck.memoryKeyring.set(service, account, secret)
+}
+
+func (ck *countingKeyring) delete(service, account string) error {
+	ck.writes++
+	return ck.memoryKeyring.delete(service, account)
+}
+
+func TestCachedKeyring(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	testKeyring := &countingKeyring{memoryKeyring: memoryKeyring{}}
+	previousKeyring := osKeyring
+	osKeyring = newCachedKeyring(testKeyring)
+	defer func() {
+		osKeyring = previousKeyring
+	}()
+
+	require.NoError(t, SaveServersConf([]*ServerDetails{{ServerId: "first", Url: "https://first.jfrog.io/", AccessToken: "token", IsDefault: true}}))
+	require.NoError(t, SetCredentialStoreType(KeyringCredentialStore))
+	secretsCount := len(getSecrets(new(ServerDetails)))
+	assert.Equal(t, secretsCount, testKeyring.writes)
+
+	// The secrets written by the process are cached, so they aren't read back.
+	serverDetails, err := GetSpecificConfig("first", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "token", serverDetails.AccessToken)
+	assert.Zero(t, testKeyring.reads)
+
+	// In a new process, the secrets are read from the keyring once, however many times the config is read.
+	osKeyring = newCachedKeyring(testKeyring)
+	for i := 0; i < 3; i++ {
+		serverDetails, err = GetSpecificConfig("first", false, false)
+		require.NoError(t, err)
+		assert.Equal(t, "token", serverDetails.AccessToken)
+	}
+	assert.Equal(t, secretsCount, testKeyring.reads)
+
+	// Only the changed secrets are written to the keyring.
+	configurations, err := GetAllServersConfigs()
+	require.NoError(t, err)
+	configurations[0].AccessToken = "new-token"
+	require.NoError(t, SaveServersConf(configurations))
+	assert.Equal(t, secretsCount+1, testKeyring.writes)
+	assert.Equal(t, "new-token", testKeyring.memoryKeyring["jfrog-cli:first/accessToken"])
+}
+
+func TestCredentialStoreMigration(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	testKeyring := memoryKeyring{}
+	previousKeyring := osKeyring
+	osKeyring = testKeyring
+	defer func() {
+		osKeyring = previousKeyring
+	}()
+
+	servers := []*ServerDetails{
+		{ServerId: "first", Url: "https://first.jfrog.io/", User: "user", Password: "password", IsDefault: true},
+		{ServerId: "second", Url: "https://second.jfrog.io/", AccessToken: "token", RefreshToken: "refresh"},
+	}
+	require.NoError(t, SaveServersConf(servers))
+	storeType, err := GetCredentialStoreType()
+	require.NoError(t, err)
+	assert.Equal(t, FileCredentialStore, storeType)
+
+	// Migrate to the keyring.
+	require.NoError(t, SetCredentialStoreType(KeyringCredentialStore))
+	fileConfig := readConfFromFile(t)
+	assert.Equal(t, KeyringCredentialStore, fileConfig.CredentialStore)
+	for _, serverDetails := range fileConfig.Servers {
+		assert.Empty(t, serverDetails.Password)
+		assert.Empty(t, serverDetails.AccessToken)
+		assert.Empty(t, serverDetails.RefreshToken)
+	}
+	assert.Equal(t, memoryKeyring{
+		"jfrog-cli:first/password":      "password",
+		"jfrog-cli:second/accessToken":  "token",
+		"jfrog-cli:second/refreshToken": "refresh",
+	}, testKeyring)
+
+	// The secrets are read from the keyring.
+	serverDetails, err := GetSpecificConfig("second", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "token", serverDetails.AccessToken)
+	assert.Equal(t, "refresh", serverDetails.RefreshToken)
+
+	// The secrets of removed servers are removed from the keyring.
+	configurations, err := GetAllServersConfigs()
+	require.NoError(t, err)
+	_, configurations = GetAndRemoveConfiguration("second", configurations)
+	require.NoError(t, SaveServersConf(configurations))
+	assert.Equal(t, memoryKeyring{"jfrog-cli:first/password": "password"}, testKeyring)
+
+	// Migrate back to the config file.
+	require.NoError(t, SetCredentialStoreType(FileCredentialStore))
+	assert.Empty(t, testKeyring)
+	fileConfig = readConfFromFile(t)
+	require.Len(t, fileConfig.Servers, 1)
+	assert.Equal(t, "password", fileConfig.Servers[0].Password)
+}
+
+func TestParseCredentialStoreType(t *testing.T) {
+	storeType, err := ParseCredentialStoreType("Keyring")
+	assert.NoError(t, err)
+	assert.Equal(t, KeyringCredentialStore, storeType)
+	_, err = ParseCredentialStoreType("vault")
+	assert.Error(t, err)
+}
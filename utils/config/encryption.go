@@ -92,6 +92,10 @@ func handleSecrets(config *Config, handler secretHandler, key string) error {
 		if err != nil {
 			return err
 		}
+		serverDetails.ClientCertPassword, err = handler(serverDetails.ClientCertPassword, key)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
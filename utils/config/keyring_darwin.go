@@ -0,0 +1,73 @@
+package config
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// This file will be compiled on macOS.
+// The secrets are stored in the login keychain through the 'security' command.
+const (
+	securityExecutable = "/usr/bin/security"
+	// The exit code of 'security' for items which aren't found in the keychain.
+	securityItemNotFoundExitCode = 44
+)
+
+type macOsKeychain struct{}
+
+func newOsKeyring() keyring {
+	return macOsKeychain{}
+}
+
+func (macOsKeychain) get(service, account string) (string, bool, error) {
+	output, err := exec.Command(securityExecutable, "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		if isItemNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, getSecurityError(err)
+	}
+	return strings.TrimSuffix(string(output), "\n"), true, nil
+}
+
+func (macOsKeychain) set(service, account, secret string) error {
+	// The command is passed through the standard input of the interactive mode, so the secret isn't exposed in the arguments of the process.
+	// The secret is hex-encoded to avoid quoting it.
+	cmd := exec.Command(securityExecutable, "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %q -a %q -X %s\n", service, account, hex.EncodeToString([]byte(secret))))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// The interactive mode exits successfully even if the command in it fails, so the failure is detected by its error output.
+	if errorOutput := strings.TrimSpace(stderr.String()); errorOutput != "" {
+		return errors.New(errorOutput)
+	}
+	return nil
+}
+
+func (macOsKeychain) delete(service, account string) error {
+	err := exec.Command(securityExecutable, "delete-generic-password", "-s", service, "-a", account).Run()
+	if err != nil && !isItemNotFound(err) {
+		return getSecurityError(err)
+	}
+	return nil
+}
+
+func isItemNotFound(err error) bool {
+	var exitError *exec.ExitError
+	return errors.As(err, &exitError) && exitError.ExitCode() == securityItemNotFoundExitCode
+}
+
+func getSecurityError(err error) error {
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) && len(exitError.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitError.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// This file will be compiled on Linux.
+// The secrets are stored by the Secret Service, such as GNOME Keyring or KWallet, through the 'secret-tool' command of libsecret.
+const secretToolExecutable = "secret-tool"
+
+type secretServiceKeyring struct{}
+
+func newOsKeyring() keyring {
+	return secretServiceKeyring{}
+}
+
+func (secretServiceKeyring) get(service, account string) (string, bool, error) {
+	cmd := exec.Command(secretToolExecutable, "lookup", "service", service, "account", account)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		// 'secret-tool lookup' exits with 1 and prints nothing if the secret isn't found.
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) && exitError.ExitCode() == 1 && stderr.Len() == 0 {
+			return "", false, nil
+		}
+		return "", false, getSecretToolError(err, stderr)
+	}
+	return stdout.String(), true, nil
+}
+
+func (secretServiceKeyring) set(service, account, secret string) error {
+	// The secret is passed through the standard input, so it isn't exposed in the arguments of the process.
+	cmd := exec.Command(secretToolExecutable, "store", "--label=JFrog CLI "+account, "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	return getSecretToolError(cmd.Run(), stderr)
+}
+
+func (secretServiceKeyring) delete(service, account string) error {
+	cmd := exec.Command(secretToolExecutable, "clear", "service", service, "account", account)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	// 'secret-tool clear' exits with 1 if no secret was cleared.
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) && exitError.ExitCode() == 1 && stderr.Len() == 0 {
+		return nil
+	}
+	return getSecretToolError(err, stderr)
+}
+
+func getSecretToolError(err error, stderr bytes.Buffer) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("the '%s' command of libsecret wasn't found: %w", secretToolExecutable, err)
+	}
+	if stderr.Len() > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+//go:build !darwin && !linux && !windows
+
+package config
+
+import "errors"
+
+// This file will be compiled on the operating systems which have no supported keyring.
+type unsupportedKeyring struct{}
+
+func newOsKeyring() keyring {
+	return unsupportedKeyring{}
+}
+
+var errKeyringUnsupported = errors.New("the OS keyring isn't supported on this operating system")
+
+func (unsupportedKeyring) get(string, string) (string, bool, error) {
+	return "", false, errKeyringUnsupported
+}
+
+func (unsupportedKeyring) set(string, string, string) error {
+	return errKeyringUnsupported
+}
+
+func (unsupportedKeyring) delete(string, string) error {
+	return errKeyringUnsupported
+}
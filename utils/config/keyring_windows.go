@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+// This file will be compiled on Windows.
+// The secrets are stored as generic credentials in the Windows Credential Manager.
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredRead   = advapi32.NewProc("CredReadW")
+	procCredWrite  = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// The CREDENTIALW struct of the Windows API.
+type windowsCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+type windowsCredentialManager struct{}
+
+func newOsKeyring() keyring {
+	return windowsCredentialManager{}
+}
+
+func getCredentialTargetName(service, account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(service + ":" + account)
+}
+
+func (windowsCredentialManager) get(service, account string) (string, bool, error) {
+	targetName, err := getCredentialTargetName(service, account)
+	if err != nil {
+		return "", false, err
+	}
+	var credential *windowsCredential
+	result, _, err := procCredRead.Call(uintptr(unsafe.Pointer(targetName)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&credential)))
+	if result == 0 {
+		if errors.Is(err, errorNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(credential)))
+	if credential.CredentialBlobSize == 0 {
+		return "", true, nil
+	}
+	return string(unsafe.Slice(credential.CredentialBlob, credential.CredentialBlobSize)), true, nil
+}
+
+func (windowsCredentialManager) set(service, account, secret string) error {
+	targetName, err := getCredentialTargetName(service, account)
+	if err != nil {
+		return err
+	}
+	userName, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	blob := []byte(secret)
+	credential := windowsCredential{
+		Type:               credTypeGeneric,
+		TargetName:         targetName,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		credential.CredentialBlob = &blob[0]
+	}
+	if result, _, err := procCredWrite.Call(uintptr(unsafe.Pointer(&credential)), 0); result == 0 {
+		return err
+	}
+	return nil
+}
+
+func (windowsCredentialManager) delete(service, account string) error {
+	targetName, err := getCredentialTargetName(service, account)
+	if err != nil {
+		return err
+	}
+	if result, _, err := procCredDelete.Call(uintptr(unsafe.Pointer(targetName)), credTypeGeneric, 0); result == 0 && !errors.Is(err, errorNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v3"
+)
+
+// The repo-local config file, relative to the root of the project.
+var localConfigFile = filepath.Join(".jfrog", "config")
+
+// The repo-local config pins the server, project and default repositories of the commands executed in the project's directory tree.
+// For example:
+//
+//	serverId: acme
+//	project: proj
+//	repositories:
+//	  npm: npm-virtual
+//	  generic: generic-local
+type LocalConfig struct {
+	ServerId string `yaml:"serverId,omitempty"`
+	Project  string `yaml:"project,omitempty"`
+	// The default repositories by their package type or purpose.
+	Repositories map[string]string `yaml:"repositories,omitempty"`
+	// The path of the file which the config was read from.
+	Path string `yaml:"-"`
+}
+
+// Returns the repo-local config of the working directory, found in it or in one of its parent directories, or nil if there's none.
+// The .jfrog directory in the user's home is the JFrog CLI home directory, rather than a project, so it's ignored.
+func GetLocalConfig() (*LocalConfig, error) {
+	projectDir, exists, err := fileutils.FindUpstream(localConfigFile, fileutils.File)
+	if err != nil || !exists {
+		return nil, err
+	}
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	if isSamePath(filepath.Join(projectDir, ".jfrog"), jfrogHomeDir) {
+		return nil, nil
+	}
+	path := filepath.Join(projectDir, localConfigFile)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	localConfig := &LocalConfig{Path: path}
+	if err = yaml.Unmarshal(content, localConfig); err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the local config file '%s': %s", path, err.Error())
+	}
+	return localConfig, nil
+}
+
+// Returns the default repository of the package type or purpose, or an empty string if there's none.
+func (localConfig *LocalConfig) GetRepository(name string) string {
+	if localConfig == nil {
+		return ""
+	}
+	return localConfig.Repositories[name]
+}
+
+func isSamePath(first, second string) bool {
+	firstInfo, err := os.Stat(first)
+	if err != nil {
+		return false
+	}
+	secondInfo, err := os.Stat(second)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(firstInfo, secondInfo)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLocalConfig(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	require.NoError(t, SaveServersConf([]*ServerDetails{
+		{ServerId: "default", Url: "https://default.jfrog.io/", IsDefault: true},
+		{ServerId: "pinned", Url: "https://pinned.jfrog.io/"},
+	}))
+
+	projectDir := t.TempDir()
+	subDir := filepath.Join(projectDir, "a", "b")
+	require.NoError(t, os.MkdirAll(subDir, 0755))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	chdirCallBack := testsutils.ChangeDirWithCallback(t, wd, subDir)
+	defer chdirCallBack()
+
+	// Without a local config, the default server is used.
+	localConfig, err := GetLocalConfig()
+	require.NoError(t, err)
+	assert.Nil(t, localConfig)
+	serverDetails, err := GetSpecificConfig("", true, false)
+	require.NoError(t, err)
+	assert.Equal(t, "default", serverDetails.ServerId)
+
+	// The local config is found in a parent directory.
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".jfrog"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".jfrog", "config"), []byte("serverId: pinned\nrepositories:\n  generic: generic-local\n"), 0644))
+	localConfig, err = GetLocalConfig()
+	require.NoError(t, err)
+	require.NotNil(t, localConfig)
+	assert.Equal(t, "generic-local", localConfig.GetRepository("generic"))
+	assert.Equal(t, "pinned", localConfig.ServerId)
+
+	// The server is resolved from the local config by the command layer only.
+	serverDetails, err = GetSpecificConfig("", true, false)
+	require.NoError(t, err)
+	assert.Equal(t, "default", serverDetails.ServerId)
+}
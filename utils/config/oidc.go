@@ -0,0 +1,167 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type OidcProvider string
+
+const (
+	GitHubOidcProvider   OidcProvider = "github"
+	GitLabOidcProvider   OidcProvider = "gitlab"
+	CircleCiOidcProvider OidcProvider = "circleci"
+
+	// The ID token to exchange, if provided explicitly. GitLab jobs must declare their ID token under this name in their 'id_tokens' section.
+	OidcIdTokenEnv = "JFROG_CLI_OIDC_ID_TOKEN"
+	// Set by GitHub Actions in jobs with the 'id-token: write' permission.
+	gitHubIdTokenRequestUrlEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	gitHubIdTokenRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+	// Set by CircleCI in every job.
+	circleCiIdTokenEnv = "CIRCLE_OIDC_TOKEN_V2"
+
+	oidcTokenExchangeApi   = "api/v1/oidc/token"
+	oidcGrantType          = "urn:ietf:params:oauth:grant-type:token-exchange"
+	oidcIdTokenSubjectType = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+func ParseOidcProvider(provider string) (OidcProvider, error) {
+	switch OidcProvider(strings.ToLower(provider)) {
+	case GitHubOidcProvider:
+		return GitHubOidcProvider, nil
+	case GitLabOidcProvider:
+		return GitLabOidcProvider, nil
+	case CircleCiOidcProvider:
+		return CircleCiOidcProvider, nil
+	}
+	return "", errorutils.CheckErrorf("unsupported OIDC provider '%s'. Supported providers: %s, %s, %s", provider, GitHubOidcProvider, GitLabOidcProvider, CircleCiOidcProvider)
+}
+
+type oidcTokenExchangeRequest struct {
+	GrantType        string `json:"grant_type"`
+	SubjectTokenType string `json:"subject_token_type"`
+	SubjectToken     string `json:"subject_token"`
+	ProviderName     string `json:"provider_name"`
+}
+
+// Returns the OIDC ID token the CI provider issued to the running job.
+func GetCiIdToken(provider OidcProvider, audience string) (string, error) {
+	if idToken := os.Getenv(OidcIdTokenEnv); idToken != "" {
+		return idToken, nil
+	}
+	switch provider {
+	case GitHubOidcProvider:
+		return getGitHubIdToken(audience)
+	case GitLabOidcProvider:
+		return "", errorutils.CheckErrorf("no ID token was found. Declare the ID token of the GitLab job as %s in its 'id_tokens' section", OidcIdTokenEnv)
+	case CircleCiOidcProvider:
+		if idToken := os.Getenv(circleCiIdTokenEnv); idToken != "" {
+			return idToken, nil
+		}
+		return "", errorutils.CheckErrorf("no ID token was found in the %s environment variable. Make sure the command runs in a CircleCI job", circleCiIdTokenEnv)
+	}
+	return "", errorutils.CheckErrorf("unsupported OIDC provider '%s'", provider)
+}
+
+// GitHub Actions issues ID tokens on request, to jobs with the 'id-token: write' permission.
+func getGitHubIdToken(audience string) (string, error) {
+	requestUrl, requestToken := os.Getenv(gitHubIdTokenRequestUrlEnv), os.Getenv(gitHubIdTokenRequestTokenEnv)
+	if requestUrl == "" || requestToken == "" {
+		return "", errorutils.CheckErrorf("failed to request an ID token from GitHub Actions. Make sure the job has the 'id-token: write' permission")
+	}
+	if audience != "" {
+		requestUrl += "&audience=" + url.QueryEscape(audience)
+	}
+	client, err := httpclient.ClientBuilder().SetRetries(3).Build()
+	if err != nil {
+		return "", err
+	}
+	resp, body, _, err := client.SendGet(requestUrl, true, httputils.HttpClientDetails{AccessToken: requestToken}, "")
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return "", err
+	}
+	var response struct {
+		Value string `json:"value"`
+	}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return response.Value, nil
+}
+
+// Exchange the ID token for a short-lived access token, through the OIDC integration of the JFrog Platform.
+func ExchangeOidcToken(serverDetails *ServerDetails, idToken string) (auth.CreateTokenResponseData, error) {
+	// The ID token authenticates the request. Configured credentials would trigger the token refresh, which exchanges tokens recursively.
+	noCredServerDetails := new(ServerDetails)
+	noCredServerDetails.Url = serverDetails.Url
+	noCredServerDetails.ClientCertPath = serverDetails.ClientCertPath
+	noCredServerDetails.ClientCertKeyPath = serverDetails.ClientCertKeyPath
+	noCredServerDetails.ClientCertPassword = serverDetails.ClientCertPassword
+	noCredServerDetails.InsecureTls = serverDetails.InsecureTls
+
+	servicesManager, err := createAccessTokensServiceManager(noCredServerDetails)
+	if err != nil {
+		return auth.CreateTokenResponseData{}, err
+	}
+	accessAuth, err := noCredServerDetails.CreateAccessAuthConfig()
+	if err != nil {
+		return auth.CreateTokenResponseData{}, err
+	}
+	content, err := json.Marshal(oidcTokenExchangeRequest{
+		GrantType:        oidcGrantType,
+		SubjectTokenType: oidcIdTokenSubjectType,
+		SubjectToken:     idToken,
+		ProviderName:     serverDetails.OidcIntegration,
+	})
+	if err != nil {
+		return auth.CreateTokenResponseData{}, errorutils.CheckError(err)
+	}
+	httpClientDetails := accessAuth.CreateHttpClientDetails()
+	httpClientDetails.SetContentTypeApplicationJson()
+	resp, body, err := servicesManager.Client().SendPost(accessAuth.GetUrl()+oidcTokenExchangeApi, content, &httpClientDetails)
+	if err != nil {
+		return auth.CreateTokenResponseData{}, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return auth.CreateTokenResponseData{}, err
+	}
+	var token auth.CreateTokenResponseData
+	if err = json.Unmarshal(body, &token); err != nil {
+		return auth.CreateTokenResponseData{}, errorutils.CheckError(err)
+	}
+	if token.AccessToken == "" {
+		return auth.CreateTokenResponseData{}, errorutils.CheckErrorf("the OIDC token exchange returned no access token")
+	}
+	log.Debug("Exchanged the OIDC ID token for an access token")
+	return token, nil
+}
+
+// Exchange a new ID token of the CI provider for an access token, and store it in the config.
+func exchangeOidcTokenAndWriteToConfig(serverConfiguration *ServerDetails) (string, error) {
+	provider, err := ParseOidcProvider(serverConfiguration.OidcProvider)
+	if err != nil {
+		return "", err
+	}
+	idToken, err := GetCiIdToken(provider, serverConfiguration.OidcAudience)
+	if err != nil {
+		return "", err
+	}
+	newToken, err := ExchangeOidcToken(serverConfiguration, idToken)
+	if err != nil {
+		return "", err
+	}
+	err = writeNewTokens(serverConfiguration, serverConfiguration.ServerId, newToken.AccessToken, "", OidcToken)
+	return newToken.AccessToken, err
+}
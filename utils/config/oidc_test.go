@@ -0,0 +1,83 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOidcProvider(t *testing.T) {
+	provider, err := ParseOidcProvider("GitHub")
+	assert.NoError(t, err)
+	assert.Equal(t, GitHubOidcProvider, provider)
+	provider, err = ParseOidcProvider("circleci")
+	assert.NoError(t, err)
+	assert.Equal(t, CircleCiOidcProvider, provider)
+	_, err = ParseOidcProvider("jenkins")
+	assert.Error(t, err)
+}
+
+func TestGetCiIdToken(t *testing.T) {
+	t.Setenv(OidcIdTokenEnv, "")
+	_, err := GetCiIdToken(GitLabOidcProvider, "")
+	assert.ErrorContains(t, err, OidcIdTokenEnv)
+
+	t.Setenv(circleCiIdTokenEnv, "circleci-token")
+	idToken, err := GetCiIdToken(CircleCiOidcProvider, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "circleci-token", idToken)
+
+	// An explicit ID token is preferred.
+	t.Setenv(OidcIdTokenEnv, "explicit-token")
+	idToken, err = GetCiIdToken(GitLabOidcProvider, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "explicit-token", idToken)
+}
+
+func TestGetGitHubIdToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer request-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "jfrog-github", r.URL.Query().Get("audience"))
+		_, err := w.Write([]byte(`{"value":"github-token"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+	t.Setenv(OidcIdTokenEnv, "")
+	t.Setenv(gitHubIdTokenRequestUrlEnv, server.URL+"/token?api-version=2.0")
+	t.Setenv(gitHubIdTokenRequestTokenEnv, "request-token")
+
+	idToken, err := GetCiIdToken(GitHubOidcProvider, "jfrog-github")
+	require.NoError(t, err)
+	assert.Equal(t, "github-token", idToken)
+
+	t.Setenv(gitHubIdTokenRequestTokenEnv, "")
+	_, err = GetCiIdToken(GitHubOidcProvider, "")
+	assert.ErrorContains(t, err, "id-token: write")
+}
+
+func TestExchangeOidcToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/access/"+oidcTokenExchangeApi, r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		request := new(oidcTokenExchangeRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(request))
+		assert.Equal(t, oidcTokenExchangeRequest{
+			GrantType:        oidcGrantType,
+			SubjectTokenType: oidcIdTokenSubjectType,
+			SubjectToken:     "github-token",
+			ProviderName:     "github-integration",
+		}, *request)
+		_, err := w.Write([]byte(`{"access_token":"access-token","expires_in":3600,"token_type":"Bearer"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	serverDetails := &ServerDetails{Url: server.URL + "/", OidcProvider: string(GitHubOidcProvider), OidcIntegration: "github-integration", AccessToken: "expired-token"}
+	token, err := ExchangeOidcToken(serverDetails, "github-token")
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token.AccessToken)
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/auth/cert"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+var supportedProxySchemes = []string{"http", "https", "socks5"}
+
+// Returns true if the server has its own proxy settings, which override the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func (serverDetails *ServerDetails) HasProxy() bool {
+	return serverDetails.HttpProxy != "" || serverDetails.HttpsProxy != "" || serverDetails.NoProxy != ""
+}
+
+// Returns an error if one of the proxy URLs of the server is invalid.
+func (serverDetails *ServerDetails) ValidateProxy() error {
+	for _, proxy := range []string{serverDetails.HttpProxy, serverDetails.HttpsProxy} {
+		if _, err := parseProxyUrl(proxy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the proxy function of the requests to the server.
+// The HTTPS requests are sent through the HTTPS proxy, or through the HTTP proxy if no HTTPS proxy is configured.
+// If the server has no proxy settings, the proxy is taken from the environment variables.
+func (serverDetails *ServerDetails) GetProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if !serverDetails.HasProxy() {
+		return http.ProxyFromEnvironment, nil
+	}
+	httpProxy, err := parseProxyUrl(serverDetails.HttpProxy)
+	if err != nil {
+		return nil, err
+	}
+	httpsProxy, err := parseProxyUrl(serverDetails.HttpsProxy)
+	if err != nil {
+		return nil, err
+	}
+	if httpsProxy == nil {
+		httpsProxy = httpProxy
+	}
+	noProxy := parseNoProxy(serverDetails.NoProxy)
+	return func(req *http.Request) (*url.URL, error) {
+		if noProxy.matches(req.URL) {
+			return nil, nil
+		}
+		if req.URL.Scheme == "https" {
+			return httpsProxy, nil
+		}
+		return httpProxy, nil
+	}, nil
+}
+
+// Create the transport of the requests to the server, which uses the proxy and the client certificate of the server, if configured.
+// The transport trusts the certificates in certsPath, if provided.
+func (serverDetails *ServerDetails) CreateHttpTransport(certsPath string) (*http.Transport, error) {
+	proxy, err := serverDetails.GetProxyFunc()
+	if err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   httpclient.DefaultDialTimeout,
+			KeepAlive: 20 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if certsPath == "" {
+		//#nosec G402 -- Insecure TLS allowed here.
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: serverDetails.InsecureTls}
+	} else if transport, err = cert.GetTransportWithLoadedCert(certsPath, serverDetails.InsecureTls, transport); err != nil {
+		return nil, err
+	}
+	if serverDetails.ClientCertPath != "" {
+		certificate, err := serverDetails.LoadClientCertificate()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return transport, nil
+}
+
+// Returns an HTTP client which sends the requests to the server through its proxy and with its PKCS#12 client certificate,
+// or nil if the server has neither, since the default client of jfrog-client-go supports them.
+// A nil client may be passed to the services config builder, which then creates its default client.
+// Only the Artifactory services manager uses the HTTP client of the services config. The other managers use SetCustomHttpTransport.
+func (serverDetails *ServerDetails) GetCustomHttpClient(certsPath string, timeout time.Duration) (*http.Client, error) {
+	if !serverDetails.HasProxy() && !serverDetails.IsPkcs12ClientCert() {
+		return nil, nil
+	}
+	transport, err := serverDetails.CreateHttpTransport(certsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+// Set the transport of the HTTP client of a services manager to a transport which sends the requests to the server through its proxy
+// and with its PKCS#12 client certificate, if the server has either of them.
+// Unlike the Artifactory services manager, the managers of the other services don't use the HTTP client of the services config,
+// so their client's transport is replaced once they are created.
+func (serverDetails *ServerDetails) SetCustomHttpTransport(client *jfroghttpclient.JfrogHttpClient, certsPath string) error {
+	if !serverDetails.HasProxy() && !serverDetails.IsPkcs12ClientCert() {
+		return nil
+	}
+	transport, err := serverDetails.CreateHttpTransport(certsPath)
+	if err != nil {
+		return err
+	}
+	client.GetHttpClient().GetClient().Transport = transport
+	return nil
+}
+
+// Parse a proxy URL. A URL without a scheme is an HTTP proxy, as in the HTTP_PROXY environment variable.
+func parseProxyUrl(proxy string) (*url.URL, error) {
+	if proxy == "" {
+		return nil, nil
+	}
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	proxyUrl, err := url.Parse(proxy)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid proxy URL '%s': %s", proxy, err.Error())
+	}
+	for _, scheme := range supportedProxySchemes {
+		if proxyUrl.Scheme == scheme && proxyUrl.Host != "" {
+			return proxyUrl, nil
+		}
+	}
+	return nil, errorutils.CheckErrorf("invalid proxy URL '%s'. The URL must have a host and one of the schemes: %s", proxy, strings.Join(supportedProxySchemes, ", "))
+}
+
+// The hosts which are accessed without the proxy, in the format of the NO_PROXY environment variable.
+type noProxyList struct {
+	all      bool
+	networks []*net.IPNet
+	// Hosts, optionally with a port. A host matches its subdomains too.
+	hosts []string
+}
+
+func parseNoProxy(noProxy string) *noProxyList {
+	list := &noProxyList{}
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case entry == "*":
+			list.all = true
+		default:
+			if _, network, err := net.ParseCIDR(entry); err == nil {
+				list.networks = append(list.networks, network)
+				continue
+			}
+			list.hosts = append(list.hosts, strings.TrimPrefix(strings.TrimPrefix(entry, "*"), "."))
+		}
+	}
+	return list
+}
+
+func (list *noProxyList) matches(requestUrl *url.URL) bool {
+	if list.all {
+		return true
+	}
+	host := strings.ToLower(requestUrl.Hostname())
+	if ip := net.ParseIP(host); ip != nil {
+		for _, network := range list.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	for _, entry := range list.hosts {
+		entryHost, entryPort, err := net.SplitHostPort(entry)
+		if err != nil {
+			entryHost, entryPort = entry, ""
+		}
+		if entryPort != "" && entryPort != getPort(requestUrl) {
+			continue
+		}
+		if host == entryHost || strings.HasSuffix(host, "."+entryHost) {
+			return true
+		}
+	}
+	return false
+}
+
+func getPort(requestUrl *url.URL) string {
+	if port := requestUrl.Port(); port != "" {
+		return port
+	}
+	if requestUrl.Scheme == "https" {
+		return "443"
+	}
+	return "80"
+}
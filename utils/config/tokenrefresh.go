@@ -2,18 +2,20 @@ package config
 
 import (
 	"errors"
-	"github.com/jfrog/jfrog-client-go/access"
-	accessservices "github.com/jfrog/jfrog-client-go/access/services"
-	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"fmt"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/lock"
+	"github.com/jfrog/jfrog-client-go/access"
+	accessservices "github.com/jfrog/jfrog-client-go/access/services"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
 	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/jfrog/jfrog-client-go/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
@@ -21,31 +23,73 @@ import (
 // Internal golang locking for the same process.
 var mutex sync.Mutex
 
-// The serverId used for authentication. Use for reading and writing tokens from/to the config file, and for reading the credentials if needed.
+// The serverId of the last created auth config, used by the exported interceptors for reading and writing tokens from/to the config file.
+// The interceptors added by createAuthConfig are bound to their server instead, so that the tokens of several servers, such as the source and target of a transfer, are refreshed independently.
 var tokenRefreshServerId string
 
 const (
 	ArtifactoryToken TokenType = "artifactory"
 	AccessToken      TokenType = "access"
+	OidcToken        TokenType = "oidc"
 )
 
 type TokenType string
 
 func AccessTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) (err error) {
-	return tokenRefreshPreRequestInterceptor(fields, httpClientDetails, AccessToken, auth.RefreshPlatformTokenBeforeExpiryMinutes)
+	return tokenRefreshPreRequestInterceptor(fields, httpClientDetails, tokenRefreshServerId, AccessToken)
 }
 
 func ArtifactoryTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) (err error) {
-	return tokenRefreshPreRequestInterceptor(fields, httpClientDetails, ArtifactoryToken, auth.RefreshArtifactoryTokenBeforeExpiryMinutes)
+	return tokenRefreshPreRequestInterceptor(fields, httpClientDetails, tokenRefreshServerId, ArtifactoryToken)
+}
+
+func OidcTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) (err error) {
+	return tokenRefreshPreRequestInterceptor(fields, httpClientDetails, tokenRefreshServerId, OidcToken)
+}
+
+// Returns an interceptor which refreshes the access token of the server before it expires.
+// If the server ID is empty, the default server is used.
+func newTokenRefreshPreRequestInterceptor(serverId string, tokenType TokenType) auth.ServiceDetailsPreRequestFunc {
+	return func(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) error {
+		return tokenRefreshPreRequestInterceptor(fields, httpClientDetails, serverId, tokenType)
+	}
 }
 
-func tokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails, tokenType TokenType, refreshBeforeExpiryMinutes int64) (err error) {
+// Returns the duration before the expiry of the access token, in which the token is refreshed.
+// The duration is set by the JFROG_CLI_TOKEN_REFRESH_LEAD_TIME environment variable, or by the type of the token.
+// It's limited to half of the token's lifetime, so that a refreshed token isn't immediately refreshed again.
+func getTokenRefreshLeadTime(accessToken string, tokenType TokenType) (time.Duration, error) {
+	leadTime := time.Duration(auth.RefreshPlatformTokenBeforeExpiryMinutes) * time.Minute
+	if tokenType == ArtifactoryToken {
+		leadTime = time.Duration(auth.RefreshArtifactoryTokenBeforeExpiryMinutes) * time.Minute
+	}
+	if leadTimeEnv := os.Getenv(coreutils.TokenRefreshLeadTime); leadTimeEnv != "" {
+		var err error
+		if leadTime, err = time.ParseDuration(leadTimeEnv); err != nil || leadTime < 0 {
+			return 0, errorutils.CheckErrorf("invalid %s value '%s'. The value should be a duration, such as 30m or 24h", coreutils.TokenRefreshLeadTime, leadTimeEnv)
+		}
+	}
+	lifetimeSeconds, err := auth.ExtractExpiryFromAccessToken(accessToken)
+	if err != nil {
+		return 0, err
+	}
+	if lifetime := time.Duration(lifetimeSeconds) * time.Second; lifetime > 0 && leadTime > lifetime/2 {
+		leadTime = lifetime / 2
+	}
+	return leadTime, nil
+}
+
+func tokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails, serverId string, tokenType TokenType) (err error) {
 	if fields.GetAccessToken() == "" || httpClientDetails.AccessToken == "" {
 		return nil
 	}
 
+	leadTime, err := getTokenRefreshLeadTime(httpClientDetails.AccessToken, tokenType)
+	if err != nil {
+		return err
+	}
 	timeLeft, err := auth.GetTokenMinutesLeft(httpClientDetails.AccessToken)
-	if err != nil || timeLeft > refreshBeforeExpiryMinutes {
+	if err != nil || time.Duration(timeLeft)*time.Minute > leadTime {
 		return err
 	}
 	// Lock to make sure only one thread is trying to refresh
@@ -53,7 +97,7 @@ func tokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClie
 	defer mutex.Unlock()
 	// Refresh only if a new token wasn't acquired (by another thread) while waiting at mutex.
 	if fields.AccessToken == httpClientDetails.AccessToken {
-		newAccessToken, err := tokenRefreshHandler(httpClientDetails.AccessToken, tokenType)
+		newAccessToken, err := tokenRefreshHandler(serverId, httpClientDetails.AccessToken, tokenType)
 		if err != nil {
 			return err
 		}
@@ -66,7 +110,9 @@ func tokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClie
 	return nil
 }
 
-func tokenRefreshHandler(currentAccessToken string, tokenType TokenType) (newAccessToken string, err error) {
+// Refresh the access token of the server, and write the new tokens to the config.
+// The config is locked, so that only one process refreshes the token, and the other processes read the refreshed token from the config.
+func tokenRefreshHandler(serverId, currentAccessToken string, tokenType TokenType) (newAccessToken string, err error) {
 	log.Debug("Refreshing token...")
 	// Lock config to prevent access from different processes
 	lockDirPath, err := coreutils.GetJfrogConfigLockDir()
@@ -82,19 +128,21 @@ func tokenRefreshHandler(currentAccessToken string, tokenType TokenType) (newAcc
 		return
 	}
 
-	serverConfiguration, err := GetSpecificConfig(tokenRefreshServerId, true, false)
+	serverConfiguration, err := GetSpecificConfig(serverId, true, false)
 	if err != nil {
 		return
 	}
-	if tokenRefreshServerId == "" && serverConfiguration != nil {
-		tokenRefreshServerId = serverConfiguration.ServerId
-	}
 	// If token already refreshed, get new token from config
 	if serverConfiguration.AccessToken != "" && serverConfiguration.AccessToken != currentAccessToken {
 		log.Debug("Fetched new token from config.")
 		newAccessToken = serverConfiguration.AccessToken
 		return
 	}
+	defer func() {
+		if err == nil {
+			logTokenRefreshEvent(serverConfiguration.ServerId, tokenType, currentAccessToken, newAccessToken)
+		}
+	}()
 
 	// If token isn't already expired, Wait to make sure requests using the current token are sent before it is refreshed and becomes invalid
 	timeLeft, err := auth.GetTokenMinutesLeft(currentAccessToken)
@@ -113,10 +161,26 @@ func tokenRefreshHandler(currentAccessToken string, tokenType TokenType) (newAcc
 		newAccessToken, err = refreshAccessTokenAndWriteToConfig(serverConfiguration, currentAccessToken)
 		return
 	}
+	if tokenType == OidcToken {
+		newAccessToken, err = exchangeOidcTokenAndWriteToConfig(serverConfiguration)
+		return
+	}
 	err = errorutils.CheckErrorf("unsupported refreshable token type: " + string(tokenType))
 	return
 }
 
+// Log the refresh of a token as a structured event of key=value pairs, which can be collected from the logs of long operations.
+func logTokenRefreshEvent(serverId string, tokenType TokenType, previousAccessToken, newAccessToken string) {
+	event := fmt.Sprintf("event=token_refresh server_id=%q token_type=%s", serverId, tokenType)
+	if minutesLeft, err := auth.GetTokenMinutesLeft(previousAccessToken); err == nil {
+		event += fmt.Sprintf(" previous_token_minutes_left=%d", minutesLeft)
+	}
+	if minutesLeft, err := auth.GetTokenMinutesLeft(newAccessToken); err == nil {
+		event += fmt.Sprintf(" new_token_minutes_left=%d", minutesLeft)
+	}
+	log.Info(event)
+}
+
 func refreshArtifactoryTokenAndWriteToConfig(serverConfiguration *ServerDetails, currentAccessToken string) (string, error) {
 	refreshToken := serverConfiguration.ArtifactoryRefreshToken
 	// Remove previous tokens
@@ -143,7 +207,7 @@ func refreshArtifactoryTokenAndWriteToConfig(serverConfiguration *ServerDetails,
 		log.Debug("Token refreshed successfully.")
 	}
 
-	err = writeNewTokens(serverConfiguration, tokenRefreshServerId, newToken.AccessToken, newToken.RefreshToken, ArtifactoryToken)
+	err = writeNewTokens(serverConfiguration, serverConfiguration.ServerId, newToken.AccessToken, newToken.RefreshToken, ArtifactoryToken)
 	return newToken.AccessToken, err
 }
 
@@ -153,7 +217,7 @@ func refreshAccessTokenAndWriteToConfig(serverConfiguration *ServerDetails, curr
 	if err != nil {
 		return "", errorutils.CheckErrorf("Refresh access token failed: " + err.Error())
 	}
-	err = writeNewTokens(serverConfiguration, tokenRefreshServerId, newToken.AccessToken, newToken.RefreshToken, AccessToken)
+	err = writeNewTokens(serverConfiguration, serverConfiguration.ServerId, newToken.AccessToken, newToken.RefreshToken, AccessToken)
 	return newToken.AccessToken, err
 }
 
@@ -237,6 +301,7 @@ func refreshArtifactoryExpiredToken(serverDetails *ServerDetails, currentAccessT
 	noCredsDetails.ArtifactoryUrl = serverDetails.ArtifactoryUrl
 	noCredsDetails.ClientCertPath = serverDetails.ClientCertPath
 	noCredsDetails.ClientCertKeyPath = serverDetails.ClientCertKeyPath
+	noCredsDetails.ClientCertPassword = serverDetails.ClientCertPassword
 	noCredsDetails.ServerId = serverDetails.ServerId
 	noCredsDetails.IsDefault = serverDetails.IsDefault
 
@@ -258,6 +323,7 @@ func refreshExpiredAccessToken(serverDetails *ServerDetails, currentAccessToken
 	noCredServerDetails.Url = serverDetails.Url
 	noCredServerDetails.ClientCertPath = serverDetails.ClientCertPath
 	noCredServerDetails.ClientCertKeyPath = serverDetails.ClientCertKeyPath
+	noCredServerDetails.ClientCertPassword = serverDetails.ClientCertPassword
 	noCredServerDetails.ServerId = serverDetails.ServerId
 	noCredServerDetails.IsDefault = serverDetails.IsDefault
 
@@ -281,10 +347,15 @@ func createArtifactoryTokensServiceManager(artDetails *ServerDetails) (artifacto
 	if err != nil {
 		return nil, err
 	}
+	httpClient, err := artDetails.GetCustomHttpClient(certsPath, 0)
+	if err != nil {
+		return nil, err
+	}
 	serviceConfig, err := config.NewConfigBuilder().
 		SetServiceDetails(artAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(artDetails.InsecureTls).
+		SetHttpClient(httpClient).
 		SetDryRun(false).
 		Build()
 	if err != nil {
@@ -311,5 +382,12 @@ func createAccessTokensServiceManager(serviceDetails *ServerDetails) (*access.Ac
 	if err != nil {
 		return nil, err
 	}
-	return access.New(serviceConfig)
+	serviceManager, err := access.New(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = serviceDetails.SetCustomHttpTransport(serviceManager.Client(), certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
 }
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Create an unsigned access token with the given lifetime, which is enough for reading its expiry.
+func createTestAccessToken(lifetime time.Duration) string {
+	issuedAt := time.Now().Unix()
+	payload := fmt.Sprintf(`{"sub":"jfrt@test/users/user","iat":%d,"exp":%d}`, issuedAt, issuedAt+int64(lifetime.Seconds()))
+	return "header." + base64.RawStdEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestGetTokenRefreshLeadTime(t *testing.T) {
+	yearToken := createTestAccessToken(365 * 24 * time.Hour)
+	hourToken := createTestAccessToken(time.Hour)
+	testCases := []struct {
+		name             string
+		leadTimeEnv      string
+		accessToken      string
+		tokenType        TokenType
+		expectedLeadTime time.Duration
+		expectError      bool
+	}{
+		{name: "platform default", accessToken: yearToken, tokenType: AccessToken, expectedLeadTime: 7 * 24 * time.Hour},
+		{name: "artifactory default", accessToken: hourToken, tokenType: ArtifactoryToken, expectedLeadTime: 10 * time.Minute},
+		{name: "configured", leadTimeEnv: "30m", accessToken: hourToken, tokenType: ArtifactoryToken, expectedLeadTime: 30 * time.Minute},
+		{name: "limited to half of the lifetime", leadTimeEnv: "2h", accessToken: hourToken, tokenType: AccessToken, expectedLeadTime: 30 * time.Minute},
+		{name: "invalid", leadTimeEnv: "soon", accessToken: hourToken, tokenType: AccessToken, expectError: true},
+		{name: "negative", leadTimeEnv: "-5m", accessToken: hourToken, tokenType: AccessToken, expectError: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv(coreutils.TokenRefreshLeadTime, testCase.leadTimeEnv)
+			leadTime, err := getTokenRefreshLeadTime(testCase.accessToken, testCase.tokenType)
+			if testCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedLeadTime, leadTime)
+		})
+	}
+}
+
+func TestTokenRefreshNotNeeded(t *testing.T) {
+	t.Setenv(coreutils.TokenRefreshLeadTime, "10m")
+	accessToken := createTestAccessToken(time.Hour)
+	serverDetails := &ServerDetails{ServerId: "server", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: accessToken, RefreshToken: "refresh"}
+	artAuth, err := serverDetails.CreateArtAuthConfig()
+	require.NoError(t, err)
+	// The token doesn't expire within the lead time, so the interceptor neither refreshes it nor reads the config.
+	httpClientDetails := artAuth.CreateHttpClientDetails()
+	require.NoError(t, artAuth.RunPreRequestFunctions(&httpClientDetails))
+	assert.Equal(t, accessToken, httpClientDetails.AccessToken)
+}
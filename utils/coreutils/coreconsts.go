@@ -18,6 +18,7 @@ const (
 	JfrogLogsDirName                    = "logs"
 	JfrogPluginsDirName                 = "plugins"
 	JfrogPluginsFileName                = "plugins.yml"
+	JfrogScanCacheDirName               = "scan-cache"
 	JfrogSecurityConfFile               = "security.yaml"
 	JfrogSecurityDirName                = "security"
 	JfrogTransferDelaysDirName          = "delays"
@@ -25,6 +26,7 @@ const (
 	JfrogTransferErrorsDirName          = "errors"
 	JfrogTransferRepoSnapshotFileName   = "repo-snapshot.json"
 	JfrogTransferRepoStateFileName      = "repo-state.json"
+	JfrogTransferReposSelectionFileName = "repos-selection.json"
 	JfrogTransferRepositoriesDirName    = "repositories"
 	JfrogTransferTempDirName            = "tmp"
 	JfrogTransferRetryableErrorsDirName = "retryable"
@@ -49,6 +51,8 @@ const (
 	CI                      = "CI"
 	ServerID                = "JFROG_CLI_SERVER_ID"
 	TransitiveDownload      = "JFROG_CLI_TRANSITIVE_DOWNLOAD"
+	// The duration before the expiry of a refreshable access token, in which the token is refreshed, such as 30m or 24h.
+	TokenRefreshLeadTime = "JFROG_CLI_TOKEN_REFRESH_LEAD_TIME"
 
 	// These environment variables are used to adjust command names for more detailed tracking in the usage report.
 	// Set by the setup-jfrog-cli GitHub Action to identify specific command usage scenarios.
@@ -364,6 +364,14 @@ func GetJfrogBackupDir() (string, error) {
 	return filepath.Join(homeDir, JfrogBackupDirName), nil
 }
 
+func GetJfrogScanCacheDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogScanCacheDirName), nil
+}
+
 func GetJfrogPluginsDir() (string, error) {
 	homeDir, err := GetJfrogHomeDir()
 	if err != nil {
@@ -454,6 +462,14 @@ func GetJfrogTransferRunStatusFilePath() (string, error) {
 	return filepath.Join(transferDir, JfrogTransferRunStatusFileName), nil
 }
 
+func GetJfrogTransferReposSelectionFilePath() (string, error) {
+	transferDir, err := GetJfrogTransferDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(transferDir, JfrogTransferReposSelectionFileName), nil
+}
+
 func GetJfrogTransferRepositoriesDir() (string, error) {
 	transferDir, err := GetJfrogTransferDir()
 	if err != nil {
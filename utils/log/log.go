@@ -40,6 +40,14 @@ func SetDefaultLogger() {
 	log.SetLogger(log.NewLoggerWithFlags(GetCliLogLevel(), nil, getJfrogCliLogTimestamp()))
 }
 
+// Set the default logger, while writing the commands output to stderr instead of stdout.
+// Used by commands writing content to stdout, such as a file downloaded to stdout, so that the content isn't mixed with the output.
+func SetStderrOutputLogger() {
+	logger := log.NewLoggerWithFlags(GetCliLogLevel(), nil, getJfrogCliLogTimestamp())
+	logger.SetOutputWriter(os.Stderr)
+	log.SetLogger(logger)
+}
+
 const DefaultLogTimeLayout = "2006-01-02.15-04-05"
 
 func CreateLogFile() (*os.File, error) {
@@ -0,0 +1,229 @@
+package xray
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	PolicyMaxCvssRule        = "max-cvss"
+	PolicyMinSeverityRule    = "fail-on-severity"
+	PolicyBannedLicensesRule = "banned-licenses"
+	policyDateLayout         = "2006-01-02"
+)
+
+// The severities of the issues by their rank.
+var severityRanks = map[string]int{"unknown": 0, "low": 1, "medium": 2, "high": 3, "critical": 4}
+
+// A local policy, which the scan results are evaluated against without Xray watches. For example:
+//
+//	maxCvss: 7.0
+//	failOnSeverity: critical
+//	bannedLicenses: [GPL-3.0, AGPL-3.0]
+//	allowedCves:
+//	  - id: CVE-2020-8203
+//	    expires: 2025-06-30
+//	    reason: The vulnerable function isn't used.
+type Policy struct {
+	// Vulnerabilities with a higher CVSS score violate the policy.
+	MaxCvss *float64 `yaml:"maxCvss,omitempty"`
+	// Vulnerabilities with this severity or a higher one violate the policy.
+	FailOnSeverity string `yaml:"failOnSeverity,omitempty"`
+	// Components with these licenses violate the policy. The licenses are matched case-insensitively.
+	BannedLicenses []string `yaml:"bannedLicenses,omitempty"`
+	// CVEs which don't violate the policy until their expiration dates.
+	AllowedCves []AllowedCve `yaml:"allowedCves,omitempty"`
+}
+
+type AllowedCve struct {
+	Id string `yaml:"id"`
+	// The date the exception expires at, in the YYYY-MM-DD format. The exception never expires if empty.
+	Expires string `yaml:"expires,omitempty"`
+	Reason  string `yaml:"reason,omitempty"`
+}
+
+type PolicyViolation struct {
+	Rule      string
+	IssueId   string
+	Component string
+	Message   string
+}
+
+func (pv PolicyViolation) String() string {
+	return fmt.Sprintf("[%s] %s %s: %s", pv.Rule, pv.IssueId, pv.Component, pv.Message)
+}
+
+// Read a policy file. Policies are written in YAML, which JSON policies are also valid as.
+func ReadPolicyFile(policyPath string) (*Policy, error) {
+	if strings.EqualFold(filepath.Ext(policyPath), ".rego") {
+		return nil, errorutils.CheckErrorf("Rego policies aren't supported. Write the policy '%s' in YAML", policyPath)
+	}
+	content, err := os.ReadFile(policyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParsePolicy(content)
+}
+
+func ParsePolicy(content []byte) (*Policy, error) {
+	policy := new(Policy)
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	// Misspelled rules would silently allow everything, so unknown fields fail the parsing.
+	decoder.KnownFields(true)
+	// An empty policy allows everything.
+	if err := decoder.Decode(policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errorutils.CheckErrorf("failed to parse the policy: %s", err.Error())
+	}
+	return policy, policy.validate()
+}
+
+func (policy *Policy) validate() error {
+	if policy.FailOnSeverity != "" {
+		if _, exists := severityRanks[strings.ToLower(policy.FailOnSeverity)]; !exists {
+			return errorutils.CheckErrorf("invalid severity '%s' in the policy. The supported severities are low, medium, high and critical", policy.FailOnSeverity)
+		}
+	}
+	for _, allowedCve := range policy.AllowedCves {
+		if allowedCve.Id == "" {
+			return errorutils.CheckErrorf("the allowed CVEs of the policy must have IDs")
+		}
+		if allowedCve.Expires != "" {
+			if _, err := time.Parse(policyDateLayout, allowedCve.Expires); err != nil {
+				return errorutils.CheckErrorf("invalid expiration date '%s' of %s in the policy. Dates are written as YYYY-MM-DD", allowedCve.Expires, allowedCve.Id)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate the vulnerabilities, violations and licenses of the scan results against the policy.
+// Returns the violations of the policy, sorted by their rules, issues and components.
+func (policy *Policy) Evaluate(results []services.ScanResponse, now time.Time) []PolicyViolation {
+	var violations []PolicyViolation
+	for _, result := range results {
+		for _, vulnerability := range result.Vulnerabilities {
+			violations = append(violations, policy.evaluateVulnerability(vulnerability.IssueId, vulnerability.Severity, vulnerability.Cves, vulnerability.Components, now)...)
+		}
+		for _, violation := range result.Violations {
+			if violation.LicenseKey != "" {
+				violations = append(violations, policy.evaluateLicense(violation.LicenseKey, violation.Components)...)
+				continue
+			}
+			violations = append(violations, policy.evaluateVulnerability(violation.IssueId, violation.Severity, violation.Cves, violation.Components, now)...)
+		}
+		for _, license := range result.Licenses {
+			violations = append(violations, policy.evaluateLicense(license.Key, license.Components)...)
+		}
+	}
+	slices.SortFunc(violations, func(a, b PolicyViolation) int {
+		return strings.Compare(a.Rule+a.IssueId+a.Component, b.Rule+b.IssueId+b.Component)
+	})
+	// Vulnerabilities may be reported both as vulnerabilities and as violations of Xray policies.
+	return slices.Compact(violations)
+}
+
+func (policy *Policy) evaluateVulnerability(issueId, severity string, cves []services.Cve, components map[string]services.Component, now time.Time) []PolicyViolation {
+	issueId = getIssueId(issueId, cves)
+	if policy.isAllowed(cves, now) {
+		log.Debug("Skipping", issueId, "which is allowed by the policy")
+		return nil
+	}
+	var violations []PolicyViolation
+	if policy.MaxCvss != nil {
+		if score := getMaxCvss(cves); score > *policy.MaxCvss {
+			violations = append(violations, PolicyViolation{Rule: PolicyMaxCvssRule, IssueId: issueId, Message: fmt.Sprintf("the CVSS score %.1f is higher than %.1f", score, *policy.MaxCvss)})
+		}
+	}
+	if policy.FailOnSeverity != "" && severityRanks[strings.ToLower(severity)] >= severityRanks[strings.ToLower(policy.FailOnSeverity)] {
+		violations = append(violations, PolicyViolation{Rule: PolicyMinSeverityRule, IssueId: issueId, Message: fmt.Sprintf("the severity %s is %s or higher", severity, policy.FailOnSeverity)})
+	}
+	return forEachComponent(violations, components)
+}
+
+func (policy *Policy) evaluateLicense(licenseKey string, components map[string]services.Component) []PolicyViolation {
+	if !slices.ContainsFunc(policy.BannedLicenses, func(banned string) bool { return strings.EqualFold(banned, licenseKey) }) {
+		return nil
+	}
+	return forEachComponent([]PolicyViolation{{Rule: PolicyBannedLicensesRule, IssueId: licenseKey, Message: "the license is banned"}}, components)
+}
+
+// A vulnerability is allowed if all of its CVEs are allowed and their exceptions haven't expired.
+// Expired exceptions are reported, so they are either renewed or the vulnerabilities are fixed.
+func (policy *Policy) isAllowed(cves []services.Cve, now time.Time) bool {
+	if len(cves) == 0 {
+		return false
+	}
+	for _, cve := range cves {
+		index := slices.IndexFunc(policy.AllowedCves, func(allowedCve AllowedCve) bool { return strings.EqualFold(allowedCve.Id, cve.Id) })
+		if index < 0 {
+			return false
+		}
+		if expires := policy.AllowedCves[index].Expires; expires != "" {
+			expirationDate, err := time.Parse(policyDateLayout, expires)
+			// The exception is valid through the whole expiration day.
+			if err != nil || !now.Before(expirationDate.AddDate(0, 0, 1)) {
+				log.Warn("The exception of", cve.Id, "in the policy expired at", expires)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns the highest CVSS score of the CVEs, preferring the CVSS v3 scores.
+func getMaxCvss(cves []services.Cve) (maxScore float64) {
+	for _, cve := range cves {
+		score := cve.CvssV3Score
+		if score == "" {
+			score = cve.CvssV2Score
+		}
+		if parsedScore, err := strconv.ParseFloat(score, 64); err == nil && parsedScore > maxScore {
+			maxScore = parsedScore
+		}
+	}
+	return
+}
+
+func forEachComponent(violations []PolicyViolation, components map[string]services.Component) (result []PolicyViolation) {
+	for componentId := range components {
+		for _, violation := range violations {
+			violation.Component = componentId
+			result = append(result, violation)
+		}
+	}
+	return
+}
+
+// Evaluate the scan results against the policy file, and fail with the exit code of vulnerable builds if the policy is violated.
+func EvaluatePolicyFile(policyPath string, results []services.ScanResponse) error {
+	policy, err := ReadPolicyFile(policyPath)
+	if err != nil {
+		return err
+	}
+	violations := policy.Evaluate(results, time.Now())
+	if len(violations) == 0 {
+		log.Info("The scan results comply with the policy", policyPath)
+		return nil
+	}
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	return coreutils.CliError{
+		ExitCode: coreutils.ExitCodeVulnerableBuild,
+		ErrorMsg: fmt.Sprintf("the scan results violate the policy %s:\n%s", policyPath, strings.Join(messages, "\n")),
+	}
+}
@@ -0,0 +1,91 @@
+package xray
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `maxCvss: 7.0
+failOnSeverity: critical
+bannedLicenses: [GPL-3.0]
+allowedCves:
+  - id: CVE-2021-23337
+    expires: 2025-06-30
+    reason: The template function isn't used.
+  - id: CVE-2019-10744
+`
+
+var testPolicyResults = []services.ScanResponse{{
+	Vulnerabilities: []services.Vulnerability{
+		{IssueId: "XRAY-1", Severity: "High", Cves: []services.Cve{{Id: "CVE-2020-8203", CvssV3Score: "7.4"}}, Components: map[string]services.Component{"npm://lodash:4.17.15": {}}},
+		{IssueId: "XRAY-2", Severity: "High", Cves: []services.Cve{{Id: "CVE-2021-23337", CvssV3Score: "7.2"}}, Components: map[string]services.Component{"npm://lodash:4.17.15": {}}},
+		{IssueId: "XRAY-3", Severity: "Critical", Cves: []services.Cve{{Id: "CVE-2019-10744", CvssV3Score: "9.1"}}, Components: map[string]services.Component{"npm://lodash:4.17.15": {}}},
+		{IssueId: "XRAY-4", Severity: "Medium", Cves: []services.Cve{{Id: "CVE-2022-0001", CvssV2Score: "5.0"}}, Components: map[string]services.Component{"npm://qs:6.7.0": {}}},
+	},
+	Licenses: []services.License{
+		{Key: "gpl-3.0", Components: map[string]services.Component{"npm://left-pad:1.3.0": {}}},
+		{Key: "MIT", Components: map[string]services.Component{"npm://lodash:4.17.15": {}}},
+	},
+}}
+
+func TestPolicyEvaluate(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	// Before the exception of CVE-2021-23337 expires.
+	violations := policy.Evaluate(testPolicyResults, time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, []PolicyViolation{
+		{Rule: PolicyBannedLicensesRule, IssueId: "gpl-3.0", Component: "npm://left-pad:1.3.0", Message: "the license is banned"},
+		{Rule: PolicyMaxCvssRule, IssueId: "CVE-2020-8203", Component: "npm://lodash:4.17.15", Message: "the CVSS score 7.4 is higher than 7.0"},
+	}, violations)
+
+	// After the exception expires.
+	violations = policy.Evaluate(testPolicyResults, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, violations, 3)
+	assert.Equal(t, "CVE-2021-23337", violations[2].IssueId)
+}
+
+func TestParsePolicyInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{"unknown rule", "maxCVSS: 7.0"},
+		{"invalid severity", "failOnSeverity: severe"},
+		{"invalid expiration date", "allowedCves:\n  - id: CVE-2020-8203\n    expires: 30/06/2025"},
+		{"missing CVE ID", "allowedCves:\n  - reason: unused"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(test.policy))
+			assert.Error(t, err)
+		})
+	}
+	policy, err := ParsePolicy(nil)
+	require.NoError(t, err)
+	assert.Empty(t, policy.Evaluate(testPolicyResults, time.Now()))
+}
+
+func TestEvaluatePolicyFile(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyPath, []byte("failOnSeverity: critical"), 0644))
+	err := EvaluatePolicyFile(policyPath, testPolicyResults)
+	var cliError coreutils.CliError
+	require.True(t, errors.As(err, &cliError))
+	assert.Equal(t, coreutils.ExitCodeVulnerableBuild, cliError.ExitCode)
+	assert.Contains(t, cliError.ErrorMsg, "CVE-2019-10744")
+
+	require.NoError(t, os.WriteFile(policyPath, []byte("failOnSeverity: critical\nallowedCves:\n  - id: CVE-2019-10744"), 0644))
+	assert.NoError(t, EvaluatePolicyFile(policyPath, testPolicyResults))
+
+	_, err = ReadPolicyFile(filepath.Join(t.TempDir(), "policy.rego"))
+	assert.ErrorContains(t, err, "Rego")
+}
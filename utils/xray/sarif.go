@@ -0,0 +1,297 @@
+package xray
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifToolUri = "https://jfrog.com/xray/"
+)
+
+// The security-severity scores of the severities, for issues without CVSS scores. The scores match the ranges GitHub code scanning maps back to the severities.
+var severityScores = map[string]float64{"critical": 9.5, "high": 8.0, "medium": 5.5, "low": 2.0}
+
+// A SARIF 2.1.0 log. Only the properties the scan results are mapped to are defined.
+type SarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	Id               string          `json:"id"`
+	ShortDescription SarifMessage    `json:"shortDescription"`
+	HelpUri          string          `json:"helpUri,omitempty"`
+	Properties       *SarifRuleProps `json:"properties,omitempty"`
+}
+
+type SarifRuleProps struct {
+	// The score GitHub code scanning ranks the security results by.
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// Format the scan results in the output format. Only the formats which don't depend on the way the results are displayed are supported.
+// The results are located in the descriptor files, such as package.json or pom.xml, if provided.
+func FormatScanResults(results []services.ScanResponse, outputFormat format.OutputFormat, descriptors []string) (string, error) {
+	switch outputFormat {
+	case format.Json:
+		content, err := json.MarshalIndent(results, "", "  ")
+		return string(content), errorutils.CheckError(err)
+	case format.Sarif:
+		report, err := ConvertScanResultsToSarif(results, descriptors)
+		if err != nil {
+			return "", err
+		}
+		content, err := json.MarshalIndent(report, "", "  ")
+		return string(content), errorutils.CheckError(err)
+	}
+	return "", errorutils.CheckErrorf("the '%s' format isn't supported for the scan results. Supported formats: %s, %s", outputFormat, format.Json, format.Sarif)
+}
+
+// Convert the vulnerabilities and violations of the scan results to a SARIF report, with a rule for each issue and a result for each vulnerable component.
+// The results are located in the first descriptor file the direct dependency which brings the component is found in, and in its line when known.
+func ConvertScanResultsToSarif(results []services.ScanResponse, descriptors []string) (*SarifReport, error) {
+	locator, err := newDescriptorLocator(descriptors)
+	if err != nil {
+		return nil, err
+	}
+	converter := &sarifConverter{locator: locator, rules: []SarifRule{}, rulesIndexes: map[string]int{}, results: []SarifResult{}}
+	for _, result := range results {
+		for _, vulnerability := range result.Vulnerabilities {
+			converter.addIssue(getIssueId(vulnerability.IssueId, vulnerability.Cves), vulnerability.Summary, vulnerability.Severity, vulnerability.Cves, vulnerability.References, vulnerability.Components)
+		}
+		for _, violation := range result.Violations {
+			issueId := getIssueId(violation.IssueId, violation.Cves)
+			if violation.LicenseKey != "" {
+				issueId = violation.LicenseKey
+			}
+			converter.addIssue(issueId, violation.Summary, violation.Severity, violation.Cves, violation.References, violation.Components)
+		}
+	}
+	return &SarifReport{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SarifRun{{
+			Tool:    SarifTool{Driver: SarifDriver{Name: "JFrog Xray", InformationUri: sarifToolUri, Rules: converter.rules}},
+			Results: converter.results,
+		}},
+	}, nil
+}
+
+type sarifConverter struct {
+	locator      *descriptorLocator
+	rules        []SarifRule
+	rulesIndexes map[string]int
+	results      []SarifResult
+}
+
+func (sc *sarifConverter) addIssue(issueId, summary, severity string, cves []services.Cve, references []string, components map[string]services.Component) {
+	ruleIndex, exists := sc.rulesIndexes[issueId]
+	if !exists {
+		rule := SarifRule{
+			Id:               issueId,
+			ShortDescription: SarifMessage{Text: summary},
+			Properties:       &SarifRuleProps{SecuritySeverity: getSecuritySeverity(severity, cves), Tags: []string{"security"}},
+		}
+		if len(references) > 0 {
+			rule.HelpUri = references[0]
+		}
+		ruleIndex = len(sc.rules)
+		sc.rules = append(sc.rules, rule)
+		sc.rulesIndexes[issueId] = ruleIndex
+	}
+	componentIds := make([]string, 0, len(components))
+	for componentId := range components {
+		componentIds = append(componentIds, componentId)
+	}
+	slices.Sort(componentIds)
+	for _, componentId := range componentIds {
+		component := components[componentId]
+		message := fmt.Sprintf("[%s] %s %s: %s", issueId, severity, getComponentName(componentId), summary)
+		if len(component.FixedVersions) > 0 {
+			message += ". Fixed versions: " + strings.Join(component.FixedVersions, ", ")
+		}
+		sc.results = append(sc.results, SarifResult{
+			RuleId:    issueId,
+			RuleIndex: ruleIndex,
+			Level:     getSarifLevel(severity),
+			Message:   SarifMessage{Text: message},
+			Locations: sc.locator.locate(getDirectDependency(componentId, component)),
+		})
+	}
+}
+
+// Vulnerabilities are identified by their first CVE, or by their Xray issue ID if they have none.
+func getIssueId(issueId string, cves []services.Cve) string {
+	for _, cve := range cves {
+		if cve.Id != "" {
+			return cve.Id
+		}
+	}
+	return issueId
+}
+
+// Returns the highest CVSS v3 score of the CVEs, or the score of the severity if none of them is scored.
+func getSecuritySeverity(severity string, cves []services.Cve) string {
+	score := 0.0
+	for _, cve := range cves {
+		if cveScore, err := strconv.ParseFloat(cve.CvssV3Score, 64); err == nil && cveScore > score {
+			score = cveScore
+		}
+	}
+	if score == 0 {
+		score = severityScores[strings.ToLower(severity)]
+	}
+	if score == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(score, 'f', 1, 64)
+}
+
+func getSarifLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	}
+	return "note"
+}
+
+// The direct dependency of the component is the second node of its impact paths, after the scanned project.
+func getDirectDependency(componentId string, component services.Component) string {
+	for _, impactPath := range component.ImpactPaths {
+		if len(impactPath) > 1 {
+			return impactPath[1].ComponentId
+		}
+	}
+	return componentId
+}
+
+// Returns the name of the component from its ID, such as 'lodash' for 'npm://lodash:4.17.20' and 'commons-io' for 'gav://commons-io:commons-io:2.6'.
+func getComponentName(componentId string) string {
+	if _, id, found := strings.Cut(componentId, "://"); found {
+		componentId = id
+	}
+	if separator := strings.LastIndex(componentId, ":"); separator > 0 {
+		componentId = componentId[:separator]
+	}
+	if separator := strings.LastIndex(componentId, ":"); separator >= 0 {
+		componentId = componentId[separator+1:]
+	}
+	return componentId
+}
+
+type descriptorLocator struct {
+	descriptors []string
+	contents    [][]string
+}
+
+func newDescriptorLocator(descriptors []string) (*descriptorLocator, error) {
+	locator := &descriptorLocator{}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, descriptor := range descriptors {
+		content, err := os.ReadFile(descriptor)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		// SARIF consumers resolve the locations relative to the root of the repository.
+		if relativePath, err := filepath.Rel(workingDir, descriptor); err == nil && filepath.IsAbs(descriptor) && !strings.HasPrefix(relativePath, "..") {
+			descriptor = relativePath
+		}
+		locator.descriptors = append(locator.descriptors, filepath.ToSlash(descriptor))
+		locator.contents = append(locator.contents, strings.Split(string(content), "\n"))
+	}
+	return locator, nil
+}
+
+// Returns the location of the first line which references the dependency in the descriptors.
+// If none of the descriptors references it, it is located in the first descriptor, without a line.
+func (dl *descriptorLocator) locate(dependencyId string) []SarifLocation {
+	if len(dl.descriptors) == 0 {
+		return nil
+	}
+	name := getComponentName(dependencyId)
+	nameRegex, err := regexp.Compile(`(^|[^\w.-])` + regexp.QuoteMeta(name) + `($|[^\w.-])`)
+	if err == nil && name != "" {
+		for i, lines := range dl.contents {
+			for lineIndex, line := range lines {
+				if nameRegex.MatchString(line) {
+					return []SarifLocation{newSarifLocation(dl.descriptors[i], lineIndex+1)}
+				}
+			}
+		}
+	}
+	log.Debug("The dependency", dependencyId, "wasn't found in the descriptors")
+	return []SarifLocation{newSarifLocation(dl.descriptors[0], 0)}
+}
+
+func newSarifLocation(descriptor string, line int) SarifLocation {
+	location := SarifLocation{PhysicalLocation: SarifPhysicalLocation{ArtifactLocation: SarifArtifactLocation{Uri: descriptor}}}
+	if line > 0 {
+		location.PhysicalLocation.Region = &SarifRegion{StartLine: line}
+	}
+	return location
+}
@@ -0,0 +1,88 @@
+package xray
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertScanResultsToSarif(t *testing.T) {
+	descriptor := filepath.Join(t.TempDir(), "package.json")
+	require.NoError(t, os.WriteFile(descriptor, []byte("{\n  \"dependencies\": {\n    \"express\": \"^4.17.0\",\n    \"lodash\": \"4.17.20\"\n  }\n}\n"), 0644))
+	results := []services.ScanResponse{{
+		Vulnerabilities: []services.Vulnerability{{
+			IssueId:    "XRAY-140575",
+			Summary:    "Prototype pollution in lodash",
+			Severity:   "High",
+			Cves:       []services.Cve{{Id: "CVE-2020-8203", CvssV3Score: "7.4"}},
+			References: []string{"https://nvd.nist.gov/vuln/detail/CVE-2020-8203"},
+			Components: map[string]services.Component{
+				"npm://lodash:4.17.20": {FixedVersions: []string{"[4.17.21]"}, ImpactPaths: [][]services.ImpactPathNode{{{ComponentId: "npm://project:1.0.0"}, {ComponentId: "npm://lodash:4.17.20"}}}},
+				"npm://qs:6.7.0":       {ImpactPaths: [][]services.ImpactPathNode{{{ComponentId: "npm://project:1.0.0"}, {ComponentId: "npm://express:4.17.1"}, {ComponentId: "npm://qs:6.7.0"}}}},
+			},
+		}},
+		Violations: []services.Violation{{
+			LicenseKey: "GPL-3.0",
+			Summary:    "Banned license",
+			Severity:   "Medium",
+			Components: map[string]services.Component{"npm://left-pad:1.3.0": {}},
+		}},
+	}}
+	report, err := ConvertScanResultsToSarif(results, []string{descriptor})
+	require.NoError(t, err)
+	require.Len(t, report.Runs, 1)
+	run := report.Runs[0]
+
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "CVE-2020-8203", run.Tool.Driver.Rules[0].Id)
+	assert.Equal(t, "https://nvd.nist.gov/vuln/detail/CVE-2020-8203", run.Tool.Driver.Rules[0].HelpUri)
+	assert.Equal(t, "7.4", run.Tool.Driver.Rules[0].Properties.SecuritySeverity)
+	assert.Equal(t, "GPL-3.0", run.Tool.Driver.Rules[1].Id)
+	assert.Equal(t, "5.5", run.Tool.Driver.Rules[1].Properties.SecuritySeverity)
+
+	require.Len(t, run.Results, 3)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, "[CVE-2020-8203] High lodash: Prototype pollution in lodash. Fixed versions: [4.17.21]", run.Results[0].Message.Text)
+	assert.Equal(t, 4, run.Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	// The transitive dependency is located by the direct dependency which brings it.
+	assert.Equal(t, 3, run.Results[1].Locations[0].PhysicalLocation.Region.StartLine)
+	// Dependencies which aren't found are located in the descriptor without a line.
+	assert.Equal(t, "warning", run.Results[2].Level)
+	assert.Equal(t, 1, run.Results[2].RuleIndex)
+	assert.Equal(t, filepath.ToSlash(descriptor), run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.Uri)
+	assert.Nil(t, run.Results[2].Locations[0].PhysicalLocation.Region)
+}
+
+func TestFormatScanResults(t *testing.T) {
+	output, err := FormatScanResults(nil, format.Sarif, nil)
+	require.NoError(t, err)
+	report := new(SarifReport)
+	require.NoError(t, json.Unmarshal([]byte(output), report))
+	assert.Equal(t, sarifVersion, report.Version)
+	assert.Contains(t, output, `"results": []`)
+
+	_, err = FormatScanResults(nil, format.Table, nil)
+	assert.Error(t, err)
+}
+
+func TestGetComponentName(t *testing.T) {
+	tests := []struct {
+		componentId string
+		expected    string
+	}{
+		{"npm://lodash:4.17.20", "lodash"},
+		{"npm://@types/node:20.1.0", "@types/node"},
+		{"gav://commons-io:commons-io:2.6", "commons-io"},
+		{"go://github.com/gin-gonic/gin:v1.9.0", "github.com/gin-gonic/gin"},
+		{"pypi://requests", "requests"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getComponentName(test.componentId), test.componentId)
+	}
+}
@@ -0,0 +1,280 @@
+package xray
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const (
+	DefaultScanCacheTtl       = 24 * time.Hour
+	scanCacheSettingsFileName = "settings.json"
+	scanCacheEntryExtension   = ".json"
+)
+
+// The checksums are used as the file names of the entries, so only hexadecimal checksums are cached.
+var checksumRegex = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// A local cache of the scan results of the components, keyed by the Xray server and by the checksums of the components.
+// Components which haven't changed since they were last scanned are served from the cache, so only new components are sent to Xray.
+type ScanCache struct {
+	dir string
+	// The URL of the Xray server the results are cached for, since the results of one server don't apply to another.
+	serverUrl string
+	ttl       time.Duration
+	disabled  bool
+}
+
+type scanCacheSettings struct {
+	Ttl string `json:"ttl,omitempty"`
+}
+
+type scanCacheEntry struct {
+	CachedAt time.Time             `json:"cachedAt"`
+	Result   services.ScanResponse `json:"result"`
+}
+
+// Returns the scan cache of the Xray server under the JFrog home dir. If noCache is true, the returned cache never serves nor stores results.
+// The server URL may be empty if the cache is only managed, such as cleared, and its entries aren't read.
+func NewScanCache(serverUrl string, noCache bool) (*ScanCache, error) {
+	dir, err := coreutils.GetJfrogScanCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return newScanCache(dir, serverUrl, noCache)
+}
+
+func newScanCache(dir, serverUrl string, noCache bool) (*ScanCache, error) {
+	cache := &ScanCache{dir: dir, serverUrl: strings.TrimSuffix(strings.ToLower(serverUrl), "/"), disabled: noCache}
+	settings, err := readScanCacheSettings(dir)
+	if err != nil {
+		return nil, err
+	}
+	cache.ttl = DefaultScanCacheTtl
+	if settings.Ttl != "" {
+		if cache.ttl, err = time.ParseDuration(settings.Ttl); err != nil {
+			return nil, errorutils.CheckErrorf("invalid TTL '%s' in the scan cache settings: %s", settings.Ttl, err.Error())
+		}
+	}
+	return cache, nil
+}
+
+func (sc *ScanCache) Ttl() time.Duration {
+	return sc.ttl
+}
+
+// Returns the cached scan results of the component with the checksum, unless they expired.
+func (sc *ScanCache) Get(checksum string) (*services.ScanResponse, bool) {
+	if sc.disabled || !checksumRegex.MatchString(checksum) {
+		return nil, false
+	}
+	entryPath := sc.getEntryPath(checksum)
+	content, err := os.ReadFile(entryPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Debug("Failed to read the cached scan results of", checksum+":", err.Error())
+		}
+		return nil, false
+	}
+	entry := new(scanCacheEntry)
+	if err = json.Unmarshal(content, entry); err != nil || time.Since(entry.CachedAt) > sc.ttl {
+		// Expired and corrupted entries are removed, so the cache doesn't grow with stale results.
+		log.Debug("Removing the stale cached scan results of", checksum)
+		if err = os.Remove(entryPath); err != nil {
+			log.Debug("Failed to remove the cached scan results of", checksum+":", err.Error())
+		}
+		return nil, false
+	}
+	return &entry.Result, true
+}
+
+// Store the scan results of the component with the checksum.
+func (sc *ScanCache) Set(checksum string, result services.ScanResponse) error {
+	if sc.disabled || !checksumRegex.MatchString(checksum) {
+		return nil
+	}
+	content, err := json.Marshal(scanCacheEntry{CachedAt: time.Now(), Result: result})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	entryPath := sc.getEntryPath(checksum)
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(entryPath)); err != nil {
+		return err
+	}
+	// Concurrent scans may read the entry while it is written, so it is written to a temporary file and renamed.
+	tempFile, err := os.CreateTemp(filepath.Dir(entryPath), filepath.Base(entryPath)+".*.tmp")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = tempFile.Write(content)
+	err = errors.Join(err, tempFile.Close())
+	if err == nil {
+		err = os.Rename(tempFile.Name(), entryPath)
+	}
+	if err != nil {
+		return errorutils.CheckError(errors.Join(err, os.Remove(tempFile.Name())))
+	}
+	return nil
+}
+
+// Split the components, mapped from their IDs to their checksums, to the cached scan results and to the IDs of the components which need to be scanned.
+func (sc *ScanCache) SplitCachedComponents(components map[string]string) (cached []services.ScanResponse, uncached []string) {
+	for componentId, checksum := range components {
+		if result, found := sc.Get(checksum); found {
+			cached = append(cached, *result)
+			continue
+		}
+		uncached = append(uncached, componentId)
+	}
+	if len(cached) > 0 {
+		log.Info("Found the scan results of", len(cached), "unchanged components in the scan cache")
+	}
+	return
+}
+
+// Store the scan results of each of the components, mapped from their IDs to their checksums, in the cache.
+func (sc *ScanCache) SetComponentsResults(components map[string]string, results []services.ScanResponse) error {
+	if sc.disabled {
+		return nil
+	}
+	for componentId, checksum := range components {
+		var componentResult services.ScanResponse
+		for _, result := range results {
+			componentResult = MergeScanResponses(componentResult, FilterScanResponse(result, componentId))
+		}
+		if err := sc.Set(checksum, componentResult); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the part of the scan results which concerns the component.
+func FilterScanResponse(result services.ScanResponse, componentId string) services.ScanResponse {
+	filtered := services.ScanResponse{ScanId: result.ScanId, XrayDataUrl: result.XrayDataUrl}
+	for _, vulnerability := range result.Vulnerabilities {
+		if component, exists := vulnerability.Components[componentId]; exists {
+			vulnerability.Components = map[string]services.Component{componentId: component}
+			filtered.Vulnerabilities = append(filtered.Vulnerabilities, vulnerability)
+		}
+	}
+	for _, violation := range result.Violations {
+		if component, exists := violation.Components[componentId]; exists {
+			violation.Components = map[string]services.Component{componentId: component}
+			filtered.Violations = append(filtered.Violations, violation)
+		}
+	}
+	for _, license := range result.Licenses {
+		if component, exists := license.Components[componentId]; exists {
+			license.Components = map[string]services.Component{componentId: component}
+			filtered.Licenses = append(filtered.Licenses, license)
+		}
+	}
+	return filtered
+}
+
+// Returns the scan results of both responses, with the scan ID of the first one which has one.
+func MergeScanResponses(first, second services.ScanResponse) services.ScanResponse {
+	if first.ScanId == "" {
+		first.ScanId, first.XrayDataUrl = second.ScanId, second.XrayDataUrl
+	}
+	first.Vulnerabilities = append(first.Vulnerabilities, second.Vulnerabilities...)
+	first.Violations = append(first.Violations, second.Violations...)
+	first.Licenses = append(first.Licenses, second.Licenses...)
+	return first
+}
+
+// Returns the number of the cached entries and their total size in bytes.
+func (sc *ScanCache) Size() (entries int, size int64, err error) {
+	err = filepath.WalkDir(sc.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), scanCacheEntryExtension) || entry.Name() == scanCacheSettingsFileName {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		entries++
+		size += info.Size()
+		return nil
+	})
+	return entries, size, errorutils.CheckError(err)
+}
+
+// Remove all the cached entries. The settings of the cache are kept.
+func (sc *ScanCache) Clear() error {
+	dirEntries, err := os.ReadDir(sc.dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return errorutils.CheckError(err)
+	}
+	for _, dirEntry := range dirEntries {
+		if dirEntry.Name() == scanCacheSettingsFileName {
+			continue
+		}
+		if err = os.RemoveAll(filepath.Join(sc.dir, dirEntry.Name())); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+// Set the time the cached entries are valid for.
+func (sc *ScanCache) SetTtl(ttl time.Duration) error {
+	if ttl <= 0 {
+		return errorutils.CheckErrorf("the scan cache TTL must be positive")
+	}
+	if err := fileutils.CreateDirIfNotExist(sc.dir); err != nil {
+		return err
+	}
+	content, err := json.Marshal(scanCacheSettings{Ttl: ttl.String()})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.WriteFile(filepath.Join(sc.dir, scanCacheSettingsFileName), content, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	sc.ttl = ttl
+	return nil
+}
+
+// The entries are named by the SHA-256 of the server URL and the checksum of the component,
+// and are spread in subdirectories by the first characters of their names, to avoid huge directories.
+func (sc *ScanCache) getEntryPath(checksum string) string {
+	hash := sha256.Sum256([]byte(sc.serverUrl + "\n" + strings.ToLower(checksum)))
+	entryName := hex.EncodeToString(hash[:])
+	return filepath.Join(sc.dir, entryName[:2], entryName+scanCacheEntryExtension)
+}
+
+func readScanCacheSettings(dir string) (*scanCacheSettings, error) {
+	settings := new(scanCacheSettings)
+	content, err := os.ReadFile(filepath.Join(dir, scanCacheSettingsFileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return settings, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return settings, errorutils.CheckError(json.Unmarshal(content, settings))
+}
@@ -0,0 +1,102 @@
+package xray
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testServerUrl  = "https://acme.jfrog.io/xray/"
+	lodashChecksum = "6f0cbf6d1da3b8b2cb3e5ee7e2b7bd54bff1b7ed6cd4e4ddbfe6c8c23a3a4a8f"
+	qsChecksum     = "0f3bd5c1c4a3e0b7e0a4c0cfc4c1d5f0e3c9b1a8f0e6d3c2b5a4f7e8d9c0b1a2"
+)
+
+func TestScanCache(t *testing.T) {
+	cache, err := newScanCache(t.TempDir(), testServerUrl, false)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultScanCacheTtl, cache.Ttl())
+
+	results := []services.ScanResponse{{
+		ScanId: "scan-1",
+		Vulnerabilities: []services.Vulnerability{{
+			IssueId:    "XRAY-1",
+			Components: map[string]services.Component{"npm://lodash:4.17.20": {}, "npm://qs:6.7.0": {}},
+		}},
+		Licenses: []services.License{{Key: "MIT", Components: map[string]services.Component{"npm://lodash:4.17.20": {}}}},
+	}}
+	components := map[string]string{"npm://lodash:4.17.20": lodashChecksum, "npm://qs:6.7.0": qsChecksum}
+	require.NoError(t, cache.SetComponentsResults(components, results))
+
+	result, found := cache.Get(lodashChecksum)
+	require.True(t, found)
+	assert.Equal(t, "scan-1", result.ScanId)
+	require.Len(t, result.Vulnerabilities, 1)
+	assert.Equal(t, map[string]services.Component{"npm://lodash:4.17.20": {}}, result.Vulnerabilities[0].Components)
+	require.Len(t, result.Licenses, 1)
+
+	cached, uncached := cache.SplitCachedComponents(map[string]string{"npm://qs:6.7.0": qsChecksum, "npm://express:4.17.1": "a1b2c3"})
+	assert.Len(t, cached, 1)
+	assert.Equal(t, []string{"npm://express:4.17.1"}, uncached)
+
+	entries, size, err := cache.Size()
+	require.NoError(t, err)
+	assert.Equal(t, 2, entries)
+	assert.Positive(t, size)
+
+	// The results are cached per server.
+	otherServerCache, err := newScanCache(cache.dir, "https://other.jfrog.io/xray/", false)
+	require.NoError(t, err)
+	_, found = otherServerCache.Get(lodashChecksum)
+	assert.False(t, found)
+	sameServerCache, err := newScanCache(cache.dir, "https://ACME.jfrog.io/xray", false)
+	require.NoError(t, err)
+	_, found = sameServerCache.Get(lodashChecksum)
+	assert.True(t, found)
+
+	// The settings are kept when the cache is cleared.
+	require.NoError(t, cache.SetTtl(time.Hour))
+	require.NoError(t, cache.Clear())
+	entries, _, err = cache.Size()
+	require.NoError(t, err)
+	assert.Zero(t, entries)
+	cache, err = newScanCache(cache.dir, testServerUrl, false)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, cache.Ttl())
+	assert.Error(t, cache.SetTtl(0))
+}
+
+func TestScanCacheExpiredEntry(t *testing.T) {
+	cache, err := newScanCache(t.TempDir(), testServerUrl, false)
+	require.NoError(t, err)
+	content, err := json.Marshal(scanCacheEntry{CachedAt: time.Now().Add(-DefaultScanCacheTtl - time.Minute), Result: services.ScanResponse{ScanId: "scan-1"}})
+	require.NoError(t, err)
+	entryPath := cache.getEntryPath(lodashChecksum)
+	require.NoError(t, os.MkdirAll(filepath.Dir(entryPath), 0755))
+	require.NoError(t, os.WriteFile(entryPath, content, 0644))
+
+	_, found := cache.Get(lodashChecksum)
+	assert.False(t, found)
+	assert.NoFileExists(t, entryPath)
+}
+
+func TestScanCacheDisabled(t *testing.T) {
+	cache, err := newScanCache(t.TempDir(), testServerUrl, true)
+	require.NoError(t, err)
+	require.NoError(t, cache.Set(lodashChecksum, services.ScanResponse{ScanId: "scan-1"}))
+	_, found := cache.Get(lodashChecksum)
+	assert.False(t, found)
+
+	// Checksums which aren't hexadecimal aren't used as file names.
+	cache.disabled = false
+	require.NoError(t, cache.Set("../"+lodashChecksum, services.ScanResponse{ScanId: "scan-1"}))
+	entries, _, err := cache.Size()
+	require.NoError(t, err)
+	assert.Zero(t, entries)
+}
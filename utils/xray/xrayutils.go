@@ -2,6 +2,7 @@ package xray
 
 import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	clientconfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/xray"
 )
@@ -17,5 +18,16 @@ func CreateXrayServiceManager(serviceDetails *config.ServerDetails) (*xray.XrayS
 	if err != nil {
 		return nil, err
 	}
-	return xray.New(serviceConfig)
+	serviceManager, err := xray.New(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err = serviceDetails.SetCustomHttpTransport(serviceManager.Client(), certsPath); err != nil {
+		return nil, err
+	}
+	return serviceManager, nil
 }
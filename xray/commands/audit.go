@@ -0,0 +1,327 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/xray"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayClient "github.com/jfrog/jfrog-client-go/xray"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+// Scans the dependencies of the project with Xray, by the dependency trees of the technologies detected in the project.
+type AuditCommand struct {
+	serverDetails *config.ServerDetails
+	workingDir    string
+	project       string
+	watches       []string
+	format        format.OutputFormat
+	// A local policy file the results are evaluated against. The command fails if the policy is violated.
+	policyPath string
+	// If true, the scan cache is neither read nor updated, and all the components are scanned.
+	noCache bool
+	results []services.ScanResponse
+	// The descriptor files of the scanned technologies, which the results are located in by the SARIF output.
+	descriptors []string
+}
+
+func NewAuditCommand() *AuditCommand {
+	return &AuditCommand{format: format.Table}
+}
+
+func (ac *AuditCommand) SetServerDetails(serverDetails *config.ServerDetails) *AuditCommand {
+	ac.serverDetails = serverDetails
+	return ac
+}
+
+// Set the directory of the audited project. The current directory is audited by default.
+func (ac *AuditCommand) SetWorkingDir(workingDir string) *AuditCommand {
+	ac.workingDir = workingDir
+	return ac
+}
+
+// Set the project whose watches the dependencies are checked against.
+func (ac *AuditCommand) SetProject(project string) *AuditCommand {
+	ac.project = project
+	return ac
+}
+
+func (ac *AuditCommand) SetWatches(watches []string) *AuditCommand {
+	ac.watches = watches
+	return ac
+}
+
+func (ac *AuditCommand) SetFormat(format format.OutputFormat) *AuditCommand {
+	ac.format = format
+	return ac
+}
+
+func (ac *AuditCommand) SetPolicyPath(policyPath string) *AuditCommand {
+	ac.policyPath = policyPath
+	return ac
+}
+
+func (ac *AuditCommand) SetNoCache(noCache bool) *AuditCommand {
+	ac.noCache = noCache
+	return ac
+}
+
+// Returns the scan results of the last run, one for each technology.
+func (ac *AuditCommand) Results() []services.ScanResponse {
+	return ac.results
+}
+
+func (ac *AuditCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ac.serverDetails, nil
+}
+
+func (ac *AuditCommand) CommandName() string {
+	return "xr_audit"
+}
+
+func (ac *AuditCommand) Run() (err error) {
+	if ac.format != format.Table && ac.format != format.Json && ac.format != format.Sarif {
+		return errorutils.CheckErrorf("unsupported audit format '%s'. Supported formats: %s, %s, %s", ac.format, format.Table, format.Json, format.Sarif)
+	}
+	if ac.workingDir == "" {
+		if ac.workingDir, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	detected, err := DetectTechnologies(ac.workingDir)
+	if err != nil {
+		return err
+	}
+	if len(detected) == 0 {
+		return errorutils.CheckErrorf("couldn't detect any of the supported technologies in '%s'. Supported technologies: %s", ac.workingDir, coreutils.ListToText(getTechnologiesNames(technologies)))
+	}
+	xrayManager, err := xray.CreateXrayServiceManager(ac.serverDetails)
+	if err != nil {
+		return err
+	}
+	xrayVersion, err := xrayManager.GetVersion()
+	if err != nil {
+		return err
+	}
+	// The results depend on the watches and on the project, so the cache is used only by scans without them.
+	cache, err := xray.NewScanCache(xrayManager.Config().GetServiceDetails().GetUrl(), ac.noCache || ac.project != "" || len(ac.watches) > 0)
+	if err != nil {
+		return err
+	}
+	ac.results, ac.descriptors = nil, nil
+	for _, technology := range detected {
+		auditor := technologiesAuditors[technology]
+		dependencyTree, err := auditor.buildDependencyTree(ac.workingDir)
+		if err != nil {
+			return err
+		}
+		if len(dependencyTree.Nodes) == 0 {
+			log.Info(fmt.Sprintf("The %s project has no dependencies to scan.", technology))
+			continue
+		}
+		checksums := map[string]string{}
+		if auditor.getChecksums != nil {
+			if checksums, err = auditor.getChecksums(ac.workingDir); err != nil {
+				return err
+			}
+		}
+		result, err := ac.scanDependencyTree(xrayManager, xrayVersion, cache, technology, dependencyTree, checksums)
+		if err != nil {
+			return err
+		}
+		ac.results = append(ac.results, *result)
+		ac.descriptors = append(ac.descriptors, filepath.Join(ac.workingDir, auditor.dependenciesDescriptor))
+	}
+	if err = ac.printResults(); err != nil {
+		return err
+	}
+	if ac.policyPath != "" {
+		return xray.EvaluatePolicyFile(ac.policyPath, ac.results)
+	}
+	return nil
+}
+
+// Scan the components of the dependency tree which have no cached scan results, and return their results together with the cached ones.
+// The whole tree is scanned if none of its components is cached. Otherwise, the uncached components are scanned as direct dependencies of the root.
+func (ac *AuditCommand) scanDependencyTree(xrayManager *xrayClient.XrayServicesManager, xrayVersion string, cache *xray.ScanCache, technology Technology, dependencyTree *xrayUtils.GraphNode, checksums map[string]string) (*services.ScanResponse, error) {
+	components := getComponentsChecksums(dependencyTree, checksums)
+	cached, uncached := cache.SplitCachedComponents(components)
+	result := new(services.ScanResponse)
+	if len(uncached) > 0 {
+		scannedTree := dependencyTree
+		if len(cached) > 0 {
+			slices.Sort(uncached)
+			scannedTree = &xrayUtils.GraphNode{Id: dependencyTree.Id, Nodes: []*xrayUtils.GraphNode{}}
+			for _, componentId := range uncached {
+				scannedTree.Nodes = append(scannedTree.Nodes, &xrayUtils.GraphNode{Id: componentId, Parent: scannedTree})
+			}
+		}
+		log.Info(fmt.Sprintf("Scanning the %d %s dependencies...", len(uncached), technology))
+		scanId, err := xrayManager.ScanGraph(services.XrayGraphScanParams{
+			DependenciesGraph:      scannedTree,
+			ScanType:               services.Dependency,
+			Technology:             string(technology),
+			ProjectKey:             ac.project,
+			Watches:                ac.watches,
+			IncludeVulnerabilities: true,
+			IncludeLicenses:        true,
+			XrayVersion:            xrayVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if result, err = xrayManager.GetScanGraphResults(scanId, xrayVersion, true, true, false); err != nil {
+			return nil, err
+		}
+		scannedComponents := map[string]string{}
+		for _, componentId := range uncached {
+			scannedComponents[componentId] = components[componentId]
+		}
+		if err = cache.SetComponentsResults(scannedComponents, []services.ScanResponse{*result}); err != nil {
+			return nil, err
+		}
+	}
+	for _, cachedResult := range cached {
+		*result = xray.MergeScanResponses(*result, cachedResult)
+	}
+	return result, nil
+}
+
+// Returns the checksums of the components of the dependency tree by their IDs, with empty checksums for the components which have none.
+func getComponentsChecksums(dependencyTree *xrayUtils.GraphNode, checksums map[string]string) map[string]string {
+	components := map[string]string{}
+	var addNodes func(nodes []*xrayUtils.GraphNode)
+	addNodes = func(nodes []*xrayUtils.GraphNode) {
+		for _, node := range nodes {
+			if _, exists := components[node.Id]; exists {
+				continue
+			}
+			components[node.Id] = checksums[node.Id]
+			addNodes(node.Nodes)
+		}
+	}
+	addNodes(dependencyTree.Nodes)
+	return components
+}
+
+func (ac *AuditCommand) printResults() error {
+	if ac.format != format.Table {
+		output, err := xray.FormatScanResults(ac.results, ac.format, ac.descriptors)
+		if err != nil {
+			return err
+		}
+		log.Output(output)
+		return nil
+	}
+	if err := coreutils.PrintTable(getVulnerabilitiesRows(ac.results), "Vulnerabilities", "No vulnerabilities were found", false); err != nil {
+		return err
+	}
+	return coreutils.PrintTable(getViolationsRows(ac.results), "Violations", "No violations were found", false)
+}
+
+type vulnerabilityRow struct {
+	Severity      string `col-name:"Severity"`
+	Issue         string `col-name:"Issue"`
+	Component     string `col-name:"Component"`
+	FixedVersions string `col-name:"Fixed Versions"`
+	Summary       string `col-name:"Summary"`
+}
+
+type violationRow struct {
+	Type      string `col-name:"Type"`
+	Severity  string `col-name:"Severity"`
+	Issue     string `col-name:"Issue"`
+	Component string `col-name:"Component"`
+	Policies  string `col-name:"Policies"`
+}
+
+// Returns a row for each vulnerable component, sorted by the severities of the vulnerabilities, from the most severe.
+func getVulnerabilitiesRows(results []services.ScanResponse) []vulnerabilityRow {
+	rows := []vulnerabilityRow{}
+	for _, result := range results {
+		for _, vulnerability := range result.Vulnerabilities {
+			for componentId, component := range vulnerability.Components {
+				rows = append(rows, vulnerabilityRow{
+					Severity:      vulnerability.Severity,
+					Issue:         getIssueName(vulnerability.IssueId, vulnerability.Cves),
+					Component:     componentId,
+					FixedVersions: strings.Join(component.FixedVersions, ", "),
+					Summary:       vulnerability.Summary,
+				})
+			}
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b vulnerabilityRow) int {
+		return compareIssues(a.Severity, a.Issue+a.Component, b.Severity, b.Issue+b.Component)
+	})
+	return rows
+}
+
+func getViolationsRows(results []services.ScanResponse) []violationRow {
+	rows := []violationRow{}
+	for _, result := range results {
+		for _, violation := range result.Violations {
+			issue := getIssueName(violation.IssueId, violation.Cves)
+			if violation.LicenseKey != "" {
+				issue = violation.LicenseKey
+			}
+			var policies []string
+			for _, policy := range violation.Policies {
+				policies = append(policies, policy.Policy)
+			}
+			for componentId := range violation.Components {
+				rows = append(rows, violationRow{
+					Type:      violation.ViolationType,
+					Severity:  violation.Severity,
+					Issue:     issue,
+					Component: componentId,
+					Policies:  strings.Join(policies, ", "),
+				})
+			}
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b violationRow) int {
+		return compareIssues(a.Severity, a.Issue+a.Component, b.Severity, b.Issue+b.Component)
+	})
+	return rows
+}
+
+// Returns the CVEs of the issue, or its Xray ID if it has no CVEs.
+func getIssueName(issueId string, cves []services.Cve) string {
+	var cveIds []string
+	for _, cve := range cves {
+		if cve.Id != "" {
+			cveIds = append(cveIds, cve.Id)
+		}
+	}
+	if len(cveIds) == 0 {
+		return issueId
+	}
+	return strings.Join(cveIds, ", ")
+}
+
+var severitiesOrder = map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3}
+
+func compareIssues(firstSeverity, firstKey, secondSeverity, secondKey string) int {
+	firstOrder, found := severitiesOrder[strings.ToLower(firstSeverity)]
+	if !found {
+		firstOrder = len(severitiesOrder)
+	}
+	secondOrder, found := severitiesOrder[strings.ToLower(secondSeverity)]
+	if !found {
+		secondOrder = len(severitiesOrder)
+	}
+	if firstOrder != secondOrder {
+		return firstOrder - secondOrder
+	}
+	return strings.Compare(firstKey, secondKey)
+}
@@ -0,0 +1,265 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const swiftResolvedFile = `{
+  "pins" : [
+    {
+      "identity" : "mona.linkedlist",
+      "kind" : "registry",
+      "location" : "",
+      "state" : { "version" : "1.2.0" }
+    }
+  ],
+  "version" : 2
+}`
+
+func TestDetectTechnologies(t *testing.T) {
+	projectDir := t.TempDir()
+	detected, err := DetectTechnologies(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, detected)
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "Package.swift"), []byte("// swift-tools-version:5.9"), 0644))
+	detected, err = DetectTechnologies(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []Technology{Swift}, detected)
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "composer.json"), []byte("{}"), 0644))
+	detected, err = DetectTechnologies(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []Technology{Swift, Composer}, detected)
+
+	// A pyproject.toml without a poetry.lock isn't a Poetry project.
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "pyproject.toml"), []byte("[project]"), 0644))
+	detected, err = DetectTechnologies(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []Technology{Swift, Composer}, detected)
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "poetry.lock"), []byte(""), 0644))
+	detected, err = DetectTechnologies(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []Technology{Swift, Composer, Poetry}, detected)
+}
+
+func TestBuildComposerDependencyTree(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "composer.json"), []byte(`{"name":"acme/app","require":{"php":">=8.1","monolog/monolog":"^3.0"}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "composer.lock"), []byte(`{"packages":[{"name":"monolog/monolog","version":"3.5.0","require":{"psr/log":"^3.0"}},{"name":"psr/log","version":"3.0.0"}]}`), 0644))
+	tree, err := buildComposerDependencyTree(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, "composer://acme/app", tree.Id)
+	require.Len(t, tree.Nodes, 1)
+	assert.Equal(t, "composer://monolog/monolog:3.5.0", tree.Nodes[0].Id)
+	require.Len(t, tree.Nodes[0].Nodes, 1)
+	assert.Equal(t, "composer://psr/log:3.0.0", tree.Nodes[0].Nodes[0].Id)
+}
+
+func TestAuditCommand(t *testing.T) {
+	projectDir := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "Package.swift"), []byte("// swift-tools-version:5.9"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "Package.resolved"), []byte(swiftResolvedFile), 0644))
+
+	var scannedGraph xrayUtils.GraphNode
+	testServer := commonTests.CreateRestsMockServer(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/system/version":
+			_, err := w.Write([]byte(`{"xray_version":"3.100.0"}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/scan/graph":
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(content, &scannedGraph))
+			assert.Equal(t, "dependency", r.URL.Query().Get("scan_type"))
+			_, err = w.Write([]byte(`{"scan_id":"scan-1"}`))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/v1/scan/graph/scan-1":
+			_, err := w.Write([]byte(`{"scan_id":"scan-1","vulnerabilities":[{"issue_id":"XRAY-1","severity":"High","cves":[{"cve":"CVE-2024-1"}],"components":{"swift://mona.linkedlist:1.2.0":{"fixed_versions":["[1.2.1]"]}}}]}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer testServer.Close()
+
+	command := NewAuditCommand().SetServerDetails(&config.ServerDetails{XrayUrl: testServer.URL + "/"}).SetWorkingDir(projectDir)
+	require.NoError(t, command.Run())
+	assert.Equal(t, "swift://app", scannedGraph.Id)
+	require.Len(t, scannedGraph.Nodes, 1)
+	assert.Equal(t, "swift://mona.linkedlist:1.2.0", scannedGraph.Nodes[0].Id)
+	require.Len(t, command.Results(), 1)
+	assert.Equal(t, []vulnerabilityRow{{Severity: "High", Issue: "CVE-2024-1", Component: "swift://mona.linkedlist:1.2.0", FixedVersions: "[1.2.1]"}}, getVulnerabilitiesRows(command.Results()))
+
+	// The SARIF results are located in the descriptors of the scanned technologies.
+	require.NoError(t, command.SetFormat(format.Sarif).Run())
+	assert.Equal(t, []string{filepath.Join(projectDir, "Package.swift")}, command.descriptors)
+	assert.ErrorContains(t, command.SetFormat(format.SimpleJson).Run(), "unsupported audit format 'simple-json'")
+
+	// The results are evaluated against the local policy.
+	policyPath := filepath.Join(t.TempDir(), "policy.yml")
+	require.NoError(t, os.WriteFile(policyPath, []byte("failOnSeverity: high"), 0644))
+	err := command.SetFormat(format.Json).SetPolicyPath(policyPath).Run()
+	var cliError coreutils.CliError
+	require.ErrorAs(t, err, &cliError)
+	assert.Equal(t, coreutils.ExitCodeVulnerableBuild, cliError.ExitCode)
+	assert.Contains(t, cliError.ErrorMsg, "CVE-2024-1")
+	require.NoError(t, os.WriteFile(policyPath, []byte("failOnSeverity: critical"), 0644))
+	assert.NoError(t, command.Run())
+}
+
+func TestBuildPoetryDependencyTree(t *testing.T) {
+	projectDir := t.TempDir()
+	pyProject := `[tool.poetry]
+name = "app"
+version = "1.0.0"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+requests = "^2.31"
+
+[tool.poetry.group.test.dependencies]
+pytest = "^8.0"
+`
+	lockfile := `[[package]]
+name = "requests"
+version = "2.31.0"
+
+[package.dependencies]
+urllib3 = ">=1.21.1,<3"
+
+[[package]]
+name = "urllib3"
+version = "2.2.1"
+
+[[package]]
+name = "pytest"
+version = "8.1.1"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "pyproject.toml"), []byte(pyProject), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "poetry.lock"), []byte(lockfile), 0644))
+	tree, err := buildPoetryDependencyTree(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, "pypi://app:1.0.0", tree.Id)
+	require.Len(t, tree.Nodes, 2)
+	assert.Equal(t, "pypi://pytest:8.1.1", tree.Nodes[0].Id)
+	assert.Equal(t, "pypi://requests:2.31.0", tree.Nodes[1].Id)
+	require.Len(t, tree.Nodes[1].Nodes, 1)
+	assert.Equal(t, "pypi://urllib3:2.2.1", tree.Nodes[1].Nodes[0].Id)
+}
+
+func TestAuditCommandNoTechnologies(t *testing.T) {
+	err := NewAuditCommand().SetWorkingDir(t.TempDir()).Run()
+	assert.ErrorContains(t, err, "couldn't detect any of the supported technologies")
+}
+
+func TestGetVulnerabilitiesRowsOrder(t *testing.T) {
+	results := []services.ScanResponse{{Vulnerabilities: []services.Vulnerability{
+		{IssueId: "XRAY-2", Severity: "Low", Components: map[string]services.Component{"swift://b:1.0.0": {}}},
+		{IssueId: "XRAY-1", Severity: "Critical", Components: map[string]services.Component{"swift://a:1.0.0": {}}},
+	}}}
+	rows := getVulnerabilitiesRows(results)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "XRAY-1", rows[0].Issue)
+	assert.Equal(t, "XRAY-2", rows[1].Issue)
+}
+
+func TestAuditCommandScanCache(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	projectDir := t.TempDir()
+	pyProject := `[tool.poetry]
+name = "app"
+version = "1.0.0"
+
+[tool.poetry.dependencies]
+requests = "^2.31"
+`
+	lockfile := `[[package]]
+name = "requests"
+version = "2.31.0"
+files = [{file = "requests-2.31.0-py3-none-any.whl", hash = "sha256:58cd2187c01e70e6e26505bca751777aa9f2ee0b7f4300988b709f44e013003f"}]
+
+[package.dependencies]
+urllib3 = ">=1.21.1,<3"
+
+[[package]]
+name = "urllib3"
+version = "2.2.1"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "pyproject.toml"), []byte(pyProject), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "poetry.lock"), []byte(lockfile), 0644))
+
+	var scannedGraphs []xrayUtils.GraphNode
+	testServer := commonTests.CreateRestsMockServer(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/system/version":
+			_, err := w.Write([]byte(`{"xray_version":"3.100.0"}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/scan/graph":
+			var scannedGraph xrayUtils.GraphNode
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(content, &scannedGraph))
+			scannedGraphs = append(scannedGraphs, scannedGraph)
+			_, err = w.Write([]byte(`{"scan_id":"scan-1"}`))
+			assert.NoError(t, err)
+		case r.URL.Path == "/api/v1/scan/graph/scan-1":
+			response := `{"scan_id":"scan-1"}`
+			if scannedGraphs[len(scannedGraphs)-1].Nodes[0].Id == "pypi://requests:2.31.0" {
+				response = `{"scan_id":"scan-1","vulnerabilities":[{"issue_id":"XRAY-1","severity":"High","components":{"pypi://requests:2.31.0":{}}}]}`
+			}
+			_, err := w.Write([]byte(response))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer testServer.Close()
+
+	command := NewAuditCommand().SetServerDetails(&config.ServerDetails{XrayUrl: testServer.URL + "/"}).SetWorkingDir(projectDir).SetFormat(format.Json)
+	require.NoError(t, command.Run())
+	require.Len(t, scannedGraphs, 1)
+	require.Len(t, scannedGraphs[0].Nodes, 1)
+	assert.Equal(t, "pypi://requests:2.31.0", scannedGraphs[0].Nodes[0].Id)
+
+	// The cached requests package isn't scanned again, while urllib3, which has no checksum, is.
+	require.NoError(t, command.Run())
+	require.Len(t, scannedGraphs, 2)
+	require.Len(t, scannedGraphs[1].Nodes, 1)
+	assert.Equal(t, "pypi://urllib3:2.2.1", scannedGraphs[1].Nodes[0].Id)
+	assert.Equal(t, []vulnerabilityRow{{Severity: "High", Issue: "XRAY-1", Component: "pypi://requests:2.31.0"}}, getVulnerabilitiesRows(command.Results()))
+
+	// The cached results were computed without watches, so an audit with watches doesn't read them.
+	require.NoError(t, command.SetWatches([]string{"watch-1"}).Run())
+	require.Len(t, scannedGraphs, 3)
+	assert.Equal(t, scannedGraphs[0], scannedGraphs[2])
+	command.SetWatches(nil)
+
+	// The results are cached per Xray server.
+	otherServer := commonTests.CreateRestsMockServer(testServer.Config.Handler.ServeHTTP)
+	defer otherServer.Close()
+	require.NoError(t, command.SetServerDetails(&config.ServerDetails{XrayUrl: otherServer.URL + "/"}).Run())
+	require.Len(t, scannedGraphs, 4)
+	assert.Equal(t, scannedGraphs[0], scannedGraphs[3])
+
+	// All the components are scanned when the cache is disabled.
+	require.NoError(t, command.SetNoCache(true).Run())
+	require.Len(t, scannedGraphs, 5)
+	assert.Equal(t, scannedGraphs[0], scannedGraphs[4])
+}
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/curation"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/nuget"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+const (
+	Go     Technology = "go"
+	Nuget  Technology = "nuget"
+	Gradle Technology = "gradle"
+
+	gradleLockfileName = "gradle.lockfile"
+)
+
+// The technologies whose packages are checked against the curation policies, in the order they are detected in.
+var curationTechnologies = []Technology{Go, Nuget, Gradle}
+
+// Checks the dependencies of the project, including the transitive ones, against the curation policies,
+// through the pass-through API of the Artifactory repositories the packages are resolved from.
+// The packages which are blocked by the policies are reported together, for all the technologies detected in the project.
+type CurationAuditCommand struct {
+	serverDetails *config.ServerDetails
+	workingDir    string
+	repositories  map[curation.PackageType]string
+	report        *curation.Report
+}
+
+func NewCurationAuditCommand() *CurationAuditCommand {
+	return &CurationAuditCommand{}
+}
+
+func (cac *CurationAuditCommand) SetServerDetails(serverDetails *config.ServerDetails) *CurationAuditCommand {
+	cac.serverDetails = serverDetails
+	return cac
+}
+
+// Set the directory of the audited project. The current directory is audited by default.
+func (cac *CurationAuditCommand) SetWorkingDir(workingDir string) *CurationAuditCommand {
+	cac.workingDir = workingDir
+	return cac
+}
+
+// Set the repositories the packages are resolved from, by their package types.
+func (cac *CurationAuditCommand) SetRepositories(repositories map[curation.PackageType]string) *CurationAuditCommand {
+	cac.repositories = repositories
+	return cac
+}
+
+// Returns the report of the last run.
+func (cac *CurationAuditCommand) Report() *curation.Report {
+	return cac.report
+}
+
+func (cac *CurationAuditCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cac.serverDetails, nil
+}
+
+func (cac *CurationAuditCommand) CommandName() string {
+	return "curation_audit"
+}
+
+func (cac *CurationAuditCommand) Run() (err error) {
+	if cac.workingDir == "" {
+		if cac.workingDir, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	detected, err := detectTechnologies(cac.workingDir, curationTechnologies)
+	if err != nil {
+		return err
+	}
+	if len(detected) == 0 {
+		return errorutils.CheckErrorf("couldn't detect any of the technologies the curation audit supports in '%s'. Supported technologies: %s", cac.workingDir, coreutils.ListToText(getTechnologiesNames(curationTechnologies)))
+	}
+	servicesManager, err := utils.CreateServiceManager(cac.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	cac.report = &curation.Report{}
+	for _, technology := range detected {
+		dependencyTree, err := technologiesAuditors[technology].buildDependencyTree(cac.workingDir)
+		if err != nil {
+			return err
+		}
+		packages, err := curation.GetPackagesFromTree(dependencyTree)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Checking the %d %s packages against the curation policies...", len(packages), technology))
+		if err = curation.CheckPackages(servicesManager, cac.repositories, packages, cac.report); err != nil {
+			return err
+		}
+	}
+	log.Output(cac.report.String())
+	return nil
+}
+
+// Returns the dependency tree of the Go module, with the versions of the modules which are selected for the build.
+// The dependencies of each module are expanded once under each direct dependency, so that the tree doesn't grow exponentially.
+func buildGoDependencyTree(projectDir string) (*xrayUtils.GraphNode, error) {
+	moduleName, err := biutils.GetModuleNameByDir(projectDir, log.Logger)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := biutils.GetDependenciesGraph(projectDir, log.Logger)
+	if err != nil {
+		return nil, err
+	}
+	// 'go mod graph' lists all the required versions of the modules, while only the selected ones are built.
+	selected, err := biutils.GetDependenciesList(projectDir, log.Logger, nil)
+	if err != nil {
+		return nil, err
+	}
+	root := &xrayUtils.GraphNode{Id: curation.GoPackageTypeIdentifier + moduleName, Nodes: []*xrayUtils.GraphNode{}}
+	for _, directDependency := range graph[moduleName] {
+		if selected[directDependency] {
+			addGoTreeNode(root, directDependency, graph, selected, map[string]bool{})
+		}
+	}
+	return root, nil
+}
+
+func addGoTreeNode(parent *xrayUtils.GraphNode, moduleId string, graph map[string][]string, selected, expanded map[string]bool) {
+	node := &xrayUtils.GraphNode{Id: curation.GoPackageTypeIdentifier + moduleId, Parent: parent, Nodes: []*xrayUtils.GraphNode{}}
+	parent.Nodes = append(parent.Nodes, node)
+	if expanded[moduleId] {
+		return
+	}
+	expanded[moduleId] = true
+	for _, dependency := range graph[moduleId] {
+		if selected[dependency] {
+			addGoTreeNode(node, dependency, graph, selected, expanded)
+		}
+	}
+}
+
+func hasNugetLockfiles(projectDir string) (bool, error) {
+	projectDirs, err := nuget.FindLockfileProjects(projectDir)
+	return len(projectDirs) > 0, err
+}
+
+// Returns the dependency tree of all the NuGet projects under the directory which have lock files, with the direct dependencies of all the projects under the root.
+func buildNugetDependencyTree(projectDir string) (*xrayUtils.GraphNode, error) {
+	projectDirs, err := nuget.FindLockfileProjects(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	root := &xrayUtils.GraphNode{Id: nuget.NugetPackageTypeIdentifier + filepath.Base(projectDir), Nodes: []*xrayUtils.GraphNode{}}
+	for _, lockfileProjectDir := range projectDirs {
+		lockfile, err := nuget.ReadLockfile(lockfileProjectDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range lockfile.GetDependencyTree(nuget.GetProjectName(lockfileProjectDir)).Nodes {
+			node.Parent = root
+			root.Nodes = append(root.Nodes, node)
+		}
+	}
+	return root, nil
+}
+
+// Returns the dependencies locked in the gradle.lockfile of the project as direct dependencies of the root,
+// since the lock file holds all the resolved dependencies without the relations between them.
+func buildGradleDependencyTree(projectDir string) (*xrayUtils.GraphNode, error) {
+	dependencies, err := readGradleLockfile(filepath.Join(projectDir, gradleLockfileName))
+	if err != nil {
+		return nil, err
+	}
+	root := &xrayUtils.GraphNode{Id: curation.GradlePackageTypeIdentifier + filepath.Base(projectDir), Nodes: []*xrayUtils.GraphNode{}}
+	for _, dependency := range dependencies {
+		root.Nodes = append(root.Nodes, &xrayUtils.GraphNode{Id: curation.GradlePackageTypeIdentifier + dependency, Parent: root})
+	}
+	return root, nil
+}
+
+// Returns the 'group:artifact:version' IDs of the dependencies locked in a gradle.lockfile, whose lines look like:
+// org.slf4j:slf4j-api:2.0.9=compileClasspath,runtimeClasspath
+func readGradleLockfile(lockfilePath string) (dependencies []string, err error) {
+	lockfile, err := os.Open(lockfilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(lockfile.Close()))
+	}()
+	scanner := bufio.NewScanner(lockfile)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id, _, _ := strings.Cut(line, "=")
+		// The 'empty' line lists the configurations without dependencies.
+		if strings.Count(id, ":") != 2 || slices.Contains(dependencies, id) {
+			continue
+		}
+		dependencies = append(dependencies, id)
+	}
+	return dependencies, errorutils.CheckError(scanner.Err())
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/curation"
+	commonTests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gradleLockfile = `# This is a Gradle generated file for dependency locking.
+# Manual edits can break the build and are not advised.
+# This file is expected to be part of source control.
+org.slf4j:slf4j-api:2.0.9=compileClasspath,runtimeClasspath
+com.google.guava:guava:32.1.3-jre=runtimeClasspath
+org.slf4j:slf4j-api:2.0.9=testCompileClasspath
+empty=annotationProcessor
+`
+
+func TestReadGradleLockfile(t *testing.T) {
+	lockfilePath := filepath.Join(t.TempDir(), gradleLockfileName)
+	require.NoError(t, os.WriteFile(lockfilePath, []byte(gradleLockfile), 0644))
+	dependencies, err := readGradleLockfile(lockfilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org.slf4j:slf4j-api:2.0.9", "com.google.guava:guava:32.1.3-jre"}, dependencies)
+}
+
+func TestDetectCurationTechnologies(t *testing.T) {
+	projectDir := t.TempDir()
+	detected, err := detectTechnologies(projectDir, curationTechnologies)
+	require.NoError(t, err)
+	assert.Empty(t, detected)
+
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, gradleLockfileName), []byte(gradleLockfile), 0644))
+	detected, err = detectTechnologies(projectDir, curationTechnologies)
+	require.NoError(t, err)
+	assert.Equal(t, []Technology{Gradle}, detected)
+
+	// The curation technologies aren't audited by the audit command.
+	detected, err = DetectTechnologies(projectDir)
+	require.NoError(t, err)
+	assert.Empty(t, detected)
+}
+
+func TestCurationAuditCommand(t *testing.T) {
+	projectDir := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, gradleLockfileName), []byte(gradleLockfile), 0644))
+
+	var checkedPaths []string
+	testServer, serverDetails, _ := commonTests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			checkedPaths = append(checkedPaths, r.URL.Path)
+		}
+		if r.URL.Path != "/api/curation/audit/gradle-remote/com/google/guava/guava/32.1.3-jre/guava-32.1.3-jre.pom" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		if r.Method == http.MethodGet {
+			_, err := w.Write([]byte(`{"errors":[{"status":403,"message":"Package guava:32.1.3-jre download was blocked by JFrog Packages Curation service due to the following policies violated {Aged, Package age, Package is too new}."}]}`))
+			assert.NoError(t, err)
+		}
+	})
+	defer testServer.Close()
+
+	command := NewCurationAuditCommand().SetServerDetails(serverDetails).SetWorkingDir(projectDir).SetRepositories(map[curation.PackageType]string{curation.Gradle: "gradle-remote"})
+	require.NoError(t, command.Run())
+	assert.Equal(t, []string{
+		"/api/curation/audit/gradle-remote/org/slf4j/slf4j-api/2.0.9/slf4j-api-2.0.9.pom",
+		"/api/curation/audit/gradle-remote/com/google/guava/guava/32.1.3-jre/guava-32.1.3-jre.pom",
+	}, checkedPaths)
+	report := command.Report()
+	assert.Equal(t, 2, report.Checked)
+	require.Len(t, report.Blocked, 1)
+	assert.Equal(t, "com.google.guava:guava", report.Blocked[0].Name)
+	assert.Equal(t, []curation.Policy{{Policy: "Aged", Condition: "Package age", Explanation: "Package is too new"}}, report.Blocked[0].Policies)
+
+	assert.ErrorContains(t, command.SetRepositories(nil).Run(), "no repository is configured for the gradle packages")
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/composer"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/poetry"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/swift"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+type Technology string
+
+const (
+	Swift    Technology = "swift"
+	Composer Technology = "composer"
+	Poetry   Technology = "poetry"
+)
+
+// The technologies which are audited, in the order they are detected in.
+var technologies = []Technology{Swift, Composer, Poetry}
+
+type technologyAuditor struct {
+	// The descriptor file which indicates that the project uses the technology.
+	descriptor string
+	// Returns true if the project in the directory uses the technology, for technologies which aren't detected by a descriptor in the project directory.
+	detect func(projectDir string) (bool, error)
+	// The descriptor file which declares the direct dependencies of the project, which the scan results are located in.
+	dependenciesDescriptor string
+	// Returns the dependency tree of the project in the directory.
+	buildDependencyTree func(projectDir string) (*xrayUtils.GraphNode, error)
+	// Returns the checksums of the components of the project, by their IDs in the dependency tree, which their scan results are cached by.
+	// Components without checksums are scanned on every audit.
+	getChecksums func(projectDir string) (map[string]string, error)
+}
+
+var technologiesAuditors = map[Technology]technologyAuditor{
+	Swift:    {descriptor: "Package.swift", dependenciesDescriptor: "Package.swift", buildDependencyTree: buildSwiftDependencyTree},
+	Composer: {descriptor: composer.ManifestFileName, dependenciesDescriptor: composer.ManifestFileName, buildDependencyTree: buildComposerDependencyTree, getChecksums: getComposerChecksums},
+	// pyproject.toml is also the descriptor of projects which aren't managed by Poetry, so the Poetry projects are detected by their lock files.
+	Poetry: {descriptor: poetry.LockfileName, dependenciesDescriptor: poetry.PyProjectName, buildDependencyTree: buildPoetryDependencyTree, getChecksums: getPoetryChecksums},
+	Go:     {descriptor: "go.mod", buildDependencyTree: buildGoDependencyTree},
+	Nuget:  {detect: hasNugetLockfiles, buildDependencyTree: buildNugetDependencyTree},
+	// Gradle projects are checked by the dependencies locked by the dependency locking of Gradle, which include the transitive dependencies.
+	Gradle: {descriptor: gradleLockfileName, buildDependencyTree: buildGradleDependencyTree},
+}
+
+// Returns the audited technologies of the project in the directory, by the descriptor files found in it.
+func DetectTechnologies(projectDir string) ([]Technology, error) {
+	return detectTechnologies(projectDir, technologies)
+}
+
+func detectTechnologies(projectDir string, candidates []Technology) ([]Technology, error) {
+	var detected []Technology
+	for _, technology := range candidates {
+		auditor := technologiesAuditors[technology]
+		if auditor.detect != nil {
+			found, err := auditor.detect(projectDir)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				detected = append(detected, technology)
+			}
+			continue
+		}
+		_, err := os.Stat(filepath.Join(projectDir, auditor.descriptor))
+		if err == nil {
+			detected = append(detected, technology)
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	return detected, nil
+}
+
+func getTechnologiesNames(technologies []Technology) []string {
+	names := make([]string, 0, len(technologies))
+	for _, technology := range technologies {
+		names = append(names, string(technology))
+	}
+	return names
+}
+
+func buildSwiftDependencyTree(projectDir string) (*xrayUtils.GraphNode, error) {
+	resolvedFile, err := swift.ReadResolvedFile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	return resolvedFile.GetDependencyTree(filepath.Base(projectDir)), nil
+}
+
+func buildComposerDependencyTree(projectDir string) (*xrayUtils.GraphNode, error) {
+	manifest, err := composer.ReadManifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	lockfile, err := composer.ReadLockfile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	return lockfile.GetDependencyTree(manifest, manifest.GetModuleId(projectDir)), nil
+}
+
+func getComposerChecksums(projectDir string) (map[string]string, error) {
+	lockfile, err := composer.ReadLockfile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	return lockfile.GetChecksums(), nil
+}
+
+// Returns the dependency tree of the Poetry project, including the dependencies of all of its groups.
+func buildPoetryDependencyTree(projectDir string) (*xrayUtils.GraphNode, error) {
+	pyProject, err := poetry.ReadPyProject(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	lockfile, err := poetry.ReadLockfile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	moduleId := pyProject.GetModuleId()
+	if moduleId == "" {
+		moduleId = filepath.Base(projectDir)
+	}
+	return lockfile.GetDependencyTree(pyProject, moduleId), nil
+}
+
+func getPoetryChecksums(projectDir string) (map[string]string, error) {
+	lockfile, err := poetry.ReadLockfile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	return lockfile.GetChecksums(), nil
+}